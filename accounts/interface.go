@@ -12,26 +12,30 @@ const (
 	Exec        Operation = "exec"
 	Admin       Operation = "admin"
 	QueryRepeat Operation = "query_repeat"
+	Run         Operation = "run"
 )
 
 var MethodMap = map[string]Operation{
-	"/gripql.Query/Traversal":    Query,
-	"/gripql.Query/GetVertex":    Read,
-	"/gripql.Query/GetEdge":      Read,
-	"/gripql.Query/GetTimestamp": Read,
-	"/gripql.Query/GetSchema":    Read,
-	"/gripql.Query/GetMapping":   Read,
-	"/gripql.Query/ListGraphs":   Read,
-	"/gripql.Query/ListIndices":  Read,
-	"/gripql.Query/ListLabels":   Read,
+	"/gripql.Query/Traversal":     Query,
+	"/gripql.Query/TraversalText": Query,
+	"/gripql.Query/GetVertex":     Read,
+	"/gripql.Query/GetEdge":       Read,
+	"/gripql.Query/GetTimestamp":  Read,
+	"/gripql.Query/GetSchema":     Read,
+	"/gripql.Query/GetMapping":    Read,
+	"/gripql.Query/ListGraphs":    Read,
+	"/gripql.Query/ListIndices":   Read,
+	"/gripql.Query/ListLabels":    Read,
+	"/gripql.Query/Watch":         Read,
 
-	"/gripql.Job/Submit":     Exec,
-	"/gripql.Job/ListJobs":   Read,
-	"/gripql.Job/SearchJobs": Read,
-	"/gripql.Job/DeleteJob":  Write,
-	"/gripql.Job/GetJob":     Read,
-	"/gripql.Job/ViewJob":    Read,
-	"/gripql.Job/ResumeJob":  Exec,
+	"/gripql.Job/Submit":      Exec,
+	"/gripql.Job/ListJobs":    Read,
+	"/gripql.Job/SearchJobs":  Read,
+	"/gripql.Job/DeleteJob":   Write,
+	"/gripql.Job/GetJob":      Read,
+	"/gripql.Job/ViewJob":     Read,
+	"/gripql.Job/ResumeJob":   Exec,
+	"/gripql.Job/CollectJobs": Write,
 
 	"/gripql.Edit/AddVertex":    Write,
 	"/gripql.Edit/AddEdge":      Write,
@@ -45,6 +49,12 @@ var MethodMap = map[string]Operation{
 	"/gripql.Edit/AddMapping":   Write,
 	"/gripql.Edit/SampleSchema": Write, //Maybe exec?
 
+	"/gripql.StoredQuery/AddStoredQuery":    Write,
+	"/gripql.StoredQuery/GetStoredQuery":    Read,
+	"/gripql.StoredQuery/ListStoredQueries": Read,
+	"/gripql.StoredQuery/DeleteStoredQuery": Write,
+	"/gripql.StoredQuery/RunStoredQuery":    Run,
+
 	"/gripql.Configure/StartPlugin": Admin,
 	"/gripql.Configure/ListPlugin":  Admin,
 	"/gripql.Configure/ListDrivers": Admin,
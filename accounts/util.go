@@ -3,6 +3,7 @@ package accounts
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/bmeg/grip/gripql"
 	"github.com/bmeg/grip/log"
@@ -14,6 +15,29 @@ import (
 	"google.golang.org/grpc/metadata"
 )
 
+// storedQuerySuffix names the graph holding the stored queries of a graph,
+// as named by the server
+const storedQuerySuffix = "__queries__"
+
+// editMethods change the elements of a graph directly. Stored queries can
+// only be changed through the StoredQuery service
+var editMethods = map[string]bool{
+	"/gripql.Edit/AddVertex":    true,
+	"/gripql.Edit/AddEdge":      true,
+	"/gripql.Edit/DeleteVertex": true,
+	"/gripql.Edit/DeleteEdge":   true,
+}
+
+func isStoredQueries(graph string) bool {
+	return strings.HasSuffix(graph, storedQuerySuffix)
+}
+
+// accessGraph returns the graph that access to a graph is checked against.
+// The stored queries of a graph are protected by the graph's permissions
+func accessGraph(graph string) string {
+	return strings.TrimSuffix(graph, storedQuerySuffix)
+}
+
 func (c *Config) init() {
 	if c.auth != nil && c.access != nil {
 		return
@@ -75,7 +99,10 @@ func unaryAuthInterceptor(auth Authenticate, access Access) grpc.UnaryServerInte
 			if err != nil {
 				return nil, status.Error(codes.Unknown, fmt.Sprintf("Unable to get graph: %s", err))
 			}
-			err = access.Enforce(user, graph, op)
+			if editMethods[info.FullMethod] && isStoredQueries(graph) {
+				return nil, status.Error(codes.PermissionDenied, "stored queries are edited with the StoredQuery service")
+			}
+			err = access.Enforce(user, accessGraph(graph), op)
 			if err != nil {
 				return nil, status.Error(codes.PermissionDenied, "PermissionDenied")
 			}
@@ -114,11 +141,46 @@ func streamAuthInterceptor(auth Authenticate, access Access) grpc.StreamServerIn
 				if err != nil {
 					return status.Error(codes.Unknown, "Request error")
 				}
-				err = access.Enforce(user, w.Request.Graph, Query)
+				err = access.Enforce(user, accessGraph(w.Request.Graph), Query)
+				if err != nil {
+					return status.Error(codes.PermissionDenied, "PermissionDenied")
+				}
+				return handler(srv, w)
+			case "/gripql.Query/TraversalText":
+				w, err := NewStreamOutWrapper[gripql.GraphQueryText](ss)
+				if err != nil {
+					return status.Error(codes.Unknown, "Request error")
+				}
+				err = access.Enforce(user, accessGraph(w.Request.Graph), Query)
+				if err != nil {
+					return status.Error(codes.PermissionDenied, "PermissionDenied")
+				}
+				return handler(srv, w)
+			case "/gripql.Query/Watch":
+				w, err := NewStreamOutWrapper[gripql.GraphID](ss)
+				if err != nil {
+					return status.Error(codes.Unknown, "Request error")
+				}
+				err = access.Enforce(user, accessGraph(w.Request.Graph), Read)
 				if err != nil {
 					return status.Error(codes.PermissionDenied, "PermissionDenied")
 				}
 				return handler(srv, w)
+			case "/gripql.StoredQuery/RunStoredQuery":
+				// users that can query the graph can run any of its stored
+				// queries, others need to be allowed to run the query,
+				// using the object <graph>/<query name>
+				w, err := NewStreamOutWrapper[gripql.StoredQueryCall](ss)
+				if err != nil {
+					return status.Error(codes.Unknown, "Request error")
+				}
+				if access.Enforce(user, w.Request.Graph, Query) != nil {
+					err = access.Enforce(user, w.Request.Graph+"/"+w.Request.Name, Run)
+					if err != nil {
+						return status.Error(codes.PermissionDenied, "PermissionDenied")
+					}
+				}
+				return handler(srv, w)
 			case "/gripql.Job/ListJobs":
 				//TODO: filter list of jobs
 				return handler(srv, ss)
@@ -131,6 +193,16 @@ func streamAuthInterceptor(auth Authenticate, access Access) grpc.StreamServerIn
 			case "/gripql.Job/SearchJobs":
 				//TODO: filter list of jobs
 				return handler(srv, ss)
+			case "/gripql.Job/CollectJobs":
+				w, err := NewStreamOutWrapper[gripql.GraphID](ss)
+				if err != nil {
+					return status.Error(codes.Unknown, "Request error")
+				}
+				err = access.Enforce(user, w.Request.Graph, Write)
+				if err != nil {
+					return status.Error(codes.PermissionDenied, "PermissionDenied")
+				}
+				return handler(srv, w)
 			}
 			log.Errorf("Unknown streaming output: %#v", info)
 			return handler(srv, ss)
@@ -190,6 +262,15 @@ func getUnaryRequestGraph(req interface{}, info *grpc.UnaryServerInfo) (string,
 	case "/gripql.Edit/SampleSchema":
 		o := req.(*gripql.GraphID)
 		return o.Graph, nil
+	case "/gripql.StoredQuery/AddStoredQuery":
+		o := req.(*gripql.QueryDefinition)
+		return o.Graph, nil
+	case "/gripql.StoredQuery/GetStoredQuery", "/gripql.StoredQuery/DeleteStoredQuery":
+		o := req.(*gripql.StoredQueryID)
+		return o.Graph, nil
+	case "/gripql.StoredQuery/ListStoredQueries":
+		o := req.(*gripql.GraphID)
+		return o.Graph, nil
 	case "/gripql.Configure/StartPlugin", "/gripql.Configure/ListPlugins", "/gripql.Configure/ListDrivers":
 		return "*", nil //these operations effect all graphs
 	}
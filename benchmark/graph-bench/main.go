@@ -116,7 +116,10 @@ func randomOneToManyInsert(kgraph gdbi.GraphInterface) {
 }
 
 func graphBenchRun(kv kvi.KVInterface, f func(kgraph gdbi.GraphInterface)) {
-	db := kvgraph.NewKVGraph(kv)
+	db, err := kvgraph.NewKVGraph(kv)
+	if err != nil {
+		return
+	}
 	graph := "bench-graph"
 	db.AddGraph(graph)
 	kgraph, err := db.Graph(graph)
@@ -129,7 +132,10 @@ func graphBenchRun(kv kvi.KVInterface, f func(kgraph gdbi.GraphInterface)) {
 }
 
 func graphBenchRunQuery(kv kvi.KVInterface, build, query func(kgraph gdbi.GraphInterface)) {
-	db := kvgraph.NewKVGraph(kv)
+	db, err := kvgraph.NewKVGraph(kv)
+	if err != nil {
+		return
+	}
 	graph := "bench-graph"
 	db.AddGraph(graph)
 	kgraph, err := db.Graph(graph)
@@ -23,7 +23,10 @@ func randID() string {
 
 func BenchmarkVertexInsert(b *testing.B) {
 	kv, _ := badgerdb.NewKVInterface("test_1.db", kvi.Options{})
-	graphDB := kvgraph.NewKVGraph(kv)
+	graphDB, err := kvgraph.NewKVGraph(kv)
+	if err != nil {
+		b.Fatal(err)
+	}
 	graphDB.AddGraph("test")
 	graph, err := graphDB.Graph("test")
 	if err != nil {
@@ -46,7 +49,10 @@ func BenchmarkVertexInsert(b *testing.B) {
 
 func BenchmarkEdgeInsert(b *testing.B) {
 	kv, _ := badgerdb.NewKVInterface("test_1.db", kvi.Options{})
-	graphDB := kvgraph.NewKVGraph(kv)
+	graphDB, err := kvgraph.NewKVGraph(kv)
+	if err != nil {
+		b.Fatal(err)
+	}
 	graphDB.AddGraph("test")
 	graph, err := graphDB.Graph("test")
 	if err != nil {
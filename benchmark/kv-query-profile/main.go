@@ -38,8 +38,11 @@ func main() {
 	if err != nil {
 		return
 	}
-	db := kvgraph.NewKVGraph(kv)
 	defer kv.Close()
+	db, err := kvgraph.NewKVGraph(kv)
+	if err != nil {
+		return
+	}
 
 	vm := goja.New()
 
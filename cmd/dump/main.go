@@ -2,8 +2,14 @@ package dump
 
 import (
 	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/bmeg/grip/gripql"
+	"github.com/bmeg/grip/log"
+	"github.com/bmeg/grip/util/export"
 	"github.com/bmeg/grip/util/rpc"
 	"github.com/spf13/cobra"
 	"google.golang.org/protobuf/encoding/protojson"
@@ -13,13 +19,30 @@ var host = "localhost:8202"
 var vertexDump = false
 var edgeDump = false
 var graph string
+var format = "json"
+var outPath string
+var queryString string
+var rdfConfig string
+
+// number of ids looked up per traversal when completing a subgraph
+const batchSize = 1000
 
 // Cmd command line declaration
 var Cmd = &cobra.Command{
 	Use:   "dump <graph>",
 	Short: "Dump vertices/edges from a graph",
-	Long:  ``,
-	Args:  cobra.ExactArgs(1),
+	Long: `Dump vertices/edges from a graph.
+
+With --format graphml, gexf, neo4j-csv, parquet, ntriples or turtle, the vertices and edges are
+written in a format that can be read by other graph tools, with nested data
+fields flattened according to the graph schema. neo4j-csv writes nodes.csv and
+relationships.csv into the --out directory. parquet writes a file per vertex
+and edge label into the --out directory. ntriples and turtle write RDF, with
+IRIs created from the --rdf-config mapping.
+
+With --query, only the subgraph selected by a traversal is dumped. For example:
+    grip dump example-graph --format graphml --query 'V().hasLabel("Human")'`,
+	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		graph = args[0]
 		conn, err := gripql.Connect(rpc.ConfigWithDefaults(host), true)
@@ -27,6 +50,23 @@ var Cmd = &cobra.Command{
 			return err
 		}
 
+		if !vertexDump && !edgeDump && (format != "json" || queryString != "") {
+			vertexDump, edgeDump = true, true
+		}
+
+		writer, closer, err := newWriter(conn)
+		if err != nil {
+			return err
+		}
+		defer closer()
+
+		if queryString != "" {
+			if err := dumpSubgraph(conn, writer); err != nil {
+				return err
+			}
+			return writer.Close()
+		}
+
 		if vertexDump {
 			q := gripql.V()
 			elems, err := conn.Traversal(&gripql.GraphQuery{Graph: graph, Query: q.Statements})
@@ -34,11 +74,9 @@ var Cmd = &cobra.Command{
 				return err
 			}
 			for v := range elems {
-				txt, err := protojson.Marshal(v.GetVertex())
-				if err != nil {
+				if err := writer.WriteVertex(v.GetVertex()); err != nil {
 					return err
 				}
-				fmt.Printf("%s\n", string(txt))
 			}
 		}
 
@@ -49,21 +87,238 @@ var Cmd = &cobra.Command{
 				return err
 			}
 			for v := range elems {
-				txt, err := protojson.Marshal(v.GetEdge())
-				if err != nil {
+				if err := writer.WriteEdge(v.GetEdge()); err != nil {
 					return err
 				}
-				fmt.Printf("%s\n", string(txt))
 			}
 		}
 
-		return nil
+		return writer.Close()
 	},
 }
 
+// jsonWriter prints a vertex or edge per line
+type jsonWriter struct {
+	w io.Writer
+}
+
+func (j jsonWriter) WriteVertex(v *gripql.Vertex) error {
+	if !vertexDump {
+		return nil
+	}
+	txt, err := protojson.Marshal(v)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(j.w, "%s\n", string(txt))
+	return err
+}
+
+func (j jsonWriter) WriteEdge(e *gripql.Edge) error {
+	if !edgeDump {
+		return nil
+	}
+	txt, err := protojson.Marshal(e)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(j.w, "%s\n", string(txt))
+	return err
+}
+
+func (j jsonWriter) Close() error {
+	return nil
+}
+
+func newWriter(conn gripql.Client) (export.Writer, func(), error) {
+	createFile := func(path string) (*os.File, error) {
+		if path == "" {
+			return os.Stdout, nil
+		}
+		return os.Create(path)
+	}
+	files := []*os.File{}
+	closer := func() {
+		for _, f := range files {
+			if f != os.Stdout {
+				f.Close()
+			}
+		}
+	}
+
+	if format == "json" {
+		out, err := createFile(outPath)
+		if err != nil {
+			return nil, nil, err
+		}
+		files = append(files, out)
+		return jsonWriter{w: out}, closer, nil
+	}
+
+	if format == "ntriples" || format == "turtle" {
+		conf, err := export.LoadRDFConfig(rdfConfig)
+		if err != nil {
+			return nil, nil, err
+		}
+		out, err := createFile(outPath)
+		if err != nil {
+			return nil, nil, err
+		}
+		files = append(files, out)
+		w, err := export.NewRDFWriter(out, format == "turtle", conf)
+		return w, closer, err
+	}
+
+	schema, err := conn.GetSchema(graph)
+	if err != nil {
+		log.WithFields(log.Fields{"graph": graph, "error": err}).Info("Schema not found; sampling graph")
+		schema, err = conn.SampleSchema(graph)
+		if err != nil {
+			return nil, nil, fmt.Errorf("getting graph schema: %v", err)
+		}
+	}
+	fields := export.SchemaFields(schema)
+
+	switch format {
+	case "graphml", "gexf":
+		out, err := createFile(outPath)
+		if err != nil {
+			return nil, nil, err
+		}
+		files = append(files, out)
+		if format == "graphml" {
+			w, err := export.NewGraphMLWriter(out, fields)
+			return w, closer, err
+		}
+		w, err := export.NewGEXFWriter(out, fields)
+		return w, closer, err
+	case "neo4j-csv":
+		if outPath == "" {
+			return nil, nil, fmt.Errorf("--out directory is required for neo4j-csv")
+		}
+		if err := os.MkdirAll(outPath, 0755); err != nil {
+			return nil, nil, err
+		}
+		nodes, err := os.Create(filepath.Join(outPath, "nodes.csv"))
+		if err != nil {
+			return nil, nil, err
+		}
+		files = append(files, nodes)
+		rels, err := os.Create(filepath.Join(outPath, "relationships.csv"))
+		if err != nil {
+			closer()
+			return nil, nil, err
+		}
+		files = append(files, rels)
+		w, err := export.NewNeo4jWriter(nodes, rels, fields)
+		return w, closer, err
+	case "parquet":
+		if outPath == "" {
+			return nil, nil, fmt.Errorf("--out directory is required for parquet")
+		}
+		w, err := export.NewParquetWriter(outPath, schema)
+		return w, closer, err
+	}
+	return nil, nil, fmt.Errorf("unknown format '%s'; use json or one of %s", format, strings.Join(export.Formats, ", "))
+}
+
+// dumpSubgraph writes the vertices and edges returned by the query. If the
+// query only returns vertices, the edges between them are included. If it
+// returns edges, the vertices they connect are included.
+func dumpSubgraph(conn gripql.Client, writer export.Writer) error {
+	query, err := parseQuery(queryString)
+	if err != nil {
+		return err
+	}
+	res, err := conn.Traversal(&gripql.GraphQuery{Graph: graph, Query: query})
+	if err != nil {
+		return err
+	}
+	vertices := map[string]bool{}
+	edges := []*gripql.Edge{}
+	edgeIDs := map[string]bool{}
+	for row := range res {
+		if v := row.GetVertex(); v != nil && !vertices[v.Gid] {
+			vertices[v.Gid] = true
+			if err := writer.WriteVertex(v); err != nil {
+				return err
+			}
+		} else if e := row.GetEdge(); e != nil && !edgeIDs[e.Gid] {
+			edgeIDs[e.Gid] = true
+			edges = append(edges, e)
+		}
+	}
+
+	if len(edges) > 0 {
+		missing := []string{}
+		for _, e := range edges {
+			for _, id := range []string{e.From, e.To} {
+				if !vertices[id] {
+					vertices[id] = true
+					missing = append(missing, id)
+				}
+			}
+		}
+		err := batchTraversal(conn, missing, func(ids []string) *gripql.Query { return gripql.V(ids...) }, func(r *gripql.QueryResult) error {
+			return writer.WriteVertex(r.GetVertex())
+		})
+		if err != nil {
+			return err
+		}
+		for _, e := range edges {
+			if err := writer.WriteEdge(e); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	ids := make([]string, 0, len(vertices))
+	for id := range vertices {
+		ids = append(ids, id)
+	}
+	return batchTraversal(conn, ids, func(ids []string) *gripql.Query { return gripql.V(ids...).OutE() }, func(r *gripql.QueryResult) error {
+		if e := r.GetEdge(); vertices[e.To] {
+			return writer.WriteEdge(e)
+		}
+		return nil
+	})
+}
+
+func batchTraversal(conn gripql.Client, ids []string, query func([]string) *gripql.Query, f func(*gripql.QueryResult) error) error {
+	for start := 0; start < len(ids); start += batchSize {
+		end := start + batchSize
+		if end > len(ids) {
+			end = len(ids)
+		}
+		res, err := conn.Traversal(&gripql.GraphQuery{Graph: graph, Query: query(ids[start:end]).Statements})
+		if err != nil {
+			return err
+		}
+		for r := range res {
+			if err := f(r); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func parseQuery(queryString string) ([]*gripql.GraphStatement, error) {
+	q, err := gripql.ParseQuery(queryString)
+	if err != nil {
+		return nil, fmt.Errorf("parsing query: %v", err)
+	}
+	return q.Statements, nil
+}
+
 func init() {
 	flags := Cmd.Flags()
 	flags.StringVar(&host, "host", host, "grip server url")
 	flags.BoolVar(&vertexDump, "vertex", false, "dump all vertices")
 	flags.BoolVar(&edgeDump, "edge", false, "dump all edges")
+	flags.StringVar(&format, "format", format, "output format [json, graphml, gexf, neo4j-csv, parquet, ntriples, turtle]")
+	flags.StringVarP(&outPath, "out", "o", "", "output file, or directory for neo4j-csv and parquet")
+	flags.StringVar(&queryString, "query", "", "dump the subgraph selected by a traversal")
+	flags.StringVar(&rdfConfig, "rdf-config", "", "YAML mapping of vertices, labels and fields to IRIs, for ntriples and turtle")
 }
@@ -3,15 +3,10 @@ package job
 
 import (
 	"fmt"
-	"encoding/json"
 	"github.com/bmeg/grip/gripql"
 	"github.com/bmeg/grip/util/rpc"
 	"github.com/spf13/cobra"
-	_ "github.com/bmeg/grip/jsengine/goja" // import goja so it registers with the driver map
 	"google.golang.org/protobuf/encoding/protojson"
-	"github.com/dop251/goja"
-	gripqljs "github.com/bmeg/grip/gripql/javascript"
-	"github.com/bmeg/grip/jsengine/underscore"
 )
 
 var host = "localhost:8202"
@@ -70,6 +65,39 @@ var dropCmd = &cobra.Command{
 	},
 }
 
+var gcCmd = &cobra.Command{
+	Use:   "gc [graph]",
+	Short: "Remove jobs using the server retention policy",
+	Long:  ``,
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		conn, err := gripql.Connect(rpc.ConfigWithDefaults(host), true)
+		if err != nil {
+			return err
+		}
+
+		graphs := args
+		if len(graphs) == 0 {
+			resp, err := conn.ListGraphs()
+			if err != nil {
+				return err
+			}
+			graphs = resp.Graphs
+		}
+
+		for _, graph := range graphs {
+			resp, err := conn.CollectJobs(graph)
+			if err != nil {
+				return err
+			}
+			for _, j := range resp {
+				fmt.Printf("%s\t%s\n", j.Graph, j.Id)
+			}
+		}
+		return nil
+	},
+}
+
 var getCmd = &cobra.Command{
 	Use:   "get job",
 	Short: "Get job info",
@@ -113,38 +141,11 @@ var submitCmd = &cobra.Command{
     graph := args[0]
 		queryString := args[1]
 
-		vm := goja.New()
-		us, err := underscore.Asset("underscore.js")
-		if err != nil {
-			return fmt.Errorf("failed to load underscore.js")
-		}
-		if _, err := vm.RunString(string(us)); err != nil {
-			return err
-		}
-		gripqlString, err := gripqljs.Asset("gripql.js")
-		if err != nil {
-			return fmt.Errorf("failed to load gripql.js")
-		}
-		if _, err := vm.RunString(string(gripqlString)); err != nil {
-			return err
-		}
-
-		val, err := vm.RunString(queryString)
-		if err != nil {
-			return err
-		}
-
-		queryJSON, err := json.Marshal(val)
-		if err != nil {
-			return err
-		}
-
-		query := gripql.GraphQuery{}
-		err = protojson.Unmarshal(queryJSON, &query)
+		q, err := gripql.ParseQuery(queryString)
 		if err != nil {
-			return err
+			return fmt.Errorf("parsing query: %v", err)
 		}
-		query.Graph = graph
+		query := gripql.GraphQuery{Graph: graph, Query: q.Statements}
 
 		conn, err := gripql.Connect(rpc.ConfigWithDefaults(host), true)
 		if err != nil {
@@ -165,8 +166,10 @@ func init() {
 	listJobsCmd.Flags().StringVar(&host, "host", host, "grip server url")
 	getCmd.Flags().StringVar(&host, "host", host, "grip server url")
 	dropCmd.Flags().StringVar(&host, "host", host, "grip server url")
+	gcCmd.Flags().StringVar(&host, "host", host, "grip server url")
 
 	Cmd.AddCommand(listJobsCmd)
 	Cmd.AddCommand(getCmd)
 	Cmd.AddCommand(dropCmd)
+	Cmd.AddCommand(gcCmd)
 }
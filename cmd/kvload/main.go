@@ -2,15 +2,19 @@ package kvload
 
 import (
 	"fmt"
+	"os"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/bmeg/grip/gdbi"
+	"github.com/bmeg/grip/grids"
+	"github.com/bmeg/grip/gripql"
 	"github.com/bmeg/grip/kvgraph"
 	"github.com/bmeg/grip/kvi"
 	"github.com/bmeg/grip/log"
 	"github.com/bmeg/grip/util"
+	"github.com/bmeg/grip/util/checkpoint"
 	"github.com/paulbellamy/ratecounter"
 	"github.com/spf13/cobra"
 )
@@ -22,6 +26,9 @@ var vertexFile string
 var edgeFile string
 var vertexManifestFile string
 var edgeManifestFile string
+var checkpointPath string
+var resume bool
+var batchSize = checkpoint.DefaultBatchSize
 
 var workerCount = 1
 
@@ -40,27 +47,6 @@ var Cmd = &cobra.Command{
 
 		log.GetLogger().SetLevel(log.DebugLevel)
 
-		// Create the graph  if it doesn't already exist.
-		// Creating the graph also results in the creation of indices
-		// for the edge/vertex collections.
-		kv, err := kvi.NewKVInterface(kvDriver, dbPath, &kvi.Options{BulkLoad: true})
-		if err != nil {
-			return err
-		}
-		db := kvgraph.NewKVGraph(kv)
-		defer db.Close()
-
-		err = db.AddGraph(graph)
-		if err != nil {
-			if strings.Contains(err.Error(), "invalid graph name") {
-				return err
-			}
-		}
-		kgraph, err := db.Graph(graph)
-		if err != nil {
-			return err
-		}
-
 		vertexFileArray := []string{}
 		edgeFileArray := []string{}
 
@@ -95,6 +81,68 @@ var Cmd = &cobra.Command{
 			edgeFileArray = append(edgeFileArray, edgeFile)
 		}
 
+		if resume && checkpointPath == "" {
+			checkpointPath = graph + ".checkpoint.json"
+		}
+		var cp *checkpoint.Checkpoint
+		if checkpointPath != "" {
+			if _, err := os.Stat(checkpointPath); err == nil && !resume {
+				return fmt.Errorf("checkpoint %s exists, use --resume to continue the load or remove it", checkpointPath)
+			}
+			var err error
+			cp, err = checkpoint.Open(checkpointPath, graph)
+			if err != nil {
+				return err
+			}
+			cp.BatchSize = batchSize
+		}
+
+		if kvDriver == "grids" {
+			if cp != nil {
+				return gridsCheckpointLoad(cp, vertexFileArray, edgeFileArray)
+			}
+			return gridsLoad(vertexFileArray, edgeFileArray)
+		}
+
+		// Create the graph  if it doesn't already exist.
+		// Creating the graph also results in the creation of indices
+		// for the edge/vertex collections.
+		kv, err := kvi.NewKVInterface(kvDriver, dbPath, &kvi.Options{BulkLoad: true})
+		if err != nil {
+			return err
+		}
+		db, err := kvgraph.NewKVGraph(kv)
+		if err != nil {
+			kv.Close()
+			return err
+		}
+		defer db.Close()
+
+		err = db.AddGraph(graph)
+		if err != nil {
+			if strings.Contains(err.Error(), "invalid graph name") {
+				return err
+			}
+		}
+		kgraph, err := db.Graph(graph)
+		if err != nil {
+			return err
+		}
+
+		if cp != nil {
+			commit := func(batch []*gripql.GraphElement) error {
+				graphChan := make(chan *gdbi.GraphElement, 10)
+				go func() {
+					for _, e := range batch {
+						graphChan <- gdbi.NewGraphElement(e)
+					}
+					close(graphChan)
+				}()
+				return kgraph.BulkAdd(graphChan)
+			}
+			return checkpointLoad(cp, vertexFileArray, edgeFileArray, commit)
+		}
+
 		graphChan := make(chan *gdbi.GraphElement, 10)
 		wg := &sync.WaitGroup{}
 		go func() {
@@ -151,13 +199,163 @@ var Cmd = &cobra.Command{
 	},
 }
 
+// gridsCheckpointLoad writes the files into a grids graph, syncing the
+// loader before each checkpoint is recorded
+func gridsCheckpointLoad(cp *checkpoint.Checkpoint, vertexFiles []string, edgeFiles []string) error {
+	loader, err := grids.NewBulkLoader(dbPath, graph)
+	if err != nil {
+		return err
+	}
+	commit := func(batch []*gripql.GraphElement) error {
+		for _, e := range batch {
+			if e.Vertex != nil {
+				if err := loader.AddVertex(gdbi.NewElementFromVertex(e.Vertex)); err != nil {
+					log.WithFields(log.Fields{"error": err}).Error("Adding vertex")
+				}
+			} else if e.Edge != nil {
+				if err := loader.AddEdge(gdbi.NewElementFromEdge(e.Edge)); err != nil {
+					log.WithFields(log.Fields{"error": err}).Error("Adding edge")
+				}
+			}
+		}
+		return loader.Sync()
+	}
+	if err := checkpointLoad(cp, vertexFiles, edgeFiles, commit); err != nil {
+		loader.Close()
+		return err
+	}
+	if err := loader.Close(); err != nil {
+		return err
+	}
+	if n := loader.Skipped(); n > 0 {
+		log.Warningf("Skipped %d edges with missing vertices", n)
+	}
+	return nil
+}
+
+// checkpointLoad streams the files to commit in batches, starting each file
+// after the elements that were committed by an earlier run
+func checkpointLoad(cp *checkpoint.Checkpoint, vertexFiles []string, edgeFiles []string, commit checkpoint.CommitFunc) error {
+	log.WithFields(log.Fields{"graph": graph, "checkpoint": cp.Path()}).Info("Loading with checkpoints")
+	for _, vertexFile := range vertexFiles {
+		vertexFile := vertexFile
+		open := func(offset int64) (chan checkpoint.Element, error) {
+			vertChan, err := util.StreamVerticesAt(vertexFile, offset, workerCount)
+			if err != nil {
+				return nil, err
+			}
+			out := make(chan checkpoint.Element, workerCount)
+			go func() {
+				for v := range vertChan {
+					out <- checkpoint.Element{GraphElement: &gripql.GraphElement{Graph: graph, Vertex: v.Vertex}, Offset: v.Offset}
+				}
+				close(out)
+			}()
+			return out, nil
+		}
+		res, err := cp.Load(vertexFile, open, commit)
+		if err != nil {
+			return fmt.Errorf("loading %s: %v", vertexFile, err)
+		}
+		log.Infof("Loaded %d vertices from %s", res.Vertices, vertexFile)
+	}
+	for _, edgeFile := range edgeFiles {
+		edgeFile := edgeFile
+		open := func(offset int64) (chan checkpoint.Element, error) {
+			edgeChan, err := util.StreamEdgesAt(edgeFile, offset, workerCount)
+			if err != nil {
+				return nil, err
+			}
+			out := make(chan checkpoint.Element, workerCount)
+			go func() {
+				for e := range edgeChan {
+					out <- checkpoint.Element{GraphElement: &gripql.GraphElement{Graph: graph, Edge: e.Edge}, Offset: e.Offset}
+				}
+				close(out)
+			}()
+			return out, nil
+		}
+		res, err := cp.Load(edgeFile, open, commit)
+		if err != nil {
+			return fmt.Errorf("loading %s: %v", edgeFile, err)
+		}
+		log.Infof("Loaded %d edges from %s", res.Edges, edgeFile)
+	}
+	return nil
+}
+
+// gridsLoad writes the files directly into a grids graph directory
+func gridsLoad(vertexFiles []string, edgeFiles []string) error {
+	loader, err := grids.NewBulkLoader(dbPath, graph)
+	if err != nil {
+		return err
+	}
+
+	vertexCounter := ratecounter.NewRateCounter(10 * time.Second)
+	for _, vertexFile := range vertexFiles {
+		log.Infof("Loading %s", vertexFile)
+		count := 0
+		vertChan, err := util.StreamVerticesFromFile(vertexFile, workerCount)
+		if err != nil {
+			log.WithFields(log.Fields{"error": err}).Errorf("Error reading file: %s", vertexFile)
+			continue
+		}
+		for v := range vertChan {
+			if err := loader.AddVertex(gdbi.NewElementFromVertex(v)); err != nil {
+				log.WithFields(log.Fields{"error": err}).Error("Adding vertex")
+				continue
+			}
+			count++
+			vertexCounter.Incr(1)
+			if count%10000 == 0 {
+				log.Infof("Loaded %d vertices (%d/sec)", count, vertexCounter.Rate()/10)
+			}
+		}
+		log.Infof("Loaded %d vertices (%d/sec)", count, vertexCounter.Rate()/10)
+	}
+
+	edgeCounter := ratecounter.NewRateCounter(10 * time.Second)
+	for _, edgeFile := range edgeFiles {
+		log.Infof("Loading %s", edgeFile)
+		count := 0
+		edgeChan, err := util.StreamEdgesFromFile(edgeFile, workerCount)
+		if err != nil {
+			log.WithFields(log.Fields{"error": err}).Errorf("Error reading file: %s", edgeFile)
+			continue
+		}
+		for e := range edgeChan {
+			if err := loader.AddEdge(gdbi.NewElementFromEdge(e)); err != nil {
+				log.WithFields(log.Fields{"error": err}).Error("Adding edge")
+				continue
+			}
+			count++
+			edgeCounter.Incr(1)
+			if count%10000 == 0 {
+				log.Infof("Loaded %d edges (%d/sec)", count, edgeCounter.Rate()/10)
+			}
+		}
+		log.Infof("Loaded %d edges (%d/sec)", count, edgeCounter.Rate()/10)
+	}
+
+	if err := loader.Close(); err != nil {
+		return err
+	}
+	if n := loader.Skipped(); n > 0 {
+		log.Warningf("Skipped %d edges with missing vertices", n)
+	}
+	return nil
+}
+
 func init() {
 	flags := Cmd.Flags()
 	flags.StringVar(&dbPath, "db", dbPath, "DB Path")
-	flags.StringVar(&kvDriver, "driver", kvDriver, "KV Driver")
+	flags.StringVar(&kvDriver, "driver", kvDriver, "KV Driver, or grids to build a grids graph")
 	flags.StringVar(&vertexFile, "vertex", "", "vertex file")
 	flags.StringVar(&edgeFile, "edge", "", "edge file")
 	flags.StringVar(&vertexManifestFile, "vertex-manifest", "", "vertex manifest file")
 	flags.IntVarP(&workerCount, "workers", "n", workerCount, "number of processing threads")
 	flags.StringVar(&edgeManifestFile, "edge-manifest", "", "edge manifest file")
+	flags.StringVar(&checkpointPath, "checkpoint", "", "record the progress of the load in this file")
+	flags.BoolVar(&resume, "resume", resume, "skip input that was committed in the checkpoint file (default <graph>.checkpoint.json)")
+	flags.IntVar(&batchSize, "batch-size", batchSize, "number of elements committed between checkpoints")
 }
@@ -0,0 +1,169 @@
+package load
+
+import (
+	"os"
+
+	"github.com/bmeg/grip/gripql"
+	"github.com/bmeg/grip/util"
+	"github.com/bmeg/grip/util/checkpoint"
+	"github.com/bmeg/grip/util/export"
+	"github.com/bmeg/grip/util/table"
+)
+
+// input is a file, or other source of graph elements, that is loaded in turn
+type input struct {
+	name string
+	open checkpoint.OpenFunc
+}
+
+// listInputs returns the inputs selected by the command line flags, in the
+// order they are loaded
+func listInputs(mapping *table.Mapping) ([]input, error) {
+	inputs := []input{}
+	if vertexFile != "" {
+		inputs = append(inputs, vertexInput(vertexFile))
+	}
+	if edgeFile != "" {
+		inputs = append(inputs, edgeInput(edgeFile))
+	}
+	if dirPath != "" {
+		if glob, err := util.DirScan(dirPath, "*.vertex.json.gz"); err == nil {
+			for _, file := range glob {
+				inputs = append(inputs, vertexInput(file))
+			}
+		}
+		if glob, err := util.DirScan(dirPath, "*.edge.json.gz"); err == nil {
+			for _, file := range glob {
+				inputs = append(inputs, edgeInput(file))
+			}
+		}
+	}
+	if parquetPath != "" {
+		files := []string{parquetPath}
+		if info, err := os.Stat(parquetPath); err == nil && info.IsDir() {
+			if files, err = export.ParquetFiles(parquetPath); err != nil {
+				return nil, err
+			}
+		}
+		for _, file := range files {
+			file := file
+			inputs = append(inputs, input{file, func(int64) (chan checkpoint.Element, error) {
+				elems, err := export.StreamParquet(file)
+				if err != nil {
+					return nil, err
+				}
+				return checkpoint.Elements(elems), nil
+			}})
+		}
+	}
+	if jsonFile != "" {
+		inputs = append(inputs, input{jsonFile, func(int64) (chan checkpoint.Element, error) {
+			graphs, err := gripql.ParseJSONGraphsFile(jsonFile)
+			if err != nil {
+				return nil, err
+			}
+			return checkpoint.Elements(streamGraphs(graphs)), nil
+		}})
+	}
+	if yamlFile != "" {
+		inputs = append(inputs, input{yamlFile, func(int64) (chan checkpoint.Element, error) {
+			graphs, err := gripql.ParseYAMLGraphsFile(yamlFile)
+			if err != nil {
+				return nil, err
+			}
+			return checkpoint.Elements(streamGraphs(graphs)), nil
+		}})
+	}
+	if mapping != nil {
+		for _, t := range mapping.Tables {
+			t := t
+			inputs = append(inputs, input{t.Path, func(int64) (chan checkpoint.Element, error) {
+				elems, err := t.Stream()
+				if err != nil {
+					return nil, err
+				}
+				return checkpoint.Elements(elems), nil
+			}})
+		}
+	}
+	return inputs, nil
+}
+
+// vertexInput reads a file with a vertex per line. It can seek, so a resumed
+// load starts reading after the last committed line
+func vertexInput(file string) input {
+	return input{file, func(offset int64) (chan checkpoint.Element, error) {
+		vertChan, err := util.StreamVerticesAt(file, offset, workerCount)
+		if err != nil {
+			return nil, err
+		}
+		out := make(chan checkpoint.Element, workerCount)
+		go func() {
+			for v := range vertChan {
+				out <- checkpoint.Element{GraphElement: &gripql.GraphElement{Vertex: v.Vertex}, Offset: v.Offset}
+			}
+			close(out)
+		}()
+		return out, nil
+	}}
+}
+
+// edgeInput reads a file with an edge per line, and can seek like vertexInput
+func edgeInput(file string) input {
+	return input{file, func(offset int64) (chan checkpoint.Element, error) {
+		edgeChan, err := util.StreamEdgesAt(file, offset, workerCount)
+		if err != nil {
+			return nil, err
+		}
+		out := make(chan checkpoint.Element, workerCount)
+		go func() {
+			for e := range edgeChan {
+				out <- checkpoint.Element{GraphElement: &gripql.GraphElement{Edge: e.Edge}, Offset: e.Offset}
+			}
+			close(out)
+		}()
+		return out, nil
+	}}
+}
+
+func streamGraphs(graphs []*gripql.Graph) chan *gripql.GraphElement {
+	out := make(chan *gripql.GraphElement, 100)
+	go func() {
+		for _, g := range graphs {
+			for _, v := range g.Vertices {
+				out <- &gripql.GraphElement{Vertex: v}
+			}
+			for _, e := range g.Edges {
+				out <- &gripql.GraphElement{Edge: e}
+			}
+		}
+		close(out)
+	}()
+	return out
+}
+
+// stream opens the input, setting the graph of its elements and filling in
+// blank edge ids when --edge-uid is set. Checkpointed loads leave blank ids to
+// checkpoint.Load, which derives them from the position of the edge in the
+// input, so edges sent again after a resume replace the copies that were
+// already stored.
+func (in input) stream(checkpointed bool) checkpoint.OpenFunc {
+	return func(offset int64) (chan checkpoint.Element, error) {
+		elems, err := in.open(offset)
+		if err != nil {
+			return nil, err
+		}
+		out := make(chan checkpoint.Element, 100)
+		go func() {
+			for e := range elems {
+				e.Graph = graph
+				if !checkpointed && edgeUID && e.Edge != nil && e.Edge.Gid == "" {
+					e.Edge.Gid = util.UUID()
+				}
+				out <- e
+			}
+			close(out)
+		}()
+		return out, nil
+	}
+}
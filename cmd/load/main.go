@@ -2,12 +2,14 @@ package load
 
 import (
 	"fmt"
+	"os"
 
 	"github.com/bmeg/grip/cmd/load/example"
 	"github.com/bmeg/grip/gripql"
 	"github.com/bmeg/grip/log"
-	"github.com/bmeg/grip/util"
+	"github.com/bmeg/grip/util/checkpoint"
 	"github.com/bmeg/grip/util/rpc"
+	"github.com/bmeg/grip/util/table"
 	"github.com/spf13/cobra"
 )
 
@@ -18,7 +20,12 @@ var edgeFile string
 var jsonFile string
 var yamlFile string
 var dirPath string
+var tableMapping string
+var parquetPath string
 var edgeUID bool
+var checkpointPath string
+var resume bool
+var batchSize = checkpoint.DefaultBatchSize
 
 var workerCount = 1
 
@@ -31,12 +38,21 @@ var Cmd = &cobra.Command{
 	Long:  ``,
 	Args:  cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		if vertexFile == "" && edgeFile == "" && jsonFile == "" && yamlFile == "" && dirPath == "" {
+		if vertexFile == "" && edgeFile == "" && jsonFile == "" && yamlFile == "" && dirPath == "" && tableMapping == "" && parquetPath == "" {
 			return fmt.Errorf("no input files were provided")
 		}
 
 		graph = args[0]
 
+		var mapping *table.Mapping
+		if tableMapping != "" {
+			var err error
+			mapping, err = table.LoadMapping(tableMapping)
+			if err != nil {
+				return err
+			}
+		}
+
 		conn, err := gripql.Connect(rpc.ConfigWithDefaults(host), true)
 		if err != nil {
 			return err
@@ -61,130 +77,74 @@ var Cmd = &cobra.Command{
 			}
 		}
 
-		log.WithFields(log.Fields{"graph": graph}).Info("loading data")
-
-		elemChan := make(chan *gripql.GraphElement)
-		wait := make(chan bool)
-		go func() {
-			if err := conn.BulkAdd(elemChan); err != nil {
-				log.Errorf("bulk add error: %v", err)
-			}
-			wait <- false
-		}()
-
-		if vertexFile != "" {
-			log.Infof("Loading vertex file: %s", vertexFile)
-			count := 0
-			vertChan, err := util.StreamVerticesFromFile(vertexFile, workerCount)
-			if err != nil {
-				return err
-			}
-			for v := range vertChan {
-				count++
-				if count%logRate == 0 {
-					log.Infof("Loaded %d vertices", count)
-				}
-				elemChan <- &gripql.GraphElement{Graph: graph, Vertex: v}
-			}
-			log.Infof("Loaded total of %d vertices", count)
+		inputs, err := listInputs(mapping)
+		if err != nil {
+			return err
 		}
 
-		if edgeFile != "" {
-			log.Infof("Loading edge file: %s", edgeFile)
-			count := 0
-			edgeChan, err := util.StreamEdgesFromFile(edgeFile, workerCount)
+		if resume && checkpointPath == "" {
+			checkpointPath = graph + ".checkpoint.json"
+		}
+		if checkpointPath != "" {
+			if _, err := os.Stat(checkpointPath); err == nil && !resume {
+				return fmt.Errorf("checkpoint %s exists, use --resume to continue the load or remove it", checkpointPath)
+			}
+			cp, err := checkpoint.Open(checkpointPath, graph)
 			if err != nil {
 				return err
 			}
-			for e := range edgeChan {
-				count++
-				if count%logRate == 0 {
-					log.Infof("Loaded %d edges", count)
-				}
-				if edgeUID && e.Gid == "" {
-					e.Gid = util.UUID()
-				}
-				elemChan <- &gripql.GraphElement{Graph: graph, Edge: e}
-			}
-			log.Infof("Loaded total of %d edges", count)
-		}
-
-		if dirPath != "" {
-			vertexCount := 0
-			if glob, err := util.DirScan(dirPath, "*.vertex.json.gz"); err == nil {
-				for _, vertexFile := range glob {
-					log.Infof("Loading vertex file: %s", vertexFile)
-					vertChan, err := util.StreamVerticesFromFile(vertexFile, workerCount)
-					if err != nil {
-						return err
-					}
-					for v := range vertChan {
-						vertexCount++
-						if vertexCount%logRate == 0 {
-							log.Infof("Loaded %d vertices", vertexCount)
+			cp.BatchSize = batchSize
+			log.WithFields(log.Fields{"graph": graph, "checkpoint": checkpointPath}).Info("loading data")
+			for _, in := range inputs {
+				commit := func(batch []*gripql.GraphElement) error {
+					elemChan := make(chan *gripql.GraphElement, 100)
+					go func() {
+						for _, e := range batch {
+							elemChan <- e
 						}
-						elemChan <- &gripql.GraphElement{Graph: graph, Vertex: v}
-					}
+						close(elemChan)
+					}()
+					return conn.BulkAdd(elemChan)
 				}
-			}
-			edgeCount := 0
-			if glob, err := util.DirScan(dirPath, "*.edge.json.gz"); err == nil {
-				for _, edgeFile := range glob {
-					log.Infof("Loading edge file: %s", edgeFile)
-					edgeChan, err := util.StreamEdgesFromFile(edgeFile, workerCount)
-					if err != nil {
-						return err
-					}
-					for e := range edgeChan {
-						edgeCount++
-						if edgeCount%logRate == 0 {
-							log.Infof("Loaded %d edges", edgeCount)
-						}
-						if edgeUID && e.Gid == "" {
-							e.Gid = util.UUID()
-						}
-						elemChan <- &gripql.GraphElement{Graph: graph, Edge: e}
-					}
+				res, err := cp.Load(in.name, in.stream(true), commit)
+				if err != nil {
+					return fmt.Errorf("loading %s: %v", in.name, err)
 				}
+				log.Infof("Loaded total of %d vertices and %d edges from %s", res.Vertices, res.Edges, in.name)
 			}
-			log.Infof("Loaded total of %d vertices", vertexCount)
-			log.Infof("Loaded total of %d edges", edgeCount)
+			return nil
 		}
 
-		if jsonFile != "" {
-			log.Infof("Loading json file: %s", jsonFile)
-			graphs, err := gripql.ParseJSONGraphsFile(jsonFile)
-			if err != nil {
-				return err
-			}
-			for _, g := range graphs {
-				for _, v := range g.Vertices {
-					elemChan <- &gripql.GraphElement{Graph: graph, Vertex: v}
-				}
-				log.Infof("Loaded %d vertices", len(g.Vertices))
-				for _, e := range g.Edges {
-					elemChan <- &gripql.GraphElement{Graph: graph, Edge: e}
-				}
-				log.Infof("Loaded %d edges", len(g.Edges))
+		log.WithFields(log.Fields{"graph": graph}).Info("loading data")
+
+		elemChan := make(chan *gripql.GraphElement)
+		wait := make(chan bool)
+		go func() {
+			if err := conn.BulkAdd(elemChan); err != nil {
+				log.Errorf("bulk add error: %v", err)
 			}
-		}
+			wait <- false
+		}()
 
-		if yamlFile != "" {
-			log.Infof("Loading YAML file: %s", yamlFile)
-			graphs, err := gripql.ParseYAMLGraphsFile(yamlFile)
+		for _, in := range inputs {
+			log.Infof("Loading %s", in.name)
+			stream, err := in.stream(false)(0)
 			if err != nil {
 				return err
 			}
-			for _, g := range graphs {
-				for _, v := range g.Vertices {
-					elemChan <- &gripql.GraphElement{Graph: graph, Vertex: v}
+			vertexCount, edgeCount := 0, 0
+			for e := range stream {
+				if e.Vertex != nil {
+					vertexCount++
+				} else {
+					edgeCount++
 				}
-				log.Infof("Loaded %d vertices", len(g.Vertices))
-				for _, e := range g.Edges {
-					elemChan <- &gripql.GraphElement{Graph: graph, Edge: e}
+				if (vertexCount+edgeCount)%logRate == 0 {
+					log.Infof("Loaded %d vertices and %d edges", vertexCount, edgeCount)
 				}
-				log.Infof("Loaded %d edges", len(g.Edges))
+				elemChan <- e.GraphElement
 			}
+			log.Infof("Loaded total of %d vertices and %d edges from %s", vertexCount, edgeCount, in.name)
 		}
 
 		close(elemChan)
@@ -203,6 +163,11 @@ func init() {
 	flags.StringVar(&jsonFile, "json", "", "JSON graph file")
 	flags.StringVar(&yamlFile, "yaml", "", "YAML graph file")
 	flags.StringVar(&dirPath, "dir", "", "Load graph elements from directory")
+	flags.StringVar(&tableMapping, "table", "", "YAML mapping of CSV/TSV tables to vertices and edges")
+	flags.StringVar(&parquetPath, "parquet", "", "Parquet file, or directory of Parquet files, with a vertex or edge label per file")
 	flags.BoolVar(&edgeUID, "edge-uid", edgeUID, "fill in blank edge ids")
 	flags.IntVarP(&workerCount, "workers", "n", workerCount, "number of processing threads")
+	flags.StringVar(&checkpointPath, "checkpoint", "", "record the progress of the load in this file")
+	flags.BoolVar(&resume, "resume", resume, "skip input that was committed in the checkpoint file (default <graph>.checkpoint.json)")
+	flags.IntVar(&batchSize, "batch-size", batchSize, "number of elements committed between checkpoints")
 }
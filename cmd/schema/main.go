@@ -1,15 +1,19 @@
 package schema
 
 import (
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/bmeg/grip/gripql"
 	gripql_schema "github.com/bmeg/grip/gripql/schema"
 	"github.com/bmeg/grip/log"
 	"github.com/bmeg/grip/util/rpc"
 	"github.com/spf13/cobra"
+	sigsyaml "sigs.k8s.io/yaml"
 )
 
 var host = "localhost:8202"
@@ -18,6 +22,9 @@ var jsonFile string
 var yamlFile string
 var sampleCount uint32 = 50
 var excludeLabels []string
+var format = "graph"
+var graphName string
+var outDir string
 
 var manual bool
 
@@ -164,6 +171,206 @@ var sampleCmd = &cobra.Command{
 	},
 }
 
+var importCmd = &cobra.Command{
+	Use:   "import <file|dir>...",
+	Short: "Import graph schemas from other formats",
+	Long: `Converts schema documents and posts them to the server.
+
+With --format jsonschema, each file holds one JSON Schema document per
+vertex label, in JSON or YAML. Directories are searched for .json, .yaml
+and .yml files.`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var graphs []*gripql.Graph
+		switch format {
+		case "graph":
+			for _, f := range args {
+				g, err := gripql.ParseYAMLGraphsFile(f)
+				if err != nil {
+					return err
+				}
+				graphs = append(graphs, g...)
+			}
+		case "jsonschema":
+			if graphName == "" {
+				return fmt.Errorf("--graph is required for jsonschema imports")
+			}
+			docs, err := readSchemaDocs(args)
+			if err != nil {
+				return err
+			}
+			g, err := gripql_schema.JSONSchemaToGraph(graphName, docs)
+			if err != nil {
+				return err
+			}
+			graphs = append(graphs, g)
+		default:
+			return fmt.Errorf("unknown schema format: %s", format)
+		}
+
+		conn, err := gripql.Connect(rpc.ConfigWithDefaults(host), true)
+		if err != nil {
+			return err
+		}
+		for _, g := range graphs {
+			if graphName != "" {
+				g.Graph = graphName
+			}
+			if err := conn.AddSchema(g); err != nil {
+				return err
+			}
+			log.Infof("Posted schema: %s", g.Graph)
+		}
+		return nil
+	},
+}
+
+var exportCmd = &cobra.Command{
+	Use:   "export <graph>",
+	Short: "Export the schema for a graph to other formats",
+	Long: `Converts the schema of a graph to another format.
+
+With --format jsonschema, one JSON Schema document is written per vertex
+label. If --out is set each document is written to <out>/<label>.json
+(or .yaml), otherwise the documents are printed as a list.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		conn, err := gripql.Connect(rpc.ConfigWithDefaults(host), true)
+		if err != nil {
+			return err
+		}
+		schema, err := conn.GetSchema(args[0])
+		if err != nil {
+			return err
+		}
+
+		switch format {
+		case "graph":
+			var txt string
+			if yaml {
+				txt, err = gripql.GraphToYAMLString(schema)
+			} else {
+				txt, err = gripql.GraphToJSONString(schema)
+			}
+			if err != nil {
+				return err
+			}
+			fmt.Printf("%s\n", txt)
+			return nil
+		case "jsonschema":
+		default:
+			return fmt.Errorf("unknown schema format: %s", format)
+		}
+
+		docs, err := gripql_schema.GraphToJSONSchema(schema)
+		if err != nil {
+			return err
+		}
+		if outDir == "" {
+			out, err := marshalSchemaDoc(docs)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("%s\n", out)
+			return nil
+		}
+		if err := os.MkdirAll(outDir, 0755); err != nil {
+			return err
+		}
+		ext := ".json"
+		if yaml {
+			ext = ".yaml"
+		}
+		for _, doc := range docs {
+			out, err := marshalSchemaDoc(doc)
+			if err != nil {
+				return err
+			}
+			name, err := schemaDocPath(outDir, doc, ext)
+			if err != nil {
+				return err
+			}
+			if err := ioutil.WriteFile(name, append(out, '\n'), 0644); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+}
+
+// schemaDocPath returns the file a JSON Schema document is exported to, named
+// after its $id. Ids that would name a file outside of dir are rejected.
+func schemaDocPath(dir string, doc map[string]interface{}, ext string) (string, error) {
+	id, ok := doc["$id"].(string)
+	if !ok || id == "" {
+		return "", fmt.Errorf("schema document has no $id")
+	}
+	if id == "." || id == ".." || strings.ContainsAny(id, `/\`) {
+		return "", fmt.Errorf("schema $id '%s' can't be used as a file name", id)
+	}
+	name := filepath.Join(dir, id+ext)
+	if rel, err := filepath.Rel(dir, name); err != nil || rel != filepath.Base(name) {
+		return "", fmt.Errorf("schema $id '%s' can't be used as a file name", id)
+	}
+	return name, nil
+}
+
+func marshalSchemaDoc(doc interface{}) ([]byte, error) {
+	out, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil || !yaml {
+		return out, err
+	}
+	return sigsyaml.JSONToYAML(out)
+}
+
+// readSchemaDocs reads JSON Schema documents from files and directories.
+// A file can hold a single document, a list of documents, or several YAML
+// documents separated by '---'.
+func readSchemaDocs(paths []string) ([]map[string]interface{}, error) {
+	files := []string{}
+	for _, p := range paths {
+		info, err := os.Stat(p)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			files = append(files, p)
+			continue
+		}
+		for _, ext := range []string{"*.json", "*.yaml", "*.yml"} {
+			m, _ := filepath.Glob(filepath.Join(p, ext))
+			files = append(files, m...)
+		}
+	}
+	docs := []map[string]interface{}{}
+	for _, f := range files {
+		raw, err := ioutil.ReadFile(f)
+		if err != nil {
+			return nil, err
+		}
+		for _, part := range strings.Split("\n"+string(raw), "\n---") {
+			if strings.TrimSpace(part) == "" {
+				continue
+			}
+			var v interface{}
+			if err := sigsyaml.Unmarshal([]byte(part), &v); err != nil {
+				return nil, fmt.Errorf("reading %s: %v", f, err)
+			}
+			switch d := v.(type) {
+			case map[string]interface{}:
+				docs = append(docs, d)
+			case []interface{}:
+				for _, i := range d {
+					if m, ok := i.(map[string]interface{}); ok {
+						docs = append(docs, m)
+					}
+				}
+			}
+		}
+	}
+	return docs, nil
+}
+
 func init() {
 	gflags := getCmd.Flags()
 	gflags.StringVar(&host, "host", host, "grip server url")
@@ -181,7 +388,20 @@ func init() {
 	sflags.BoolVar(&manual, "manual", manual, "Use client side schema sampling")
 	sflags.StringSliceVar(&excludeLabels, "exclude-label", excludeLabels, "exclude vertex/edge label from schema")
 
+	iflags := importCmd.Flags()
+	iflags.StringVar(&host, "host", host, "grip server url")
+	iflags.StringVar(&format, "format", format, "schema format [graph, jsonschema]")
+	iflags.StringVar(&graphName, "graph", "", "name of the graph the schema describes")
+
+	eflags := exportCmd.Flags()
+	eflags.StringVar(&host, "host", host, "grip server url")
+	eflags.StringVar(&format, "format", format, "schema format [graph, jsonschema]")
+	eflags.BoolVar(&yaml, "yaml", yaml, "output schema in YAML rather than JSON format")
+	eflags.StringVar(&outDir, "out", "", "directory to write one document per vertex label to")
+
 	Cmd.AddCommand(getCmd)
 	Cmd.AddCommand(postCmd)
 	Cmd.AddCommand(sampleCmd)
+	Cmd.AddCommand(importCmd)
+	Cmd.AddCommand(exportCmd)
 }
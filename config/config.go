@@ -5,6 +5,7 @@ import (
 	"io/ioutil"
 	"math/rand"
 	"path/filepath"
+	"runtime"
 	"strings"
 	"time"
 
@@ -15,6 +16,7 @@ import (
 	"github.com/bmeg/grip/log"
 	"github.com/bmeg/grip/mongo"
 	"github.com/bmeg/grip/psql"
+	"github.com/bmeg/grip/sharded"
 	"github.com/bmeg/grip/util"
 	"github.com/bmeg/grip/util/duration"
 	"github.com/bmeg/grip/util/rpc"
@@ -36,6 +38,7 @@ type DriverConfig struct {
 	PSQL          *psql.Config
 	ExistingSQL   *esql.Config
 	Gripper       *gripper.Config
+	Sharded       *sharded.Config
 }
 
 // Config describes the configuration for Grip.
@@ -67,6 +70,9 @@ func DefaultConfig() *Config {
 	c.Server.SchemaRefreshInterval = duration.Duration(24 * time.Hour)
 	c.Server.SchemaInspectN = 500
 	c.Server.SchemaRandomSample = true
+	c.Server.Parallelism.Workers = 1
+	c.Server.Parallelism.MaxWorkers = runtime.NumCPU()
+	c.Server.JobRetention.Interval = duration.Duration(10 * time.Minute)
 	c.Server.RequestLogging.HeaderWhitelist = []string{
 		"authorization", "oauthemail", "content-type", "content-length",
 		"forwarded", "x-forwarded-for", "x-forwarded-host", "user-agent",
@@ -4,6 +4,8 @@ import (
 	"time"
 
 	"github.com/bmeg/grip/accounts"
+	"github.com/bmeg/grip/gdbi/schema"
+	"github.com/bmeg/grip/jobstorage"
 	"github.com/bmeg/grip/util"
 	"github.com/bmeg/grip/util/duration"
 )
@@ -30,6 +32,35 @@ type ServerConfig struct {
 	// Strategy to use for selecting the vertices/edges to inspect.
 	// Random if True; first N otherwise
 	SchemaRandomSample bool
+	// Graphs whose writes are checked against the graph schema. Vertices and
+	// edges that don't match the schema are rejected
+	SchemaEnforcement map[string]schema.Enforcement
+	// Object store used to share jobs between servers. Jobs are stored
+	// in WorkDir if not set
+	JobStorage struct {
+		S3 *jobstorage.S3Config
+	}
+	// When spooled job results should be removed
+	JobRetention jobstorage.RetentionPolicy
+	// Reuse the results of completed jobs when running traversals
+	QueryCache struct {
+		Enable bool
+		// Submit a statement prefix as a job once it has been seen this many
+		// times. Set to 0 to only reuse jobs submitted by clients
+		AutoCacheThreshold int
+		// Shortest statement prefix that will be submitted automatically
+		MinPrefix int
+	}
+	// Run stateless steps of traversals, such as has and render, on several
+	// workers
+	Parallelism struct {
+		// Workers for each step of queries that don't set their own
+		Workers int
+		// Most workers a query may ask for. Set to 0 for no limit
+		MaxWorkers int
+		// Keep the order of results for queries that don't ask for it
+		PreserveOrder bool
+	}
 	// Configure how the server logs requests
 	RequestLogging struct {
 		Enable bool
@@ -88,6 +88,34 @@ def test_traversal_histogram_aggregation(man):
     return errors
 
 
+def test_date_histogram_aggregation(man):
+    errors = []
+
+    G = man.setGraph("swapi")
+
+    created_agg_map = {
+        "2014-12-09T00:00:00Z": 1,
+        "2014-12-10T00:00:00Z": 14,
+        "2014-12-12T00:00:00Z": 2,
+        "2014-12-20T00:00:00Z": 1
+    }
+
+    count = 0
+    for row in G.query().V().hasLabel("Character").aggregate(gripql.date_histogram("created-agg", "system.created", "day")):
+        count += 1
+        if row['name'] != 'created-agg':
+            errors.append("Result had Incorrect aggregation name")
+            return errors
+
+        if created_agg_map.get(row["key"], 0) != row["value"]:
+            errors.append("Incorrect bucket count returned: %s" % row)
+
+    if count != 12:
+        errors.append("Incorrect bucket size returned: %d" % count)
+
+    return errors
+
+
 def test_traversal_percentile_aggregation(man):
     errors = []
 
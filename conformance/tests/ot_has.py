@@ -497,3 +497,33 @@ def test_has_complex(man):
         )
 
     return errors
+
+
+def test_has_datetime(man):
+    errors = []
+
+    G = man.setGraph("swapi")
+
+    # the lower bound is 15:00 UTC, which sorts after the matching
+    # timestamps when compared as a string
+    count = 0
+    for i in G.query().V().hasLabel("Character").has(gripql.between("system.created", ["2014-12-10T16:00:00+01:00", "2014-12-10T17:00:00Z"])):
+        count += 1
+        if not "2014-12-10T15:00:00" <= i["data"]["system"]["created"] < "2014-12-10T17:00:00":
+            errors.append("Wrong vertex returned %s" % (i["gid"]))
+    if count != 12:
+        errors.append(
+            "Fail: G.query().V().hasLabel(\"Character\").has(gripql.between(\"system.created\", ...)) %s != %s" %
+            (count, 12))
+
+    count = 0
+    for i in G.query().V().hasLabel("Character").has(gripql.gt("system.created", "2014-12-12")):
+        count += 1
+        if i["gid"] not in ["Character:18", "Character:19", "Character:81"]:
+            errors.append("Wrong vertex returned %s" % (i["gid"]))
+    if count != 3:
+        errors.append(
+            "Fail: G.query().V().hasLabel(\"Character\").has(gripql.gt(\"system.created\", \"2014-12-12\")) %s != %s" %
+            (count, 3))
+
+    return errors
@@ -1,3 +1,8 @@
+from __future__ import absolute_import
+
+import gripql
+
+
 
 
 def test_index(man):
@@ -31,3 +36,35 @@ def test_index(man):
         errors.append("Expected index not found")
 
     return errors
+
+
+def test_geo_index(man):
+    errors = []
+
+    G = man.writeTest()
+
+    G.addIndex("Site", "loc", "GEO_INDEX")
+
+    def point(lat, lon):
+        return {"type": "Point", "coordinates": [lon, lat]}
+
+    G.addVertex("oak", "Site", {"loc": point(37.8044, -122.2712)})
+    G.addVertex("sf", "Site", {"loc": point(37.7749, -122.4194)})
+    G.addVertex("sj", "Site", {"loc": point(37.3382, -121.8863)})
+    G.addVertex("nyc", "Site", {"loc": point(40.7484, -73.9857)})
+    G.addVertex("suva", "Site", {"loc": point(-18.1416, 178.4419)})
+    G.addVertex("none", "Site", {"name": "nowhere"})
+    G.addVertex("p1", "Person", {"loc": point(37.8, -122.3)})
+
+    tests = [
+        (gripql.geo_radius("loc", 37.7749, -122.4194, 15000), ["oak", "sf"]),
+        (gripql.geo_box("loc", -20, 170, -10, -170), ["suva"]),
+        (gripql.geo_polygon("loc", [(37, -123), (38.5, -122), (37, -121)]), ["oak", "sf", "sj"]),
+        (gripql.not_(gripql.geo_radius("loc", 37.7749, -122.4194, 100000)), ["none", "nyc", "suva"]),
+    ]
+    for cond, expected in tests:
+        found = sorted(v["gid"] for v in G.query().V().hasLabel("Site").has(cond))
+        if found != expected:
+            errors.append("Fail: %s: %s != %s" % (cond, found, expected))
+
+    return errors
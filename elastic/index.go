@@ -15,8 +15,8 @@ import (
 )
 
 // AddVertexIndex adds a new field to be indexed
-func (es *Graph) AddVertexIndex(label string, field string) error {
-	log.WithFields(log.Fields{"label": label, "field": field}).Info("Adding vertex index")
+func (es *Graph) AddVertexIndex(label string, field string, itype gripql.IndexType) error {
+	log.WithFields(log.Fields{"label": label, "field": field, "type": itype}).Info("Adding vertex index")
 	return nil
 }
 
@@ -3,6 +3,7 @@ package main
 import (
 	"fmt"
 
+	"github.com/bmeg/grip/endpoints/graphqlutil"
 	"github.com/bmeg/grip/gripql"
 	"github.com/bmeg/grip/log"
 	"github.com/graphql-go/graphql"
@@ -15,33 +16,10 @@ const ARG_ID = "id"
 const ARG_IDS = "ids"
 const ARG_FILTER = "filter"
 
-var JSONScalar = graphql.NewScalar(graphql.ScalarConfig{
-	Name: "JSON",
-	Serialize: func(value interface{}) interface{} {
-		return fmt.Sprintf("Serialize %v", value)
-	},
-	ParseValue: func(value interface{}) interface{} {
-		fmt.Printf("Unmarshal JSON: %v %T\n", value, value)
-		return value
-	},
-	ParseLiteral: func(valueAST ast.Value) interface{} {
-		fmt.Printf("ParseLiteral: %#v\n", valueAST)
-		/*
-			switch valueAST := valueAST.(type) {
-			case *ast.StringValue:
-				id, _ := models.IDFromString(valueAST.Value)
-				return id
-			default:
-				return nil
-			}*/
-		return nil
-	},
-})
-
 // buildGraphQLSchema reads a GRIP graph schema (which is stored as a graph) and creates
 // a GraphQL-GO based schema. The GraphQL-GO schema all wraps the request functions that use
 // the gripql.Client to find the requested data
-func buildGraphQLSchema(schema *gripql.Graph, client gripql.Client, graph string) (*graphql.Schema, error) {
+func buildGraphQLSchema(schema *gripql.Graph, client gripql.Client, graph string, queries []*gripql.QueryDefinition) (*graphql.Schema, error) {
 	if schema == nil {
 		return nil, fmt.Errorf("graphql.NewSchema error: nil gripql.Graph for graph: %s", graph)
 	}
@@ -53,9 +31,15 @@ func buildGraphQLSchema(schema *gripql.Graph, client gripql.Client, graph string
 
 	// Build the set of objects that exist in the query structuer
 	queryObj := buildQueryObject(client, graph, objectMap)
+	graphqlutil.AddStoredQueryFields(queryObj, client, graph, queries)
 	schemaConfig := graphql.SchemaConfig{
 		Query: queryObj,
 	}
+	// Mutations are only offered when the client can edit the graph
+	if client.EditC != nil {
+		schemaConfig.Mutation = graphqlutil.BuildMutationObject(client, graph, schema, objectMap)
+	}
+	schemaConfig.Subscription = buildSubscriptionObject(client, graph, objectMap)
 
 	// Setup the GraphQL schema based on the objects there have been created
 	gqlSchema, err := graphql.NewSchema(schemaConfig)
@@ -75,6 +59,8 @@ func buildField(x string) (*graphql.Field, error) {
 		o = &graphql.Field{Type: graphql.String}
 	case "BOOL":
 		o = &graphql.Field{Type: graphql.Boolean}
+	case "DATETIME":
+		o = &graphql.Field{Type: graphqlutil.DateTimeScalar}
 	default:
 		return nil, fmt.Errorf("%s does not map to a GQL type", x)
 	}
@@ -196,15 +182,7 @@ func buildObjectMap(client gripql.Client, graph string, schema *gripql.Graph) (m
 		if _, ok := objects[obj.From]; ok {
 			if _, ok := objects[obj.To]; ok {
 				obj := obj // This makes an inner loop copy of the variable that is used by the Resolve function
-				fname := obj.Label
-				//ensure the fname is unique
-				for j := range schema.Edges {
-					if i != j {
-						if schema.Edges[i].From == schema.Edges[j].From && schema.Edges[i].Label == schema.Edges[j].Label {
-							fname = obj.Label + "_to_" + obj.To
-						}
-					}
-				}
+				fname := graphqlutil.EdgeFieldName(schema.Edges, i)
 
 				f := &graphql.Field{
 					Name: fname,
@@ -247,14 +225,14 @@ func buildFieldConfigArgument(obj *graphql.Object) graphql.FieldConfigArgument {
 		ARG_IDS:    &graphql.ArgumentConfig{Type: graphql.NewList(graphql.String)},
 		ARG_LIMIT:  &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: 100},
 		ARG_OFFSET: &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: 0},
-		ARG_FILTER: &graphql.ArgumentConfig{Type: JSONScalar},
+		ARG_FILTER: &graphql.ArgumentConfig{Type: graphqlutil.JSONScalar},
 	}
 	if obj == nil {
 		return args
 	}
 	for k, v := range obj.Fields() {
 		switch v.Type {
-		case graphql.String, graphql.Int, graphql.Float, graphql.Boolean:
+		case graphql.String, graphql.Int, graphql.Float, graphql.Boolean, graphqlutil.DateTimeScalar:
 			args[k] = &graphql.ArgumentConfig{Type: v.Type}
 		default:
 			continue
@@ -310,7 +288,7 @@ func buildAggregationField(client gripql.Client, graph string, objects map[strin
 				Name: k + "Aggregation",
 				Type: ao,
 				Args: graphql.FieldConfigArgument{
-					"filter": &graphql.ArgumentConfig{Type: JSONScalar},
+					"filter": &graphql.ArgumentConfig{Type: graphqlutil.JSONScalar},
 				},
 				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
 					var filter *FilterBuilder
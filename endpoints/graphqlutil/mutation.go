@@ -0,0 +1,293 @@
+package graphqlutil
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/bmeg/grip/gripql"
+	"github.com/bmeg/grip/log"
+	"github.com/bmeg/grip/util"
+	"github.com/graphql-go/graphql"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const ARG_DATA = "data"
+const ARG_FROM = "from"
+const ARG_TO = "to"
+
+// BuildInputType maps a field type of the graph schema to a GraphQL input type
+func BuildInputType(x string) (graphql.Input, error) {
+	switch x {
+	case "NUMERIC":
+		return graphql.Float, nil
+	case "STRING":
+		return graphql.String, nil
+	case "BOOL":
+		return graphql.Boolean, nil
+	case "DATETIME":
+		return DateTimeScalar, nil
+	}
+	return nil, fmt.Errorf("%s does not map to a GQL type", x)
+}
+
+// buildInputValue maps a field of the graph schema to a GraphQL input type,
+// making input objects for nested data
+func buildInputValue(name string, val interface{}) (graphql.Input, error) {
+	switch x := val.(type) {
+	case string:
+		return BuildInputType(x)
+	case []interface{}:
+		if len(x) == 0 {
+			return nil, fmt.Errorf("slice is empty")
+		}
+		t, err := buildInputValue(name, x[0])
+		if err != nil {
+			return nil, err
+		}
+		return graphql.NewList(t), nil
+	case map[string]interface{}:
+		return buildInputObject(name, x)
+	}
+	return nil, fmt.Errorf("unhandled type: %T %v", val, val)
+}
+
+// buildInputObject creates the input type used to create and update vertices
+// with the fields of a schema object
+func buildInputObject(name string, obj map[string]interface{}) (*graphql.InputObject, error) {
+	fields := graphql.InputObjectConfigFieldMap{}
+	for key, val := range obj {
+		t, err := buildInputValue(name+"_"+key, val)
+		if err != nil {
+			log.WithFields(log.Fields{"object": name, "field": key, "error": err}).Error("graphql: buildInputObject ignoring field")
+			continue
+		}
+		fields[key] = &graphql.InputObjectFieldConfig{Type: t}
+	}
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("no fields in object")
+	}
+	return graphql.NewInputObject(graphql.InputObjectConfig{
+		Name:   name + "Input",
+		Fields: fields,
+	}), nil
+}
+
+// getVertex fetches a vertex, checking that it has the expected label
+func getVertex(ctx context.Context, client gripql.Client, graph, label, id string) (*gripql.Vertex, error) {
+	v, err := client.QueryC.GetVertex(ctx, &gripql.ElementID{Graph: graph, Id: id})
+	if err != nil {
+		return nil, err
+	}
+	if v.Label != label {
+		return nil, fmt.Errorf("vertex %s is a %s, not a %s", id, v.Label, label)
+	}
+	return v, nil
+}
+
+// VertexResult converts a vertex to the map returned for its GraphQL object
+func VertexResult(v *gripql.Vertex) map[string]interface{} {
+	d := v.GetDataMap()
+	if d == nil {
+		d = map[string]interface{}{}
+	}
+	d["id"] = v.Gid
+	return d
+}
+
+// buildVertexMutations adds the create, update and delete mutations of a vertex type
+func buildVertexMutations(fields graphql.Fields, client gripql.Client, graph string, label string, obj *graphql.Object, input *graphql.InputObject) {
+	fields["create"+label] = &graphql.Field{
+		Name: "create" + label,
+		Type: obj,
+		Args: graphql.FieldConfigArgument{
+			ARG_ID:   &graphql.ArgumentConfig{Type: graphql.String},
+			ARG_DATA: &graphql.ArgumentConfig{Type: graphql.NewNonNull(input)},
+		},
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			id, _ := p.Args[ARG_ID].(string)
+			if id == "" {
+				id = util.UUID()
+			}
+			data, _ := p.Args[ARG_DATA].(map[string]interface{})
+			v := &gripql.Vertex{Gid: id, Label: label}
+			v.SetDataMap(data)
+			// the server fails the write if the id is taken, so two creates
+			// can't both succeed
+			_, err := client.EditC.AddVertex(p.Context, &gripql.GraphElement{Graph: graph, Vertex: v, Create: true})
+			if status.Code(err) == codes.AlreadyExists {
+				return nil, fmt.Errorf("vertex %s already exists", id)
+			}
+			if err != nil {
+				return nil, err
+			}
+			return VertexResult(v), nil
+		},
+	}
+
+	fields["update"+label] = &graphql.Field{
+		Name: "update" + label,
+		Type: obj,
+		Args: graphql.FieldConfigArgument{
+			ARG_ID:   &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+			ARG_DATA: &graphql.ArgumentConfig{Type: graphql.NewNonNull(input)},
+		},
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			id := p.Args[ARG_ID].(string)
+			v, err := getVertex(p.Context, client, graph, label, id)
+			if err != nil {
+				return nil, err
+			}
+			// fields that are not given keep their values
+			data := v.GetDataMap()
+			if data == nil {
+				data = map[string]interface{}{}
+			}
+			update, _ := p.Args[ARG_DATA].(map[string]interface{})
+			for k, val := range update {
+				data[k] = val
+			}
+			v.SetDataMap(data)
+			if _, err := client.EditC.AddVertex(p.Context, &gripql.GraphElement{Graph: graph, Vertex: v}); err != nil {
+				return nil, err
+			}
+			return VertexResult(v), nil
+		},
+	}
+
+	fields["delete"+label] = &graphql.Field{
+		Name: "delete" + label,
+		Type: graphql.String,
+		Args: graphql.FieldConfigArgument{
+			ARG_ID: &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+		},
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			id := p.Args[ARG_ID].(string)
+			if _, err := getVertex(p.Context, client, graph, label, id); err != nil {
+				return nil, err
+			}
+			if _, err := client.EditC.DeleteVertex(p.Context, &gripql.ElementID{Graph: graph, Id: id}); err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	}
+}
+
+// buildEdgeMutations adds the mutations that connect and disconnect vertices
+// through the field of a schema edge
+func buildEdgeMutations(fields graphql.Fields, client gripql.Client, graph string, fname string, edge *gripql.Edge) {
+	args := graphql.FieldConfigArgument{
+		ARG_FROM: &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+		ARG_TO:   &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+	}
+	// endpoints checks the labels of the vertices being connected
+	endpoints := func(p graphql.ResolveParams) (string, string, error) {
+		from := p.Args[ARG_FROM].(string)
+		to := p.Args[ARG_TO].(string)
+		if _, err := getVertex(p.Context, client, graph, edge.From, from); err != nil {
+			return "", "", err
+		}
+		if _, err := getVertex(p.Context, client, graph, edge.To, to); err != nil {
+			return "", "", err
+		}
+		return from, to, nil
+	}
+
+	name := "connect" + edge.From + "_" + fname
+	fields[name] = &graphql.Field{
+		Name: name,
+		Type: graphql.String,
+		Args: args,
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			from, to, err := endpoints(p)
+			if err != nil {
+				return nil, err
+			}
+			res, err := client.EditC.AddEdge(p.Context, &gripql.GraphElement{
+				Graph: graph,
+				Edge:  &gripql.Edge{From: from, To: to, Label: edge.Label},
+			})
+			if err != nil {
+				return nil, err
+			}
+			return res.Id, nil
+		},
+	}
+
+	name = "disconnect" + edge.From + "_" + fname
+	fields[name] = &graphql.Field{
+		Name: name,
+		Type: graphql.Int,
+		Args: args,
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			from, to, err := endpoints(p)
+			if err != nil {
+				return nil, err
+			}
+			q := gripql.V(from).OutE(edge.Label).Has(gripql.Eq("_to", to))
+			res, err := client.QueryC.Traversal(p.Context, &gripql.GraphQuery{Graph: graph, Query: q.Statements})
+			if err != nil {
+				return nil, err
+			}
+			ids := []string{}
+			for {
+				row, err := res.Recv()
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					return nil, err
+				}
+				ids = append(ids, row.GetEdge().Gid)
+			}
+			for _, id := range ids {
+				if _, err := client.EditC.DeleteEdge(p.Context, &gripql.ElementID{Graph: graph, Id: id}); err != nil {
+					return nil, err
+				}
+			}
+			return len(ids), nil
+		},
+	}
+}
+
+// BuildMutationObject creates the mutations of the graph: create, update and
+// delete for each vertex type, and connect and disconnect for each of their
+// edge fields. Edits are sent through the client's Edit API, so they pass the
+// same access checks as other writes
+func BuildMutationObject(client gripql.Client, graph string, schema *gripql.Graph, objects map[string]*graphql.Object) *graphql.Object {
+	mutationFields := graphql.Fields{}
+	for _, obj := range schema.Vertices {
+		gqlObj, ok := objects[obj.Gid]
+		if obj.Label != "Vertex" || !ok {
+			continue
+		}
+		props := obj.GetDataMap()
+		delete(props, "id")
+		input, err := buildInputObject(obj.Gid, props)
+		if err != nil {
+			log.WithFields(log.Fields{"object": obj.Gid, "error": err}).Error("graphql: BuildMutationObject ignoring object")
+			continue
+		}
+		buildVertexMutations(mutationFields, client, graph, obj.Gid, gqlObj, input)
+	}
+	for i, obj := range schema.Edges {
+		if _, ok := objects[obj.From]; !ok {
+			continue
+		}
+		if _, ok := objects[obj.To]; !ok {
+			continue
+		}
+		buildEdgeMutations(mutationFields, client, graph, EdgeFieldName(schema.Edges, i), obj)
+	}
+	if len(mutationFields) == 0 {
+		return nil
+	}
+	return graphql.NewObject(
+		graphql.ObjectConfig{
+			Name:   "Mutation",
+			Fields: mutationFields,
+		},
+	)
+}
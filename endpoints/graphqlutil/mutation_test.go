@@ -0,0 +1,227 @@
+package graphqlutil
+
+import (
+	"context"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/bmeg/grip/config"
+	"github.com/bmeg/grip/gdbi"
+	"github.com/bmeg/grip/gripql"
+	"github.com/bmeg/grip/kvgraph"
+	"github.com/bmeg/grip/server"
+	"github.com/bmeg/grip/util"
+	"github.com/graphql-go/graphql"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+const testGraph = "test"
+
+// newTestServer starts an in-process server with an empty graph, and returns
+// a client calling it directly
+func newTestServer(t *testing.T) (*server.GripServer, gripql.Client) {
+	conf := config.DefaultConfig()
+	config.TestifyConfig(conf)
+	tmpDB := "grip.db." + util.RandomString(6)
+	gdb, err := kvgraph.NewKVGraphDB("badger", tmpDB)
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv, err := server.NewGripServer(conf, "./", map[string]gdbi.GraphDB{"badger": gdb})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		gdb.Close()
+		os.RemoveAll(tmpDB)
+		os.RemoveAll(conf.Server.WorkDir)
+	})
+	client := gripql.WrapClient(gripql.NewQueryDirectClient(srv), gripql.NewEditDirectClient(srv), nil, nil)
+	if err := client.AddGraph(testGraph); err != nil {
+		t.Fatal(err)
+	}
+	return srv, client
+}
+
+func mustStruct(t *testing.T, m map[string]interface{}) *structpb.Struct {
+	s, err := structpb.NewStruct(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+// testSchema describes people who own dogs
+func testSchema(t *testing.T) *gripql.Graph {
+	return &gripql.Graph{
+		Graph: testGraph,
+		Vertices: []*gripql.Vertex{
+			{Gid: "Person", Label: "Vertex", Data: mustStruct(t, map[string]interface{}{"name": "STRING", "age": "NUMERIC"})},
+			{Gid: "Dog", Label: "Vertex", Data: mustStruct(t, map[string]interface{}{"name": "STRING"})},
+		},
+		Edges: []*gripql.Edge{
+			{Gid: "(Person)--owns->(Dog)", Label: "owns", From: "Person", To: "Dog"},
+		},
+	}
+}
+
+// newMutationSchema builds a GraphQL schema with the mutations of the test
+// schema, and a placeholder query
+func newMutationSchema(t *testing.T, client gripql.Client) graphql.Schema {
+	objects := map[string]*graphql.Object{}
+	for _, name := range []string{"Person", "Dog"} {
+		objects[name] = graphql.NewObject(graphql.ObjectConfig{
+			Name: name,
+			Fields: graphql.Fields{
+				"id":   &graphql.Field{Type: graphql.String},
+				"name": &graphql.Field{Type: graphql.String},
+				"age":  &graphql.Field{Type: graphql.Float},
+			},
+		})
+	}
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name:   "Query",
+			Fields: graphql.Fields{"ok": &graphql.Field{Type: graphql.Boolean}},
+		}),
+		Mutation: BuildMutationObject(client, testGraph, testSchema(t), objects),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return schema
+}
+
+func runQuery(schema graphql.Schema, query string) *graphql.Result {
+	return graphql.Do(graphql.Params{Schema: schema, RequestString: query, Context: context.Background()})
+}
+
+func TestVertexMutations(t *testing.T) {
+	_, client := newTestServer(t)
+	schema := newMutationSchema(t, client)
+
+	res := runQuery(schema, `mutation { createPerson(id: "p1", data: {name: "alice", age: 30}) { id name age } }`)
+	if res.HasErrors() {
+		t.Fatal(res.Errors)
+	}
+	created := res.Data.(map[string]interface{})["createPerson"].(map[string]interface{})
+	if created["id"] != "p1" || created["name"] != "alice" || created["age"] != 30.0 {
+		t.Errorf("unexpected created vertex: %v", created)
+	}
+	v, err := client.GetVertex(testGraph, "p1")
+	if err != nil || v.Label != "Person" || v.GetDataMap()["name"] != "alice" {
+		t.Errorf("vertex not written: %v %v", v, err)
+	}
+
+	if res := runQuery(schema, `mutation { createPerson(id: "p1", data: {name: "bob"}) { id } }`); !res.HasErrors() {
+		t.Error("expected creating an existing vertex to fail")
+	}
+	res = runQuery(schema, `mutation { createDog(data: {name: "rex"}) { id } }`)
+	if res.HasErrors() {
+		t.Fatal(res.Errors)
+	}
+	dogID := res.Data.(map[string]interface{})["createDog"].(map[string]interface{})["id"].(string)
+	if dogID == "" {
+		t.Error("no id generated")
+	}
+
+	// fields that are not given keep their values
+	res = runQuery(schema, `mutation { updatePerson(id: "p1", data: {age: 31}) { name age } }`)
+	if res.HasErrors() {
+		t.Fatal(res.Errors)
+	}
+	updated := res.Data.(map[string]interface{})["updatePerson"].(map[string]interface{})
+	if updated["name"] != "alice" || updated["age"] != 31.0 {
+		t.Errorf("unexpected updated vertex: %v", updated)
+	}
+	if res := runQuery(schema, `mutation { updatePerson(id: "missing", data: {age: 1}) { id } }`); !res.HasErrors() {
+		t.Error("expected updating a missing vertex to fail")
+	}
+	if res := runQuery(schema, `mutation { updateDog(id: "p1", data: {name: "x"}) { id } }`); !res.HasErrors() {
+		t.Error("expected updating a vertex with another label to fail")
+	}
+
+	if res := runQuery(schema, `mutation { deleteDog(id: "p1") }`); !res.HasErrors() {
+		t.Error("expected deleting a vertex with another label to fail")
+	}
+	res = runQuery(schema, `mutation { deletePerson(id: "p1") }`)
+	if res.HasErrors() {
+		t.Fatal(res.Errors)
+	}
+	if _, err := client.GetVertex(testGraph, "p1"); err == nil {
+		t.Error("vertex not deleted")
+	}
+}
+
+func TestCreateVertexOnce(t *testing.T) {
+	_, client := newTestServer(t)
+	schema := newMutationSchema(t, client)
+
+	// concurrent creates of the same id can't both succeed
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	succeeded := 0
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			res := runQuery(schema, `mutation { createPerson(id: "p1", data: {name: "alice"}) { id } }`)
+			if !res.HasErrors() {
+				mu.Lock()
+				succeeded++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	if succeeded != 1 {
+		t.Errorf("%d creates succeeded", succeeded)
+	}
+}
+
+func TestEdgeMutations(t *testing.T) {
+	_, client := newTestServer(t)
+	schema := newMutationSchema(t, client)
+	for _, q := range []string{
+		`mutation { createPerson(id: "p1", data: {name: "alice"}) { id } }`,
+		`mutation { createDog(id: "d1", data: {name: "rex"}) { id } }`,
+	} {
+		if res := runQuery(schema, q); res.HasErrors() {
+			t.Fatal(res.Errors)
+		}
+	}
+
+	res := runQuery(schema, `mutation { connectPerson_owns(from: "p1", to: "d1") }`)
+	if res.HasErrors() {
+		t.Fatal(res.Errors)
+	}
+	edgeID := res.Data.(map[string]interface{})["connectPerson_owns"].(string)
+	e, err := client.GetEdge(testGraph, edgeID)
+	if err != nil || e.From != "p1" || e.To != "d1" || e.Label != "owns" {
+		t.Errorf("edge not written: %v %v", e, err)
+	}
+	if res := runQuery(schema, `mutation { connectPerson_owns(from: "d1", to: "p1") }`); !res.HasErrors() {
+		t.Error("expected connecting vertices with the wrong labels to fail")
+	}
+	if res := runQuery(schema, `mutation { connectPerson_owns(from: "p1", to: "missing") }`); !res.HasErrors() {
+		t.Error("expected connecting a missing vertex to fail")
+	}
+	if res := runQuery(schema, `mutation { connectPerson_owns(from: "p1", to: "d1") }`); res.HasErrors() {
+		t.Fatal(res.Errors)
+	}
+
+	res = runQuery(schema, `mutation { disconnectPerson_owns(from: "p1", to: "d1") }`)
+	if res.HasErrors() {
+		t.Fatal(res.Errors)
+	}
+	if n := res.Data.(map[string]interface{})["disconnectPerson_owns"]; n != 2 {
+		t.Errorf("unexpected number of removed edges: %v", n)
+	}
+	if _, err := client.GetEdge(testGraph, edgeID); err == nil {
+		t.Error("edge not deleted")
+	}
+	if _, err := client.GetVertex(testGraph, "d1"); err != nil {
+		t.Errorf("vertex deleted with edge: %v", err)
+	}
+}
@@ -0,0 +1,96 @@
+package graphqlutil
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/bmeg/grip/gripql"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+const ARG_ID = "id"
+
+// JSONScalar is the type of fields and arguments holding any JSON value
+var JSONScalar = graphql.NewScalar(graphql.ScalarConfig{
+	Name: "JSON",
+	Serialize: func(value interface{}) interface{} {
+		return value
+	},
+	ParseValue: func(value interface{}) interface{} {
+		fmt.Printf("Unmarshal JSON: %v %T\n", value, value)
+		return value
+	},
+	ParseLiteral: parseJSONLiteral,
+})
+
+// parseJSONLiteral reads a JSON value written in a GraphQL query
+func parseJSONLiteral(valueAST ast.Value) interface{} {
+	switch v := valueAST.(type) {
+	case *ast.StringValue:
+		return v.Value
+	case *ast.EnumValue:
+		return v.Value
+	case *ast.BooleanValue:
+		return v.Value
+	case *ast.IntValue:
+		if i, err := strconv.Atoi(v.Value); err == nil {
+			return i
+		}
+	case *ast.FloatValue:
+		if f, err := strconv.ParseFloat(v.Value, 64); err == nil {
+			return f
+		}
+	case *ast.ListValue:
+		out := []interface{}{}
+		for _, i := range v.Values {
+			out = append(out, parseJSONLiteral(i))
+		}
+		return out
+	case *ast.ObjectValue:
+		out := map[string]interface{}{}
+		for _, f := range v.Fields {
+			out[f.Name.Value] = parseJSONLiteral(f.Value)
+		}
+		return out
+	}
+	return nil
+}
+
+// DateTimeScalar is the type of DATETIME fields, which hold RFC3339
+// timestamps or dates. Values are passed on as strings, which conditions
+// compare by time
+var DateTimeScalar = graphql.NewScalar(graphql.ScalarConfig{
+	Name:        "DateTime",
+	Description: "An RFC3339 timestamp, such as 2014-12-10T15:10:51Z, or a date, such as 2014-12-10",
+	Serialize: func(value interface{}) interface{} {
+		return value
+	},
+	ParseValue: parseDateTime,
+	ParseLiteral: func(valueAST ast.Value) interface{} {
+		if v, ok := valueAST.(*ast.StringValue); ok {
+			return parseDateTime(v.Value)
+		}
+		return nil
+	},
+})
+
+// parseDateTime returns nil for values that aren't timestamps, which GraphQL
+// reports as invalid
+func parseDateTime(value interface{}) interface{} {
+	if _, ok := gripql.ParseDateTime(value); ok {
+		return value
+	}
+	return nil
+}
+
+// EdgeFieldName names the field of the i'th schema edge on its source object.
+// The destination label is added when the source has several edges with the label
+func EdgeFieldName(edges []*gripql.Edge, i int) string {
+	for j := range edges {
+		if i != j && edges[i].From == edges[j].From && edges[i].Label == edges[j].Label {
+			return edges[i].Label + "_to_" + edges[i].To
+		}
+	}
+	return edges[i].Label
+}
@@ -0,0 +1,53 @@
+package graphqlutil
+
+import (
+	"testing"
+
+	"github.com/graphql-go/graphql"
+)
+
+func TestDateTimeScalar(t *testing.T) {
+	typ, err := BuildInputType("DATETIME")
+	if err != nil || typ != DateTimeScalar {
+		t.Fatalf("unexpected DATETIME input type: %v %v", typ, err)
+	}
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"echo": &graphql.Field{
+					Type: DateTimeScalar,
+					Args: graphql.FieldConfigArgument{"at": &graphql.ArgumentConfig{Type: DateTimeScalar}},
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return p.Args["at"], nil
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		query    string
+		expected interface{}
+		valid    bool
+	}{
+		{`{echo(at: "2014-12-10T15:10:51Z")}`, "2014-12-10T15:10:51Z", true},
+		{`{echo(at: "2014-12-10")}`, "2014-12-10", true},
+		{`{echo(at: "yesterday")}`, nil, false},
+		{`{echo(at: 5)}`, nil, false},
+	}
+	for _, tt := range tests {
+		res := graphql.Do(graphql.Params{Schema: schema, RequestString: tt.query})
+		if res.HasErrors() == tt.valid {
+			t.Errorf("%s: unexpected errors %v", tt.query, res.Errors)
+			continue
+		}
+		if tt.valid {
+			if v := res.Data.(map[string]interface{})["echo"]; v != tt.expected {
+				t.Errorf("%s: unexpected result %v", tt.query, v)
+			}
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package graphqlutil
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+
+	"github.com/bmeg/grip/gripql"
+	"github.com/bmeg/grip/log"
+	"github.com/graphql-go/graphql"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// StoredQueries lists the stored queries of the graph, with a key that
+// changes when they are edited, so the schema can be rebuilt
+func StoredQueries(ctx context.Context, client gripql.Client, graph string) ([]*gripql.QueryDefinition, string) {
+	if client.StoredQueryC == nil {
+		return nil, ""
+	}
+	res, err := client.StoredQueryC.ListStoredQueries(ctx, &gripql.GraphID{Graph: graph})
+	if err != nil {
+		log.WithFields(log.Fields{"graph": graph, "error": err}).Debug("ListStoredQueries error")
+		return nil, ""
+	}
+	key, _ := proto.MarshalOptions{Deterministic: true}.Marshal(res)
+	return res.Queries, string(key)
+}
+
+func parameterType(p *gripql.QueryParameter) graphql.Input {
+	switch p.Type {
+	case gripql.FieldType_STRING:
+		return graphql.String
+	case gripql.FieldType_NUMERIC:
+		return graphql.Float
+	case gripql.FieldType_BOOL:
+		return graphql.Boolean
+	case gripql.FieldType_DATETIME:
+		return DateTimeScalar
+	}
+	return JSONScalar
+}
+
+// storedQueryResult converts a result of a stored query to JSON
+func storedQueryResult(r *gripql.QueryResult) interface{} {
+	switch x := r.Result.(type) {
+	case *gripql.QueryResult_Vertex:
+		return VertexResult(x.Vertex)
+	case *gripql.QueryResult_Edge:
+		d := x.Edge.GetDataMap()
+		if d == nil {
+			d = map[string]interface{}{}
+		}
+		d["id"] = x.Edge.Gid
+		d["from"] = x.Edge.From
+		d["to"] = x.Edge.To
+		return d
+	case *gripql.QueryResult_Render:
+		return x.Render.AsInterface()
+	case *gripql.QueryResult_Count:
+		return x.Count
+	}
+	data, _ := protojson.Marshal(r)
+	var out interface{}
+	json.Unmarshal(data, &out)
+	return out
+}
+
+// AddStoredQueryFields adds a query field for each stored query, which
+// takes the parameters of the query as arguments and returns its results
+func AddStoredQueryFields(query *graphql.Object, client gripql.Client, graph string, queries []*gripql.QueryDefinition) {
+	existing := query.Fields()
+	for _, def := range queries {
+		if _, ok := existing[def.Name]; ok {
+			log.WithFields(log.Fields{"graph": graph, "query": def.Name}).Warning("Stored query has the same name as a query field; skipping")
+			continue
+		}
+		args := graphql.FieldConfigArgument{}
+		for _, p := range def.Parameters {
+			arg := &graphql.ArgumentConfig{Type: parameterType(p), Description: p.Description}
+			if p.DefaultValue == nil {
+				arg.Type = graphql.NewNonNull(arg.Type)
+			} else if arg.Type != JSONScalar {
+				arg.DefaultValue = p.DefaultValue.AsInterface()
+			}
+			args[p.Name] = arg
+		}
+		name := def.Name
+		query.AddFieldConfig(name, &graphql.Field{
+			Name:        name,
+			Description: def.Description,
+			Type:        graphql.NewList(JSONScalar),
+			Args:        args,
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				params, err := structpb.NewStruct(p.Args)
+				if err != nil {
+					return nil, err
+				}
+				stream, err := client.StoredQueryC.RunStoredQuery(p.Context, &gripql.StoredQueryCall{Graph: graph, Name: name, Parameters: params})
+				if err != nil {
+					return nil, err
+				}
+				out := []interface{}{}
+				for {
+					r, err := stream.Recv()
+					if err == io.EOF {
+						return out, nil
+					}
+					if err != nil {
+						return nil, err
+					}
+					out = append(out, storedQueryResult(r))
+				}
+			},
+		})
+	}
+}
@@ -0,0 +1,146 @@
+package graphqlutil
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/bmeg/grip/gripql"
+	"github.com/graphql-go/graphql"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// newStoredQueryClient starts an in-process server with people who own dogs,
+// and returns a client that can call stored queries
+func newStoredQueryClient(t *testing.T) gripql.Client {
+	srv, client := newTestServer(t)
+	client.StoredQueryC = gripql.NewStoredQueryDirectClient(srv)
+	for _, v := range []*gripql.Vertex{
+		{Gid: "p1", Label: "Person", Data: mustStruct(t, map[string]interface{}{"name": "alice", "age": 30})},
+		{Gid: "p2", Label: "Person", Data: mustStruct(t, map[string]interface{}{"name": "bob", "age": 40})},
+		{Gid: "d1", Label: "Dog", Data: mustStruct(t, map[string]interface{}{"name": "rex"})},
+	} {
+		if err := client.AddVertex(testGraph, v); err != nil {
+			t.Fatal(err)
+		}
+	}
+	err := client.AddEdge(testGraph, &gripql.Edge{Gid: "e1", Label: "owns", From: "p1", To: "d1", Data: mustStruct(t, map[string]interface{}{"since": 2020})})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
+
+// jsonValue converts a result the way it is sent to clients
+func jsonValue(t *testing.T, v interface{}) interface{} {
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out interface{}
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatal(err)
+	}
+	return out
+}
+
+func TestStoredQueries(t *testing.T) {
+	client := newStoredQueryClient(t)
+	if queries, key := StoredQueries(context.Background(), gripql.Client{}, testGraph); queries != nil || key != "" {
+		t.Errorf("unexpected stored queries without a client: %v %q", queries, key)
+	}
+
+	def := &gripql.QueryDefinition{Graph: testGraph, Name: "people", Query: gripql.V().HasLabel("Person").Statements}
+	if err := client.AddStoredQuery(def); err != nil {
+		t.Fatal(err)
+	}
+	queries, key := StoredQueries(context.Background(), client, testGraph)
+	if len(queries) != 1 || queries[0].Name != "people" || key == "" {
+		t.Fatalf("unexpected stored queries: %v %q", queries, key)
+	}
+	if _, again := StoredQueries(context.Background(), client, testGraph); again != key {
+		t.Error("key changed without an edit")
+	}
+	def.Description = "all the people"
+	if err := client.AddStoredQuery(def); err != nil {
+		t.Fatal(err)
+	}
+	if _, edited := StoredQueries(context.Background(), client, testGraph); edited == key {
+		t.Error("key not changed by an edit")
+	}
+}
+
+func TestStoredQueryFields(t *testing.T) {
+	client := newStoredQueryClient(t)
+	defs := []*gripql.QueryDefinition{
+		{
+			Name:       "olderThan",
+			Parameters: []*gripql.QueryParameter{{Name: "age", Type: gripql.FieldType_NUMERIC}},
+			Query:      gripql.V().HasLabel("Person").Has(gripql.Gt("age", "${age}")).Statements,
+		},
+		{
+			Name: "owned",
+			Parameters: []*gripql.QueryParameter{
+				{Name: "id", Type: gripql.FieldType_STRING, DefaultValue: structpb.NewStringValue("p1"), Description: "owner"},
+			},
+			Query: gripql.V("${id}").OutE("owns").Statements,
+		},
+		{Name: "names", Query: gripql.V().HasLabel("Person").As("p").Render(map[string]interface{}{"name": "$p.name"}).Statements},
+		{Name: "total", Query: gripql.V().Count().Statements},
+		// hidden by the query field of the same name
+		{Name: "ok", Query: gripql.V().Statements},
+	}
+	for _, def := range defs {
+		def.Graph = testGraph
+		if err := client.AddStoredQuery(def); err != nil {
+			t.Fatal(err)
+		}
+	}
+	queries, _ := StoredQueries(context.Background(), client, testGraph)
+
+	query := graphql.NewObject(graphql.ObjectConfig{
+		Name:   "Query",
+		Fields: graphql.Fields{"ok": &graphql.Field{Type: graphql.Boolean}},
+	})
+	AddStoredQueryFields(query, client, testGraph, queries)
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{Query: query})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if query.Fields()["ok"].Type != graphql.Boolean {
+		t.Error("query field replaced by a stored query")
+	}
+	if arg := query.Fields()["owned"].Args[0]; arg.Type != graphql.String || arg.DefaultValue != "p1" || arg.Description() != "owner" {
+		t.Errorf("unexpected argument: %+v", arg)
+	}
+
+	tests := []struct {
+		query    string
+		expected interface{}
+	}{
+		{`{ olderThan(age: 35) }`, []interface{}{map[string]interface{}{"id": "p2", "name": "bob", "age": 40.0}}},
+		{`{ owned }`, []interface{}{map[string]interface{}{"id": "e1", "from": "p1", "to": "d1", "since": 2020.0}}},
+		{`{ owned(id: "p2") }`, []interface{}{}},
+		{`{ names }`, []interface{}{map[string]interface{}{"name": "alice"}, map[string]interface{}{"name": "bob"}}},
+		{`{ total }`, []interface{}{3.0}},
+	}
+	for _, test := range tests {
+		res := runQuery(schema, test.query)
+		if res.HasErrors() {
+			t.Errorf("%s: %v", test.query, res.Errors)
+			continue
+		}
+		out := res.Data.(map[string]interface{})
+		for _, v := range out {
+			if !reflect.DeepEqual(jsonValue(t, v), test.expected) {
+				t.Errorf("%s: unexpected result %v", test.query, jsonValue(t, v))
+			}
+		}
+	}
+
+	// parameters without a default are required
+	if res := runQuery(schema, `{ olderThan }`); !res.HasErrors() {
+		t.Error("expected a missing parameter to fail")
+	}
+}
@@ -0,0 +1,358 @@
+package graphqlutil
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bmeg/grip/log"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+	"golang.org/x/net/websocket"
+	"google.golang.org/grpc/metadata"
+)
+
+// Websocket subprotocols. graphql-transport-ws is the protocol of the
+// graphql-ws library, graphql-ws is the protocol of the older
+// subscriptions-transport-ws library
+const (
+	transportWS = "graphql-transport-ws"
+	legacyWS    = "graphql-ws"
+)
+
+// how long a client has to send connection_init after connecting
+const wsInitTimeout = 10 * time.Second
+
+// Close codes of the graphql-transport-ws protocol
+const (
+	closeBadRequest   = 4400
+	closeUnauthorized = 4401
+	closeInitTimeout  = 4408
+	closeDuplicateID  = 4409
+	closeTooManyInits = 4429
+)
+
+type wsMessage struct {
+	ID      string          `json:"id,omitempty"`
+	Type    string          `json:"type"`
+	Payload json.RawMessage `json:"payload,omitempty"`
+}
+
+type wsRequest struct {
+	Query         string                 `json:"query"`
+	Variables     map[string]interface{} `json:"variables"`
+	OperationName string                 `json:"operationName"`
+}
+
+// message types of the legacy protocol that are named differently
+var legacyReceived = map[string]string{"start": "subscribe", "stop": "complete"}
+var legacySent = map[string]string{"next": "data"}
+
+// IsWebSocket reports if a request opens a websocket
+func IsWebSocket(request *http.Request) bool {
+	return strings.EqualFold(request.Header.Get("Upgrade"), "websocket")
+}
+
+// SchemaFunc loads the GraphQL schema of a graph when an operation is run
+type SchemaFunc func(ctx context.Context, graph string) (*graphql.Schema, error)
+
+// wsConnection runs the operations sent over a websocket
+type wsConnection struct {
+	ws       *websocket.Conn
+	protocol string
+	schema   SchemaFunc
+	graph    string
+	ctx      context.Context
+	writeMu  sync.Mutex
+	mu       sync.Mutex
+	ops      map[string]context.CancelFunc
+}
+
+// ServeWebSocket runs queries, mutations and subscriptions sent with the
+// graphql-transport-ws or graphql-ws protocols, against the schema of the
+// graph loaded by schema
+func ServeWebSocket(writer http.ResponseWriter, request *http.Request, graph string, schema SchemaFunc) {
+	// the handshake reads the location of the websocket from the URL, which
+	// has had the endpoint prefix removed
+	if u, err := url.ParseRequestURI(request.RequestURI); err == nil {
+		request = request.Clone(request.Context())
+		request.URL = u
+	}
+	websocket.Server{
+		Handshake: func(config *websocket.Config, req *http.Request) error {
+			for _, p := range config.Protocol {
+				if p == transportWS || p == legacyWS {
+					config.Protocol = []string{p}
+					return nil
+				}
+			}
+			return fmt.Errorf("unsupported websocket subprotocol: %v", config.Protocol)
+		},
+		Handler: func(ws *websocket.Conn) {
+			ctx, cancel := context.WithCancel(request.Context())
+			defer cancel()
+			conn := &wsConnection{
+				ws:       ws,
+				protocol: ws.Config().Protocol[0],
+				schema:   schema,
+				graph:    graph,
+				ctx:      ctx,
+				ops:      map[string]context.CancelFunc{},
+			}
+			conn.run()
+		},
+	}.ServeHTTP(writer, request)
+}
+
+func (c *wsConnection) send(msg wsMessage) error {
+	if c.protocol == legacyWS {
+		if t, ok := legacySent[msg.Type]; ok {
+			msg.Type = t
+		}
+	}
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	return websocket.JSON.Send(c.ws, msg)
+}
+
+func (c *wsConnection) sendPayload(id string, msgType string, payload interface{}) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	return c.send(wsMessage{ID: id, Type: msgType, Payload: data})
+}
+
+// sendErrors reports errors found before an operation starts. The legacy
+// protocol sends a single error
+func (c *wsConnection) sendErrors(id string, errs []gqlerrors.FormattedError) error {
+	if c.protocol == legacyWS && len(errs) > 0 {
+		return c.sendPayload(id, "error", errs[0])
+	}
+	return c.sendPayload(id, "error", errs)
+}
+
+// close ends the connection with a close code of the protocol
+func (c *wsConnection) close(code int, reason string) {
+	log.WithFields(log.Fields{"graph": c.graph, "code": code, "reason": reason}).Info("GraphQL websocket closed")
+	frame := make([]byte, 2, 2+len(reason))
+	binary.BigEndian.PutUint16(frame, uint16(code))
+	frame = append(frame, reason...)
+	c.writeMu.Lock()
+	c.ws.PayloadType = websocket.CloseFrame
+	c.ws.Write(frame)
+	c.writeMu.Unlock()
+	c.ws.Close()
+}
+
+// run reads messages until the client disconnects
+func (c *wsConnection) run() {
+	messages := make(chan wsMessage)
+	done := c.ctx.Done()
+	go func() {
+		defer close(messages)
+		for {
+			var data []byte
+			if err := websocket.Message.Receive(c.ws, &data); err != nil {
+				return
+			}
+			var msg wsMessage
+			if err := json.Unmarshal(data, &msg); err != nil {
+				msg = wsMessage{Type: "invalid"}
+			}
+			select {
+			case messages <- msg:
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	initialized := false
+	timeout := time.NewTimer(wsInitTimeout)
+	defer timeout.Stop()
+	for {
+		var msg wsMessage
+		var ok bool
+		select {
+		case msg, ok = <-messages:
+			if !ok {
+				return
+			}
+		case <-timeout.C:
+			if !initialized {
+				c.close(closeInitTimeout, "Connection initialisation timeout")
+				return
+			}
+			continue
+		}
+		if c.protocol == legacyWS {
+			if t, ok := legacyReceived[msg.Type]; ok {
+				msg.Type = t
+			}
+		}
+
+		switch msg.Type {
+		case "connection_init":
+			if initialized {
+				c.close(closeTooManyInits, "Too many initialisation requests")
+				return
+			}
+			initialized = true
+			c.ctx = withInitCredentials(c.ctx, msg.Payload)
+			c.send(wsMessage{Type: "connection_ack"})
+			if c.protocol == legacyWS {
+				c.send(wsMessage{Type: "ka"})
+			}
+
+		case "ping":
+			c.send(wsMessage{Type: "pong", Payload: msg.Payload})
+
+		case "pong":
+
+		case "subscribe":
+			if !initialized {
+				c.close(closeUnauthorized, "Unauthorized")
+				return
+			}
+			req := wsRequest{}
+			if msg.ID == "" || json.Unmarshal(msg.Payload, &req) != nil {
+				c.close(closeBadRequest, "Invalid subscribe message")
+				return
+			}
+			c.mu.Lock()
+			if _, exists := c.ops[msg.ID]; exists {
+				c.mu.Unlock()
+				c.close(closeDuplicateID, fmt.Sprintf("Subscriber for %s already exists", msg.ID))
+				return
+			}
+			ctx, cancel := context.WithCancel(c.ctx)
+			c.ops[msg.ID] = cancel
+			c.mu.Unlock()
+			go c.execute(ctx, msg.ID, req)
+
+		case "complete":
+			c.mu.Lock()
+			if cancel, ok := c.ops[msg.ID]; ok {
+				cancel()
+				delete(c.ops, msg.ID)
+			}
+			c.mu.Unlock()
+
+		case "connection_terminate":
+			c.ws.Close()
+			return
+
+		default:
+			c.close(closeBadRequest, fmt.Sprintf("Invalid message type %q", msg.Type))
+			return
+		}
+	}
+}
+
+// withInitCredentials uses the Authorization of the connection_init payload
+// for the operations of the connection, as browsers can't set the headers of
+// a websocket request
+func withInitCredentials(ctx context.Context, payload json.RawMessage) context.Context {
+	if md, ok := metadata.FromOutgoingContext(ctx); ok && len(md.Get("authorization")) > 0 {
+		return ctx
+	}
+	params := map[string]interface{}{}
+	json.Unmarshal(payload, &params)
+	for k, v := range params {
+		if auth, ok := v.(string); ok && strings.EqualFold(k, "authorization") {
+			return metadata.AppendToOutgoingContext(ctx, "authorization", auth)
+		}
+	}
+	return ctx
+}
+
+// operationType finds the type of the operation that will be run
+func operationType(doc *ast.Document, name string) string {
+	for _, d := range doc.Definitions {
+		if op, ok := d.(*ast.OperationDefinition); ok {
+			if name == "" || (op.Name != nil && op.Name.Value == name) {
+				return op.Operation
+			}
+		}
+	}
+	return ""
+}
+
+// execute runs an operation, sending its results until it ends or the client
+// completes it
+func (c *wsConnection) execute(ctx context.Context, id string, req wsRequest) {
+	defer func() {
+		c.mu.Lock()
+		if cancel, ok := c.ops[id]; ok {
+			cancel()
+			delete(c.ops, id)
+		}
+		c.mu.Unlock()
+	}()
+
+	schema, err := c.schema(ctx, c.graph)
+	if err != nil || schema == nil {
+		c.sendErrors(id, []gqlerrors.FormattedError{gqlerrors.NewFormattedError("No GraphQL handler found for graph: " + c.graph)})
+		return
+	}
+	// errors found before the operation starts are sent in an error message
+	src := source.NewSource(&source.Source{Body: []byte(req.Query), Name: "GraphQL request"})
+	doc, err := parser.Parse(parser.ParseParams{Source: src})
+	if err != nil {
+		c.sendErrors(id, gqlerrors.FormatErrors(err))
+		return
+	}
+	if res := graphql.ValidateDocument(schema, doc, nil); !res.IsValid {
+		c.sendErrors(id, res.Errors)
+		return
+	}
+
+	params := graphql.Params{
+		Schema:         *schema,
+		RequestString:  req.Query,
+		VariableValues: req.Variables,
+		OperationName:  req.OperationName,
+		Context:        ctx,
+	}
+	if operationType(doc, req.OperationName) != ast.OperationTypeSubscription {
+		res := graphql.Do(params)
+		if ctx.Err() == nil {
+			c.sendPayload(id, "next", res)
+			c.send(wsMessage{ID: id, Type: "complete"})
+		}
+		return
+	}
+
+	results := graphql.Subscribe(params)
+	for {
+		select {
+		case res, ok := <-results:
+			if !ok {
+				if ctx.Err() == nil {
+					c.send(wsMessage{ID: id, Type: "complete"})
+				}
+				return
+			}
+			if err := c.sendPayload(id, "next", res); err != nil {
+				return
+			}
+		case <-ctx.Done():
+			// the subscription stops sending once it sees the context is done
+			go func() {
+				for range results {
+				}
+			}()
+			return
+		}
+	}
+}
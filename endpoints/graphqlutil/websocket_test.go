@@ -0,0 +1,381 @@
+package graphqlutil
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bmeg/grip/gripql"
+	"github.com/graphql-go/graphql"
+	"golang.org/x/net/websocket"
+	"google.golang.org/grpc/metadata"
+)
+
+// newWebSocketSchema builds a GraphQL schema with the mutations of the test
+// schema, a query that returns the authorization sent with the operation,
+// and subscriptions that count to n, or wait until they are stopped
+func newWebSocketSchema(t *testing.T, client gripql.Client, waits *waitSignals) *graphql.Schema {
+	objects := map[string]*graphql.Object{}
+	for _, name := range []string{"Person", "Dog"} {
+		objects[name] = graphql.NewObject(graphql.ObjectConfig{
+			Name: name,
+			Fields: graphql.Fields{
+				"id":   &graphql.Field{Type: graphql.String},
+				"name": &graphql.Field{Type: graphql.String},
+			},
+		})
+	}
+	source := func(p graphql.ResolveParams) (interface{}, error) {
+		return p.Source, nil
+	}
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"authorization": &graphql.Field{
+					Type: graphql.String,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						md, _ := metadata.FromOutgoingContext(p.Context)
+						return strings.Join(md.Get("authorization"), ","), nil
+					},
+				},
+			},
+		}),
+		Mutation: BuildMutationObject(client, testGraph, testSchema(t), objects),
+		Subscription: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Subscription",
+			Fields: graphql.Fields{
+				"count": &graphql.Field{
+					Type: graphql.Int,
+					Args: graphql.FieldConfigArgument{"n": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.Int)}},
+					Subscribe: func(p graphql.ResolveParams) (interface{}, error) {
+						out := make(chan interface{})
+						go func() {
+							defer close(out)
+							for i := 1; i <= p.Args["n"].(int); i++ {
+								select {
+								case out <- i:
+								case <-p.Context.Done():
+									return
+								}
+							}
+						}()
+						return out, nil
+					},
+					Resolve: source,
+				},
+				"wait": &graphql.Field{
+					Type: graphql.Int,
+					Subscribe: func(p graphql.ResolveParams) (interface{}, error) {
+						out := make(chan interface{})
+						if waits != nil {
+							close(waits.started)
+						}
+						go func() {
+							<-p.Context.Done()
+							if waits != nil {
+								close(waits.stopped)
+							}
+							close(out)
+						}()
+						return out, nil
+					},
+					Resolve: source,
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &schema
+}
+
+// waitSignals report when the wait subscription starts and stops
+type waitSignals struct {
+	started, stopped chan struct{}
+}
+
+// recorder keeps the bytes read from a connection, to find the close frame
+// that the websocket package hides
+type recorder struct {
+	net.Conn
+	mu   sync.Mutex
+	on   bool
+	data bytes.Buffer
+}
+
+func (r *recorder) Read(p []byte) (int, error) {
+	n, err := r.Conn.Read(p)
+	r.mu.Lock()
+	if r.on {
+		r.data.Write(p[:n])
+	}
+	r.mu.Unlock()
+	return n, err
+}
+
+type wsClient struct {
+	t    *testing.T
+	ws   *websocket.Conn
+	conn *recorder
+}
+
+// startWebSocket starts a websocket endpoint for the schema
+func startWebSocket(t *testing.T, schema *graphql.Schema) *httptest.Server {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ServeWebSocket(w, r, testGraph, func(ctx context.Context, graph string) (*graphql.Schema, error) {
+			return schema, nil
+		})
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// dialWebSocket starts a websocket endpoint for the schema, and connects to
+// it with the subprotocol
+func dialWebSocket(t *testing.T, schema *graphql.Schema, protocol string) *wsClient {
+	srv := startWebSocket(t, schema)
+	config, err := websocket.NewConfig("ws"+strings.TrimPrefix(srv.URL, "http")+"/graphql", srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	config.Protocol = []string{protocol}
+	conn, err := net.Dial("tcp", srv.Listener.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	conn.SetDeadline(time.Now().Add(5 * time.Second))
+	rec := &recorder{Conn: conn}
+	ws, err := websocket.NewClient(config, rec)
+	if err != nil {
+		conn.Close()
+		t.Fatal(err)
+	}
+	rec.mu.Lock()
+	rec.on = true
+	rec.mu.Unlock()
+	t.Cleanup(func() { ws.Close() })
+	return &wsClient{t: t, ws: ws, conn: rec}
+}
+
+func (c *wsClient) send(id, msgType string, payload interface{}) {
+	msg := wsMessage{ID: id, Type: msgType}
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			c.t.Fatal(err)
+		}
+		msg.Payload = data
+	}
+	if err := websocket.JSON.Send(c.ws, msg); err != nil {
+		c.t.Fatal(err)
+	}
+}
+
+func (c *wsClient) receive() wsMessage {
+	var msg wsMessage
+	if err := websocket.JSON.Receive(c.ws, &msg); err != nil {
+		c.t.Fatal(err)
+	}
+	return msg
+}
+
+// expect receives a message, and checks its id and type
+func (c *wsClient) expect(id, msgType string) wsMessage {
+	c.t.Helper()
+	msg := c.receive()
+	if msg.ID != id || msg.Type != msgType {
+		c.t.Fatalf("expected %s message for %q, got %s for %q: %s", msgType, id, msg.Type, msg.ID, msg.Payload)
+	}
+	return msg
+}
+
+// closeCode reads until the server closes the connection, and returns the
+// code of its close frame
+func (c *wsClient) closeCode() int {
+	var data []byte
+	for websocket.Message.Receive(c.ws, &data) == nil {
+	}
+	c.conn.mu.Lock()
+	b := c.conn.data.Bytes()
+	c.conn.mu.Unlock()
+	// frames sent by a server are not masked
+	for len(b) >= 2 {
+		n, h := int(b[1]&0x7f), 2
+		switch n {
+		case 126:
+			n, h = int(binary.BigEndian.Uint16(b[2:])), 4
+		case 127:
+			n, h = int(binary.BigEndian.Uint64(b[2:])), 10
+		}
+		if len(b) < h+n {
+			break
+		}
+		if b[0]&0x0f == websocket.CloseFrame && n >= 2 {
+			return int(binary.BigEndian.Uint16(b[h:]))
+		}
+		b = b[h+n:]
+	}
+	return 0
+}
+
+func TestWebSocketOperations(t *testing.T) {
+	_, client := newTestServer(t)
+	schema := newWebSocketSchema(t, client, nil)
+	c := dialWebSocket(t, schema, transportWS)
+
+	c.send("", "connection_init", map[string]interface{}{"Authorization": "Bearer token"})
+	c.expect("", "connection_ack")
+	c.send("", "ping", nil)
+	c.expect("", "pong")
+
+	// the authorization of connection_init is used for operations
+	c.send("1", "subscribe", map[string]interface{}{"query": "{ authorization }"})
+	msg := c.expect("1", "next")
+	if !strings.Contains(string(msg.Payload), `"authorization":"Bearer token"`) {
+		t.Errorf("unexpected result: %s", msg.Payload)
+	}
+	c.expect("1", "complete")
+
+	c.send("2", "subscribe", map[string]interface{}{
+		"query":     `mutation ($name: String) { createPerson(id: "p1", data: {name: $name}) { id } }`,
+		"variables": map[string]interface{}{"name": "alice"},
+	})
+	c.expect("2", "next")
+	c.expect("2", "complete")
+	if v, err := client.GetVertex(testGraph, "p1"); err != nil || v.GetDataMap()["name"] != "alice" {
+		t.Errorf("vertex not written: %v %v", v, err)
+	}
+
+	c.send("3", "subscribe", map[string]interface{}{"query": "subscription { count(n: 3) }"})
+	for i := 1; i <= 3; i++ {
+		msg := c.expect("3", "next")
+		res := struct{ Data struct{ Count int } }{}
+		if err := json.Unmarshal(msg.Payload, &res); err != nil || res.Data.Count != i {
+			t.Errorf("expected %d, got %s", i, msg.Payload)
+		}
+	}
+	c.expect("3", "complete")
+
+	// invalid operations are reported before they start
+	c.send("4", "subscribe", map[string]interface{}{"query": "{ missing }"})
+	msg = c.expect("4", "error")
+	errs := []map[string]interface{}{}
+	if err := json.Unmarshal(msg.Payload, &errs); err != nil || len(errs) != 1 {
+		t.Errorf("unexpected errors: %s", msg.Payload)
+	}
+}
+
+func TestWebSocketComplete(t *testing.T) {
+	_, client := newTestServer(t)
+	waits := &waitSignals{started: make(chan struct{}), stopped: make(chan struct{})}
+	schema := newWebSocketSchema(t, client, waits)
+	c := dialWebSocket(t, schema, transportWS)
+
+	c.send("", "connection_init", nil)
+	c.expect("", "connection_ack")
+	c.send("1", "subscribe", map[string]interface{}{"query": "subscription { wait }"})
+	select {
+	case <-waits.started:
+	case <-time.After(5 * time.Second):
+		t.Fatal("subscription not started")
+	}
+	c.send("1", "complete", nil)
+	select {
+	case <-waits.stopped:
+	case <-time.After(5 * time.Second):
+		t.Fatal("subscription not stopped")
+	}
+
+	// the id can be used again once the operation is completed
+	c.send("1", "subscribe", map[string]interface{}{"query": "{ authorization }"})
+	c.expect("1", "next")
+	c.expect("1", "complete")
+}
+
+func TestWebSocketLegacyProtocol(t *testing.T) {
+	_, client := newTestServer(t)
+	schema := newWebSocketSchema(t, client, nil)
+	c := dialWebSocket(t, schema, legacyWS)
+
+	c.send("", "connection_init", nil)
+	c.expect("", "connection_ack")
+	c.expect("", "ka")
+	c.send("1", "start", map[string]interface{}{"query": "subscription { count(n: 2) }"})
+	c.expect("1", "data")
+	c.expect("1", "data")
+	c.expect("1", "complete")
+
+	// the legacy protocol sends a single error
+	c.send("2", "start", map[string]interface{}{"query": "{ missing }"})
+	msg := c.expect("2", "error")
+	e := map[string]interface{}{}
+	if err := json.Unmarshal(msg.Payload, &e); err != nil || e["message"] == nil {
+		t.Errorf("unexpected error: %s", msg.Payload)
+	}
+}
+
+func TestWebSocketProtocolErrors(t *testing.T) {
+	_, client := newTestServer(t)
+	tests := []struct {
+		name     string
+		messages []wsMessage
+		code     int
+	}{
+		{"subscribe before init", []wsMessage{
+			{ID: "1", Type: "subscribe"},
+		}, closeUnauthorized},
+		{"init twice", []wsMessage{
+			{Type: "connection_init"},
+			{Type: "connection_init"},
+		}, closeTooManyInits},
+		{"duplicate id", []wsMessage{
+			{Type: "connection_init"},
+			{ID: "1", Type: "subscribe"},
+			{ID: "1", Type: "subscribe"},
+		}, closeDuplicateID},
+		{"no id", []wsMessage{
+			{Type: "connection_init"},
+			{Type: "subscribe"},
+		}, closeBadRequest},
+		{"unknown type", []wsMessage{
+			{Type: "connection_init"},
+			{Type: "start"},
+		}, closeBadRequest},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			schema := newWebSocketSchema(t, client, nil)
+			c := dialWebSocket(t, schema, transportWS)
+			for _, msg := range test.messages {
+				var payload interface{}
+				if msg.Type == "subscribe" {
+					payload = map[string]interface{}{"query": "subscription { wait }"}
+				}
+				c.send(msg.ID, msg.Type, payload)
+			}
+			if code := c.closeCode(); code != test.code {
+				t.Errorf("expected close code %d, got %d", test.code, code)
+			}
+		})
+	}
+
+	// unknown subprotocols are refused
+	schema := newWebSocketSchema(t, client, nil)
+	srv := startWebSocket(t, schema)
+	config, _ := websocket.NewConfig("ws"+strings.TrimPrefix(srv.URL, "http"), srv.URL)
+	config.Protocol = []string{"unknown"}
+	if ws, err := websocket.DialConfig(config); err == nil {
+		ws.Close()
+		t.Error("expected an unknown subprotocol to be refused")
+	}
+}
@@ -0,0 +1,370 @@
+package main
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/bmeg/grip/endpoints/graphqlutil"
+	"github.com/bmeg/grip/gripql"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+const ARG_AFTER = "after"
+const ARG_BEFORE = "before"
+const ARG_LAST = "last"
+const ARG_ORDER_BY = "orderBy"
+
+// cursor is the position of a vertex in a connection. It holds the values
+// of the sort fields, so the next page can start after it without skipping
+// over the previous pages
+type cursor struct {
+	Order  []string      `json:"order"`
+	Values []interface{} `json:"values"`
+}
+
+func encodeCursor(c cursor) string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func decodeCursor(s string, order []string) ([]interface{}, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor: %s", s)
+	}
+	c := cursor{}
+	if err := json.Unmarshal(b, &c); err != nil {
+		return nil, fmt.Errorf("invalid cursor: %s", s)
+	}
+	if !reflect.DeepEqual(c.Order, order) || len(c.Values) != len(order) {
+		return nil, fmt.Errorf("cursor %s was made with a different orderBy", s)
+	}
+	return c.Values, nil
+}
+
+// sortKey is a field of the sort order of a connection
+type sortKey struct {
+	field      string
+	descending bool
+}
+
+func (k sortKey) String() string {
+	if k.descending {
+		return "-" + k.field
+	}
+	return k.field
+}
+
+// sortKeys reads the orderBy argument. The gid is always the last key, so the
+// order of the vertices is stable
+func sortKeys(args map[string]interface{}) []sortKey {
+	keys := []sortKey{}
+	hasGid := false
+	if orders, ok := args[ARG_ORDER_BY].([]interface{}); ok {
+		for _, o := range orders {
+			m, ok := o.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			field, _ := m["field"].(string)
+			if field == "id" {
+				field = "_gid"
+				hasGid = true
+			}
+			keys = append(keys, sortKey{field: field, descending: m["direction"] == "DESC"})
+		}
+	}
+	if !hasGid {
+		keys = append(keys, sortKey{field: "_gid"})
+	}
+	return keys
+}
+
+// mergeRows assembles rendered rows into nested objects. A row is made for
+// each path through the edge fields, so elements are merged by their gid
+func mergeRows(rows []map[string]interface{}, rt *renderTree) []map[string]interface{} {
+	out := []map[string]interface{}{}
+	nodes := map[string]map[string]interface{}{}
+	for _, values := range rows {
+		keys := map[string]string{}
+		for _, r := range rt.fields {
+			gid, _ := values[r+"_gid"].(string)
+			d, ok := values[r+"_data"].(map[string]interface{})
+			if gid == "" || !ok {
+				continue
+			}
+			key := gid
+			parent, hasParent := rt.parent[r]
+			if hasParent {
+				pkey, ok := keys[parent]
+				if !ok {
+					continue
+				}
+				key = pkey + "\x00" + rt.fieldName[r] + "\x00" + gid
+			}
+			keys[r] = key
+			if _, ok := nodes[key]; ok {
+				continue
+			}
+			d["id"] = gid
+			nodes[key] = d
+			if !hasParent {
+				out = append(out, d)
+			} else {
+				p := nodes[keys[parent]]
+				l, _ := p[rt.fieldName[r]].([]interface{})
+				p[rt.fieldName[r]] = append(l, d)
+			}
+		}
+	}
+	return out
+}
+
+// nodeFields finds the selections of edges.node in a connection query
+func nodeFields(fields []*ast.Field) []*ast.Field {
+	out := []*ast.Field{}
+	for _, f := range fields {
+		if f.SelectionSet == nil {
+			continue
+		}
+		for _, s := range f.SelectionSet.Selections {
+			if e, ok := s.(*ast.Field); ok && e.Name.Value == "edges" && e.SelectionSet != nil {
+				for _, n := range e.SelectionSet.Selections {
+					if node, ok := n.(*ast.Field); ok && node.Name.Value == "node" && node.SelectionSet != nil {
+						out = append(out, node)
+					}
+				}
+			}
+		}
+	}
+	return out
+}
+
+// buildConnectionFields creates a Relay style connection field for each
+// vertex type. Pages are selected with first/after or last/before, and
+// ordered by the orderBy argument and then by gid
+func buildConnectionFields(client gripql.Client, graph string, objects *objectMap) graphql.Fields {
+	pageInfo := graphql.NewObject(graphql.ObjectConfig{
+		Name: "PageInfo",
+		Fields: graphql.Fields{
+			"hasNextPage":     &graphql.Field{Type: graphql.NewNonNull(graphql.Boolean)},
+			"hasPreviousPage": &graphql.Field{Type: graphql.NewNonNull(graphql.Boolean)},
+			"startCursor":     &graphql.Field{Type: graphql.String},
+			"endCursor":       &graphql.Field{Type: graphql.String},
+		},
+	})
+	direction := graphql.NewEnum(graphql.EnumConfig{
+		Name: "SortDirection",
+		Values: graphql.EnumValueConfigMap{
+			"ASC":  &graphql.EnumValueConfig{Value: "ASC"},
+			"DESC": &graphql.EnumValueConfig{Value: "DESC"},
+		},
+	})
+
+	connectionFields := graphql.Fields{}
+	for objName, obj := range objects.objects {
+		label := obj.Name()
+
+		orderFields := graphql.EnumValueConfigMap{}
+		for k, v := range obj.Fields() {
+			switch v.Type {
+			case graphql.String, graphql.Int, graphql.Float, graphql.Boolean, graphqlutil.DateTimeScalar:
+				orderFields[k] = &graphql.EnumValueConfig{Value: k}
+			}
+		}
+		order := graphql.NewInputObject(graphql.InputObjectConfig{
+			Name: objName + "Order",
+			Fields: graphql.InputObjectConfigFieldMap{
+				"field": &graphql.InputObjectFieldConfig{
+					Type: graphql.NewNonNull(graphql.NewEnum(graphql.EnumConfig{
+						Name:   objName + "OrderField",
+						Values: orderFields,
+					})),
+				},
+				"direction": &graphql.InputObjectFieldConfig{Type: direction, DefaultValue: "ASC"},
+			},
+		})
+		edge := graphql.NewObject(graphql.ObjectConfig{
+			Name: objName + "Edge",
+			Fields: graphql.Fields{
+				"node":   &graphql.Field{Type: obj},
+				"cursor": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			},
+		})
+		connection := graphql.NewObject(graphql.ObjectConfig{
+			Name: objName + "Connection",
+			Fields: graphql.Fields{
+				"edges":    &graphql.Field{Type: graphql.NewList(edge)},
+				"pageInfo": &graphql.Field{Type: graphql.NewNonNull(pageInfo)},
+				"totalCount": &graphql.Field{
+					Type: graphql.Int,
+					// the count is only run when it is requested
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						src, _ := p.Source.(map[string]interface{})
+						q, ok := src["countQuery"].(*gripql.Query)
+						if !ok {
+							return nil, nil
+						}
+						result, err := client.Traversal(&gripql.GraphQuery{Graph: graph, Query: q.Count().Statements})
+						if err != nil {
+							return nil, err
+						}
+						count := 0
+						for r := range result {
+							count = int(r.GetCount())
+						}
+						return count, nil
+					},
+				},
+			},
+		})
+
+		args := graphql.FieldConfigArgument{
+			ARG_LIMIT:    &graphql.ArgumentConfig{Type: graphql.Int},
+			ARG_AFTER:    &graphql.ArgumentConfig{Type: graphql.String},
+			ARG_LAST:     &graphql.ArgumentConfig{Type: graphql.Int},
+			ARG_BEFORE:   &graphql.ArgumentConfig{Type: graphql.String},
+			ARG_ORDER_BY: &graphql.ArgumentConfig{Type: graphql.NewList(graphql.NewNonNull(order))},
+			ARG_FILTER:   &graphql.ArgumentConfig{Type: graphqlutil.JSONScalar},
+		}
+		for k, v := range obj.Fields() {
+			switch v.Type {
+			case graphql.String, graphql.Int, graphql.Float, graphql.Boolean, graphqlutil.DateTimeScalar:
+				args[k] = &graphql.ArgumentConfig{Type: v.Type}
+			}
+		}
+
+		connectionFields[objName+"Connection"] = &graphql.Field{
+			Name: objName + "Connection",
+			Type: connection,
+			Args: args,
+			Resolve: func(params graphql.ResolveParams) (interface{}, error) {
+				first, hasFirst := params.Args[ARG_LIMIT].(int)
+				last, backward := params.Args[ARG_LAST].(int)
+				if hasFirst && backward {
+					return nil, fmt.Errorf("only one of first and last can be used")
+				}
+				size := first
+				if backward {
+					size = last
+				} else if !hasFirst {
+					size = 100
+				}
+				if size < 0 {
+					return nil, fmt.Errorf("page size can't be negative")
+				}
+
+				q := gripql.V().HasLabel(label)
+				for key, val := range params.Args {
+					switch key {
+					case ARG_LIMIT, ARG_AFTER, ARG_LAST, ARG_BEFORE, ARG_ORDER_BY, ARG_FILTER:
+					default:
+						q = q.Has(gripql.Eq(key, val))
+					}
+				}
+				if filterArg, ok := params.Args[ARG_FILTER].(map[string]any); ok {
+					var err error
+					q, err = NewFilterBuilder(filterArg).ExtendGrip(q)
+					if err != nil {
+						return nil, err
+					}
+				}
+				countQuery := q
+
+				keys := sortKeys(params.Args)
+				order := []string{}
+				for _, k := range keys {
+					order = append(order, k.String())
+				}
+				// a backward page is read in the reverse order, then flipped
+				seekKeys := keys
+				position, _ := params.Args[ARG_AFTER].(string)
+				if backward {
+					seekKeys = make([]sortKey, len(keys))
+					for i, k := range keys {
+						seekKeys[i] = sortKey{field: k.field, descending: !k.descending}
+					}
+					position, _ = params.Args[ARG_BEFORE].(string)
+				}
+				sortFields := []string{}
+				for _, k := range seekKeys {
+					sortFields = append(sortFields, k.String())
+				}
+				if position != "" {
+					values, err := decodeCursor(position, order)
+					if err != nil {
+						return nil, err
+					}
+					q = q.Seek(sortFields, values)
+				}
+				q = q.Sort(sortFields...).Limit(uint32(size + 1)).As("f0")
+
+				rt := &renderTree{
+					fields:    []string{"f0"},
+					parent:    map[string]string{},
+					fieldName: map[string]string{},
+				}
+				for _, f := range nodeFields(params.Info.FieldASTs) {
+					q = objects.traversalBuild(q, label, f, "f0", rt)
+				}
+				render := map[string]any{}
+				for _, i := range rt.fields {
+					render[i+"_gid"] = "$" + i + "._gid"
+					render[i+"_data"] = "$" + i + "._data"
+				}
+				q = q.Render(render)
+				result, err := client.Traversal(&gripql.GraphQuery{Graph: graph, Query: q.Statements})
+				if err != nil {
+					return nil, err
+				}
+				rows := []map[string]interface{}{}
+				for r := range result {
+					rows = append(rows, r.GetRender().GetStructValue().AsMap())
+				}
+
+				nodes := mergeRows(rows, rt)
+				more := len(nodes) > size
+				if more {
+					nodes = nodes[:size]
+				}
+				if backward {
+					for i, j := 0, len(nodes)-1; i < j; i, j = i+1, j-1 {
+						nodes[i], nodes[j] = nodes[j], nodes[i]
+					}
+				}
+				edges := []interface{}{}
+				for _, n := range nodes {
+					c := cursor{Order: order}
+					for _, k := range keys {
+						if k.field == "_gid" {
+							c.Values = append(c.Values, n["id"])
+						} else {
+							c.Values = append(c.Values, n[k.field])
+						}
+					}
+					edges = append(edges, map[string]interface{}{"node": n, "cursor": encodeCursor(c)})
+				}
+				info := map[string]interface{}{
+					"hasNextPage":     more,
+					"hasPreviousPage": position != "",
+				}
+				if backward {
+					info["hasNextPage"], info["hasPreviousPage"] = position != "", more
+				}
+				if len(edges) > 0 {
+					info["startCursor"] = edges[0].(map[string]interface{})["cursor"]
+					info["endCursor"] = edges[len(edges)-1].(map[string]interface{})["cursor"]
+				}
+				return map[string]interface{}{
+					"edges":      edges,
+					"pageInfo":   info,
+					"countQuery": countQuery,
+				}, nil
+			},
+		}
+	}
+	return connectionFields
+}
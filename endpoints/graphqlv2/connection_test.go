@@ -0,0 +1,224 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/bmeg/grip/config"
+	"github.com/bmeg/grip/gdbi"
+	"github.com/bmeg/grip/gripql"
+	"github.com/bmeg/grip/kvgraph"
+	"github.com/bmeg/grip/server"
+	"github.com/bmeg/grip/util"
+	"github.com/graphql-go/graphql"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+const testGraph = "test"
+
+// newTestSchema starts an in-process server with a graph of people, and
+// builds the GraphQL schema of the graph
+func newTestSchema(t *testing.T) (*graphql.Schema, gripql.Client) {
+	conf := config.DefaultConfig()
+	config.TestifyConfig(conf)
+	tmpDB := "grip.db." + util.RandomString(6)
+	gdb, err := kvgraph.NewKVGraphDB("badger", tmpDB)
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv, err := server.NewGripServer(conf, "./", map[string]gdbi.GraphDB{"badger": gdb})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		gdb.Close()
+		os.RemoveAll(tmpDB)
+		os.RemoveAll(conf.Server.WorkDir)
+	})
+	client := gripql.WrapClient(gripql.NewQueryDirectClient(srv), gripql.NewEditDirectClient(srv), nil, nil)
+	if err := client.AddGraph(testGraph); err != nil {
+		t.Fatal(err)
+	}
+
+	// ages repeat and are missing for some people, so pages split ties
+	for i := 0; i < 12; i++ {
+		data := map[string]interface{}{"name": fmt.Sprintf("person %d", 11-i)}
+		if i%4 != 3 {
+			data["age"] = float64(20 + (i%3)*10)
+		}
+		s, err := structpb.NewStruct(data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := client.AddVertex(testGraph, &gripql.Vertex{Gid: fmt.Sprintf("p%02d", i), Label: "Person", Data: s}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	props, err := structpb.NewStruct(map[string]interface{}{"name": "STRING", "age": "NUMERIC"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	graphSchema := &gripql.Graph{Graph: testGraph, Vertices: []*gripql.Vertex{{Gid: "Person", Label: "Vertex", Data: props}}}
+	schema, err := buildGraphQLSchema(graphSchema, client, testGraph, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return schema, client
+}
+
+type page struct {
+	ids                    []string
+	hasNext, hasPrevious   bool
+	startCursor, endCursor string
+	totalCount             int
+}
+
+// readPage runs a connection query, with the page arguments given as
+// variables
+func readPage(t *testing.T, schema *graphql.Schema, vars map[string]interface{}) (*page, error) {
+	res := graphql.Do(graphql.Params{
+		Schema: *schema,
+		RequestString: `query ($first: Int, $after: String, $last: Int, $before: String, $orderBy: [PersonOrder!]) {
+			PersonConnection(first: $first, after: $after, last: $last, before: $before, orderBy: $orderBy) {
+				totalCount
+				edges { cursor node { id } }
+				pageInfo { hasNextPage hasPreviousPage startCursor endCursor }
+			}
+		}`,
+		VariableValues: vars,
+		Context:        context.Background(),
+	})
+	if res.HasErrors() {
+		return nil, res.Errors[0]
+	}
+	conn := res.Data.(map[string]interface{})["PersonConnection"].(map[string]interface{})
+	info := conn["pageInfo"].(map[string]interface{})
+	p := &page{
+		hasNext:     info["hasNextPage"].(bool),
+		hasPrevious: info["hasPreviousPage"].(bool),
+		totalCount:  conn["totalCount"].(int),
+	}
+	p.startCursor, _ = info["startCursor"].(string)
+	p.endCursor, _ = info["endCursor"].(string)
+	for _, e := range conn["edges"].([]interface{}) {
+		node := e.(map[string]interface{})["node"].(map[string]interface{})
+		p.ids = append(p.ids, node["id"].(string))
+	}
+	return p, nil
+}
+
+func TestConnectionPages(t *testing.T) {
+	schema, _ := newTestSchema(t)
+	orders := []interface{}{
+		nil,
+		[]interface{}{map[string]interface{}{"field": "name"}},
+		[]interface{}{map[string]interface{}{"field": "age", "direction": "DESC"}},
+		[]interface{}{map[string]interface{}{"field": "age"}, map[string]interface{}{"field": "id", "direction": "DESC"}},
+	}
+	for _, orderBy := range orders {
+		// page forward, then back from the end, which must give the same order
+		forward := []string{}
+		after := ""
+		for i := 0; ; i++ {
+			vars := map[string]interface{}{"first": 5, "orderBy": orderBy}
+			if after != "" {
+				vars["after"] = after
+			}
+			p, err := readPage(t, schema, vars)
+			if err != nil {
+				t.Fatalf("%v: %v", orderBy, err)
+			}
+			if p.totalCount != 12 {
+				t.Errorf("%v: unexpected total count %d", orderBy, p.totalCount)
+			}
+			if p.hasPrevious != (i > 0) {
+				t.Errorf("%v: page %d has hasPreviousPage %v", orderBy, i, p.hasPrevious)
+			}
+			forward = append(forward, p.ids...)
+			if !p.hasNext {
+				break
+			}
+			if i > 3 {
+				t.Fatalf("%v: paging did not end", orderBy)
+			}
+			after = p.endCursor
+		}
+		backward := []string{}
+		before := ""
+		for i := 0; ; i++ {
+			vars := map[string]interface{}{"last": 5, "orderBy": orderBy}
+			if before != "" {
+				vars["before"] = before
+			}
+			p, err := readPage(t, schema, vars)
+			if err != nil {
+				t.Fatalf("%v: %v", orderBy, err)
+			}
+			if p.hasNext != (i > 0) {
+				t.Errorf("%v: page %d from the end has hasNextPage %v", orderBy, i, p.hasNext)
+			}
+			backward = append(p.ids, backward...)
+			if !p.hasPrevious {
+				break
+			}
+			if i > 3 {
+				t.Fatalf("%v: paging did not end", orderBy)
+			}
+			before = p.startCursor
+		}
+
+		seen := map[string]bool{}
+		for _, id := range forward {
+			if seen[id] {
+				t.Errorf("%v: %s returned twice", orderBy, id)
+			}
+			seen[id] = true
+		}
+		if len(seen) != 12 {
+			t.Errorf("%v: %d of 12 vertices returned: %v", orderBy, len(seen), forward)
+		}
+		if !reflect.DeepEqual(forward, backward) {
+			t.Errorf("%v: paging forward and backward differ:\n%v\n%v", orderBy, forward, backward)
+		}
+	}
+
+	// the default order is by id
+	p, err := readPage(t, schema, map[string]interface{}{"first": 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(p.ids, []string{"p00", "p01", "p02"}) {
+		t.Errorf("unexpected first page: %v", p.ids)
+	}
+}
+
+func TestConnectionErrors(t *testing.T) {
+	schema, _ := newTestSchema(t)
+	p, err := readPage(t, schema, map[string]interface{}{"first": 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	byName := []interface{}{map[string]interface{}{"field": "name"}}
+	if _, err := readPage(t, schema, map[string]interface{}{"first": 2, "after": p.endCursor, "orderBy": byName}); err == nil {
+		t.Error("expected a cursor of another order to fail")
+	}
+	if _, err := readPage(t, schema, map[string]interface{}{"first": 2, "after": "not a cursor"}); err == nil {
+		t.Error("expected an invalid cursor to fail")
+	}
+	if _, err := readPage(t, schema, map[string]interface{}{"first": 2, "last": 2}); err == nil {
+		t.Error("expected first and last together to fail")
+	}
+	if _, err := readPage(t, schema, map[string]interface{}{"first": -1}); err == nil {
+		t.Error("expected a negative page size to fail")
+	}
+	empty, err := readPage(t, schema, map[string]interface{}{"first": 0})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(empty.ids) != 0 || !empty.hasNext {
+		t.Errorf("unexpected empty page: %+v", empty)
+	}
+}
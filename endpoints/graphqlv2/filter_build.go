@@ -48,31 +48,37 @@ func fieldMap(s string) string {
 	return s
 }
 
-func (fb *FilterBuilder) ExtendGrip(q *gripql.Query) (*gripql.Query, error) {
+// HasExpressions lists the conditions of the filter
+func (fb *FilterBuilder) HasExpressions() []*gripql.HasExpression {
+	out := []*gripql.HasExpression{}
 	if val, ok := isFilterEQ(fb.filter); ok {
 		if vMap, ok := val.(map[string]any); ok {
 			for k, v := range vMap {
-				k = fieldMap(k)
-				q = q.Has(gripql.Eq(k, v))
+				out = append(out, gripql.Eq(fieldMap(k), v))
 			}
 		}
 	}
 	if val, ok := isFilterGT(fb.filter); ok {
 		if vMap, ok := val.(map[string]any); ok {
 			for k, v := range vMap {
-				k = fieldMap(k)
-				q = q.Has(gripql.Gt(k, v))
+				out = append(out, gripql.Gt(fieldMap(k), v))
 			}
 		}
 	}
 	if val, ok := isFilterLT(fb.filter); ok {
 		if vMap, ok := val.(map[string]any); ok {
 			for k, v := range vMap {
-				k = fieldMap(k)
-				q = q.Has(gripql.Lt(k, v))
+				out = append(out, gripql.Lt(fieldMap(k), v))
 			}
 		}
 	}
+	return out
+}
+
+func (fb *FilterBuilder) ExtendGrip(q *gripql.Query) (*gripql.Query, error) {
+	for _, h := range fb.HasExpressions() {
+		q = q.Has(h)
+	}
 	log.Infof("Filter Query %s", q.String())
 	return q, nil
 }
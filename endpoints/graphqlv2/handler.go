@@ -5,25 +5,33 @@ GraphQL Web endpoint
 package main
 
 import (
+	"context"
 	"fmt"
 	"net/http"
+	"sync"
 
+	"github.com/bmeg/grip/endpoints/graphqlutil"
 	"github.com/bmeg/grip/gripql"
 	"github.com/bmeg/grip/log"
+	"github.com/graphql-go/graphql"
 	"github.com/graphql-go/handler"
+	"google.golang.org/grpc/metadata"
 )
 
 // handle the graphql queries for a single endpoint
 type graphHandler struct {
 	graph      string
 	gqlHandler *handler.Handler
+	schema     *graphql.Schema
 	timestamp  string
+	queriesKey string
 	client     gripql.Client
 	//schema     *gripql.Graph
 }
 
 // Handler is a GraphQL endpoint to query the Grip database
 type Handler struct {
+	mu       sync.Mutex
 	handlers map[string]*graphHandler
 	client   gripql.Client
 }
@@ -62,39 +70,70 @@ func (gh *Handler) ServeHTTP(writer http.ResponseWriter, request *http.Request)
 	//pathRE := regexp.MustCompile("/(.+)$")
 	//graphName := pathRE.FindStringSubmatch(request.URL.Path)[1]
 	graphName := request.URL.Path
-	var handler *graphHandler
-	var ok bool
-	if handler, ok = gh.handlers[graphName]; ok {
-		//Call the setup function. If nothing has changed it will return without doing anything
-		err := handler.setup()
-		if err != nil {
-			http.Error(writer, fmt.Sprintf("No GraphQL handler found for graph: %s", graphName), http.StatusInternalServerError)
-			return
-		}
-	} else {
-		//Graph handler was not found, so we'll need to set it up
-		var err error
-		handler, err = newGraphHandler(graphName, gh.client)
-		if err != nil {
-			http.Error(writer, fmt.Sprintf("No GraphQL handler found for graph: %s", graphName), http.StatusInternalServerError)
-			return
-		}
-		gh.handlers[graphName] = handler
+	request = withCredentials(request)
+	if graphqlutil.IsWebSocket(request) {
+		// credentials can be sent after the websocket is opened, so the
+		// schema is loaded when operations are run
+		graphqlutil.ServeWebSocket(writer, request, graphName, gh.graphSchema)
+		return
 	}
-	if handler != nil && handler.gqlHandler != nil {
+	handler, err := gh.graphHandler(request.Context(), graphName)
+	if err == nil && handler.gqlHandler != nil {
 		handler.gqlHandler.ServeHTTP(writer, request)
 	} else {
 		http.Error(writer, fmt.Sprintf("No GraphQL handler found for graph: %s", graphName), http.StatusInternalServerError)
 	}
 }
 
+// graphHandler returns the handler of a graph, loading the schema of the
+// graph if it has changed
+func (gh *Handler) graphHandler(ctx context.Context, graphName string) (*graphHandler, error) {
+	gh.mu.Lock()
+	defer gh.mu.Unlock()
+	if handler, ok := gh.handlers[graphName]; ok {
+		//Call the setup function. If nothing has changed it will return without doing anything
+		if err := handler.setup(ctx); err != nil {
+			return nil, err
+		}
+		return handler, nil
+	}
+	//Graph handler was not found, so we'll need to set it up
+	handler, err := newGraphHandler(ctx, graphName, gh.client)
+	if err != nil {
+		return nil, err
+	}
+	gh.handlers[graphName] = handler
+	return handler, nil
+}
+
+// graphSchema returns the GraphQL schema of a graph
+func (gh *Handler) graphSchema(ctx context.Context, graphName string) (*graphql.Schema, error) {
+	handler, err := gh.graphHandler(ctx, graphName)
+	if err != nil {
+		return nil, err
+	}
+	return handler.schema, nil
+}
+
+// withCredentials passes the Authorization header of the request on to the
+// schema lookups and resolvers, so they are checked by the server's auth
+// interceptors
+func withCredentials(request *http.Request) *http.Request {
+	auth := request.Header.Get("Authorization")
+	if auth == "" {
+		return request
+	}
+	ctx := metadata.AppendToOutgoingContext(request.Context(), "authorization", auth)
+	return request.WithContext(ctx)
+}
+
 // newGraphHandler creates a new graphql handler from schema
-func newGraphHandler(graph string, client gripql.Client) (*graphHandler, error) {
+func newGraphHandler(ctx context.Context, graph string, client gripql.Client) (*graphHandler, error) {
 	o := &graphHandler{
 		graph:  graph,
 		client: client,
 	}
-	err := o.setup()
+	err := o.setup(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -103,26 +142,31 @@ func newGraphHandler(graph string, client gripql.Client) (*graphHandler, error)
 
 // check timestamp to see if schema needs to be updated, and if so
 // rebuild graphql schema
-func (gh *graphHandler) setup() error {
-	ts, _ := gh.client.GetTimestamp(gh.graph)
-	if ts == nil || ts.Timestamp != gh.timestamp {
+func (gh *graphHandler) setup(ctx context.Context) error {
+	ts, _ := gh.client.QueryC.GetTimestamp(ctx, &gripql.GraphID{Graph: gh.graph})
+	queries, queriesKey := graphqlutil.StoredQueries(ctx, gh.client, gh.graph)
+	if ts == nil || ts.Timestamp != gh.timestamp || queriesKey != gh.queriesKey {
 		log.WithFields(log.Fields{"graph": gh.graph}).Info("Reloading GraphQL schema")
-		schema, err := gh.client.GetSchema(gh.graph)
+		schema, err := gh.client.QueryC.GetSchema(ctx, &gripql.GraphID{Graph: gh.graph})
 		if err != nil {
 			log.WithFields(log.Fields{"graph": gh.graph, "error": err}).Error("GetSchema error")
 			return err
 		}
-		gqlSchema, err := buildGraphQLSchema(schema, gh.client, gh.graph)
+		gqlSchema, err := buildGraphQLSchema(schema, gh.client, gh.graph, queries)
 		if err != nil {
 			log.WithFields(log.Fields{"graph": gh.graph, "error": err}).Error("GraphQL schema build failed")
 			gh.gqlHandler = nil
+			gh.schema = nil
 			gh.timestamp = ""
+			gh.queriesKey = ""
 		} else {
 			log.WithFields(log.Fields{"graph": gh.graph}).Info("Built GraphQL schema")
 			gh.gqlHandler = handler.New(&handler.Config{
 				Schema: gqlSchema,
 			})
+			gh.schema = gqlSchema
 			gh.timestamp = ts.Timestamp
+			gh.queriesKey = queriesKey
 		}
 	}
 	return nil
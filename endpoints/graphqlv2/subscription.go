@@ -0,0 +1,209 @@
+package main
+
+import (
+	"context"
+	"io"
+
+	"github.com/bmeg/grip/endpoints/graphqlutil"
+	"github.com/bmeg/grip/engine/logic"
+	"github.com/bmeg/grip/gdbi"
+	"github.com/bmeg/grip/gripql"
+	"github.com/graphql-go/graphql"
+)
+
+var changeTypeEnum = graphql.NewEnum(graphql.EnumConfig{
+	Name:        "ChangeType",
+	Description: "How a vertex was changed",
+	Values: graphql.EnumValueConfigMap{
+		"ADDED":   &graphql.EnumValueConfig{Value: "ADDED"},
+		"UPDATED": &graphql.EnumValueConfig{Value: "UPDATED"},
+		"DELETED": &graphql.EnumValueConfig{Value: "DELETED"},
+	},
+})
+
+var changeTypes = map[gripql.EventType]string{
+	gripql.EventType_ADDED:   "ADDED",
+	gripql.EventType_UPDATED: "UPDATED",
+	gripql.EventType_REMOVED: "DELETED",
+}
+
+// subscriptionConditions reads the arguments of a subscription, which select
+// vertices the same way as the arguments of a query
+func subscriptionConditions(args map[string]interface{}) []*gripql.HasExpression {
+	out := []*gripql.HasExpression{}
+	for key, val := range args {
+		switch key {
+		case ARG_ID:
+			out = append(out, gripql.Eq("_gid", val))
+		case ARG_IDS:
+			ids, _ := val.([]interface{})
+			out = append(out, gripql.Within("_gid", ids...))
+		case ARG_FILTER:
+			if filterArg, ok := val.(map[string]any); ok {
+				out = append(out, NewFilterBuilder(filterArg).HasExpressions()...)
+			}
+		default:
+			out = append(out, gripql.Eq(key, val))
+		}
+	}
+	return out
+}
+
+func matchesConditions(v *gripql.Vertex, conditions []*gripql.HasExpression) bool {
+	trav := &gdbi.BaseTraveler{Current: gdbi.NewElementFromVertex(v)}
+	for _, c := range conditions {
+		if !logic.MatchesHasExpression(trav, c) {
+			return false
+		}
+	}
+	return true
+}
+
+// watchVertices sends the changes to vertices with the label that meet the
+// conditions, until ctx is done. Errors are sent to the subscription, which
+// reports them and ends
+func watchVertices(ctx context.Context, client gripql.Client, graph string, label string, conditions []*gripql.HasExpression) chan interface{} {
+	out := make(chan interface{})
+	send := func(x interface{}) bool {
+		select {
+		case out <- x:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+	go func() {
+		defer close(out)
+		stream, err := client.QueryC.Watch(ctx, &gripql.GraphID{Graph: graph})
+		if err != nil {
+			send(err)
+			return
+		}
+		// read until the server ends the stream, so it isn't left blocked
+		// sending events
+		defer func() {
+			for {
+				if _, err := stream.Recv(); err != nil {
+					return
+				}
+			}
+		}()
+		for {
+			ev, err := stream.Recv()
+			if err != nil {
+				if ctx.Err() == nil {
+					send(err)
+				}
+				return
+			}
+			v := ev.GetVertex()
+			if v == nil || v.Label != label || !matchesConditions(v, conditions) {
+				continue
+			}
+			change := map[string]interface{}{
+				"type": changeTypes[ev.Type],
+				"id":   v.Gid,
+				"node": graphqlutil.VertexResult(v),
+			}
+			if !send(change) {
+				return
+			}
+		}
+	}()
+	return out
+}
+
+// resolveNode loads the edge fields selected on the node of a change. The
+// node of a deleted vertex has no edges
+func resolveNode(client gripql.Client, graph string, objects *objectMap, label string) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		change, _ := p.Source.(map[string]interface{})
+		node := change["node"]
+		id, _ := change["id"].(string)
+		if change["type"] == "DELETED" {
+			return node, nil
+		}
+		rt := &renderTree{
+			fields:    []string{"f0"},
+			parent:    map[string]string{},
+			fieldName: map[string]string{},
+		}
+		q := gripql.V(id).HasLabel(label).As("f0")
+		for _, f := range p.Info.FieldASTs {
+			q = objects.traversalBuild(q, label, f, "f0", rt)
+		}
+		if len(rt.fields) == 1 {
+			return node, nil
+		}
+		render := map[string]any{}
+		for _, i := range rt.fields {
+			render[i+"_gid"] = "$" + i + "._gid"
+			render[i+"_data"] = "$" + i + "._data"
+		}
+		q = q.Render(render)
+		res, err := client.QueryC.Traversal(p.Context, &gripql.GraphQuery{Graph: graph, Query: q.Statements})
+		if err != nil {
+			return nil, err
+		}
+		rows := []map[string]interface{}{}
+		for {
+			r, err := res.Recv()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return nil, err
+			}
+			rows = append(rows, r.GetRender().GetStructValue().AsMap())
+		}
+		if nodes := mergeRows(rows, rt); len(nodes) > 0 {
+			return nodes[0], nil
+		}
+		// the vertex was deleted after the change
+		return node, nil
+	}
+}
+
+// buildSubscriptionObject creates a subscription for each vertex type, which
+// sends the vertices that are added, updated or deleted. Subscriptions take
+// the arguments of the query fields, except for paging
+func buildSubscriptionObject(client gripql.Client, graph string, objects *objectMap) *graphql.Object {
+	subscriptionFields := graphql.Fields{}
+	for objName, obj := range objects.objects {
+		label := obj.Name()
+		change := graphql.NewObject(graphql.ObjectConfig{
+			Name: objName + "Change",
+			Fields: graphql.Fields{
+				"type": &graphql.Field{Type: changeTypeEnum},
+				"id":   &graphql.Field{Type: graphql.String},
+				"node": &graphql.Field{Type: obj, Resolve: resolveNode(client, graph, objects, label)},
+			},
+		})
+		args := buildFieldConfigArgument(obj)
+		delete(args, ARG_LIMIT)
+		delete(args, ARG_OFFSET)
+		subscriptionFields[objName] = &graphql.Field{
+			Name: objName,
+			Type: change,
+			Args: args,
+			Subscribe: func(p graphql.ResolveParams) (interface{}, error) {
+				return watchVertices(p.Context, client, graph, label, subscriptionConditions(p.Args)), nil
+			},
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				if err, ok := p.Source.(error); ok {
+					return nil, err
+				}
+				return p.Source, nil
+			},
+		}
+	}
+	if len(subscriptionFields) == 0 {
+		return nil
+	}
+	return graphql.NewObject(
+		graphql.ObjectConfig{
+			Name:   "Subscription",
+			Fields: subscriptionFields,
+		},
+	)
+}
@@ -0,0 +1,120 @@
+/*
+SPARQL Web endpoint
+*/
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/bmeg/grip/endpoints/sparql/translate"
+	"github.com/bmeg/grip/gripql"
+	"github.com/bmeg/grip/util/export"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// Handler answers SPARQL SELECT queries on the graph named by the request
+// path, such as /sparql/my-graph
+type Handler struct {
+	client gripql.Client
+	conf   *export.RDFConfig
+}
+
+// NewHTTPHandler initilizes a new SPARQL handler. The IRIs of vertices,
+// labels and fields are mapped with the YAML file named by the
+// GRIP_RDF_CONFIG environment variable, in the format used by grip dump
+func NewHTTPHandler(client gripql.Client) (http.Handler, error) {
+	conf, err := export.LoadRDFConfig(os.Getenv("GRIP_RDF_CONFIG"))
+	if err != nil {
+		return nil, err
+	}
+	return &Handler{client: client, conf: conf}, nil
+}
+
+func httpError(writer http.ResponseWriter, code int, err error) {
+	log.Printf("SPARQL error: %s", err)
+	http.Error(writer, err.Error(), code)
+}
+
+// readQuery gets the query from the url, a form, or a
+// application/sparql-query body
+func readQuery(request *http.Request) (string, error) {
+	if request.Method == http.MethodGet {
+		return request.URL.Query().Get("query"), nil
+	}
+	if request.Method != http.MethodPost {
+		return "", fmt.Errorf("method %s is not supported", request.Method)
+	}
+	if strings.HasPrefix(request.Header.Get("Content-Type"), "application/sparql-query") {
+		body, err := ioutil.ReadAll(request.Body)
+		return string(body), err
+	}
+	return request.FormValue("query"), nil
+}
+
+// ServeHTTP responds to SPARQL protocol requests with SPARQL JSON results
+func (h *Handler) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	graphName := strings.Trim(request.URL.Path, "/")
+	src, err := readQuery(request)
+	if err != nil {
+		httpError(writer, http.StatusMethodNotAllowed, err)
+		return
+	}
+	if src == "" {
+		httpError(writer, http.StatusBadRequest, fmt.Errorf("no query was given"))
+		return
+	}
+	query, err := translate.Parse(src)
+	if err != nil {
+		httpError(writer, http.StatusBadRequest, err)
+		return
+	}
+	labels, err := h.client.ListLabels(graphName)
+	if err != nil {
+		httpError(writer, http.StatusNotFound, err)
+		return
+	}
+	edgeLabels := map[string]bool{}
+	for _, l := range labels.EdgeLabels {
+		edgeLabels[l] = true
+	}
+	plan, err := translate.Translate(query, h.conf, edgeLabels)
+	if err != nil {
+		httpError(writer, http.StatusBadRequest, err)
+		return
+	}
+	log.Printf("SPARQL Query: %s = %s", graphName, plan.Query.String())
+
+	ctx, cancel := context.WithCancel(request.Context())
+	defer cancel()
+	client, err := h.client.QueryC.Traversal(ctx, &gripql.GraphQuery{Graph: graphName, Query: plan.Query.Statements})
+	if err != nil {
+		httpError(writer, http.StatusInternalServerError, err)
+		return
+	}
+	results := translate.NewResults(plan, h.conf)
+	for {
+		row, err := client.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			httpError(writer, http.StatusInternalServerError, err)
+			return
+		}
+		render, _ := row.GetRender().AsInterface().(map[string]interface{})
+		if !results.Add(render) {
+			break
+		}
+	}
+	writer.Header().Set("Content-Type", "application/sparql-results+json")
+	json.NewEncoder(writer).Encode(results)
+}
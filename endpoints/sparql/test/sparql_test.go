@@ -0,0 +1,135 @@
+package test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/bmeg/grip/endpoints/sparql/translate"
+	"github.com/bmeg/grip/gripql"
+	"github.com/bmeg/grip/util/export"
+	"google.golang.org/protobuf/proto"
+)
+
+var conf = &export.RDFConfig{
+	Base:   "http://example.org/",
+	Vocab:  "http://example.org/vocab/",
+	Labels: map[string]string{"Person": "http://example.org/people/"},
+}
+
+var edgeLabels = map[string]bool{"knows": true, "livesIn": true}
+
+const prefixes = `
+PREFIX ex: <http://example.org/vocab/>
+PREFIX people: <http://example.org/people/>
+`
+
+type testPair struct {
+	sparql string
+	gripql *gripql.Query
+}
+
+var pairs = []testPair{
+	{
+		`SELECT ?p ?name WHERE { ?p a ex:Person ; ex:name ?name . FILTER (?name != "Bob") } LIMIT 10`,
+		gripql.V().HasLabel("Person").HasKey("name").Has(gripql.Neq("name", "Bob")).As("v0").
+			Limit(10).Render(map[string]interface{}{"p": []interface{}{"$v0._gid", "$v0._label"}, "name": "$v0.name"}),
+	},
+	{
+		`SELECT ?friend WHERE {
+			people:alice ex:knows ?friend .
+			?friend ex:age ?age ; ex:livesIn ?city .
+			?city ex:name "Portland" .
+			FILTER (?age >= 30 && ?age < 40)
+		}`,
+		gripql.V("alice").HasLabel("Person").HasID("alice").As("v0").
+			Out("knows").HasKey("age").Has(gripql.And(gripql.Gte("age", 30.0), gripql.Lt("age", 40.0))).As("v1").
+			Out("livesIn").Has(gripql.Eq("name", "Portland")).As("v2").
+			Render(map[string]interface{}{"friend": []interface{}{"$v1._gid", "$v1._label"}}),
+	},
+	{
+		`SELECT DISTINCT * WHERE { ?a ex:knows ?b . ?a ex:livesIn ?c . ?b a ex:Person }`,
+		gripql.V().HasLabel("Person").As("v1").
+			In("knows").As("v0").
+			Out("livesIn").As("v2").
+			Render(map[string]interface{}{
+				"a": []interface{}{"$v0._gid", "$v0._label"},
+				"b": []interface{}{"$v1._gid", "$v1._label"},
+				"c": []interface{}{"$v2._gid", "$v2._label"},
+			}),
+	},
+	{
+		`SELECT ?p WHERE { ?p ex:knows ?a ; ex:knows ?b . ?a ex:name "x" . ?b ex:name "y" . FILTER (!(?p = people:carol)) }`,
+		gripql.V().Has(gripql.Not(gripql.Eq("_gid", "carol"))).As("v0").
+			Out("knows").Has(gripql.Eq("name", "x")).As("v1").
+			Select("v0").Out("knows").Has(gripql.Eq("name", "y")).As("v2").
+			Render(map[string]interface{}{"p": []interface{}{"$v0._gid", "$v0._label"}}),
+	},
+}
+
+func TestTranslate(t *testing.T) {
+	for _, pair := range pairs {
+		q, err := translate.Parse(prefixes + pair.sparql)
+		if err != nil {
+			t.Errorf("parsing %s: %s", pair.sparql, err)
+			continue
+		}
+		plan, err := translate.Translate(q, conf, edgeLabels)
+		if err != nil {
+			t.Errorf("translating %s: %s", pair.sparql, err)
+			continue
+		}
+		if len(plan.Query.Statements) != len(pair.gripql.Statements) {
+			t.Errorf("%s\n  got %s\n  expected %s", pair.sparql, plan.Query.String(), pair.gripql.String())
+			continue
+		}
+		for i := range plan.Query.Statements {
+			if !proto.Equal(plan.Query.Statements[i], pair.gripql.Statements[i]) {
+				t.Errorf("%s\n  got %s\n  expected %s", pair.sparql, plan.Query.String(), pair.gripql.String())
+				break
+			}
+		}
+	}
+}
+
+func TestUnsupported(t *testing.T) {
+	queries := []string{
+		`SELECT ?a WHERE { ?a ?p ?b }`,
+		`SELECT ?a WHERE { ?a ex:knows ?b . ?b ex:knows ?a }`,
+		`SELECT ?a WHERE { ?a ex:name ?n . ?b ex:name ?n }`,
+		`SELECT ?a WHERE { ?a ex:name ?n } ORDER BY ?n`,
+		`SELECT ?a WHERE { ?a ex:name ?n . FILTER regex(?n, "^B") }`,
+		`SELECT ?a WHERE { ?a ex:name ?n . OPTIONAL { ?a ex:age ?x } }`,
+		`SELECT ?a WHERE { ?a ex:name ?n . ?b ex:name ?m }`,
+		`SELECT ?a WHERE { ?a <http://other.org/name> ?n }`,
+	}
+	for _, src := range queries {
+		q, err := translate.Parse(prefixes + src)
+		if err == nil {
+			_, err = translate.Translate(q, conf, edgeLabels)
+		}
+		if err == nil {
+			t.Errorf("expected an error for %s", src)
+		}
+	}
+}
+
+func TestResults(t *testing.T) {
+	q, err := translate.Parse(prefixes + `SELECT DISTINCT ?p ?tag WHERE { ?p ex:tag ?tag } OFFSET 1 LIMIT 2`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	plan, err := translate.Translate(q, conf, edgeLabels)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res := translate.NewResults(plan, conf)
+	res.Add(map[string]interface{}{"p": []interface{}{"bob", "Person"}, "tag": []interface{}{"a", 1.0, "a"}})
+	res.Add(map[string]interface{}{"p": []interface{}{"x y", "City"}, "tag": true})
+	out, _ := json.Marshal(res)
+	expected := `{"head":{"vars":["p","tag"]},"results":{"bindings":[` +
+		`{"p":{"type":"uri","value":"http://example.org/people/bob"},"tag":{"type":"literal","value":"1","datatype":"http://www.w3.org/2001/XMLSchema#integer"}},` +
+		`{"p":{"type":"uri","value":"http://example.org/City/x%20y"},"tag":{"type":"literal","value":"true","datatype":"http://www.w3.org/2001/XMLSchema#boolean"}}]}}`
+	if string(out) != expected {
+		t.Errorf("unexpected results:\n%s\n%s", out, expected)
+	}
+}
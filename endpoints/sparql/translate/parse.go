@@ -0,0 +1,574 @@
+package translate
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/bmeg/grip/util/export"
+)
+
+// TermKind is the type of a term of a triple pattern
+type TermKind int
+
+// Kinds of terms
+const (
+	Var TermKind = iota
+	IRI
+	Literal
+)
+
+// Term is a variable, IRI or literal value
+type Term struct {
+	Kind TermKind
+	// Variable name, without the ?, or the full IRI
+	Name  string
+	Value interface{}
+}
+
+// Triple is a triple pattern of a basic graph pattern
+type Triple struct {
+	Subject   Term
+	Predicate Term
+	Object    Term
+}
+
+// Expr is a FILTER expression. Op is one of = != < <= > >= && || !, or empty
+// for a single term
+type Expr struct {
+	Op   string
+	Args []*Expr
+	Term *Term
+}
+
+// Query is a parsed SPARQL SELECT query
+type Query struct {
+	// Selected variables, empty for SELECT *
+	Vars     []string
+	Distinct bool
+	Patterns []Triple
+	Filters  []*Expr
+	// -1 when not set
+	Limit  int64
+	Offset int64
+}
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokIRI
+	tokPName
+	tokVar
+	tokString
+	tokNumber
+	tokLang
+	tokWord
+	tokPunct
+)
+
+type token struct {
+	kind tokenKind
+	text string
+}
+
+var iriRef = regexp.MustCompile("^<[^<>\"{}|^`\\\\\\x00-\\x20]*>")
+var numberRe = regexp.MustCompile(`^[+-]?([0-9]+(\.[0-9]*)?|\.[0-9]+)([eE][+-]?[0-9]+)?`)
+
+func isNameChar(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '-' || r == '.'
+}
+
+func lex(src string) ([]token, error) {
+	tokens := []token{}
+	rs := []rune(src)
+	for i := 0; i < len(rs); {
+		r := rs[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '#':
+			for i < len(rs) && rs[i] != '\n' {
+				i++
+			}
+		case r == '<':
+			if m := iriRef.FindString(string(rs[i:])); m != "" {
+				tokens = append(tokens, token{tokIRI, m[1 : len(m)-1]})
+				i += len([]rune(m))
+			} else if i+1 < len(rs) && rs[i+1] == '=' {
+				tokens = append(tokens, token{tokPunct, "<="})
+				i += 2
+			} else {
+				tokens = append(tokens, token{tokPunct, "<"})
+				i++
+			}
+		case r == '?' || r == '$':
+			j := i + 1
+			for j < len(rs) && (unicode.IsLetter(rs[j]) || unicode.IsDigit(rs[j]) || rs[j] == '_') {
+				j++
+			}
+			if j == i+1 {
+				return nil, fmt.Errorf("invalid variable at offset %d", i)
+			}
+			tokens = append(tokens, token{tokVar, string(rs[i+1 : j])})
+			i = j
+		case r == '"' || r == '\'':
+			var b strings.Builder
+			j := i + 1
+			for ; j < len(rs) && rs[j] != r; j++ {
+				if rs[j] == '\\' && j+1 < len(rs) {
+					j++
+					switch rs[j] {
+					case 'n':
+						b.WriteRune('\n')
+					case 't':
+						b.WriteRune('\t')
+					case 'r':
+						b.WriteRune('\r')
+					default:
+						b.WriteRune(rs[j])
+					}
+					continue
+				}
+				b.WriteRune(rs[j])
+			}
+			if j >= len(rs) {
+				return nil, fmt.Errorf("unterminated string at offset %d", i)
+			}
+			tokens = append(tokens, token{tokString, b.String()})
+			i = j + 1
+		case r == '@':
+			j := i + 1
+			for j < len(rs) && (unicode.IsLetter(rs[j]) || unicode.IsDigit(rs[j]) || rs[j] == '-') {
+				j++
+			}
+			tokens = append(tokens, token{tokLang, string(rs[i+1 : j])})
+			i = j
+		case unicode.IsDigit(r) || ((r == '-' || r == '+' || r == '.') && i+1 < len(rs) && unicode.IsDigit(rs[i+1])):
+			m := numberRe.FindString(string(rs[i:]))
+			// a trailing dot ends the triple
+			m = strings.TrimSuffix(m, ".")
+			tokens = append(tokens, token{tokNumber, m})
+			i += len([]rune(m))
+		case unicode.IsLetter(r) || r == ':' || r == '_':
+			j := i
+			for j < len(rs) && (isNameChar(rs[j]) || rs[j] == ':' || rs[j] == '%') {
+				j++
+			}
+			for j > i && rs[j-1] == '.' {
+				j--
+			}
+			text := string(rs[i:j])
+			if strings.Contains(text, ":") {
+				tokens = append(tokens, token{tokPName, text})
+			} else {
+				tokens = append(tokens, token{tokWord, text})
+			}
+			i = j
+		default:
+			two := ""
+			if i+1 < len(rs) {
+				two = string(rs[i : i+2])
+			}
+			switch two {
+			case "&&", "||", "!=", ">=", "^^":
+				tokens = append(tokens, token{tokPunct, two})
+				i += 2
+				continue
+			}
+			if strings.ContainsRune("{}().;,*=<>!", r) {
+				tokens = append(tokens, token{tokPunct, string(r)})
+				i++
+				continue
+			}
+			return nil, fmt.Errorf("unexpected character %q at offset %d", r, i)
+		}
+	}
+	return append(tokens, token{kind: tokEOF}), nil
+}
+
+type parser struct {
+	tokens   []token
+	pos      int
+	prefixes map[string]string
+	base     string
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) isWord(word string) bool {
+	t := p.peek()
+	return t.kind == tokWord && strings.EqualFold(t.text, word)
+}
+
+func (p *parser) isPunct(punct string) bool {
+	t := p.peek()
+	return t.kind == tokPunct && t.text == punct
+}
+
+func (p *parser) expectPunct(punct string) error {
+	if !p.isPunct(punct) {
+		return p.unexpected("'" + punct + "'")
+	}
+	p.next()
+	return nil
+}
+
+func (p *parser) unexpected(want string) error {
+	t := p.peek()
+	if t.kind == tokEOF {
+		return fmt.Errorf("expected %s, found end of query", want)
+	}
+	return fmt.Errorf("expected %s, found '%s'", want, t.text)
+}
+
+// Parse parses a SPARQL SELECT query. Only basic graph patterns, FILTER,
+// DISTINCT, LIMIT and OFFSET are supported
+func Parse(src string) (*Query, error) {
+	tokens, err := lex(src)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens, prefixes: map[string]string{}}
+	q := &Query{Limit: -1, Offset: -1}
+
+	for {
+		if p.isWord("PREFIX") {
+			p.next()
+			name := p.next()
+			if name.kind != tokPName || !strings.HasSuffix(name.text, ":") {
+				return nil, fmt.Errorf("expected prefix name, found '%s'", name.text)
+			}
+			iri := p.next()
+			if iri.kind != tokIRI {
+				return nil, fmt.Errorf("expected IRI for prefix %s", name.text)
+			}
+			p.prefixes[strings.TrimSuffix(name.text, ":")] = iri.text
+		} else if p.isWord("BASE") {
+			p.next()
+			iri := p.next()
+			if iri.kind != tokIRI {
+				return nil, fmt.Errorf("expected IRI after BASE")
+			}
+			p.base = iri.text
+		} else {
+			break
+		}
+	}
+
+	if !p.isWord("SELECT") {
+		return nil, p.unexpected("SELECT")
+	}
+	p.next()
+	if p.isWord("DISTINCT") || p.isWord("REDUCED") {
+		q.Distinct = true
+		p.next()
+	}
+	if p.isPunct("*") {
+		p.next()
+	} else {
+		for p.peek().kind == tokVar {
+			q.Vars = append(q.Vars, p.next().text)
+		}
+		if len(q.Vars) == 0 {
+			if p.isPunct("(") {
+				return nil, fmt.Errorf("select expressions are not supported")
+			}
+			return nil, p.unexpected("variables or '*'")
+		}
+	}
+
+	if p.isWord("FROM") {
+		return nil, fmt.Errorf("FROM is not supported; the graph is set by the endpoint path")
+	}
+	if p.isWord("WHERE") {
+		p.next()
+	}
+	if err := p.expectPunct("{"); err != nil {
+		return nil, err
+	}
+	if err := p.groupPattern(q); err != nil {
+		return nil, err
+	}
+
+	for p.peek().kind != tokEOF {
+		switch {
+		case p.isWord("LIMIT"):
+			p.next()
+			n, err := p.integer()
+			if err != nil {
+				return nil, err
+			}
+			q.Limit = n
+		case p.isWord("OFFSET"):
+			p.next()
+			n, err := p.integer()
+			if err != nil {
+				return nil, err
+			}
+			q.Offset = n
+		case p.peek().kind == tokWord:
+			return nil, fmt.Errorf("%s is not supported", strings.ToUpper(p.peek().text))
+		default:
+			return nil, p.unexpected("LIMIT, OFFSET or end of query")
+		}
+	}
+	return q, nil
+}
+
+func (p *parser) integer() (int64, error) {
+	t := p.next()
+	if t.kind != tokNumber {
+		return 0, fmt.Errorf("expected number, found '%s'", t.text)
+	}
+	return strconv.ParseInt(t.text, 10, 64)
+}
+
+// groupPattern parses the triples and filters of a { } block, after the {
+func (p *parser) groupPattern(q *Query) error {
+	for {
+		switch {
+		case p.isPunct("}"):
+			p.next()
+			return nil
+		case p.isPunct("."):
+			p.next()
+		case p.isWord("FILTER"):
+			p.next()
+			e, err := p.constraint()
+			if err != nil {
+				return err
+			}
+			q.Filters = append(q.Filters, e)
+		case p.isPunct("{"):
+			return fmt.Errorf("nested groups are not supported")
+		case p.peek().kind == tokWord && !p.isWord("a") && !p.isWord("true") && !p.isWord("false"):
+			return fmt.Errorf("%s is not supported", strings.ToUpper(p.peek().text))
+		case p.peek().kind == tokEOF:
+			return p.unexpected("'}'")
+		default:
+			if err := p.triples(q); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// triples parses a subject and its predicate and object lists
+func (p *parser) triples(q *Query) error {
+	subj, err := p.term()
+	if err != nil {
+		return err
+	}
+	for {
+		var pred Term
+		if p.isWord("a") {
+			p.next()
+			pred = Term{Kind: IRI, Name: export.RDFType}
+		} else if pred, err = p.term(); err != nil {
+			return err
+		}
+		for {
+			obj, err := p.term()
+			if err != nil {
+				return err
+			}
+			q.Patterns = append(q.Patterns, Triple{subj, pred, obj})
+			if !p.isPunct(",") {
+				break
+			}
+			p.next()
+		}
+		if !p.isPunct(";") {
+			return nil
+		}
+		p.next()
+		// a trailing ; is allowed
+		if p.isPunct(".") || p.isPunct("}") {
+			return nil
+		}
+	}
+}
+
+func (p *parser) iri(t token) (string, error) {
+	if t.kind == tokIRI {
+		if p.base != "" && !strings.Contains(t.text, ":") {
+			return p.base + t.text, nil
+		}
+		return t.text, nil
+	}
+	parts := strings.SplitN(t.text, ":", 2)
+	ns, ok := p.prefixes[parts[0]]
+	if !ok {
+		return "", fmt.Errorf("undefined prefix '%s'", parts[0])
+	}
+	return ns + parts[1], nil
+}
+
+// term parses a variable, IRI or literal
+func (p *parser) term() (Term, error) {
+	t := p.next()
+	switch t.kind {
+	case tokVar:
+		return Term{Kind: Var, Name: t.text}, nil
+	case tokIRI, tokPName:
+		iri, err := p.iri(t)
+		if err != nil {
+			return Term{}, err
+		}
+		return Term{Kind: IRI, Name: iri}, nil
+	case tokNumber:
+		f, err := strconv.ParseFloat(t.text, 64)
+		if err != nil {
+			return Term{}, err
+		}
+		return Term{Kind: Literal, Value: f}, nil
+	case tokWord:
+		switch strings.ToLower(t.text) {
+		case "true":
+			return Term{Kind: Literal, Value: true}, nil
+		case "false":
+			return Term{Kind: Literal, Value: false}, nil
+		}
+	case tokString:
+		if p.peek().kind == tokLang {
+			p.next()
+			return Term{Kind: Literal, Value: t.text}, nil
+		}
+		if p.isPunct("^^") {
+			p.next()
+			dt, err := p.iri(p.next())
+			if err != nil {
+				return Term{}, err
+			}
+			return typedLiteral(t.text, dt)
+		}
+		return Term{Kind: Literal, Value: t.text}, nil
+	}
+	if t.kind == tokEOF {
+		return Term{}, fmt.Errorf("unexpected end of query")
+	}
+	return Term{}, fmt.Errorf("unexpected '%s'", t.text)
+}
+
+func typedLiteral(lex string, datatype string) (Term, error) {
+	switch strings.TrimPrefix(datatype, export.XSDPrefix) {
+	case "integer", "int", "long", "short", "decimal", "double", "float", "nonNegativeInteger", "positiveInteger":
+		f, err := strconv.ParseFloat(lex, 64)
+		if err != nil {
+			return Term{}, fmt.Errorf("invalid number '%s'", lex)
+		}
+		return Term{Kind: Literal, Value: f}, nil
+	case "boolean":
+		b, err := strconv.ParseBool(lex)
+		if err != nil {
+			return Term{}, fmt.Errorf("invalid boolean '%s'", lex)
+		}
+		return Term{Kind: Literal, Value: b}, nil
+	}
+	return Term{Kind: Literal, Value: lex}, nil
+}
+
+// constraint parses the expression of a FILTER
+func (p *parser) constraint() (*Expr, error) {
+	if p.peek().kind == tokWord {
+		return nil, fmt.Errorf("FILTER function %s is not supported", p.peek().text)
+	}
+	if err := p.expectPunct("("); err != nil {
+		return nil, err
+	}
+	e, err := p.orExpr()
+	if err != nil {
+		return nil, err
+	}
+	return e, p.expectPunct(")")
+}
+
+func (p *parser) orExpr() (*Expr, error) {
+	e, err := p.andExpr()
+	if err != nil {
+		return nil, err
+	}
+	for p.isPunct("||") {
+		p.next()
+		r, err := p.andExpr()
+		if err != nil {
+			return nil, err
+		}
+		e = &Expr{Op: "||", Args: []*Expr{e, r}}
+	}
+	return e, nil
+}
+
+func (p *parser) andExpr() (*Expr, error) {
+	e, err := p.relExpr()
+	if err != nil {
+		return nil, err
+	}
+	for p.isPunct("&&") {
+		p.next()
+		r, err := p.relExpr()
+		if err != nil {
+			return nil, err
+		}
+		e = &Expr{Op: "&&", Args: []*Expr{e, r}}
+	}
+	return e, nil
+}
+
+func (p *parser) relExpr() (*Expr, error) {
+	e, err := p.unaryExpr()
+	if err != nil {
+		return nil, err
+	}
+	for _, op := range []string{"=", "!=", "<", "<=", ">", ">="} {
+		if p.isPunct(op) {
+			p.next()
+			r, err := p.unaryExpr()
+			if err != nil {
+				return nil, err
+			}
+			return &Expr{Op: op, Args: []*Expr{e, r}}, nil
+		}
+	}
+	return e, nil
+}
+
+func (p *parser) unaryExpr() (*Expr, error) {
+	if p.isPunct("!") {
+		p.next()
+		e, err := p.unaryExpr()
+		if err != nil {
+			return nil, err
+		}
+		return &Expr{Op: "!", Args: []*Expr{e}}, nil
+	}
+	if p.isPunct("(") {
+		p.next()
+		e, err := p.orExpr()
+		if err != nil {
+			return nil, err
+		}
+		return e, p.expectPunct(")")
+	}
+	if t := p.peek(); t.kind == tokWord && !p.isWord("true") && !p.isWord("false") {
+		return nil, fmt.Errorf("FILTER function %s is not supported", t.text)
+	}
+	t, err := p.term()
+	if err != nil {
+		return nil, err
+	}
+	return &Expr{Term: &t}, nil
+}
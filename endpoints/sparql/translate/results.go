@@ -0,0 +1,107 @@
+package translate
+
+import (
+	"encoding/json"
+
+	"github.com/bmeg/grip/util/export"
+)
+
+// Binding is the value of a variable in the SPARQL JSON results format
+type Binding struct {
+	Type     string `json:"type"`
+	Value    string `json:"value"`
+	Datatype string `json:"datatype,omitempty"`
+}
+
+// Results collects the rendered rows of a plan's traversal as SPARQL
+// solutions, applying DISTINCT, OFFSET and LIMIT
+type Results struct {
+	Head struct {
+		Vars []string `json:"vars"`
+	} `json:"head"`
+	Results struct {
+		Bindings []map[string]Binding `json:"bindings"`
+	} `json:"results"`
+	plan    *Plan
+	conf    *export.RDFConfig
+	seen    map[string]bool
+	skipped int64
+}
+
+// NewResults creates an empty result set for a plan
+func NewResults(plan *Plan, conf *export.RDFConfig) *Results {
+	r := &Results{plan: plan, conf: conf, seen: map[string]bool{}}
+	for _, c := range plan.Columns {
+		r.Head.Vars = append(r.Head.Vars, c.Var)
+	}
+	r.Results.Bindings = []map[string]Binding{}
+	return r
+}
+
+// Add converts a rendered row into solutions. Variables holding a list give
+// a solution per item. It returns false once the limit has been reached
+func (r *Results) Add(row map[string]interface{}) bool {
+	solutions := []map[string]Binding{{}}
+	for _, c := range r.plan.Columns {
+		values := []Binding{}
+		switch c.Kind {
+		case VertexColumn:
+			if v, ok := row[c.Var].([]interface{}); ok && len(v) == 2 {
+				gid, _ := v[0].(string)
+				label, _ := v[1].(string)
+				if gid != "" {
+					values = append(values, Binding{Type: "uri", Value: r.conf.VertexIRI(label, gid)})
+				}
+			}
+		case ValueColumn:
+			items := []interface{}{row[c.Var]}
+			if l, ok := row[c.Var].([]interface{}); ok {
+				items = l
+			}
+			for _, i := range items {
+				if i == nil {
+					continue
+				}
+				lex, dt := export.Literal(i)
+				if dt == export.XSDPrefix+"string" {
+					dt = ""
+				}
+				values = append(values, Binding{Type: "literal", Value: lex, Datatype: dt})
+			}
+		}
+		if len(values) == 0 {
+			continue
+		}
+		next := make([]map[string]Binding, 0, len(solutions)*len(values))
+		for _, s := range solutions {
+			for _, v := range values {
+				n := make(map[string]Binding, len(s)+1)
+				for k, b := range s {
+					n[k] = b
+				}
+				n[c.Var] = v
+				next = append(next, n)
+			}
+		}
+		solutions = next
+	}
+
+	for _, s := range solutions {
+		if r.plan.Distinct {
+			key, _ := json.Marshal(s)
+			if r.seen[string(key)] {
+				continue
+			}
+			r.seen[string(key)] = true
+		}
+		if r.skipped < r.plan.Offset {
+			r.skipped++
+			continue
+		}
+		if r.plan.Limit >= 0 && int64(len(r.Results.Bindings)) >= r.plan.Limit {
+			return false
+		}
+		r.Results.Bindings = append(r.Results.Bindings, s)
+	}
+	return r.plan.Limit < 0 || int64(len(r.Results.Bindings)) < r.plan.Limit
+}
@@ -0,0 +1,428 @@
+package translate
+
+import (
+	"fmt"
+
+	"github.com/bmeg/grip/gripql"
+	"github.com/bmeg/grip/util/export"
+)
+
+// ColumnKind says how a rendered column is converted back to an RDF term
+type ColumnKind int
+
+// Kinds of columns
+const (
+	// The column holds the [gid, label] of a vertex
+	VertexColumn ColumnKind = iota
+	// The column holds a data value
+	ValueColumn
+)
+
+// Column is a variable of the query results
+type Column struct {
+	Var  string
+	Kind ColumnKind
+}
+
+// Plan is a GripQL traversal, and how to read its rows as SPARQL solutions
+type Plan struct {
+	Query    *gripql.Query
+	Columns  []Column
+	Distinct bool
+	Limit    int64
+	Offset   int64
+}
+
+// node is a vertex of the pattern, named by a variable or a constant IRI
+type node struct {
+	key     string
+	mark    string
+	label   string
+	gid     string
+	has     []*gripql.HasExpression
+	keys    []string
+	visited bool
+}
+
+type patternEdge struct {
+	from, to *node
+	label    string
+	used     bool
+}
+
+// binding is a value variable, read from a data field of a node
+type binding struct {
+	node  *node
+	field string
+}
+
+type translator struct {
+	conf       *export.RDFConfig
+	edgeLabels map[string]bool
+	nodes      map[string]*node
+	order      []*node
+	edges      []*patternEdge
+	values     map[string]*binding
+}
+
+func (t *translator) node(term Term) (*node, error) {
+	key := "?" + term.Name
+	if term.Kind == IRI {
+		key = "<" + term.Name + ">"
+	} else if term.Kind != Var {
+		return nil, fmt.Errorf("a literal can't be the subject of a triple")
+	}
+	if _, ok := t.values[term.Name]; ok && term.Kind == Var {
+		return nil, fmt.Errorf("?%s is used as both a vertex and a data value", term.Name)
+	}
+	if n, ok := t.nodes[key]; ok {
+		return n, nil
+	}
+	n := &node{key: key, mark: fmt.Sprintf("v%d", len(t.order))}
+	if term.Kind == IRI {
+		label, gid, ok := t.conf.ParseVertexIRI(term.Name)
+		if !ok {
+			return nil, fmt.Errorf("<%s> is not a vertex IRI", term.Name)
+		}
+		n.gid = gid
+		n.label = label
+	}
+	t.nodes[key] = n
+	t.order = append(t.order, n)
+	return n, nil
+}
+
+func (t *translator) setLabel(n *node, label string) error {
+	if n.label != "" && n.label != label {
+		return fmt.Errorf("%s can't have the types %s and %s", n.key, n.label, label)
+	}
+	n.label = label
+	return nil
+}
+
+// Translate converts a query into a traversal. edgeLabels are the edge
+// labels of the graph; other predicates are read as data fields. The triple
+// patterns must form a tree, starting from any of its vertices, and each
+// value variable must be read from a single vertex.
+func Translate(q *Query, conf *export.RDFConfig, edgeLabels map[string]bool) (*Plan, error) {
+	t := &translator{
+		conf:       conf,
+		edgeLabels: edgeLabels,
+		nodes:      map[string]*node{},
+		values:     map[string]*binding{},
+	}
+	if len(q.Patterns) == 0 {
+		return nil, fmt.Errorf("the query has no triple patterns")
+	}
+
+	// variables that are vertices, because they are subjects or the objects
+	// of edges
+	vertexVars := map[string]bool{}
+	for _, tr := range q.Patterns {
+		if tr.Subject.Kind == Var {
+			vertexVars[tr.Subject.Name] = true
+		}
+		if tr.Predicate.Kind != IRI {
+			return nil, fmt.Errorf("variable predicates are not supported")
+		}
+		if name, ok := conf.ParseTermIRI(tr.Predicate.Name); ok && edgeLabels[name] && tr.Object.Kind == Var {
+			vertexVars[tr.Object.Name] = true
+		}
+	}
+
+	for _, tr := range q.Patterns {
+		subj, err := t.node(tr.Subject)
+		if err != nil {
+			return nil, err
+		}
+		if tr.Predicate.Name == export.RDFType {
+			if tr.Object.Kind != IRI {
+				return nil, fmt.Errorf("the type of %s must be an IRI", subj.key)
+			}
+			label, ok := conf.ParseTermIRI(tr.Object.Name)
+			if !ok {
+				return nil, fmt.Errorf("<%s> is not a class of the graph", tr.Object.Name)
+			}
+			if err := t.setLabel(subj, label); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		name, ok := conf.ParseTermIRI(tr.Predicate.Name)
+		if !ok {
+			return nil, fmt.Errorf("<%s> is not a predicate of the graph", tr.Predicate.Name)
+		}
+		obj := tr.Object
+		switch {
+		case obj.Kind == IRI || (obj.Kind == Var && vertexVars[obj.Name]):
+			to, err := t.node(obj)
+			if err != nil {
+				return nil, err
+			}
+			t.edges = append(t.edges, &patternEdge{from: subj, to: to, label: name})
+		case obj.Kind == Literal:
+			subj.has = append(subj.has, gripql.Eq(name, obj.Value))
+		default:
+			if _, ok := t.values[obj.Name]; ok {
+				return nil, fmt.Errorf("?%s is read from more than one field, joins on values are not supported", obj.Name)
+			}
+			t.values[obj.Name] = &binding{node: subj, field: name}
+			subj.keys = append(subj.keys, name)
+		}
+	}
+
+	for _, f := range q.Filters {
+		n, err := t.filterNode(f)
+		if err != nil {
+			return nil, err
+		}
+		expr, err := t.filter(f)
+		if err != nil {
+			return nil, err
+		}
+		n.has = append(n.has, expr)
+	}
+
+	plan := &Plan{Distinct: q.Distinct, Limit: q.Limit, Offset: q.Offset}
+	start := t.start()
+	query := gripql.V()
+	if start.gid != "" {
+		query = gripql.V(start.gid)
+	}
+	query = t.walk(t.constrain(query, start), start)
+	for _, n := range t.order {
+		if !n.visited {
+			return nil, fmt.Errorf("%s is not connected to the other patterns", n.key)
+		}
+	}
+	for _, e := range t.edges {
+		if !e.used {
+			return nil, fmt.Errorf("cyclic patterns are not supported")
+		}
+	}
+
+	vars := q.Vars
+	if len(vars) == 0 {
+		// SELECT * lists the vertex variables, then the value variables
+		for _, n := range t.order {
+			if n.key[0] == '?' {
+				vars = append(vars, n.key[1:])
+			}
+		}
+		for _, tr := range q.Patterns {
+			if _, ok := t.values[tr.Object.Name]; ok && tr.Object.Kind == Var {
+				vars = append(vars, tr.Object.Name)
+			}
+		}
+		vars = unique(vars)
+	}
+	render := map[string]interface{}{}
+	for _, v := range vars {
+		if n, ok := t.nodes["?"+v]; ok {
+			render[v] = []interface{}{"$" + n.mark + "._gid", "$" + n.mark + "._label"}
+			plan.Columns = append(plan.Columns, Column{Var: v, Kind: VertexColumn})
+		} else if b, ok := t.values[v]; ok {
+			render[v] = "$" + b.node.mark + "." + b.field
+			plan.Columns = append(plan.Columns, Column{Var: v, Kind: ValueColumn})
+		} else {
+			return nil, fmt.Errorf("?%s is not used in the query", v)
+		}
+	}
+	if !plan.Distinct && plan.Limit >= 0 {
+		// list values can only add rows, so the traversal is limited too
+		n := plan.Limit
+		if plan.Offset > 0 {
+			n += plan.Offset
+		}
+		query = query.Limit(uint32(n))
+	}
+	plan.Query = query.Render(render)
+	return plan, nil
+}
+
+func unique(s []string) []string {
+	seen := map[string]bool{}
+	out := []string{}
+	for _, i := range s {
+		if !seen[i] {
+			seen[i] = true
+			out = append(out, i)
+		}
+	}
+	return out
+}
+
+// start picks the vertex the traversal starts from: a constant IRI, or the
+// first vertex with a type
+func (t *translator) start() *node {
+	for _, n := range t.order {
+		if n.gid != "" {
+			return n
+		}
+	}
+	for _, n := range t.order {
+		if n.label != "" {
+			return n
+		}
+	}
+	return t.order[0]
+}
+
+// constrain filters the current vertex, and marks it
+func (t *translator) constrain(q *gripql.Query, n *node) *gripql.Query {
+	n.visited = true
+	if n.label != "" {
+		q = q.HasLabel(n.label)
+	}
+	if n.gid != "" {
+		q = q.HasID(n.gid)
+	}
+	for _, k := range n.keys {
+		q = q.HasKey(k)
+	}
+	for _, h := range n.has {
+		q = q.Has(h)
+	}
+	return q.As(n.mark)
+}
+
+// walk follows the edges of the pattern from n, returning to n with select
+// before each branch after the first
+func (t *translator) walk(q *gripql.Query, n *node) *gripql.Query {
+	first := true
+	for _, e := range t.edges {
+		if e.used || (e.from != n && e.to != n) {
+			continue
+		}
+		next := e.to
+		if e.to == n {
+			next = e.from
+		}
+		if next.visited {
+			continue
+		}
+		e.used = true
+		if !first {
+			q = q.Select(n.mark)
+		}
+		first = false
+		if e.from == n {
+			q = q.Out(e.label)
+		} else {
+			q = q.In(e.label)
+		}
+		q = t.walk(t.constrain(q, next), next)
+	}
+	return q
+}
+
+// filterNode returns the vertex whose fields are used by a filter
+func (t *translator) filterNode(e *Expr) (*node, error) {
+	var found *node
+	var visit func(e *Expr) error
+	visit = func(e *Expr) error {
+		if e.Term != nil && e.Term.Kind == Var {
+			var n *node
+			if v, ok := t.nodes["?"+e.Term.Name]; ok {
+				n = v
+			} else if b, ok := t.values[e.Term.Name]; ok {
+				n = b.node
+			} else {
+				return fmt.Errorf("?%s is not bound by a triple pattern", e.Term.Name)
+			}
+			if found != nil && found != n {
+				return fmt.Errorf("filters comparing the values of different vertices are not supported")
+			}
+			found = n
+		}
+		for _, a := range e.Args {
+			if err := visit(a); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := visit(e); err != nil {
+		return nil, err
+	}
+	if found == nil {
+		return nil, fmt.Errorf("filters without variables are not supported")
+	}
+	return found, nil
+}
+
+var flipped = map[string]string{"=": "=", "!=": "!=", "<": ">", "<=": ">=", ">": "<", ">=": "<="}
+
+// filter converts a filter expression into a has expression on its vertex
+func (t *translator) filter(e *Expr) (*gripql.HasExpression, error) {
+	switch e.Op {
+	case "&&", "||":
+		a, err := t.filter(e.Args[0])
+		if err != nil {
+			return nil, err
+		}
+		b, err := t.filter(e.Args[1])
+		if err != nil {
+			return nil, err
+		}
+		if e.Op == "&&" {
+			return gripql.And(a, b), nil
+		}
+		return gripql.Or(a, b), nil
+	case "!":
+		a, err := t.filter(e.Args[0])
+		if err != nil {
+			return nil, err
+		}
+		return gripql.Not(a), nil
+	case "":
+		return nil, fmt.Errorf("filter terms must be compared to a value")
+	}
+
+	op := e.Op
+	left, right := e.Args[0].Term, e.Args[1].Term
+	if left == nil || right == nil {
+		return nil, fmt.Errorf("only variables can be compared to values")
+	}
+	if left.Kind != Var {
+		left, right = right, left
+		op = flipped[op]
+	}
+	if left.Kind != Var || right.Kind == Var {
+		return nil, fmt.Errorf("only variables can be compared to values")
+	}
+
+	key := ""
+	var value interface{}
+	if _, ok := t.nodes["?"+left.Name]; ok {
+		if right.Kind != IRI || (op != "=" && op != "!=") {
+			return nil, fmt.Errorf("vertex ?%s can only be compared to an IRI with = or !=", left.Name)
+		}
+		_, gid, ok := t.conf.ParseVertexIRI(right.Name)
+		if !ok {
+			return nil, fmt.Errorf("<%s> is not a vertex IRI", right.Name)
+		}
+		key, value = "_gid", gid
+	} else {
+		if right.Kind != Literal {
+			return nil, fmt.Errorf("?%s can only be compared to a literal", left.Name)
+		}
+		key, value = t.values[left.Name].field, right.Value
+	}
+
+	switch op {
+	case "=":
+		return gripql.Eq(key, value), nil
+	case "!=":
+		return gripql.Neq(key, value), nil
+	case "<":
+		return gripql.Lt(key, value), nil
+	case "<=":
+		return gripql.Lte(key, value), nil
+	case ">":
+		return gripql.Gt(key, value), nil
+	case ">=":
+		return gripql.Gte(key, value), nil
+	}
+	return nil, fmt.Errorf("unknown operator %s", op)
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/bmeg/grip/gdbi"
 	"github.com/bmeg/grip/gripql"
 	"github.com/bmeg/grip/jsonpath"
+	"github.com/bmeg/grip/util/geo"
 	"github.com/bmeg/grip/util/protoutil"
 )
 
@@ -87,9 +88,41 @@ func (comp DefaultCompiler) Compile(stmts []*gripql.GraphStatement, opts *gdbi.C
 		procs = append(procs, p)
 	}
 
+	// a sort followed by a limit only needs to keep the first elements
+	for i := 0; i+1 < len(procs); i++ {
+		if s, ok := procs[i].(*Sort); ok {
+			if l, ok := procs[i+1].(*Limit); ok {
+				s.limit = l.count
+			}
+		}
+	}
+
+	// the steps after a sort keep its order, which limits, skips and
+	// cursors depend on
+	sorted := false
+	for i := range procs {
+		if _, ok := procs[i].(*Sort); ok {
+			sorted = true
+		}
+		if stateless(procs[i]) {
+			procs[i] = &gdbi.Parallel{Processor: procs[i], Ordered: sorted}
+		}
+	}
+
 	return &DefaultPipeline{comp.db, procs, ps.LastType, ps.MarkTypes}, nil
 }
 
+// stateless tests if a processor handles each traveler on its own, so that
+// the pipeline may run it on several workers
+func stateless(p gdbi.Processor) bool {
+	switch p.(type) {
+	case *Has, *HasLabel, *HasKey, *HasID, *Seek, *Fields, *Render, *Path, *Unwind,
+		*Marker, *Selector, *MarkSelect, *ValueIncrement:
+		return true
+	}
+	return false
+}
+
 func StatementProcessor(gs *gripql.GraphStatement, db gdbi.GraphInterface, ps *pipeline.State) (gdbi.Processor, error) {
 	switch stmt := gs.GetStatement().(type) {
 
@@ -213,7 +246,11 @@ func StatementProcessor(gs *gripql.GraphStatement, db gdbi.GraphInterface, ps *p
 		if ps.LastType != gdbi.VertexData && ps.LastType != gdbi.EdgeData {
 			return nil, fmt.Errorf(`"Has" statement is only valid for edge or vertex types not: %s`, ps.LastType.String())
 		}
-		return &Has{stmt.Has}, nil
+		match, err := logic.NewHasMatcher(stmt.Has)
+		if err != nil {
+			return nil, err
+		}
+		return &Has{match}, nil
 
 	case *gripql.GraphStatement_HasLabel:
 		if ps.LastType != gdbi.VertexData && ps.LastType != gdbi.EdgeData {
@@ -268,6 +305,30 @@ func StatementProcessor(gs *gripql.GraphStatement, db gdbi.GraphInterface, ps *p
 		}
 		return &Distinct{fields}, nil
 
+	case *gripql.GraphStatement_Sort:
+		if ps.LastType != gdbi.VertexData && ps.LastType != gdbi.EdgeData {
+			return nil, fmt.Errorf(`"sort" statement is only valid for edge or vertex types not: %s`, ps.LastType.String())
+		}
+		fields := protoutil.AsStringList(stmt.Sort)
+		if len(fields) == 0 {
+			return nil, fmt.Errorf(`no fields provided to "sort" statement`)
+		}
+		return NewSort(fields), nil
+
+	case *gripql.GraphStatement_Seek:
+		if ps.LastType != gdbi.VertexData && ps.LastType != gdbi.EdgeData {
+			return nil, fmt.Errorf(`"seek" statement is only valid for edge or vertex types not: %s`, ps.LastType.String())
+		}
+		fields := stmt.Seek.GetFields()
+		if len(fields) == 0 {
+			return nil, fmt.Errorf(`no fields provided to "seek" statement`)
+		}
+		position := stmt.Seek.GetValues().AsSlice()
+		if len(position) != len(fields) {
+			return nil, fmt.Errorf(`"seek" statement has %d values for %d fields`, len(position), len(fields))
+		}
+		return NewSeek(fields, position), nil
+
 	case *gripql.GraphStatement_As:
 		if ps.LastType == gdbi.NoData {
 			return nil, fmt.Errorf(`"mark" statement is not valid at the beginning of a traversal`)
@@ -294,9 +355,31 @@ func StatementProcessor(gs *gripql.GraphStatement, db gdbi.GraphInterface, ps *p
 		return &logic.JumpMark{Name: stmt.Mark}, nil
 
 	case *gripql.GraphStatement_Jump:
-		j := &logic.Jump{Mark: stmt.Jump.Mark, Stmt: stmt.Jump.Expression, Emit: stmt.Jump.Emit}
+		j := &logic.Jump{Mark: stmt.Jump.Mark, Emit: stmt.Jump.Emit}
+		if stmt.Jump.Expression != nil {
+			match, err := logic.NewHasMatcher(stmt.Jump.Expression)
+			if err != nil {
+				return nil, err
+			}
+			j.Match = match
+		}
 		return j, nil
 
+	case *gripql.GraphStatement_Where:
+		return compileSubQuery(stmt.Where.Query, false, db, ps)
+
+	case *gripql.GraphStatement_Not:
+		return compileSubQuery(stmt.Not.Query, true, db, ps)
+
+	case *gripql.GraphStatement_Union:
+		return compileBranches(branchUnion, stmt.Union.Queries, db, ps)
+
+	case *gripql.GraphStatement_Optional:
+		return compileBranches(branchOptional, []*gripql.SubQuery{stmt.Optional}, db, ps)
+
+	case *gripql.GraphStatement_Coalesce:
+		return compileBranches(branchCoalesce, stmt.Coalesce.Queries, db, ps)
+
 	case *gripql.GraphStatement_Select:
 		if ps.LastType != gdbi.VertexData && ps.LastType != gdbi.EdgeData {
 			return nil, fmt.Errorf(`"select" statement is only valid for edge or vertex types not: %s`, ps.LastType.String())
@@ -319,6 +402,23 @@ func StatementProcessor(gs *gripql.GraphStatement, db gdbi.GraphInterface, ps *p
 		ps.LastType = gdbi.RenderData
 		return &Render{stmt.Render.AsInterface()}, nil
 
+	case *gripql.GraphStatement_Group:
+		if ps.LastType != gdbi.VertexData && ps.LastType != gdbi.EdgeData {
+			return nil, fmt.Errorf(`"group" statement is only valid for edge or vertex types not: %s`, ps.LastType.String())
+		}
+		if stmt.Group.Key == "" {
+			return nil, fmt.Errorf(`"group" statement has an empty key`)
+		}
+		ps.LastType = gdbi.RenderData
+		return NewGroup(stmt.Group.Key, stmt.Group.Value.AsInterface()), nil
+
+	case *gripql.GraphStatement_Fold:
+		if ps.LastType != gdbi.VertexData && ps.LastType != gdbi.EdgeData {
+			return nil, fmt.Errorf(`"fold" statement is only valid for edge or vertex types not: %s`, ps.LastType.String())
+		}
+		ps.LastType = gdbi.RenderData
+		return NewFold(stmt.Fold.AsInterface()), nil
+
 	case *gripql.GraphStatement_Path:
 		if ps.LastType != gdbi.VertexData && ps.LastType != gdbi.EdgeData {
 			return nil, fmt.Errorf(`"path" statement is only valid for edge or vertex types not: %s`, ps.LastType.String())
@@ -345,6 +445,16 @@ func StatementProcessor(gs *gripql.GraphStatement, db gdbi.GraphInterface, ps *p
 			if _, ok := aggs[a.Name]; ok {
 				return nil, fmt.Errorf("duplicate aggregation name '%s' found; all aggregations must have a unique name", a.Name)
 			}
+			if dh := a.GetDateHistogram(); dh != nil {
+				if err := gripql.CheckDateInterval(dh.Interval); err != nil {
+					return nil, fmt.Errorf("date histogram aggregation '%s': %v", a.Name, err)
+				}
+			}
+			if gg := a.GetGeohashGrid(); gg != nil && gg.Precision != 0 {
+				if err := geo.CheckPrecision(gg.Precision); err != nil {
+					return nil, fmt.Errorf("geohash grid aggregation '%s': %v", a.Name, err)
+				}
+			}
 		}
 		ps.LastType = gdbi.AggregationData
 		return &aggregate{stmt.Aggregate.Aggregations}, nil
@@ -352,7 +462,7 @@ func StatementProcessor(gs *gripql.GraphStatement, db gdbi.GraphInterface, ps *p
 	//Custom graph statements
 	case *gripql.GraphStatement_LookupVertsIndex:
 		ps.LastType = gdbi.VertexData
-		return &LookupVertsIndex{db: db, labels: stmt.Labels, loadData: ps.StepLoadData()}, nil
+		return &LookupVertsIndex{db: db, labels: stmt.Labels, geo: stmt.Geo, time: stmt.Time, loadData: ps.StepLoadData()}, nil
 
 	case *gripql.GraphStatement_EngineCustom:
 		proc := stmt.Custom.(gdbi.CustomProcGen)
@@ -0,0 +1,211 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/bmeg/grip/gdbi"
+	"github.com/bmeg/grip/jsonpath"
+	"github.com/bmeg/grip/kvi"
+)
+
+// groupSpillSize is the number of values a group keeps in memory before
+// moving them to a temporary key/value store
+const groupSpillSize = 10000
+
+// groupCollector collects values into lists keyed by JSON encoded keys. Keys
+// are emitted in byte order and values in the order they were added. Once
+// more than spillSize values are held, they are written to a temporary
+// key/value store, where each value is stored under its key, a 0x00 separator
+// and its position
+type groupCollector struct {
+	spillSize int
+	keys      map[string][]interface{}
+	held      int
+	pos       uint64
+	kv        kvi.KVInterface
+}
+
+func newGroupCollector(spillSize int) *groupCollector {
+	return &groupCollector{spillSize: spillSize, keys: map[string][]interface{}{}}
+}
+
+func (g *groupCollector) add(man gdbi.Manager, key []byte, value interface{}) error {
+	g.keys[string(key)] = append(g.keys[string(key)], value)
+	g.held++
+	if g.held > g.spillSize {
+		if g.kv == nil {
+			g.kv = man.GetTempKV()
+		}
+		return g.spill()
+	}
+	return nil
+}
+
+func (g *groupCollector) spill() error {
+	err := g.kv.BulkWrite(func(bl kvi.KVBulkWrite) error {
+		for k, values := range g.keys {
+			for _, v := range values {
+				data, err := json.Marshal(v)
+				if err != nil {
+					return err
+				}
+				pos := make([]byte, 8)
+				binary.BigEndian.PutUint64(pos, g.pos)
+				g.pos++
+				if err := bl.Set(bytes.Join([][]byte{[]byte(k), pos}, []byte{0x00}), data); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+	g.keys = map[string][]interface{}{}
+	g.held = 0
+	return err
+}
+
+// emit calls f with each key and its values
+func (g *groupCollector) emit(f func(key []byte, values []interface{})) error {
+	if g.kv == nil {
+		keys := make([]string, 0, len(g.keys))
+		for k := range g.keys {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			f([]byte(k), g.keys[k])
+		}
+		return nil
+	}
+	if g.held > 0 {
+		if err := g.spill(); err != nil {
+			return err
+		}
+	}
+	return g.kv.View(func(it kvi.KVIterator) error {
+		var key []byte
+		values := []interface{}{}
+		for it.Seek([]byte{}); it.Valid(); it.Next() {
+			k := it.Key()
+			i := bytes.IndexByte(k, 0x00)
+			if i < 0 {
+				continue
+			}
+			if key != nil && !bytes.Equal(key, k[:i]) {
+				f(key, values)
+				values = []interface{}{}
+			}
+			key = append([]byte{}, k[:i]...)
+			data, err := it.Value()
+			if err != nil {
+				return err
+			}
+			var v interface{}
+			if err := json.Unmarshal(data, &v); err != nil {
+				return err
+			}
+			values = append(values, v)
+		}
+		if key != nil {
+			f(key, values)
+		}
+		return nil
+	})
+}
+
+// Group renders a template for each traveler and collects the values into
+// lists keyed by the value found at a path. Travelers without a value at the
+// path are dropped
+type Group struct {
+	key       string
+	template  interface{}
+	spillSize int
+}
+
+// NewGroup creates a Group processor
+func NewGroup(key string, template interface{}) *Group {
+	return &Group{key: key, template: template, spillSize: groupSpillSize}
+}
+
+// Process runs the group processor, emitting a render result with the key and
+// values of each group
+func (g *Group) Process(ctx context.Context, man gdbi.Manager, in gdbi.InPipe, out gdbi.OutPipe) context.Context {
+	go func() {
+		defer close(out)
+		c := newGroupCollector(g.spillSize)
+		var err error
+		for t := range in {
+			if t.IsSignal() {
+				out <- t
+				continue
+			}
+			// keep reading after an error, so the upstream steps are not left blocked
+			if err != nil || !jsonpath.TravelerPathExists(t, g.key) {
+				continue
+			}
+			var key []byte
+			key, err = json.Marshal(jsonpath.TravelerPathLookup(t, g.key))
+			if err == nil {
+				err = c.add(man, key, jsonpath.RenderTraveler(t, g.template))
+			}
+		}
+		if err == nil {
+			err = c.emit(func(key []byte, values []interface{}) {
+				var k interface{}
+				json.Unmarshal(key, &k)
+				out <- &gdbi.BaseTraveler{Render: map[string]interface{}{"key": k, "values": values}}
+			})
+		}
+		if err != nil {
+			gdbi.ReportError(ctx, "group", fmt.Errorf("key '%s': %v", g.key, err))
+		}
+	}()
+	return ctx
+}
+
+// Fold renders a template for each traveler and emits a single render result
+// holding the list of values
+type Fold struct {
+	template  interface{}
+	spillSize int
+}
+
+// NewFold creates a Fold processor
+func NewFold(template interface{}) *Fold {
+	return &Fold{template: template, spillSize: groupSpillSize}
+}
+
+// Process runs the fold processor
+func (f *Fold) Process(ctx context.Context, man gdbi.Manager, in gdbi.InPipe, out gdbi.OutPipe) context.Context {
+	go func() {
+		defer close(out)
+		c := newGroupCollector(f.spillSize)
+		var err error
+		for t := range in {
+			if t.IsSignal() {
+				out <- t
+				continue
+			}
+			if err == nil {
+				err = c.add(man, []byte{}, jsonpath.RenderTraveler(t, f.template))
+			}
+		}
+		values := []interface{}{}
+		if err == nil {
+			err = c.emit(func(key []byte, v []interface{}) {
+				values = v
+			})
+		}
+		if err != nil {
+			gdbi.ReportError(ctx, "fold", err)
+			return
+		}
+		out <- &gdbi.BaseTraveler{Render: values}
+	}()
+	return ctx
+}
@@ -0,0 +1,66 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/bmeg/grip/engine"
+	"github.com/bmeg/grip/gdbi"
+)
+
+func runProcessor(p gdbi.Processor, input []gdbi.Traveler) []interface{} {
+	man := engine.NewManager("")
+	defer man.Cleanup()
+	in := make(chan gdbi.Traveler, len(input))
+	for _, t := range input {
+		in <- t
+	}
+	close(in)
+	out := make(chan gdbi.Traveler, 10)
+	p.Process(context.Background(), man, in, out)
+	res := []interface{}{}
+	for t := range out {
+		res = append(res, t.GetRender())
+	}
+	return res
+}
+
+func groupInput(n int) []gdbi.Traveler {
+	input := []gdbi.Traveler{}
+	for i := 0; i < n; i++ {
+		input = append(input, &gdbi.BaseTraveler{Current: &gdbi.DataElement{
+			ID:   fmt.Sprintf("v%d", i),
+			Data: map[string]interface{}{"parity": i % 2},
+		}})
+	}
+	return input
+}
+
+func TestGroupSpill(t *testing.T) {
+	expected := []interface{}{
+		map[string]interface{}{"key": 0.0, "values": []interface{}{"v0", "v2", "v4", "v6", "v8"}},
+		map[string]interface{}{"key": 1.0, "values": []interface{}{"v1", "v3", "v5", "v7", "v9"}},
+	}
+	for _, size := range []int{groupSpillSize, 3} {
+		g := NewGroup("parity", "_gid")
+		g.spillSize = size
+		res := runProcessor(g, groupInput(10))
+		if !reflect.DeepEqual(res, expected) {
+			t.Errorf("spill size %d: unexpected result %#v", size, res)
+		}
+	}
+}
+
+func TestFoldSpill(t *testing.T) {
+	for _, size := range []int{groupSpillSize, 3} {
+		f := NewFold("_gid")
+		f.spillSize = size
+		res := runProcessor(f, groupInput(5))
+		expected := []interface{}{[]interface{}{"v0", "v1", "v2", "v3", "v4"}}
+		if !reflect.DeepEqual(res, expected) {
+			t.Errorf("spill size %d: unexpected result %#v", size, res)
+		}
+	}
+}
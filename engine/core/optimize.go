@@ -1,8 +1,11 @@
 package core
 
 import (
+	"strings"
+
 	"github.com/bmeg/grip/gripql"
 	"github.com/bmeg/grip/jsonpath"
+	"github.com/bmeg/grip/util/geo"
 	"github.com/bmeg/grip/util/protoutil"
 )
 
@@ -15,6 +18,8 @@ func IndexStartOptimize(pipe []*gripql.GraphStatement) []*gripql.GraphStatement
 	//var lookupV *gripql.GraphStatement_V
 	hasIDIdx := []int{}
 	hasLabelIdx := []int{}
+	var geoCond *gripql.HasCondition
+	var timeCond *gripql.HasCondition
 	isDone := false
 	for i, step := range pipe {
 		if isDone {
@@ -46,7 +51,7 @@ func IndexStartOptimize(pipe []*gripql.GraphStatement) []*gripql.GraphStatement
 				newPipe = append(newPipe, pipe[i+1:]...)
 				return IndexStartOptimize(newPipe)
 			}
-			if cond := s.Has.GetCondition(); cond != nil {
+			if cond := s.Has.GetCondition(); cond != nil && cond.ValueRef == "" {
 				path := jsonpath.GetJSONPath(cond.Key)
 				switch path {
 				case "$.gid":
@@ -54,7 +59,15 @@ func IndexStartOptimize(pipe []*gripql.GraphStatement) []*gripql.GraphStatement
 				case "$.label":
 					hasLabelIdx = append(hasLabelIdx, i)
 				default:
-					// do nothing
+					if jsonpath.GetNamespace(cond.Key) != jsonpath.Current || !strings.HasPrefix(path, "$.data.") {
+						break
+					}
+					if geoCond == nil && geo.IsCondition(cond.Condition) {
+						geoCond = cond
+					}
+					if _, _, ok := timeBounds(cond); timeCond == nil && ok {
+						timeCond = cond
+					}
 				}
 			}
 		default:
@@ -91,7 +104,9 @@ func IndexStartOptimize(pipe []*gripql.GraphStatement) []*gripql.GraphStatement
 		}
 		if len(labels) > 0 {
 			labelOpt = true
-			hIdx := &gripql.GraphStatement_LookupVertsIndex{Labels: labels}
+			// the geo and time conditions stay in the pipeline, as an index
+			// may find points outside the shape, or strings outside the range
+			hIdx := &gripql.GraphStatement_LookupVertsIndex{Labels: labels, Geo: geoCond, Time: timeCond}
 			optimized = append(optimized, &gripql.GraphStatement{Statement: hIdx})
 		}
 	}
@@ -482,4 +482,51 @@ func TestIndexStartOptimize(t *testing.T) {
 		t.Log("expected:", spew.Sdump(expected))
 		t.Error("indexStartOptimize returned an unexpected result")
 	}
+
+	// a geo condition is kept, and passed to the lookup for a geo index
+	radius := gripql.GeoRadius("loc", 37.77, -122.42, 5000)
+	expected = []*gripql.GraphStatement{
+		{Statement: &gripql.GraphStatement_LookupVertsIndex{Labels: []string{"foo", "bar"}, Geo: radius.GetCondition()}},
+		{Statement: &gripql.GraphStatement_Has{Has: radius}},
+		{Statement: &gripql.GraphStatement_Out{}},
+	}
+
+	original = []*gripql.GraphStatement{
+		{Statement: &gripql.GraphStatement_V{}},
+		{Statement: &gripql.GraphStatement_Has{Has: radius}},
+		{Statement: &gripql.GraphStatement_HasLabel{HasLabel: protoutil.NewListFromStrings([]string{"foo", "bar"})}},
+		{Statement: &gripql.GraphStatement_Out{}},
+	}
+
+	optimized = IndexStartOptimize(original)
+	if !reflect.DeepEqual(optimized, expected) {
+		t.Log("actual", spew.Sdump(optimized))
+		t.Log("expected:", spew.Sdump(expected))
+		t.Error("indexStartOptimize returned an unexpected result")
+	}
+
+	// a timestamp range condition is kept, and passed to the lookup for a
+	// time index
+	window := gripql.Between("visit_date", []interface{}{"2020-01-01", "2020-02-01"})
+	expected = []*gripql.GraphStatement{
+		{Statement: &gripql.GraphStatement_LookupVertsIndex{Labels: []string{"foo", "bar"}, Time: window.GetCondition()}},
+		{Statement: &gripql.GraphStatement_Has{Has: gripql.Gt("count", 3)}},
+		{Statement: &gripql.GraphStatement_Has{Has: window}},
+		{Statement: &gripql.GraphStatement_Out{}},
+	}
+
+	original = []*gripql.GraphStatement{
+		{Statement: &gripql.GraphStatement_V{}},
+		{Statement: &gripql.GraphStatement_HasLabel{HasLabel: protoutil.NewListFromStrings([]string{"foo", "bar"})}},
+		{Statement: &gripql.GraphStatement_Has{Has: gripql.Gt("count", 3)}},
+		{Statement: &gripql.GraphStatement_Has{Has: window}},
+		{Statement: &gripql.GraphStatement_Out{}},
+	}
+
+	optimized = IndexStartOptimize(original)
+	if !reflect.DeepEqual(optimized, expected) {
+		t.Log("actual", spew.Sdump(optimized))
+		t.Log("expected:", spew.Sdump(expected))
+		t.Error("indexStartOptimize returned an unexpected result")
+	}
 }
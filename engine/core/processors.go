@@ -2,18 +2,25 @@ package core
 
 import (
 	"bytes"
+	"container/heap"
 	"context"
+	"encoding/binary"
+	"encoding/json"
 	"fmt"
 	"math"
 	"reflect"
 	"sort"
+	"strings"
+	"time"
 
 	"github.com/bmeg/grip/engine/logic"
 	"github.com/bmeg/grip/gdbi"
 	"github.com/bmeg/grip/gripql"
 	"github.com/bmeg/grip/jsonpath"
+	"github.com/bmeg/grip/kvi"
 	"github.com/bmeg/grip/log"
 	"github.com/bmeg/grip/util/copy"
+	"github.com/bmeg/grip/util/geo"
 	"github.com/influxdata/tdigest"
 	"github.com/spf13/cast"
 	"golang.org/x/sync/errgroup"
@@ -70,9 +77,65 @@ func (l *LookupVerts) Process(ctx context.Context, man gdbi.Manager, in gdbi.InP
 type LookupVertsIndex struct {
 	db       gdbi.GraphInterface
 	labels   []string
+	geo      *gripql.HasCondition
+	time     *gripql.HasCondition
 	loadData bool
 }
 
+// scan lists the vertices with a label, using a geo or time index for the
+// field of the geo or time condition when the graph has one
+func (l *LookupVertsIndex) scan(ctx context.Context, label string) chan string {
+	if gi, ok := l.db.(gdbi.GeoIndexer); ok && l.geo != nil {
+		if shape, err := geo.ParseShape(l.geo.Condition, l.geo.Value.AsInterface()); err == nil {
+			if ids, ok := gi.VertexGeoScan(ctx, label, l.geo.Key, shape.Bounds()); ok {
+				return ids
+			}
+		}
+	}
+	if ti, ok := l.db.(gdbi.TimeIndexer); ok && l.time != nil {
+		if min, max, ok := timeBounds(l.time); ok {
+			if ids, ok := ti.VertexTimeScan(ctx, label, l.time.Key, min, max); ok {
+				return ids
+			}
+		}
+	}
+	return l.db.VertexLabelScan(ctx, label)
+}
+
+var (
+	// timeScanMin and timeScanMax bound the time index scans of conditions
+	// that only limit one side
+	timeScanMin = time.Unix(-1<<62, 0)
+	timeScanMax = time.Unix(1<<62, 0)
+)
+
+// timeBounds returns the range of timestamps that a GT, GTE, LT, LTE, INSIDE
+// or BETWEEN condition on a timestamp may match. ok is false for other
+// conditions
+func timeBounds(cond *gripql.HasCondition) (time.Time, time.Time, bool) {
+	val := cond.Value.AsInterface()
+	switch cond.Condition {
+	case gripql.Condition_GT, gripql.Condition_GTE:
+		if t, ok := gripql.ParseDateTime(val); ok {
+			return t, timeScanMax, true
+		}
+	case gripql.Condition_LT, gripql.Condition_LTE:
+		if t, ok := gripql.ParseDateTime(val); ok {
+			// the range ends before max, so it has to include an LTE timestamp
+			return timeScanMin, t.Add(time.Nanosecond), true
+		}
+	case gripql.Condition_INSIDE, gripql.Condition_BETWEEN:
+		if vals, ok := val.([]interface{}); ok && len(vals) == 2 {
+			lower, lok := gripql.ParseDateTime(vals[0])
+			upper, uok := gripql.ParseDateTime(vals[1])
+			if lok && uok {
+				return lower, upper, true
+			}
+		}
+	}
+	return time.Time{}, time.Time{}, false
+}
+
 // Process LookupVertsIndex
 func (l *LookupVertsIndex) Process(ctx context.Context, man gdbi.Manager, in gdbi.InPipe, out gdbi.OutPipe) context.Context {
 	queryChan := make(chan gdbi.ElementLookup, 100)
@@ -80,7 +143,7 @@ func (l *LookupVertsIndex) Process(ctx context.Context, man gdbi.Manager, in gdb
 		defer close(queryChan)
 		for t := range in {
 			for _, label := range l.labels {
-				for id := range l.db.VertexLabelScan(ctx, label) {
+				for id := range l.scan(ctx, label) {
 					queryChan <- gdbi.ElementLookup{
 						ID:  id,
 						Ref: t,
@@ -506,7 +569,7 @@ func (r *Unwind) Process(ctx context.Context, man gdbi.Manager, in gdbi.InPipe,
 
 // Has filters based on data
 type Has struct {
-	stmt *gripql.HasExpression
+	match *logic.HasMatcher
 }
 
 // Process runs Has
@@ -518,7 +581,7 @@ func (w *Has) Process(ctx context.Context, man gdbi.Manager, in gdbi.InPipe, out
 				out <- t
 				continue
 			}
-			if logic.MatchesHasExpression(t, w.stmt) {
+			if w.match.Matches(t) {
 				out <- t
 			}
 		}
@@ -757,6 +820,290 @@ func (g *Distinct) Process(ctx context.Context, man gdbi.Manager, in gdbi.InPipe
 
 ////////////////////////////////////////////////////////////////////////////////
 
+// sortSpillSize is the number of travelers a sort without a limit keeps in
+// memory. Larger inputs are sorted in runs of this size, which are written to
+// a temporary key/value store and merged
+const sortSpillSize = 100000
+
+// Sort orders travelers by the values of a set of fields. When the sort is
+// followed by a limit, only the first limit travelers are kept
+type Sort struct {
+	fields     []string
+	descending []bool
+	limit      uint32
+	spillSize  int
+}
+
+// NewSort creates a Sort processor. Fields starting with "-" are sorted in
+// descending order
+func NewSort(fields []string) *Sort {
+	s := &Sort{spillSize: sortSpillSize}
+	for _, f := range fields {
+		s.descending = append(s.descending, strings.HasPrefix(f, "-"))
+		s.fields = append(s.fields, strings.TrimPrefix(f, "-"))
+	}
+	return s
+}
+
+type sortItem struct {
+	values []interface{}
+	pos    int
+	trav   gdbi.Traveler
+	// the run holding the item, and the position of the next item of the
+	// run, when merging spilled runs
+	run  int
+	next int
+}
+
+func (s *Sort) item(t gdbi.Traveler, pos int) *sortItem {
+	item := &sortItem{values: make([]interface{}, len(s.fields)), pos: pos, trav: t}
+	for i, f := range s.fields {
+		item.values[i] = jsonpath.TravelerPathLookup(t, f)
+	}
+	return item
+}
+
+// less orders items by their values, and then by the order they arrived in
+func (s *Sort) less(a, b *sortItem) bool {
+	for i := range s.fields {
+		c := logic.SortCompare(a.values[i], b.values[i])
+		if s.descending[i] {
+			c = -c
+		}
+		if c != 0 {
+			return c < 0
+		}
+	}
+	return a.pos < b.pos
+}
+
+// sortHeap keeps the last item on top, so it can be dropped when a better
+// one arrives. When first is set it keeps the first item on top instead
+type sortHeap struct {
+	s     *Sort
+	items []*sortItem
+	first bool
+}
+
+func (h *sortHeap) Len() int { return len(h.items) }
+func (h *sortHeap) Less(i, j int) bool {
+	if h.first {
+		return h.s.less(h.items[i], h.items[j])
+	}
+	return h.s.less(h.items[j], h.items[i])
+}
+func (h *sortHeap) Swap(i, j int)      { h.items[i], h.items[j] = h.items[j], h.items[i] }
+func (h *sortHeap) Push(x interface{}) { h.items = append(h.items, x.(*sortItem)) }
+func (h *sortHeap) Pop() interface{} {
+	x := h.items[len(h.items)-1]
+	h.items = h.items[:len(h.items)-1]
+	return x
+}
+
+// sortRuns are sorted runs of travelers written to a temporary key/value
+// store, keyed by their run and their position in the run
+type sortRuns struct {
+	kv    kvi.KVInterface
+	sizes []int
+}
+
+type sortRecord struct {
+	Pos      int             `json:"pos"`
+	Traveler json.RawMessage `json:"traveler"`
+}
+
+func sortRunKey(run, i int) []byte {
+	key := make([]byte, 16)
+	binary.BigEndian.PutUint64(key, uint64(run))
+	binary.BigEndian.PutUint64(key[8:], uint64(i))
+	return key
+}
+
+// spill sorts items and writes them as a new run
+func (r *sortRuns) spill(man gdbi.Manager, s *Sort, items []*sortItem) error {
+	if r.kv == nil {
+		r.kv = man.GetTempKV()
+	}
+	sort.Slice(items, func(i, j int) bool {
+		return s.less(items[i], items[j])
+	})
+	run := len(r.sizes)
+	r.sizes = append(r.sizes, len(items))
+	return r.kv.BulkWrite(func(bl kvi.KVBulkWrite) error {
+		for i, item := range items {
+			t, err := json.Marshal(item.trav)
+			if err != nil {
+				return err
+			}
+			data, err := json.Marshal(sortRecord{Pos: item.pos, Traveler: t})
+			if err != nil {
+				return err
+			}
+			if err := bl.Set(sortRunKey(run, i), data); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// merge emits the travelers of all runs in order. Only the first item of
+// each run is held in memory
+func (r *sortRuns) merge(s *Sort, emit func(gdbi.Traveler)) error {
+	return r.kv.View(func(it kvi.KVIterator) error {
+		read := func(run, i int) (*sortItem, error) {
+			data, err := it.Get(sortRunKey(run, i))
+			if err != nil {
+				return nil, err
+			}
+			rec := sortRecord{}
+			if err := json.Unmarshal(data, &rec); err != nil {
+				return nil, err
+			}
+			t := &gdbi.BaseTraveler{}
+			if err := json.Unmarshal(rec.Traveler, t); err != nil {
+				return nil, err
+			}
+			item := s.item(t, rec.Pos)
+			item.run = run
+			item.next = i + 1
+			return item, nil
+		}
+		h := &sortHeap{s: s, first: true}
+		for run, size := range r.sizes {
+			if size == 0 {
+				continue
+			}
+			item, err := read(run, 0)
+			if err != nil {
+				return err
+			}
+			h.items = append(h.items, item)
+		}
+		heap.Init(h)
+		for h.Len() > 0 {
+			item := h.items[0]
+			emit(item.trav)
+			if item.next < r.sizes[item.run] {
+				next, err := read(item.run, item.next)
+				if err != nil {
+					return err
+				}
+				h.items[0] = next
+				heap.Fix(h, 0)
+			} else {
+				heap.Pop(h)
+			}
+		}
+		return nil
+	})
+}
+
+// Process runs sort. When there is no limit, travelers are spilled to a
+// temporary key/value store in sorted runs of spillSize, so memory use is
+// bounded by spillSize travelers
+func (s *Sort) Process(ctx context.Context, man gdbi.Manager, in gdbi.InPipe, out gdbi.OutPipe) context.Context {
+	go func() {
+		defer close(out)
+		h := &sortHeap{s: s}
+		runs := &sortRuns{}
+		var err error
+		pos := 0
+		for t := range in {
+			if t.IsSignal() {
+				out <- t
+				continue
+			}
+			// keep reading after an error, so the upstream steps are not left blocked
+			if err != nil {
+				continue
+			}
+			item := s.item(t, pos)
+			pos++
+			if s.limit == 0 {
+				h.items = append(h.items, item)
+				if len(h.items) >= s.spillSize {
+					err = runs.spill(man, s, h.items)
+					h.items = nil
+				}
+				continue
+			}
+			if uint32(h.Len()) < s.limit {
+				heap.Push(h, item)
+			} else if s.less(item, h.items[0]) {
+				h.items[0] = item
+				heap.Fix(h, 0)
+			}
+		}
+		if err == nil && len(runs.sizes) > 0 {
+			if len(h.items) > 0 {
+				err = runs.spill(man, s, h.items)
+			}
+			if err == nil {
+				err = runs.merge(s, func(t gdbi.Traveler) {
+					out <- t
+				})
+			}
+		} else if err == nil {
+			sort.Slice(h.items, func(i, j int) bool {
+				return s.less(h.items[i], h.items[j])
+			})
+			for _, i := range h.items {
+				out <- i.trav
+			}
+		}
+		if err != nil {
+			gdbi.ReportError(ctx, "sort", err)
+		}
+	}()
+	return ctx
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// Seek keeps the travelers that come after a position in the order of a sort
+// step with the same fields. Relay cursors use it to start a page after the
+// last element of the previous one
+type Seek struct {
+	sort     *Sort
+	position []interface{}
+}
+
+// NewSeek creates a Seek processor. The position holds a value for each field
+func NewSeek(fields []string, position []interface{}) *Seek {
+	return &Seek{sort: NewSort(fields), position: position}
+}
+
+// after tests if the traveler comes after the position
+func (s *Seek) after(t gdbi.Traveler) bool {
+	item := s.sort.item(t, 0)
+	for i := range s.sort.fields {
+		c := logic.SortCompare(item.values[i], s.position[i])
+		if s.sort.descending[i] {
+			c = -c
+		}
+		if c != 0 {
+			return c > 0
+		}
+	}
+	return false
+}
+
+// Process runs seek
+func (s *Seek) Process(ctx context.Context, man gdbi.Manager, in gdbi.InPipe, out gdbi.OutPipe) context.Context {
+	go func() {
+		defer close(out)
+		for t := range in {
+			if t.IsSignal() || s.after(t) {
+				out <- t
+			}
+		}
+	}()
+	return ctx
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
 // Marker marks the current element
 type Marker struct {
 	mark string
@@ -994,7 +1341,7 @@ func (agg *aggregate) Process(ctx context.Context, man gdbi.Manager, in gdbi.InP
 				fieldTermCounts := map[interface{}]int{}
 				for t := range aChans[a.Name] {
 					if len(fieldTermCounts) > maxTerms {
-						outErr = fmt.Errorf("term aggreagtion: collected more unique terms (%v) than allowed (%v)", len(fieldTermCounts), maxTerms)
+						outErr = fmt.Errorf("term aggregation '%s': collected more unique terms (%v) than allowed (%v): %w", a.Name, len(fieldTermCounts), maxTerms, gdbi.ErrLimit)
 					} else {
 						val := jsonpath.TravelerPathLookup(t, tagg.Field)
 						if val != nil {
@@ -1038,11 +1385,11 @@ func (agg *aggregate) Process(ctx context.Context, man gdbi.Manager, in gdbi.InP
 					if val != nil {
 						fval, err := cast.ToFloat64E(val)
 						if err != nil {
-							outErr = fmt.Errorf("histogram aggregation: can't convert %v to float64", val)
+							outErr = fmt.Errorf("histogram aggregation '%s': can't convert %v to float64", a.Name, val)
 						}
 						fieldValues = append(fieldValues, fval)
 						if c > maxValues {
-							outErr = fmt.Errorf("histogram aggreagtion: collected more values (%v) than allowed (%v)", c, maxValues)
+							outErr = fmt.Errorf("histogram aggregation '%s': collected more values (%v) than allowed (%v): %w", a.Name, c, maxValues, gdbi.ErrLimit)
 						}
 						c++
 					}
@@ -1064,6 +1411,100 @@ func (agg *aggregate) Process(ctx context.Context, man gdbi.Manager, in gdbi.InP
 				return outErr
 			})
 
+		case *gripql.Aggregate_DateHistogram:
+
+			g.Go(func() error {
+				dagg := a.GetDateHistogram()
+
+				var outErr error
+				bucketCounts := map[time.Time]int{}
+				var min, max time.Time
+				for t := range aChans[a.Name] {
+					val := jsonpath.TravelerPathLookup(t, dagg.Field)
+					if val == nil {
+						continue
+					}
+					ts, ok := gripql.ParseDateTime(val)
+					if !ok {
+						outErr = fmt.Errorf("date histogram aggregation '%s': %v is not an RFC3339 timestamp", a.Name, val)
+						continue
+					}
+					bucket, _, err := gripql.DateBucket(ts, dagg.Interval)
+					if err != nil {
+						outErr = fmt.Errorf("date histogram aggregation '%s': %v", a.Name, err)
+						continue
+					}
+					if len(bucketCounts) == 0 || bucket.Before(min) {
+						min = bucket
+					}
+					if len(bucketCounts) == 0 || bucket.After(max) {
+						max = bucket
+					}
+					bucketCounts[bucket]++
+				}
+				if len(bucketCounts) == 0 {
+					return outErr
+				}
+
+				// empty buckets are filled in, unless the span is too wide
+				buckets := []time.Time{}
+				if gripql.DateBucketCount(min, max, dagg.Interval) <= gripql.MaxDateBuckets {
+					for bucket := min; !bucket.After(max); _, bucket, _ = gripql.DateBucket(bucket, dagg.Interval) {
+						buckets = append(buckets, bucket)
+					}
+				} else {
+					for bucket := range bucketCounts {
+						buckets = append(buckets, bucket)
+					}
+					sort.Slice(buckets, func(i, j int) bool { return buckets[i].Before(buckets[j]) })
+				}
+				for _, bucket := range buckets {
+					key := bucket.Format(time.RFC3339)
+					out <- &gdbi.BaseTraveler{Aggregation: &gdbi.Aggregate{Name: a.Name, Key: key, Value: float64(bucketCounts[bucket])}}
+				}
+				return outErr
+			})
+
+		case *gripql.Aggregate_GeohashGrid:
+
+			g.Go(func() error {
+				gagg := a.GetGeohashGrid()
+				precision := int(gagg.Precision)
+				if precision == 0 {
+					precision = geo.DefaultPrecision
+				}
+
+				var outErr error
+				cellCounts := map[string]int{}
+				for t := range aChans[a.Name] {
+					val := jsonpath.TravelerPathLookup(t, gagg.Field)
+					if val == nil {
+						continue
+					}
+					p, ok := geo.ParsePoint(val)
+					if !ok {
+						outErr = fmt.Errorf("geohash grid aggregation '%s': %v is not a point", a.Name, val)
+						continue
+					}
+					cellCounts[geo.Geohash(p, precision)]++
+				}
+
+				cells := make([]string, 0, len(cellCounts))
+				for cell := range cellCounts {
+					cells = append(cells, cell)
+				}
+				sort.Slice(cells, func(i, j int) bool {
+					if cellCounts[cells[i]] != cellCounts[cells[j]] {
+						return cellCounts[cells[i]] > cellCounts[cells[j]]
+					}
+					return cells[i] < cells[j]
+				})
+				for _, cell := range cells {
+					out <- &gdbi.BaseTraveler{Aggregation: &gdbi.Aggregate{Name: a.Name, Key: cell, Value: float64(cellCounts[cell])}}
+				}
+				return outErr
+			})
+
 		case *gripql.Aggregate_Percentile:
 
 			g.Go(func() error {
@@ -1076,7 +1517,7 @@ func (agg *aggregate) Process(ctx context.Context, man gdbi.Manager, in gdbi.InP
 					val := jsonpath.TravelerPathLookup(t, pagg.Field)
 					fval, err := cast.ToFloat64E(val)
 					if err != nil {
-						outErr = fmt.Errorf("percentile aggregation: can't convert %v to float64", val)
+						outErr = fmt.Errorf("percentile aggregation '%s': can't convert %v to float64", a.Name, val)
 					}
 					td.Add(fval, 1)
 				}
@@ -1141,7 +1582,7 @@ func (agg *aggregate) Process(ctx context.Context, man gdbi.Manager, in gdbi.InP
 
 	go func() {
 		if err := g.Wait(); err != nil {
-			log.WithFields(log.Fields{"error": err}).Error("one or more aggregation failed")
+			gdbi.ReportError(ctx, "aggregate", err)
 		}
 		close(out)
 	}()
@@ -66,6 +66,8 @@ func (agg *aggregateDisk) Process(ctx context.Context, man gdbi.Manager, in gdbi
 				field = strings.TrimPrefix(field, "$.")
 				idx.AddField(field)
 
+				// keep reading after an error, so the upstream steps are not left blocked
+				var outErr error
 				tid := 0
 				for batch := range aChans[a.Name] {
 					err := kv.Update(func(tx kvi.KVTransaction) error {
@@ -80,7 +82,7 @@ func (agg *aggregateDisk) Process(ctx context.Context, man gdbi.Manager, in gdbi
 						return nil
 					})
 					if err != nil {
-						log.Errorf("Error: aggregation index: %s", err)
+						outErr = fmt.Errorf("term aggregation '%s': index: %v", a.Name, err)
 					}
 				}
 
@@ -97,7 +99,7 @@ func (agg *aggregateDisk) Process(ctx context.Context, man gdbi.Manager, in gdbi
 					}
 					count++
 				}
-				return nil
+				return outErr
 			})
 
 		case *gripql.Aggregate_Histogram:
@@ -112,6 +114,7 @@ func (agg *aggregateDisk) Process(ctx context.Context, man gdbi.Manager, in gdbi
 				field = strings.TrimPrefix(field, "$.")
 				idx.AddField(field)
 
+				var outErr error
 				tid := 0
 				for batch := range aChans[a.Name] {
 					err := kv.Update(func(tx kvi.KVTransaction) error {
@@ -126,7 +129,7 @@ func (agg *aggregateDisk) Process(ctx context.Context, man gdbi.Manager, in gdbi
 						return nil
 					})
 					if err != nil {
-						log.Errorf("Error: aggregation index: %s", err)
+						outErr = fmt.Errorf("histogram aggregation '%s': index: %v", a.Name, err)
 					}
 				}
 
@@ -142,7 +145,7 @@ func (agg *aggregateDisk) Process(ctx context.Context, man gdbi.Manager, in gdbi
 					sBucket, _ := structpb.NewValue(bucket)
 					out <- &gdbi.BaseTraveler{Aggregation: &gdbi.Aggregate{Name: a.Name, Key: sBucket, Value: float64(count)}}
 				}
-				return nil
+				return outErr
 			})
 
 		case *gripql.Aggregate_Percentile:
@@ -157,6 +160,7 @@ func (agg *aggregateDisk) Process(ctx context.Context, man gdbi.Manager, in gdbi
 				field = strings.TrimPrefix(field, "$.")
 				idx.AddField(field)
 
+				var outErr error
 				tid := 0
 				for batch := range aChans[a.Name] {
 					err := kv.Update(func(tx kvi.KVTransaction) error {
@@ -171,7 +175,7 @@ func (agg *aggregateDisk) Process(ctx context.Context, man gdbi.Manager, in gdbi
 						return nil
 					})
 					if err != nil {
-						log.Errorf("Error: aggregation index: %s", err)
+						outErr = fmt.Errorf("percentile aggregation '%s': index: %v", a.Name, err)
 					}
 				}
 
@@ -185,7 +189,7 @@ func (agg *aggregateDisk) Process(ctx context.Context, man gdbi.Manager, in gdbi
 					sp, _ := structpb.NewValue(p)
 					out <- &gdbi.BaseTraveler{Aggregation: &gdbi.Aggregate{Name: a.Name, Key: sp, Value: q}}
 				}
-				return nil
+				return outErr
 			})
 
 		default:
@@ -196,7 +200,7 @@ func (agg *aggregateDisk) Process(ctx context.Context, man gdbi.Manager, in gdbi
 
 	go func() {
 		if err := g.Wait(); err != nil {
-			log.WithFields(log.Fields{"error": err}).Error("one or more aggregation failed")
+			gdbi.ReportError(ctx, "aggregate", err)
 		}
 		close(out)
 	}()
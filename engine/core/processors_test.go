@@ -0,0 +1,309 @@
+package core
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/bmeg/grip/engine"
+	"github.com/bmeg/grip/gdbi"
+	"github.com/bmeg/grip/gripql"
+)
+
+func TestDateHistogram(t *testing.T) {
+	dates := []interface{}{
+		"2014-12-09T13:50:51.644000Z",
+		"2014-12-31T23:30:00-01:00",
+		"2015-02-10",
+		"2015-02-28T10:00:00Z",
+		nil,
+	}
+	tests := []struct {
+		interval string
+		expected map[string]float64
+	}{
+		{gripql.DateIntervalMonth, map[string]float64{
+			"2014-12-01T00:00:00Z": 1,
+			"2015-01-01T00:00:00Z": 1,
+			"2015-02-01T00:00:00Z": 2,
+		}},
+		{gripql.DateIntervalYear, map[string]float64{
+			"2014-01-01T00:00:00Z": 1,
+			"2015-01-01T00:00:00Z": 3,
+		}},
+	}
+	for _, tt := range tests {
+		agg := &aggregate{[]*gripql.Aggregate{{
+			Name:        "created",
+			Aggregation: &gripql.Aggregate_DateHistogram{DateHistogram: &gripql.DateHistogramAggregation{Field: "created", Interval: tt.interval}},
+		}}}
+		in := make(chan gdbi.Traveler, len(dates))
+		for _, d := range dates {
+			data := map[string]interface{}{}
+			if d != nil {
+				data["created"] = d
+			}
+			in <- &gdbi.BaseTraveler{Current: &gdbi.DataElement{ID: "v", Data: data}}
+		}
+		close(in)
+		out := make(chan gdbi.Traveler, 10)
+		man := engine.NewManager("")
+		agg.Process(context.Background(), man, in, out)
+		res := map[string]float64{}
+		for t := range out {
+			a := t.GetAggregation()
+			res[a.Key.(string)] = a.Value
+		}
+		man.Cleanup()
+		if !reflect.DeepEqual(res, tt.expected) {
+			t.Errorf("%s: unexpected buckets %v", tt.interval, res)
+		}
+	}
+}
+
+func TestGeohashGrid(t *testing.T) {
+	points := []interface{}{
+		map[string]interface{}{"lat": 57.64911, "lon": 10.40744},
+		map[string]interface{}{"type": "Point", "coordinates": []interface{}{10.4, 57.65}},
+		[]interface{}{-5.6, 42.6},
+		nil,
+	}
+	agg := &aggregate{[]*gripql.Aggregate{{
+		Name:        "loc",
+		Aggregation: &gripql.Aggregate_GeohashGrid{GeohashGrid: &gripql.GeohashGridAggregation{Field: "loc", Precision: 3}},
+	}}}
+	in := make(chan gdbi.Traveler, len(points))
+	for _, p := range points {
+		data := map[string]interface{}{}
+		if p != nil {
+			data["loc"] = p
+		}
+		in <- &gdbi.BaseTraveler{Current: &gdbi.DataElement{ID: "v", Data: data}}
+	}
+	close(in)
+	out := make(chan gdbi.Traveler, 10)
+	man := engine.NewManager("")
+	agg.Process(context.Background(), man, in, out)
+	keys := []string{}
+	res := map[string]float64{}
+	for t := range out {
+		a := t.GetAggregation()
+		keys = append(keys, a.Key.(string))
+		res[a.Key.(string)] = a.Value
+	}
+	man.Cleanup()
+	if !reflect.DeepEqual(res, map[string]float64{"u4p": 2, "ezs": 1}) {
+		t.Errorf("unexpected cells %v", res)
+	}
+	if !reflect.DeepEqual(keys, []string{"u4p", "ezs"}) {
+		t.Errorf("expected cells by count, found %v", keys)
+	}
+}
+
+func TestDateHistogramWideSpan(t *testing.T) {
+	agg := &aggregate{[]*gripql.Aggregate{{
+		Name:        "created",
+		Aggregation: &gripql.Aggregate_DateHistogram{DateHistogram: &gripql.DateHistogramAggregation{Field: "created", Interval: gripql.DateIntervalDay}},
+	}}}
+	in := make(chan gdbi.Traveler, 3)
+	for _, d := range []string{"1900-01-01", "2020-05-05T10:00:00Z", "1900-01-01T12:00:00Z"} {
+		in <- &gdbi.BaseTraveler{Current: &gdbi.DataElement{ID: "v", Data: map[string]interface{}{"created": d}}}
+	}
+	close(in)
+	out := make(chan gdbi.Traveler, 10)
+	man := engine.NewManager("")
+	agg.Process(context.Background(), man, in, out)
+	keys := []string{}
+	for t := range out {
+		keys = append(keys, t.GetAggregation().Key.(string))
+	}
+	man.Cleanup()
+	// the empty days between them aren't filled in
+	if !reflect.DeepEqual(keys, []string{"1900-01-01T00:00:00Z", "2020-05-05T00:00:00Z"}) {
+		t.Errorf("unexpected buckets %v", keys)
+	}
+}
+
+func TestSortSpill(t *testing.T) {
+	tests := []struct {
+		fields   []string
+		expected []string
+	}{
+		{[]string{"parity"}, []string{"v0", "v2", "v4", "v6", "v8", "v1", "v3", "v5", "v7", "v9"}},
+		{[]string{"-parity", "-_gid"}, []string{"v9", "v7", "v5", "v3", "v1", "v8", "v6", "v4", "v2", "v0"}},
+	}
+	for _, tt := range tests {
+		for _, size := range []int{sortSpillSize, 3} {
+			s := NewSort(tt.fields)
+			s.spillSize = size
+			man := engine.NewManager(t.TempDir())
+			input := groupInput(10)
+			in := make(chan gdbi.Traveler, len(input))
+			for _, t := range input {
+				in <- t
+			}
+			close(in)
+			out := make(chan gdbi.Traveler, 10)
+			s.Process(context.Background(), man, in, out)
+			res := []string{}
+			for t := range out {
+				res = append(res, t.GetCurrentID())
+			}
+			man.Cleanup()
+			if !reflect.DeepEqual(res, tt.expected) {
+				t.Errorf("%v, spill size %d: unexpected order %v", tt.fields, size, res)
+			}
+		}
+	}
+}
+
+func TestSeek(t *testing.T) {
+	tests := []struct {
+		fields   []string
+		position []interface{}
+		expected []string
+	}{
+		{[]string{"parity", "_gid"}, []interface{}{0.0, "v4"}, []string{"v1", "v3", "v5", "v6", "v7", "v8", "v9"}},
+		{[]string{"-parity", "-_gid"}, []interface{}{1.0, "v3"}, []string{"v0", "v1", "v2", "v4", "v6", "v8"}},
+		{[]string{"parity", "_gid"}, []interface{}{nil, nil}, []string{"v0", "v1", "v2", "v3", "v4", "v5", "v6", "v7", "v8", "v9"}},
+		// numbers come before strings
+		{[]string{"parity"}, []interface{}{"0"}, []string{}},
+	}
+	for _, tt := range tests {
+		input := groupInput(10)
+		in := make(chan gdbi.Traveler, len(input))
+		for _, t := range input {
+			in <- t
+		}
+		close(in)
+		out := make(chan gdbi.Traveler, 10)
+		NewSeek(tt.fields, tt.position).Process(context.Background(), nil, in, out)
+		res := []string{}
+		for t := range out {
+			res = append(res, t.GetCurrentID())
+		}
+		if !reflect.DeepEqual(res, tt.expected) {
+			t.Errorf("%v after %v: unexpected result %v", tt.fields, tt.position, res)
+		}
+	}
+}
+
+func TestInvalidGeoShape(t *testing.T) {
+	comp := NewCompiler(nil)
+	jump := gripql.NewQuery().V().Out()
+	jump.Statements = append(jump.Statements, &gripql.GraphStatement{Statement: &gripql.GraphStatement_Jump{
+		Jump: &gripql.Jump{Mark: "a", Expression: gripql.Not(gripql.GeoBox("loc", 10, 0, -10, 1))},
+	}})
+	for _, q := range []*gripql.Query{
+		gripql.NewQuery().V().Has(gripql.GeoRadius("loc", 37.77, -122.42, -1)),
+		jump,
+	} {
+		if _, err := comp.Compile(q.Statements, nil); err == nil {
+			t.Errorf("expected error compiling %s", q.String())
+		}
+	}
+}
+
+// repeatProc emits its input count times, and then waits for the context to
+// be cancelled if wait is set
+type repeatProc struct {
+	count   int
+	wait    bool
+	skip    map[string]bool
+	emitted int
+}
+
+func (r *repeatProc) Process(ctx context.Context, man gdbi.Manager, in gdbi.InPipe, out gdbi.OutPipe) context.Context {
+	go func() {
+		defer close(out)
+		travelers := []gdbi.Traveler{}
+		for t := range in {
+			if !r.skip[t.GetCurrent().ID] {
+				travelers = append(travelers, t)
+			}
+		}
+		for i := 0; i < r.count; i++ {
+			for _, t := range travelers {
+				r.emitted++
+				select {
+				case out <- t:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+		if r.wait {
+			<-ctx.Done()
+		}
+	}()
+	return ctx
+}
+
+func TestMatchSubPipeline(t *testing.T) {
+	batch := []gdbi.Traveler{}
+	for _, id := range []string{"a", "b", "c"} {
+		batch = append(batch, &gdbi.BaseTraveler{Current: &gdbi.DataElement{ID: id}})
+	}
+	proc := &repeatProc{count: 10, skip: map[string]bool{"b": true}}
+	pipe := &DefaultPipeline{procs: []gdbi.Processor{proc}, dataType: gdbi.VertexData}
+	man := engine.NewManager("")
+	defer man.Cleanup()
+	found := matchSubPipeline(context.Background(), man, pipe, batch)
+	if !reflect.DeepEqual(found, []bool{true, false, true}) {
+		t.Errorf("unexpected matches: %v", found)
+	}
+
+	// once every traveler has a result the subquery is stopped, so this
+	// only returns before the timeout if the rest of the results are dropped
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	proc = &repeatProc{count: 1000, wait: true}
+	pipe.procs = []gdbi.Processor{proc}
+	found = matchSubPipeline(ctx, man, pipe, batch)
+	if ctx.Err() != nil {
+		t.Fatal("subquery was not stopped")
+	}
+	if !reflect.DeepEqual(found, []bool{true, true, true}) {
+		t.Errorf("unexpected matches: %v", found)
+	}
+	if proc.emitted >= proc.count*len(batch) {
+		t.Errorf("subquery ran to the end: %d results", proc.emitted)
+	}
+}
+
+func TestBranchOrder(t *testing.T) {
+	man := engine.NewManager("")
+	defer man.Cleanup()
+	pipe := func(count int, skip ...string) *DefaultPipeline {
+		p := &repeatProc{count: count, skip: map[string]bool{}}
+		for _, s := range skip {
+			p.skip[s] = true
+		}
+		return &DefaultPipeline{procs: []gdbi.Processor{p}, dataType: gdbi.VertexData}
+	}
+	tests := []struct {
+		branch   *Branch
+		expected []string
+	}{
+		{&Branch{mode: branchUnion, pipes: []*DefaultPipeline{pipe(2), pipe(1, "a")}}, []string{"a", "a", "b", "b", "b", "c", "c", "c"}},
+		{&Branch{mode: branchOptional, pipes: []*DefaultPipeline{pipe(2, "b")}}, []string{"a", "a", "b", "c", "c"}},
+		{&Branch{mode: branchCoalesce, pipes: []*DefaultPipeline{pipe(2, "b"), pipe(3)}}, []string{"a", "a", "b", "b", "b", "c", "c"}},
+	}
+	for _, tt := range tests {
+		in := make(chan gdbi.Traveler, 3)
+		for _, id := range []string{"a", "b", "c"} {
+			in <- &gdbi.BaseTraveler{Current: &gdbi.DataElement{ID: id}}
+		}
+		close(in)
+		out := make(chan gdbi.Traveler, 10)
+		tt.branch.Process(context.Background(), man, in, out)
+		res := []string{}
+		for r := range out {
+			res = append(res, r.GetCurrent().ID)
+		}
+		if !reflect.DeepEqual(res, tt.expected) {
+			t.Errorf("%s: expected %v, got %v", tt.branch.mode, tt.expected, res)
+		}
+	}
+}
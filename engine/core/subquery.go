@@ -0,0 +1,344 @@
+package core
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/bmeg/grip/engine/pipeline"
+	"github.com/bmeg/grip/gdbi"
+	"github.com/bmeg/grip/gripql"
+)
+
+// subQueryMark is the mark used to find the traveler a result of a subquery
+// came from. The index of the traveler is kept in the data of the mark, which
+// is carried over by every traveler implementation
+const subQueryMark = "__subquery__"
+
+// subQueryBatchSize is the largest number of travelers sent through a
+// subquery at once
+const subQueryBatchSize = 1000
+
+// checkSubQueryStatement returns an error for statements whose results depend
+// on more than one traveler, or that drop the marks of the traveler, which
+// can't be used in a subquery
+func checkSubQueryStatement(gs *gripql.GraphStatement) error {
+	switch gs.GetStatement().(type) {
+	case *gripql.GraphStatement_In, *gripql.GraphStatement_Out, *gripql.GraphStatement_Both,
+		*gripql.GraphStatement_InE, *gripql.GraphStatement_OutE, *gripql.GraphStatement_BothE,
+		*gripql.GraphStatement_InNull, *gripql.GraphStatement_OutNull,
+		*gripql.GraphStatement_InENull, *gripql.GraphStatement_OutENull,
+		*gripql.GraphStatement_Has, *gripql.GraphStatement_HasLabel, *gripql.GraphStatement_HasId,
+		*gripql.GraphStatement_HasKey, *gripql.GraphStatement_As, *gripql.GraphStatement_Unwind,
+		*gripql.GraphStatement_Where, *gripql.GraphStatement_Not,
+		*gripql.GraphStatement_Union, *gripql.GraphStatement_Optional, *gripql.GraphStatement_Coalesce:
+		return nil
+	case *gripql.GraphStatement_Select:
+		if len(gs.GetSelect().Marks) == 1 {
+			return nil
+		}
+	}
+	name := "unknown"
+	m := gs.ProtoReflect()
+	if fd := m.WhichOneof(m.Descriptor().Oneofs().ByName("statement")); fd != nil {
+		name = fd.JSONName()
+	}
+	return fmt.Errorf(`"%s" statement can't be used in a subquery`, name)
+}
+
+// compileSubPipeline compiles the statements of a subquery, which start from
+// the current element of the enclosing pipeline. If loadData is false, the
+// elements the subquery ends on are not loaded
+func compileSubPipeline(name string, stmts []*gripql.GraphStatement, loadData bool, db gdbi.GraphInterface, ps *pipeline.State) (*DefaultPipeline, error) {
+	for _, gs := range stmts {
+		if err := checkSubQueryStatement(gs); err != nil {
+			return nil, fmt.Errorf(`"%s" statement invalid; %v`, name, err)
+		}
+	}
+	inspected := stmts
+	if !loadData {
+		inspected = append(stmts[:len(stmts):len(stmts)], &gripql.GraphStatement{Statement: &gripql.GraphStatement_Count{}})
+	}
+	sps := pipeline.NewPipelineState(inspected)
+	sps.LastType = ps.LastType
+	for k, v := range ps.MarkTypes {
+		sps.MarkTypes[k] = v
+	}
+	procs := make([]gdbi.Processor, 0, len(stmts))
+	for i, gs := range stmts {
+		sps.SetCurStatment(i)
+		p, err := StatementProcessor(gs, db, sps)
+		if err != nil {
+			return nil, fmt.Errorf(`"%s" statement invalid; %v`, name, err)
+		}
+		procs = append(procs, p)
+	}
+	return &DefaultPipeline{db, procs, sps.LastType, sps.MarkTypes}, nil
+}
+
+// compileSubQuery compiles a where or not statement
+func compileSubQuery(stmts []*gripql.GraphStatement, negate bool, db gdbi.GraphInterface, ps *pipeline.State) (gdbi.Processor, error) {
+	name := "where"
+	if negate {
+		name = "not"
+	}
+	if ps.LastType != gdbi.VertexData && ps.LastType != gdbi.EdgeData {
+		return nil, fmt.Errorf(`"%s" statement is only valid for edge or vertex types not: %s`, name, ps.LastType.String())
+	}
+	if len(stmts) == 0 {
+		return nil, fmt.Errorf(`"%s" statement has an empty subquery`, name)
+	}
+	// only the presence of results is used
+	pipe, err := compileSubPipeline(name, stmts, false, db, ps)
+	if err != nil {
+		return nil, err
+	}
+	return &SubQuery{pipe: pipe, negate: negate}, nil
+}
+
+// compileBranches compiles a union, optional or coalesce statement. Every
+// branch has to end on the same type of element
+func compileBranches(mode branchMode, subs []*gripql.SubQuery, db gdbi.GraphInterface, ps *pipeline.State) (gdbi.Processor, error) {
+	name := mode.String()
+	if ps.LastType != gdbi.VertexData && ps.LastType != gdbi.EdgeData {
+		return nil, fmt.Errorf(`"%s" statement is only valid for edge or vertex types not: %s`, name, ps.LastType.String())
+	}
+	if len(subs) == 0 {
+		return nil, fmt.Errorf(`"%s" statement has no subqueries`, name)
+	}
+	loadData := ps.StepLoadData()
+	outType := gdbi.NoData
+	if mode == branchOptional {
+		outType = ps.LastType
+	}
+	markTypes := map[string]gdbi.DataType{}
+	pipes := make([]*DefaultPipeline, 0, len(subs))
+	for _, sub := range subs {
+		pipe, err := compileSubPipeline(name, sub.Query, loadData, db, ps)
+		if err != nil {
+			return nil, err
+		}
+		if len(sub.Query) == 0 {
+			pipe.dataType = ps.LastType
+		}
+		if outType == gdbi.NoData {
+			outType = pipe.dataType
+		} else if pipe.dataType != outType {
+			return nil, fmt.Errorf(`"%s" statement invalid; subqueries end on %s and %s`, name, outType.String(), pipe.dataType.String())
+		}
+		for k, v := range pipe.markTypes {
+			markTypes[k] = v
+		}
+		pipes = append(pipes, pipe)
+	}
+	for k, v := range markTypes {
+		ps.MarkTypes[k] = v
+	}
+	ps.LastType = outType
+	return &Branch{mode: mode, pipes: pipes}, nil
+}
+
+// matchSubPipeline runs a subquery over a batch of travelers, and returns
+// whether each traveler has at least one result. The results after the first
+// one of a traveler are dropped, and the subquery is stopped once every
+// traveler has one
+func matchSubPipeline(ctx context.Context, man gdbi.Manager, pipe *DefaultPipeline, batch []gdbi.Traveler) []bool {
+	found := make([]bool, len(batch))
+	if len(pipe.procs) == 0 {
+		for i := range batch {
+			found[i] = true
+		}
+		return found
+	}
+	subCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	input := make(chan gdbi.Traveler, len(batch))
+	for i, t := range batch {
+		input <- t.AddMark(subQueryMark, &gdbi.DataElement{Data: map[string]interface{}{"index": i}})
+	}
+	close(input)
+	remaining := len(batch)
+	for r := range pipeline.Start(subCtx, pipe, man, 100, input, func() {}) {
+		// keep reading after the cancel so the processors can exit
+		if remaining == 0 || r.IsSignal() {
+			continue
+		}
+		m := r.GetMark(subQueryMark)
+		if m == nil {
+			continue
+		}
+		if i, ok := m.Data["index"].(int); ok && i < len(batch) && !found[i] {
+			found[i] = true
+			remaining--
+			if remaining == 0 {
+				cancel()
+			}
+		}
+	}
+	return found
+}
+
+// processBatches calls flush with batches of the travelers of in, keeping
+// the signals in order
+func processBatches(in gdbi.InPipe, out gdbi.OutPipe, flush func([]gdbi.Traveler)) {
+	batch := []gdbi.Traveler{}
+	for t := range in {
+		if t.IsSignal() {
+			if len(batch) > 0 {
+				flush(batch)
+				batch = []gdbi.Traveler{}
+			}
+			out <- t
+			continue
+		}
+		batch = append(batch, t)
+		// don't hold travelers back while waiting for more input
+		if len(batch) >= subQueryBatchSize || len(in) == 0 {
+			flush(batch)
+			batch = []gdbi.Traveler{}
+		}
+	}
+	if len(batch) > 0 {
+		flush(batch)
+	}
+}
+
+// SubQuery keeps the travelers for which a subquery has at least one result,
+// or has none if negate is set
+type SubQuery struct {
+	pipe   *DefaultPipeline
+	negate bool
+}
+
+// Process runs the subquery over batches of travelers
+func (s *SubQuery) Process(ctx context.Context, man gdbi.Manager, in gdbi.InPipe, out gdbi.OutPipe) context.Context {
+	go func() {
+		defer close(out)
+		processBatches(in, out, func(batch []gdbi.Traveler) {
+			found := matchSubPipeline(ctx, man, s.pipe, batch)
+			for i, t := range batch {
+				if found[i] != s.negate {
+					out <- t
+				}
+			}
+		})
+	}()
+	return ctx
+}
+
+type branchMode int
+
+const (
+	branchUnion branchMode = iota
+	branchOptional
+	branchCoalesce
+)
+
+func (m branchMode) String() string {
+	switch m {
+	case branchOptional:
+		return "optional"
+	case branchCoalesce:
+		return "coalesce"
+	}
+	return "union"
+}
+
+// Branch sends each traveler through several subqueries. A union emits the
+// results of every subquery, an optional the results of its subquery or the
+// traveler itself, and a coalesce the results of the first subquery that has
+// any
+type Branch struct {
+	mode  branchMode
+	pipes []*DefaultPipeline
+}
+
+// runSubPipeline runs a subquery of a branch over a batch of travelers, and
+// returns every result of each traveler, so they can be emitted in the order
+// of the travelers. A subquery without statements returns the traveler itself
+func runSubPipeline(ctx context.Context, man gdbi.Manager, pipe *DefaultPipeline, batch []gdbi.Traveler) [][]gdbi.Traveler {
+	results := make([][]gdbi.Traveler, len(batch))
+	if len(pipe.procs) == 0 {
+		for i, t := range batch {
+			results[i] = []gdbi.Traveler{t}
+		}
+		return results
+	}
+	input := make(chan gdbi.Traveler, len(batch))
+	for i, t := range batch {
+		input <- t.AddMark(subQueryMark, &gdbi.DataElement{Data: map[string]interface{}{"index": i}})
+	}
+	close(input)
+	for r := range pipeline.Start(ctx, pipe, man, 100, input, func() {}) {
+		if r.IsSignal() {
+			continue
+		}
+		m := r.GetMark(subQueryMark)
+		if m == nil {
+			continue
+		}
+		if i, ok := m.Data["index"].(int); ok && i < len(batch) {
+			// restore the mark of an enclosing subquery
+			if o := batch[i].GetMark(subQueryMark); o != nil {
+				r = r.AddMark(subQueryMark, o)
+			}
+			results[i] = append(results[i], r)
+		}
+	}
+	return results
+}
+
+// Process runs the subqueries over batches of travelers, emitting the results
+// in the order of the travelers
+func (b *Branch) Process(ctx context.Context, man gdbi.Manager, in gdbi.InPipe, out gdbi.OutPipe) context.Context {
+	go func() {
+		defer close(out)
+		processBatches(in, out, func(batch []gdbi.Traveler) {
+			results := make([][]gdbi.Traveler, len(batch))
+			switch b.mode {
+			case branchUnion:
+				for _, pipe := range b.pipes {
+					for i, r := range runSubPipeline(ctx, man, pipe, batch) {
+						results[i] = append(results[i], r...)
+					}
+				}
+			case branchOptional:
+				results = runSubPipeline(ctx, man, b.pipes[0], batch)
+				for i, t := range batch {
+					if len(results[i]) == 0 {
+						results[i] = []gdbi.Traveler{t}
+					}
+				}
+			case branchCoalesce:
+				// only travelers without results are sent to the next subquery
+				pending := make([]int, len(batch))
+				for i := range batch {
+					pending[i] = i
+				}
+				for _, pipe := range b.pipes {
+					if len(pending) == 0 {
+						break
+					}
+					sub := make([]gdbi.Traveler, len(pending))
+					for j, i := range pending {
+						sub[j] = batch[i]
+					}
+					next := []int{}
+					for j, r := range runSubPipeline(ctx, man, pipe, sub) {
+						if len(r) > 0 {
+							results[pending[j]] = r
+						} else {
+							next = append(next, pending[j])
+						}
+					}
+					pending = next
+				}
+			}
+			for _, r := range results {
+				for _, t := range r {
+					out <- t
+				}
+			}
+		})
+	}()
+	return ctx
+}
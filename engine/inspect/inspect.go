@@ -2,6 +2,8 @@ package inspect
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/bmeg/grip/gripql"
 	"github.com/bmeg/grip/jsonpath"
@@ -43,15 +45,18 @@ func PipelineSteps(stmts []*gripql.GraphStatement) []string {
 			*gripql.GraphStatement_In, *gripql.GraphStatement_OutE, *gripql.GraphStatement_InE,
 			*gripql.GraphStatement_Both, *gripql.GraphStatement_BothE, *gripql.GraphStatement_Select,
 			*gripql.GraphStatement_InNull, *gripql.GraphStatement_OutNull,
-			*gripql.GraphStatement_InENull, *gripql.GraphStatement_OutENull:
+			*gripql.GraphStatement_InENull, *gripql.GraphStatement_OutENull,
+			*gripql.GraphStatement_Union, *gripql.GraphStatement_Optional, *gripql.GraphStatement_Coalesce:
 			curState++
 		case *gripql.GraphStatement_Limit, *gripql.GraphStatement_As, *gripql.GraphStatement_Has,
 			*gripql.GraphStatement_HasId, *gripql.GraphStatement_HasKey, *gripql.GraphStatement_HasLabel,
-			*gripql.GraphStatement_Count, *gripql.GraphStatement_Skip, *gripql.GraphStatement_Distinct,
+			*gripql.GraphStatement_Count, *gripql.GraphStatement_Skip, *gripql.GraphStatement_Distinct, *gripql.GraphStatement_Sort,
 			*gripql.GraphStatement_Range, *gripql.GraphStatement_Aggregate, *gripql.GraphStatement_Render,
+			*gripql.GraphStatement_Group, *gripql.GraphStatement_Fold, *gripql.GraphStatement_Seek,
 			*gripql.GraphStatement_Fields, *gripql.GraphStatement_Unwind, *gripql.GraphStatement_Path,
 			*gripql.GraphStatement_Set, *gripql.GraphStatement_Increment,
-			*gripql.GraphStatement_Mark, *gripql.GraphStatement_Jump:
+			*gripql.GraphStatement_Mark, *gripql.GraphStatement_Jump,
+			*gripql.GraphStatement_Where, *gripql.GraphStatement_Not:
 		case *gripql.GraphStatement_LookupVertsIndex, *gripql.GraphStatement_EngineCustom:
 		default:
 			log.Errorf("Unknown Graph Statement: %T", gs.GetStatement())
@@ -98,10 +103,17 @@ func PipelineStepOutputs(stmts []*gripql.GraphStatement) map[string][]string {
 				}
 				onLast = false
 			}
-		case *gripql.GraphStatement_Distinct:
-			//if there is a distinct step, we need to load data, but only for requested fields
+		case *gripql.GraphStatement_Distinct, *gripql.GraphStatement_Sort, *gripql.GraphStatement_Seek:
+			//if there is a distinct, sort or seek step, we need to load data, but only for requested fields
 			fields := protoutil.AsStringList(gs.GetDistinct())
+			if gs.GetSort() != nil {
+				fields = protoutil.AsStringList(gs.GetSort())
+			}
+			if gs.GetSeek() != nil {
+				fields = gs.GetSeek().GetFields()
+			}
 			for _, f := range fields {
+				f = strings.TrimPrefix(f, "-")
 				n := jsonpath.GetNamespace(f)
 				if n == "__current__" {
 					out[steps[i]] = []string{"*"}
@@ -132,6 +144,108 @@ func PipelineStepOutputs(stmts []*gripql.GraphStatement) map[string][]string {
 			}
 		case *gripql.GraphStatement_Has:
 			out[steps[i]] = []string{"*"}
+			//conditions can read the fields of marked elements
+			for _, n := range hasNamespaces(gs.GetHas()) {
+				if a, ok := asMap[n]; ok {
+					out[a] = []string{"*"}
+				}
+			}
+		case *gripql.GraphStatement_Where, *gripql.GraphStatement_Not:
+			//the subquery starts from the current element, so the fields its
+			//first step reads must be loaded here
+			var stmts []*gripql.GraphStatement
+			if w := gs.GetWhere(); w != nil {
+				stmts = w.Query
+			} else {
+				stmts = gs.GetNot().Query
+			}
+			if fields, ok := subQueryInputs(stmts, true)["0"]; ok {
+				mergeOutputs(out, steps[i], fields)
+			}
+		case *gripql.GraphStatement_Union, *gripql.GraphStatement_Optional, *gripql.GraphStatement_Coalesce:
+			if onLast {
+				out[steps[i]] = []string{"*"}
+			}
+			onLast = false
+			var subs []*gripql.SubQuery
+			if u := gs.GetUnion(); u != nil {
+				subs = u.Queries
+			} else if c := gs.GetCoalesce(); c != nil {
+				subs = c.Queries
+			}
+			//the subqueries start from the element of the previous step, and
+			//a subquery that doesn't move the traveler, or an optional without
+			//results, emits that element as it is
+			prev := steps[i]
+			if n, err := strconv.Atoi(steps[i]); err == nil {
+				prev = strconv.Itoa(n - 1)
+			}
+			fields, loaded := out[steps[i]]
+			loaded = loaded && !arrayEq(fields, []string{"_label"})
+			passThrough := gs.GetOptional() != nil
+			if o := gs.GetOptional(); o != nil {
+				subs = []*gripql.SubQuery{o}
+			}
+			for _, sub := range subs {
+				sOut := subQueryInputs(sub.Query, !loaded)
+				if f, ok := sOut["0"]; ok {
+					mergeOutputs(out, prev, f)
+				}
+				sSteps := PipelineSteps(sub.Query)
+				if len(sSteps) == 0 || sSteps[len(sSteps)-1] == "0" {
+					passThrough = true
+				}
+			}
+			if passThrough && len(fields) > 0 {
+				mergeOutputs(out, prev, fields)
+			}
+		}
+	}
+	return out
+}
+
+// mergeOutputs adds fields to the required outputs of a step
+func mergeOutputs(out map[string][]string, step string, fields []string) {
+	if contains(fields, "*") || contains(out[step], "*") {
+		out[step] = []string{"*"}
+		return
+	}
+	for _, f := range fields {
+		if !contains(out[step], f) {
+			out[step] = append(out[step], f)
+		}
+	}
+}
+
+// subQueryInputs returns the outputs required by each step of a subquery. If
+// counted is set, only the presence of results of the subquery is used, so
+// its last step is treated as if it were counted
+func subQueryInputs(stmts []*gripql.GraphStatement, counted bool) map[string][]string {
+	if counted {
+		stmts = append(stmts[:len(stmts):len(stmts)], &gripql.GraphStatement{Statement: &gripql.GraphStatement_Count{}})
+	}
+	return PipelineStepOutputs(stmts)
+}
+
+// hasNamespaces returns the namespaces of the keys and references used by the
+// conditions of a has expression
+func hasNamespaces(expr *gripql.HasExpression) []string {
+	out := []string{}
+	switch e := expr.GetExpression().(type) {
+	case *gripql.HasExpression_And:
+		for _, sub := range e.And.Expressions {
+			out = append(out, hasNamespaces(sub)...)
+		}
+	case *gripql.HasExpression_Or:
+		for _, sub := range e.Or.Expressions {
+			out = append(out, hasNamespaces(sub)...)
+		}
+	case *gripql.HasExpression_Not:
+		out = append(out, hasNamespaces(e.Not)...)
+	case *gripql.HasExpression_Condition:
+		out = append(out, jsonpath.GetNamespace(e.Condition.Key))
+		if e.Condition.ValueRef != "" {
+			out = append(out, jsonpath.GetNamespace(e.Condition.ValueRef))
 		}
 	}
 	return out
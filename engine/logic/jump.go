@@ -7,7 +7,6 @@ import (
 
 	"github.com/bmeg/grip/engine/queue"
 	"github.com/bmeg/grip/gdbi"
-	"github.com/bmeg/grip/gripql"
 )
 
 // MarkJump creates mark where jump instruction can send travelers
@@ -136,7 +135,7 @@ func (s *JumpMark) AddInput(in chan gdbi.Traveler) {
 
 type Jump struct {
 	Mark    string
-	Stmt    *gripql.HasExpression
+	Match   *HasMatcher
 	Emit    bool
 	jumpers chan gdbi.Traveler
 	queue   queue.Queue
@@ -175,7 +174,7 @@ func (s *Jump) Process(ctx context.Context, man gdbi.Manager, in gdbi.InPipe, ou
 				out <- t
 				continue
 			}
-			if s.Stmt == nil || MatchesHasExpression(t, s.Stmt) {
+			if s.Match == nil || s.Match.Matches(t) {
 				if !canceled {
 					s.jumpers <- t
 				}
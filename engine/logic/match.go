@@ -1,7 +1,10 @@
 package logic
 
 import (
+	"fmt"
+	"math"
 	"reflect"
+	"strings"
 
 	"github.com/spf13/cast"
 
@@ -9,13 +12,221 @@ import (
 	"github.com/bmeg/grip/gripql"
 	"github.com/bmeg/grip/jsonpath"
 	"github.com/bmeg/grip/log"
+	"github.com/bmeg/grip/util/geo"
 )
 
+// Compare orders two values for the range conditions (GT, GTE, LT, LTE,
+// INSIDE, OUTSIDE and BETWEEN). Values that can be read as numbers are
+// compared numerically, and RFC3339 timestamps by time. ok is false for other
+// values
+func Compare(a, b interface{}) (int, bool) {
+	if aN, err := cast.ToFloat64E(a); err == nil {
+		if bN, err := cast.ToFloat64E(b); err == nil {
+			switch {
+			case aN < bN:
+				return -1, true
+			case aN > bN:
+				return 1, true
+			}
+			return 0, true
+		}
+	}
+	if aT, ok := gripql.ParseDateTime(a); ok {
+		if bT, ok := gripql.ParseDateTime(b); ok {
+			switch {
+			case aT.Before(bT):
+				return -1, true
+			case aT.After(bT):
+				return 1, true
+			}
+			return 0, true
+		}
+	}
+	return 0, false
+}
+
+// Ranks of the kinds of values in the sort order
+const (
+	rankNil = iota
+	rankNumber
+	rankTime
+	rankString
+	rankMap
+	rankList
+	rankBool
+	rankOther
+)
+
+func sortRank(v interface{}) int {
+	switch v.(type) {
+	case nil:
+		return rankNil
+	case float64, float32, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return rankNumber
+	case string:
+		if _, ok := gripql.ParseDateTime(v); ok {
+			return rankTime
+		}
+		return rankString
+	case map[string]interface{}:
+		return rankMap
+	case []interface{}:
+		return rankList
+	case bool:
+		return rankBool
+	}
+	return rankOther
+}
+
+// SortCompare is the order of the sort step and of the cursors that seek
+// past a position in it. Values are ordered by kind first: missing values,
+// numbers, RFC3339 timestamps, other strings, maps, lists and booleans. Values
+// of the same kind are then compared by value, so strings that hold numbers
+// are ordered as strings
+func SortCompare(a, b interface{}) int {
+	ra, rb := sortRank(a), sortRank(b)
+	if ra != rb {
+		return ra - rb
+	}
+	switch ra {
+	case rankNil:
+		return 0
+	case rankNumber:
+		aN, bN := cast.ToFloat64(a), cast.ToFloat64(b)
+		switch {
+		case aN < bN:
+			return -1
+		case aN > bN:
+			return 1
+		case aN == bN:
+			return 0
+		}
+		// NaN comes before the other numbers
+		if math.IsNaN(aN) && math.IsNaN(bN) {
+			return 0
+		} else if math.IsNaN(aN) {
+			return -1
+		}
+		return 1
+	case rankTime:
+		aT, _ := gripql.ParseDateTime(a)
+		bT, _ := gripql.ParseDateTime(b)
+		switch {
+		case aT.Before(bT):
+			return -1
+		case aT.After(bT):
+			return 1
+		}
+		// the same time written differently
+		return strings.Compare(a.(string), b.(string))
+	case rankString:
+		return strings.Compare(a.(string), b.(string))
+	case rankBool:
+		aB, bB := a.(bool), b.(bool)
+		switch {
+		case aB == bB:
+			return 0
+		case bB:
+			return -1
+		}
+		return 1
+	}
+	return strings.Compare(fmt.Sprintf("%v", a), fmt.Sprintf("%v", b))
+}
+
+// compareRange compares a value to the lower and upper bounds of an INSIDE,
+// OUTSIDE or BETWEEN condition
+func compareRange(cond gripql.Condition, val, condVal interface{}) (int, int, bool) {
+	vals, err := cast.ToSliceE(condVal)
+	if err != nil {
+		log.Errorf("Error: could not cast %s condition value: %v", cond, err)
+		return 0, 0, false
+	}
+	if len(vals) != 2 {
+		log.Errorf("Error: expected slice of length 2 not %v for %s condition value", len(vals), cond)
+		return 0, 0, false
+	}
+	lower, ok := Compare(val, vals[0])
+	if !ok {
+		log.Errorf("Error: could not compare %s value %v to lower bound %v", cond, val, vals[0])
+		return 0, 0, false
+	}
+	upper, ok := Compare(val, vals[1])
+	if !ok {
+		log.Errorf("Error: could not compare %s value %v to upper bound %v", cond, val, vals[1])
+		return 0, 0, false
+	}
+	return lower, upper, true
+}
+
+// HasMatcher matches travelers to a has expression. The shapes of its geo
+// conditions are parsed once, when the matcher is created
+type HasMatcher struct {
+	stmt   *gripql.HasExpression
+	shapes map[*gripql.HasCondition]geo.Shape
+}
+
+// NewHasMatcher checks the conditions of a has expression. It fails on geo
+// conditions with invalid shapes
+func NewHasMatcher(stmt *gripql.HasExpression) (*HasMatcher, error) {
+	m := &HasMatcher{stmt: stmt, shapes: map[*gripql.HasCondition]geo.Shape{}}
+	if err := m.parseShapes(stmt); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (m *HasMatcher) parseShapes(stmt *gripql.HasExpression) error {
+	switch e := stmt.GetExpression().(type) {
+	case *gripql.HasExpression_Condition:
+		cond := e.Condition
+		switch cond.Condition {
+		case gripql.Condition_GEO_RADIUS, gripql.Condition_GEO_BOX, gripql.Condition_GEO_POLYGON:
+			if cond.ValueRef != "" {
+				return nil
+			}
+			shape, err := geo.ParseShape(cond.Condition, cond.Value.AsInterface())
+			if err != nil {
+				return fmt.Errorf("%s: %v", cond.Key, err)
+			}
+			m.shapes[cond] = shape
+		}
+	case *gripql.HasExpression_And:
+		for _, s := range e.And.GetExpressions() {
+			if err := m.parseShapes(s); err != nil {
+				return err
+			}
+		}
+	case *gripql.HasExpression_Or:
+		for _, s := range e.Or.GetExpressions() {
+			if err := m.parseShapes(s); err != nil {
+				return err
+			}
+		}
+	case *gripql.HasExpression_Not:
+		return m.parseShapes(e.Not)
+	}
+	return nil
+}
+
+// Matches reports whether the traveler matches the expression
+func (m *HasMatcher) Matches(trav gdbi.Traveler) bool {
+	return matchesHasExpression(trav, m.stmt, m.shapes)
+}
+
 func MatchesCondition(trav gdbi.Traveler, cond *gripql.HasCondition) bool {
+	return matchesCondition(trav, cond, nil)
+}
+
+func matchesCondition(trav gdbi.Traveler, cond *gripql.HasCondition, shape geo.Shape) bool {
 	var val interface{}
 	var condVal interface{}
 	val = jsonpath.TravelerPathLookup(trav, cond.Key)
-	condVal = cond.Value.AsInterface()
+	if cond.ValueRef != "" {
+		condVal = jsonpath.TravelerPathLookup(trav, cond.ValueRef)
+	} else {
+		condVal = cond.Value.AsInterface()
+	}
 
 	switch cond.Condition {
 	case gripql.Condition_EQ:
@@ -25,129 +236,32 @@ func MatchesCondition(trav gdbi.Traveler, cond *gripql.HasCondition) bool {
 		return !reflect.DeepEqual(val, condVal)
 
 	case gripql.Condition_GT:
-		valN, err := cast.ToFloat64E(val)
-		if err != nil {
-			return false
-		}
-		condN, err := cast.ToFloat64E(condVal)
-		if err != nil {
-			return false
-		}
-		return valN > condN
+		c, ok := Compare(val, condVal)
+		return ok && c > 0
 
 	case gripql.Condition_GTE:
-		valN, err := cast.ToFloat64E(val)
-		if err != nil {
-			return false
-		}
-		condN, err := cast.ToFloat64E(condVal)
-		if err != nil {
-			return false
-		}
-		return valN >= condN
+		c, ok := Compare(val, condVal)
+		return ok && c >= 0
 
 	case gripql.Condition_LT:
-		valN, err := cast.ToFloat64E(val)
-		if err != nil {
-			return false
-		}
-		condN, err := cast.ToFloat64E(condVal)
-		if err != nil {
-			return false
-		}
-		return valN < condN
+		c, ok := Compare(val, condVal)
+		return ok && c < 0
 
 	case gripql.Condition_LTE:
-		valN, err := cast.ToFloat64E(val)
-		if err != nil {
-			return false
-		}
-		condN, err := cast.ToFloat64E(condVal)
-		if err != nil {
-			return false
-		}
-		return valN <= condN
+		c, ok := Compare(val, condVal)
+		return ok && c <= 0
 
 	case gripql.Condition_INSIDE:
-		vals, err := cast.ToSliceE(condVal)
-		if err != nil {
-			log.Errorf("Error: could not cast INSIDE condition value: %v", err)
-			return false
-		}
-		if len(vals) != 2 {
-			log.Errorf("Error: expected slice of length 2 not %v for INSIDE condition value", len(vals))
-			return false
-		}
-		lower, err := cast.ToFloat64E(vals[0])
-		if err != nil {
-			log.Errorf("Error: could not cast lower INSIDE condition value: %v", err)
-			return false
-		}
-		upper, err := cast.ToFloat64E(vals[1])
-		if err != nil {
-			log.Errorf("Error: could not cast upper INSIDE condition value: %v", err)
-			return false
-		}
-		valF, err := cast.ToFloat64E(val)
-		if err != nil {
-			log.Errorf("Error: could not cast INSIDE value: %v", err)
-			return false
-		}
-		return valF > lower && valF < upper
+		lower, upper, ok := compareRange(cond.Condition, val, condVal)
+		return ok && lower > 0 && upper < 0
 
 	case gripql.Condition_OUTSIDE:
-		vals, err := cast.ToSliceE(condVal)
-		if err != nil {
-			log.Errorf("Error: could not cast OUTSIDE condition value: %v", err)
-			return false
-		}
-		if len(vals) != 2 {
-			log.Errorf("Error: expected slice of length 2 not %v for OUTSIDE condition value", len(vals))
-			return false
-		}
-		lower, err := cast.ToFloat64E(vals[0])
-		if err != nil {
-			log.Errorf("Error: could not cast lower OUTSIDE condition value: %v", err)
-			return false
-		}
-		upper, err := cast.ToFloat64E(vals[1])
-		if err != nil {
-			log.Errorf("Error: could not cast upper OUTSIDE condition value: %v", err)
-			return false
-		}
-		valF, err := cast.ToFloat64E(val)
-		if err != nil {
-			log.Errorf("Error: could not cast OUTSIDE value: %v", err)
-			return false
-		}
-		return valF < lower || valF > upper
+		lower, upper, ok := compareRange(cond.Condition, val, condVal)
+		return ok && (lower < 0 || upper > 0)
 
 	case gripql.Condition_BETWEEN:
-		vals, err := cast.ToSliceE(condVal)
-		if err != nil {
-			log.Errorf("Error: could not cast BETWEEN condition value: %v", err)
-			return false
-		}
-		if len(vals) != 2 {
-			log.Errorf("Error: expected slice of length 2 not %v for BETWEEN condition value", len(vals))
-			return false
-		}
-		lower, err := cast.ToFloat64E(vals[0])
-		if err != nil {
-			log.Errorf("Error: could not cast lower BETWEEN condition value: %v", err)
-			return false
-		}
-		upper, err := cast.ToFloat64E(vals[1])
-		if err != nil {
-			log.Errorf("Error: could not cast upper BETWEEN condition value: %v", err)
-			return false
-		}
-		valF, err := cast.ToFloat64E(val)
-		if err != nil {
-			log.Errorf("Error: could not cast BETWEEN value: %v", err)
-			return false
-		}
-		return valF >= lower && valF < upper
+		lower, upper, ok := compareRange(cond.Condition, val, condVal)
+		return ok && lower >= 0 && upper < 0
 
 	case gripql.Condition_WITHIN:
 		found := false
@@ -207,22 +321,37 @@ func MatchesCondition(trav gdbi.Traveler, cond *gripql.HasCondition) bool {
 
 		return found
 
+	case gripql.Condition_GEO_RADIUS, gripql.Condition_GEO_BOX, gripql.Condition_GEO_POLYGON:
+		if shape == nil {
+			var err error
+			if shape, err = geo.ParseShape(cond.Condition, condVal); err != nil {
+				log.Errorf("Error: %s", err)
+				return false
+			}
+		}
+		p, ok := geo.ParsePoint(val)
+		return ok && shape.Contains(p)
+
 	default:
 		return false
 	}
 }
 
 func MatchesHasExpression(trav gdbi.Traveler, stmt *gripql.HasExpression) bool {
+	return matchesHasExpression(trav, stmt, nil)
+}
+
+func matchesHasExpression(trav gdbi.Traveler, stmt *gripql.HasExpression, shapes map[*gripql.HasCondition]geo.Shape) bool {
 	switch stmt.Expression.(type) {
 	case *gripql.HasExpression_Condition:
 		cond := stmt.GetCondition()
-		return MatchesCondition(trav, cond)
+		return matchesCondition(trav, cond, shapes[cond])
 
 	case *gripql.HasExpression_And:
 		and := stmt.GetAnd()
 		andRes := []bool{}
 		for _, e := range and.Expressions {
-			andRes = append(andRes, MatchesHasExpression(trav, e))
+			andRes = append(andRes, matchesHasExpression(trav, e, shapes))
 		}
 		for _, r := range andRes {
 			if !r {
@@ -235,7 +364,7 @@ func MatchesHasExpression(trav gdbi.Traveler, stmt *gripql.HasExpression) bool {
 		or := stmt.GetOr()
 		orRes := []bool{}
 		for _, e := range or.Expressions {
-			orRes = append(orRes, MatchesHasExpression(trav, e))
+			orRes = append(orRes, matchesHasExpression(trav, e, shapes))
 		}
 		for _, r := range orRes {
 			if r {
@@ -246,7 +375,7 @@ func MatchesHasExpression(trav gdbi.Traveler, stmt *gripql.HasExpression) bool {
 
 	case *gripql.HasExpression_Not:
 		e := stmt.GetNot()
-		return !MatchesHasExpression(trav, e)
+		return !matchesHasExpression(trav, e, shapes)
 
 	default:
 		log.Errorf("unknown where expression type: %T", stmt.Expression)
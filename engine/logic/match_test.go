@@ -0,0 +1,161 @@
+package logic
+
+import (
+	"math"
+	"testing"
+
+	"github.com/bmeg/grip/gdbi"
+	"github.com/bmeg/grip/gripql"
+)
+
+func TestCompareDateTime(t *testing.T) {
+	tests := []struct {
+		a, b     interface{}
+		expected int
+		ok       bool
+	}{
+		{"2014-12-10T15:10:51Z", "2014-12-10T16:10:51+01:00", 0, true},
+		{"2014-12-10T15:10:51.5Z", "2014-12-10T15:10:51Z", 1, true},
+		{"2014-12-09", "2014-12-09T00:00:01Z", -1, true},
+		// offsets would order these the other way as strings
+		{"2014-12-10T10:00:00-08:00", "2014-12-10T12:00:00Z", 1, true},
+		{"2014-12-10", "apple", 0, false},
+		{"2014-12-10", 5.0, 0, false},
+	}
+	for _, tt := range tests {
+		c, ok := Compare(tt.a, tt.b)
+		if c != tt.expected || ok != tt.ok {
+			t.Errorf("Compare(%v, %v) = %d, %v: expected %d, %v", tt.a, tt.b, c, ok, tt.expected, tt.ok)
+		}
+	}
+}
+
+func TestMatchesDateConditions(t *testing.T) {
+	trav := &gdbi.BaseTraveler{Current: &gdbi.DataElement{
+		ID:   "1",
+		Data: map[string]interface{}{"created": "2014-12-10T10:00:00-08:00"},
+	}}
+	tests := []struct {
+		expr     *gripql.HasExpression
+		expected bool
+	}{
+		{gripql.Gt("created", "2014-12-10T12:00:00Z"), true},
+		{gripql.Lte("created", "2014-12-10"), false},
+		{gripql.Between("created", []interface{}{"2014-12-10", "2014-12-11"}), true},
+		{gripql.Inside("created", []interface{}{"2014-12-10T18:00:00Z", "2014-12-11"}), false},
+		{gripql.Outside("created", []interface{}{"2014-12-01", "2014-12-10T12:00:00Z"}), true},
+		{gripql.Gt("missing", "2014-12-10"), false},
+	}
+	for _, tt := range tests {
+		if m := MatchesHasExpression(trav, tt.expr); m != tt.expected {
+			t.Errorf("%v: expected %v", tt.expr, tt.expected)
+		}
+	}
+}
+
+func TestMatchesGeoConditions(t *testing.T) {
+	trav := &gdbi.BaseTraveler{Current: &gdbi.DataElement{
+		ID: "1",
+		Data: map[string]interface{}{
+			// Oakland, as a map and as a GeoJSON point
+			"loc":  map[string]interface{}{"lat": 37.8044, "lon": -122.2712},
+			"geom": map[string]interface{}{"type": "Point", "coordinates": []interface{}{-122.2712, 37.8044}},
+			"name": "Oakland",
+		},
+	}}
+	tests := []struct {
+		expr     *gripql.HasExpression
+		expected bool
+	}{
+		// about 13km from San Francisco
+		{gripql.GeoRadius("loc", 37.7749, -122.4194, 15000), true},
+		{gripql.GeoRadius("geom", 37.7749, -122.4194, 10000), false},
+		{gripql.GeoBox("geom", 37, -123, 38, -122), true},
+		{gripql.GeoBox("loc", 38, -123, 39, -122), false},
+		{gripql.GeoPolygon("loc", [2]float64{37, -123}, [2]float64{38.5, -122}, [2]float64{37, -122}), true},
+		{gripql.GeoPolygon("loc", [2]float64{37, -123}, [2]float64{38.5, -123}, [2]float64{37, -122}), false},
+		{gripql.GeoRadius("name", 37.7749, -122.4194, 15000), false},
+		{gripql.GeoRadius("missing", 37.7749, -122.4194, 15000), false},
+		{gripql.Not(gripql.GeoBox("loc", 38, -123, 39, -122)), true},
+	}
+	for _, tt := range tests {
+		if m := MatchesHasExpression(trav, tt.expr); m != tt.expected {
+			t.Errorf("%v: expected %v", tt.expr, tt.expected)
+		}
+		match, err := NewHasMatcher(tt.expr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if m := match.Matches(trav); m != tt.expected {
+			t.Errorf("%v: expected %v from matcher", tt.expr, tt.expected)
+		}
+	}
+
+	for _, expr := range []*gripql.HasExpression{
+		gripql.GeoRadius("loc", 37.7749, -122.4194, -1),
+		gripql.Not(gripql.GeoBox("loc", 39, -123, 38, -122)),
+		gripql.Or(gripql.Eq("name", "Oakland"), gripql.GeoPolygon("loc", [2]float64{37, -123})),
+	} {
+		if _, err := NewHasMatcher(expr); err == nil {
+			t.Errorf("%v: expected invalid shape error", expr)
+		}
+	}
+}
+
+func TestMatchesStringConditions(t *testing.T) {
+	trav := &gdbi.BaseTraveler{Current: &gdbi.DataElement{
+		ID:   "1",
+		Data: map[string]interface{}{"name": "Oakland", "zip": "10", "count": 3.0},
+	}}
+	tests := []struct {
+		expr     *gripql.HasExpression
+		expected bool
+	}{
+		// range conditions only compare numbers and timestamps
+		{gripql.Gt("name", "Alameda"), false},
+		{gripql.Lte("name", "Berkeley"), false},
+		{gripql.Between("name", []interface{}{"O", "P"}), false},
+		{gripql.Outside("name", []interface{}{"A", "Z"}), false},
+		// strings that can be read as numbers are compared as numbers
+		{gripql.Gt("zip", "9"), true},
+		{gripql.Lt("count", "10"), true},
+		{gripql.Gt("name", 5), false},
+		{gripql.Lt("name", 5), false},
+	}
+	for _, tt := range tests {
+		if m := MatchesHasExpression(trav, tt.expr); m != tt.expected {
+			t.Errorf("%v: expected %v", tt.expr, tt.expected)
+		}
+	}
+}
+
+func TestSortCompare(t *testing.T) {
+	// in ascending order
+	values := []interface{}{
+		nil,
+		math.NaN(),
+		-1.5,
+		2.0,
+		int64(10),
+		"2014-12-09",
+		"2014-12-10T10:00:00-08:00",
+		"2014-12-10T18:00:00Z",
+		"10",
+		"1a",
+		"2",
+		"Oakland",
+		map[string]interface{}{"a": 1.0},
+		[]interface{}{1.0},
+		false,
+		true,
+	}
+	for i, a := range values {
+		for j, b := range values {
+			c := SortCompare(a, b)
+			switch {
+			case i < j && c >= 0, i > j && c <= 0, i == j && c != 0:
+				t.Errorf("SortCompare(%v, %v) = %d", a, b, c)
+			}
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package pipeline
+
+import (
+	"context"
+	"sync"
+
+	"github.com/bmeg/grip/gdbi"
+)
+
+// startParallel runs copies of a processor on workers that share its input.
+// Unordered workers read from the input as they are free. Ordered workers are
+// handed travelers in turn, each followed by a barrier, and their outputs are
+// read back in the same turn up to the barrier, so the results keep the order
+// of the input
+func startParallel(ctx context.Context, proc gdbi.Processor, man gdbi.Manager, bufsize int, workers int, ordered bool, in gdbi.InPipe, out gdbi.OutPipe) context.Context {
+	ins := make([]chan gdbi.Traveler, workers)
+	outs := make([]chan gdbi.Traveler, workers)
+	var procCtx context.Context
+	for i := range outs {
+		var wIn gdbi.InPipe = in
+		if ordered {
+			ins[i] = make(chan gdbi.Traveler, bufsize)
+			wIn = ins[i]
+		}
+		outs[i] = make(chan gdbi.Traveler, bufsize)
+		c := proc.Process(ctx, man, wIn, outs[i])
+		if procCtx == nil {
+			procCtx = c
+		}
+	}
+
+	if !ordered {
+		var wg sync.WaitGroup
+		for i := range outs {
+			wg.Add(1)
+			go func(o chan gdbi.Traveler) {
+				defer wg.Done()
+				for t := range o {
+					out <- t
+				}
+			}(outs[i])
+		}
+		go func() {
+			wg.Wait()
+			close(out)
+		}()
+		return procCtx
+	}
+
+	// barrier is a signal, which every parallel processor passes on as it is
+	var barrier gdbi.Traveler = &gdbi.BaseTraveler{Signal: &gdbi.Signal{}}
+	go func() {
+		defer func() {
+			for i := range ins {
+				close(ins[i])
+			}
+		}()
+		w := 0
+		for t := range in {
+			ins[w] <- t
+			ins[w] <- barrier
+			w = (w + 1) % workers
+		}
+	}()
+	go func() {
+		defer close(out)
+		for w := 0; ; w = (w + 1) % workers {
+			found := false
+			for t := range outs[w] {
+				if t == barrier {
+					found = true
+					break
+				}
+				out <- t
+			}
+			if !found {
+				// the worker was handed nothing more, nor were the ones
+				// after it
+				for i := range outs {
+					for range outs[i] {
+					}
+				}
+				return
+			}
+		}
+	}()
+	return procCtx
+}
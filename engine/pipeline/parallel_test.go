@@ -0,0 +1,96 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/bmeg/grip/engine"
+	"github.com/bmeg/grip/gdbi"
+)
+
+// repeat passes on each traveler as many times as its count, after a random
+// delay so that workers finish out of turn
+type repeat struct{}
+
+func (r *repeat) Process(ctx context.Context, man gdbi.Manager, in gdbi.InPipe, out gdbi.OutPipe) context.Context {
+	go func() {
+		defer close(out)
+		for t := range in {
+			if t.IsSignal() {
+				out <- t
+				continue
+			}
+			time.Sleep(time.Duration(rand.Intn(100)) * time.Microsecond)
+			for i := uint32(0); i < t.GetCount(); i++ {
+				out <- t
+			}
+		}
+	}()
+	return ctx
+}
+
+type testPipeline []gdbi.Processor
+
+func (p testPipeline) Graph() gdbi.GraphInterface          { return nil }
+func (p testPipeline) Processors() []gdbi.Processor        { return p }
+func (p testPipeline) DataType() gdbi.DataType             { return gdbi.VertexData }
+func (p testPipeline) MarkTypes() map[string]gdbi.DataType { return nil }
+
+func TestParallel(t *testing.T) {
+	pipe := testPipeline{&gdbi.Parallel{Processor: &repeat{}}, &gdbi.Parallel{Processor: &repeat{}}}
+	for _, ordered := range []bool{true, false} {
+		in := make(chan gdbi.Traveler, 500)
+		expected := []string{}
+		for i := 0; i < 500; i++ {
+			id := fmt.Sprintf("v%d", i)
+			count := uint32(i % 3)
+			in <- &gdbi.BaseTraveler{Current: &gdbi.DataElement{ID: id}, Count: count}
+			// both steps repeat the traveler
+			for j := uint32(0); j < count*count; j++ {
+				expected = append(expected, id)
+			}
+		}
+		close(in)
+
+		man := engine.NewManager("")
+		ctx := gdbi.WithParallelism(context.Background(), 4, ordered)
+		res := []string{}
+		for t := range Start(ctx, pipe, man, 10, in, nil) {
+			res = append(res, t.GetCurrentID())
+		}
+		man.Cleanup()
+		if !ordered {
+			sort.Strings(res)
+			sort.Strings(expected)
+		}
+		if !reflect.DeepEqual(res, expected) {
+			t.Errorf("ordered %v: unexpected results %v", ordered, res)
+		}
+	}
+
+	// steps marked as ordered keep their order in unordered queries
+	pipe = testPipeline{&gdbi.Parallel{Processor: &repeat{}, Ordered: true}}
+	in := make(chan gdbi.Traveler, 500)
+	expected := []string{}
+	for i := 0; i < 500; i++ {
+		id := fmt.Sprintf("v%d", i)
+		in <- &gdbi.BaseTraveler{Current: &gdbi.DataElement{ID: id}, Count: 1}
+		expected = append(expected, id)
+	}
+	close(in)
+	man := engine.NewManager("")
+	ctx := gdbi.WithParallelism(context.Background(), 4, false)
+	res := []string{}
+	for t := range Start(ctx, pipe, man, 10, in, nil) {
+		res = append(res, t.GetCurrentID())
+	}
+	man.Cleanup()
+	if !reflect.DeepEqual(res, expected) {
+		t.Errorf("ordered step: unexpected results %v", res)
+	}
+}
@@ -6,6 +6,7 @@ package pipeline
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/bmeg/grip/engine"
 	"github.com/bmeg/grip/engine/logic"
@@ -24,6 +25,7 @@ func Start(ctx context.Context, pipe gdbi.Pipeline, man gdbi.Manager, bufsize in
 		return ch
 	}
 
+	workers, ordered := gdbi.Parallelism(ctx)
 	markProcs := map[string]*logic.JumpMark{}
 	for i := range procs {
 		if p, ok := procs[i].(*logic.JumpMark); ok {
@@ -32,11 +34,14 @@ func Start(ctx context.Context, pipe gdbi.Pipeline, man gdbi.Manager, bufsize in
 	}
 	for i := range procs {
 		if p, ok := procs[i].(*logic.Jump); ok {
+			// the signals of a loop have to stay behind the travelers they
+			// follow, so loops run on a single worker
+			workers = 1
 			if d, ok := markProcs[p.Mark]; ok {
 				p.Init()
 				d.AddInput(p.GetJumpOutput())
 			} else {
-				log.Errorf("Missing Jump Mark")
+				gdbi.ReportError(ctx, "jump", fmt.Errorf("mark %s not found", p.Mark))
 				ch := make(chan gdbi.Traveler)
 				close(ch)
 				return ch
@@ -48,7 +53,11 @@ func Start(ctx context.Context, pipe gdbi.Pipeline, man gdbi.Manager, bufsize in
 	final := make(chan gdbi.Traveler, bufsize)
 	out := final
 	for i := len(procs) - 1; i >= 0; i-- {
-		ctx = procs[i].Process(ctx, man, in, out)
+		if p, ok := procs[i].(*gdbi.Parallel); ok && workers > 1 {
+			ctx = startParallel(ctx, p.Processor, man, bufsize, workers, ordered || p.Ordered, in, out)
+		} else {
+			ctx = procs[i].Process(ctx, man, in, out)
+		}
 		out = in
 		in = make(chan gdbi.Traveler, bufsize)
 	}
@@ -74,7 +83,8 @@ func Start(ctx context.Context, pipe gdbi.Pipeline, man gdbi.Manager, bufsize in
 	return final
 }
 
-// Run starts a pipeline and converts the output to server output structures
+// Run starts a pipeline and converts the output to server output structures.
+// Errors of the steps are reported to ctx, see gdbi.WithQueryErrors
 func Run(ctx context.Context, pipe gdbi.Pipeline, workdir string) <-chan *gripql.QueryResult {
 	bufsize := 5000
 	resch := make(chan *gripql.QueryResult, bufsize)
@@ -7,7 +7,7 @@ import (
 )
 
 // AddVertexIndex add index to vertices
-func (g *Graph) AddVertexIndex(label string, field string) error {
+func (g *Graph) AddVertexIndex(label string, field string, itype gripql.IndexType) error {
 	return errors.New("not implemented")
 }
 
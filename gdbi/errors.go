@@ -0,0 +1,79 @@
+package gdbi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/bmeg/grip/log"
+)
+
+// ErrLimit is wrapped by the errors of steps that stop because they reached
+// a resource limit
+var ErrLimit = errors.New("limit exceeded")
+
+// StepError is an error raised by a step of a query while it runs
+type StepError struct {
+	Step string
+	Err  error
+}
+
+func (e *StepError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Step, e.Err)
+}
+
+func (e *StepError) Unwrap() error {
+	return e.Err
+}
+
+type queryErrorsKey struct{}
+
+// queryErrors holds the first error raised by the steps of a query
+type queryErrors struct {
+	mu     sync.Mutex
+	err    *StepError
+	cancel context.CancelFunc
+}
+
+// WithQueryErrors returns a context for running a query, that collects the
+// errors of its steps. The context is cancelled when an error is reported,
+// stopping the query, and the error is then returned by QueryError
+func WithQueryErrors(ctx context.Context) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(ctx)
+	return context.WithValue(ctx, queryErrorsKey{}, &queryErrors{cancel: cancel}), cancel
+}
+
+// ReportError records the error of a step of the query run with ctx, and
+// stops the query. If ctx doesn't collect errors, the error is logged
+func ReportError(ctx context.Context, step string, err error) {
+	if err == nil {
+		return
+	}
+	qe, ok := ctx.Value(queryErrorsKey{}).(*queryErrors)
+	if !ok {
+		log.WithFields(log.Fields{"step": step, "error": err}).Error("query step failed")
+		return
+	}
+	qe.mu.Lock()
+	if qe.err == nil {
+		qe.err = &StepError{Step: step, Err: err}
+	}
+	qe.mu.Unlock()
+	qe.cancel()
+}
+
+// QueryError returns the first error reported by the steps of the query run
+// with ctx, or nil
+func QueryError(ctx context.Context) error {
+	qe, ok := ctx.Value(queryErrorsKey{}).(*queryErrors)
+	if !ok {
+		return nil
+	}
+	qe.mu.Lock()
+	defer qe.mu.Unlock()
+	if qe.err == nil {
+		return nil
+	}
+	return qe.err
+}
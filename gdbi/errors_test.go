@@ -0,0 +1,39 @@
+package gdbi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestQueryErrors(t *testing.T) {
+	ctx, cancel := WithQueryErrors(context.Background())
+	defer cancel()
+
+	if err := QueryError(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	ReportError(ctx, "aggregate", nil)
+	if ctx.Err() != nil {
+		t.Fatal("context cancelled by nil error")
+	}
+
+	ReportError(ctx, "aggregate", fmt.Errorf("too many terms: %w", ErrLimit))
+	ReportError(ctx, "GetVertexList", errors.New("backend failure"))
+	if ctx.Err() == nil {
+		t.Error("context was not cancelled")
+	}
+	err := QueryError(ctx)
+	var se *StepError
+	if !errors.As(err, &se) || se.Step != "aggregate" {
+		t.Errorf("expected the first error, got %v", err)
+	}
+	if !errors.Is(err, ErrLimit) {
+		t.Errorf("expected error to wrap ErrLimit: %v", err)
+	}
+
+	if err := QueryError(context.Background()); err != nil {
+		t.Errorf("unexpected error without collector: %v", err)
+	}
+}
@@ -6,9 +6,11 @@ package gdbi
 
 import (
 	"context"
+	"time"
 
 	"github.com/bmeg/grip/gripql"
 	"github.com/bmeg/grip/kvi"
+	"github.com/bmeg/grip/util/geo"
 )
 
 // InPipe incoming traveler messages
@@ -139,7 +141,9 @@ type GraphInterface interface {
 	ListVertexLabels() ([]string, error)
 	ListEdgeLabels() ([]string, error)
 
-	AddVertexIndex(label string, field string) error
+	// AddVertexIndex indexes the field of vertices with the label. GEO_INDEX
+	// indexes points for geo conditions
+	AddVertexIndex(label string, field string, itype gripql.IndexType) error
 	DeleteVertexIndex(label string, field string) error
 	GetVertexIndexList() <-chan *gripql.IndexID
 
@@ -153,6 +157,24 @@ type GraphInterface interface {
 	GetInEdgeChannel(ctx context.Context, req chan ElementLookup, load bool, emitNull bool, edgeLabels []string) chan ElementLookup
 }
 
+// GeoIndexer is implemented by graphs that can find vertices by the point
+// held in an indexed field
+type GeoIndexer interface {
+	// VertexGeoScan produces the ids of vertices with the label whose field
+	// may hold a point inside the box. ok is false when the field isn't indexed
+	VertexGeoScan(ctx context.Context, label string, field string, box geo.Box) (chan string, bool)
+}
+
+// TimeIndexer is implemented by graphs that can find vertices by the
+// timestamp held in an indexed field
+type TimeIndexer interface {
+	// VertexTimeScan produces the ids of vertices with the label whose field
+	// holds a timestamp at or after min and before max, along with those
+	// holding other strings, which are compared to timestamps by their text.
+	// ok is false when the field isn't indexed
+	VertexTimeScan(ctx context.Context, label string, field string, min, max time.Time) (chan string, bool)
+}
+
 // Manager is a resource manager that is passed to processors to allow them ]
 // to make resource requests
 type Manager interface {
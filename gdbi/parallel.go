@@ -0,0 +1,38 @@
+package gdbi
+
+import "context"
+
+// Parallel marks a processor that keeps no state between travelers, so its
+// copies can run on several workers that share its input. Run on its own, it
+// acts as the processor it wraps
+type Parallel struct {
+	Processor
+	// Ordered is set for steps after a sort, whose workers keep their input
+	// order even when the query doesn't ask for it
+	Ordered bool
+}
+
+type parallelismKey struct{}
+
+type parallelism struct {
+	workers int
+	ordered bool
+}
+
+// WithParallelism returns a context for running a query whose parallel steps
+// each run on the given number of workers. If ordered is set, the workers
+// return their results in the order of their input
+func WithParallelism(ctx context.Context, workers int, ordered bool) context.Context {
+	return context.WithValue(ctx, parallelismKey{}, parallelism{workers, ordered})
+}
+
+// Parallelism returns the number of workers for the parallel steps of a query
+// run with ctx, and whether they keep their input order. Steps run on a single
+// worker unless set by WithParallelism
+func Parallelism(ctx context.Context) (int, bool) {
+	p, ok := ctx.Value(parallelismKey{}).(parallelism)
+	if !ok || p.workers < 1 {
+		return 1, false
+	}
+	return p.workers, p.ordered
+}
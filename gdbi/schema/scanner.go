@@ -30,7 +30,7 @@ func SchemaScan(ctx context.Context, graphName string, graph gdbi.GraphInterface
 		for res := range pipeline.Run(ctx, p, "./") {
 			v := res.GetVertex()
 			vids = append(vids, v.Gid)
-			util.MergeMaps(lSchema, v.GetData().AsMap())
+			util.MergeMaps(lSchema, gripql.GetDataFieldTypes(v.GetData().AsMap()))
 		}
 		sSchema, _ := structpb.NewStruct(lSchema)
 		vEnt := &gripql.Vertex{Gid: label, Label: label, Data: sSchema}
@@ -53,7 +53,7 @@ func SchemaScan(ctx context.Context, graphName string, graph gdbi.GraphInterface
 		for res := range pipeline.Run(ctx, p2, "./") {
 			r := res.GetRender().GetListValue()
 			eLabel := r.Values[0].GetStringValue()
-			eData := r.Values[1].GetStructValue().AsMap()
+			eData := gripql.GetDataFieldTypes(r.Values[1].GetStructValue().AsMap())
 			dLabel := r.Values[2].GetStringValue()
 			k := fromtokey{from: label, to: dLabel, label: eLabel}
 			if p, ok := fromToPairs[k]; ok {
@@ -0,0 +1,177 @@
+package schema
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/bmeg/grip/gripql"
+)
+
+// Enforcement configures how writes to a graph are checked against its schema
+type Enforcement struct {
+	// Fields that every vertex or edge with the label must have
+	Required map[string][]string
+}
+
+// VertexLabelFunc returns the label of a vertex, and false if the vertex
+// is not found
+type VertexLabelFunc func(gid string) (string, bool)
+
+type edgeType struct {
+	from, to string
+	fields   map[string]interface{}
+}
+
+// Validator checks graph elements against a graph schema
+type Validator struct {
+	vertices map[string]map[string]interface{}
+	edges    map[string][]edgeType
+	required map[string][]string
+}
+
+// NewValidator builds a Validator from a graph schema, as returned by GetSchema
+func NewValidator(schema *gripql.Graph, conf Enforcement) *Validator {
+	v := &Validator{
+		vertices: map[string]map[string]interface{}{},
+		edges:    map[string][]edgeType{},
+		required: conf.Required,
+	}
+	for _, vs := range schema.GetVertices() {
+		// schema vertices use the vertex label as the gid
+		v.vertices[vs.Gid] = vs.GetDataMap()
+	}
+	for _, es := range schema.GetEdges() {
+		v.edges[es.Label] = append(v.edges[es.Label], edgeType{from: es.From, to: es.To, fields: es.GetDataMap()})
+	}
+	return v
+}
+
+// ValidateVertex returns an error if the vertex label is not in the schema,
+// if any of its fields has the wrong type, or if a required field is missing
+func (v *Validator) ValidateVertex(vertex *gripql.Vertex) error {
+	fields, ok := v.vertices[vertex.Label]
+	if !ok {
+		return fmt.Errorf("vertex %s: label '%s' not found in schema", vertex.Gid, vertex.Label)
+	}
+	data := vertex.GetDataMap()
+	if err := v.checkRequired(vertex.Label, data); err != nil {
+		return fmt.Errorf("vertex %s: %v", vertex.Gid, err)
+	}
+	if err := checkFields("", fields, data); err != nil {
+		return fmt.Errorf("vertex %s: %v", vertex.Gid, err)
+	}
+	return nil
+}
+
+// ValidateEdge returns an error if the edge label is not in the schema, if the
+// labels of the vertices it connects are not an allowed pair for the edge label,
+// if any of its fields has the wrong type, or if a required field is missing.
+func (v *Validator) ValidateEdge(edge *gripql.Edge, labels VertexLabelFunc) error {
+	types, ok := v.edges[edge.Label]
+	if !ok {
+		return fmt.Errorf("edge %s: label '%s' not found in schema", edge.Gid, edge.Label)
+	}
+	from, ok := labels(edge.From)
+	if !ok {
+		return fmt.Errorf("edge %s: vertex %s not found", edge.Gid, edge.From)
+	}
+	to, ok := labels(edge.To)
+	if !ok {
+		return fmt.Errorf("edge %s: vertex %s not found", edge.Gid, edge.To)
+	}
+	var fields map[string]interface{}
+	found := false
+	allowed := []string{}
+	for _, t := range types {
+		if t.from == from && t.to == to {
+			fields, found = t.fields, true
+			break
+		}
+		allowed = append(allowed, fmt.Sprintf("(%s)->(%s)", t.from, t.to))
+	}
+	if !found {
+		sort.Strings(allowed)
+		return fmt.Errorf("edge %s: '%s' edges not allowed from '%s' to '%s'; allowed: %s",
+			edge.Gid, edge.Label, from, to, strings.Join(allowed, ", "))
+	}
+	data := edge.GetDataMap()
+	if err := v.checkRequired(edge.Label, data); err != nil {
+		return fmt.Errorf("edge %s: %v", edge.Gid, err)
+	}
+	if err := checkFields("", fields, data); err != nil {
+		return fmt.Errorf("edge %s: %v", edge.Gid, err)
+	}
+	return nil
+}
+
+func (v *Validator) checkRequired(label string, data map[string]interface{}) error {
+	for _, f := range v.required[label] {
+		if val, ok := data[f]; !ok || val == nil {
+			return fmt.Errorf("required field '%s' missing", f)
+		}
+	}
+	return nil
+}
+
+// checkFields compares data to the field types of a schema element. Fields
+// that are not in the schema, or have a null value, are not checked.
+func checkFields(prefix string, fields map[string]interface{}, data map[string]interface{}) error {
+	for k, val := range data {
+		ftype, ok := fields[k]
+		if !ok || val == nil {
+			continue
+		}
+		if err := checkField(prefix+k, ftype, val); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func checkField(name string, ftype interface{}, val interface{}) error {
+	switch t := ftype.(type) {
+	case map[string]interface{}:
+		m, ok := val.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("field '%s' should be %s not %s", name, gripql.FieldType_MAP, valueType(val))
+		}
+		return checkFields(name+".", t, m)
+	case []interface{}:
+		l, ok := val.([]interface{})
+		if !ok {
+			return fmt.Errorf("field '%s' should be %s not %s", name, gripql.FieldType_ARRAY, valueType(val))
+		}
+		if len(t) == 0 {
+			return nil
+		}
+		for i, e := range l {
+			if e == nil {
+				continue
+			}
+			if err := checkField(fmt.Sprintf("%s[%d]", name, i), t[0], e); err != nil {
+				return err
+			}
+		}
+	case string:
+		if _, ok := gripql.FieldType_value[t]; !ok || t == gripql.FieldType_UNKNOWN.String() {
+			return nil
+		}
+		vt := valueType(val)
+		// timestamps are also strings
+		if vt != t && !(vt == gripql.FieldType_DATETIME.String() && t == gripql.FieldType_STRING.String()) {
+			return fmt.Errorf("field '%s' should be %s not %s", name, t, vt)
+		}
+	}
+	return nil
+}
+
+func valueType(val interface{}) string {
+	switch val.(type) {
+	case map[string]interface{}:
+		return gripql.FieldType_MAP.String()
+	case []interface{}:
+		return gripql.FieldType_ARRAY.String()
+	}
+	return gripql.GetFieldType(val)
+}
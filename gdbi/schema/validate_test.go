@@ -0,0 +1,85 @@
+package schema
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bmeg/grip/gripql"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func mustStruct(t *testing.T, m map[string]interface{}) *structpb.Struct {
+	s, err := structpb.NewStruct(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func TestValidator(t *testing.T) {
+	graphSchema := &gripql.Graph{
+		Graph: "test",
+		Vertices: []*gripql.Vertex{
+			{Gid: "Person", Label: "Vertex", Data: mustStruct(t, map[string]interface{}{
+				"name":    "STRING",
+				"age":     "NUMERIC",
+				"tags":    []interface{}{"STRING"},
+				"address": map[string]interface{}{"city": "STRING"},
+			})},
+			{Gid: "Dog", Label: "Vertex", Data: mustStruct(t, map[string]interface{}{"name": "STRING"})},
+		},
+		Edges: []*gripql.Edge{
+			{Gid: "(Person)--owns->(Dog)", Label: "owns", From: "Person", To: "Dog",
+				Data: mustStruct(t, map[string]interface{}{"since": "NUMERIC"})},
+		},
+	}
+	v := NewValidator(graphSchema, Enforcement{Required: map[string][]string{"Person": {"name"}}})
+
+	vertexTests := []struct {
+		label string
+		data  map[string]interface{}
+		err   string
+	}{
+		{"Person", map[string]interface{}{"name": "bob", "age": 30, "tags": []interface{}{"a"}}, ""},
+		{"Person", map[string]interface{}{"name": "bob", "address": map[string]interface{}{"city": "x"}, "extra": true}, ""},
+		{"Cat", map[string]interface{}{}, "label 'Cat' not found"},
+		{"Person", map[string]interface{}{"age": 30}, "required field 'name' missing"},
+		{"Person", map[string]interface{}{"name": "bob", "age": "30"}, "field 'age' should be NUMERIC not STRING"},
+		{"Person", map[string]interface{}{"name": "bob", "tags": []interface{}{"a", 1}}, "field 'tags[1]' should be STRING not NUMERIC"},
+		{"Person", map[string]interface{}{"name": "bob", "address": map[string]interface{}{"city": false}}, "field 'address.city' should be STRING not BOOL"},
+		{"Person", map[string]interface{}{"name": "bob", "address": "x"}, "field 'address' should be MAP not STRING"},
+	}
+	for i, tc := range vertexTests {
+		err := v.ValidateVertex(&gripql.Vertex{Gid: "1", Label: tc.label, Data: mustStruct(t, tc.data)})
+		if tc.err == "" && err != nil {
+			t.Errorf("test %d: unexpected error: %v", i, err)
+		} else if tc.err != "" && (err == nil || !strings.Contains(err.Error(), tc.err)) {
+			t.Errorf("test %d: expected error '%s', got: %v", i, tc.err, err)
+		}
+	}
+
+	labels := func(gid string) (string, bool) {
+		l, ok := map[string]string{"p": "Person", "d": "Dog"}[gid]
+		return l, ok
+	}
+	edgeTests := []struct {
+		label, from, to string
+		data            map[string]interface{}
+		err             string
+	}{
+		{"owns", "p", "d", map[string]interface{}{"since": 2010}, ""},
+		{"likes", "p", "d", map[string]interface{}{}, "label 'likes' not found"},
+		{"owns", "d", "p", map[string]interface{}{}, "'owns' edges not allowed from 'Dog' to 'Person'"},
+		{"owns", "p", "x", map[string]interface{}{}, "vertex x not found"},
+		{"owns", "p", "d", map[string]interface{}{"since": "2010"}, "field 'since' should be NUMERIC not STRING"},
+	}
+	for i, tc := range edgeTests {
+		e := &gripql.Edge{Gid: "e", Label: tc.label, From: tc.from, To: tc.to, Data: mustStruct(t, tc.data)}
+		err := v.ValidateEdge(e, labels)
+		if tc.err == "" && err != nil {
+			t.Errorf("edge test %d: unexpected error: %v", i, err)
+		} else if tc.err != "" && (err == nil || !strings.Contains(err.Error(), tc.err)) {
+			t.Errorf("edge test %d: expected error '%s', got: %v", i, tc.err, err)
+		}
+	}
+}
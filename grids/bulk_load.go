@@ -0,0 +1,291 @@
+package grids
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/akrylysov/pogreb"
+	"github.com/bmeg/grip/gdbi"
+	"github.com/bmeg/grip/gripql"
+	"github.com/bmeg/grip/kvi/pebbledb"
+	"github.com/bmeg/grip/log"
+	"github.com/bmeg/grip/util/protoutil"
+)
+
+// BulkBatchSize is the number of vertices or edges that are sorted and
+// assigned keys together
+const BulkBatchSize = 100000
+
+// BulkLoader writes vertices and edges directly into the files of a grids
+// graph, without a running server. Elements are collected into batches that
+// are sorted by id before their keys are assigned. Keys are taken from in
+// memory counters, and the graph and label index entries are written as
+// sorted SST files. The graph must not be open in a server during the load.
+type BulkLoader struct {
+	graph      *Graph
+	graphIn    *pebbledb.Ingester
+	indexIn    *pebbledb.Ingester
+	vertices   []*gdbi.Vertex
+	edges      []*gdbi.Edge
+	labels     map[string]uint64
+	nextVertex uint64
+	nextEdge   uint64
+	nextLabel  uint64
+	skipped    int
+}
+
+// NewBulkLoader opens the graph, creating it if it doesn't exist
+func NewBulkLoader(baseDir string, graph string) (*BulkLoader, error) {
+	if err := gripql.ValidateGraphName(graph); err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(baseDir, 0700); err != nil {
+		return nil, err
+	}
+	g, err := newGraph(baseDir, graph)
+	if err != nil {
+		return nil, err
+	}
+	graphkv, ok := g.graphkv.(*pebbledb.PebbleKV)
+	if !ok {
+		g.Close()
+		return nil, fmt.Errorf("bulk loading needs a pebble graph store")
+	}
+	indexkv, ok := g.indexkv.(*pebbledb.PebbleKV)
+	if !ok {
+		g.Close()
+		return nil, fmt.Errorf("bulk loading needs a pebble index store")
+	}
+	dbPath := filepath.Join(baseDir, graph)
+	graphIn, err := graphkv.NewIngester(filepath.Join(dbPath, "ingest-graph"), 0)
+	if err != nil {
+		g.Close()
+		return nil, err
+	}
+	indexIn, err := indexkv.NewIngester(filepath.Join(dbPath, "ingest-index"), 0)
+	if err != nil {
+		g.Close()
+		return nil, err
+	}
+	km := g.keyMap.db
+	return &BulkLoader{
+		graph:      g,
+		graphIn:    graphIn,
+		indexIn:    indexIn,
+		labels:     map[string]uint64{},
+		nextVertex: nextKey(vInc, km),
+		nextEdge:   nextKey(eInc, km),
+		nextLabel:  nextKey(lInc, km),
+	}, nil
+}
+
+// AddVertex adds a vertex to the current batch. A vertex that is already in
+// the graph is replaced
+func (b *BulkLoader) AddVertex(v *gdbi.Vertex) error {
+	if v.ID == "" {
+		return fmt.Errorf("inserting null key vertex")
+	}
+	b.vertices = append(b.vertices, v)
+	if len(b.vertices) >= BulkBatchSize {
+		return b.flushVertices()
+	}
+	return nil
+}
+
+// AddEdge adds an edge to the current batch. The vertices it connects must be
+// added first
+func (b *BulkLoader) AddEdge(e *gdbi.Edge) error {
+	if e.ID == "" {
+		return fmt.Errorf("inserting null key edge")
+	}
+	if len(b.vertices) > 0 {
+		if err := b.flushVertices(); err != nil {
+			return err
+		}
+	}
+	b.edges = append(b.edges, e)
+	if len(b.edges) >= BulkBatchSize {
+		return b.flushEdges()
+	}
+	return nil
+}
+
+// Skipped returns the number of edges that were skipped because a vertex was
+// not found
+func (b *BulkLoader) Skipped() int {
+	return b.skipped
+}
+
+func (b *BulkLoader) labelKey(label string) (uint64, error) {
+	if k, ok := b.labels[label]; ok {
+		return k, nil
+	}
+	db := b.graph.keyMap.db
+	k, ok := getIDKey(lIDPrefix, label, db)
+	if !ok {
+		k = b.nextLabel
+		b.nextLabel++
+		if err := setNextKey(lInc, b.nextLabel, db); err != nil {
+			return 0, err
+		}
+		if err := setKeyID(lKeyPrefix, label, k, db); err != nil {
+			return 0, err
+		}
+		if err := setIDKey(lIDPrefix, label, k, db); err != nil {
+			return 0, err
+		}
+	}
+	b.labels[label] = k
+	return k, nil
+}
+
+// elementKey returns the key of an existing element, or assigns the next key
+func (b *BulkLoader) elementKey(idPrefix []byte, keyPrefix byte, next *uint64, id string) (uint64, error) {
+	db := b.graph.keyMap.db
+	if k, ok := getIDKey(idPrefix, id, db); ok {
+		return k, nil
+	}
+	k := *next
+	(*next)++
+	if err := setKeyID(keyPrefix, id, k, db); err != nil {
+		return 0, err
+	}
+	return k, setIDKey(idPrefix, id, k, db)
+}
+
+func (b *BulkLoader) flushVertices() error {
+	sort.SliceStable(b.vertices, func(i, j int) bool { return b.vertices[i].ID < b.vertices[j].ID })
+	db := b.graph.keyMap.db
+	// reserve the keys of the batch first, so a load that stops part way
+	// through can't reuse them
+	if err := reserveKeys(vInc, b.nextVertex+uint64(len(b.vertices)), db); err != nil {
+		return err
+	}
+	for i, v := range b.vertices {
+		// when a vertex was added more than once, keep the last one
+		if i+1 < len(b.vertices) && b.vertices[i+1].ID == v.ID {
+			continue
+		}
+		key, err := b.elementKey(vIDPrefix, vKeyPrefix, &b.nextVertex, v.ID)
+		if err != nil {
+			return err
+		}
+		lkey, err := b.labelKey(v.Label)
+		if err != nil {
+			return err
+		}
+		if err := setIDLabel(vLabelPrefix, key, lkey, db); err != nil {
+			return err
+		}
+		if v.Data == nil {
+			v.Data = map[string]interface{}{}
+		}
+		value, err := protoutil.StructMarshal(v.Data)
+		if err != nil {
+			return err
+		}
+		if err := b.graphIn.Set(VertexKey(key), value); err != nil {
+			return err
+		}
+		if err := indexVertex(b.indexIn, b.graph.idx, b.graph.graphID, v); err != nil {
+			return err
+		}
+	}
+	b.vertices = b.vertices[:0]
+	return nil
+}
+
+func (b *BulkLoader) flushEdges() error {
+	sort.SliceStable(b.edges, func(i, j int) bool { return b.edges[i].ID < b.edges[j].ID })
+	db := b.graph.keyMap.db
+	if err := reserveKeys(eInc, b.nextEdge+uint64(len(b.edges)), db); err != nil {
+		return err
+	}
+	for i, e := range b.edges {
+		if i+1 < len(b.edges) && b.edges[i+1].ID == e.ID {
+			continue
+		}
+		src, ok := getIDKey(vIDPrefix, e.From, db)
+		if !ok {
+			log.WithFields(log.Fields{"edge": e.ID, "vertex": e.From}).Error("BulkLoader: vertex not found")
+			b.skipped++
+			continue
+		}
+		dst, ok := getIDKey(vIDPrefix, e.To, db)
+		if !ok {
+			log.WithFields(log.Fields{"edge": e.ID, "vertex": e.To}).Error("BulkLoader: vertex not found")
+			b.skipped++
+			continue
+		}
+		key, err := b.elementKey(eIDPrefix, eKeyPrefix, &b.nextEdge, e.ID)
+		if err != nil {
+			return err
+		}
+		lkey, err := b.labelKey(e.Label)
+		if err != nil {
+			return err
+		}
+		if err := setIDLabel(eLabelPrefix, key, lkey, db); err != nil {
+			return err
+		}
+		data, err := protoutil.StructMarshal(e.Data)
+		if err != nil {
+			return err
+		}
+		if err := b.graphIn.Set(EdgeKey(key, src, dst, lkey), data); err != nil {
+			return err
+		}
+		if err := b.graphIn.Set(SrcEdgeKey(key, src, dst, lkey), []byte{}); err != nil {
+			return err
+		}
+		if err := b.graphIn.Set(DstEdgeKey(key, src, dst, lkey), []byte{}); err != nil {
+			return err
+		}
+		if err := indexEdge(b.indexIn, b.graph.idx, b.graph.graphID, e); err != nil {
+			return err
+		}
+	}
+	b.edges = b.edges[:0]
+	return nil
+}
+
+// reserveKeys moves the stored key counter up to next
+func reserveKeys(k []byte, next uint64, db *pogreb.DB) error {
+	if next > nextKey(k, db) {
+		return setNextKey(k, next, db)
+	}
+	return nil
+}
+
+// Sync writes the current batches, and ingests everything written so far.
+// Elements added before a Sync are stored even if the load doesn't finish
+func (b *BulkLoader) Sync() error {
+	if err := b.flushVertices(); err != nil {
+		return err
+	}
+	if err := b.flushEdges(); err != nil {
+		return err
+	}
+	if err := b.graphIn.Flush(); err != nil {
+		return err
+	}
+	if err := b.indexIn.Flush(); err != nil {
+		return err
+	}
+	b.graph.ts.Touch(b.graph.graphID)
+	return b.graph.keyMap.db.Sync()
+}
+
+// Close writes the remaining batches and closes the graph
+func (b *BulkLoader) Close() error {
+	defer b.graph.Close()
+	if err := b.Sync(); err != nil {
+		return err
+	}
+	if err := b.graphIn.Close(); err != nil {
+		return err
+	}
+	return b.indexIn.Close()
+}
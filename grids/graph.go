@@ -48,8 +48,8 @@ func insertVertex(tx kvi.KVBulkWrite, keyMap *KeyMap, vertex *gdbi.Vertex) error
 	return nil
 }
 
-func indexVertex(tx kvi.KVBulkWrite, idx *kvindex.KVIndex, vertex *gdbi.Vertex) error {
-	doc := vertexIdxStruct(vertex)
+func indexVertex(tx kvi.KVBulkWrite, idx *kvindex.KVIndex, graph string, vertex *gdbi.Vertex) error {
+	doc := map[string]interface{}{graph: vertexIdxStruct(vertex)}
 	if err := idx.AddDocTx(tx, vertex.ID, doc); err != nil {
 		return fmt.Errorf("AddVertex Error %s", err)
 	}
@@ -98,14 +98,19 @@ func insertEdge(tx kvi.KVBulkWrite, keyMap *KeyMap, edge *gdbi.Edge) error {
 	return nil
 }
 
-func indexEdge(tx kvi.KVBulkWrite, idx *kvindex.KVIndex, edge *gdbi.Edge) error {
-	err := idx.AddDocTx(tx, edge.ID, edgeIdxStruct(edge))
+func indexEdge(tx kvi.KVBulkWrite, idx *kvindex.KVIndex, graph string, edge *gdbi.Edge) error {
+	err := idx.AddDocTx(tx, edge.ID, map[string]interface{}{graph: edgeIdxStruct(edge)})
 	return err
 }
 
 // AddVertex adds an edge to the graph, if it already exists
 // in the graph, it is replaced
 func (ggraph *Graph) AddVertex(vertices []*gdbi.Vertex) error {
+	for _, vert := range vertices {
+		if err := ggraph.clearVertex(vert.ID); err != nil {
+			return err
+		}
+	}
 	err := ggraph.graphkv.BulkWrite(func(tx kvi.KVBulkWrite) error {
 		var bulkErr *multierror.Error
 		for _, vert := range vertices {
@@ -120,7 +125,7 @@ func (ggraph *Graph) AddVertex(vertices []*gdbi.Vertex) error {
 	err = ggraph.indexkv.BulkWrite(func(tx kvi.KVBulkWrite) error {
 		var bulkErr *multierror.Error
 		for _, vert := range vertices {
-			if err := indexVertex(tx, ggraph.idx, vert); err != nil {
+			if err := indexVertex(tx, ggraph.idx, ggraph.graphID, vert); err != nil {
 				bulkErr = multierror.Append(bulkErr, err)
 				log.Errorf("IndexVertex Error %s", err)
 			}
@@ -134,6 +139,11 @@ func (ggraph *Graph) AddVertex(vertices []*gdbi.Vertex) error {
 // AddEdge adds an edge to the graph, if the id is not "" and in already exists
 // in the graph, it is replaced
 func (ggraph *Graph) AddEdge(edges []*gdbi.Edge) error {
+	for _, edge := range edges {
+		if err := ggraph.clearEdge(edge.ID); err != nil {
+			return err
+		}
+	}
 	err := ggraph.graphkv.BulkWrite(func(tx kvi.KVBulkWrite) error {
 		for _, edge := range edges {
 			err := insertEdge(tx, ggraph.keyMap, edge)
@@ -150,7 +160,7 @@ func (ggraph *Graph) AddEdge(edges []*gdbi.Edge) error {
 	err = ggraph.indexkv.BulkWrite(func(tx kvi.KVBulkWrite) error {
 		var bulkErr *multierror.Error
 		for _, edge := range edges {
-			if err := indexEdge(tx, ggraph.idx, edge); err != nil {
+			if err := indexEdge(tx, ggraph.idx, ggraph.graphID, edge); err != nil {
 				bulkErr = multierror.Append(bulkErr, err)
 			}
 		}
@@ -161,6 +171,10 @@ func (ggraph *Graph) AddEdge(edges []*gdbi.Edge) error {
 
 }
 
+// BulkAdd streams vertices and edges into the graph. Elements that already
+// exist are replaced as in AddVertex and AddEdge. An id that appears twice in
+// one stream keeps the index entries of its first copy, since neither copy is
+// written until the stream ends.
 func (ggraph *Graph) BulkAdd(stream <-chan *gdbi.GraphElement) error {
 	var anyErr error
 	insertStream := make(chan *gdbi.GraphElement, 100)
@@ -190,12 +204,12 @@ func (ggraph *Graph) BulkAdd(stream <-chan *gdbi.GraphElement) error {
 		ggraph.indexkv.BulkWrite(func(tx kvi.KVBulkWrite) error {
 			for elem := range indexStream {
 				if elem.Vertex != nil {
-					if err := indexVertex(tx, ggraph.idx, elem.Vertex); err != nil {
+					if err := indexVertex(tx, ggraph.idx, ggraph.graphID, elem.Vertex); err != nil {
 						anyErr = err
 					}
 				}
 				if elem.Edge != nil {
-					if err := indexEdge(tx, ggraph.idx, elem.Edge); err != nil {
+					if err := indexEdge(tx, ggraph.idx, ggraph.graphID, elem.Edge); err != nil {
 						anyErr = err
 					}
 				}
@@ -205,13 +219,29 @@ func (ggraph *Graph) BulkAdd(stream <-chan *gdbi.GraphElement) error {
 		})
 	}()
 
+	var clearErr error
 	for i := range stream {
+		var err error
+		if i.Vertex != nil {
+			err = ggraph.clearVertex(i.Vertex.ID)
+		} else if i.Edge != nil {
+			err = ggraph.clearEdge(i.Edge.ID)
+		}
+		if err != nil {
+			log.Errorf("BulkAdd: replacing element: %s", err)
+			clearErr = err
+			continue
+		}
 		insertStream <- i
 		indexStream <- i
 	}
 	close(insertStream)
 	close(indexStream)
 	s.Wait()
+	ggraph.ts.Touch(ggraph.graphID)
+	if anyErr == nil {
+		anyErr = clearErr
+	}
 	return anyErr
 }
 
@@ -221,39 +251,76 @@ func (ggraph *Graph) DelEdge(eid string) error {
 	if !ok {
 		return fmt.Errorf("edge not found")
 	}
+	keys := ggraph.edgeKeys(edgeKey)
+	if keys == nil {
+		return fmt.Errorf("edge not found")
+	}
+
+	var bulkErr *multierror.Error
+	for _, k := range keys {
+		if err := ggraph.graphkv.Delete(k); err != nil {
+			bulkErr = multierror.Append(bulkErr, err)
+		}
+	}
+	if err := ggraph.keyMap.DelEdgeKey(eid); err != nil {
+		bulkErr = multierror.Append(bulkErr, err)
+	}
+	if err := ggraph.idx.RemoveDoc(eid); err != nil {
+		bulkErr = multierror.Append(bulkErr, err)
+	}
+	ggraph.ts.Touch(ggraph.graphID)
+	return bulkErr.ErrorOrNil()
+}
+
+// edgeKeys returns the edge, source and destination keys stored for an edge,
+// or nil if the edge is not in the graph store
+func (ggraph *Graph) edgeKeys(edgeKey uint64) [][]byte {
 	ekeyPrefix := EdgeKeyPrefix(edgeKey)
 	var ekey []byte
 	ggraph.graphkv.View(func(it kvi.KVIterator) error {
 		for it.Seek(ekeyPrefix); it.Valid() && bytes.HasPrefix(it.Key(), ekeyPrefix); it.Next() {
-			ekey = it.Key()
+			ekey = append([]byte{}, it.Key()...)
 		}
 		return nil
 	})
-
 	if ekey == nil {
-		return fmt.Errorf("edge not found")
+		return nil
 	}
+	_, sid, did, lbl := EdgeKeyParse(ekey)
+	return [][]byte{ekey, SrcEdgeKey(edgeKey, sid, did, lbl), DstEdgeKey(edgeKey, sid, did, lbl)}
+}
 
-	_, sid, did, _ := EdgeKeyParse(ekey)
-
-	skey := SrcEdgeKeyPrefix(edgeKey, sid, did)
-	dkey := DstEdgeKeyPrefix(edgeKey, sid, did)
-
-	var bulkErr *multierror.Error
-	if err := ggraph.graphkv.Delete(ekey); err != nil {
-		bulkErr = multierror.Append(bulkErr, err)
-	}
-	if err := ggraph.graphkv.Delete(skey); err != nil {
-		bulkErr = multierror.Append(bulkErr, err)
+// clearVertex removes the index document of a vertex that is about to be
+// replaced, so the entries of its old label and fields stop matching it
+func (ggraph *Graph) clearVertex(id string) error {
+	if _, ok := ggraph.keyMap.GetVertexKey(id); !ok {
+		return nil
 	}
-	if err := ggraph.graphkv.Delete(dkey); err != nil {
-		bulkErr = multierror.Append(bulkErr, err)
+	return ggraph.idx.RemoveDoc(id)
+}
+
+// clearEdge removes the keys and the index document of an edge that is about
+// to be replaced. The keys hold the label and endpoints of the edge, so they
+// would be left behind if the new edge changes them.
+func (ggraph *Graph) clearEdge(id string) error {
+	edgeKey, ok := ggraph.keyMap.GetEdgeKey(id)
+	if !ok {
+		return nil
 	}
-	if err := ggraph.keyMap.DelEdgeKey(eid); err != nil {
-		bulkErr = multierror.Append(bulkErr, err)
+	if keys := ggraph.edgeKeys(edgeKey); keys != nil {
+		err := ggraph.graphkv.Update(func(tx kvi.KVTransaction) error {
+			for _, k := range keys {
+				if err := tx.Delete(k); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
 	}
-	ggraph.ts.Touch(ggraph.graphID)
-	return bulkErr.ErrorOrNil()
+	return ggraph.idx.RemoveDoc(id)
 }
 
 // DelVertex deletes vertex with id `key`
@@ -267,6 +334,7 @@ func (ggraph *Graph) DelVertex(id string) error {
 	dkeyPrefix := DstEdgePrefix(vertexKey)
 
 	delKeys := make([][]byte, 0, 1000)
+	delDocs := []string{id}
 
 	var bulkErr *multierror.Error
 
@@ -282,6 +350,7 @@ func (ggraph *Graph) DelVertex(id string) error {
 
 			edgeID, ok := ggraph.keyMap.GetEdgeID(eid)
 			if ok {
+				delDocs = append(delDocs, edgeID)
 				if err := ggraph.keyMap.DelEdgeKey(edgeID); err != nil {
 					bulkErr = multierror.Append(bulkErr, err)
 				}
@@ -297,6 +366,7 @@ func (ggraph *Graph) DelVertex(id string) error {
 
 			edgeID, ok := ggraph.keyMap.GetEdgeID(eid)
 			if ok {
+				delDocs = append(delDocs, edgeID)
 				if err := ggraph.keyMap.DelEdgeKey(edgeID); err != nil {
 					bulkErr = multierror.Append(bulkErr, err)
 				}
@@ -327,6 +397,11 @@ func (ggraph *Graph) DelVertex(id string) error {
 	if err != nil {
 		bulkErr = multierror.Append(bulkErr, err)
 	}
+	for _, doc := range delDocs {
+		if err := ggraph.idx.RemoveDoc(doc); err != nil {
+			bulkErr = multierror.Append(bulkErr, err)
+		}
+	}
 	return bulkErr.ErrorOrNil()
 }
 
@@ -356,7 +431,7 @@ func (ggraph *Graph) GetEdgeList(ctx context.Context, loadProp bool) <-chan *gdb
 					e.Data, err = protoutil.StructUnMarshal(edgeData)
 					e.Loaded = true
 					if err != nil {
-						log.Errorf("GetEdgeList: unmarshal error: %v", err)
+						gdbi.ReportError(ctx, "GetEdgeList", fmt.Errorf("unmarshal error: %v", err))
 						continue
 					}
 				} else {
@@ -451,7 +526,7 @@ func (ggraph *Graph) GetVertexChannel(ctx context.Context, ids chan gdbi.Element
 					var err error
 					v.Data, err = protoutil.StructUnMarshal(d.data)
 					if err != nil {
-						log.Errorf("GetVertexChannel: unmarshal error: %v", err)
+						gdbi.ReportError(ctx, "GetVertexChannel", fmt.Errorf("unmarshal error: %v", err))
 						continue
 					}
 					v.Loaded = true
@@ -523,7 +598,7 @@ func (ggraph *Graph) GetOutChannel(ctx context.Context, reqChan chan gdbi.Elemen
 						if err == nil {
 							v.Data, err = protoutil.StructUnMarshal(dataValue)
 							if err != nil {
-								log.Errorf("GetOutChannel: unmarshal error: %v", err)
+								gdbi.ReportError(ctx, "GetOutChannel", fmt.Errorf("unmarshal error: %v", err))
 								continue
 							}
 							v.Loaded = true
@@ -575,7 +650,7 @@ func (ggraph *Graph) GetInChannel(ctx context.Context, reqChan chan gdbi.Element
 									if err == nil {
 										v.Data, err = protoutil.StructUnMarshal(dataValue)
 										if err != nil {
-											log.Errorf("GetInChannel: unmarshal error: %v", err)
+											gdbi.ReportError(ctx, "GetInChannel", fmt.Errorf("unmarshal error: %v", err))
 											continue
 										}
 										v.Loaded = true
@@ -631,7 +706,7 @@ func (ggraph *Graph) GetOutEdgeChannel(ctx context.Context, reqChan chan gdbi.El
 									if err == nil {
 										e.Data, err = protoutil.StructUnMarshal(dataValue)
 										if err != nil {
-											log.Errorf("GetOutEdgeChannel: unmarshal error: %v", err)
+											gdbi.ReportError(ctx, "GetOutEdgeChannel", fmt.Errorf("unmarshal error: %v", err))
 											continue
 										}
 										e.Loaded = true
@@ -688,7 +763,7 @@ func (ggraph *Graph) GetInEdgeChannel(ctx context.Context, reqChan chan gdbi.Ele
 									if err == nil {
 										e.Data, err = protoutil.StructUnMarshal(dataValue)
 										if err != nil {
-											log.Errorf("GetInEdgeChannel: unmarshal error: %v", err)
+											gdbi.ReportError(ctx, "GetInEdgeChannel", fmt.Errorf("unmarshal error: %v", err))
 											continue
 										}
 										e.Loaded = true
@@ -777,7 +852,7 @@ func (ggraph *Graph) GetVertexList(ctx context.Context, loadProp bool) <-chan *g
 					dataValue, _ := it.Value()
 					v.Data, err = protoutil.StructUnMarshal(dataValue)
 					if err != nil {
-						log.Errorf("GetVertexList: unmarshal error: %v", err)
+						gdbi.ReportError(ctx, "GetVertexList", fmt.Errorf("unmarshal error: %v", err))
 						continue
 					}
 					v.Loaded = true
@@ -2,13 +2,18 @@ package grids
 
 import (
 	"context"
+	"encoding/binary"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/bmeg/grip/gdbi"
 	"github.com/bmeg/grip/gripql"
 	"github.com/bmeg/grip/jsonpath"
+	"github.com/bmeg/grip/kvi"
+	"github.com/bmeg/grip/kvindex"
 	"github.com/bmeg/grip/log"
+	"github.com/bmeg/grip/util/geo"
 )
 
 func (kgraph *Graph) setupGraphIndex(graph string) error {
@@ -23,6 +28,61 @@ func (kgraph *Graph) setupGraphIndex(graph string) error {
 	return nil
 }
 
+// indexVersion is the layout of the index documents. Version 1 keys the
+// document of each element under the graph name, and version 2 stores
+// timestamp strings as time terms
+const indexVersion = 2
+
+// indexVersionKey holds the layout version in the index store. It doesn't
+// collide with the single letter prefixes of the kvindex keys
+var indexVersionKey = []byte("grids.index.version")
+
+// migrateIndex rebuilds the index of a graph that was written with an older
+// document layout. The index entries of older layouts are dropped, and the
+// vertices and edges are streamed from the graph store into the index.
+func (kgraph *Graph) migrateIndex() error {
+	if v, err := kgraph.indexkv.Get(indexVersionKey); err == nil {
+		if n, _ := binary.Uvarint(v); n >= indexVersion {
+			return nil
+		}
+	}
+	log.Infof("Reindexing GRIDS graph %s", kgraph.graphID)
+	for _, f := range kgraph.idx.ListFields() {
+		isGeo := kgraph.idx.GeoFields[f]
+		if err := kgraph.idx.RemoveField(f); err != nil {
+			return err
+		}
+		add := kgraph.idx.AddField
+		if isGeo {
+			add = kgraph.idx.AddGeoField
+		}
+		if err := add(f); err != nil {
+			return err
+		}
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	err := kgraph.indexkv.BulkWrite(func(tx kvi.KVBulkWrite) error {
+		for v := range kgraph.GetVertexList(ctx, true) {
+			if err := indexVertex(tx, kgraph.idx, kgraph.graphID, v); err != nil {
+				return err
+			}
+		}
+		for e := range kgraph.GetEdgeList(ctx, true) {
+			if err := indexEdge(tx, kgraph.idx, kgraph.graphID, e); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("reindexing graph %s: %v", kgraph.graphID, err)
+	}
+	b := make([]byte, binary.MaxVarintLen64)
+	n := binary.PutUvarint(b, indexVersion)
+	return kgraph.indexkv.Set(indexVersionKey, b[:n])
+}
+
 func (kgraph *Graph) deleteGraphIndex(graph string) error {
 	var anyError error
 	fields := kgraph.idx.ListFields()
@@ -64,12 +124,71 @@ func edgeIdxStruct(e *gdbi.Edge) map[string]interface{} {
 	return k
 }
 
-//AddVertexIndex add index to vertices
-func (ggraph *Graph) AddVertexIndex(label string, field string) error {
-	log.WithFields(log.Fields{"label": label, "field": field}).Info("Adding vertex index")
+//AddVertexIndex add index to vertices. Points are stored by geohash only
+//for GEO_INDEX fields
+func (ggraph *Graph) AddVertexIndex(label string, field string, itype gripql.IndexType) error {
+	log.WithFields(log.Fields{"label": label, "field": field, "type": itype}).Info("Adding vertex index")
 	field = normalizePath(field)
-	//TODO kick off background process to reindex existing data
-	return ggraph.idx.AddField(fmt.Sprintf("%s.v.%s.%s", ggraph.graphID, label, field))
+	path := fmt.Sprintf("%s.v.%s.%s", ggraph.graphID, label, field)
+	add := ggraph.idx.AddField
+	if itype == gripql.IndexType_GEO_INDEX {
+		add = ggraph.idx.AddGeoField
+	}
+	if err := add(path); err != nil {
+		return err
+	}
+	return ggraph.indexVertices(label)
+}
+
+// indexVertices adds the vertices with the label that were loaded before
+// their fields were indexed. Like migrateIndex, the vertices are streamed from
+// the graph store into the index
+func (ggraph *Graph) indexVertices(label string) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	return ggraph.indexkv.BulkWrite(func(tx kvi.KVBulkWrite) error {
+		for v := range ggraph.GetVertexList(ctx, true) {
+			if v.Label != label {
+				continue
+			}
+			if err := indexVertex(tx, ggraph.idx, ggraph.graphID, v); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// VertexGeoScan produces the ids of vertices with the label whose indexed
+// field holds a point near the box
+func (ggraph *Graph) VertexGeoScan(ctx context.Context, label string, field string, box geo.Box) (chan string, bool) {
+	path := fmt.Sprintf("%s.v.%s.%s", ggraph.graphID, label, normalizePath(field))
+	if !ggraph.idx.GeoFields[path] {
+		return nil, false
+	}
+	log.WithFields(log.Fields{"label": label, "field": field}).Debug("Running VertexGeoScan")
+	return ggraph.idx.GetGeoBox(ctx, path, box, 0), true
+}
+
+// VertexTimeScan produces the ids of vertices with the label whose indexed
+// field holds a timestamp in the range, or a string that isn't a timestamp
+func (ggraph *Graph) VertexTimeScan(ctx context.Context, label string, field string, min, max time.Time) (chan string, bool) {
+	path := fmt.Sprintf("%s.v.%s.%s", ggraph.graphID, label, normalizePath(field))
+	if _, ok := ggraph.idx.Fields[path]; !ok {
+		return nil, false
+	}
+	log.WithFields(log.Fields{"label": label, "field": field}).Debug("Running VertexTimeScan")
+	out := make(chan string, 100)
+	go func() {
+		defer close(out)
+		for id := range ggraph.idx.GetTimeRange(ctx, path, min, max, 0) {
+			out <- id
+		}
+		for id := range ggraph.idx.GetTypeMatch(ctx, path, kvindex.TermString, 0) {
+			out <- id
+		}
+	}()
+	return out, true
 }
 
 //DeleteVertexIndex delete index from vertices
@@ -89,7 +208,11 @@ func (ggraph *Graph) GetVertexIndexList() <-chan *gripql.IndexID {
 		for _, f := range fields {
 			t := strings.Split(f, ".")
 			if len(t) > 3 {
-				out <- &gripql.IndexID{Graph: ggraph.graphID, Label: t[2], Field: t[3]}
+				itype := gripql.IndexType_FIELD_INDEX
+				if ggraph.idx.GeoFields[f] {
+					itype = gripql.IndexType_GEO_INDEX
+				}
+				out <- &gripql.IndexID{Graph: ggraph.graphID, Label: t[2], Field: t[3], Type: itype}
 			}
 		}
 	}()
@@ -282,3 +282,20 @@ func dbInc(inc *uint64, k []byte, db *pogreb.DB) (uint64, error) {
 	}
 	return o, nil
 }
+
+// nextKey returns the first key that dbInc has not reserved
+func nextKey(k []byte, db *pogreb.DB) uint64 {
+	v, err := db.Get(k)
+	if v == nil || err != nil {
+		return 1
+	}
+	n, _ := binary.Uvarint(v)
+	return n
+}
+
+// setNextKey reserves the keys below next, so dbInc continues from there
+func setNextKey(k []byte, next uint64, db *pogreb.DB) error {
+	b := make([]byte, binary.MaxVarintLen64)
+	binary.PutUvarint(b, next)
+	return db.Put(k, b)
+}
@@ -74,8 +74,16 @@ func newGraph(baseDir, name string) (*Graph, error) {
 		return nil, err
 	}
 	ts := timestamp.NewTimestamp()
-	o := &Graph{keyMap: NewKeyMap(keykv), graphkv: graphkv, indexkv: indexkv, ts: &ts, idx: kvindex.NewIndex(indexkv)}
-
+	o := &Graph{graphID: name, keyMap: NewKeyMap(keykv), graphkv: graphkv, indexkv: indexkv, ts: &ts, idx: kvindex.NewIndex(indexkv)}
+	if err := o.idx.LoadFields(); err != nil {
+		return nil, err
+	}
+	if err := o.setupGraphIndex(name); err != nil {
+		return nil, err
+	}
+	if err := o.migrateIndex(); err != nil {
+		return nil, err
+	}
 	return o, nil
 }
 
@@ -323,7 +323,7 @@ func (t *TabularGraph) ListEdgeLabels() ([]string, error) {
 	return out, nil
 }
 
-func (t *TabularGraph) AddVertexIndex(label string, field string) error {
+func (t *TabularGraph) AddVertexIndex(label string, field string, itype gripql.IndexType) error {
 	return fmt.Errorf("DelEdge not implemented")
 }
 
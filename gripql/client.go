@@ -7,21 +7,23 @@ import (
 	"github.com/bmeg/grip/log"
 	"github.com/bmeg/grip/util/rpc"
 	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/structpb"
 )
 
 // Client is a GRPC grip client with some helper functions
 type Client struct {
-	QueryC     QueryClient
-	EditC      EditClient
-	JobC       JobClient
-	ConfigureC ConfigureClient
-	conn       *grpc.ClientConn
+	QueryC       QueryClient
+	EditC        EditClient
+	JobC         JobClient
+	ConfigureC   ConfigureClient
+	StoredQueryC StoredQueryClient
+	conn         *grpc.ClientConn
 }
 
 // WrapClient takes previously initialized GRPC clients and uses them for the
 // client wrapper
 func WrapClient(QueryC QueryClient, EditC EditClient, JobC JobClient, ConfigureC ConfigureClient) Client {
-	return Client{QueryC, EditC, JobC, ConfigureC, nil}
+	return Client{QueryC, EditC, JobC, ConfigureC, nil, nil}
 }
 
 // Connect opens a GRPC connection to an Grip server
@@ -35,11 +37,11 @@ func Connect(conf rpc.Config, write bool) (Client, error) {
 	if write {
 		editOut = NewEditClient(conn)
 	}
-	return Client{queryOut, editOut, nil, nil, conn}, nil
+	return Client{queryOut, editOut, NewJobClient(conn), nil, NewStoredQueryClient(conn), conn}, nil
 }
 
 func (client Client) WithConfigureAPI() Client {
-	return Client{client.QueryC, client.EditC, nil, NewConfigureClient(client.conn), client.conn}
+	return Client{client.QueryC, client.EditC, nil, NewConfigureClient(client.conn), client.StoredQueryC, client.conn}
 }
 
 // Close the connection
@@ -121,6 +123,33 @@ func (client Client) ListTables() (chan *TableInfo, error) {
 	return out, nil
 }
 
+// Watch streams the edits made to a graph, until ctx is cancelled
+func (client Client) Watch(ctx context.Context, graph string) (chan *GraphEvent, error) {
+	out := make(chan *GraphEvent, 100)
+	clt, err := client.QueryC.Watch(ctx, &GraphID{Graph: graph})
+	if err != nil {
+		close(out)
+		return out, err
+	}
+	go func() {
+		defer close(out)
+		for {
+			ev, err := clt.Recv()
+			if err == io.EOF {
+				return
+			}
+			if err != nil {
+				if ctx.Err() == nil {
+					log.WithFields(log.Fields{"error": err}).Error("Receiving graph events")
+				}
+				return
+			}
+			out <- ev
+		}
+	}()
+	return out, nil
+}
+
 // ListIndices lists the indices on a graph in the database
 func (client Client) ListIndices(graph string) (*ListIndicesResponse, error) {
 	return client.QueryC.ListIndices(context.Background(), &GraphID{Graph: graph})
@@ -259,6 +288,27 @@ func (client Client) GetJob(graph string, jobID string) (*JobStatus, error) {
 	return client.JobC.GetJob(context.Background(), &QueryJob{Graph: graph, Id: jobID})
 }
 
+// CollectJobs removes the jobs of a graph that violate the server's retention
+// policy and returns the status of the removed jobs
+func (client Client) CollectJobs(graph string) ([]*JobStatus, error) {
+	out := []*JobStatus{}
+	tclient, err := client.JobC.CollectJobs(context.Background(), &GraphID{Graph: graph})
+	if err != nil {
+		return nil, err
+	}
+	for {
+		t, err := tclient.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, t)
+	}
+	return out, nil
+}
+
 /*
 func (client Client) ViewJob(in *QueryJob, opts ...grpc.CallOption) (Job_ViewJobClient, error) {
 
@@ -279,3 +329,66 @@ func (client Client) ListPlugins() (*ListPluginsResponse, error) {
 func (client Client) StartPlugin(conf *PluginConfig) (*PluginStatus, error) {
 	return client.ConfigureC.StartPlugin(context.Background(), conf)
 }
+
+// AddStoredQuery stores a named query with parameters for a graph
+func (client Client) AddStoredQuery(def *QueryDefinition) error {
+	_, err := client.StoredQueryC.AddStoredQuery(context.Background(), def)
+	return err
+}
+
+// GetStoredQuery returns the definition of a stored query
+func (client Client) GetStoredQuery(graph string, name string) (*QueryDefinition, error) {
+	return client.StoredQueryC.GetStoredQuery(context.Background(), &StoredQueryID{Graph: graph, Name: name})
+}
+
+// ListStoredQueries lists the stored queries of a graph
+func (client Client) ListStoredQueries(graph string) (*ListStoredQueriesResponse, error) {
+	return client.StoredQueryC.ListStoredQueries(context.Background(), &GraphID{Graph: graph})
+}
+
+// DeleteStoredQuery removes a stored query
+func (client Client) DeleteStoredQuery(graph string, name string) error {
+	_, err := client.StoredQueryC.DeleteStoredQuery(context.Background(), &StoredQueryID{Graph: graph, Name: name})
+	return err
+}
+
+// RunStoredQuery runs a stored query with the parameters given
+func (client Client) RunStoredQuery(graph string, name string, params map[string]interface{}) (chan *QueryResult, error) {
+	args, err := structpb.NewStruct(params)
+	if err != nil {
+		return nil, err
+	}
+	out := make(chan *QueryResult, 100)
+	tclient, err := client.StoredQueryC.RunStoredQuery(context.Background(), &StoredQueryCall{Graph: graph, Name: name, Parameters: args})
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := tclient.Recv()
+	if err == io.EOF {
+		close(out)
+		return out, nil
+	}
+	if err != nil {
+		close(out)
+		return out, err
+	}
+	out <- t
+
+	go func() {
+		defer close(out)
+		for {
+			t, err := tclient.Recv()
+			if err == io.EOF {
+				return
+			}
+			if err != nil {
+				log.WithFields(log.Fields{"error": err}).Error("Receiving stored query result")
+				return
+			}
+			out <- t
+		}
+	}()
+
+	return out, nil
+}
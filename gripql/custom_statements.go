@@ -9,6 +9,11 @@ package gripql
 
 type GraphStatement_LookupVertsIndex struct {
 	Labels []string `protobuf:"bytes,1,rep,name=labels" json:"labels,omitempty"`
+	// Geo is a geo condition that a geo index may narrow the lookup with
+	Geo *HasCondition `protobuf:"bytes,2,opt,name=geo" json:"geo,omitempty"`
+	// Time is a timestamp range condition that a time index may narrow the
+	// lookup with
+	Time *HasCondition `protobuf:"bytes,3,opt,name=time" json:"time,omitempty"`
 }
 
 func (*GraphStatement_LookupVertsIndex) isGraphStatement_Statement() {}
@@ -0,0 +1,82 @@
+package gripql
+
+import (
+	"fmt"
+	"time"
+)
+
+// dateLayouts are the timestamp formats read as DATETIME values: RFC3339,
+// with optional fractional seconds, and its plain date form
+var dateLayouts = []string{time.RFC3339Nano, "2006-01-02"}
+
+// ParseDateTime reads a value as a timestamp. Only strings holding an RFC3339
+// timestamp or date are timestamps
+func ParseDateTime(val interface{}) (time.Time, bool) {
+	s, ok := val.(string)
+	// the shortest accepted form is a date
+	if !ok || len(s) < 10 || s[4] != '-' {
+		return time.Time{}, false
+	}
+	for _, layout := range dateLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// Date histogram intervals
+const (
+	DateIntervalDay   = "day"
+	DateIntervalMonth = "month"
+	DateIntervalYear  = "year"
+)
+
+// MaxDateBuckets is the widest span, in buckets, that a date histogram fills
+// with empty buckets. Wider histograms only return the buckets holding
+// timestamps
+const MaxDateBuckets = 10000
+
+// CheckDateInterval returns an error for an unknown date histogram interval
+func CheckDateInterval(interval string) error {
+	switch interval {
+	case DateIntervalDay, DateIntervalMonth, DateIntervalYear:
+		return nil
+	}
+	return fmt.Errorf("unknown date interval '%s': expected day, month or year", interval)
+}
+
+// DateBucket returns the start of the day, month or year holding t, in UTC,
+// and the start of the bucket following it
+func DateBucket(t time.Time, interval string) (time.Time, time.Time, error) {
+	t = t.UTC()
+	switch interval {
+	case DateIntervalDay:
+		start := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+		return start, start.AddDate(0, 0, 1), nil
+	case DateIntervalMonth:
+		start := time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+		return start, start.AddDate(0, 1, 0), nil
+	case DateIntervalYear:
+		start := time.Date(t.Year(), 1, 1, 0, 0, 0, 0, time.UTC)
+		return start, start.AddDate(1, 0, 0), nil
+	}
+	return time.Time{}, time.Time{}, CheckDateInterval(interval)
+}
+
+// DateBucketCount returns the number of buckets from the bucket holding start
+// to the one holding end
+func DateBucketCount(start, end time.Time, interval string) int {
+	start, end = start.UTC(), end.UTC()
+	switch interval {
+	case DateIntervalDay:
+		s, _, _ := DateBucket(start, interval)
+		e, _, _ := DateBucket(end, interval)
+		return int(e.Sub(s).Hours()/24) + 1
+	case DateIntervalMonth:
+		return (end.Year()-start.Year())*12 + int(end.Month()) - int(start.Month()) + 1
+	case DateIntervalYear:
+		return end.Year() - start.Year() + 1
+	}
+	return 0
+}
@@ -7,11 +7,13 @@ import (
 	context "context"
 	fmt "fmt"
 	gateway "github.com/akuity/grpc-gateway-client/pkg/grpc/gateway"
+	url "net/url"
 )
 
 // QueryGatewayClient is the interface for Query service client.
 type QueryGatewayClient interface {
 	Traversal(context.Context, *GraphQuery) (<-chan *QueryResult, <-chan error, error)
+	TraversalText(context.Context, *GraphQueryText) (<-chan *QueryResult, <-chan error, error)
 	GetVertex(context.Context, *ElementID) (*Vertex, error)
 	GetEdge(context.Context, *ElementID) (*Edge, error)
 	GetTimestamp(context.Context, *GraphID) (*Timestamp, error)
@@ -21,6 +23,7 @@ type QueryGatewayClient interface {
 	ListIndices(context.Context, *GraphID) (*ListIndicesResponse, error)
 	ListLabels(context.Context, *GraphID) (*ListLabelsResponse, error)
 	ListTables(context.Context, *Empty) (<-chan *TableInfo, <-chan error, error)
+	Watch(context.Context, *GraphID) (<-chan *GraphEvent, <-chan error, error)
 }
 
 func NewQueryGatewayClient(c gateway.Client) QueryGatewayClient {
@@ -40,6 +43,13 @@ func (c *queryGatewayClient) Traversal(ctx context.Context, req *GraphQuery) (<-
 	return gateway.DoStreamingRequest[QueryResult](ctx, c.gwc, gwReq)
 }
 
+func (c *queryGatewayClient) TraversalText(ctx context.Context, req *GraphQueryText) (<-chan *QueryResult, <-chan error, error) {
+	gwReq := c.gwc.NewRequest("POST", "/v1/graph/{graph}/query-text")
+	gwReq.SetPathParam("graph", fmt.Sprintf("%v", req.Graph))
+	gwReq.SetBody(req)
+	return gateway.DoStreamingRequest[QueryResult](ctx, c.gwc, gwReq)
+}
+
 func (c *queryGatewayClient) GetVertex(ctx context.Context, req *ElementID) (*Vertex, error) {
 	gwReq := c.gwc.NewRequest("GET", "/v1/graph/{graph}/vertex/{id}")
 	gwReq.SetPathParam("graph", fmt.Sprintf("%v", req.Graph))
@@ -94,6 +104,12 @@ func (c *queryGatewayClient) ListTables(ctx context.Context, req *Empty) (<-chan
 	return gateway.DoStreamingRequest[TableInfo](ctx, c.gwc, gwReq)
 }
 
+func (c *queryGatewayClient) Watch(ctx context.Context, req *GraphID) (<-chan *GraphEvent, <-chan error, error) {
+	gwReq := c.gwc.NewRequest("GET", "/v1/graph/{graph}/watch")
+	gwReq.SetPathParam("graph", fmt.Sprintf("%v", req.Graph))
+	return gateway.DoStreamingRequest[GraphEvent](ctx, c.gwc, gwReq)
+}
+
 // JobGatewayClient is the interface for Job service client.
 type JobGatewayClient interface {
 	Submit(context.Context, *GraphQuery) (*QueryJob, error)
@@ -103,6 +119,7 @@ type JobGatewayClient interface {
 	GetJob(context.Context, *QueryJob) (*JobStatus, error)
 	ViewJob(context.Context, *QueryJob) (<-chan *QueryResult, <-chan error, error)
 	ResumeJob(context.Context, *ExtendQuery) (<-chan *QueryResult, <-chan error, error)
+	CollectJobs(context.Context, *GraphID) (<-chan *JobStatus, <-chan error, error)
 }
 
 func NewJobGatewayClient(c gateway.Client) JobGatewayClient {
@@ -147,6 +164,9 @@ func (c *jobGatewayClient) GetJob(ctx context.Context, req *QueryJob) (*JobStatu
 	gwReq := c.gwc.NewRequest("GET", "/v1/graph/{graph}/job/{id}")
 	gwReq.SetPathParam("id", fmt.Sprintf("%v", req.Id))
 	gwReq.SetPathParam("graph", fmt.Sprintf("%v", req.Graph))
+	q := url.Values{}
+	q.Add("format", req.Format.String())
+	gwReq.SetQueryParamsFromValues(q)
 	return gateway.DoRequest[JobStatus](ctx, gwReq)
 }
 
@@ -165,6 +185,13 @@ func (c *jobGatewayClient) ResumeJob(ctx context.Context, req *ExtendQuery) (<-c
 	return gateway.DoStreamingRequest[QueryResult](ctx, c.gwc, gwReq)
 }
 
+func (c *jobGatewayClient) CollectJobs(ctx context.Context, req *GraphID) (<-chan *JobStatus, <-chan error, error) {
+	gwReq := c.gwc.NewRequest("POST", "/v1/graph/{graph}/job-gc")
+	gwReq.SetPathParam("graph", fmt.Sprintf("%v", req.Graph))
+	gwReq.SetBody(req)
+	return gateway.DoStreamingRequest[JobStatus](ctx, c.gwc, gwReq)
+}
+
 // EditGatewayClient is the interface for Edit service client.
 type EditGatewayClient interface {
 	AddVertex(context.Context, *GraphElement) (*EditResult, error)
@@ -271,6 +298,61 @@ func (c *editGatewayClient) AddMapping(ctx context.Context, req *Graph) (*EditRe
 	return gateway.DoRequest[EditResult](ctx, gwReq)
 }
 
+// StoredQueryGatewayClient is the interface for StoredQuery service client.
+type StoredQueryGatewayClient interface {
+	AddStoredQuery(context.Context, *QueryDefinition) (*EditResult, error)
+	GetStoredQuery(context.Context, *StoredQueryID) (*QueryDefinition, error)
+	ListStoredQueries(context.Context, *GraphID) (*ListStoredQueriesResponse, error)
+	DeleteStoredQuery(context.Context, *StoredQueryID) (*EditResult, error)
+	RunStoredQuery(context.Context, *StoredQueryCall) (<-chan *QueryResult, <-chan error, error)
+}
+
+func NewStoredQueryGatewayClient(c gateway.Client) StoredQueryGatewayClient {
+	return &storedQueryGatewayClient{
+		gwc: c,
+	}
+}
+
+type storedQueryGatewayClient struct {
+	gwc gateway.Client
+}
+
+func (c *storedQueryGatewayClient) AddStoredQuery(ctx context.Context, req *QueryDefinition) (*EditResult, error) {
+	gwReq := c.gwc.NewRequest("POST", "/v1/graph/{graph}/stored-query")
+	gwReq.SetPathParam("graph", fmt.Sprintf("%v", req.Graph))
+	gwReq.SetBody(req)
+	return gateway.DoRequest[EditResult](ctx, gwReq)
+}
+
+func (c *storedQueryGatewayClient) GetStoredQuery(ctx context.Context, req *StoredQueryID) (*QueryDefinition, error) {
+	gwReq := c.gwc.NewRequest("GET", "/v1/graph/{graph}/stored-query/{name}")
+	gwReq.SetPathParam("graph", fmt.Sprintf("%v", req.Graph))
+	gwReq.SetPathParam("name", fmt.Sprintf("%v", req.Name))
+	return gateway.DoRequest[QueryDefinition](ctx, gwReq)
+}
+
+func (c *storedQueryGatewayClient) ListStoredQueries(ctx context.Context, req *GraphID) (*ListStoredQueriesResponse, error) {
+	gwReq := c.gwc.NewRequest("GET", "/v1/graph/{graph}/stored-query")
+	gwReq.SetPathParam("graph", fmt.Sprintf("%v", req.Graph))
+	return gateway.DoRequest[ListStoredQueriesResponse](ctx, gwReq)
+}
+
+func (c *storedQueryGatewayClient) DeleteStoredQuery(ctx context.Context, req *StoredQueryID) (*EditResult, error) {
+	gwReq := c.gwc.NewRequest("DELETE", "/v1/graph/{graph}/stored-query/{name}")
+	gwReq.SetPathParam("graph", fmt.Sprintf("%v", req.Graph))
+	gwReq.SetPathParam("name", fmt.Sprintf("%v", req.Name))
+	gwReq.SetBody(req)
+	return gateway.DoRequest[EditResult](ctx, gwReq)
+}
+
+func (c *storedQueryGatewayClient) RunStoredQuery(ctx context.Context, req *StoredQueryCall) (<-chan *QueryResult, <-chan error, error) {
+	gwReq := c.gwc.NewRequest("POST", "/v1/graph/{graph}/stored-query/{name}/run")
+	gwReq.SetPathParam("graph", fmt.Sprintf("%v", req.Graph))
+	gwReq.SetPathParam("name", fmt.Sprintf("%v", req.Name))
+	gwReq.SetBody(req.Parameters)
+	return gateway.DoStreamingRequest[QueryResult](ctx, c.gwc, gwReq)
+}
+
 // ConfigureGatewayClient is the interface for Configure service client.
 type ConfigureGatewayClient interface {
 	StartPlugin(context.Context, *PluginConfig) (*PluginStatus, error)
@@ -128,6 +128,77 @@ func (shim *QueryDirectClient) Traversal(ctx context.Context, in *GraphQuery, op
 }
 
 
+/* Start QueryTraversalText call output server  */
+type directQueryTraversalText struct {
+  ctx context.Context
+  c   chan *QueryResult
+  in  *GraphQueryText
+  e   error
+}
+
+func (dsm *directQueryTraversalText) Recv() (*QueryResult, error) {
+	value, ok := <-dsm.c
+	if !ok {
+    if dsm.e != nil {
+      return nil, dsm.e
+    }
+		return nil, io.EOF
+	}
+	return value, dsm.e
+}
+
+func (dsm *directQueryTraversalText) Send(a *QueryResult) error {
+	return dsm.SendMsg(a)
+}
+
+func (dsm *directQueryTraversalText) SendMsg(m interface{}) error  { 
+	dsm.c <- m.(*QueryResult)
+	return nil 
+}
+
+func (dsm *directQueryTraversalText) close() {
+	close(dsm.c)
+}
+func (dsm *directQueryTraversalText) Context() context.Context {
+	return dsm.ctx
+}
+func (dsm *directQueryTraversalText) CloseSend() error             { return nil }
+func (dsm *directQueryTraversalText) SetTrailer(metadata.MD)       {}
+func (dsm *directQueryTraversalText) SetHeader(metadata.MD) error  { return nil }
+func (dsm *directQueryTraversalText) SendHeader(metadata.MD) error { return nil }
+func (dsm *directQueryTraversalText) RecvMsg(m interface{}) error  { 
+	mPtr := m.(*GraphQueryText)
+	*mPtr = *dsm.in
+	return nil
+}
+func (dsm *directQueryTraversalText) Header() (metadata.MD, error) { return nil, nil }
+func (dsm *directQueryTraversalText) Trailer() metadata.MD         { return nil }
+/* End QueryTraversalText call output server  */
+
+func (shim *QueryDirectClient) TraversalText(ctx context.Context, in *GraphQueryText, opts ...grpc.CallOption) (Query_TraversalTextClient, error) {
+  md, _ := metadata.FromOutgoingContext(ctx)
+  ictx := metadata.NewIncomingContext(ctx, md)
+
+	w := &directQueryTraversalText{ictx, make(chan *QueryResult, 100), in, nil}
+  if shim.streamServerInt != nil {
+    go func() {
+      defer w.close()
+      info := grpc.StreamServerInfo{
+        FullMethod: "/gripql.Query/TraversalText",
+        IsServerStream: true,
+      }
+      w.e = shim.streamServerInt(shim.server, w, &info, _Query_TraversalText_Handler)
+    } ()
+    return w, nil
+  }
+	go func() {
+    defer w.close()
+		w.e = shim.server.TraversalText(in, w)
+	}()
+	return w, nil
+}
+
+
 //GetVertex shim
 func (shim *QueryDirectClient) GetVertex(ctx context.Context, in *ElementID, opts ...grpc.CallOption) (*Vertex, error) {
   md, _ := metadata.FromOutgoingContext(ctx)
@@ -360,6 +431,77 @@ func (shim *QueryDirectClient) ListTables(ctx context.Context, in *Empty, opts .
 }
 
 
+/* Start QueryWatch call output server  */
+type directQueryWatch struct {
+  ctx context.Context
+  c   chan *GraphEvent
+  in  *GraphID
+  e   error
+}
+
+func (dsm *directQueryWatch) Recv() (*GraphEvent, error) {
+	value, ok := <-dsm.c
+	if !ok {
+    if dsm.e != nil {
+      return nil, dsm.e
+    }
+		return nil, io.EOF
+	}
+	return value, dsm.e
+}
+
+func (dsm *directQueryWatch) Send(a *GraphEvent) error {
+	return dsm.SendMsg(a)
+}
+
+func (dsm *directQueryWatch) SendMsg(m interface{}) error  { 
+	dsm.c <- m.(*GraphEvent)
+	return nil 
+}
+
+func (dsm *directQueryWatch) close() {
+	close(dsm.c)
+}
+func (dsm *directQueryWatch) Context() context.Context {
+	return dsm.ctx
+}
+func (dsm *directQueryWatch) CloseSend() error             { return nil }
+func (dsm *directQueryWatch) SetTrailer(metadata.MD)       {}
+func (dsm *directQueryWatch) SetHeader(metadata.MD) error  { return nil }
+func (dsm *directQueryWatch) SendHeader(metadata.MD) error { return nil }
+func (dsm *directQueryWatch) RecvMsg(m interface{}) error  { 
+	mPtr := m.(*GraphID)
+	*mPtr = *dsm.in
+	return nil
+}
+func (dsm *directQueryWatch) Header() (metadata.MD, error) { return nil, nil }
+func (dsm *directQueryWatch) Trailer() metadata.MD         { return nil }
+/* End QueryWatch call output server  */
+
+func (shim *QueryDirectClient) Watch(ctx context.Context, in *GraphID, opts ...grpc.CallOption) (Query_WatchClient, error) {
+  md, _ := metadata.FromOutgoingContext(ctx)
+  ictx := metadata.NewIncomingContext(ctx, md)
+
+	w := &directQueryWatch{ictx, make(chan *GraphEvent, 100), in, nil}
+  if shim.streamServerInt != nil {
+    go func() {
+      defer w.close()
+      info := grpc.StreamServerInfo{
+        FullMethod: "/gripql.Query/Watch",
+        IsServerStream: true,
+      }
+      w.e = shim.streamServerInt(shim.server, w, &info, _Query_Watch_Handler)
+    } ()
+    return w, nil
+  }
+	go func() {
+    defer w.close()
+		w.e = shim.server.Watch(in, w)
+	}()
+	return w, nil
+}
+
+
 // JobDirectClient is a shim to connect Job client directly server
 type JobDirectClient struct {
   unaryServerInt grpc.UnaryServerInterceptor
@@ -732,6 +874,77 @@ func (shim *JobDirectClient) ResumeJob(ctx context.Context, in *ExtendQuery, opt
 }
 
 
+/* Start JobCollectJobs call output server  */
+type directJobCollectJobs struct {
+  ctx context.Context
+  c   chan *JobStatus
+  in  *GraphID
+  e   error
+}
+
+func (dsm *directJobCollectJobs) Recv() (*JobStatus, error) {
+	value, ok := <-dsm.c
+	if !ok {
+    if dsm.e != nil {
+      return nil, dsm.e
+    }
+		return nil, io.EOF
+	}
+	return value, dsm.e
+}
+
+func (dsm *directJobCollectJobs) Send(a *JobStatus) error {
+	return dsm.SendMsg(a)
+}
+
+func (dsm *directJobCollectJobs) SendMsg(m interface{}) error  { 
+	dsm.c <- m.(*JobStatus)
+	return nil 
+}
+
+func (dsm *directJobCollectJobs) close() {
+	close(dsm.c)
+}
+func (dsm *directJobCollectJobs) Context() context.Context {
+	return dsm.ctx
+}
+func (dsm *directJobCollectJobs) CloseSend() error             { return nil }
+func (dsm *directJobCollectJobs) SetTrailer(metadata.MD)       {}
+func (dsm *directJobCollectJobs) SetHeader(metadata.MD) error  { return nil }
+func (dsm *directJobCollectJobs) SendHeader(metadata.MD) error { return nil }
+func (dsm *directJobCollectJobs) RecvMsg(m interface{}) error  { 
+	mPtr := m.(*GraphID)
+	*mPtr = *dsm.in
+	return nil
+}
+func (dsm *directJobCollectJobs) Header() (metadata.MD, error) { return nil, nil }
+func (dsm *directJobCollectJobs) Trailer() metadata.MD         { return nil }
+/* End JobCollectJobs call output server  */
+
+func (shim *JobDirectClient) CollectJobs(ctx context.Context, in *GraphID, opts ...grpc.CallOption) (Job_CollectJobsClient, error) {
+  md, _ := metadata.FromOutgoingContext(ctx)
+  ictx := metadata.NewIncomingContext(ctx, md)
+
+	w := &directJobCollectJobs{ictx, make(chan *JobStatus, 100), in, nil}
+  if shim.streamServerInt != nil {
+    go func() {
+      defer w.close()
+      info := grpc.StreamServerInfo{
+        FullMethod: "/gripql.Job/CollectJobs",
+        IsServerStream: true,
+      }
+      w.e = shim.streamServerInt(shim.server, w, &info, _Job_CollectJobs_Handler)
+    } ()
+    return w, nil
+  }
+	go func() {
+    defer w.close()
+		w.e = shim.server.CollectJobs(in, w)
+	}()
+	return w, nil
+}
+
+
 // EditDirectClient is a shim to connect Edit client directly server
 type EditDirectClient struct {
   unaryServerInt grpc.UnaryServerInterceptor
@@ -1137,3 +1350,177 @@ func (shim *ConfigureDirectClient) ListDrivers(ctx context.Context, in *Empty, o
   }
 	return shim.server.ListDrivers(ictx, in)
 }
+
+// StoredQueryDirectClient is a shim to connect StoredQuery client directly server
+type StoredQueryDirectClient struct {
+  unaryServerInt grpc.UnaryServerInterceptor
+  streamServerInt grpc.StreamServerInterceptor
+	server StoredQueryServer
+}
+ // NewStoredQueryDirectClient creates new StoredQueryDirectClient
+func NewStoredQueryDirectClient(server StoredQueryServer, opts ...DirectOption) *StoredQueryDirectClient {
+	o := &StoredQueryDirectClient{server:server}
+  for _, opt := range opts {
+    opt(o)
+  }
+  return o
+}
+
+func (shim *StoredQueryDirectClient) setUnaryInterceptor(a grpc.UnaryServerInterceptor) {
+  shim.unaryServerInt = a
+}
+
+func (shim *StoredQueryDirectClient) setStreamInterceptor(a grpc.StreamServerInterceptor) {
+  shim.streamServerInt = a
+}
+
+//AddStoredQuery shim
+func (shim *StoredQueryDirectClient) AddStoredQuery(ctx context.Context, in *QueryDefinition, opts ...grpc.CallOption) (*EditResult, error) {
+  md, _ := metadata.FromOutgoingContext(ctx)
+  ictx := metadata.NewIncomingContext(ctx, md)
+  if shim.unaryServerInt != nil {
+    handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+  		return shim.server.AddStoredQuery(ctx, req.(*QueryDefinition))
+  	}
+    info := grpc.UnaryServerInfo{
+      FullMethod: "/gripql.StoredQuery/AddStoredQuery",
+    }
+    o, err := shim.unaryServerInt(ictx, in, &info, handler)
+    if o == nil {
+      return nil, err
+    }
+    return o.(*EditResult), err
+  }
+	return shim.server.AddStoredQuery(ictx, in)
+}
+
+//GetStoredQuery shim
+func (shim *StoredQueryDirectClient) GetStoredQuery(ctx context.Context, in *StoredQueryID, opts ...grpc.CallOption) (*QueryDefinition, error) {
+  md, _ := metadata.FromOutgoingContext(ctx)
+  ictx := metadata.NewIncomingContext(ctx, md)
+  if shim.unaryServerInt != nil {
+    handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+  		return shim.server.GetStoredQuery(ctx, req.(*StoredQueryID))
+  	}
+    info := grpc.UnaryServerInfo{
+      FullMethod: "/gripql.StoredQuery/GetStoredQuery",
+    }
+    o, err := shim.unaryServerInt(ictx, in, &info, handler)
+    if o == nil {
+      return nil, err
+    }
+    return o.(*QueryDefinition), err
+  }
+	return shim.server.GetStoredQuery(ictx, in)
+}
+
+//ListStoredQueries shim
+func (shim *StoredQueryDirectClient) ListStoredQueries(ctx context.Context, in *GraphID, opts ...grpc.CallOption) (*ListStoredQueriesResponse, error) {
+  md, _ := metadata.FromOutgoingContext(ctx)
+  ictx := metadata.NewIncomingContext(ctx, md)
+  if shim.unaryServerInt != nil {
+    handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+  		return shim.server.ListStoredQueries(ctx, req.(*GraphID))
+  	}
+    info := grpc.UnaryServerInfo{
+      FullMethod: "/gripql.StoredQuery/ListStoredQueries",
+    }
+    o, err := shim.unaryServerInt(ictx, in, &info, handler)
+    if o == nil {
+      return nil, err
+    }
+    return o.(*ListStoredQueriesResponse), err
+  }
+	return shim.server.ListStoredQueries(ictx, in)
+}
+
+//DeleteStoredQuery shim
+func (shim *StoredQueryDirectClient) DeleteStoredQuery(ctx context.Context, in *StoredQueryID, opts ...grpc.CallOption) (*EditResult, error) {
+  md, _ := metadata.FromOutgoingContext(ctx)
+  ictx := metadata.NewIncomingContext(ctx, md)
+  if shim.unaryServerInt != nil {
+    handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+  		return shim.server.DeleteStoredQuery(ctx, req.(*StoredQueryID))
+  	}
+    info := grpc.UnaryServerInfo{
+      FullMethod: "/gripql.StoredQuery/DeleteStoredQuery",
+    }
+    o, err := shim.unaryServerInt(ictx, in, &info, handler)
+    if o == nil {
+      return nil, err
+    }
+    return o.(*EditResult), err
+  }
+	return shim.server.DeleteStoredQuery(ictx, in)
+}
+
+
+/* Start StoredQueryRunStoredQuery call output server  */
+type directStoredQueryRunStoredQuery struct {
+  ctx context.Context
+  c   chan *QueryResult
+  in  *StoredQueryCall
+  e   error
+}
+
+func (dsm *directStoredQueryRunStoredQuery) Recv() (*QueryResult, error) {
+	value, ok := <-dsm.c
+	if !ok {
+    if dsm.e != nil {
+      return nil, dsm.e
+    }
+		return nil, io.EOF
+	}
+	return value, dsm.e
+}
+
+func (dsm *directStoredQueryRunStoredQuery) Send(a *QueryResult) error {
+	return dsm.SendMsg(a)
+}
+
+func (dsm *directStoredQueryRunStoredQuery) SendMsg(m interface{}) error  { 
+	dsm.c <- m.(*QueryResult)
+	return nil 
+}
+
+func (dsm *directStoredQueryRunStoredQuery) close() {
+	close(dsm.c)
+}
+func (dsm *directStoredQueryRunStoredQuery) Context() context.Context {
+	return dsm.ctx
+}
+func (dsm *directStoredQueryRunStoredQuery) CloseSend() error             { return nil }
+func (dsm *directStoredQueryRunStoredQuery) SetTrailer(metadata.MD)       {}
+func (dsm *directStoredQueryRunStoredQuery) SetHeader(metadata.MD) error  { return nil }
+func (dsm *directStoredQueryRunStoredQuery) SendHeader(metadata.MD) error { return nil }
+func (dsm *directStoredQueryRunStoredQuery) RecvMsg(m interface{}) error  { 
+	mPtr := m.(*StoredQueryCall)
+	*mPtr = *dsm.in
+	return nil
+}
+func (dsm *directStoredQueryRunStoredQuery) Header() (metadata.MD, error) { return nil, nil }
+func (dsm *directStoredQueryRunStoredQuery) Trailer() metadata.MD         { return nil }
+/* End StoredQueryRunStoredQuery call output server  */
+
+func (shim *StoredQueryDirectClient) RunStoredQuery(ctx context.Context, in *StoredQueryCall, opts ...grpc.CallOption) (StoredQuery_RunStoredQueryClient, error) {
+  md, _ := metadata.FromOutgoingContext(ctx)
+  ictx := metadata.NewIncomingContext(ctx, md)
+
+	w := &directStoredQueryRunStoredQuery{ictx, make(chan *QueryResult, 100), in, nil}
+  if shim.streamServerInt != nil {
+    go func() {
+      defer w.close()
+      info := grpc.StreamServerInfo{
+        FullMethod: "/gripql.StoredQuery/RunStoredQuery",
+        IsServerStream: true,
+      }
+      w.e = shim.streamServerInt(shim.server, w, &info, _StoredQuery_RunStoredQuery_Handler)
+    } ()
+    return w, nil
+  }
+	go func() {
+    defer w.close()
+		w.e = shim.server.RunStoredQuery(in, w)
+	}()
+	return w, nil
+}
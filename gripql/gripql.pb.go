@@ -22,6 +22,53 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// ResultFormat selects how query results are sent to the client
+type ResultFormat int32
+
+const (
+	ResultFormat_ROWS  ResultFormat = 0
+	ResultFormat_ARROW ResultFormat = 1
+)
+
+// Enum value maps for ResultFormat.
+var (
+	ResultFormat_name = map[int32]string{
+		0: "ROWS",
+		1: "ARROW",
+	}
+	ResultFormat_value = map[string]int32{
+		"ROWS":  0,
+		"ARROW": 1,
+	}
+)
+
+func (x ResultFormat) Enum() *ResultFormat {
+	p := new(ResultFormat)
+	*p = x
+	return p
+}
+
+func (x ResultFormat) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ResultFormat) Descriptor() protoreflect.EnumDescriptor {
+	return file_gripql_proto_enumTypes[0].Descriptor()
+}
+
+func (ResultFormat) Type() protoreflect.EnumType {
+	return &file_gripql_proto_enumTypes[0]
+}
+
+func (x ResultFormat) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ResultFormat.Descriptor instead.
+func (ResultFormat) EnumDescriptor() ([]byte, []int) {
+	return file_gripql_proto_rawDescGZIP(), []int{0}
+}
+
 type Condition int32
 
 const (
@@ -38,6 +85,9 @@ const (
 	Condition_WITHIN            Condition = 10
 	Condition_WITHOUT           Condition = 11
 	Condition_CONTAINS          Condition = 12
+	Condition_GEO_RADIUS        Condition = 13
+	Condition_GEO_BOX           Condition = 14
+	Condition_GEO_POLYGON       Condition = 15
 )
 
 // Enum value maps for Condition.
@@ -56,6 +106,9 @@ var (
 		10: "WITHIN",
 		11: "WITHOUT",
 		12: "CONTAINS",
+		13: "GEO_RADIUS",
+		14: "GEO_BOX",
+		15: "GEO_POLYGON",
 	}
 	Condition_value = map[string]int32{
 		"UNKNOWN_CONDITION": 0,
@@ -71,6 +124,9 @@ var (
 		"WITHIN":            10,
 		"WITHOUT":           11,
 		"CONTAINS":          12,
+		"GEO_RADIUS":        13,
+		"GEO_BOX":           14,
+		"GEO_POLYGON":       15,
 	}
 )
 
@@ -85,11 +141,11 @@ func (x Condition) String() string {
 }
 
 func (Condition) Descriptor() protoreflect.EnumDescriptor {
-	return file_gripql_proto_enumTypes[0].Descriptor()
+	return file_gripql_proto_enumTypes[1].Descriptor()
 }
 
 func (Condition) Type() protoreflect.EnumType {
-	return &file_gripql_proto_enumTypes[0]
+	return &file_gripql_proto_enumTypes[1]
 }
 
 func (x Condition) Number() protoreflect.EnumNumber {
@@ -98,7 +154,7 @@ func (x Condition) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use Condition.Descriptor instead.
 func (Condition) EnumDescriptor() ([]byte, []int) {
-	return file_gripql_proto_rawDescGZIP(), []int{0}
+	return file_gripql_proto_rawDescGZIP(), []int{1}
 }
 
 type JobState int32
@@ -140,11 +196,11 @@ func (x JobState) String() string {
 }
 
 func (JobState) Descriptor() protoreflect.EnumDescriptor {
-	return file_gripql_proto_enumTypes[1].Descriptor()
+	return file_gripql_proto_enumTypes[2].Descriptor()
 }
 
 func (JobState) Type() protoreflect.EnumType {
-	return &file_gripql_proto_enumTypes[1]
+	return &file_gripql_proto_enumTypes[2]
 }
 
 func (x JobState) Number() protoreflect.EnumNumber {
@@ -153,18 +209,65 @@ func (x JobState) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use JobState.Descriptor instead.
 func (JobState) EnumDescriptor() ([]byte, []int) {
-	return file_gripql_proto_rawDescGZIP(), []int{1}
+	return file_gripql_proto_rawDescGZIP(), []int{2}
+}
+
+type IndexType int32
+
+const (
+	IndexType_FIELD_INDEX IndexType = 0
+	IndexType_GEO_INDEX   IndexType = 1
+)
+
+// Enum value maps for IndexType.
+var (
+	IndexType_name = map[int32]string{
+		0: "FIELD_INDEX",
+		1: "GEO_INDEX",
+	}
+	IndexType_value = map[string]int32{
+		"FIELD_INDEX": 0,
+		"GEO_INDEX":   1,
+	}
+)
+
+func (x IndexType) Enum() *IndexType {
+	p := new(IndexType)
+	*p = x
+	return p
+}
+
+func (x IndexType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (IndexType) Descriptor() protoreflect.EnumDescriptor {
+	return file_gripql_proto_enumTypes[3].Descriptor()
+}
+
+func (IndexType) Type() protoreflect.EnumType {
+	return &file_gripql_proto_enumTypes[3]
+}
+
+func (x IndexType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use IndexType.Descriptor instead.
+func (IndexType) EnumDescriptor() ([]byte, []int) {
+	return file_gripql_proto_rawDescGZIP(), []int{3}
 }
 
 type FieldType int32
 
 const (
-	FieldType_UNKNOWN FieldType = 0
-	FieldType_STRING  FieldType = 1
-	FieldType_NUMERIC FieldType = 2
-	FieldType_BOOL    FieldType = 3
-	FieldType_MAP     FieldType = 4
-	FieldType_ARRAY   FieldType = 5
+	FieldType_UNKNOWN  FieldType = 0
+	FieldType_STRING   FieldType = 1
+	FieldType_NUMERIC  FieldType = 2
+	FieldType_BOOL     FieldType = 3
+	FieldType_MAP      FieldType = 4
+	FieldType_ARRAY    FieldType = 5
+	FieldType_DATETIME FieldType = 6
 )
 
 // Enum value maps for FieldType.
@@ -176,14 +279,16 @@ var (
 		3: "BOOL",
 		4: "MAP",
 		5: "ARRAY",
+		6: "DATETIME",
 	}
 	FieldType_value = map[string]int32{
-		"UNKNOWN": 0,
-		"STRING":  1,
-		"NUMERIC": 2,
-		"BOOL":    3,
-		"MAP":     4,
-		"ARRAY":   5,
+		"UNKNOWN":  0,
+		"STRING":   1,
+		"NUMERIC":  2,
+		"BOOL":     3,
+		"MAP":      4,
+		"ARRAY":    5,
+		"DATETIME": 6,
 	}
 )
 
@@ -198,11 +303,11 @@ func (x FieldType) String() string {
 }
 
 func (FieldType) Descriptor() protoreflect.EnumDescriptor {
-	return file_gripql_proto_enumTypes[2].Descriptor()
+	return file_gripql_proto_enumTypes[4].Descriptor()
 }
 
 func (FieldType) Type() protoreflect.EnumType {
-	return &file_gripql_proto_enumTypes[2]
+	return &file_gripql_proto_enumTypes[4]
 }
 
 func (x FieldType) Number() protoreflect.EnumNumber {
@@ -211,7 +316,56 @@ func (x FieldType) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use FieldType.Descriptor instead.
 func (FieldType) EnumDescriptor() ([]byte, []int) {
-	return file_gripql_proto_rawDescGZIP(), []int{2}
+	return file_gripql_proto_rawDescGZIP(), []int{4}
+}
+
+type EventType int32
+
+const (
+	EventType_ADDED   EventType = 0
+	EventType_UPDATED EventType = 1
+	EventType_REMOVED EventType = 2
+)
+
+// Enum value maps for EventType.
+var (
+	EventType_name = map[int32]string{
+		0: "ADDED",
+		1: "UPDATED",
+		2: "REMOVED",
+	}
+	EventType_value = map[string]int32{
+		"ADDED":   0,
+		"UPDATED": 1,
+		"REMOVED": 2,
+	}
+)
+
+func (x EventType) Enum() *EventType {
+	p := new(EventType)
+	*p = x
+	return p
+}
+
+func (x EventType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (EventType) Descriptor() protoreflect.EnumDescriptor {
+	return file_gripql_proto_enumTypes[5].Descriptor()
+}
+
+func (EventType) Type() protoreflect.EnumType {
+	return &file_gripql_proto_enumTypes[5]
+}
+
+func (x EventType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use EventType.Descriptor instead.
+func (EventType) EnumDescriptor() ([]byte, []int) {
+	return file_gripql_proto_rawDescGZIP(), []int{5}
 }
 
 type Graph struct {
@@ -284,6 +438,14 @@ type GraphQuery struct {
 
 	Graph string            `protobuf:"bytes,1,opt,name=graph,proto3" json:"graph,omitempty"`
 	Query []*GraphStatement `protobuf:"bytes,2,rep,name=query,proto3" json:"query,omitempty"`
+	// Number of workers for each stateless step, such as has and render. The
+	// server setting is used if not set
+	Parallelism uint32 `protobuf:"varint,3,opt,name=parallelism,proto3" json:"parallelism,omitempty"`
+	// Keep the order of results when steps run on several workers
+	PreserveOrder bool `protobuf:"varint,4,opt,name=preserve_order,json=preserveOrder,proto3" json:"preserve_order,omitempty"`
+	// Encoding of the results. ARROW returns batches of rows as Arrow IPC
+	// streams instead of one message per result
+	Format ResultFormat `protobuf:"varint,5,opt,name=format,proto3,enum=gripql.ResultFormat" json:"format,omitempty"`
 }
 
 func (x *GraphQuery) Reset() {
@@ -332,6 +494,108 @@ func (x *GraphQuery) GetQuery() []*GraphStatement {
 	return nil
 }
 
+func (x *GraphQuery) GetParallelism() uint32 {
+	if x != nil {
+		return x.Parallelism
+	}
+	return 0
+}
+
+func (x *GraphQuery) GetPreserveOrder() bool {
+	if x != nil {
+		return x.PreserveOrder
+	}
+	return false
+}
+
+func (x *GraphQuery) GetFormat() ResultFormat {
+	if x != nil {
+		return x.Format
+	}
+	return ResultFormat_ROWS
+}
+
+// GraphQueryText is a query written in the dotted text syntax of the clients,
+// such as V().hasLabel("Person").out()
+type GraphQueryText struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Graph         string       `protobuf:"bytes,1,opt,name=graph,proto3" json:"graph,omitempty"`
+	Query         string       `protobuf:"bytes,2,opt,name=query,proto3" json:"query,omitempty"`
+	Parallelism   uint32       `protobuf:"varint,3,opt,name=parallelism,proto3" json:"parallelism,omitempty"`
+	PreserveOrder bool         `protobuf:"varint,4,opt,name=preserve_order,json=preserveOrder,proto3" json:"preserve_order,omitempty"`
+	Format        ResultFormat `protobuf:"varint,5,opt,name=format,proto3,enum=gripql.ResultFormat" json:"format,omitempty"`
+}
+
+func (x *GraphQueryText) Reset() {
+	*x = GraphQueryText{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gripql_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GraphQueryText) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GraphQueryText) ProtoMessage() {}
+
+func (x *GraphQueryText) ProtoReflect() protoreflect.Message {
+	mi := &file_gripql_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GraphQueryText.ProtoReflect.Descriptor instead.
+func (*GraphQueryText) Descriptor() ([]byte, []int) {
+	return file_gripql_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GraphQueryText) GetGraph() string {
+	if x != nil {
+		return x.Graph
+	}
+	return ""
+}
+
+func (x *GraphQueryText) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *GraphQueryText) GetParallelism() uint32 {
+	if x != nil {
+		return x.Parallelism
+	}
+	return 0
+}
+
+func (x *GraphQueryText) GetPreserveOrder() bool {
+	if x != nil {
+		return x.PreserveOrder
+	}
+	return false
+}
+
+func (x *GraphQueryText) GetFormat() ResultFormat {
+	if x != nil {
+		return x.Format
+	}
+	return ResultFormat_ROWS
+}
+
 type QuerySet struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -343,7 +607,7 @@ type QuerySet struct {
 func (x *QuerySet) Reset() {
 	*x = QuerySet{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_gripql_proto_msgTypes[2]
+		mi := &file_gripql_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -356,7 +620,7 @@ func (x *QuerySet) String() string {
 func (*QuerySet) ProtoMessage() {}
 
 func (x *QuerySet) ProtoReflect() protoreflect.Message {
-	mi := &file_gripql_proto_msgTypes[2]
+	mi := &file_gripql_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -369,7 +633,7 @@ func (x *QuerySet) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use QuerySet.ProtoReflect.Descriptor instead.
 func (*QuerySet) Descriptor() ([]byte, []int) {
-	return file_gripql_proto_rawDescGZIP(), []int{2}
+	return file_gripql_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *QuerySet) GetQuery() []*GraphStatement {
@@ -408,23 +672,32 @@ type GraphStatement struct {
 	//	*GraphStatement_HasKey
 	//	*GraphStatement_HasId
 	//	*GraphStatement_Distinct
+	//	*GraphStatement_Sort
+	//	*GraphStatement_Seek
 	//	*GraphStatement_Fields
 	//	*GraphStatement_Unwind
 	//	*GraphStatement_Count
 	//	*GraphStatement_Aggregate
 	//	*GraphStatement_Render
 	//	*GraphStatement_Path
+	//	*GraphStatement_Group
+	//	*GraphStatement_Fold
 	//	*GraphStatement_Mark
 	//	*GraphStatement_Jump
 	//	*GraphStatement_Set
 	//	*GraphStatement_Increment
+	//	*GraphStatement_Where
+	//	*GraphStatement_Not
+	//	*GraphStatement_Union
+	//	*GraphStatement_Optional
+	//	*GraphStatement_Coalesce
 	Statement isGraphStatement_Statement `protobuf_oneof:"statement"`
 }
 
 func (x *GraphStatement) Reset() {
 	*x = GraphStatement{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_gripql_proto_msgTypes[3]
+		mi := &file_gripql_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -437,7 +710,7 @@ func (x *GraphStatement) String() string {
 func (*GraphStatement) ProtoMessage() {}
 
 func (x *GraphStatement) ProtoReflect() protoreflect.Message {
-	mi := &file_gripql_proto_msgTypes[3]
+	mi := &file_gripql_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -450,7 +723,7 @@ func (x *GraphStatement) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GraphStatement.ProtoReflect.Descriptor instead.
 func (*GraphStatement) Descriptor() ([]byte, []int) {
-	return file_gripql_proto_rawDescGZIP(), []int{3}
+	return file_gripql_proto_rawDescGZIP(), []int{4}
 }
 
 func (m *GraphStatement) GetStatement() isGraphStatement_Statement {
@@ -614,6 +887,20 @@ func (x *GraphStatement) GetDistinct() *structpb.ListValue {
 	return nil
 }
 
+func (x *GraphStatement) GetSort() *structpb.ListValue {
+	if x, ok := x.GetStatement().(*GraphStatement_Sort); ok {
+		return x.Sort
+	}
+	return nil
+}
+
+func (x *GraphStatement) GetSeek() *Seek {
+	if x, ok := x.GetStatement().(*GraphStatement_Seek); ok {
+		return x.Seek
+	}
+	return nil
+}
+
 func (x *GraphStatement) GetFields() *structpb.ListValue {
 	if x, ok := x.GetStatement().(*GraphStatement_Fields); ok {
 		return x.Fields
@@ -656,6 +943,20 @@ func (x *GraphStatement) GetPath() *structpb.ListValue {
 	return nil
 }
 
+func (x *GraphStatement) GetGroup() *Group {
+	if x, ok := x.GetStatement().(*GraphStatement_Group); ok {
+		return x.Group
+	}
+	return nil
+}
+
+func (x *GraphStatement) GetFold() *structpb.Value {
+	if x, ok := x.GetStatement().(*GraphStatement_Fold); ok {
+		return x.Fold
+	}
+	return nil
+}
+
 func (x *GraphStatement) GetMark() string {
 	if x, ok := x.GetStatement().(*GraphStatement_Mark); ok {
 		return x.Mark
@@ -684,6 +985,41 @@ func (x *GraphStatement) GetIncrement() *Increment {
 	return nil
 }
 
+func (x *GraphStatement) GetWhere() *SubQuery {
+	if x, ok := x.GetStatement().(*GraphStatement_Where); ok {
+		return x.Where
+	}
+	return nil
+}
+
+func (x *GraphStatement) GetNot() *SubQuery {
+	if x, ok := x.GetStatement().(*GraphStatement_Not); ok {
+		return x.Not
+	}
+	return nil
+}
+
+func (x *GraphStatement) GetUnion() *SubQueryList {
+	if x, ok := x.GetStatement().(*GraphStatement_Union); ok {
+		return x.Union
+	}
+	return nil
+}
+
+func (x *GraphStatement) GetOptional() *SubQuery {
+	if x, ok := x.GetStatement().(*GraphStatement_Optional); ok {
+		return x.Optional
+	}
+	return nil
+}
+
+func (x *GraphStatement) GetCoalesce() *SubQueryList {
+	if x, ok := x.GetStatement().(*GraphStatement_Coalesce); ok {
+		return x.Coalesce
+	}
+	return nil
+}
+
 type isGraphStatement_Statement interface {
 	isGraphStatement_Statement()
 }
@@ -776,6 +1112,14 @@ type GraphStatement_Distinct struct {
 	Distinct *structpb.ListValue `protobuf:"bytes,40,opt,name=distinct,proto3,oneof"`
 }
 
+type GraphStatement_Sort struct {
+	Sort *structpb.ListValue `protobuf:"bytes,41,opt,name=sort,proto3,oneof"`
+}
+
+type GraphStatement_Seek struct {
+	Seek *Seek `protobuf:"bytes,42,opt,name=seek,proto3,oneof"`
+}
+
 type GraphStatement_Fields struct {
 	Fields *structpb.ListValue `protobuf:"bytes,50,opt,name=fields,proto3,oneof"`
 }
@@ -800,6 +1144,14 @@ type GraphStatement_Path struct {
 	Path *structpb.ListValue `protobuf:"bytes,63,opt,name=path,proto3,oneof"`
 }
 
+type GraphStatement_Group struct {
+	Group *Group `protobuf:"bytes,64,opt,name=group,proto3,oneof"`
+}
+
+type GraphStatement_Fold struct {
+	Fold *structpb.Value `protobuf:"bytes,65,opt,name=fold,proto3,oneof"`
+}
+
 type GraphStatement_Mark struct {
 	Mark string `protobuf:"bytes,70,opt,name=mark,proto3,oneof"`
 }
@@ -816,6 +1168,26 @@ type GraphStatement_Increment struct {
 	Increment *Increment `protobuf:"bytes,73,opt,name=increment,proto3,oneof"`
 }
 
+type GraphStatement_Where struct {
+	Where *SubQuery `protobuf:"bytes,80,opt,name=where,proto3,oneof"`
+}
+
+type GraphStatement_Not struct {
+	Not *SubQuery `protobuf:"bytes,81,opt,name=not,proto3,oneof"`
+}
+
+type GraphStatement_Union struct {
+	Union *SubQueryList `protobuf:"bytes,82,opt,name=union,proto3,oneof"`
+}
+
+type GraphStatement_Optional struct {
+	Optional *SubQuery `protobuf:"bytes,83,opt,name=optional,proto3,oneof"`
+}
+
+type GraphStatement_Coalesce struct {
+	Coalesce *SubQueryList `protobuf:"bytes,84,opt,name=coalesce,proto3,oneof"`
+}
+
 func (*GraphStatement_V) isGraphStatement_Statement() {}
 
 func (*GraphStatement_E) isGraphStatement_Statement() {}
@@ -860,6 +1232,10 @@ func (*GraphStatement_HasId) isGraphStatement_Statement() {}
 
 func (*GraphStatement_Distinct) isGraphStatement_Statement() {}
 
+func (*GraphStatement_Sort) isGraphStatement_Statement() {}
+
+func (*GraphStatement_Seek) isGraphStatement_Statement() {}
+
 func (*GraphStatement_Fields) isGraphStatement_Statement() {}
 
 func (*GraphStatement_Unwind) isGraphStatement_Statement() {}
@@ -872,6 +1248,10 @@ func (*GraphStatement_Render) isGraphStatement_Statement() {}
 
 func (*GraphStatement_Path) isGraphStatement_Statement() {}
 
+func (*GraphStatement_Group) isGraphStatement_Statement() {}
+
+func (*GraphStatement_Fold) isGraphStatement_Statement() {}
+
 func (*GraphStatement_Mark) isGraphStatement_Statement() {}
 
 func (*GraphStatement_Jump) isGraphStatement_Statement() {}
@@ -880,32 +1260,195 @@ func (*GraphStatement_Set) isGraphStatement_Statement() {}
 
 func (*GraphStatement_Increment) isGraphStatement_Statement() {}
 
-type Range struct {
+func (*GraphStatement_Where) isGraphStatement_Statement() {}
+
+func (*GraphStatement_Not) isGraphStatement_Statement() {}
+
+func (*GraphStatement_Union) isGraphStatement_Statement() {}
+
+func (*GraphStatement_Optional) isGraphStatement_Statement() {}
+
+func (*GraphStatement_Coalesce) isGraphStatement_Statement() {}
+
+// SubQuery is a traversal run from each traveler of a where, not or branching
+// statement
+type SubQuery struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Start int32 `protobuf:"varint,1,opt,name=start,proto3" json:"start,omitempty"`
-	Stop  int32 `protobuf:"varint,2,opt,name=stop,proto3" json:"stop,omitempty"`
+	Query []*GraphStatement `protobuf:"bytes,1,rep,name=query,proto3" json:"query,omitempty"`
 }
 
-func (x *Range) Reset() {
-	*x = Range{}
+func (x *SubQuery) Reset() {
+	*x = SubQuery{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_gripql_proto_msgTypes[4]
+		mi := &file_gripql_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Range) String() string {
+func (x *SubQuery) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Range) ProtoMessage() {}
+func (*SubQuery) ProtoMessage() {}
 
-func (x *Range) ProtoReflect() protoreflect.Message {
-	mi := &file_gripql_proto_msgTypes[4]
+func (x *SubQuery) ProtoReflect() protoreflect.Message {
+	mi := &file_gripql_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubQuery.ProtoReflect.Descriptor instead.
+func (*SubQuery) Descriptor() ([]byte, []int) {
+	return file_gripql_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *SubQuery) GetQuery() []*GraphStatement {
+	if x != nil {
+		return x.Query
+	}
+	return nil
+}
+
+type SubQueryList struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Queries []*SubQuery `protobuf:"bytes,1,rep,name=queries,proto3" json:"queries,omitempty"`
+}
+
+func (x *SubQueryList) Reset() {
+	*x = SubQueryList{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gripql_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SubQueryList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubQueryList) ProtoMessage() {}
+
+func (x *SubQueryList) ProtoReflect() protoreflect.Message {
+	mi := &file_gripql_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubQueryList.ProtoReflect.Descriptor instead.
+func (*SubQueryList) Descriptor() ([]byte, []int) {
+	return file_gripql_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *SubQueryList) GetQueries() []*SubQuery {
+	if x != nil {
+		return x.Queries
+	}
+	return nil
+}
+
+// Group collects the rendered value of each traveler into lists keyed by the
+// value found at a path
+type Group struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Key   string          `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Value *structpb.Value `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (x *Group) Reset() {
+	*x = Group{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gripql_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Group) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Group) ProtoMessage() {}
+
+func (x *Group) ProtoReflect() protoreflect.Message {
+	mi := &file_gripql_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Group.ProtoReflect.Descriptor instead.
+func (*Group) Descriptor() ([]byte, []int) {
+	return file_gripql_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Group) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *Group) GetValue() *structpb.Value {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+type Range struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Start int32 `protobuf:"varint,1,opt,name=start,proto3" json:"start,omitempty"`
+	Stop  int32 `protobuf:"varint,2,opt,name=stop,proto3" json:"stop,omitempty"`
+}
+
+func (x *Range) Reset() {
+	*x = Range{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gripql_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Range) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Range) ProtoMessage() {}
+
+func (x *Range) ProtoReflect() protoreflect.Message {
+	mi := &file_gripql_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -918,7 +1461,7 @@ func (x *Range) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Range.ProtoReflect.Descriptor instead.
 func (*Range) Descriptor() ([]byte, []int) {
-	return file_gripql_proto_rawDescGZIP(), []int{4}
+	return file_gripql_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *Range) GetStart() int32 {
@@ -935,6 +1478,63 @@ func (x *Range) GetStop() int32 {
 	return 0
 }
 
+// Seek keeps the elements that come after a position in the order of a sort
+// statement with the same fields
+type Seek struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Fields []string            `protobuf:"bytes,1,rep,name=fields,proto3" json:"fields,omitempty"`
+	Values *structpb.ListValue `protobuf:"bytes,2,opt,name=values,proto3" json:"values,omitempty"`
+}
+
+func (x *Seek) Reset() {
+	*x = Seek{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gripql_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Seek) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Seek) ProtoMessage() {}
+
+func (x *Seek) ProtoReflect() protoreflect.Message {
+	mi := &file_gripql_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Seek.ProtoReflect.Descriptor instead.
+func (*Seek) Descriptor() ([]byte, []int) {
+	return file_gripql_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *Seek) GetFields() []string {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+func (x *Seek) GetValues() *structpb.ListValue {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
 type AggregationsRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -947,7 +1547,7 @@ type AggregationsRequest struct {
 func (x *AggregationsRequest) Reset() {
 	*x = AggregationsRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_gripql_proto_msgTypes[5]
+		mi := &file_gripql_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -960,7 +1560,7 @@ func (x *AggregationsRequest) String() string {
 func (*AggregationsRequest) ProtoMessage() {}
 
 func (x *AggregationsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_gripql_proto_msgTypes[5]
+	mi := &file_gripql_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -973,7 +1573,7 @@ func (x *AggregationsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AggregationsRequest.ProtoReflect.Descriptor instead.
 func (*AggregationsRequest) Descriptor() ([]byte, []int) {
-	return file_gripql_proto_rawDescGZIP(), []int{5}
+	return file_gripql_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *AggregationsRequest) GetGraph() string {
@@ -1001,7 +1601,7 @@ type Aggregations struct {
 func (x *Aggregations) Reset() {
 	*x = Aggregations{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_gripql_proto_msgTypes[6]
+		mi := &file_gripql_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1014,7 +1614,7 @@ func (x *Aggregations) String() string {
 func (*Aggregations) ProtoMessage() {}
 
 func (x *Aggregations) ProtoReflect() protoreflect.Message {
-	mi := &file_gripql_proto_msgTypes[6]
+	mi := &file_gripql_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1027,7 +1627,7 @@ func (x *Aggregations) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Aggregations.ProtoReflect.Descriptor instead.
 func (*Aggregations) Descriptor() ([]byte, []int) {
-	return file_gripql_proto_rawDescGZIP(), []int{6}
+	return file_gripql_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *Aggregations) GetAggregations() []*Aggregate {
@@ -1051,13 +1651,15 @@ type Aggregate struct {
 	//	*Aggregate_Field
 	//	*Aggregate_Type
 	//	*Aggregate_Count
+	//	*Aggregate_DateHistogram
+	//	*Aggregate_GeohashGrid
 	Aggregation isAggregate_Aggregation `protobuf_oneof:"aggregation"`
 }
 
 func (x *Aggregate) Reset() {
 	*x = Aggregate{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_gripql_proto_msgTypes[7]
+		mi := &file_gripql_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1070,7 +1672,7 @@ func (x *Aggregate) String() string {
 func (*Aggregate) ProtoMessage() {}
 
 func (x *Aggregate) ProtoReflect() protoreflect.Message {
-	mi := &file_gripql_proto_msgTypes[7]
+	mi := &file_gripql_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1083,7 +1685,7 @@ func (x *Aggregate) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Aggregate.ProtoReflect.Descriptor instead.
 func (*Aggregate) Descriptor() ([]byte, []int) {
-	return file_gripql_proto_rawDescGZIP(), []int{7}
+	return file_gripql_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *Aggregate) GetName() string {
@@ -1142,6 +1744,20 @@ func (x *Aggregate) GetCount() *CountAggregation {
 	return nil
 }
 
+func (x *Aggregate) GetDateHistogram() *DateHistogramAggregation {
+	if x, ok := x.GetAggregation().(*Aggregate_DateHistogram); ok {
+		return x.DateHistogram
+	}
+	return nil
+}
+
+func (x *Aggregate) GetGeohashGrid() *GeohashGridAggregation {
+	if x, ok := x.GetAggregation().(*Aggregate_GeohashGrid); ok {
+		return x.GeohashGrid
+	}
+	return nil
+}
+
 type isAggregate_Aggregation interface {
 	isAggregate_Aggregation()
 }
@@ -1170,6 +1786,14 @@ type Aggregate_Count struct {
 	Count *CountAggregation `protobuf:"bytes,7,opt,name=count,proto3,oneof"`
 }
 
+type Aggregate_DateHistogram struct {
+	DateHistogram *DateHistogramAggregation `protobuf:"bytes,8,opt,name=date_histogram,json=dateHistogram,proto3,oneof"`
+}
+
+type Aggregate_GeohashGrid struct {
+	GeohashGrid *GeohashGridAggregation `protobuf:"bytes,9,opt,name=geohash_grid,json=geohashGrid,proto3,oneof"`
+}
+
 func (*Aggregate_Term) isAggregate_Aggregation() {}
 
 func (*Aggregate_Percentile) isAggregate_Aggregation() {}
@@ -1182,6 +1806,10 @@ func (*Aggregate_Type) isAggregate_Aggregation() {}
 
 func (*Aggregate_Count) isAggregate_Aggregation() {}
 
+func (*Aggregate_DateHistogram) isAggregate_Aggregation() {}
+
+func (*Aggregate_GeohashGrid) isAggregate_Aggregation() {}
+
 type TermAggregation struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -1194,7 +1822,7 @@ type TermAggregation struct {
 func (x *TermAggregation) Reset() {
 	*x = TermAggregation{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_gripql_proto_msgTypes[8]
+		mi := &file_gripql_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1207,7 +1835,7 @@ func (x *TermAggregation) String() string {
 func (*TermAggregation) ProtoMessage() {}
 
 func (x *TermAggregation) ProtoReflect() protoreflect.Message {
-	mi := &file_gripql_proto_msgTypes[8]
+	mi := &file_gripql_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1220,7 +1848,7 @@ func (x *TermAggregation) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TermAggregation.ProtoReflect.Descriptor instead.
 func (*TermAggregation) Descriptor() ([]byte, []int) {
-	return file_gripql_proto_rawDescGZIP(), []int{8}
+	return file_gripql_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *TermAggregation) GetField() string {
@@ -1249,7 +1877,7 @@ type PercentileAggregation struct {
 func (x *PercentileAggregation) Reset() {
 	*x = PercentileAggregation{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_gripql_proto_msgTypes[9]
+		mi := &file_gripql_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1262,7 +1890,7 @@ func (x *PercentileAggregation) String() string {
 func (*PercentileAggregation) ProtoMessage() {}
 
 func (x *PercentileAggregation) ProtoReflect() protoreflect.Message {
-	mi := &file_gripql_proto_msgTypes[9]
+	mi := &file_gripql_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1275,7 +1903,7 @@ func (x *PercentileAggregation) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PercentileAggregation.ProtoReflect.Descriptor instead.
 func (*PercentileAggregation) Descriptor() ([]byte, []int) {
-	return file_gripql_proto_rawDescGZIP(), []int{9}
+	return file_gripql_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *PercentileAggregation) GetField() string {
@@ -1304,7 +1932,7 @@ type HistogramAggregation struct {
 func (x *HistogramAggregation) Reset() {
 	*x = HistogramAggregation{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_gripql_proto_msgTypes[10]
+		mi := &file_gripql_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1317,7 +1945,7 @@ func (x *HistogramAggregation) String() string {
 func (*HistogramAggregation) ProtoMessage() {}
 
 func (x *HistogramAggregation) ProtoReflect() protoreflect.Message {
-	mi := &file_gripql_proto_msgTypes[10]
+	mi := &file_gripql_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1330,7 +1958,7 @@ func (x *HistogramAggregation) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HistogramAggregation.ProtoReflect.Descriptor instead.
 func (*HistogramAggregation) Descriptor() ([]byte, []int) {
-	return file_gripql_proto_rawDescGZIP(), []int{10}
+	return file_gripql_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *HistogramAggregation) GetField() string {
@@ -1347,31 +1975,34 @@ func (x *HistogramAggregation) GetInterval() uint32 {
 	return 0
 }
 
-type FieldAggregation struct {
+// DateHistogramAggregation counts RFC3339 timestamps in calendar buckets.
+// interval is one of "day", "month" or "year"
+type DateHistogramAggregation struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Field string `protobuf:"bytes,2,opt,name=field,proto3" json:"field,omitempty"`
+	Field    string `protobuf:"bytes,2,opt,name=field,proto3" json:"field,omitempty"`
+	Interval string `protobuf:"bytes,3,opt,name=interval,proto3" json:"interval,omitempty"`
 }
 
-func (x *FieldAggregation) Reset() {
-	*x = FieldAggregation{}
+func (x *DateHistogramAggregation) Reset() {
+	*x = DateHistogramAggregation{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_gripql_proto_msgTypes[11]
+		mi := &file_gripql_proto_msgTypes[16]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *FieldAggregation) String() string {
+func (x *DateHistogramAggregation) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*FieldAggregation) ProtoMessage() {}
+func (*DateHistogramAggregation) ProtoMessage() {}
 
-func (x *FieldAggregation) ProtoReflect() protoreflect.Message {
-	mi := &file_gripql_proto_msgTypes[11]
+func (x *DateHistogramAggregation) ProtoReflect() protoreflect.Message {
+	mi := &file_gripql_proto_msgTypes[16]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1382,43 +2013,51 @@ func (x *FieldAggregation) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use FieldAggregation.ProtoReflect.Descriptor instead.
-func (*FieldAggregation) Descriptor() ([]byte, []int) {
-	return file_gripql_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use DateHistogramAggregation.ProtoReflect.Descriptor instead.
+func (*DateHistogramAggregation) Descriptor() ([]byte, []int) {
+	return file_gripql_proto_rawDescGZIP(), []int{16}
 }
 
-func (x *FieldAggregation) GetField() string {
+func (x *DateHistogramAggregation) GetField() string {
 	if x != nil {
 		return x.Field
 	}
 	return ""
 }
 
-type TypeAggregation struct {
+func (x *DateHistogramAggregation) GetInterval() string {
+	if x != nil {
+		return x.Interval
+	}
+	return ""
+}
+
+type GeohashGridAggregation struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Field string `protobuf:"bytes,2,opt,name=field,proto3" json:"field,omitempty"`
+	Field     string `protobuf:"bytes,2,opt,name=field,proto3" json:"field,omitempty"`
+	Precision uint32 `protobuf:"varint,3,opt,name=precision,proto3" json:"precision,omitempty"`
 }
 
-func (x *TypeAggregation) Reset() {
-	*x = TypeAggregation{}
+func (x *GeohashGridAggregation) Reset() {
+	*x = GeohashGridAggregation{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_gripql_proto_msgTypes[12]
+		mi := &file_gripql_proto_msgTypes[17]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *TypeAggregation) String() string {
+func (x *GeohashGridAggregation) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TypeAggregation) ProtoMessage() {}
+func (*GeohashGridAggregation) ProtoMessage() {}
 
-func (x *TypeAggregation) ProtoReflect() protoreflect.Message {
-	mi := &file_gripql_proto_msgTypes[12]
+func (x *GeohashGridAggregation) ProtoReflect() protoreflect.Message {
+	mi := &file_gripql_proto_msgTypes[17]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1429,9 +2068,110 @@ func (x *TypeAggregation) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TypeAggregation.ProtoReflect.Descriptor instead.
-func (*TypeAggregation) Descriptor() ([]byte, []int) {
-	return file_gripql_proto_rawDescGZIP(), []int{12}
+// Deprecated: Use GeohashGridAggregation.ProtoReflect.Descriptor instead.
+func (*GeohashGridAggregation) Descriptor() ([]byte, []int) {
+	return file_gripql_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *GeohashGridAggregation) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *GeohashGridAggregation) GetPrecision() uint32 {
+	if x != nil {
+		return x.Precision
+	}
+	return 0
+}
+
+type FieldAggregation struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Field string `protobuf:"bytes,2,opt,name=field,proto3" json:"field,omitempty"`
+}
+
+func (x *FieldAggregation) Reset() {
+	*x = FieldAggregation{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gripql_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FieldAggregation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FieldAggregation) ProtoMessage() {}
+
+func (x *FieldAggregation) ProtoReflect() protoreflect.Message {
+	mi := &file_gripql_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FieldAggregation.ProtoReflect.Descriptor instead.
+func (*FieldAggregation) Descriptor() ([]byte, []int) {
+	return file_gripql_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *FieldAggregation) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+type TypeAggregation struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Field string `protobuf:"bytes,2,opt,name=field,proto3" json:"field,omitempty"`
+}
+
+func (x *TypeAggregation) Reset() {
+	*x = TypeAggregation{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gripql_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TypeAggregation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TypeAggregation) ProtoMessage() {}
+
+func (x *TypeAggregation) ProtoReflect() protoreflect.Message {
+	mi := &file_gripql_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TypeAggregation.ProtoReflect.Descriptor instead.
+func (*TypeAggregation) Descriptor() ([]byte, []int) {
+	return file_gripql_proto_rawDescGZIP(), []int{19}
 }
 
 func (x *TypeAggregation) GetField() string {
@@ -1450,7 +2190,7 @@ type CountAggregation struct {
 func (x *CountAggregation) Reset() {
 	*x = CountAggregation{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_gripql_proto_msgTypes[13]
+		mi := &file_gripql_proto_msgTypes[20]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1463,7 +2203,7 @@ func (x *CountAggregation) String() string {
 func (*CountAggregation) ProtoMessage() {}
 
 func (x *CountAggregation) ProtoReflect() protoreflect.Message {
-	mi := &file_gripql_proto_msgTypes[13]
+	mi := &file_gripql_proto_msgTypes[20]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1476,7 +2216,7 @@ func (x *CountAggregation) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CountAggregation.ProtoReflect.Descriptor instead.
 func (*CountAggregation) Descriptor() ([]byte, []int) {
-	return file_gripql_proto_rawDescGZIP(), []int{13}
+	return file_gripql_proto_rawDescGZIP(), []int{20}
 }
 
 type NamedAggregationResult struct {
@@ -1492,7 +2232,7 @@ type NamedAggregationResult struct {
 func (x *NamedAggregationResult) Reset() {
 	*x = NamedAggregationResult{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_gripql_proto_msgTypes[14]
+		mi := &file_gripql_proto_msgTypes[21]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1505,7 +2245,7 @@ func (x *NamedAggregationResult) String() string {
 func (*NamedAggregationResult) ProtoMessage() {}
 
 func (x *NamedAggregationResult) ProtoReflect() protoreflect.Message {
-	mi := &file_gripql_proto_msgTypes[14]
+	mi := &file_gripql_proto_msgTypes[21]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1518,7 +2258,7 @@ func (x *NamedAggregationResult) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use NamedAggregationResult.ProtoReflect.Descriptor instead.
 func (*NamedAggregationResult) Descriptor() ([]byte, []int) {
-	return file_gripql_proto_rawDescGZIP(), []int{14}
+	return file_gripql_proto_rawDescGZIP(), []int{21}
 }
 
 func (x *NamedAggregationResult) GetName() string {
@@ -1553,7 +2293,7 @@ type HasExpressionList struct {
 func (x *HasExpressionList) Reset() {
 	*x = HasExpressionList{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_gripql_proto_msgTypes[15]
+		mi := &file_gripql_proto_msgTypes[22]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1566,7 +2306,7 @@ func (x *HasExpressionList) String() string {
 func (*HasExpressionList) ProtoMessage() {}
 
 func (x *HasExpressionList) ProtoReflect() protoreflect.Message {
-	mi := &file_gripql_proto_msgTypes[15]
+	mi := &file_gripql_proto_msgTypes[22]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1579,7 +2319,7 @@ func (x *HasExpressionList) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HasExpressionList.ProtoReflect.Descriptor instead.
 func (*HasExpressionList) Descriptor() ([]byte, []int) {
-	return file_gripql_proto_rawDescGZIP(), []int{15}
+	return file_gripql_proto_rawDescGZIP(), []int{22}
 }
 
 func (x *HasExpressionList) GetExpressions() []*HasExpression {
@@ -1606,7 +2346,7 @@ type HasExpression struct {
 func (x *HasExpression) Reset() {
 	*x = HasExpression{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_gripql_proto_msgTypes[16]
+		mi := &file_gripql_proto_msgTypes[23]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1619,7 +2359,7 @@ func (x *HasExpression) String() string {
 func (*HasExpression) ProtoMessage() {}
 
 func (x *HasExpression) ProtoReflect() protoreflect.Message {
-	mi := &file_gripql_proto_msgTypes[16]
+	mi := &file_gripql_proto_msgTypes[23]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1632,7 +2372,7 @@ func (x *HasExpression) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HasExpression.ProtoReflect.Descriptor instead.
 func (*HasExpression) Descriptor() ([]byte, []int) {
-	return file_gripql_proto_rawDescGZIP(), []int{16}
+	return file_gripql_proto_rawDescGZIP(), []int{23}
 }
 
 func (m *HasExpression) GetExpression() isHasExpression_Expression {
@@ -1706,12 +2446,15 @@ type HasCondition struct {
 	Key       string          `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
 	Value     *structpb.Value `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
 	Condition Condition       `protobuf:"varint,3,opt,name=condition,proto3,enum=gripql.Condition" json:"condition,omitempty"`
+	// path of a field of the traveler, such as "$a.age", compared to
+	// instead of value
+	ValueRef string `protobuf:"bytes,4,opt,name=value_ref,json=valueRef,proto3" json:"value_ref,omitempty"`
 }
 
 func (x *HasCondition) Reset() {
 	*x = HasCondition{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_gripql_proto_msgTypes[17]
+		mi := &file_gripql_proto_msgTypes[24]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1724,7 +2467,7 @@ func (x *HasCondition) String() string {
 func (*HasCondition) ProtoMessage() {}
 
 func (x *HasCondition) ProtoReflect() protoreflect.Message {
-	mi := &file_gripql_proto_msgTypes[17]
+	mi := &file_gripql_proto_msgTypes[24]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1737,7 +2480,7 @@ func (x *HasCondition) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HasCondition.ProtoReflect.Descriptor instead.
 func (*HasCondition) Descriptor() ([]byte, []int) {
-	return file_gripql_proto_rawDescGZIP(), []int{17}
+	return file_gripql_proto_rawDescGZIP(), []int{24}
 }
 
 func (x *HasCondition) GetKey() string {
@@ -1761,6 +2504,13 @@ func (x *HasCondition) GetCondition() Condition {
 	return Condition_UNKNOWN_CONDITION
 }
 
+func (x *HasCondition) GetValueRef() string {
+	if x != nil {
+		return x.ValueRef
+	}
+	return ""
+}
+
 type SelectStatement struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -1772,7 +2522,7 @@ type SelectStatement struct {
 func (x *SelectStatement) Reset() {
 	*x = SelectStatement{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_gripql_proto_msgTypes[18]
+		mi := &file_gripql_proto_msgTypes[25]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1785,7 +2535,7 @@ func (x *SelectStatement) String() string {
 func (*SelectStatement) ProtoMessage() {}
 
 func (x *SelectStatement) ProtoReflect() protoreflect.Message {
-	mi := &file_gripql_proto_msgTypes[18]
+	mi := &file_gripql_proto_msgTypes[25]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1798,7 +2548,7 @@ func (x *SelectStatement) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SelectStatement.ProtoReflect.Descriptor instead.
 func (*SelectStatement) Descriptor() ([]byte, []int) {
-	return file_gripql_proto_rawDescGZIP(), []int{18}
+	return file_gripql_proto_rawDescGZIP(), []int{25}
 }
 
 func (x *SelectStatement) GetMarks() []string {
@@ -1823,7 +2573,7 @@ type Selection struct {
 func (x *Selection) Reset() {
 	*x = Selection{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_gripql_proto_msgTypes[19]
+		mi := &file_gripql_proto_msgTypes[26]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1836,7 +2586,7 @@ func (x *Selection) String() string {
 func (*Selection) ProtoMessage() {}
 
 func (x *Selection) ProtoReflect() protoreflect.Message {
-	mi := &file_gripql_proto_msgTypes[19]
+	mi := &file_gripql_proto_msgTypes[26]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1849,7 +2599,7 @@ func (x *Selection) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Selection.ProtoReflect.Descriptor instead.
 func (*Selection) Descriptor() ([]byte, []int) {
-	return file_gripql_proto_rawDescGZIP(), []int{19}
+	return file_gripql_proto_rawDescGZIP(), []int{26}
 }
 
 func (m *Selection) GetResult() isSelection_Result {
@@ -1900,7 +2650,7 @@ type Selections struct {
 func (x *Selections) Reset() {
 	*x = Selections{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_gripql_proto_msgTypes[20]
+		mi := &file_gripql_proto_msgTypes[27]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1913,7 +2663,7 @@ func (x *Selections) String() string {
 func (*Selections) ProtoMessage() {}
 
 func (x *Selections) ProtoReflect() protoreflect.Message {
-	mi := &file_gripql_proto_msgTypes[20]
+	mi := &file_gripql_proto_msgTypes[27]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1926,7 +2676,7 @@ func (x *Selections) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Selections.ProtoReflect.Descriptor instead.
 func (*Selections) Descriptor() ([]byte, []int) {
-	return file_gripql_proto_rawDescGZIP(), []int{20}
+	return file_gripql_proto_rawDescGZIP(), []int{27}
 }
 
 func (x *Selections) GetSelections() map[string]*Selection {
@@ -1949,7 +2699,7 @@ type Jump struct {
 func (x *Jump) Reset() {
 	*x = Jump{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_gripql_proto_msgTypes[21]
+		mi := &file_gripql_proto_msgTypes[28]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1962,7 +2712,7 @@ func (x *Jump) String() string {
 func (*Jump) ProtoMessage() {}
 
 func (x *Jump) ProtoReflect() protoreflect.Message {
-	mi := &file_gripql_proto_msgTypes[21]
+	mi := &file_gripql_proto_msgTypes[28]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1975,7 +2725,7 @@ func (x *Jump) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Jump.ProtoReflect.Descriptor instead.
 func (*Jump) Descriptor() ([]byte, []int) {
-	return file_gripql_proto_rawDescGZIP(), []int{21}
+	return file_gripql_proto_rawDescGZIP(), []int{28}
 }
 
 func (x *Jump) GetMark() string {
@@ -2011,7 +2761,7 @@ type Set struct {
 func (x *Set) Reset() {
 	*x = Set{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_gripql_proto_msgTypes[22]
+		mi := &file_gripql_proto_msgTypes[29]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2024,7 +2774,7 @@ func (x *Set) String() string {
 func (*Set) ProtoMessage() {}
 
 func (x *Set) ProtoReflect() protoreflect.Message {
-	mi := &file_gripql_proto_msgTypes[22]
+	mi := &file_gripql_proto_msgTypes[29]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2037,7 +2787,7 @@ func (x *Set) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Set.ProtoReflect.Descriptor instead.
 func (*Set) Descriptor() ([]byte, []int) {
-	return file_gripql_proto_rawDescGZIP(), []int{22}
+	return file_gripql_proto_rawDescGZIP(), []int{29}
 }
 
 func (x *Set) GetKey() string {
@@ -2066,7 +2816,7 @@ type Increment struct {
 func (x *Increment) Reset() {
 	*x = Increment{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_gripql_proto_msgTypes[23]
+		mi := &file_gripql_proto_msgTypes[30]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2079,7 +2829,7 @@ func (x *Increment) String() string {
 func (*Increment) ProtoMessage() {}
 
 func (x *Increment) ProtoReflect() protoreflect.Message {
-	mi := &file_gripql_proto_msgTypes[23]
+	mi := &file_gripql_proto_msgTypes[30]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2092,7 +2842,7 @@ func (x *Increment) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Increment.ProtoReflect.Descriptor instead.
 func (*Increment) Descriptor() ([]byte, []int) {
-	return file_gripql_proto_rawDescGZIP(), []int{23}
+	return file_gripql_proto_rawDescGZIP(), []int{30}
 }
 
 func (x *Increment) GetKey() string {
@@ -2122,7 +2872,7 @@ type Vertex struct {
 func (x *Vertex) Reset() {
 	*x = Vertex{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_gripql_proto_msgTypes[24]
+		mi := &file_gripql_proto_msgTypes[31]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2135,7 +2885,7 @@ func (x *Vertex) String() string {
 func (*Vertex) ProtoMessage() {}
 
 func (x *Vertex) ProtoReflect() protoreflect.Message {
-	mi := &file_gripql_proto_msgTypes[24]
+	mi := &file_gripql_proto_msgTypes[31]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2148,7 +2898,7 @@ func (x *Vertex) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Vertex.ProtoReflect.Descriptor instead.
 func (*Vertex) Descriptor() ([]byte, []int) {
-	return file_gripql_proto_rawDescGZIP(), []int{24}
+	return file_gripql_proto_rawDescGZIP(), []int{31}
 }
 
 func (x *Vertex) GetGid() string {
@@ -2187,7 +2937,7 @@ type Edge struct {
 func (x *Edge) Reset() {
 	*x = Edge{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_gripql_proto_msgTypes[25]
+		mi := &file_gripql_proto_msgTypes[32]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2200,7 +2950,7 @@ func (x *Edge) String() string {
 func (*Edge) ProtoMessage() {}
 
 func (x *Edge) ProtoReflect() protoreflect.Message {
-	mi := &file_gripql_proto_msgTypes[25]
+	mi := &file_gripql_proto_msgTypes[32]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2213,7 +2963,7 @@ func (x *Edge) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Edge.ProtoReflect.Descriptor instead.
 func (*Edge) Descriptor() ([]byte, []int) {
-	return file_gripql_proto_rawDescGZIP(), []int{25}
+	return file_gripql_proto_rawDescGZIP(), []int{32}
 }
 
 func (x *Edge) GetGid() string {
@@ -2265,13 +3015,14 @@ type QueryResult struct {
 	//	*QueryResult_Render
 	//	*QueryResult_Count
 	//	*QueryResult_Path
+	//	*QueryResult_Arrow
 	Result isQueryResult_Result `protobuf_oneof:"result"`
 }
 
 func (x *QueryResult) Reset() {
 	*x = QueryResult{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_gripql_proto_msgTypes[26]
+		mi := &file_gripql_proto_msgTypes[33]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2284,7 +3035,7 @@ func (x *QueryResult) String() string {
 func (*QueryResult) ProtoMessage() {}
 
 func (x *QueryResult) ProtoReflect() protoreflect.Message {
-	mi := &file_gripql_proto_msgTypes[26]
+	mi := &file_gripql_proto_msgTypes[33]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2297,7 +3048,7 @@ func (x *QueryResult) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use QueryResult.ProtoReflect.Descriptor instead.
 func (*QueryResult) Descriptor() ([]byte, []int) {
-	return file_gripql_proto_rawDescGZIP(), []int{26}
+	return file_gripql_proto_rawDescGZIP(), []int{33}
 }
 
 func (m *QueryResult) GetResult() isQueryResult_Result {
@@ -2356,6 +3107,13 @@ func (x *QueryResult) GetPath() *structpb.ListValue {
 	return nil
 }
 
+func (x *QueryResult) GetArrow() []byte {
+	if x, ok := x.GetResult().(*QueryResult_Arrow); ok {
+		return x.Arrow
+	}
+	return nil
+}
+
 type isQueryResult_Result interface {
 	isQueryResult_Result()
 }
@@ -2388,6 +3146,12 @@ type QueryResult_Path struct {
 	Path *structpb.ListValue `protobuf:"bytes,7,opt,name=path,proto3,oneof"`
 }
 
+type QueryResult_Arrow struct {
+	// An Arrow IPC stream holding a schema and one record batch of results,
+	// sent when the query asked for the ARROW format
+	Arrow []byte `protobuf:"bytes,8,opt,name=arrow,proto3,oneof"`
+}
+
 func (*QueryResult_Vertex) isQueryResult_Result() {}
 
 func (*QueryResult_Edge) isQueryResult_Result() {}
@@ -2402,19 +3166,22 @@ func (*QueryResult_Count) isQueryResult_Result() {}
 
 func (*QueryResult_Path) isQueryResult_Result() {}
 
+func (*QueryResult_Arrow) isQueryResult_Result() {}
+
 type QueryJob struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id    string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Graph string `protobuf:"bytes,2,opt,name=graph,proto3" json:"graph,omitempty"`
+	Id     string       `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Graph  string       `protobuf:"bytes,2,opt,name=graph,proto3" json:"graph,omitempty"`
+	Format ResultFormat `protobuf:"varint,3,opt,name=format,proto3,enum=gripql.ResultFormat" json:"format,omitempty"`
 }
 
 func (x *QueryJob) Reset() {
 	*x = QueryJob{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_gripql_proto_msgTypes[27]
+		mi := &file_gripql_proto_msgTypes[34]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2427,7 +3194,7 @@ func (x *QueryJob) String() string {
 func (*QueryJob) ProtoMessage() {}
 
 func (x *QueryJob) ProtoReflect() protoreflect.Message {
-	mi := &file_gripql_proto_msgTypes[27]
+	mi := &file_gripql_proto_msgTypes[34]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2440,7 +3207,7 @@ func (x *QueryJob) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use QueryJob.ProtoReflect.Descriptor instead.
 func (*QueryJob) Descriptor() ([]byte, []int) {
-	return file_gripql_proto_rawDescGZIP(), []int{27}
+	return file_gripql_proto_rawDescGZIP(), []int{34}
 }
 
 func (x *QueryJob) GetId() string {
@@ -2457,6 +3224,13 @@ func (x *QueryJob) GetGraph() string {
 	return ""
 }
 
+func (x *QueryJob) GetFormat() ResultFormat {
+	if x != nil {
+		return x.Format
+	}
+	return ResultFormat_ROWS
+}
+
 type ExtendQuery struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -2470,7 +3244,7 @@ type ExtendQuery struct {
 func (x *ExtendQuery) Reset() {
 	*x = ExtendQuery{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_gripql_proto_msgTypes[28]
+		mi := &file_gripql_proto_msgTypes[35]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2483,7 +3257,7 @@ func (x *ExtendQuery) String() string {
 func (*ExtendQuery) ProtoMessage() {}
 
 func (x *ExtendQuery) ProtoReflect() protoreflect.Message {
-	mi := &file_gripql_proto_msgTypes[28]
+	mi := &file_gripql_proto_msgTypes[35]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2496,7 +3270,7 @@ func (x *ExtendQuery) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ExtendQuery.ProtoReflect.Descriptor instead.
 func (*ExtendQuery) Descriptor() ([]byte, []int) {
-	return file_gripql_proto_rawDescGZIP(), []int{28}
+	return file_gripql_proto_rawDescGZIP(), []int{35}
 }
 
 func (x *ExtendQuery) GetSrcId() string {
@@ -2525,18 +3299,21 @@ type JobStatus struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id        string            `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Graph     string            `protobuf:"bytes,2,opt,name=graph,proto3" json:"graph,omitempty"`
-	State     JobState          `protobuf:"varint,3,opt,name=state,proto3,enum=gripql.JobState" json:"state,omitempty"`
-	Count     uint64            `protobuf:"varint,4,opt,name=count,proto3" json:"count,omitempty"`
-	Query     []*GraphStatement `protobuf:"bytes,5,rep,name=query,proto3" json:"query,omitempty"`
-	Timestamp string            `protobuf:"bytes,6,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Id             string            `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Graph          string            `protobuf:"bytes,2,opt,name=graph,proto3" json:"graph,omitempty"`
+	State          JobState          `protobuf:"varint,3,opt,name=state,proto3,enum=gripql.JobState" json:"state,omitempty"`
+	Count          uint64            `protobuf:"varint,4,opt,name=count,proto3" json:"count,omitempty"`
+	Query          []*GraphStatement `protobuf:"bytes,5,rep,name=query,proto3" json:"query,omitempty"`
+	Timestamp      string            `protobuf:"bytes,6,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	GraphTimestamp string            `protobuf:"bytes,7,opt,name=graph_timestamp,json=graphTimestamp,proto3" json:"graph_timestamp,omitempty"`
+	// the error that stopped the job, if the state is ERROR
+	Error string `protobuf:"bytes,8,opt,name=error,proto3" json:"error,omitempty"`
 }
 
 func (x *JobStatus) Reset() {
 	*x = JobStatus{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_gripql_proto_msgTypes[29]
+		mi := &file_gripql_proto_msgTypes[36]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2549,7 +3326,7 @@ func (x *JobStatus) String() string {
 func (*JobStatus) ProtoMessage() {}
 
 func (x *JobStatus) ProtoReflect() protoreflect.Message {
-	mi := &file_gripql_proto_msgTypes[29]
+	mi := &file_gripql_proto_msgTypes[36]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2562,7 +3339,7 @@ func (x *JobStatus) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use JobStatus.ProtoReflect.Descriptor instead.
 func (*JobStatus) Descriptor() ([]byte, []int) {
-	return file_gripql_proto_rawDescGZIP(), []int{29}
+	return file_gripql_proto_rawDescGZIP(), []int{36}
 }
 
 func (x *JobStatus) GetId() string {
@@ -2607,6 +3384,20 @@ func (x *JobStatus) GetTimestamp() string {
 	return ""
 }
 
+func (x *JobStatus) GetGraphTimestamp() string {
+	if x != nil {
+		return x.GraphTimestamp
+	}
+	return ""
+}
+
+func (x *JobStatus) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
 type EditResult struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -2618,7 +3409,7 @@ type EditResult struct {
 func (x *EditResult) Reset() {
 	*x = EditResult{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_gripql_proto_msgTypes[30]
+		mi := &file_gripql_proto_msgTypes[37]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2631,7 +3422,7 @@ func (x *EditResult) String() string {
 func (*EditResult) ProtoMessage() {}
 
 func (x *EditResult) ProtoReflect() protoreflect.Message {
-	mi := &file_gripql_proto_msgTypes[30]
+	mi := &file_gripql_proto_msgTypes[37]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2644,7 +3435,7 @@ func (x *EditResult) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use EditResult.ProtoReflect.Descriptor instead.
 func (*EditResult) Descriptor() ([]byte, []int) {
-	return file_gripql_proto_rawDescGZIP(), []int{30}
+	return file_gripql_proto_rawDescGZIP(), []int{37}
 }
 
 func (x *EditResult) GetId() string {
@@ -2659,14 +3450,15 @@ type BulkEditResult struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	InsertCount int32 `protobuf:"varint,1,opt,name=insert_count,json=insertCount,proto3" json:"insert_count,omitempty"`
-	ErrorCount  int32 `protobuf:"varint,2,opt,name=error_count,json=errorCount,proto3" json:"error_count,omitempty"`
+	InsertCount int32    `protobuf:"varint,1,opt,name=insert_count,json=insertCount,proto3" json:"insert_count,omitempty"`
+	ErrorCount  int32    `protobuf:"varint,2,opt,name=error_count,json=errorCount,proto3" json:"error_count,omitempty"`
+	Errors      []string `protobuf:"bytes,3,rep,name=errors,proto3" json:"errors,omitempty"`
 }
 
 func (x *BulkEditResult) Reset() {
 	*x = BulkEditResult{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_gripql_proto_msgTypes[31]
+		mi := &file_gripql_proto_msgTypes[38]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2679,7 +3471,7 @@ func (x *BulkEditResult) String() string {
 func (*BulkEditResult) ProtoMessage() {}
 
 func (x *BulkEditResult) ProtoReflect() protoreflect.Message {
-	mi := &file_gripql_proto_msgTypes[31]
+	mi := &file_gripql_proto_msgTypes[38]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2692,7 +3484,7 @@ func (x *BulkEditResult) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BulkEditResult.ProtoReflect.Descriptor instead.
 func (*BulkEditResult) Descriptor() ([]byte, []int) {
-	return file_gripql_proto_rawDescGZIP(), []int{31}
+	return file_gripql_proto_rawDescGZIP(), []int{38}
 }
 
 func (x *BulkEditResult) GetInsertCount() int32 {
@@ -2709,6 +3501,13 @@ func (x *BulkEditResult) GetErrorCount() int32 {
 	return 0
 }
 
+func (x *BulkEditResult) GetErrors() []string {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
 type GraphElement struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -2717,12 +3516,14 @@ type GraphElement struct {
 	Graph  string  `protobuf:"bytes,1,opt,name=graph,proto3" json:"graph,omitempty"`
 	Vertex *Vertex `protobuf:"bytes,2,opt,name=vertex,proto3" json:"vertex,omitempty"`
 	Edge   *Edge   `protobuf:"bytes,3,opt,name=edge,proto3" json:"edge,omitempty"`
+	// Fail if an element with the same id exists, instead of replacing it
+	Create bool `protobuf:"varint,4,opt,name=create,proto3" json:"create,omitempty"`
 }
 
 func (x *GraphElement) Reset() {
 	*x = GraphElement{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_gripql_proto_msgTypes[32]
+		mi := &file_gripql_proto_msgTypes[39]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2735,7 +3536,7 @@ func (x *GraphElement) String() string {
 func (*GraphElement) ProtoMessage() {}
 
 func (x *GraphElement) ProtoReflect() protoreflect.Message {
-	mi := &file_gripql_proto_msgTypes[32]
+	mi := &file_gripql_proto_msgTypes[39]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2748,7 +3549,7 @@ func (x *GraphElement) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GraphElement.ProtoReflect.Descriptor instead.
 func (*GraphElement) Descriptor() ([]byte, []int) {
-	return file_gripql_proto_rawDescGZIP(), []int{32}
+	return file_gripql_proto_rawDescGZIP(), []int{39}
 }
 
 func (x *GraphElement) GetGraph() string {
@@ -2772,10 +3573,17 @@ func (x *GraphElement) GetEdge() *Edge {
 	return nil
 }
 
-type GraphID struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
+func (x *GraphElement) GetCreate() bool {
+	if x != nil {
+		return x.Create
+	}
+	return false
+}
+
+type GraphID struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
 	Graph string `protobuf:"bytes,1,opt,name=graph,proto3" json:"graph,omitempty"`
 }
@@ -2783,7 +3591,7 @@ type GraphID struct {
 func (x *GraphID) Reset() {
 	*x = GraphID{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_gripql_proto_msgTypes[33]
+		mi := &file_gripql_proto_msgTypes[40]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2796,7 +3604,7 @@ func (x *GraphID) String() string {
 func (*GraphID) ProtoMessage() {}
 
 func (x *GraphID) ProtoReflect() protoreflect.Message {
-	mi := &file_gripql_proto_msgTypes[33]
+	mi := &file_gripql_proto_msgTypes[40]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2809,7 +3617,7 @@ func (x *GraphID) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GraphID.ProtoReflect.Descriptor instead.
 func (*GraphID) Descriptor() ([]byte, []int) {
-	return file_gripql_proto_rawDescGZIP(), []int{33}
+	return file_gripql_proto_rawDescGZIP(), []int{40}
 }
 
 func (x *GraphID) GetGraph() string {
@@ -2831,7 +3639,7 @@ type ElementID struct {
 func (x *ElementID) Reset() {
 	*x = ElementID{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_gripql_proto_msgTypes[34]
+		mi := &file_gripql_proto_msgTypes[41]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2844,7 +3652,7 @@ func (x *ElementID) String() string {
 func (*ElementID) ProtoMessage() {}
 
 func (x *ElementID) ProtoReflect() protoreflect.Message {
-	mi := &file_gripql_proto_msgTypes[34]
+	mi := &file_gripql_proto_msgTypes[41]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2857,7 +3665,7 @@ func (x *ElementID) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ElementID.ProtoReflect.Descriptor instead.
 func (*ElementID) Descriptor() ([]byte, []int) {
-	return file_gripql_proto_rawDescGZIP(), []int{34}
+	return file_gripql_proto_rawDescGZIP(), []int{41}
 }
 
 func (x *ElementID) GetGraph() string {
@@ -2879,15 +3687,16 @@ type IndexID struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Graph string `protobuf:"bytes,1,opt,name=graph,proto3" json:"graph,omitempty"`
-	Label string `protobuf:"bytes,2,opt,name=label,proto3" json:"label,omitempty"`
-	Field string `protobuf:"bytes,3,opt,name=field,proto3" json:"field,omitempty"`
+	Graph string    `protobuf:"bytes,1,opt,name=graph,proto3" json:"graph,omitempty"`
+	Label string    `protobuf:"bytes,2,opt,name=label,proto3" json:"label,omitempty"`
+	Field string    `protobuf:"bytes,3,opt,name=field,proto3" json:"field,omitempty"`
+	Type  IndexType `protobuf:"varint,4,opt,name=type,proto3,enum=gripql.IndexType" json:"type,omitempty"`
 }
 
 func (x *IndexID) Reset() {
 	*x = IndexID{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_gripql_proto_msgTypes[35]
+		mi := &file_gripql_proto_msgTypes[42]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2900,7 +3709,7 @@ func (x *IndexID) String() string {
 func (*IndexID) ProtoMessage() {}
 
 func (x *IndexID) ProtoReflect() protoreflect.Message {
-	mi := &file_gripql_proto_msgTypes[35]
+	mi := &file_gripql_proto_msgTypes[42]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2913,7 +3722,7 @@ func (x *IndexID) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use IndexID.ProtoReflect.Descriptor instead.
 func (*IndexID) Descriptor() ([]byte, []int) {
-	return file_gripql_proto_rawDescGZIP(), []int{35}
+	return file_gripql_proto_rawDescGZIP(), []int{42}
 }
 
 func (x *IndexID) GetGraph() string {
@@ -2937,6 +3746,13 @@ func (x *IndexID) GetField() string {
 	return ""
 }
 
+func (x *IndexID) GetType() IndexType {
+	if x != nil {
+		return x.Type
+	}
+	return IndexType_FIELD_INDEX
+}
+
 type Timestamp struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -2948,7 +3764,7 @@ type Timestamp struct {
 func (x *Timestamp) Reset() {
 	*x = Timestamp{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_gripql_proto_msgTypes[36]
+		mi := &file_gripql_proto_msgTypes[43]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2961,7 +3777,7 @@ func (x *Timestamp) String() string {
 func (*Timestamp) ProtoMessage() {}
 
 func (x *Timestamp) ProtoReflect() protoreflect.Message {
-	mi := &file_gripql_proto_msgTypes[36]
+	mi := &file_gripql_proto_msgTypes[43]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2974,7 +3790,7 @@ func (x *Timestamp) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Timestamp.ProtoReflect.Descriptor instead.
 func (*Timestamp) Descriptor() ([]byte, []int) {
-	return file_gripql_proto_rawDescGZIP(), []int{36}
+	return file_gripql_proto_rawDescGZIP(), []int{43}
 }
 
 func (x *Timestamp) GetTimestamp() string {
@@ -2993,7 +3809,7 @@ type Empty struct {
 func (x *Empty) Reset() {
 	*x = Empty{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_gripql_proto_msgTypes[37]
+		mi := &file_gripql_proto_msgTypes[44]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -3006,7 +3822,7 @@ func (x *Empty) String() string {
 func (*Empty) ProtoMessage() {}
 
 func (x *Empty) ProtoReflect() protoreflect.Message {
-	mi := &file_gripql_proto_msgTypes[37]
+	mi := &file_gripql_proto_msgTypes[44]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3019,7 +3835,7 @@ func (x *Empty) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Empty.ProtoReflect.Descriptor instead.
 func (*Empty) Descriptor() ([]byte, []int) {
-	return file_gripql_proto_rawDescGZIP(), []int{37}
+	return file_gripql_proto_rawDescGZIP(), []int{44}
 }
 
 type ListGraphsResponse struct {
@@ -3033,7 +3849,7 @@ type ListGraphsResponse struct {
 func (x *ListGraphsResponse) Reset() {
 	*x = ListGraphsResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_gripql_proto_msgTypes[38]
+		mi := &file_gripql_proto_msgTypes[45]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -3046,7 +3862,7 @@ func (x *ListGraphsResponse) String() string {
 func (*ListGraphsResponse) ProtoMessage() {}
 
 func (x *ListGraphsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_gripql_proto_msgTypes[38]
+	mi := &file_gripql_proto_msgTypes[45]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3059,7 +3875,7 @@ func (x *ListGraphsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListGraphsResponse.ProtoReflect.Descriptor instead.
 func (*ListGraphsResponse) Descriptor() ([]byte, []int) {
-	return file_gripql_proto_rawDescGZIP(), []int{38}
+	return file_gripql_proto_rawDescGZIP(), []int{45}
 }
 
 func (x *ListGraphsResponse) GetGraphs() []string {
@@ -3080,7 +3896,7 @@ type ListIndicesResponse struct {
 func (x *ListIndicesResponse) Reset() {
 	*x = ListIndicesResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_gripql_proto_msgTypes[39]
+		mi := &file_gripql_proto_msgTypes[46]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -3093,7 +3909,7 @@ func (x *ListIndicesResponse) String() string {
 func (*ListIndicesResponse) ProtoMessage() {}
 
 func (x *ListIndicesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_gripql_proto_msgTypes[39]
+	mi := &file_gripql_proto_msgTypes[46]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3106,7 +3922,7 @@ func (x *ListIndicesResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListIndicesResponse.ProtoReflect.Descriptor instead.
 func (*ListIndicesResponse) Descriptor() ([]byte, []int) {
-	return file_gripql_proto_rawDescGZIP(), []int{39}
+	return file_gripql_proto_rawDescGZIP(), []int{46}
 }
 
 func (x *ListIndicesResponse) GetIndices() []*IndexID {
@@ -3128,7 +3944,7 @@ type ListLabelsResponse struct {
 func (x *ListLabelsResponse) Reset() {
 	*x = ListLabelsResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_gripql_proto_msgTypes[40]
+		mi := &file_gripql_proto_msgTypes[47]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -3141,7 +3957,7 @@ func (x *ListLabelsResponse) String() string {
 func (*ListLabelsResponse) ProtoMessage() {}
 
 func (x *ListLabelsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_gripql_proto_msgTypes[40]
+	mi := &file_gripql_proto_msgTypes[47]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3154,7 +3970,7 @@ func (x *ListLabelsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListLabelsResponse.ProtoReflect.Descriptor instead.
 func (*ListLabelsResponse) Descriptor() ([]byte, []int) {
-	return file_gripql_proto_rawDescGZIP(), []int{40}
+	return file_gripql_proto_rawDescGZIP(), []int{47}
 }
 
 func (x *ListLabelsResponse) GetVertexLabels() []string {
@@ -3185,7 +4001,7 @@ type TableInfo struct {
 func (x *TableInfo) Reset() {
 	*x = TableInfo{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_gripql_proto_msgTypes[41]
+		mi := &file_gripql_proto_msgTypes[48]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -3198,7 +4014,7 @@ func (x *TableInfo) String() string {
 func (*TableInfo) ProtoMessage() {}
 
 func (x *TableInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_gripql_proto_msgTypes[41]
+	mi := &file_gripql_proto_msgTypes[48]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3211,7 +4027,7 @@ func (x *TableInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TableInfo.ProtoReflect.Descriptor instead.
 func (*TableInfo) Descriptor() ([]byte, []int) {
-	return file_gripql_proto_rawDescGZIP(), []int{41}
+	return file_gripql_proto_rawDescGZIP(), []int{48}
 }
 
 func (x *TableInfo) GetSource() string {
@@ -3242,6 +4058,395 @@ func (x *TableInfo) GetLinkMap() map[string]string {
 	return nil
 }
 
+// GraphEvent is an edit of a graph, sent to the clients watching it
+type GraphEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Graph  string    `protobuf:"bytes,1,opt,name=graph,proto3" json:"graph,omitempty"`
+	Type   EventType `protobuf:"varint,2,opt,name=type,proto3,enum=gripql.EventType" json:"type,omitempty"`
+	Vertex *Vertex   `protobuf:"bytes,3,opt,name=vertex,proto3" json:"vertex,omitempty"`
+	Edge   *Edge     `protobuf:"bytes,4,opt,name=edge,proto3" json:"edge,omitempty"`
+}
+
+func (x *GraphEvent) Reset() {
+	*x = GraphEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gripql_proto_msgTypes[49]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GraphEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GraphEvent) ProtoMessage() {}
+
+func (x *GraphEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_gripql_proto_msgTypes[49]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GraphEvent.ProtoReflect.Descriptor instead.
+func (*GraphEvent) Descriptor() ([]byte, []int) {
+	return file_gripql_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *GraphEvent) GetGraph() string {
+	if x != nil {
+		return x.Graph
+	}
+	return ""
+}
+
+func (x *GraphEvent) GetType() EventType {
+	if x != nil {
+		return x.Type
+	}
+	return EventType_ADDED
+}
+
+func (x *GraphEvent) GetVertex() *Vertex {
+	if x != nil {
+		return x.Vertex
+	}
+	return nil
+}
+
+func (x *GraphEvent) GetEdge() *Edge {
+	if x != nil {
+		return x.Edge
+	}
+	return nil
+}
+
+type QueryParameter struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name         string          `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Type         FieldType       `protobuf:"varint,2,opt,name=type,proto3,enum=gripql.FieldType" json:"type,omitempty"`
+	Description  string          `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	DefaultValue *structpb.Value `protobuf:"bytes,4,opt,name=default_value,json=defaultValue,proto3" json:"default_value,omitempty"`
+}
+
+func (x *QueryParameter) Reset() {
+	*x = QueryParameter{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gripql_proto_msgTypes[50]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *QueryParameter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryParameter) ProtoMessage() {}
+
+func (x *QueryParameter) ProtoReflect() protoreflect.Message {
+	mi := &file_gripql_proto_msgTypes[50]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryParameter.ProtoReflect.Descriptor instead.
+func (*QueryParameter) Descriptor() ([]byte, []int) {
+	return file_gripql_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *QueryParameter) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *QueryParameter) GetType() FieldType {
+	if x != nil {
+		return x.Type
+	}
+	return FieldType_UNKNOWN
+}
+
+func (x *QueryParameter) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *QueryParameter) GetDefaultValue() *structpb.Value {
+	if x != nil {
+		return x.DefaultValue
+	}
+	return nil
+}
+
+// QueryDefinition is a stored query. Strings in the statements of the form
+// ${name} are replaced with the value of the parameter
+type QueryDefinition struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Graph       string            `protobuf:"bytes,1,opt,name=graph,proto3" json:"graph,omitempty"`
+	Name        string            `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description string            `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Parameters  []*QueryParameter `protobuf:"bytes,4,rep,name=parameters,proto3" json:"parameters,omitempty"`
+	Query       []*GraphStatement `protobuf:"bytes,5,rep,name=query,proto3" json:"query,omitempty"`
+}
+
+func (x *QueryDefinition) Reset() {
+	*x = QueryDefinition{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gripql_proto_msgTypes[51]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *QueryDefinition) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryDefinition) ProtoMessage() {}
+
+func (x *QueryDefinition) ProtoReflect() protoreflect.Message {
+	mi := &file_gripql_proto_msgTypes[51]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryDefinition.ProtoReflect.Descriptor instead.
+func (*QueryDefinition) Descriptor() ([]byte, []int) {
+	return file_gripql_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *QueryDefinition) GetGraph() string {
+	if x != nil {
+		return x.Graph
+	}
+	return ""
+}
+
+func (x *QueryDefinition) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *QueryDefinition) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *QueryDefinition) GetParameters() []*QueryParameter {
+	if x != nil {
+		return x.Parameters
+	}
+	return nil
+}
+
+func (x *QueryDefinition) GetQuery() []*GraphStatement {
+	if x != nil {
+		return x.Query
+	}
+	return nil
+}
+
+type StoredQueryID struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Graph string `protobuf:"bytes,1,opt,name=graph,proto3" json:"graph,omitempty"`
+	Name  string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (x *StoredQueryID) Reset() {
+	*x = StoredQueryID{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gripql_proto_msgTypes[52]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StoredQueryID) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StoredQueryID) ProtoMessage() {}
+
+func (x *StoredQueryID) ProtoReflect() protoreflect.Message {
+	mi := &file_gripql_proto_msgTypes[52]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StoredQueryID.ProtoReflect.Descriptor instead.
+func (*StoredQueryID) Descriptor() ([]byte, []int) {
+	return file_gripql_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *StoredQueryID) GetGraph() string {
+	if x != nil {
+		return x.Graph
+	}
+	return ""
+}
+
+func (x *StoredQueryID) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type StoredQueryCall struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Graph      string           `protobuf:"bytes,1,opt,name=graph,proto3" json:"graph,omitempty"`
+	Name       string           `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Parameters *structpb.Struct `protobuf:"bytes,3,opt,name=parameters,proto3" json:"parameters,omitempty"`
+}
+
+func (x *StoredQueryCall) Reset() {
+	*x = StoredQueryCall{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gripql_proto_msgTypes[53]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StoredQueryCall) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StoredQueryCall) ProtoMessage() {}
+
+func (x *StoredQueryCall) ProtoReflect() protoreflect.Message {
+	mi := &file_gripql_proto_msgTypes[53]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StoredQueryCall.ProtoReflect.Descriptor instead.
+func (*StoredQueryCall) Descriptor() ([]byte, []int) {
+	return file_gripql_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *StoredQueryCall) GetGraph() string {
+	if x != nil {
+		return x.Graph
+	}
+	return ""
+}
+
+func (x *StoredQueryCall) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *StoredQueryCall) GetParameters() *structpb.Struct {
+	if x != nil {
+		return x.Parameters
+	}
+	return nil
+}
+
+type ListStoredQueriesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Queries []*QueryDefinition `protobuf:"bytes,1,rep,name=queries,proto3" json:"queries,omitempty"`
+}
+
+func (x *ListStoredQueriesResponse) Reset() {
+	*x = ListStoredQueriesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gripql_proto_msgTypes[54]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListStoredQueriesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListStoredQueriesResponse) ProtoMessage() {}
+
+func (x *ListStoredQueriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_gripql_proto_msgTypes[54]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListStoredQueriesResponse.ProtoReflect.Descriptor instead.
+func (*ListStoredQueriesResponse) Descriptor() ([]byte, []int) {
+	return file_gripql_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *ListStoredQueriesResponse) GetQueries() []*QueryDefinition {
+	if x != nil {
+		return x.Queries
+	}
+	return nil
+}
+
 type PluginConfig struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -3255,7 +4460,7 @@ type PluginConfig struct {
 func (x *PluginConfig) Reset() {
 	*x = PluginConfig{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_gripql_proto_msgTypes[42]
+		mi := &file_gripql_proto_msgTypes[55]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -3268,7 +4473,7 @@ func (x *PluginConfig) String() string {
 func (*PluginConfig) ProtoMessage() {}
 
 func (x *PluginConfig) ProtoReflect() protoreflect.Message {
-	mi := &file_gripql_proto_msgTypes[42]
+	mi := &file_gripql_proto_msgTypes[55]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3281,7 +4486,7 @@ func (x *PluginConfig) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PluginConfig.ProtoReflect.Descriptor instead.
 func (*PluginConfig) Descriptor() ([]byte, []int) {
-	return file_gripql_proto_rawDescGZIP(), []int{42}
+	return file_gripql_proto_rawDescGZIP(), []int{55}
 }
 
 func (x *PluginConfig) GetName() string {
@@ -3317,7 +4522,7 @@ type PluginStatus struct {
 func (x *PluginStatus) Reset() {
 	*x = PluginStatus{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_gripql_proto_msgTypes[43]
+		mi := &file_gripql_proto_msgTypes[56]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -3330,7 +4535,7 @@ func (x *PluginStatus) String() string {
 func (*PluginStatus) ProtoMessage() {}
 
 func (x *PluginStatus) ProtoReflect() protoreflect.Message {
-	mi := &file_gripql_proto_msgTypes[43]
+	mi := &file_gripql_proto_msgTypes[56]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3343,7 +4548,7 @@ func (x *PluginStatus) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PluginStatus.ProtoReflect.Descriptor instead.
 func (*PluginStatus) Descriptor() ([]byte, []int) {
-	return file_gripql_proto_rawDescGZIP(), []int{43}
+	return file_gripql_proto_rawDescGZIP(), []int{56}
 }
 
 func (x *PluginStatus) GetName() string {
@@ -3371,7 +4576,7 @@ type ListDriversResponse struct {
 func (x *ListDriversResponse) Reset() {
 	*x = ListDriversResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_gripql_proto_msgTypes[44]
+		mi := &file_gripql_proto_msgTypes[57]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -3384,7 +4589,7 @@ func (x *ListDriversResponse) String() string {
 func (*ListDriversResponse) ProtoMessage() {}
 
 func (x *ListDriversResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_gripql_proto_msgTypes[44]
+	mi := &file_gripql_proto_msgTypes[57]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3397,7 +4602,7 @@ func (x *ListDriversResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListDriversResponse.ProtoReflect.Descriptor instead.
 func (*ListDriversResponse) Descriptor() ([]byte, []int) {
-	return file_gripql_proto_rawDescGZIP(), []int{44}
+	return file_gripql_proto_rawDescGZIP(), []int{57}
 }
 
 func (x *ListDriversResponse) GetDrivers() []string {
@@ -3418,7 +4623,7 @@ type ListPluginsResponse struct {
 func (x *ListPluginsResponse) Reset() {
 	*x = ListPluginsResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_gripql_proto_msgTypes[45]
+		mi := &file_gripql_proto_msgTypes[58]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -3431,7 +4636,7 @@ func (x *ListPluginsResponse) String() string {
 func (*ListPluginsResponse) ProtoMessage() {}
 
 func (x *ListPluginsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_gripql_proto_msgTypes[45]
+	mi := &file_gripql_proto_msgTypes[58]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3444,7 +4649,7 @@ func (x *ListPluginsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListPluginsResponse.ProtoReflect.Descriptor instead.
 func (*ListPluginsResponse) Descriptor() ([]byte, []int) {
-	return file_gripql_proto_rawDescGZIP(), []int{45}
+	return file_gripql_proto_rawDescGZIP(), []int{58}
 }
 
 func (x *ListPluginsResponse) GetPlugins() []string {
@@ -3469,486 +4674,659 @@ var file_gripql_proto_rawDesc = []byte{
 	0x74, 0x65, 0x78, 0x52, 0x08, 0x76, 0x65, 0x72, 0x74, 0x69, 0x63, 0x65, 0x73, 0x12, 0x22, 0x0a,
 	0x05, 0x65, 0x64, 0x67, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x67,
 	0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e, 0x45, 0x64, 0x67, 0x65, 0x52, 0x05, 0x65, 0x64, 0x67, 0x65,
-	0x73, 0x22, 0x50, 0x0a, 0x0a, 0x47, 0x72, 0x61, 0x70, 0x68, 0x51, 0x75, 0x65, 0x72, 0x79, 0x12,
-	0x14, 0x0a, 0x05, 0x67, 0x72, 0x61, 0x70, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
-	0x67, 0x72, 0x61, 0x70, 0x68, 0x12, 0x2c, 0x0a, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x18, 0x02,
-	0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e, 0x47, 0x72,
-	0x61, 0x70, 0x68, 0x53, 0x74, 0x61, 0x74, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x05, 0x71, 0x75,
-	0x65, 0x72, 0x79, 0x22, 0x38, 0x0a, 0x08, 0x51, 0x75, 0x65, 0x72, 0x79, 0x53, 0x65, 0x74, 0x12,
-	0x2c, 0x0a, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16,
-	0x2e, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e, 0x47, 0x72, 0x61, 0x70, 0x68, 0x53, 0x74, 0x61,
-	0x74, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x22, 0xba, 0x0b,
-	0x0a, 0x0e, 0x47, 0x72, 0x61, 0x70, 0x68, 0x53, 0x74, 0x61, 0x74, 0x65, 0x6d, 0x65, 0x6e, 0x74,
-	0x12, 0x2a, 0x0a, 0x01, 0x76, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f,
-	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x4c, 0x69,
-	0x73, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x48, 0x00, 0x52, 0x01, 0x76, 0x12, 0x2a, 0x0a, 0x01,
-	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x56, 0x61,
-	0x6c, 0x75, 0x65, 0x48, 0x00, 0x52, 0x01, 0x65, 0x12, 0x2c, 0x0a, 0x02, 0x69, 0x6e, 0x18, 0x0a,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65,
-	0x48, 0x00, 0x52, 0x02, 0x69, 0x6e, 0x12, 0x2e, 0x0a, 0x03, 0x6f, 0x75, 0x74, 0x18, 0x0b, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x48,
-	0x00, 0x52, 0x03, 0x6f, 0x75, 0x74, 0x12, 0x30, 0x0a, 0x04, 0x62, 0x6f, 0x74, 0x68, 0x18, 0x0e,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65,
-	0x48, 0x00, 0x52, 0x04, 0x62, 0x6f, 0x74, 0x68, 0x12, 0x2f, 0x0a, 0x04, 0x69, 0x6e, 0x5f, 0x65,
-	0x18, 0x0c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x56, 0x61, 0x6c,
-	0x75, 0x65, 0x48, 0x00, 0x52, 0x03, 0x69, 0x6e, 0x45, 0x12, 0x31, 0x0a, 0x05, 0x6f, 0x75, 0x74,
-	0x5f, 0x65, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
-	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x56,
-	0x61, 0x6c, 0x75, 0x65, 0x48, 0x00, 0x52, 0x04, 0x6f, 0x75, 0x74, 0x45, 0x12, 0x33, 0x0a, 0x06,
-	0x62, 0x6f, 0x74, 0x68, 0x5f, 0x65, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67,
-	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x4c,
-	0x69, 0x73, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x48, 0x00, 0x52, 0x05, 0x62, 0x6f, 0x74, 0x68,
-	0x45, 0x12, 0x35, 0x0a, 0x07, 0x69, 0x6e, 0x5f, 0x6e, 0x75, 0x6c, 0x6c, 0x18, 0x10, 0x20, 0x01,
+	0x73, 0x22, 0xc7, 0x01, 0x0a, 0x0a, 0x47, 0x72, 0x61, 0x70, 0x68, 0x51, 0x75, 0x65, 0x72, 0x79,
+	0x12, 0x14, 0x0a, 0x05, 0x67, 0x72, 0x61, 0x70, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x67, 0x72, 0x61, 0x70, 0x68, 0x12, 0x2c, 0x0a, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x18,
+	0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e, 0x47,
+	0x72, 0x61, 0x70, 0x68, 0x53, 0x74, 0x61, 0x74, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x05, 0x71,
+	0x75, 0x65, 0x72, 0x79, 0x12, 0x20, 0x0a, 0x0b, 0x70, 0x61, 0x72, 0x61, 0x6c, 0x6c, 0x65, 0x6c,
+	0x69, 0x73, 0x6d, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b, 0x70, 0x61, 0x72, 0x61, 0x6c,
+	0x6c, 0x65, 0x6c, 0x69, 0x73, 0x6d, 0x12, 0x25, 0x0a, 0x0e, 0x70, 0x72, 0x65, 0x73, 0x65, 0x72,
+	0x76, 0x65, 0x5f, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d,
+	0x70, 0x72, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x12, 0x2c, 0x0a,
+	0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x14, 0x2e,
+	0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x46, 0x6f, 0x72,
+	0x6d, 0x61, 0x74, 0x52, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x22, 0xb3, 0x01, 0x0a, 0x0e,
+	0x47, 0x72, 0x61, 0x70, 0x68, 0x51, 0x75, 0x65, 0x72, 0x79, 0x54, 0x65, 0x78, 0x74, 0x12, 0x14,
+	0x0a, 0x05, 0x67, 0x72, 0x61, 0x70, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x67,
+	0x72, 0x61, 0x70, 0x68, 0x12, 0x14, 0x0a, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x12, 0x20, 0x0a, 0x0b, 0x70, 0x61,
+	0x72, 0x61, 0x6c, 0x6c, 0x65, 0x6c, 0x69, 0x73, 0x6d, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x0b, 0x70, 0x61, 0x72, 0x61, 0x6c, 0x6c, 0x65, 0x6c, 0x69, 0x73, 0x6d, 0x12, 0x25, 0x0a, 0x0e,
+	0x70, 0x72, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x5f, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x70, 0x72, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x4f, 0x72,
+	0x64, 0x65, 0x72, 0x12, 0x2c, 0x0a, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x14, 0x2e, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e, 0x52, 0x65, 0x73,
+	0x75, 0x6c, 0x74, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x52, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61,
+	0x74, 0x22, 0x38, 0x0a, 0x08, 0x51, 0x75, 0x65, 0x72, 0x79, 0x53, 0x65, 0x74, 0x12, 0x2c, 0x0a,
+	0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x67,
+	0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e, 0x47, 0x72, 0x61, 0x70, 0x68, 0x53, 0x74, 0x61, 0x74, 0x65,
+	0x6d, 0x65, 0x6e, 0x74, 0x52, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x22, 0xc7, 0x0e, 0x0a, 0x0e,
+	0x47, 0x72, 0x61, 0x70, 0x68, 0x53, 0x74, 0x61, 0x74, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x2a,
+	0x0a, 0x01, 0x76, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x4c, 0x69, 0x73, 0x74,
+	0x56, 0x61, 0x6c, 0x75, 0x65, 0x48, 0x00, 0x52, 0x01, 0x76, 0x12, 0x2a, 0x0a, 0x01, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x56, 0x61, 0x6c, 0x75,
+	0x65, 0x48, 0x00, 0x52, 0x01, 0x65, 0x12, 0x2c, 0x0a, 0x02, 0x69, 0x6e, 0x18, 0x0a, 0x20, 0x01,
 	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
 	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x48, 0x00,
-	0x52, 0x06, 0x69, 0x6e, 0x4e, 0x75, 0x6c, 0x6c, 0x12, 0x37, 0x0a, 0x08, 0x6f, 0x75, 0x74, 0x5f,
-	0x6e, 0x75, 0x6c, 0x6c, 0x18, 0x11, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f,
-	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x4c, 0x69, 0x73,
-	0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x48, 0x00, 0x52, 0x07, 0x6f, 0x75, 0x74, 0x4e, 0x75, 0x6c,
-	0x6c, 0x12, 0x38, 0x0a, 0x09, 0x69, 0x6e, 0x5f, 0x65, 0x5f, 0x6e, 0x75, 0x6c, 0x6c, 0x18, 0x12,
+	0x52, 0x02, 0x69, 0x6e, 0x12, 0x2e, 0x0a, 0x03, 0x6f, 0x75, 0x74, 0x18, 0x0b, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x48, 0x00, 0x52,
+	0x03, 0x6f, 0x75, 0x74, 0x12, 0x30, 0x0a, 0x04, 0x62, 0x6f, 0x74, 0x68, 0x18, 0x0e, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x48, 0x00,
+	0x52, 0x04, 0x62, 0x6f, 0x74, 0x68, 0x12, 0x2f, 0x0a, 0x04, 0x69, 0x6e, 0x5f, 0x65, 0x18, 0x0c,
 	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
 	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65,
-	0x48, 0x00, 0x52, 0x07, 0x69, 0x6e, 0x45, 0x4e, 0x75, 0x6c, 0x6c, 0x12, 0x3a, 0x0a, 0x0a, 0x6f,
-	0x75, 0x74, 0x5f, 0x65, 0x5f, 0x6e, 0x75, 0x6c, 0x6c, 0x18, 0x13, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
-	0x66, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x48, 0x00, 0x52, 0x08, 0x6f,
-	0x75, 0x74, 0x45, 0x4e, 0x75, 0x6c, 0x6c, 0x12, 0x10, 0x0a, 0x02, 0x61, 0x73, 0x18, 0x14, 0x20,
-	0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x02, 0x61, 0x73, 0x12, 0x31, 0x0a, 0x06, 0x73, 0x65, 0x6c,
-	0x65, 0x63, 0x74, 0x18, 0x15, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x67, 0x72, 0x69, 0x70,
-	0x71, 0x6c, 0x2e, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x53, 0x74, 0x61, 0x74, 0x65, 0x6d, 0x65,
-	0x6e, 0x74, 0x48, 0x00, 0x52, 0x06, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x12, 0x16, 0x0a, 0x05,
-	0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x18, 0x20, 0x01, 0x28, 0x0d, 0x48, 0x00, 0x52, 0x05, 0x6c,
-	0x69, 0x6d, 0x69, 0x74, 0x12, 0x14, 0x0a, 0x04, 0x73, 0x6b, 0x69, 0x70, 0x18, 0x19, 0x20, 0x01,
-	0x28, 0x0d, 0x48, 0x00, 0x52, 0x04, 0x73, 0x6b, 0x69, 0x70, 0x12, 0x25, 0x0a, 0x05, 0x72, 0x61,
-	0x6e, 0x67, 0x65, 0x18, 0x1a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x67, 0x72, 0x69, 0x70,
-	0x71, 0x6c, 0x2e, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x48, 0x00, 0x52, 0x05, 0x72, 0x61, 0x6e, 0x67,
-	0x65, 0x12, 0x29, 0x0a, 0x03, 0x68, 0x61, 0x73, 0x18, 0x1e, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15,
-	0x2e, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e, 0x48, 0x61, 0x73, 0x45, 0x78, 0x70, 0x72, 0x65,
-	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x48, 0x00, 0x52, 0x03, 0x68, 0x61, 0x73, 0x12, 0x39, 0x0a, 0x09,
-	0x68, 0x61, 0x73, 0x5f, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x18, 0x1f, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
-	0x66, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x48, 0x00, 0x52, 0x08, 0x68,
-	0x61, 0x73, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x12, 0x35, 0x0a, 0x07, 0x68, 0x61, 0x73, 0x5f, 0x6b,
-	0x65, 0x79, 0x18, 0x20, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x48, 0x00, 0x52, 0x03, 0x69, 0x6e, 0x45, 0x12, 0x31, 0x0a, 0x05, 0x6f, 0x75, 0x74, 0x5f, 0x65,
+	0x18, 0x0d, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x56, 0x61, 0x6c,
+	0x75, 0x65, 0x48, 0x00, 0x52, 0x04, 0x6f, 0x75, 0x74, 0x45, 0x12, 0x33, 0x0a, 0x06, 0x62, 0x6f,
+	0x74, 0x68, 0x5f, 0x65, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x4c, 0x69, 0x73,
+	0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x48, 0x00, 0x52, 0x05, 0x62, 0x6f, 0x74, 0x68, 0x45, 0x12,
+	0x35, 0x0a, 0x07, 0x69, 0x6e, 0x5f, 0x6e, 0x75, 0x6c, 0x6c, 0x18, 0x10, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x48, 0x00, 0x52, 0x06,
+	0x69, 0x6e, 0x4e, 0x75, 0x6c, 0x6c, 0x12, 0x37, 0x0a, 0x08, 0x6f, 0x75, 0x74, 0x5f, 0x6e, 0x75,
+	0x6c, 0x6c, 0x18, 0x11, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
 	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x56,
-	0x61, 0x6c, 0x75, 0x65, 0x48, 0x00, 0x52, 0x06, 0x68, 0x61, 0x73, 0x4b, 0x65, 0x79, 0x12, 0x33,
-	0x0a, 0x06, 0x68, 0x61, 0x73, 0x5f, 0x69, 0x64, 0x18, 0x21, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a,
-	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
-	0x2e, 0x4c, 0x69, 0x73, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x48, 0x00, 0x52, 0x05, 0x68, 0x61,
-	0x73, 0x49, 0x64, 0x12, 0x38, 0x0a, 0x08, 0x64, 0x69, 0x73, 0x74, 0x69, 0x6e, 0x63, 0x74, 0x18,
-	0x28, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x56, 0x61, 0x6c, 0x75,
-	0x65, 0x48, 0x00, 0x52, 0x08, 0x64, 0x69, 0x73, 0x74, 0x69, 0x6e, 0x63, 0x74, 0x12, 0x34, 0x0a,
-	0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x18, 0x32, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e,
+	0x61, 0x6c, 0x75, 0x65, 0x48, 0x00, 0x52, 0x07, 0x6f, 0x75, 0x74, 0x4e, 0x75, 0x6c, 0x6c, 0x12,
+	0x38, 0x0a, 0x09, 0x69, 0x6e, 0x5f, 0x65, 0x5f, 0x6e, 0x75, 0x6c, 0x6c, 0x18, 0x12, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x48, 0x00,
+	0x52, 0x07, 0x69, 0x6e, 0x45, 0x4e, 0x75, 0x6c, 0x6c, 0x12, 0x3a, 0x0a, 0x0a, 0x6f, 0x75, 0x74,
+	0x5f, 0x65, 0x5f, 0x6e, 0x75, 0x6c, 0x6c, 0x18, 0x13, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e,
 	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
-	0x4c, 0x69, 0x73, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x48, 0x00, 0x52, 0x06, 0x66, 0x69, 0x65,
-	0x6c, 0x64, 0x73, 0x12, 0x18, 0x0a, 0x06, 0x75, 0x6e, 0x77, 0x69, 0x6e, 0x64, 0x18, 0x33, 0x20,
-	0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x06, 0x75, 0x6e, 0x77, 0x69, 0x6e, 0x64, 0x12, 0x16, 0x0a,
-	0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x3c, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x05,
-	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x34, 0x0a, 0x09, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61,
-	0x74, 0x65, 0x18, 0x3d, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x67, 0x72, 0x69, 0x70, 0x71,
-	0x6c, 0x2e, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x48, 0x00,
-	0x52, 0x09, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x12, 0x30, 0x0a, 0x06, 0x72,
-	0x65, 0x6e, 0x64, 0x65, 0x72, 0x18, 0x3e, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x67, 0x6f,
-	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x56, 0x61,
-	0x6c, 0x75, 0x65, 0x48, 0x00, 0x52, 0x06, 0x72, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x12, 0x30, 0x0a,
-	0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x3f, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f,
-	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x4c, 0x69,
-	0x73, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x48, 0x00, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x12,
-	0x14, 0x0a, 0x04, 0x6d, 0x61, 0x72, 0x6b, 0x18, 0x46, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52,
-	0x04, 0x6d, 0x61, 0x72, 0x6b, 0x12, 0x22, 0x0a, 0x04, 0x6a, 0x75, 0x6d, 0x70, 0x18, 0x47, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e, 0x4a, 0x75, 0x6d,
-	0x70, 0x48, 0x00, 0x52, 0x04, 0x6a, 0x75, 0x6d, 0x70, 0x12, 0x1f, 0x0a, 0x03, 0x73, 0x65, 0x74,
-	0x18, 0x48, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e,
-	0x53, 0x65, 0x74, 0x48, 0x00, 0x52, 0x03, 0x73, 0x65, 0x74, 0x12, 0x31, 0x0a, 0x09, 0x69, 0x6e,
-	0x63, 0x72, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x49, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e,
-	0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e, 0x49, 0x6e, 0x63, 0x72, 0x65, 0x6d, 0x65, 0x6e, 0x74,
-	0x48, 0x00, 0x52, 0x09, 0x69, 0x6e, 0x63, 0x72, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x42, 0x0b, 0x0a,
-	0x09, 0x73, 0x74, 0x61, 0x74, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x22, 0x31, 0x0a, 0x05, 0x52, 0x61,
-	0x6e, 0x67, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x05, 0x52, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x74, 0x6f,
-	0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x73, 0x74, 0x6f, 0x70, 0x22, 0x62, 0x0a,
-	0x13, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x67, 0x72, 0x61, 0x70, 0x68, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x05, 0x67, 0x72, 0x61, 0x70, 0x68, 0x12, 0x35, 0x0a, 0x0c, 0x61, 0x67,
-	0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b,
-	0x32, 0x11, 0x2e, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67,
-	0x61, 0x74, 0x65, 0x52, 0x0c, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e,
-	0x73, 0x22, 0x45, 0x0a, 0x0c, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e,
-	0x73, 0x12, 0x35, 0x0a, 0x0c, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e,
-	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c,
-	0x2e, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x52, 0x0c, 0x61, 0x67, 0x67, 0x72,
-	0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0xef, 0x02, 0x0a, 0x09, 0x41, 0x67, 0x67,
-	0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x2d, 0x0a, 0x04, 0x74, 0x65,
-	0x72, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x67, 0x72, 0x69, 0x70, 0x71,
-	0x6c, 0x2e, 0x54, 0x65, 0x72, 0x6d, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f,
-	0x6e, 0x48, 0x00, 0x52, 0x04, 0x74, 0x65, 0x72, 0x6d, 0x12, 0x3f, 0x0a, 0x0a, 0x70, 0x65, 0x72,
-	0x63, 0x65, 0x6e, 0x74, 0x69, 0x6c, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e,
-	0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e, 0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x69, 0x6c,
-	0x65, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x00, 0x52, 0x0a,
-	0x70, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x69, 0x6c, 0x65, 0x12, 0x3c, 0x0a, 0x09, 0x68, 0x69,
-	0x73, 0x74, 0x6f, 0x67, 0x72, 0x61, 0x6d, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e,
-	0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x67, 0x72, 0x61, 0x6d,
-	0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x00, 0x52, 0x09, 0x68,
-	0x69, 0x73, 0x74, 0x6f, 0x67, 0x72, 0x61, 0x6d, 0x12, 0x30, 0x0a, 0x05, 0x66, 0x69, 0x65, 0x6c,
-	0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c,
-	0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f,
-	0x6e, 0x48, 0x00, 0x52, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x2d, 0x0a, 0x04, 0x74, 0x79,
-	0x70, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x67, 0x72, 0x69, 0x70, 0x71,
-	0x6c, 0x2e, 0x54, 0x79, 0x70, 0x65, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f,
-	0x6e, 0x48, 0x00, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x30, 0x0a, 0x05, 0x63, 0x6f, 0x75,
-	0x6e, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x67, 0x72, 0x69, 0x70, 0x71,
-	0x6c, 0x2e, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69,
-	0x6f, 0x6e, 0x48, 0x00, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x42, 0x0d, 0x0a, 0x0b, 0x61,
-	0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x3b, 0x0a, 0x0f, 0x54, 0x65,
-	0x72, 0x6d, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a,
-	0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x66, 0x69,
-	0x65, 0x6c, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x0d, 0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x22, 0x49, 0x0a, 0x15, 0x50, 0x65, 0x72, 0x63, 0x65,
-	0x6e, 0x74, 0x69, 0x6c, 0x65, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e,
-	0x12, 0x14, 0x0a, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x65, 0x72, 0x63, 0x65, 0x6e,
-	0x74, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x01, 0x52, 0x08, 0x70, 0x65, 0x72, 0x63, 0x65, 0x6e,
-	0x74, 0x73, 0x22, 0x48, 0x0a, 0x14, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x67, 0x72, 0x61, 0x6d, 0x41,
-	0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x69,
-	0x65, 0x6c, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64,
-	0x12, 0x1a, 0x0a, 0x08, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x0d, 0x52, 0x08, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x22, 0x28, 0x0a, 0x10,
-	0x46, 0x69, 0x65, 0x6c, 0x64, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e,
-	0x12, 0x14, 0x0a, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x22, 0x27, 0x0a, 0x0f, 0x54, 0x79, 0x70, 0x65, 0x41, 0x67,
-	0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x69, 0x65,
-	0x6c, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x22,
-	0x12, 0x0a, 0x10, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74,
-	0x69, 0x6f, 0x6e, 0x22, 0x6c, 0x0a, 0x16, 0x4e, 0x61, 0x6d, 0x65, 0x64, 0x41, 0x67, 0x67, 0x72,
-	0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x12, 0x0a,
-	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d,
-	0x65, 0x12, 0x28, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16,
-	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
-	0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76,
-	0x61, 0x6c, 0x75, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75,
-	0x65, 0x22, 0x4c, 0x0a, 0x11, 0x48, 0x61, 0x73, 0x45, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69,
-	0x6f, 0x6e, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x37, 0x0a, 0x0b, 0x65, 0x78, 0x70, 0x72, 0x65, 0x73,
-	0x73, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x67, 0x72,
-	0x69, 0x70, 0x71, 0x6c, 0x2e, 0x48, 0x61, 0x73, 0x45, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69,
-	0x6f, 0x6e, 0x52, 0x0b, 0x65, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x22,
-	0xda, 0x01, 0x0a, 0x0d, 0x48, 0x61, 0x73, 0x45, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f,
-	0x6e, 0x12, 0x2d, 0x0a, 0x03, 0x61, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19,
-	0x2e, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e, 0x48, 0x61, 0x73, 0x45, 0x78, 0x70, 0x72, 0x65,
-	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x4c, 0x69, 0x73, 0x74, 0x48, 0x00, 0x52, 0x03, 0x61, 0x6e, 0x64,
-	0x12, 0x2b, 0x0a, 0x02, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67,
+	0x4c, 0x69, 0x73, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x48, 0x00, 0x52, 0x08, 0x6f, 0x75, 0x74,
+	0x45, 0x4e, 0x75, 0x6c, 0x6c, 0x12, 0x10, 0x0a, 0x02, 0x61, 0x73, 0x18, 0x14, 0x20, 0x01, 0x28,
+	0x09, 0x48, 0x00, 0x52, 0x02, 0x61, 0x73, 0x12, 0x31, 0x0a, 0x06, 0x73, 0x65, 0x6c, 0x65, 0x63,
+	0x74, 0x18, 0x15, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c,
+	0x2e, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x53, 0x74, 0x61, 0x74, 0x65, 0x6d, 0x65, 0x6e, 0x74,
+	0x48, 0x00, 0x52, 0x06, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x12, 0x16, 0x0a, 0x05, 0x6c, 0x69,
+	0x6d, 0x69, 0x74, 0x18, 0x18, 0x20, 0x01, 0x28, 0x0d, 0x48, 0x00, 0x52, 0x05, 0x6c, 0x69, 0x6d,
+	0x69, 0x74, 0x12, 0x14, 0x0a, 0x04, 0x73, 0x6b, 0x69, 0x70, 0x18, 0x19, 0x20, 0x01, 0x28, 0x0d,
+	0x48, 0x00, 0x52, 0x04, 0x73, 0x6b, 0x69, 0x70, 0x12, 0x25, 0x0a, 0x05, 0x72, 0x61, 0x6e, 0x67,
+	0x65, 0x18, 0x1a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c,
+	0x2e, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x48, 0x00, 0x52, 0x05, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x12,
+	0x29, 0x0a, 0x03, 0x68, 0x61, 0x73, 0x18, 0x1e, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x67,
 	0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e, 0x48, 0x61, 0x73, 0x45, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73,
-	0x69, 0x6f, 0x6e, 0x4c, 0x69, 0x73, 0x74, 0x48, 0x00, 0x52, 0x02, 0x6f, 0x72, 0x12, 0x29, 0x0a,
-	0x03, 0x6e, 0x6f, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x67, 0x72, 0x69,
-	0x70, 0x71, 0x6c, 0x2e, 0x48, 0x61, 0x73, 0x45, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f,
-	0x6e, 0x48, 0x00, 0x52, 0x03, 0x6e, 0x6f, 0x74, 0x12, 0x34, 0x0a, 0x09, 0x63, 0x6f, 0x6e, 0x64,
-	0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x67, 0x72,
-	0x69, 0x70, 0x71, 0x6c, 0x2e, 0x48, 0x61, 0x73, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f,
-	0x6e, 0x48, 0x00, 0x52, 0x09, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x0c,
-	0x0a, 0x0a, 0x65, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x7f, 0x0a, 0x0c,
-	0x48, 0x61, 0x73, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x10, 0x0a, 0x03,
-	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x2c,
-	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e,
+	0x69, 0x6f, 0x6e, 0x48, 0x00, 0x52, 0x03, 0x68, 0x61, 0x73, 0x12, 0x39, 0x0a, 0x09, 0x68, 0x61,
+	0x73, 0x5f, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x18, 0x1f, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e,
 	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
-	0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x2f, 0x0a, 0x09,
-	0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32,
-	0x11, 0x2e, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69,
-	0x6f, 0x6e, 0x52, 0x09, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x27, 0x0a,
-	0x0f, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x53, 0x74, 0x61, 0x74, 0x65, 0x6d, 0x65, 0x6e, 0x74,
-	0x12, 0x14, 0x0a, 0x05, 0x6d, 0x61, 0x72, 0x6b, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52,
-	0x05, 0x6d, 0x61, 0x72, 0x6b, 0x73, 0x22, 0x63, 0x0a, 0x09, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74,
-	0x69, 0x6f, 0x6e, 0x12, 0x28, 0x0a, 0x06, 0x76, 0x65, 0x72, 0x74, 0x65, 0x78, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e, 0x56, 0x65, 0x72,
-	0x74, 0x65, 0x78, 0x48, 0x00, 0x52, 0x06, 0x76, 0x65, 0x72, 0x74, 0x65, 0x78, 0x12, 0x22, 0x0a,
-	0x04, 0x65, 0x64, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x67, 0x72,
-	0x69, 0x70, 0x71, 0x6c, 0x2e, 0x45, 0x64, 0x67, 0x65, 0x48, 0x00, 0x52, 0x04, 0x65, 0x64, 0x67,
-	0x65, 0x42, 0x08, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x22, 0xa2, 0x01, 0x0a, 0x0a,
-	0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x42, 0x0a, 0x0a, 0x73, 0x65,
-	0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x22,
-	0x2e, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f,
-	0x6e, 0x73, 0x2e, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x45, 0x6e, 0x74,
-	0x72, 0x79, 0x52, 0x0a, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x1a, 0x50,
-	0x0a, 0x0f, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x45, 0x6e, 0x74, 0x72,
-	0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
-	0x6b, 0x65, 0x79, 0x12, 0x27, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x11, 0x2e, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e, 0x53, 0x65, 0x6c, 0x65,
-	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01,
-	0x22, 0x65, 0x0a, 0x04, 0x4a, 0x75, 0x6d, 0x70, 0x12, 0x12, 0x0a, 0x04, 0x6d, 0x61, 0x72, 0x6b,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6d, 0x61, 0x72, 0x6b, 0x12, 0x35, 0x0a, 0x0a,
-	0x65, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x15, 0x2e, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e, 0x48, 0x61, 0x73, 0x45, 0x78, 0x70,
-	0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x65, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73,
-	0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x65, 0x6d, 0x69, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x08, 0x52, 0x04, 0x65, 0x6d, 0x69, 0x74, 0x22, 0x45, 0x0a, 0x03, 0x53, 0x65, 0x74, 0x12, 0x10,
-	0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79,
-	0x12, 0x2c, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x4c, 0x69, 0x73, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x48, 0x00, 0x52, 0x08, 0x68, 0x61, 0x73,
+	0x4c, 0x61, 0x62, 0x65, 0x6c, 0x12, 0x35, 0x0a, 0x07, 0x68, 0x61, 0x73, 0x5f, 0x6b, 0x65, 0x79,
+	0x18, 0x20, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x56, 0x61, 0x6c,
+	0x75, 0x65, 0x48, 0x00, 0x52, 0x06, 0x68, 0x61, 0x73, 0x4b, 0x65, 0x79, 0x12, 0x33, 0x0a, 0x06,
+	0x68, 0x61, 0x73, 0x5f, 0x69, 0x64, 0x18, 0x21, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x4c,
+	0x69, 0x73, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x48, 0x00, 0x52, 0x05, 0x68, 0x61, 0x73, 0x49,
+	0x64, 0x12, 0x38, 0x0a, 0x08, 0x64, 0x69, 0x73, 0x74, 0x69, 0x6e, 0x63, 0x74, 0x18, 0x28, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x48,
+	0x00, 0x52, 0x08, 0x64, 0x69, 0x73, 0x74, 0x69, 0x6e, 0x63, 0x74, 0x12, 0x30, 0x0a, 0x04, 0x73,
+	0x6f, 0x72, 0x74, 0x18, 0x29, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x4c, 0x69, 0x73, 0x74,
+	0x56, 0x61, 0x6c, 0x75, 0x65, 0x48, 0x00, 0x52, 0x04, 0x73, 0x6f, 0x72, 0x74, 0x12, 0x22, 0x0a,
+	0x04, 0x73, 0x65, 0x65, 0x6b, 0x18, 0x2a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x67, 0x72,
+	0x69, 0x70, 0x71, 0x6c, 0x2e, 0x53, 0x65, 0x65, 0x6b, 0x48, 0x00, 0x52, 0x04, 0x73, 0x65, 0x65,
+	0x6b, 0x12, 0x34, 0x0a, 0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x18, 0x32, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x48, 0x00, 0x52,
+	0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x12, 0x18, 0x0a, 0x06, 0x75, 0x6e, 0x77, 0x69, 0x6e,
+	0x64, 0x18, 0x33, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x06, 0x75, 0x6e, 0x77, 0x69, 0x6e,
+	0x64, 0x12, 0x16, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x3c, 0x20, 0x01, 0x28, 0x09,
+	0x48, 0x00, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x34, 0x0a, 0x09, 0x61, 0x67, 0x67,
+	0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x18, 0x3d, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x67,
+	0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x48, 0x00, 0x52, 0x09, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x12,
+	0x30, 0x0a, 0x06, 0x72, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x18, 0x3e, 0x20, 0x01, 0x28, 0x0b, 0x32,
 	0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
-	0x66, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x33,
-	0x0a, 0x09, 0x49, 0x6e, 0x63, 0x72, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6b,
-	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a,
-	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x76, 0x61,
-	0x6c, 0x75, 0x65, 0x22, 0x5d, 0x0a, 0x06, 0x56, 0x65, 0x72, 0x74, 0x65, 0x78, 0x12, 0x10, 0x0a,
-	0x03, 0x67, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x67, 0x69, 0x64, 0x12,
-	0x14, 0x0a, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
-	0x6c, 0x61, 0x62, 0x65, 0x6c, 0x12, 0x2b, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x03, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x52, 0x04, 0x64, 0x61,
-	0x74, 0x61, 0x22, 0x7f, 0x0a, 0x04, 0x45, 0x64, 0x67, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x67, 0x69,
-	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x67, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05,
-	0x6c, 0x61, 0x62, 0x65, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6c, 0x61, 0x62,
-	0x65, 0x6c, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x12, 0x0e, 0x0a, 0x02, 0x74, 0x6f, 0x18, 0x04, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x02, 0x74, 0x6f, 0x12, 0x2b, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x05,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x52, 0x04, 0x64,
-	0x61, 0x74, 0x61, 0x22, 0xdd, 0x02, 0x0a, 0x0b, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x73,
-	0x75, 0x6c, 0x74, 0x12, 0x28, 0x0a, 0x06, 0x76, 0x65, 0x72, 0x74, 0x65, 0x78, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e, 0x56, 0x65, 0x72,
-	0x74, 0x65, 0x78, 0x48, 0x00, 0x52, 0x06, 0x76, 0x65, 0x72, 0x74, 0x65, 0x78, 0x12, 0x22, 0x0a,
-	0x04, 0x65, 0x64, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x67, 0x72,
-	0x69, 0x70, 0x71, 0x6c, 0x2e, 0x45, 0x64, 0x67, 0x65, 0x48, 0x00, 0x52, 0x04, 0x65, 0x64, 0x67,
-	0x65, 0x12, 0x44, 0x0a, 0x0c, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e,
-	0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c,
-	0x2e, 0x4e, 0x61, 0x6d, 0x65, 0x64, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f,
-	0x6e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x48, 0x00, 0x52, 0x0c, 0x61, 0x67, 0x67, 0x72, 0x65,
-	0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x34, 0x0a, 0x0a, 0x73, 0x65, 0x6c, 0x65, 0x63,
-	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x67, 0x72,
-	0x69, 0x70, 0x71, 0x6c, 0x2e, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x48,
-	0x00, 0x52, 0x0a, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x30, 0x0a,
-	0x06, 0x72, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e,
+	0x66, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x48, 0x00, 0x52, 0x06, 0x72, 0x65, 0x6e, 0x64, 0x65,
+	0x72, 0x12, 0x30, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x3f, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x48, 0x00, 0x52, 0x04, 0x70,
+	0x61, 0x74, 0x68, 0x12, 0x25, 0x0a, 0x05, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x18, 0x40, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e, 0x47, 0x72, 0x6f, 0x75,
+	0x70, 0x48, 0x00, 0x52, 0x05, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x12, 0x2c, 0x0a, 0x04, 0x66, 0x6f,
+	0x6c, 0x64, 0x18, 0x41, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65,
+	0x48, 0x00, 0x52, 0x04, 0x66, 0x6f, 0x6c, 0x64, 0x12, 0x14, 0x0a, 0x04, 0x6d, 0x61, 0x72, 0x6b,
+	0x18, 0x46, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x04, 0x6d, 0x61, 0x72, 0x6b, 0x12, 0x22,
+	0x0a, 0x04, 0x6a, 0x75, 0x6d, 0x70, 0x18, 0x47, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x67,
+	0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e, 0x4a, 0x75, 0x6d, 0x70, 0x48, 0x00, 0x52, 0x04, 0x6a, 0x75,
+	0x6d, 0x70, 0x12, 0x1f, 0x0a, 0x03, 0x73, 0x65, 0x74, 0x18, 0x48, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x0b, 0x2e, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e, 0x53, 0x65, 0x74, 0x48, 0x00, 0x52, 0x03,
+	0x73, 0x65, 0x74, 0x12, 0x31, 0x0a, 0x09, 0x69, 0x6e, 0x63, 0x72, 0x65, 0x6d, 0x65, 0x6e, 0x74,
+	0x18, 0x49, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e,
+	0x49, 0x6e, 0x63, 0x72, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x48, 0x00, 0x52, 0x09, 0x69, 0x6e, 0x63,
+	0x72, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x28, 0x0a, 0x05, 0x77, 0x68, 0x65, 0x72, 0x65, 0x18,
+	0x50, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e, 0x53,
+	0x75, 0x62, 0x51, 0x75, 0x65, 0x72, 0x79, 0x48, 0x00, 0x52, 0x05, 0x77, 0x68, 0x65, 0x72, 0x65,
+	0x12, 0x24, 0x0a, 0x03, 0x6e, 0x6f, 0x74, 0x18, 0x51, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e,
+	0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e, 0x53, 0x75, 0x62, 0x51, 0x75, 0x65, 0x72, 0x79, 0x48,
+	0x00, 0x52, 0x03, 0x6e, 0x6f, 0x74, 0x12, 0x2c, 0x0a, 0x05, 0x75, 0x6e, 0x69, 0x6f, 0x6e, 0x18,
+	0x52, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e, 0x53,
+	0x75, 0x62, 0x51, 0x75, 0x65, 0x72, 0x79, 0x4c, 0x69, 0x73, 0x74, 0x48, 0x00, 0x52, 0x05, 0x75,
+	0x6e, 0x69, 0x6f, 0x6e, 0x12, 0x2e, 0x0a, 0x08, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c,
+	0x18, 0x53, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e,
+	0x53, 0x75, 0x62, 0x51, 0x75, 0x65, 0x72, 0x79, 0x48, 0x00, 0x52, 0x08, 0x6f, 0x70, 0x74, 0x69,
+	0x6f, 0x6e, 0x61, 0x6c, 0x12, 0x32, 0x0a, 0x08, 0x63, 0x6f, 0x61, 0x6c, 0x65, 0x73, 0x63, 0x65,
+	0x18, 0x54, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e,
+	0x53, 0x75, 0x62, 0x51, 0x75, 0x65, 0x72, 0x79, 0x4c, 0x69, 0x73, 0x74, 0x48, 0x00, 0x52, 0x08,
+	0x63, 0x6f, 0x61, 0x6c, 0x65, 0x73, 0x63, 0x65, 0x42, 0x0b, 0x0a, 0x09, 0x73, 0x74, 0x61, 0x74,
+	0x65, 0x6d, 0x65, 0x6e, 0x74, 0x22, 0x38, 0x0a, 0x08, 0x53, 0x75, 0x62, 0x51, 0x75, 0x65, 0x72,
+	0x79, 0x12, 0x2c, 0x0a, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x16, 0x2e, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e, 0x47, 0x72, 0x61, 0x70, 0x68, 0x53,
+	0x74, 0x61, 0x74, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x22,
+	0x3a, 0x0a, 0x0c, 0x53, 0x75, 0x62, 0x51, 0x75, 0x65, 0x72, 0x79, 0x4c, 0x69, 0x73, 0x74, 0x12,
+	0x2a, 0x0a, 0x07, 0x71, 0x75, 0x65, 0x72, 0x69, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x10, 0x2e, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e, 0x53, 0x75, 0x62, 0x51, 0x75, 0x65,
+	0x72, 0x79, 0x52, 0x07, 0x71, 0x75, 0x65, 0x72, 0x69, 0x65, 0x73, 0x22, 0x47, 0x0a, 0x05, 0x47,
+	0x72, 0x6f, 0x75, 0x70, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x2c, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x22, 0x31, 0x0a, 0x05, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x14, 0x0a,
+	0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x73, 0x74,
+	0x61, 0x72, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x74, 0x6f, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x04, 0x73, 0x74, 0x6f, 0x70, 0x22, 0x52, 0x0a, 0x04, 0x53, 0x65, 0x65, 0x6b, 0x12,
+	0x16, 0x0a, 0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x12, 0x32, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x56, 0x61,
+	0x6c, 0x75, 0x65, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x22, 0x62, 0x0a, 0x13, 0x41,
+	0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x67, 0x72, 0x61, 0x70, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x67, 0x72, 0x61, 0x70, 0x68, 0x12, 0x35, 0x0a, 0x0c, 0x61, 0x67, 0x67, 0x72,
+	0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11,
+	0x2e, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74,
+	0x65, 0x52, 0x0c, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22,
+	0x45, 0x0a, 0x0c, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12,
+	0x35, 0x0a, 0x0c, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e, 0x41,
+	0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x52, 0x0c, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0xff, 0x03, 0x0a, 0x09, 0x41, 0x67, 0x67, 0x72, 0x65,
+	0x67, 0x61, 0x74, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x2d, 0x0a, 0x04, 0x74, 0x65, 0x72, 0x6d,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e,
+	0x54, 0x65, 0x72, 0x6d, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x48,
+	0x00, 0x52, 0x04, 0x74, 0x65, 0x72, 0x6d, 0x12, 0x3f, 0x0a, 0x0a, 0x70, 0x65, 0x72, 0x63, 0x65,
+	0x6e, 0x74, 0x69, 0x6c, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x67, 0x72,
+	0x69, 0x70, 0x71, 0x6c, 0x2e, 0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x69, 0x6c, 0x65, 0x41,
+	0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x00, 0x52, 0x0a, 0x70, 0x65,
+	0x72, 0x63, 0x65, 0x6e, 0x74, 0x69, 0x6c, 0x65, 0x12, 0x3c, 0x0a, 0x09, 0x68, 0x69, 0x73, 0x74,
+	0x6f, 0x67, 0x72, 0x61, 0x6d, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x67, 0x72,
+	0x69, 0x70, 0x71, 0x6c, 0x2e, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x67, 0x72, 0x61, 0x6d, 0x41, 0x67,
+	0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x00, 0x52, 0x09, 0x68, 0x69, 0x73,
+	0x74, 0x6f, 0x67, 0x72, 0x61, 0x6d, 0x12, 0x30, 0x0a, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e, 0x46,
+	0x69, 0x65, 0x6c, 0x64, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x48,
+	0x00, 0x52, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x2d, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e,
+	0x54, 0x79, 0x70, 0x65, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x48,
+	0x00, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x30, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e,
+	0x43, 0x6f, 0x75, 0x6e, 0x74, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x48, 0x00, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x49, 0x0a, 0x0e, 0x64, 0x61, 0x74,
+	0x65, 0x5f, 0x68, 0x69, 0x73, 0x74, 0x6f, 0x67, 0x72, 0x61, 0x6d, 0x18, 0x08, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x20, 0x2e, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e, 0x44, 0x61, 0x74, 0x65, 0x48,
+	0x69, 0x73, 0x74, 0x6f, 0x67, 0x72, 0x61, 0x6d, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x48, 0x00, 0x52, 0x0d, 0x64, 0x61, 0x74, 0x65, 0x48, 0x69, 0x73, 0x74, 0x6f,
+	0x67, 0x72, 0x61, 0x6d, 0x12, 0x43, 0x0a, 0x0c, 0x67, 0x65, 0x6f, 0x68, 0x61, 0x73, 0x68, 0x5f,
+	0x67, 0x72, 0x69, 0x64, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x67, 0x72, 0x69,
+	0x70, 0x71, 0x6c, 0x2e, 0x47, 0x65, 0x6f, 0x68, 0x61, 0x73, 0x68, 0x47, 0x72, 0x69, 0x64, 0x41,
+	0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x00, 0x52, 0x0b, 0x67, 0x65,
+	0x6f, 0x68, 0x61, 0x73, 0x68, 0x47, 0x72, 0x69, 0x64, 0x42, 0x0d, 0x0a, 0x0b, 0x61, 0x67, 0x67,
+	0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x3b, 0x0a, 0x0f, 0x54, 0x65, 0x72, 0x6d,
+	0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x66,
+	0x69, 0x65, 0x6c, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x66, 0x69, 0x65, 0x6c,
+	0x64, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x04, 0x73, 0x69, 0x7a, 0x65, 0x22, 0x49, 0x0a, 0x15, 0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74,
+	0x69, 0x6c, 0x65, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x14,
+	0x0a, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x66,
+	0x69, 0x65, 0x6c, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x73,
+	0x18, 0x03, 0x20, 0x03, 0x28, 0x01, 0x52, 0x08, 0x70, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x73,
+	0x22, 0x48, 0x0a, 0x14, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x67, 0x72, 0x61, 0x6d, 0x41, 0x67, 0x67,
+	0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x69, 0x65, 0x6c,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x1a,
+	0x0a, 0x08, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x08, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x22, 0x4c, 0x0a, 0x18, 0x44, 0x61,
+	0x74, 0x65, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x67, 0x72, 0x61, 0x6d, 0x41, 0x67, 0x67, 0x72, 0x65,
+	0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x1a, 0x0a, 0x08,
+	0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x22, 0x4c, 0x0a, 0x16, 0x47, 0x65, 0x6f, 0x68,
+	0x61, 0x73, 0x68, 0x47, 0x72, 0x69, 0x64, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x70, 0x72, 0x65, 0x63,
+	0x69, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x70, 0x72, 0x65,
+	0x63, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x28, 0x0a, 0x10, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x41,
+	0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x69,
+	0x65, 0x6c, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64,
+	0x22, 0x27, 0x0a, 0x0f, 0x54, 0x79, 0x70, 0x65, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x22, 0x12, 0x0a, 0x10, 0x43, 0x6f, 0x75,
+	0x6e, 0x74, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x6c, 0x0a,
+	0x16, 0x4e, 0x61, 0x6d, 0x65, 0x64, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x28, 0x0a, 0x03, 0x6b,
+	0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65,
+	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x4c, 0x0a, 0x11, 0x48,
+	0x61, 0x73, 0x45, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x4c, 0x69, 0x73, 0x74,
+	0x12, 0x37, 0x0a, 0x0b, 0x65, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e, 0x48,
+	0x61, 0x73, 0x45, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x0b, 0x65, 0x78,
+	0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0xda, 0x01, 0x0a, 0x0d, 0x48, 0x61,
+	0x73, 0x45, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x2d, 0x0a, 0x03, 0x61,
+	0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x72, 0x69, 0x70, 0x71,
+	0x6c, 0x2e, 0x48, 0x61, 0x73, 0x45, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x4c,
+	0x69, 0x73, 0x74, 0x48, 0x00, 0x52, 0x03, 0x61, 0x6e, 0x64, 0x12, 0x2b, 0x0a, 0x02, 0x6f, 0x72,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e,
+	0x48, 0x61, 0x73, 0x45, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x4c, 0x69, 0x73,
+	0x74, 0x48, 0x00, 0x52, 0x02, 0x6f, 0x72, 0x12, 0x29, 0x0a, 0x03, 0x6e, 0x6f, 0x74, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e, 0x48, 0x61,
+	0x73, 0x45, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x48, 0x00, 0x52, 0x03, 0x6e,
+	0x6f, 0x74, 0x12, 0x34, 0x0a, 0x09, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e, 0x48,
+	0x61, 0x73, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x00, 0x52, 0x09, 0x63,
+	0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x0c, 0x0a, 0x0a, 0x65, 0x78, 0x70, 0x72,
+	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x9c, 0x01, 0x0a, 0x0c, 0x48, 0x61, 0x73, 0x43, 0x6f,
+	0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x2c, 0x0a, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65,
+	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x2f, 0x0a, 0x09, 0x63, 0x6f, 0x6e, 0x64, 0x69,
+	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x11, 0x2e, 0x67, 0x72, 0x69,
+	0x70, 0x71, 0x6c, 0x2e, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x09, 0x63,
+	0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1b, 0x0a, 0x09, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x5f, 0x72, 0x65, 0x66, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x52, 0x65, 0x66, 0x22, 0x27, 0x0a, 0x0f, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x53,
+	0x74, 0x61, 0x74, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x6d, 0x61, 0x72, 0x6b,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x6d, 0x61, 0x72, 0x6b, 0x73, 0x22, 0x63,
+	0x0a, 0x09, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x28, 0x0a, 0x06, 0x76,
+	0x65, 0x72, 0x74, 0x65, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x67, 0x72,
+	0x69, 0x70, 0x71, 0x6c, 0x2e, 0x56, 0x65, 0x72, 0x74, 0x65, 0x78, 0x48, 0x00, 0x52, 0x06, 0x76,
+	0x65, 0x72, 0x74, 0x65, 0x78, 0x12, 0x22, 0x0a, 0x04, 0x65, 0x64, 0x67, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e, 0x45, 0x64, 0x67,
+	0x65, 0x48, 0x00, 0x52, 0x04, 0x65, 0x64, 0x67, 0x65, 0x42, 0x08, 0x0a, 0x06, 0x72, 0x65, 0x73,
+	0x75, 0x6c, 0x74, 0x22, 0xa2, 0x01, 0x0a, 0x0a, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x12, 0x42, 0x0a, 0x0a, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e,
+	0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x53, 0x65, 0x6c, 0x65, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0a, 0x73, 0x65, 0x6c, 0x65,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x1a, 0x50, 0x0a, 0x0f, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x27, 0x0a, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x67, 0x72, 0x69,
+	0x70, 0x71, 0x6c, 0x2e, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x65, 0x0a, 0x04, 0x4a, 0x75, 0x6d, 0x70,
+	0x12, 0x12, 0x0a, 0x04, 0x6d, 0x61, 0x72, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x6d, 0x61, 0x72, 0x6b, 0x12, 0x35, 0x0a, 0x0a, 0x65, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69,
+	0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x67, 0x72, 0x69, 0x70, 0x71,
+	0x6c, 0x2e, 0x48, 0x61, 0x73, 0x45, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52,
+	0x0a, 0x65, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x65,
+	0x6d, 0x69, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x65, 0x6d, 0x69, 0x74, 0x22,
+	0x45, 0x0a, 0x03, 0x53, 0x65, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x2c, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x33, 0x0a, 0x09, 0x49, 0x6e, 0x63, 0x72, 0x65, 0x6d,
+	0x65, 0x6e, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x5d, 0x0a, 0x06, 0x56,
+	0x65, 0x72, 0x74, 0x65, 0x78, 0x12, 0x10, 0x0a, 0x03, 0x67, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x67, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x12, 0x2b, 0x0a,
+	0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x74,
+	0x72, 0x75, 0x63, 0x74, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0x7f, 0x0a, 0x04, 0x45, 0x64,
+	0x67, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x67, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x03, 0x67, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x72,
+	0x6f, 0x6d, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x12, 0x0e,
+	0x0a, 0x02, 0x74, 0x6f, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x74, 0x6f, 0x12, 0x2b,
+	0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53,
+	0x74, 0x72, 0x75, 0x63, 0x74, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0xf5, 0x02, 0x0a, 0x0b,
+	0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x28, 0x0a, 0x06, 0x76,
+	0x65, 0x72, 0x74, 0x65, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x67, 0x72,
+	0x69, 0x70, 0x71, 0x6c, 0x2e, 0x56, 0x65, 0x72, 0x74, 0x65, 0x78, 0x48, 0x00, 0x52, 0x06, 0x76,
+	0x65, 0x72, 0x74, 0x65, 0x78, 0x12, 0x22, 0x0a, 0x04, 0x65, 0x64, 0x67, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e, 0x45, 0x64, 0x67,
+	0x65, 0x48, 0x00, 0x52, 0x04, 0x65, 0x64, 0x67, 0x65, 0x12, 0x44, 0x0a, 0x0c, 0x61, 0x67, 0x67,
+	0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1e, 0x2e, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e, 0x4e, 0x61, 0x6d, 0x65, 0x64, 0x41, 0x67,
+	0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x48,
+	0x00, 0x52, 0x0c, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12,
+	0x34, 0x0a, 0x0a, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e, 0x53, 0x65, 0x6c,
+	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x48, 0x00, 0x52, 0x0a, 0x73, 0x65, 0x6c, 0x65, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x30, 0x0a, 0x06, 0x72, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x48, 0x00, 0x52,
+	0x06, 0x72, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x0d, 0x48, 0x00, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12,
+	0x30, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e,
 	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
-	0x56, 0x61, 0x6c, 0x75, 0x65, 0x48, 0x00, 0x52, 0x06, 0x72, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x12,
-	0x16, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0d, 0x48, 0x00,
-	0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x30, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18,
-	0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x56, 0x61, 0x6c, 0x75,
-	0x65, 0x48, 0x00, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x42, 0x08, 0x0a, 0x06, 0x72, 0x65, 0x73,
-	0x75, 0x6c, 0x74, 0x22, 0x30, 0x0a, 0x08, 0x51, 0x75, 0x65, 0x72, 0x79, 0x4a, 0x6f, 0x62, 0x12,
+	0x4c, 0x69, 0x73, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x48, 0x00, 0x52, 0x04, 0x70, 0x61, 0x74,
+	0x68, 0x12, 0x16, 0x0a, 0x05, 0x61, 0x72, 0x72, 0x6f, 0x77, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0c,
+	0x48, 0x00, 0x52, 0x05, 0x61, 0x72, 0x72, 0x6f, 0x77, 0x42, 0x08, 0x0a, 0x06, 0x72, 0x65, 0x73,
+	0x75, 0x6c, 0x74, 0x22, 0x5e, 0x0a, 0x08, 0x51, 0x75, 0x65, 0x72, 0x79, 0x4a, 0x6f, 0x62, 0x12,
 	0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12,
 	0x14, 0x0a, 0x05, 0x67, 0x72, 0x61, 0x70, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
-	0x67, 0x72, 0x61, 0x70, 0x68, 0x22, 0x68, 0x0a, 0x0b, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x51,
-	0x75, 0x65, 0x72, 0x79, 0x12, 0x15, 0x0a, 0x06, 0x73, 0x72, 0x63, 0x5f, 0x69, 0x64, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x73, 0x72, 0x63, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x67,
+	0x67, 0x72, 0x61, 0x70, 0x68, 0x12, 0x2c, 0x0a, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x14, 0x2e, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e, 0x52,
+	0x65, 0x73, 0x75, 0x6c, 0x74, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x52, 0x06, 0x66, 0x6f, 0x72,
+	0x6d, 0x61, 0x74, 0x22, 0x68, 0x0a, 0x0b, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x51, 0x75, 0x65,
+	0x72, 0x79, 0x12, 0x15, 0x0a, 0x06, 0x73, 0x72, 0x63, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x73, 0x72, 0x63, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x67, 0x72, 0x61,
+	0x70, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x67, 0x72, 0x61, 0x70, 0x68, 0x12,
+	0x2c, 0x0a, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16,
+	0x2e, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e, 0x47, 0x72, 0x61, 0x70, 0x68, 0x53, 0x74, 0x61,
+	0x74, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x22, 0xfa, 0x01,
+	0x0a, 0x09, 0x4a, 0x6f, 0x62, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x0e, 0x0a, 0x02, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x67,
 	0x72, 0x61, 0x70, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x67, 0x72, 0x61, 0x70,
-	0x68, 0x12, 0x2c, 0x0a, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b,
-	0x32, 0x16, 0x2e, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e, 0x47, 0x72, 0x61, 0x70, 0x68, 0x53,
-	0x74, 0x61, 0x74, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x22,
-	0xbb, 0x01, 0x0a, 0x09, 0x4a, 0x6f, 0x62, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x0e, 0x0a,
-	0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x14, 0x0a,
-	0x05, 0x67, 0x72, 0x61, 0x70, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x67, 0x72,
-	0x61, 0x70, 0x68, 0x12, 0x26, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x0e, 0x32, 0x10, 0x2e, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e, 0x4a, 0x6f, 0x62, 0x53,
-	0x74, 0x61, 0x74, 0x65, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x63,
-	0x6f, 0x75, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e,
-	0x74, 0x12, 0x2c, 0x0a, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b,
-	0x32, 0x16, 0x2e, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e, 0x47, 0x72, 0x61, 0x70, 0x68, 0x53,
-	0x74, 0x61, 0x74, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x12,
-	0x1c, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x06, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x22, 0x1c, 0x0a,
-	0x0a, 0x45, 0x64, 0x69, 0x74, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69,
-	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0x54, 0x0a, 0x0e, 0x42,
-	0x75, 0x6c, 0x6b, 0x45, 0x64, 0x69, 0x74, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x21, 0x0a,
-	0x0c, 0x69, 0x6e, 0x73, 0x65, 0x72, 0x74, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x05, 0x52, 0x0b, 0x69, 0x6e, 0x73, 0x65, 0x72, 0x74, 0x43, 0x6f, 0x75, 0x6e, 0x74,
-	0x12, 0x1f, 0x0a, 0x0b, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x75, 0x6e,
-	0x74, 0x22, 0x6e, 0x0a, 0x0c, 0x47, 0x72, 0x61, 0x70, 0x68, 0x45, 0x6c, 0x65, 0x6d, 0x65, 0x6e,
-	0x74, 0x12, 0x14, 0x0a, 0x05, 0x67, 0x72, 0x61, 0x70, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x05, 0x67, 0x72, 0x61, 0x70, 0x68, 0x12, 0x26, 0x0a, 0x06, 0x76, 0x65, 0x72, 0x74, 0x65,
-	0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c,
-	0x2e, 0x56, 0x65, 0x72, 0x74, 0x65, 0x78, 0x52, 0x06, 0x76, 0x65, 0x72, 0x74, 0x65, 0x78, 0x12,
-	0x20, 0x0a, 0x04, 0x65, 0x64, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e,
-	0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e, 0x45, 0x64, 0x67, 0x65, 0x52, 0x04, 0x65, 0x64, 0x67,
-	0x65, 0x22, 0x1f, 0x0a, 0x07, 0x47, 0x72, 0x61, 0x70, 0x68, 0x49, 0x44, 0x12, 0x14, 0x0a, 0x05,
-	0x67, 0x72, 0x61, 0x70, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x67, 0x72, 0x61,
-	0x70, 0x68, 0x22, 0x31, 0x0a, 0x09, 0x45, 0x6c, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x49, 0x44, 0x12,
+	0x68, 0x12, 0x26, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x10, 0x2e, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e, 0x4a, 0x6f, 0x62, 0x53, 0x74, 0x61,
+	0x74, 0x65, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12,
+	0x2c, 0x0a, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16,
+	0x2e, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e, 0x47, 0x72, 0x61, 0x70, 0x68, 0x53, 0x74, 0x61,
+	0x74, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x12, 0x1c, 0x0a,
+	0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x27, 0x0a, 0x0f, 0x67,
+	0x72, 0x61, 0x70, 0x68, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x67, 0x72, 0x61, 0x70, 0x68, 0x54, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x08, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x22, 0x1c, 0x0a, 0x0a, 0x45, 0x64,
+	0x69, 0x74, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0x6c, 0x0a, 0x0e, 0x42, 0x75, 0x6c, 0x6b,
+	0x45, 0x64, 0x69, 0x74, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x69, 0x6e,
+	0x73, 0x65, 0x72, 0x74, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x0b, 0x69, 0x6e, 0x73, 0x65, 0x72, 0x74, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1f, 0x0a,
+	0x0b, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x0a, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x16,
+	0x0a, 0x06, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06,
+	0x65, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x22, 0x86, 0x01, 0x0a, 0x0c, 0x47, 0x72, 0x61, 0x70, 0x68,
+	0x45, 0x6c, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x67, 0x72, 0x61, 0x70, 0x68,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x67, 0x72, 0x61, 0x70, 0x68, 0x12, 0x26, 0x0a,
+	0x06, 0x76, 0x65, 0x72, 0x74, 0x65, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e,
+	0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e, 0x56, 0x65, 0x72, 0x74, 0x65, 0x78, 0x52, 0x06, 0x76,
+	0x65, 0x72, 0x74, 0x65, 0x78, 0x12, 0x20, 0x0a, 0x04, 0x65, 0x64, 0x67, 0x65, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e, 0x45, 0x64, 0x67,
+	0x65, 0x52, 0x04, 0x65, 0x64, 0x67, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x72, 0x65, 0x61, 0x74,
+	0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x22,
+	0x1f, 0x0a, 0x07, 0x47, 0x72, 0x61, 0x70, 0x68, 0x49, 0x44, 0x12, 0x14, 0x0a, 0x05, 0x67, 0x72,
+	0x61, 0x70, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x67, 0x72, 0x61, 0x70, 0x68,
+	0x22, 0x31, 0x0a, 0x09, 0x45, 0x6c, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x49, 0x44, 0x12, 0x14, 0x0a,
+	0x05, 0x67, 0x72, 0x61, 0x70, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x67, 0x72,
+	0x61, 0x70, 0x68, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x02, 0x69, 0x64, 0x22, 0x72, 0x0a, 0x07, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x49, 0x44, 0x12, 0x14,
+	0x0a, 0x05, 0x67, 0x72, 0x61, 0x70, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x67,
+	0x72, 0x61, 0x70, 0x68, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x69,
+	0x65, 0x6c, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64,
+	0x12, 0x25, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x11,
+	0x2e, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x54, 0x79, 0x70,
+	0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x22, 0x29, 0x0a, 0x09, 0x54, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x22, 0x07, 0x0a, 0x05, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x2c, 0x0a, 0x12, 0x4c,
+	0x69, 0x73, 0x74, 0x47, 0x72, 0x61, 0x70, 0x68, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x16, 0x0a, 0x06, 0x67, 0x72, 0x61, 0x70, 0x68, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x06, 0x67, 0x72, 0x61, 0x70, 0x68, 0x73, 0x22, 0x40, 0x0a, 0x13, 0x4c, 0x69, 0x73,
+	0x74, 0x49, 0x6e, 0x64, 0x69, 0x63, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x29, 0x0a, 0x07, 0x69, 0x6e, 0x64, 0x69, 0x63, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x0f, 0x2e, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e, 0x49, 0x6e, 0x64, 0x65, 0x78,
+	0x49, 0x44, 0x52, 0x07, 0x69, 0x6e, 0x64, 0x69, 0x63, 0x65, 0x73, 0x22, 0x5a, 0x0a, 0x12, 0x4c,
+	0x69, 0x73, 0x74, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x23, 0x0a, 0x0d, 0x76, 0x65, 0x72, 0x74, 0x65, 0x78, 0x5f, 0x6c, 0x61, 0x62, 0x65,
+	0x6c, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0c, 0x76, 0x65, 0x72, 0x74, 0x65, 0x78,
+	0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x65, 0x64, 0x67, 0x65, 0x5f, 0x6c,
+	0x61, 0x62, 0x65, 0x6c, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x65, 0x64, 0x67,
+	0x65, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x22, 0xc6, 0x01, 0x0a, 0x09, 0x54, 0x61, 0x62, 0x6c,
+	0x65, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x12, 0x0a,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x12, 0x39, 0x0a, 0x08, 0x6c, 0x69, 0x6e,
+	0x6b, 0x5f, 0x6d, 0x61, 0x70, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x67, 0x72,
+	0x69, 0x70, 0x71, 0x6c, 0x2e, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x2e, 0x4c,
+	0x69, 0x6e, 0x6b, 0x4d, 0x61, 0x70, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x6c, 0x69, 0x6e,
+	0x6b, 0x4d, 0x61, 0x70, 0x1a, 0x3a, 0x0a, 0x0c, 0x4c, 0x69, 0x6e, 0x6b, 0x4d, 0x61, 0x70, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01,
+	0x22, 0x93, 0x01, 0x0a, 0x0a, 0x47, 0x72, 0x61, 0x70, 0x68, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12,
 	0x14, 0x0a, 0x05, 0x67, 0x72, 0x61, 0x70, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
-	0x67, 0x72, 0x61, 0x70, 0x68, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0x4b, 0x0a, 0x07, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x49, 0x44,
-	0x12, 0x14, 0x0a, 0x05, 0x67, 0x72, 0x61, 0x70, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x05, 0x67, 0x72, 0x61, 0x70, 0x68, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x12, 0x14, 0x0a, 0x05,
-	0x66, 0x69, 0x65, 0x6c, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x66, 0x69, 0x65,
-	0x6c, 0x64, 0x22, 0x29, 0x0a, 0x09, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12,
-	0x1c, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x22, 0x07, 0x0a,
-	0x05, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x2c, 0x0a, 0x12, 0x4c, 0x69, 0x73, 0x74, 0x47, 0x72,
-	0x61, 0x70, 0x68, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06,
-	0x67, 0x72, 0x61, 0x70, 0x68, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x67, 0x72,
-	0x61, 0x70, 0x68, 0x73, 0x22, 0x40, 0x0a, 0x13, 0x4c, 0x69, 0x73, 0x74, 0x49, 0x6e, 0x64, 0x69,
-	0x63, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x29, 0x0a, 0x07, 0x69,
-	0x6e, 0x64, 0x69, 0x63, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x67,
-	0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x49, 0x44, 0x52, 0x07, 0x69,
-	0x6e, 0x64, 0x69, 0x63, 0x65, 0x73, 0x22, 0x5a, 0x0a, 0x12, 0x4c, 0x69, 0x73, 0x74, 0x4c, 0x61,
-	0x62, 0x65, 0x6c, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x23, 0x0a, 0x0d,
-	0x76, 0x65, 0x72, 0x74, 0x65, 0x78, 0x5f, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x18, 0x01, 0x20,
-	0x03, 0x28, 0x09, 0x52, 0x0c, 0x76, 0x65, 0x72, 0x74, 0x65, 0x78, 0x4c, 0x61, 0x62, 0x65, 0x6c,
-	0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x65, 0x64, 0x67, 0x65, 0x5f, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73,
-	0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x65, 0x64, 0x67, 0x65, 0x4c, 0x61, 0x62, 0x65,
-	0x6c, 0x73, 0x22, 0xc6, 0x01, 0x0a, 0x09, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x49, 0x6e, 0x66, 0x6f,
-	0x12, 0x16, 0x0a, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06,
-	0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x66, 0x69,
-	0x65, 0x6c, 0x64, 0x73, 0x12, 0x39, 0x0a, 0x08, 0x6c, 0x69, 0x6e, 0x6b, 0x5f, 0x6d, 0x61, 0x70,
-	0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e,
-	0x54, 0x61, 0x62, 0x6c, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x2e, 0x4c, 0x69, 0x6e, 0x6b, 0x4d, 0x61,
-	0x70, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x6c, 0x69, 0x6e, 0x6b, 0x4d, 0x61, 0x70, 0x1a,
-	0x3a, 0x0a, 0x0c, 0x4c, 0x69, 0x6e, 0x6b, 0x4d, 0x61, 0x70, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12,
-	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65,
-	0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xaf, 0x01, 0x0a, 0x0c,
-	0x50, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x12, 0x0a, 0x04,
+	0x67, 0x72, 0x61, 0x70, 0x68, 0x12, 0x25, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x11, 0x2e, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e, 0x45, 0x76, 0x65,
+	0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x26, 0x0a, 0x06,
+	0x76, 0x65, 0x72, 0x74, 0x65, 0x78, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x67,
+	0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e, 0x56, 0x65, 0x72, 0x74, 0x65, 0x78, 0x52, 0x06, 0x76, 0x65,
+	0x72, 0x74, 0x65, 0x78, 0x12, 0x20, 0x0a, 0x04, 0x65, 0x64, 0x67, 0x65, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e, 0x45, 0x64, 0x67, 0x65,
+	0x52, 0x04, 0x65, 0x64, 0x67, 0x65, 0x22, 0xaa, 0x01, 0x0a, 0x0e, 0x51, 0x75, 0x65, 0x72, 0x79,
+	0x50, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x25, 0x0a,
+	0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x11, 0x2e, 0x67, 0x72,
+	0x69, 0x70, 0x71, 0x6c, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04,
+	0x74, 0x79, 0x70, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72,
+	0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x3b, 0x0a, 0x0d, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c,
+	0x74, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x0c, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x56, 0x61,
+	0x6c, 0x75, 0x65, 0x22, 0xc3, 0x01, 0x0a, 0x0f, 0x51, 0x75, 0x65, 0x72, 0x79, 0x44, 0x65, 0x66,
+	0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x67, 0x72, 0x61, 0x70, 0x68,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x67, 0x72, 0x61, 0x70, 0x68, 0x12, 0x12, 0x0a,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x12, 0x36, 0x0a, 0x0a, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72,
+	0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c,
+	0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x52,
+	0x0a, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x12, 0x2c, 0x0a, 0x05, 0x71,
+	0x75, 0x65, 0x72, 0x79, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x67, 0x72, 0x69,
+	0x70, 0x71, 0x6c, 0x2e, 0x47, 0x72, 0x61, 0x70, 0x68, 0x53, 0x74, 0x61, 0x74, 0x65, 0x6d, 0x65,
+	0x6e, 0x74, 0x52, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x22, 0x39, 0x0a, 0x0d, 0x53, 0x74, 0x6f,
+	0x72, 0x65, 0x64, 0x51, 0x75, 0x65, 0x72, 0x79, 0x49, 0x44, 0x12, 0x14, 0x0a, 0x05, 0x67, 0x72,
+	0x61, 0x70, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x67, 0x72, 0x61, 0x70, 0x68,
+	0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x22, 0x74, 0x0a, 0x0f, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x64, 0x51, 0x75,
+	0x65, 0x72, 0x79, 0x43, 0x61, 0x6c, 0x6c, 0x12, 0x14, 0x0a, 0x05, 0x67, 0x72, 0x61, 0x70, 0x68,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x67, 0x72, 0x61, 0x70, 0x68, 0x12, 0x12, 0x0a,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x12, 0x37, 0x0a, 0x0a, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x52, 0x0a,
+	0x70, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x22, 0x4e, 0x0a, 0x19, 0x4c, 0x69,
+	0x73, 0x74, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x64, 0x51, 0x75, 0x65, 0x72, 0x69, 0x65, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x31, 0x0a, 0x07, 0x71, 0x75, 0x65, 0x72, 0x69,
+	0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x67, 0x72, 0x69, 0x70, 0x71,
+	0x6c, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x07, 0x71, 0x75, 0x65, 0x72, 0x69, 0x65, 0x73, 0x22, 0xaf, 0x01, 0x0a, 0x0c, 0x50,
+	0x6c, 0x75, 0x67, 0x69, 0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x12, 0x0a, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12,
+	0x16, 0x0a, 0x06, 0x64, 0x72, 0x69, 0x76, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x64, 0x72, 0x69, 0x76, 0x65, 0x72, 0x12, 0x38, 0x0a, 0x06, 0x63, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c,
+	0x2e, 0x50, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x43, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x63, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x1a, 0x39, 0x0a, 0x0b, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b,
+	0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x38, 0x0a, 0x0c,
+	0x50, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x12, 0x0a, 0x04,
 	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65,
-	0x12, 0x16, 0x0a, 0x06, 0x64, 0x72, 0x69, 0x76, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x06, 0x64, 0x72, 0x69, 0x76, 0x65, 0x72, 0x12, 0x38, 0x0a, 0x06, 0x63, 0x6f, 0x6e, 0x66,
-	0x69, 0x67, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x67, 0x72, 0x69, 0x70, 0x71,
-	0x6c, 0x2e, 0x50, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x43,
-	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x63, 0x6f, 0x6e, 0x66,
-	0x69, 0x67, 0x1a, 0x39, 0x0a, 0x0b, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x45, 0x6e, 0x74, 0x72,
-	0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
-	0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x38, 0x0a,
-	0x0c, 0x50, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x12, 0x0a,
-	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d,
-	0x65, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x22, 0x2f, 0x0a, 0x13, 0x4c, 0x69, 0x73, 0x74, 0x44,
-	0x72, 0x69, 0x76, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18,
-	0x0a, 0x07, 0x64, 0x72, 0x69, 0x76, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52,
-	0x07, 0x64, 0x72, 0x69, 0x76, 0x65, 0x72, 0x73, 0x22, 0x2f, 0x0a, 0x13, 0x4c, 0x69, 0x73, 0x74,
-	0x50, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
-	0x18, 0x0a, 0x07, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09,
-	0x52, 0x07, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x73, 0x2a, 0xa2, 0x01, 0x0a, 0x09, 0x43, 0x6f,
-	0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x15, 0x0a, 0x11, 0x55, 0x4e, 0x4b, 0x4e, 0x4f,
-	0x57, 0x4e, 0x5f, 0x43, 0x4f, 0x4e, 0x44, 0x49, 0x54, 0x49, 0x4f, 0x4e, 0x10, 0x00, 0x12, 0x06,
-	0x0a, 0x02, 0x45, 0x51, 0x10, 0x01, 0x12, 0x07, 0x0a, 0x03, 0x4e, 0x45, 0x51, 0x10, 0x02, 0x12,
-	0x06, 0x0a, 0x02, 0x47, 0x54, 0x10, 0x03, 0x12, 0x07, 0x0a, 0x03, 0x47, 0x54, 0x45, 0x10, 0x04,
-	0x12, 0x06, 0x0a, 0x02, 0x4c, 0x54, 0x10, 0x05, 0x12, 0x07, 0x0a, 0x03, 0x4c, 0x54, 0x45, 0x10,
-	0x06, 0x12, 0x0a, 0x0a, 0x06, 0x49, 0x4e, 0x53, 0x49, 0x44, 0x45, 0x10, 0x07, 0x12, 0x0b, 0x0a,
-	0x07, 0x4f, 0x55, 0x54, 0x53, 0x49, 0x44, 0x45, 0x10, 0x08, 0x12, 0x0b, 0x0a, 0x07, 0x42, 0x45,
-	0x54, 0x57, 0x45, 0x45, 0x4e, 0x10, 0x09, 0x12, 0x0a, 0x0a, 0x06, 0x57, 0x49, 0x54, 0x48, 0x49,
-	0x4e, 0x10, 0x0a, 0x12, 0x0b, 0x0a, 0x07, 0x57, 0x49, 0x54, 0x48, 0x4f, 0x55, 0x54, 0x10, 0x0b,
-	0x12, 0x0c, 0x0a, 0x08, 0x43, 0x4f, 0x4e, 0x54, 0x41, 0x49, 0x4e, 0x53, 0x10, 0x0c, 0x2a, 0x49,
-	0x0a, 0x08, 0x4a, 0x6f, 0x62, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x0a, 0x0a, 0x06, 0x51, 0x55,
-	0x45, 0x55, 0x45, 0x44, 0x10, 0x00, 0x12, 0x0b, 0x0a, 0x07, 0x52, 0x55, 0x4e, 0x4e, 0x49, 0x4e,
-	0x47, 0x10, 0x01, 0x12, 0x0c, 0x0a, 0x08, 0x43, 0x4f, 0x4d, 0x50, 0x4c, 0x45, 0x54, 0x45, 0x10,
-	0x02, 0x12, 0x09, 0x0a, 0x05, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x10, 0x03, 0x12, 0x0b, 0x0a, 0x07,
-	0x44, 0x45, 0x4c, 0x45, 0x54, 0x45, 0x44, 0x10, 0x04, 0x2a, 0x4f, 0x0a, 0x09, 0x46, 0x69, 0x65,
-	0x6c, 0x64, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57,
-	0x4e, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x53, 0x54, 0x52, 0x49, 0x4e, 0x47, 0x10, 0x01, 0x12,
-	0x0b, 0x0a, 0x07, 0x4e, 0x55, 0x4d, 0x45, 0x52, 0x49, 0x43, 0x10, 0x02, 0x12, 0x08, 0x0a, 0x04,
-	0x42, 0x4f, 0x4f, 0x4c, 0x10, 0x03, 0x12, 0x07, 0x0a, 0x03, 0x4d, 0x41, 0x50, 0x10, 0x04, 0x12,
-	0x09, 0x0a, 0x05, 0x41, 0x52, 0x52, 0x41, 0x59, 0x10, 0x05, 0x32, 0xcf, 0x06, 0x0a, 0x05, 0x51,
-	0x75, 0x65, 0x72, 0x79, 0x12, 0x5a, 0x0a, 0x09, 0x54, 0x72, 0x61, 0x76, 0x65, 0x72, 0x73, 0x61,
-	0x6c, 0x12, 0x12, 0x2e, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e, 0x47, 0x72, 0x61, 0x70, 0x68,
-	0x51, 0x75, 0x65, 0x72, 0x79, 0x1a, 0x13, 0x2e, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e, 0x51,
-	0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x22, 0x22, 0x82, 0xd3, 0xe4, 0x93,
-	0x02, 0x1c, 0x22, 0x17, 0x2f, 0x76, 0x31, 0x2f, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2f, 0x7b, 0x67,
-	0x72, 0x61, 0x70, 0x68, 0x7d, 0x2f, 0x71, 0x75, 0x65, 0x72, 0x79, 0x3a, 0x01, 0x2a, 0x30, 0x01,
-	0x12, 0x55, 0x0a, 0x09, 0x47, 0x65, 0x74, 0x56, 0x65, 0x72, 0x74, 0x65, 0x78, 0x12, 0x11, 0x2e,
-	0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e, 0x45, 0x6c, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x49, 0x44,
-	0x1a, 0x0e, 0x2e, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e, 0x56, 0x65, 0x72, 0x74, 0x65, 0x78,
-	0x22, 0x25, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1f, 0x12, 0x1d, 0x2f, 0x76, 0x31, 0x2f, 0x67, 0x72,
-	0x61, 0x70, 0x68, 0x2f, 0x7b, 0x67, 0x72, 0x61, 0x70, 0x68, 0x7d, 0x2f, 0x76, 0x65, 0x72, 0x74,
-	0x65, 0x78, 0x2f, 0x7b, 0x69, 0x64, 0x7d, 0x12, 0x4f, 0x0a, 0x07, 0x47, 0x65, 0x74, 0x45, 0x64,
-	0x67, 0x65, 0x12, 0x11, 0x2e, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e, 0x45, 0x6c, 0x65, 0x6d,
-	0x65, 0x6e, 0x74, 0x49, 0x44, 0x1a, 0x0c, 0x2e, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e, 0x45,
-	0x64, 0x67, 0x65, 0x22, 0x23, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1d, 0x12, 0x1b, 0x2f, 0x76, 0x31,
-	0x2f, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2f, 0x7b, 0x67, 0x72, 0x61, 0x70, 0x68, 0x7d, 0x2f, 0x65,
-	0x64, 0x67, 0x65, 0x2f, 0x7b, 0x69, 0x64, 0x7d, 0x12, 0x57, 0x0a, 0x0c, 0x47, 0x65, 0x74, 0x54,
-	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x0f, 0x2e, 0x67, 0x72, 0x69, 0x70, 0x71,
-	0x6c, 0x2e, 0x47, 0x72, 0x61, 0x70, 0x68, 0x49, 0x44, 0x1a, 0x11, 0x2e, 0x67, 0x72, 0x69, 0x70,
-	0x71, 0x6c, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x22, 0x23, 0x82, 0xd3,
-	0xe4, 0x93, 0x02, 0x1d, 0x12, 0x1b, 0x2f, 0x76, 0x31, 0x2f, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2f,
-	0x7b, 0x67, 0x72, 0x61, 0x70, 0x68, 0x7d, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
-	0x70, 0x12, 0x4d, 0x0a, 0x09, 0x47, 0x65, 0x74, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x12, 0x0f,
-	0x2e, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e, 0x47, 0x72, 0x61, 0x70, 0x68, 0x49, 0x44, 0x1a,
-	0x0d, 0x2e, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e, 0x47, 0x72, 0x61, 0x70, 0x68, 0x22, 0x20,
-	0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1a, 0x12, 0x18, 0x2f, 0x76, 0x31, 0x2f, 0x67, 0x72, 0x61, 0x70,
-	0x68, 0x2f, 0x7b, 0x67, 0x72, 0x61, 0x70, 0x68, 0x7d, 0x2f, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61,
-	0x12, 0x4f, 0x0a, 0x0a, 0x47, 0x65, 0x74, 0x4d, 0x61, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x12, 0x0f,
-	0x2e, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e, 0x47, 0x72, 0x61, 0x70, 0x68, 0x49, 0x44, 0x1a,
-	0x0d, 0x2e, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e, 0x47, 0x72, 0x61, 0x70, 0x68, 0x22, 0x21,
-	0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1b, 0x12, 0x19, 0x2f, 0x76, 0x31, 0x2f, 0x67, 0x72, 0x61, 0x70,
-	0x68, 0x2f, 0x7b, 0x67, 0x72, 0x61, 0x70, 0x68, 0x7d, 0x2f, 0x6d, 0x61, 0x70, 0x70, 0x69, 0x6e,
-	0x67, 0x12, 0x4a, 0x0a, 0x0a, 0x4c, 0x69, 0x73, 0x74, 0x47, 0x72, 0x61, 0x70, 0x68, 0x73, 0x12,
-	0x0d, 0x2e, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x1a,
-	0x2e, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x47, 0x72, 0x61, 0x70,
-	0x68, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x11, 0x82, 0xd3, 0xe4, 0x93,
-	0x02, 0x0b, 0x12, 0x09, 0x2f, 0x76, 0x31, 0x2f, 0x67, 0x72, 0x61, 0x70, 0x68, 0x12, 0x5c, 0x0a,
-	0x0b, 0x4c, 0x69, 0x73, 0x74, 0x49, 0x6e, 0x64, 0x69, 0x63, 0x65, 0x73, 0x12, 0x0f, 0x2e, 0x67,
-	0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e, 0x47, 0x72, 0x61, 0x70, 0x68, 0x49, 0x44, 0x1a, 0x1b, 0x2e,
-	0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x49, 0x6e, 0x64, 0x69, 0x63,
-	0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x1f, 0x82, 0xd3, 0xe4, 0x93,
-	0x02, 0x19, 0x12, 0x17, 0x2f, 0x76, 0x31, 0x2f, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2f, 0x7b, 0x67,
-	0x72, 0x61, 0x70, 0x68, 0x7d, 0x2f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x5a, 0x0a, 0x0a, 0x4c,
-	0x69, 0x73, 0x74, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x12, 0x0f, 0x2e, 0x67, 0x72, 0x69, 0x70,
-	0x71, 0x6c, 0x2e, 0x47, 0x72, 0x61, 0x70, 0x68, 0x49, 0x44, 0x1a, 0x1a, 0x2e, 0x67, 0x72, 0x69,
-	0x70, 0x71, 0x6c, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x1f, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x19, 0x12, 0x17,
+	0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x22, 0x2f, 0x0a, 0x13, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x72,
+	0x69, 0x76, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a,
+	0x07, 0x64, 0x72, 0x69, 0x76, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07,
+	0x64, 0x72, 0x69, 0x76, 0x65, 0x72, 0x73, 0x22, 0x2f, 0x0a, 0x13, 0x4c, 0x69, 0x73, 0x74, 0x50,
+	0x6c, 0x75, 0x67, 0x69, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18,
+	0x0a, 0x07, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x07, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x73, 0x2a, 0x23, 0x0a, 0x0c, 0x52, 0x65, 0x73, 0x75,
+	0x6c, 0x74, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x12, 0x08, 0x0a, 0x04, 0x52, 0x4f, 0x57, 0x53,
+	0x10, 0x00, 0x12, 0x09, 0x0a, 0x05, 0x41, 0x52, 0x52, 0x4f, 0x57, 0x10, 0x01, 0x2a, 0xd0, 0x01,
+	0x0a, 0x09, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x15, 0x0a, 0x11, 0x55,
+	0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x5f, 0x43, 0x4f, 0x4e, 0x44, 0x49, 0x54, 0x49, 0x4f, 0x4e,
+	0x10, 0x00, 0x12, 0x06, 0x0a, 0x02, 0x45, 0x51, 0x10, 0x01, 0x12, 0x07, 0x0a, 0x03, 0x4e, 0x45,
+	0x51, 0x10, 0x02, 0x12, 0x06, 0x0a, 0x02, 0x47, 0x54, 0x10, 0x03, 0x12, 0x07, 0x0a, 0x03, 0x47,
+	0x54, 0x45, 0x10, 0x04, 0x12, 0x06, 0x0a, 0x02, 0x4c, 0x54, 0x10, 0x05, 0x12, 0x07, 0x0a, 0x03,
+	0x4c, 0x54, 0x45, 0x10, 0x06, 0x12, 0x0a, 0x0a, 0x06, 0x49, 0x4e, 0x53, 0x49, 0x44, 0x45, 0x10,
+	0x07, 0x12, 0x0b, 0x0a, 0x07, 0x4f, 0x55, 0x54, 0x53, 0x49, 0x44, 0x45, 0x10, 0x08, 0x12, 0x0b,
+	0x0a, 0x07, 0x42, 0x45, 0x54, 0x57, 0x45, 0x45, 0x4e, 0x10, 0x09, 0x12, 0x0a, 0x0a, 0x06, 0x57,
+	0x49, 0x54, 0x48, 0x49, 0x4e, 0x10, 0x0a, 0x12, 0x0b, 0x0a, 0x07, 0x57, 0x49, 0x54, 0x48, 0x4f,
+	0x55, 0x54, 0x10, 0x0b, 0x12, 0x0c, 0x0a, 0x08, 0x43, 0x4f, 0x4e, 0x54, 0x41, 0x49, 0x4e, 0x53,
+	0x10, 0x0c, 0x12, 0x0e, 0x0a, 0x0a, 0x47, 0x45, 0x4f, 0x5f, 0x52, 0x41, 0x44, 0x49, 0x55, 0x53,
+	0x10, 0x0d, 0x12, 0x0b, 0x0a, 0x07, 0x47, 0x45, 0x4f, 0x5f, 0x42, 0x4f, 0x58, 0x10, 0x0e, 0x12,
+	0x0f, 0x0a, 0x0b, 0x47, 0x45, 0x4f, 0x5f, 0x50, 0x4f, 0x4c, 0x59, 0x47, 0x4f, 0x4e, 0x10, 0x0f,
+	0x2a, 0x49, 0x0a, 0x08, 0x4a, 0x6f, 0x62, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x0a, 0x0a, 0x06,
+	0x51, 0x55, 0x45, 0x55, 0x45, 0x44, 0x10, 0x00, 0x12, 0x0b, 0x0a, 0x07, 0x52, 0x55, 0x4e, 0x4e,
+	0x49, 0x4e, 0x47, 0x10, 0x01, 0x12, 0x0c, 0x0a, 0x08, 0x43, 0x4f, 0x4d, 0x50, 0x4c, 0x45, 0x54,
+	0x45, 0x10, 0x02, 0x12, 0x09, 0x0a, 0x05, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x10, 0x03, 0x12, 0x0b,
+	0x0a, 0x07, 0x44, 0x45, 0x4c, 0x45, 0x54, 0x45, 0x44, 0x10, 0x04, 0x2a, 0x2b, 0x0a, 0x09, 0x49,
+	0x6e, 0x64, 0x65, 0x78, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0f, 0x0a, 0x0b, 0x46, 0x49, 0x45, 0x4c,
+	0x44, 0x5f, 0x49, 0x4e, 0x44, 0x45, 0x58, 0x10, 0x00, 0x12, 0x0d, 0x0a, 0x09, 0x47, 0x45, 0x4f,
+	0x5f, 0x49, 0x4e, 0x44, 0x45, 0x58, 0x10, 0x01, 0x2a, 0x5d, 0x0a, 0x09, 0x46, 0x69, 0x65, 0x6c,
+	0x64, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e,
+	0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x53, 0x54, 0x52, 0x49, 0x4e, 0x47, 0x10, 0x01, 0x12, 0x0b,
+	0x0a, 0x07, 0x4e, 0x55, 0x4d, 0x45, 0x52, 0x49, 0x43, 0x10, 0x02, 0x12, 0x08, 0x0a, 0x04, 0x42,
+	0x4f, 0x4f, 0x4c, 0x10, 0x03, 0x12, 0x07, 0x0a, 0x03, 0x4d, 0x41, 0x50, 0x10, 0x04, 0x12, 0x09,
+	0x0a, 0x05, 0x41, 0x52, 0x52, 0x41, 0x59, 0x10, 0x05, 0x12, 0x0c, 0x0a, 0x08, 0x44, 0x41, 0x54,
+	0x45, 0x54, 0x49, 0x4d, 0x45, 0x10, 0x06, 0x2a, 0x30, 0x0a, 0x09, 0x45, 0x76, 0x65, 0x6e, 0x74,
+	0x54, 0x79, 0x70, 0x65, 0x12, 0x09, 0x0a, 0x05, 0x41, 0x44, 0x44, 0x45, 0x44, 0x10, 0x00, 0x12,
+	0x0b, 0x0a, 0x07, 0x55, 0x50, 0x44, 0x41, 0x54, 0x45, 0x44, 0x10, 0x01, 0x12, 0x0b, 0x0a, 0x07,
+	0x52, 0x45, 0x4d, 0x4f, 0x56, 0x45, 0x44, 0x10, 0x02, 0x32, 0x89, 0x08, 0x0a, 0x05, 0x51, 0x75,
+	0x65, 0x72, 0x79, 0x12, 0x5a, 0x0a, 0x09, 0x54, 0x72, 0x61, 0x76, 0x65, 0x72, 0x73, 0x61, 0x6c,
+	0x12, 0x12, 0x2e, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e, 0x47, 0x72, 0x61, 0x70, 0x68, 0x51,
+	0x75, 0x65, 0x72, 0x79, 0x1a, 0x13, 0x2e, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e, 0x51, 0x75,
+	0x65, 0x72, 0x79, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x22, 0x22, 0x82, 0xd3, 0xe4, 0x93, 0x02,
+	0x1c, 0x3a, 0x01, 0x2a, 0x22, 0x17, 0x2f, 0x76, 0x31, 0x2f, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2f,
+	0x7b, 0x67, 0x72, 0x61, 0x70, 0x68, 0x7d, 0x2f, 0x71, 0x75, 0x65, 0x72, 0x79, 0x30, 0x01, 0x12,
+	0x67, 0x0a, 0x0d, 0x54, 0x72, 0x61, 0x76, 0x65, 0x72, 0x73, 0x61, 0x6c, 0x54, 0x65, 0x78, 0x74,
+	0x12, 0x16, 0x2e, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e, 0x47, 0x72, 0x61, 0x70, 0x68, 0x51,
+	0x75, 0x65, 0x72, 0x79, 0x54, 0x65, 0x78, 0x74, 0x1a, 0x13, 0x2e, 0x67, 0x72, 0x69, 0x70, 0x71,
+	0x6c, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x22, 0x27, 0x82,
+	0xd3, 0xe4, 0x93, 0x02, 0x21, 0x3a, 0x01, 0x2a, 0x22, 0x1c, 0x2f, 0x76, 0x31, 0x2f, 0x67, 0x72,
+	0x61, 0x70, 0x68, 0x2f, 0x7b, 0x67, 0x72, 0x61, 0x70, 0x68, 0x7d, 0x2f, 0x71, 0x75, 0x65, 0x72,
+	0x79, 0x2d, 0x74, 0x65, 0x78, 0x74, 0x30, 0x01, 0x12, 0x55, 0x0a, 0x09, 0x47, 0x65, 0x74, 0x56,
+	0x65, 0x72, 0x74, 0x65, 0x78, 0x12, 0x11, 0x2e, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e, 0x45,
+	0x6c, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x49, 0x44, 0x1a, 0x0e, 0x2e, 0x67, 0x72, 0x69, 0x70, 0x71,
+	0x6c, 0x2e, 0x56, 0x65, 0x72, 0x74, 0x65, 0x78, 0x22, 0x25, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1f,
+	0x12, 0x1d, 0x2f, 0x76, 0x31, 0x2f, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2f, 0x7b, 0x67, 0x72, 0x61,
+	0x70, 0x68, 0x7d, 0x2f, 0x76, 0x65, 0x72, 0x74, 0x65, 0x78, 0x2f, 0x7b, 0x69, 0x64, 0x7d, 0x12,
+	0x4f, 0x0a, 0x07, 0x47, 0x65, 0x74, 0x45, 0x64, 0x67, 0x65, 0x12, 0x11, 0x2e, 0x67, 0x72, 0x69,
+	0x70, 0x71, 0x6c, 0x2e, 0x45, 0x6c, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x49, 0x44, 0x1a, 0x0c, 0x2e,
+	0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e, 0x45, 0x64, 0x67, 0x65, 0x22, 0x23, 0x82, 0xd3, 0xe4,
+	0x93, 0x02, 0x1d, 0x12, 0x1b, 0x2f, 0x76, 0x31, 0x2f, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2f, 0x7b,
+	0x67, 0x72, 0x61, 0x70, 0x68, 0x7d, 0x2f, 0x65, 0x64, 0x67, 0x65, 0x2f, 0x7b, 0x69, 0x64, 0x7d,
+	0x12, 0x57, 0x0a, 0x0c, 0x47, 0x65, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x12, 0x0f, 0x2e, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e, 0x47, 0x72, 0x61, 0x70, 0x68, 0x49,
+	0x44, 0x1a, 0x11, 0x2e, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x22, 0x23, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1d, 0x12, 0x1b, 0x2f, 0x76,
+	0x31, 0x2f, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2f, 0x7b, 0x67, 0x72, 0x61, 0x70, 0x68, 0x7d, 0x2f,
+	0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x4d, 0x0a, 0x09, 0x47, 0x65, 0x74,
+	0x53, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x12, 0x0f, 0x2e, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e,
+	0x47, 0x72, 0x61, 0x70, 0x68, 0x49, 0x44, 0x1a, 0x0d, 0x2e, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c,
+	0x2e, 0x47, 0x72, 0x61, 0x70, 0x68, 0x22, 0x20, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1a, 0x12, 0x18,
+	0x2f, 0x76, 0x31, 0x2f, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2f, 0x7b, 0x67, 0x72, 0x61, 0x70, 0x68,
+	0x7d, 0x2f, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x12, 0x4f, 0x0a, 0x0a, 0x47, 0x65, 0x74, 0x4d,
+	0x61, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x12, 0x0f, 0x2e, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e,
+	0x47, 0x72, 0x61, 0x70, 0x68, 0x49, 0x44, 0x1a, 0x0d, 0x2e, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c,
+	0x2e, 0x47, 0x72, 0x61, 0x70, 0x68, 0x22, 0x21, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1b, 0x12, 0x19,
 	0x2f, 0x76, 0x31, 0x2f, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2f, 0x7b, 0x67, 0x72, 0x61, 0x70, 0x68,
-	0x7d, 0x2f, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x12, 0x43, 0x0a, 0x0a, 0x4c, 0x69, 0x73, 0x74, 0x54,
-	0x61, 0x62, 0x6c, 0x65, 0x73, 0x12, 0x0d, 0x2e, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e, 0x45,
-	0x6d, 0x70, 0x74, 0x79, 0x1a, 0x11, 0x2e, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e, 0x54, 0x61,
-	0x62, 0x6c, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x22, 0x11, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x0b, 0x12,
-	0x09, 0x2f, 0x76, 0x31, 0x2f, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x30, 0x01, 0x32, 0xed, 0x04, 0x0a,
-	0x03, 0x4a, 0x6f, 0x62, 0x12, 0x50, 0x0a, 0x06, 0x53, 0x75, 0x62, 0x6d, 0x69, 0x74, 0x12, 0x12,
-	0x2e, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e, 0x47, 0x72, 0x61, 0x70, 0x68, 0x51, 0x75, 0x65,
-	0x72, 0x79, 0x1a, 0x10, 0x2e, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e, 0x51, 0x75, 0x65, 0x72,
-	0x79, 0x4a, 0x6f, 0x62, 0x22, 0x20, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1a, 0x22, 0x15, 0x2f, 0x76,
+	0x7d, 0x2f, 0x6d, 0x61, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x12, 0x4a, 0x0a, 0x0a, 0x4c, 0x69, 0x73,
+	0x74, 0x47, 0x72, 0x61, 0x70, 0x68, 0x73, 0x12, 0x0d, 0x2e, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c,
+	0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x1a, 0x2e, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e,
+	0x4c, 0x69, 0x73, 0x74, 0x47, 0x72, 0x61, 0x70, 0x68, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x22, 0x11, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x0b, 0x12, 0x09, 0x2f, 0x76, 0x31, 0x2f,
+	0x67, 0x72, 0x61, 0x70, 0x68, 0x12, 0x5c, 0x0a, 0x0b, 0x4c, 0x69, 0x73, 0x74, 0x49, 0x6e, 0x64,
+	0x69, 0x63, 0x65, 0x73, 0x12, 0x0f, 0x2e, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e, 0x47, 0x72,
+	0x61, 0x70, 0x68, 0x49, 0x44, 0x1a, 0x1b, 0x2e, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e, 0x4c,
+	0x69, 0x73, 0x74, 0x49, 0x6e, 0x64, 0x69, 0x63, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x22, 0x1f, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x19, 0x12, 0x17, 0x2f, 0x76, 0x31, 0x2f,
+	0x67, 0x72, 0x61, 0x70, 0x68, 0x2f, 0x7b, 0x67, 0x72, 0x61, 0x70, 0x68, 0x7d, 0x2f, 0x69, 0x6e,
+	0x64, 0x65, 0x78, 0x12, 0x5a, 0x0a, 0x0a, 0x4c, 0x69, 0x73, 0x74, 0x4c, 0x61, 0x62, 0x65, 0x6c,
+	0x73, 0x12, 0x0f, 0x2e, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e, 0x47, 0x72, 0x61, 0x70, 0x68,
+	0x49, 0x44, 0x1a, 0x1a, 0x2e, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e, 0x4c, 0x69, 0x73, 0x74,
+	0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x1f,
+	0x82, 0xd3, 0xe4, 0x93, 0x02, 0x19, 0x12, 0x17, 0x2f, 0x76, 0x31, 0x2f, 0x67, 0x72, 0x61, 0x70,
+	0x68, 0x2f, 0x7b, 0x67, 0x72, 0x61, 0x70, 0x68, 0x7d, 0x2f, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x12,
+	0x43, 0x0a, 0x0a, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x12, 0x0d, 0x2e,
+	0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x11, 0x2e, 0x67,
+	0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x22,
+	0x11, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x0b, 0x12, 0x09, 0x2f, 0x76, 0x31, 0x2f, 0x74, 0x61, 0x62,
+	0x6c, 0x65, 0x30, 0x01, 0x12, 0x4f, 0x0a, 0x05, 0x57, 0x61, 0x74, 0x63, 0x68, 0x12, 0x0f, 0x2e,
+	0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e, 0x47, 0x72, 0x61, 0x70, 0x68, 0x49, 0x44, 0x1a, 0x12,
+	0x2e, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e, 0x47, 0x72, 0x61, 0x70, 0x68, 0x45, 0x76, 0x65,
+	0x6e, 0x74, 0x22, 0x1f, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x19, 0x12, 0x17, 0x2f, 0x76, 0x31, 0x2f,
+	0x67, 0x72, 0x61, 0x70, 0x68, 0x2f, 0x7b, 0x67, 0x72, 0x61, 0x70, 0x68, 0x7d, 0x2f, 0x77, 0x61,
+	0x74, 0x63, 0x68, 0x30, 0x01, 0x32, 0xc4, 0x05, 0x0a, 0x03, 0x4a, 0x6f, 0x62, 0x12, 0x50, 0x0a,
+	0x06, 0x53, 0x75, 0x62, 0x6d, 0x69, 0x74, 0x12, 0x12, 0x2e, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c,
+	0x2e, 0x47, 0x72, 0x61, 0x70, 0x68, 0x51, 0x75, 0x65, 0x72, 0x79, 0x1a, 0x10, 0x2e, 0x67, 0x72,
+	0x69, 0x70, 0x71, 0x6c, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x4a, 0x6f, 0x62, 0x22, 0x20, 0x82,
+	0xd3, 0xe4, 0x93, 0x02, 0x1a, 0x3a, 0x01, 0x2a, 0x22, 0x15, 0x2f, 0x76, 0x31, 0x2f, 0x67, 0x72,
+	0x61, 0x70, 0x68, 0x2f, 0x7b, 0x67, 0x72, 0x61, 0x70, 0x68, 0x7d, 0x2f, 0x6a, 0x6f, 0x62, 0x12,
+	0x4e, 0x0a, 0x08, 0x4c, 0x69, 0x73, 0x74, 0x4a, 0x6f, 0x62, 0x73, 0x12, 0x0f, 0x2e, 0x67, 0x72,
+	0x69, 0x70, 0x71, 0x6c, 0x2e, 0x47, 0x72, 0x61, 0x70, 0x68, 0x49, 0x44, 0x1a, 0x10, 0x2e, 0x67,
+	0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x4a, 0x6f, 0x62, 0x22, 0x1d,
+	0x82, 0xd3, 0xe4, 0x93, 0x02, 0x17, 0x12, 0x15, 0x2f, 0x76, 0x31, 0x2f, 0x67, 0x72, 0x61, 0x70,
+	0x68, 0x2f, 0x7b, 0x67, 0x72, 0x61, 0x70, 0x68, 0x7d, 0x2f, 0x6a, 0x6f, 0x62, 0x30, 0x01, 0x12,
+	0x5e, 0x0a, 0x0a, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x4a, 0x6f, 0x62, 0x73, 0x12, 0x12, 0x2e,
+	0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e, 0x47, 0x72, 0x61, 0x70, 0x68, 0x51, 0x75, 0x65, 0x72,
+	0x79, 0x1a, 0x11, 0x2e, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e, 0x4a, 0x6f, 0x62, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x22, 0x27, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x21, 0x3a, 0x01, 0x2a, 0x22,
+	0x1c, 0x2f, 0x76, 0x31, 0x2f, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2f, 0x7b, 0x67, 0x72, 0x61, 0x70,
+	0x68, 0x7d, 0x2f, 0x6a, 0x6f, 0x62, 0x2d, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x30, 0x01, 0x12,
+	0x54, 0x0a, 0x09, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x4a, 0x6f, 0x62, 0x12, 0x10, 0x2e, 0x67,
+	0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x4a, 0x6f, 0x62, 0x1a, 0x11,
+	0x2e, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e, 0x4a, 0x6f, 0x62, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x22, 0x22, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1c, 0x2a, 0x1a, 0x2f, 0x76, 0x31, 0x2f, 0x67,
+	0x72, 0x61, 0x70, 0x68, 0x2f, 0x7b, 0x67, 0x72, 0x61, 0x70, 0x68, 0x7d, 0x2f, 0x6a, 0x6f, 0x62,
+	0x2f, 0x7b, 0x69, 0x64, 0x7d, 0x12, 0x51, 0x0a, 0x06, 0x47, 0x65, 0x74, 0x4a, 0x6f, 0x62, 0x12,
+	0x10, 0x2e, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x4a, 0x6f,
+	0x62, 0x1a, 0x11, 0x2e, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e, 0x4a, 0x6f, 0x62, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x22, 0x22, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1c, 0x12, 0x1a, 0x2f, 0x76,
 	0x31, 0x2f, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2f, 0x7b, 0x67, 0x72, 0x61, 0x70, 0x68, 0x7d, 0x2f,
-	0x6a, 0x6f, 0x62, 0x3a, 0x01, 0x2a, 0x12, 0x4e, 0x0a, 0x08, 0x4c, 0x69, 0x73, 0x74, 0x4a, 0x6f,
-	0x62, 0x73, 0x12, 0x0f, 0x2e, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e, 0x47, 0x72, 0x61, 0x70,
-	0x68, 0x49, 0x44, 0x1a, 0x10, 0x2e, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e, 0x51, 0x75, 0x65,
-	0x72, 0x79, 0x4a, 0x6f, 0x62, 0x22, 0x1d, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x17, 0x12, 0x15, 0x2f,
-	0x76, 0x31, 0x2f, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2f, 0x7b, 0x67, 0x72, 0x61, 0x70, 0x68, 0x7d,
-	0x2f, 0x6a, 0x6f, 0x62, 0x30, 0x01, 0x12, 0x5e, 0x0a, 0x0a, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68,
-	0x4a, 0x6f, 0x62, 0x73, 0x12, 0x12, 0x2e, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e, 0x47, 0x72,
-	0x61, 0x70, 0x68, 0x51, 0x75, 0x65, 0x72, 0x79, 0x1a, 0x11, 0x2e, 0x67, 0x72, 0x69, 0x70, 0x71,
-	0x6c, 0x2e, 0x4a, 0x6f, 0x62, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x27, 0x82, 0xd3, 0xe4,
-	0x93, 0x02, 0x21, 0x22, 0x1c, 0x2f, 0x76, 0x31, 0x2f, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2f, 0x7b,
-	0x67, 0x72, 0x61, 0x70, 0x68, 0x7d, 0x2f, 0x6a, 0x6f, 0x62, 0x2d, 0x73, 0x65, 0x61, 0x72, 0x63,
-	0x68, 0x3a, 0x01, 0x2a, 0x30, 0x01, 0x12, 0x54, 0x0a, 0x09, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65,
+	0x6a, 0x6f, 0x62, 0x2f, 0x7b, 0x69, 0x64, 0x7d, 0x12, 0x59, 0x0a, 0x07, 0x56, 0x69, 0x65, 0x77,
 	0x4a, 0x6f, 0x62, 0x12, 0x10, 0x2e, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e, 0x51, 0x75, 0x65,
-	0x72, 0x79, 0x4a, 0x6f, 0x62, 0x1a, 0x11, 0x2e, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e, 0x4a,
-	0x6f, 0x62, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x22, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1c,
-	0x2a, 0x1a, 0x2f, 0x76, 0x31, 0x2f, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2f, 0x7b, 0x67, 0x72, 0x61,
-	0x70, 0x68, 0x7d, 0x2f, 0x6a, 0x6f, 0x62, 0x2f, 0x7b, 0x69, 0x64, 0x7d, 0x12, 0x51, 0x0a, 0x06,
-	0x47, 0x65, 0x74, 0x4a, 0x6f, 0x62, 0x12, 0x10, 0x2e, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e,
-	0x51, 0x75, 0x65, 0x72, 0x79, 0x4a, 0x6f, 0x62, 0x1a, 0x11, 0x2e, 0x67, 0x72, 0x69, 0x70, 0x71,
-	0x6c, 0x2e, 0x4a, 0x6f, 0x62, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x22, 0x82, 0xd3, 0xe4,
-	0x93, 0x02, 0x1c, 0x12, 0x1a, 0x2f, 0x76, 0x31, 0x2f, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2f, 0x7b,
-	0x67, 0x72, 0x61, 0x70, 0x68, 0x7d, 0x2f, 0x6a, 0x6f, 0x62, 0x2f, 0x7b, 0x69, 0x64, 0x7d, 0x12,
-	0x59, 0x0a, 0x07, 0x56, 0x69, 0x65, 0x77, 0x4a, 0x6f, 0x62, 0x12, 0x10, 0x2e, 0x67, 0x72, 0x69,
-	0x70, 0x71, 0x6c, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x4a, 0x6f, 0x62, 0x1a, 0x13, 0x2e, 0x67,
-	0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x73, 0x75, 0x6c,
-	0x74, 0x22, 0x25, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1f, 0x22, 0x1a, 0x2f, 0x76, 0x31, 0x2f, 0x67,
-	0x72, 0x61, 0x70, 0x68, 0x2f, 0x7b, 0x67, 0x72, 0x61, 0x70, 0x68, 0x7d, 0x2f, 0x6a, 0x6f, 0x62,
-	0x2f, 0x7b, 0x69, 0x64, 0x7d, 0x3a, 0x01, 0x2a, 0x30, 0x01, 0x12, 0x60, 0x0a, 0x09, 0x52, 0x65,
-	0x73, 0x75, 0x6d, 0x65, 0x4a, 0x6f, 0x62, 0x12, 0x13, 0x2e, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c,
-	0x2e, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x51, 0x75, 0x65, 0x72, 0x79, 0x1a, 0x13, 0x2e, 0x67,
-	0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x73, 0x75, 0x6c,
-	0x74, 0x22, 0x27, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x21, 0x22, 0x1c, 0x2f, 0x76, 0x31, 0x2f, 0x67,
-	0x72, 0x61, 0x70, 0x68, 0x2f, 0x7b, 0x67, 0x72, 0x61, 0x70, 0x68, 0x7d, 0x2f, 0x6a, 0x6f, 0x62,
-	0x2d, 0x72, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x3a, 0x01, 0x2a, 0x30, 0x01, 0x32, 0xaa, 0x08, 0x0a,
+	0x72, 0x79, 0x4a, 0x6f, 0x62, 0x1a, 0x13, 0x2e, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e, 0x51,
+	0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x22, 0x25, 0x82, 0xd3, 0xe4, 0x93,
+	0x02, 0x1f, 0x3a, 0x01, 0x2a, 0x22, 0x1a, 0x2f, 0x76, 0x31, 0x2f, 0x67, 0x72, 0x61, 0x70, 0x68,
+	0x2f, 0x7b, 0x67, 0x72, 0x61, 0x70, 0x68, 0x7d, 0x2f, 0x6a, 0x6f, 0x62, 0x2f, 0x7b, 0x69, 0x64,
+	0x7d, 0x30, 0x01, 0x12, 0x60, 0x0a, 0x09, 0x52, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x4a, 0x6f, 0x62,
+	0x12, 0x13, 0x2e, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x64,
+	0x51, 0x75, 0x65, 0x72, 0x79, 0x1a, 0x13, 0x2e, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e, 0x51,
+	0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x22, 0x27, 0x82, 0xd3, 0xe4, 0x93,
+	0x02, 0x21, 0x3a, 0x01, 0x2a, 0x22, 0x1c, 0x2f, 0x76, 0x31, 0x2f, 0x67, 0x72, 0x61, 0x70, 0x68,
+	0x2f, 0x7b, 0x67, 0x72, 0x61, 0x70, 0x68, 0x7d, 0x2f, 0x6a, 0x6f, 0x62, 0x2d, 0x72, 0x65, 0x73,
+	0x75, 0x6d, 0x65, 0x30, 0x01, 0x12, 0x55, 0x0a, 0x0b, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74,
+	0x4a, 0x6f, 0x62, 0x73, 0x12, 0x0f, 0x2e, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e, 0x47, 0x72,
+	0x61, 0x70, 0x68, 0x49, 0x44, 0x1a, 0x11, 0x2e, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e, 0x4a,
+	0x6f, 0x62, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x20, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1a,
+	0x22, 0x18, 0x2f, 0x76, 0x31, 0x2f, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2f, 0x7b, 0x67, 0x72, 0x61,
+	0x70, 0x68, 0x7d, 0x2f, 0x6a, 0x6f, 0x62, 0x2d, 0x67, 0x63, 0x30, 0x01, 0x32, 0xaa, 0x08, 0x0a,
 	0x04, 0x45, 0x64, 0x69, 0x74, 0x12, 0x5f, 0x0a, 0x09, 0x41, 0x64, 0x64, 0x56, 0x65, 0x72, 0x74,
 	0x65, 0x78, 0x12, 0x14, 0x2e, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e, 0x47, 0x72, 0x61, 0x70,
 	0x68, 0x45, 0x6c, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x1a, 0x12, 0x2e, 0x67, 0x72, 0x69, 0x70, 0x71,
 	0x6c, 0x2e, 0x45, 0x64, 0x69, 0x74, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x22, 0x28, 0x82, 0xd3,
-	0xe4, 0x93, 0x02, 0x22, 0x22, 0x18, 0x2f, 0x76, 0x31, 0x2f, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2f,
-	0x7b, 0x67, 0x72, 0x61, 0x70, 0x68, 0x7d, 0x2f, 0x76, 0x65, 0x72, 0x74, 0x65, 0x78, 0x3a, 0x06,
+	0xe4, 0x93, 0x02, 0x22, 0x3a, 0x06, 0x76, 0x65, 0x72, 0x74, 0x65, 0x78, 0x22, 0x18, 0x2f, 0x76,
+	0x31, 0x2f, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2f, 0x7b, 0x67, 0x72, 0x61, 0x70, 0x68, 0x7d, 0x2f,
 	0x76, 0x65, 0x72, 0x74, 0x65, 0x78, 0x12, 0x59, 0x0a, 0x07, 0x41, 0x64, 0x64, 0x45, 0x64, 0x67,
 	0x65, 0x12, 0x14, 0x2e, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e, 0x47, 0x72, 0x61, 0x70, 0x68,
 	0x45, 0x6c, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x1a, 0x12, 0x2e, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c,
 	0x2e, 0x45, 0x64, 0x69, 0x74, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x22, 0x24, 0x82, 0xd3, 0xe4,
-	0x93, 0x02, 0x1e, 0x22, 0x16, 0x2f, 0x76, 0x31, 0x2f, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2f, 0x7b,
-	0x67, 0x72, 0x61, 0x70, 0x68, 0x7d, 0x2f, 0x65, 0x64, 0x67, 0x65, 0x3a, 0x04, 0x65, 0x64, 0x67,
+	0x93, 0x02, 0x1e, 0x3a, 0x04, 0x65, 0x64, 0x67, 0x65, 0x22, 0x16, 0x2f, 0x76, 0x31, 0x2f, 0x67,
+	0x72, 0x61, 0x70, 0x68, 0x2f, 0x7b, 0x67, 0x72, 0x61, 0x70, 0x68, 0x7d, 0x2f, 0x65, 0x64, 0x67,
 	0x65, 0x12, 0x4c, 0x0a, 0x07, 0x42, 0x75, 0x6c, 0x6b, 0x41, 0x64, 0x64, 0x12, 0x14, 0x2e, 0x67,
 	0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e, 0x47, 0x72, 0x61, 0x70, 0x68, 0x45, 0x6c, 0x65, 0x6d, 0x65,
 	0x6e, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e, 0x42, 0x75, 0x6c, 0x6b,
@@ -3978,9 +5356,9 @@ var file_gripql_proto_rawDesc = []byte{
 	0x64, 0x7d, 0x12, 0x5b, 0x0a, 0x08, 0x41, 0x64, 0x64, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x0f,
 	0x2e, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x49, 0x44, 0x1a,
 	0x12, 0x2e, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e, 0x45, 0x64, 0x69, 0x74, 0x52, 0x65, 0x73,
-	0x75, 0x6c, 0x74, 0x22, 0x2a, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x24, 0x22, 0x1f, 0x2f, 0x76, 0x31,
-	0x2f, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2f, 0x7b, 0x67, 0x72, 0x61, 0x70, 0x68, 0x7d, 0x2f, 0x69,
-	0x6e, 0x64, 0x65, 0x78, 0x2f, 0x7b, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x7d, 0x3a, 0x01, 0x2a, 0x12,
+	0x75, 0x6c, 0x74, 0x22, 0x2a, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x24, 0x3a, 0x01, 0x2a, 0x22, 0x1f,
+	0x2f, 0x76, 0x31, 0x2f, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2f, 0x7b, 0x67, 0x72, 0x61, 0x70, 0x68,
+	0x7d, 0x2f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x2f, 0x7b, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x7d, 0x12,
 	0x63, 0x0a, 0x0b, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x0f,
 	0x2e, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x49, 0x44, 0x1a,
 	0x12, 0x2e, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e, 0x45, 0x64, 0x69, 0x74, 0x52, 0x65, 0x73,
@@ -3990,9 +5368,9 @@ var file_gripql_proto_rawDesc = []byte{
 	0x65, 0x6c, 0x64, 0x7d, 0x12, 0x53, 0x0a, 0x09, 0x41, 0x64, 0x64, 0x53, 0x63, 0x68, 0x65, 0x6d,
 	0x61, 0x12, 0x0d, 0x2e, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e, 0x47, 0x72, 0x61, 0x70, 0x68,
 	0x1a, 0x12, 0x2e, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e, 0x45, 0x64, 0x69, 0x74, 0x52, 0x65,
-	0x73, 0x75, 0x6c, 0x74, 0x22, 0x23, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1d, 0x22, 0x18, 0x2f, 0x76,
-	0x31, 0x2f, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2f, 0x7b, 0x67, 0x72, 0x61, 0x70, 0x68, 0x7d, 0x2f,
-	0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x3a, 0x01, 0x2a, 0x12, 0x57, 0x0a, 0x0c, 0x53, 0x61, 0x6d,
+	0x73, 0x75, 0x6c, 0x74, 0x22, 0x23, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1d, 0x3a, 0x01, 0x2a, 0x22,
+	0x18, 0x2f, 0x76, 0x31, 0x2f, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2f, 0x7b, 0x67, 0x72, 0x61, 0x70,
+	0x68, 0x7d, 0x2f, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x12, 0x57, 0x0a, 0x0c, 0x53, 0x61, 0x6d,
 	0x70, 0x6c, 0x65, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x12, 0x0f, 0x2e, 0x67, 0x72, 0x69, 0x70,
 	0x71, 0x6c, 0x2e, 0x47, 0x72, 0x61, 0x70, 0x68, 0x49, 0x44, 0x1a, 0x0d, 0x2e, 0x67, 0x72, 0x69,
 	0x70, 0x71, 0x6c, 0x2e, 0x47, 0x72, 0x61, 0x70, 0x68, 0x22, 0x27, 0x82, 0xd3, 0xe4, 0x93, 0x02,
@@ -4001,28 +5379,65 @@ var file_gripql_proto_rawDesc = []byte{
 	0x6c, 0x65, 0x12, 0x55, 0x0a, 0x0a, 0x41, 0x64, 0x64, 0x4d, 0x61, 0x70, 0x70, 0x69, 0x6e, 0x67,
 	0x12, 0x0d, 0x2e, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e, 0x47, 0x72, 0x61, 0x70, 0x68, 0x1a,
 	0x12, 0x2e, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e, 0x45, 0x64, 0x69, 0x74, 0x52, 0x65, 0x73,
-	0x75, 0x6c, 0x74, 0x22, 0x24, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1e, 0x22, 0x19, 0x2f, 0x76, 0x31,
-	0x2f, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2f, 0x7b, 0x67, 0x72, 0x61, 0x70, 0x68, 0x7d, 0x2f, 0x6d,
-	0x61, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x3a, 0x01, 0x2a, 0x32, 0x82, 0x02, 0x0a, 0x09, 0x43, 0x6f,
-	0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x65, 0x12, 0x57, 0x0a, 0x0b, 0x53, 0x74, 0x61, 0x72, 0x74,
-	0x50, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x12, 0x14, 0x2e, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e,
-	0x50, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x1a, 0x14, 0x2e, 0x67,
-	0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e, 0x50, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x53, 0x74, 0x61, 0x74,
-	0x75, 0x73, 0x22, 0x1c, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x16, 0x22, 0x11, 0x2f, 0x76, 0x31, 0x2f,
-	0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2f, 0x7b, 0x6e, 0x61, 0x6d, 0x65, 0x7d, 0x3a, 0x01, 0x2a,
-	0x12, 0x4d, 0x0a, 0x0b, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x73, 0x12,
-	0x0d, 0x2e, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x1b,
-	0x2e, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x6c, 0x75, 0x67,
-	0x69, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x12, 0x82, 0xd3, 0xe4,
-	0x93, 0x02, 0x0c, 0x12, 0x0a, 0x2f, 0x76, 0x31, 0x2f, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x12,
-	0x4d, 0x0a, 0x0b, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x72, 0x69, 0x76, 0x65, 0x72, 0x73, 0x12, 0x0d,
-	0x2e, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x1b, 0x2e,
-	0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x72, 0x69, 0x76, 0x65,
-	0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x12, 0x82, 0xd3, 0xe4, 0x93,
-	0x02, 0x0c, 0x12, 0x0a, 0x2f, 0x76, 0x31, 0x2f, 0x64, 0x72, 0x69, 0x76, 0x65, 0x72, 0x42, 0x1d,
-	0x5a, 0x1b, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x62, 0x6d, 0x65,
-	0x67, 0x2f, 0x67, 0x72, 0x69, 0x70, 0x2f, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x62, 0x06, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x75, 0x6c, 0x74, 0x22, 0x24, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1e, 0x3a, 0x01, 0x2a, 0x22, 0x19,
+	0x2f, 0x76, 0x31, 0x2f, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2f, 0x7b, 0x67, 0x72, 0x61, 0x70, 0x68,
+	0x7d, 0x2f, 0x6d, 0x61, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x32, 0xc9, 0x04, 0x0a, 0x0b, 0x53, 0x74,
+	0x6f, 0x72, 0x65, 0x64, 0x51, 0x75, 0x65, 0x72, 0x79, 0x12, 0x68, 0x0a, 0x0e, 0x41, 0x64, 0x64,
+	0x53, 0x74, 0x6f, 0x72, 0x65, 0x64, 0x51, 0x75, 0x65, 0x72, 0x79, 0x12, 0x17, 0x2e, 0x67, 0x72,
+	0x69, 0x70, 0x71, 0x6c, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x69,
+	0x74, 0x69, 0x6f, 0x6e, 0x1a, 0x12, 0x2e, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e, 0x45, 0x64,
+	0x69, 0x74, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x22, 0x29, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x23,
+	0x3a, 0x01, 0x2a, 0x22, 0x1e, 0x2f, 0x76, 0x31, 0x2f, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2f, 0x7b,
+	0x67, 0x72, 0x61, 0x70, 0x68, 0x7d, 0x2f, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x64, 0x2d, 0x71, 0x75,
+	0x65, 0x72, 0x79, 0x12, 0x6f, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x64,
+	0x51, 0x75, 0x65, 0x72, 0x79, 0x12, 0x15, 0x2e, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e, 0x53,
+	0x74, 0x6f, 0x72, 0x65, 0x64, 0x51, 0x75, 0x65, 0x72, 0x79, 0x49, 0x44, 0x1a, 0x17, 0x2e, 0x67,
+	0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x44, 0x65, 0x66, 0x69, 0x6e,
+	0x69, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x2d, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x27, 0x12, 0x25, 0x2f,
+	0x76, 0x31, 0x2f, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2f, 0x7b, 0x67, 0x72, 0x61, 0x70, 0x68, 0x7d,
+	0x2f, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x64, 0x2d, 0x71, 0x75, 0x65, 0x72, 0x79, 0x2f, 0x7b, 0x6e,
+	0x61, 0x6d, 0x65, 0x7d, 0x12, 0x6f, 0x0a, 0x11, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x74, 0x6f, 0x72,
+	0x65, 0x64, 0x51, 0x75, 0x65, 0x72, 0x69, 0x65, 0x73, 0x12, 0x0f, 0x2e, 0x67, 0x72, 0x69, 0x70,
+	0x71, 0x6c, 0x2e, 0x47, 0x72, 0x61, 0x70, 0x68, 0x49, 0x44, 0x1a, 0x21, 0x2e, 0x67, 0x72, 0x69,
+	0x70, 0x71, 0x6c, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x64, 0x51, 0x75,
+	0x65, 0x72, 0x69, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x26, 0x82,
+	0xd3, 0xe4, 0x93, 0x02, 0x20, 0x12, 0x1e, 0x2f, 0x76, 0x31, 0x2f, 0x67, 0x72, 0x61, 0x70, 0x68,
+	0x2f, 0x7b, 0x67, 0x72, 0x61, 0x70, 0x68, 0x7d, 0x2f, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x64, 0x2d,
+	0x71, 0x75, 0x65, 0x72, 0x79, 0x12, 0x6d, 0x0a, 0x11, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x53,
+	0x74, 0x6f, 0x72, 0x65, 0x64, 0x51, 0x75, 0x65, 0x72, 0x79, 0x12, 0x15, 0x2e, 0x67, 0x72, 0x69,
+	0x70, 0x71, 0x6c, 0x2e, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x64, 0x51, 0x75, 0x65, 0x72, 0x79, 0x49,
+	0x44, 0x1a, 0x12, 0x2e, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e, 0x45, 0x64, 0x69, 0x74, 0x52,
+	0x65, 0x73, 0x75, 0x6c, 0x74, 0x22, 0x2d, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x27, 0x2a, 0x25, 0x2f,
+	0x76, 0x31, 0x2f, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2f, 0x7b, 0x67, 0x72, 0x61, 0x70, 0x68, 0x7d,
+	0x2f, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x64, 0x2d, 0x71, 0x75, 0x65, 0x72, 0x79, 0x2f, 0x7b, 0x6e,
+	0x61, 0x6d, 0x65, 0x7d, 0x12, 0x7f, 0x0a, 0x0e, 0x52, 0x75, 0x6e, 0x53, 0x74, 0x6f, 0x72, 0x65,
+	0x64, 0x51, 0x75, 0x65, 0x72, 0x79, 0x12, 0x17, 0x2e, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e,
+	0x53, 0x74, 0x6f, 0x72, 0x65, 0x64, 0x51, 0x75, 0x65, 0x72, 0x79, 0x43, 0x61, 0x6c, 0x6c, 0x1a,
+	0x13, 0x2e, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65,
+	0x73, 0x75, 0x6c, 0x74, 0x22, 0x3d, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x37, 0x3a, 0x0a, 0x70, 0x61,
+	0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x22, 0x29, 0x2f, 0x76, 0x31, 0x2f, 0x67, 0x72,
+	0x61, 0x70, 0x68, 0x2f, 0x7b, 0x67, 0x72, 0x61, 0x70, 0x68, 0x7d, 0x2f, 0x73, 0x74, 0x6f, 0x72,
+	0x65, 0x64, 0x2d, 0x71, 0x75, 0x65, 0x72, 0x79, 0x2f, 0x7b, 0x6e, 0x61, 0x6d, 0x65, 0x7d, 0x2f,
+	0x72, 0x75, 0x6e, 0x30, 0x01, 0x32, 0x82, 0x02, 0x0a, 0x09, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67,
+	0x75, 0x72, 0x65, 0x12, 0x57, 0x0a, 0x0b, 0x53, 0x74, 0x61, 0x72, 0x74, 0x50, 0x6c, 0x75, 0x67,
+	0x69, 0x6e, 0x12, 0x14, 0x2e, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x2e, 0x50, 0x6c, 0x75, 0x67,
+	0x69, 0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x1a, 0x14, 0x2e, 0x67, 0x72, 0x69, 0x70, 0x71,
+	0x6c, 0x2e, 0x50, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x1c,
+	0x82, 0xd3, 0xe4, 0x93, 0x02, 0x16, 0x3a, 0x01, 0x2a, 0x22, 0x11, 0x2f, 0x76, 0x31, 0x2f, 0x70,
+	0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2f, 0x7b, 0x6e, 0x61, 0x6d, 0x65, 0x7d, 0x12, 0x4d, 0x0a, 0x0b,
+	0x4c, 0x69, 0x73, 0x74, 0x50, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x73, 0x12, 0x0d, 0x2e, 0x67, 0x72,
+	0x69, 0x70, 0x71, 0x6c, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x1b, 0x2e, 0x67, 0x72, 0x69,
+	0x70, 0x71, 0x6c, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x12, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x0c, 0x12,
+	0x0a, 0x2f, 0x76, 0x31, 0x2f, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x12, 0x4d, 0x0a, 0x0b, 0x4c,
+	0x69, 0x73, 0x74, 0x44, 0x72, 0x69, 0x76, 0x65, 0x72, 0x73, 0x12, 0x0d, 0x2e, 0x67, 0x72, 0x69,
+	0x70, 0x71, 0x6c, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x1b, 0x2e, 0x67, 0x72, 0x69, 0x70,
+	0x71, 0x6c, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x72, 0x69, 0x76, 0x65, 0x72, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x12, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x0c, 0x12, 0x0a,
+	0x2f, 0x76, 0x31, 0x2f, 0x64, 0x72, 0x69, 0x76, 0x65, 0x72, 0x42, 0x1d, 0x5a, 0x1b, 0x67, 0x69,
+	0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x62, 0x6d, 0x65, 0x67, 0x2f, 0x67, 0x72,
+	0x69, 0x70, 0x2f, 0x67, 0x72, 0x69, 0x70, 0x71, 0x6c, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x33,
 }
 
 var (
@@ -4037,203 +5452,263 @@ func file_gripql_proto_rawDescGZIP() []byte {
 	return file_gripql_proto_rawDescData
 }
 
-var file_gripql_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
-var file_gripql_proto_msgTypes = make([]protoimpl.MessageInfo, 49)
+var file_gripql_proto_enumTypes = make([]protoimpl.EnumInfo, 6)
+var file_gripql_proto_msgTypes = make([]protoimpl.MessageInfo, 62)
 var file_gripql_proto_goTypes = []interface{}{
-	(Condition)(0),                 // 0: gripql.Condition
-	(JobState)(0),                  // 1: gripql.JobState
-	(FieldType)(0),                 // 2: gripql.FieldType
-	(*Graph)(nil),                  // 3: gripql.Graph
-	(*GraphQuery)(nil),             // 4: gripql.GraphQuery
-	(*QuerySet)(nil),               // 5: gripql.QuerySet
-	(*GraphStatement)(nil),         // 6: gripql.GraphStatement
-	(*Range)(nil),                  // 7: gripql.Range
-	(*AggregationsRequest)(nil),    // 8: gripql.AggregationsRequest
-	(*Aggregations)(nil),           // 9: gripql.Aggregations
-	(*Aggregate)(nil),              // 10: gripql.Aggregate
-	(*TermAggregation)(nil),        // 11: gripql.TermAggregation
-	(*PercentileAggregation)(nil),  // 12: gripql.PercentileAggregation
-	(*HistogramAggregation)(nil),   // 13: gripql.HistogramAggregation
-	(*FieldAggregation)(nil),       // 14: gripql.FieldAggregation
-	(*TypeAggregation)(nil),        // 15: gripql.TypeAggregation
-	(*CountAggregation)(nil),       // 16: gripql.CountAggregation
-	(*NamedAggregationResult)(nil), // 17: gripql.NamedAggregationResult
-	(*HasExpressionList)(nil),      // 18: gripql.HasExpressionList
-	(*HasExpression)(nil),          // 19: gripql.HasExpression
-	(*HasCondition)(nil),           // 20: gripql.HasCondition
-	(*SelectStatement)(nil),        // 21: gripql.SelectStatement
-	(*Selection)(nil),              // 22: gripql.Selection
-	(*Selections)(nil),             // 23: gripql.Selections
-	(*Jump)(nil),                   // 24: gripql.Jump
-	(*Set)(nil),                    // 25: gripql.Set
-	(*Increment)(nil),              // 26: gripql.Increment
-	(*Vertex)(nil),                 // 27: gripql.Vertex
-	(*Edge)(nil),                   // 28: gripql.Edge
-	(*QueryResult)(nil),            // 29: gripql.QueryResult
-	(*QueryJob)(nil),               // 30: gripql.QueryJob
-	(*ExtendQuery)(nil),            // 31: gripql.ExtendQuery
-	(*JobStatus)(nil),              // 32: gripql.JobStatus
-	(*EditResult)(nil),             // 33: gripql.EditResult
-	(*BulkEditResult)(nil),         // 34: gripql.BulkEditResult
-	(*GraphElement)(nil),           // 35: gripql.GraphElement
-	(*GraphID)(nil),                // 36: gripql.GraphID
-	(*ElementID)(nil),              // 37: gripql.ElementID
-	(*IndexID)(nil),                // 38: gripql.IndexID
-	(*Timestamp)(nil),              // 39: gripql.Timestamp
-	(*Empty)(nil),                  // 40: gripql.Empty
-	(*ListGraphsResponse)(nil),     // 41: gripql.ListGraphsResponse
-	(*ListIndicesResponse)(nil),    // 42: gripql.ListIndicesResponse
-	(*ListLabelsResponse)(nil),     // 43: gripql.ListLabelsResponse
-	(*TableInfo)(nil),              // 44: gripql.TableInfo
-	(*PluginConfig)(nil),           // 45: gripql.PluginConfig
-	(*PluginStatus)(nil),           // 46: gripql.PluginStatus
-	(*ListDriversResponse)(nil),    // 47: gripql.ListDriversResponse
-	(*ListPluginsResponse)(nil),    // 48: gripql.ListPluginsResponse
-	nil,                            // 49: gripql.Selections.SelectionsEntry
-	nil,                            // 50: gripql.TableInfo.LinkMapEntry
-	nil,                            // 51: gripql.PluginConfig.ConfigEntry
-	(*structpb.ListValue)(nil),     // 52: google.protobuf.ListValue
-	(*structpb.Value)(nil),         // 53: google.protobuf.Value
-	(*structpb.Struct)(nil),        // 54: google.protobuf.Struct
+	(ResultFormat)(0),                 // 0: gripql.ResultFormat
+	(Condition)(0),                    // 1: gripql.Condition
+	(JobState)(0),                     // 2: gripql.JobState
+	(IndexType)(0),                    // 3: gripql.IndexType
+	(FieldType)(0),                    // 4: gripql.FieldType
+	(EventType)(0),                    // 5: gripql.EventType
+	(*Graph)(nil),                     // 6: gripql.Graph
+	(*GraphQuery)(nil),                // 7: gripql.GraphQuery
+	(*GraphQueryText)(nil),            // 8: gripql.GraphQueryText
+	(*QuerySet)(nil),                  // 9: gripql.QuerySet
+	(*GraphStatement)(nil),            // 10: gripql.GraphStatement
+	(*SubQuery)(nil),                  // 11: gripql.SubQuery
+	(*SubQueryList)(nil),              // 12: gripql.SubQueryList
+	(*Group)(nil),                     // 13: gripql.Group
+	(*Range)(nil),                     // 14: gripql.Range
+	(*Seek)(nil),                      // 15: gripql.Seek
+	(*AggregationsRequest)(nil),       // 16: gripql.AggregationsRequest
+	(*Aggregations)(nil),              // 17: gripql.Aggregations
+	(*Aggregate)(nil),                 // 18: gripql.Aggregate
+	(*TermAggregation)(nil),           // 19: gripql.TermAggregation
+	(*PercentileAggregation)(nil),     // 20: gripql.PercentileAggregation
+	(*HistogramAggregation)(nil),      // 21: gripql.HistogramAggregation
+	(*DateHistogramAggregation)(nil),  // 22: gripql.DateHistogramAggregation
+	(*GeohashGridAggregation)(nil),    // 23: gripql.GeohashGridAggregation
+	(*FieldAggregation)(nil),          // 24: gripql.FieldAggregation
+	(*TypeAggregation)(nil),           // 25: gripql.TypeAggregation
+	(*CountAggregation)(nil),          // 26: gripql.CountAggregation
+	(*NamedAggregationResult)(nil),    // 27: gripql.NamedAggregationResult
+	(*HasExpressionList)(nil),         // 28: gripql.HasExpressionList
+	(*HasExpression)(nil),             // 29: gripql.HasExpression
+	(*HasCondition)(nil),              // 30: gripql.HasCondition
+	(*SelectStatement)(nil),           // 31: gripql.SelectStatement
+	(*Selection)(nil),                 // 32: gripql.Selection
+	(*Selections)(nil),                // 33: gripql.Selections
+	(*Jump)(nil),                      // 34: gripql.Jump
+	(*Set)(nil),                       // 35: gripql.Set
+	(*Increment)(nil),                 // 36: gripql.Increment
+	(*Vertex)(nil),                    // 37: gripql.Vertex
+	(*Edge)(nil),                      // 38: gripql.Edge
+	(*QueryResult)(nil),               // 39: gripql.QueryResult
+	(*QueryJob)(nil),                  // 40: gripql.QueryJob
+	(*ExtendQuery)(nil),               // 41: gripql.ExtendQuery
+	(*JobStatus)(nil),                 // 42: gripql.JobStatus
+	(*EditResult)(nil),                // 43: gripql.EditResult
+	(*BulkEditResult)(nil),            // 44: gripql.BulkEditResult
+	(*GraphElement)(nil),              // 45: gripql.GraphElement
+	(*GraphID)(nil),                   // 46: gripql.GraphID
+	(*ElementID)(nil),                 // 47: gripql.ElementID
+	(*IndexID)(nil),                   // 48: gripql.IndexID
+	(*Timestamp)(nil),                 // 49: gripql.Timestamp
+	(*Empty)(nil),                     // 50: gripql.Empty
+	(*ListGraphsResponse)(nil),        // 51: gripql.ListGraphsResponse
+	(*ListIndicesResponse)(nil),       // 52: gripql.ListIndicesResponse
+	(*ListLabelsResponse)(nil),        // 53: gripql.ListLabelsResponse
+	(*TableInfo)(nil),                 // 54: gripql.TableInfo
+	(*GraphEvent)(nil),                // 55: gripql.GraphEvent
+	(*QueryParameter)(nil),            // 56: gripql.QueryParameter
+	(*QueryDefinition)(nil),           // 57: gripql.QueryDefinition
+	(*StoredQueryID)(nil),             // 58: gripql.StoredQueryID
+	(*StoredQueryCall)(nil),           // 59: gripql.StoredQueryCall
+	(*ListStoredQueriesResponse)(nil), // 60: gripql.ListStoredQueriesResponse
+	(*PluginConfig)(nil),              // 61: gripql.PluginConfig
+	(*PluginStatus)(nil),              // 62: gripql.PluginStatus
+	(*ListDriversResponse)(nil),       // 63: gripql.ListDriversResponse
+	(*ListPluginsResponse)(nil),       // 64: gripql.ListPluginsResponse
+	nil,                               // 65: gripql.Selections.SelectionsEntry
+	nil,                               // 66: gripql.TableInfo.LinkMapEntry
+	nil,                               // 67: gripql.PluginConfig.ConfigEntry
+	(*structpb.ListValue)(nil),        // 68: google.protobuf.ListValue
+	(*structpb.Value)(nil),            // 69: google.protobuf.Value
+	(*structpb.Struct)(nil),           // 70: google.protobuf.Struct
 }
 var file_gripql_proto_depIdxs = []int32{
-	27,  // 0: gripql.Graph.vertices:type_name -> gripql.Vertex
-	28,  // 1: gripql.Graph.edges:type_name -> gripql.Edge
-	6,   // 2: gripql.GraphQuery.query:type_name -> gripql.GraphStatement
-	6,   // 3: gripql.QuerySet.query:type_name -> gripql.GraphStatement
-	52,  // 4: gripql.GraphStatement.v:type_name -> google.protobuf.ListValue
-	52,  // 5: gripql.GraphStatement.e:type_name -> google.protobuf.ListValue
-	52,  // 6: gripql.GraphStatement.in:type_name -> google.protobuf.ListValue
-	52,  // 7: gripql.GraphStatement.out:type_name -> google.protobuf.ListValue
-	52,  // 8: gripql.GraphStatement.both:type_name -> google.protobuf.ListValue
-	52,  // 9: gripql.GraphStatement.in_e:type_name -> google.protobuf.ListValue
-	52,  // 10: gripql.GraphStatement.out_e:type_name -> google.protobuf.ListValue
-	52,  // 11: gripql.GraphStatement.both_e:type_name -> google.protobuf.ListValue
-	52,  // 12: gripql.GraphStatement.in_null:type_name -> google.protobuf.ListValue
-	52,  // 13: gripql.GraphStatement.out_null:type_name -> google.protobuf.ListValue
-	52,  // 14: gripql.GraphStatement.in_e_null:type_name -> google.protobuf.ListValue
-	52,  // 15: gripql.GraphStatement.out_e_null:type_name -> google.protobuf.ListValue
-	21,  // 16: gripql.GraphStatement.select:type_name -> gripql.SelectStatement
-	7,   // 17: gripql.GraphStatement.range:type_name -> gripql.Range
-	19,  // 18: gripql.GraphStatement.has:type_name -> gripql.HasExpression
-	52,  // 19: gripql.GraphStatement.has_label:type_name -> google.protobuf.ListValue
-	52,  // 20: gripql.GraphStatement.has_key:type_name -> google.protobuf.ListValue
-	52,  // 21: gripql.GraphStatement.has_id:type_name -> google.protobuf.ListValue
-	52,  // 22: gripql.GraphStatement.distinct:type_name -> google.protobuf.ListValue
-	52,  // 23: gripql.GraphStatement.fields:type_name -> google.protobuf.ListValue
-	9,   // 24: gripql.GraphStatement.aggregate:type_name -> gripql.Aggregations
-	53,  // 25: gripql.GraphStatement.render:type_name -> google.protobuf.Value
-	52,  // 26: gripql.GraphStatement.path:type_name -> google.protobuf.ListValue
-	24,  // 27: gripql.GraphStatement.jump:type_name -> gripql.Jump
-	25,  // 28: gripql.GraphStatement.set:type_name -> gripql.Set
-	26,  // 29: gripql.GraphStatement.increment:type_name -> gripql.Increment
-	10,  // 30: gripql.AggregationsRequest.aggregations:type_name -> gripql.Aggregate
-	10,  // 31: gripql.Aggregations.aggregations:type_name -> gripql.Aggregate
-	11,  // 32: gripql.Aggregate.term:type_name -> gripql.TermAggregation
-	12,  // 33: gripql.Aggregate.percentile:type_name -> gripql.PercentileAggregation
-	13,  // 34: gripql.Aggregate.histogram:type_name -> gripql.HistogramAggregation
-	14,  // 35: gripql.Aggregate.field:type_name -> gripql.FieldAggregation
-	15,  // 36: gripql.Aggregate.type:type_name -> gripql.TypeAggregation
-	16,  // 37: gripql.Aggregate.count:type_name -> gripql.CountAggregation
-	53,  // 38: gripql.NamedAggregationResult.key:type_name -> google.protobuf.Value
-	19,  // 39: gripql.HasExpressionList.expressions:type_name -> gripql.HasExpression
-	18,  // 40: gripql.HasExpression.and:type_name -> gripql.HasExpressionList
-	18,  // 41: gripql.HasExpression.or:type_name -> gripql.HasExpressionList
-	19,  // 42: gripql.HasExpression.not:type_name -> gripql.HasExpression
-	20,  // 43: gripql.HasExpression.condition:type_name -> gripql.HasCondition
-	53,  // 44: gripql.HasCondition.value:type_name -> google.protobuf.Value
-	0,   // 45: gripql.HasCondition.condition:type_name -> gripql.Condition
-	27,  // 46: gripql.Selection.vertex:type_name -> gripql.Vertex
-	28,  // 47: gripql.Selection.edge:type_name -> gripql.Edge
-	49,  // 48: gripql.Selections.selections:type_name -> gripql.Selections.SelectionsEntry
-	19,  // 49: gripql.Jump.expression:type_name -> gripql.HasExpression
-	53,  // 50: gripql.Set.value:type_name -> google.protobuf.Value
-	54,  // 51: gripql.Vertex.data:type_name -> google.protobuf.Struct
-	54,  // 52: gripql.Edge.data:type_name -> google.protobuf.Struct
-	27,  // 53: gripql.QueryResult.vertex:type_name -> gripql.Vertex
-	28,  // 54: gripql.QueryResult.edge:type_name -> gripql.Edge
-	17,  // 55: gripql.QueryResult.aggregations:type_name -> gripql.NamedAggregationResult
-	23,  // 56: gripql.QueryResult.selections:type_name -> gripql.Selections
-	53,  // 57: gripql.QueryResult.render:type_name -> google.protobuf.Value
-	52,  // 58: gripql.QueryResult.path:type_name -> google.protobuf.ListValue
-	6,   // 59: gripql.ExtendQuery.query:type_name -> gripql.GraphStatement
-	1,   // 60: gripql.JobStatus.state:type_name -> gripql.JobState
-	6,   // 61: gripql.JobStatus.query:type_name -> gripql.GraphStatement
-	27,  // 62: gripql.GraphElement.vertex:type_name -> gripql.Vertex
-	28,  // 63: gripql.GraphElement.edge:type_name -> gripql.Edge
-	38,  // 64: gripql.ListIndicesResponse.indices:type_name -> gripql.IndexID
-	50,  // 65: gripql.TableInfo.link_map:type_name -> gripql.TableInfo.LinkMapEntry
-	51,  // 66: gripql.PluginConfig.config:type_name -> gripql.PluginConfig.ConfigEntry
-	22,  // 67: gripql.Selections.SelectionsEntry.value:type_name -> gripql.Selection
-	4,   // 68: gripql.Query.Traversal:input_type -> gripql.GraphQuery
-	37,  // 69: gripql.Query.GetVertex:input_type -> gripql.ElementID
-	37,  // 70: gripql.Query.GetEdge:input_type -> gripql.ElementID
-	36,  // 71: gripql.Query.GetTimestamp:input_type -> gripql.GraphID
-	36,  // 72: gripql.Query.GetSchema:input_type -> gripql.GraphID
-	36,  // 73: gripql.Query.GetMapping:input_type -> gripql.GraphID
-	40,  // 74: gripql.Query.ListGraphs:input_type -> gripql.Empty
-	36,  // 75: gripql.Query.ListIndices:input_type -> gripql.GraphID
-	36,  // 76: gripql.Query.ListLabels:input_type -> gripql.GraphID
-	40,  // 77: gripql.Query.ListTables:input_type -> gripql.Empty
-	4,   // 78: gripql.Job.Submit:input_type -> gripql.GraphQuery
-	36,  // 79: gripql.Job.ListJobs:input_type -> gripql.GraphID
-	4,   // 80: gripql.Job.SearchJobs:input_type -> gripql.GraphQuery
-	30,  // 81: gripql.Job.DeleteJob:input_type -> gripql.QueryJob
-	30,  // 82: gripql.Job.GetJob:input_type -> gripql.QueryJob
-	30,  // 83: gripql.Job.ViewJob:input_type -> gripql.QueryJob
-	31,  // 84: gripql.Job.ResumeJob:input_type -> gripql.ExtendQuery
-	35,  // 85: gripql.Edit.AddVertex:input_type -> gripql.GraphElement
-	35,  // 86: gripql.Edit.AddEdge:input_type -> gripql.GraphElement
-	35,  // 87: gripql.Edit.BulkAdd:input_type -> gripql.GraphElement
-	36,  // 88: gripql.Edit.AddGraph:input_type -> gripql.GraphID
-	36,  // 89: gripql.Edit.DeleteGraph:input_type -> gripql.GraphID
-	37,  // 90: gripql.Edit.DeleteVertex:input_type -> gripql.ElementID
-	37,  // 91: gripql.Edit.DeleteEdge:input_type -> gripql.ElementID
-	38,  // 92: gripql.Edit.AddIndex:input_type -> gripql.IndexID
-	38,  // 93: gripql.Edit.DeleteIndex:input_type -> gripql.IndexID
-	3,   // 94: gripql.Edit.AddSchema:input_type -> gripql.Graph
-	36,  // 95: gripql.Edit.SampleSchema:input_type -> gripql.GraphID
-	3,   // 96: gripql.Edit.AddMapping:input_type -> gripql.Graph
-	45,  // 97: gripql.Configure.StartPlugin:input_type -> gripql.PluginConfig
-	40,  // 98: gripql.Configure.ListPlugins:input_type -> gripql.Empty
-	40,  // 99: gripql.Configure.ListDrivers:input_type -> gripql.Empty
-	29,  // 100: gripql.Query.Traversal:output_type -> gripql.QueryResult
-	27,  // 101: gripql.Query.GetVertex:output_type -> gripql.Vertex
-	28,  // 102: gripql.Query.GetEdge:output_type -> gripql.Edge
-	39,  // 103: gripql.Query.GetTimestamp:output_type -> gripql.Timestamp
-	3,   // 104: gripql.Query.GetSchema:output_type -> gripql.Graph
-	3,   // 105: gripql.Query.GetMapping:output_type -> gripql.Graph
-	41,  // 106: gripql.Query.ListGraphs:output_type -> gripql.ListGraphsResponse
-	42,  // 107: gripql.Query.ListIndices:output_type -> gripql.ListIndicesResponse
-	43,  // 108: gripql.Query.ListLabels:output_type -> gripql.ListLabelsResponse
-	44,  // 109: gripql.Query.ListTables:output_type -> gripql.TableInfo
-	30,  // 110: gripql.Job.Submit:output_type -> gripql.QueryJob
-	30,  // 111: gripql.Job.ListJobs:output_type -> gripql.QueryJob
-	32,  // 112: gripql.Job.SearchJobs:output_type -> gripql.JobStatus
-	32,  // 113: gripql.Job.DeleteJob:output_type -> gripql.JobStatus
-	32,  // 114: gripql.Job.GetJob:output_type -> gripql.JobStatus
-	29,  // 115: gripql.Job.ViewJob:output_type -> gripql.QueryResult
-	29,  // 116: gripql.Job.ResumeJob:output_type -> gripql.QueryResult
-	33,  // 117: gripql.Edit.AddVertex:output_type -> gripql.EditResult
-	33,  // 118: gripql.Edit.AddEdge:output_type -> gripql.EditResult
-	34,  // 119: gripql.Edit.BulkAdd:output_type -> gripql.BulkEditResult
-	33,  // 120: gripql.Edit.AddGraph:output_type -> gripql.EditResult
-	33,  // 121: gripql.Edit.DeleteGraph:output_type -> gripql.EditResult
-	33,  // 122: gripql.Edit.DeleteVertex:output_type -> gripql.EditResult
-	33,  // 123: gripql.Edit.DeleteEdge:output_type -> gripql.EditResult
-	33,  // 124: gripql.Edit.AddIndex:output_type -> gripql.EditResult
-	33,  // 125: gripql.Edit.DeleteIndex:output_type -> gripql.EditResult
-	33,  // 126: gripql.Edit.AddSchema:output_type -> gripql.EditResult
-	3,   // 127: gripql.Edit.SampleSchema:output_type -> gripql.Graph
-	33,  // 128: gripql.Edit.AddMapping:output_type -> gripql.EditResult
-	46,  // 129: gripql.Configure.StartPlugin:output_type -> gripql.PluginStatus
-	48,  // 130: gripql.Configure.ListPlugins:output_type -> gripql.ListPluginsResponse
-	47,  // 131: gripql.Configure.ListDrivers:output_type -> gripql.ListDriversResponse
-	100, // [100:132] is the sub-list for method output_type
-	68,  // [68:100] is the sub-list for method input_type
-	68,  // [68:68] is the sub-list for extension type_name
-	68,  // [68:68] is the sub-list for extension extendee
-	0,   // [0:68] is the sub-list for field type_name
+	37,  // 0: gripql.Graph.vertices:type_name -> gripql.Vertex
+	38,  // 1: gripql.Graph.edges:type_name -> gripql.Edge
+	10,  // 2: gripql.GraphQuery.query:type_name -> gripql.GraphStatement
+	0,   // 3: gripql.GraphQuery.format:type_name -> gripql.ResultFormat
+	0,   // 4: gripql.GraphQueryText.format:type_name -> gripql.ResultFormat
+	10,  // 5: gripql.QuerySet.query:type_name -> gripql.GraphStatement
+	68,  // 6: gripql.GraphStatement.v:type_name -> google.protobuf.ListValue
+	68,  // 7: gripql.GraphStatement.e:type_name -> google.protobuf.ListValue
+	68,  // 8: gripql.GraphStatement.in:type_name -> google.protobuf.ListValue
+	68,  // 9: gripql.GraphStatement.out:type_name -> google.protobuf.ListValue
+	68,  // 10: gripql.GraphStatement.both:type_name -> google.protobuf.ListValue
+	68,  // 11: gripql.GraphStatement.in_e:type_name -> google.protobuf.ListValue
+	68,  // 12: gripql.GraphStatement.out_e:type_name -> google.protobuf.ListValue
+	68,  // 13: gripql.GraphStatement.both_e:type_name -> google.protobuf.ListValue
+	68,  // 14: gripql.GraphStatement.in_null:type_name -> google.protobuf.ListValue
+	68,  // 15: gripql.GraphStatement.out_null:type_name -> google.protobuf.ListValue
+	68,  // 16: gripql.GraphStatement.in_e_null:type_name -> google.protobuf.ListValue
+	68,  // 17: gripql.GraphStatement.out_e_null:type_name -> google.protobuf.ListValue
+	31,  // 18: gripql.GraphStatement.select:type_name -> gripql.SelectStatement
+	14,  // 19: gripql.GraphStatement.range:type_name -> gripql.Range
+	29,  // 20: gripql.GraphStatement.has:type_name -> gripql.HasExpression
+	68,  // 21: gripql.GraphStatement.has_label:type_name -> google.protobuf.ListValue
+	68,  // 22: gripql.GraphStatement.has_key:type_name -> google.protobuf.ListValue
+	68,  // 23: gripql.GraphStatement.has_id:type_name -> google.protobuf.ListValue
+	68,  // 24: gripql.GraphStatement.distinct:type_name -> google.protobuf.ListValue
+	68,  // 25: gripql.GraphStatement.sort:type_name -> google.protobuf.ListValue
+	15,  // 26: gripql.GraphStatement.seek:type_name -> gripql.Seek
+	68,  // 27: gripql.GraphStatement.fields:type_name -> google.protobuf.ListValue
+	17,  // 28: gripql.GraphStatement.aggregate:type_name -> gripql.Aggregations
+	69,  // 29: gripql.GraphStatement.render:type_name -> google.protobuf.Value
+	68,  // 30: gripql.GraphStatement.path:type_name -> google.protobuf.ListValue
+	13,  // 31: gripql.GraphStatement.group:type_name -> gripql.Group
+	69,  // 32: gripql.GraphStatement.fold:type_name -> google.protobuf.Value
+	34,  // 33: gripql.GraphStatement.jump:type_name -> gripql.Jump
+	35,  // 34: gripql.GraphStatement.set:type_name -> gripql.Set
+	36,  // 35: gripql.GraphStatement.increment:type_name -> gripql.Increment
+	11,  // 36: gripql.GraphStatement.where:type_name -> gripql.SubQuery
+	11,  // 37: gripql.GraphStatement.not:type_name -> gripql.SubQuery
+	12,  // 38: gripql.GraphStatement.union:type_name -> gripql.SubQueryList
+	11,  // 39: gripql.GraphStatement.optional:type_name -> gripql.SubQuery
+	12,  // 40: gripql.GraphStatement.coalesce:type_name -> gripql.SubQueryList
+	10,  // 41: gripql.SubQuery.query:type_name -> gripql.GraphStatement
+	11,  // 42: gripql.SubQueryList.queries:type_name -> gripql.SubQuery
+	69,  // 43: gripql.Group.value:type_name -> google.protobuf.Value
+	68,  // 44: gripql.Seek.values:type_name -> google.protobuf.ListValue
+	18,  // 45: gripql.AggregationsRequest.aggregations:type_name -> gripql.Aggregate
+	18,  // 46: gripql.Aggregations.aggregations:type_name -> gripql.Aggregate
+	19,  // 47: gripql.Aggregate.term:type_name -> gripql.TermAggregation
+	20,  // 48: gripql.Aggregate.percentile:type_name -> gripql.PercentileAggregation
+	21,  // 49: gripql.Aggregate.histogram:type_name -> gripql.HistogramAggregation
+	24,  // 50: gripql.Aggregate.field:type_name -> gripql.FieldAggregation
+	25,  // 51: gripql.Aggregate.type:type_name -> gripql.TypeAggregation
+	26,  // 52: gripql.Aggregate.count:type_name -> gripql.CountAggregation
+	22,  // 53: gripql.Aggregate.date_histogram:type_name -> gripql.DateHistogramAggregation
+	23,  // 54: gripql.Aggregate.geohash_grid:type_name -> gripql.GeohashGridAggregation
+	69,  // 55: gripql.NamedAggregationResult.key:type_name -> google.protobuf.Value
+	29,  // 56: gripql.HasExpressionList.expressions:type_name -> gripql.HasExpression
+	28,  // 57: gripql.HasExpression.and:type_name -> gripql.HasExpressionList
+	28,  // 58: gripql.HasExpression.or:type_name -> gripql.HasExpressionList
+	29,  // 59: gripql.HasExpression.not:type_name -> gripql.HasExpression
+	30,  // 60: gripql.HasExpression.condition:type_name -> gripql.HasCondition
+	69,  // 61: gripql.HasCondition.value:type_name -> google.protobuf.Value
+	1,   // 62: gripql.HasCondition.condition:type_name -> gripql.Condition
+	37,  // 63: gripql.Selection.vertex:type_name -> gripql.Vertex
+	38,  // 64: gripql.Selection.edge:type_name -> gripql.Edge
+	65,  // 65: gripql.Selections.selections:type_name -> gripql.Selections.SelectionsEntry
+	29,  // 66: gripql.Jump.expression:type_name -> gripql.HasExpression
+	69,  // 67: gripql.Set.value:type_name -> google.protobuf.Value
+	70,  // 68: gripql.Vertex.data:type_name -> google.protobuf.Struct
+	70,  // 69: gripql.Edge.data:type_name -> google.protobuf.Struct
+	37,  // 70: gripql.QueryResult.vertex:type_name -> gripql.Vertex
+	38,  // 71: gripql.QueryResult.edge:type_name -> gripql.Edge
+	27,  // 72: gripql.QueryResult.aggregations:type_name -> gripql.NamedAggregationResult
+	33,  // 73: gripql.QueryResult.selections:type_name -> gripql.Selections
+	69,  // 74: gripql.QueryResult.render:type_name -> google.protobuf.Value
+	68,  // 75: gripql.QueryResult.path:type_name -> google.protobuf.ListValue
+	0,   // 76: gripql.QueryJob.format:type_name -> gripql.ResultFormat
+	10,  // 77: gripql.ExtendQuery.query:type_name -> gripql.GraphStatement
+	2,   // 78: gripql.JobStatus.state:type_name -> gripql.JobState
+	10,  // 79: gripql.JobStatus.query:type_name -> gripql.GraphStatement
+	37,  // 80: gripql.GraphElement.vertex:type_name -> gripql.Vertex
+	38,  // 81: gripql.GraphElement.edge:type_name -> gripql.Edge
+	3,   // 82: gripql.IndexID.type:type_name -> gripql.IndexType
+	48,  // 83: gripql.ListIndicesResponse.indices:type_name -> gripql.IndexID
+	66,  // 84: gripql.TableInfo.link_map:type_name -> gripql.TableInfo.LinkMapEntry
+	5,   // 85: gripql.GraphEvent.type:type_name -> gripql.EventType
+	37,  // 86: gripql.GraphEvent.vertex:type_name -> gripql.Vertex
+	38,  // 87: gripql.GraphEvent.edge:type_name -> gripql.Edge
+	4,   // 88: gripql.QueryParameter.type:type_name -> gripql.FieldType
+	69,  // 89: gripql.QueryParameter.default_value:type_name -> google.protobuf.Value
+	56,  // 90: gripql.QueryDefinition.parameters:type_name -> gripql.QueryParameter
+	10,  // 91: gripql.QueryDefinition.query:type_name -> gripql.GraphStatement
+	70,  // 92: gripql.StoredQueryCall.parameters:type_name -> google.protobuf.Struct
+	57,  // 93: gripql.ListStoredQueriesResponse.queries:type_name -> gripql.QueryDefinition
+	67,  // 94: gripql.PluginConfig.config:type_name -> gripql.PluginConfig.ConfigEntry
+	32,  // 95: gripql.Selections.SelectionsEntry.value:type_name -> gripql.Selection
+	7,   // 96: gripql.Query.Traversal:input_type -> gripql.GraphQuery
+	8,   // 97: gripql.Query.TraversalText:input_type -> gripql.GraphQueryText
+	47,  // 98: gripql.Query.GetVertex:input_type -> gripql.ElementID
+	47,  // 99: gripql.Query.GetEdge:input_type -> gripql.ElementID
+	46,  // 100: gripql.Query.GetTimestamp:input_type -> gripql.GraphID
+	46,  // 101: gripql.Query.GetSchema:input_type -> gripql.GraphID
+	46,  // 102: gripql.Query.GetMapping:input_type -> gripql.GraphID
+	50,  // 103: gripql.Query.ListGraphs:input_type -> gripql.Empty
+	46,  // 104: gripql.Query.ListIndices:input_type -> gripql.GraphID
+	46,  // 105: gripql.Query.ListLabels:input_type -> gripql.GraphID
+	50,  // 106: gripql.Query.ListTables:input_type -> gripql.Empty
+	46,  // 107: gripql.Query.Watch:input_type -> gripql.GraphID
+	7,   // 108: gripql.Job.Submit:input_type -> gripql.GraphQuery
+	46,  // 109: gripql.Job.ListJobs:input_type -> gripql.GraphID
+	7,   // 110: gripql.Job.SearchJobs:input_type -> gripql.GraphQuery
+	40,  // 111: gripql.Job.DeleteJob:input_type -> gripql.QueryJob
+	40,  // 112: gripql.Job.GetJob:input_type -> gripql.QueryJob
+	40,  // 113: gripql.Job.ViewJob:input_type -> gripql.QueryJob
+	41,  // 114: gripql.Job.ResumeJob:input_type -> gripql.ExtendQuery
+	46,  // 115: gripql.Job.CollectJobs:input_type -> gripql.GraphID
+	45,  // 116: gripql.Edit.AddVertex:input_type -> gripql.GraphElement
+	45,  // 117: gripql.Edit.AddEdge:input_type -> gripql.GraphElement
+	45,  // 118: gripql.Edit.BulkAdd:input_type -> gripql.GraphElement
+	46,  // 119: gripql.Edit.AddGraph:input_type -> gripql.GraphID
+	46,  // 120: gripql.Edit.DeleteGraph:input_type -> gripql.GraphID
+	47,  // 121: gripql.Edit.DeleteVertex:input_type -> gripql.ElementID
+	47,  // 122: gripql.Edit.DeleteEdge:input_type -> gripql.ElementID
+	48,  // 123: gripql.Edit.AddIndex:input_type -> gripql.IndexID
+	48,  // 124: gripql.Edit.DeleteIndex:input_type -> gripql.IndexID
+	6,   // 125: gripql.Edit.AddSchema:input_type -> gripql.Graph
+	46,  // 126: gripql.Edit.SampleSchema:input_type -> gripql.GraphID
+	6,   // 127: gripql.Edit.AddMapping:input_type -> gripql.Graph
+	57,  // 128: gripql.StoredQuery.AddStoredQuery:input_type -> gripql.QueryDefinition
+	58,  // 129: gripql.StoredQuery.GetStoredQuery:input_type -> gripql.StoredQueryID
+	46,  // 130: gripql.StoredQuery.ListStoredQueries:input_type -> gripql.GraphID
+	58,  // 131: gripql.StoredQuery.DeleteStoredQuery:input_type -> gripql.StoredQueryID
+	59,  // 132: gripql.StoredQuery.RunStoredQuery:input_type -> gripql.StoredQueryCall
+	61,  // 133: gripql.Configure.StartPlugin:input_type -> gripql.PluginConfig
+	50,  // 134: gripql.Configure.ListPlugins:input_type -> gripql.Empty
+	50,  // 135: gripql.Configure.ListDrivers:input_type -> gripql.Empty
+	39,  // 136: gripql.Query.Traversal:output_type -> gripql.QueryResult
+	39,  // 137: gripql.Query.TraversalText:output_type -> gripql.QueryResult
+	37,  // 138: gripql.Query.GetVertex:output_type -> gripql.Vertex
+	38,  // 139: gripql.Query.GetEdge:output_type -> gripql.Edge
+	49,  // 140: gripql.Query.GetTimestamp:output_type -> gripql.Timestamp
+	6,   // 141: gripql.Query.GetSchema:output_type -> gripql.Graph
+	6,   // 142: gripql.Query.GetMapping:output_type -> gripql.Graph
+	51,  // 143: gripql.Query.ListGraphs:output_type -> gripql.ListGraphsResponse
+	52,  // 144: gripql.Query.ListIndices:output_type -> gripql.ListIndicesResponse
+	53,  // 145: gripql.Query.ListLabels:output_type -> gripql.ListLabelsResponse
+	54,  // 146: gripql.Query.ListTables:output_type -> gripql.TableInfo
+	55,  // 147: gripql.Query.Watch:output_type -> gripql.GraphEvent
+	40,  // 148: gripql.Job.Submit:output_type -> gripql.QueryJob
+	40,  // 149: gripql.Job.ListJobs:output_type -> gripql.QueryJob
+	42,  // 150: gripql.Job.SearchJobs:output_type -> gripql.JobStatus
+	42,  // 151: gripql.Job.DeleteJob:output_type -> gripql.JobStatus
+	42,  // 152: gripql.Job.GetJob:output_type -> gripql.JobStatus
+	39,  // 153: gripql.Job.ViewJob:output_type -> gripql.QueryResult
+	39,  // 154: gripql.Job.ResumeJob:output_type -> gripql.QueryResult
+	42,  // 155: gripql.Job.CollectJobs:output_type -> gripql.JobStatus
+	43,  // 156: gripql.Edit.AddVertex:output_type -> gripql.EditResult
+	43,  // 157: gripql.Edit.AddEdge:output_type -> gripql.EditResult
+	44,  // 158: gripql.Edit.BulkAdd:output_type -> gripql.BulkEditResult
+	43,  // 159: gripql.Edit.AddGraph:output_type -> gripql.EditResult
+	43,  // 160: gripql.Edit.DeleteGraph:output_type -> gripql.EditResult
+	43,  // 161: gripql.Edit.DeleteVertex:output_type -> gripql.EditResult
+	43,  // 162: gripql.Edit.DeleteEdge:output_type -> gripql.EditResult
+	43,  // 163: gripql.Edit.AddIndex:output_type -> gripql.EditResult
+	43,  // 164: gripql.Edit.DeleteIndex:output_type -> gripql.EditResult
+	43,  // 165: gripql.Edit.AddSchema:output_type -> gripql.EditResult
+	6,   // 166: gripql.Edit.SampleSchema:output_type -> gripql.Graph
+	43,  // 167: gripql.Edit.AddMapping:output_type -> gripql.EditResult
+	43,  // 168: gripql.StoredQuery.AddStoredQuery:output_type -> gripql.EditResult
+	57,  // 169: gripql.StoredQuery.GetStoredQuery:output_type -> gripql.QueryDefinition
+	60,  // 170: gripql.StoredQuery.ListStoredQueries:output_type -> gripql.ListStoredQueriesResponse
+	43,  // 171: gripql.StoredQuery.DeleteStoredQuery:output_type -> gripql.EditResult
+	39,  // 172: gripql.StoredQuery.RunStoredQuery:output_type -> gripql.QueryResult
+	62,  // 173: gripql.Configure.StartPlugin:output_type -> gripql.PluginStatus
+	64,  // 174: gripql.Configure.ListPlugins:output_type -> gripql.ListPluginsResponse
+	63,  // 175: gripql.Configure.ListDrivers:output_type -> gripql.ListDriversResponse
+	136, // [136:176] is the sub-list for method output_type
+	96,  // [96:136] is the sub-list for method input_type
+	96,  // [96:96] is the sub-list for extension type_name
+	96,  // [96:96] is the sub-list for extension extendee
+	0,   // [0:96] is the sub-list for field type_name
 }
 
 func init() { file_gripql_proto_init() }
@@ -4267,7 +5742,7 @@ func file_gripql_proto_init() {
 			}
 		}
 		file_gripql_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*QuerySet); i {
+			switch v := v.(*GraphQueryText); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4279,7 +5754,7 @@ func file_gripql_proto_init() {
 			}
 		}
 		file_gripql_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GraphStatement); i {
+			switch v := v.(*QuerySet); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4291,7 +5766,7 @@ func file_gripql_proto_init() {
 			}
 		}
 		file_gripql_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Range); i {
+			switch v := v.(*GraphStatement); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4303,7 +5778,7 @@ func file_gripql_proto_init() {
 			}
 		}
 		file_gripql_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*AggregationsRequest); i {
+			switch v := v.(*SubQuery); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4315,7 +5790,7 @@ func file_gripql_proto_init() {
 			}
 		}
 		file_gripql_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Aggregations); i {
+			switch v := v.(*SubQueryList); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4327,7 +5802,7 @@ func file_gripql_proto_init() {
 			}
 		}
 		file_gripql_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Aggregate); i {
+			switch v := v.(*Group); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4339,7 +5814,7 @@ func file_gripql_proto_init() {
 			}
 		}
 		file_gripql_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TermAggregation); i {
+			switch v := v.(*Range); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4351,7 +5826,7 @@ func file_gripql_proto_init() {
 			}
 		}
 		file_gripql_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PercentileAggregation); i {
+			switch v := v.(*Seek); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4363,7 +5838,7 @@ func file_gripql_proto_init() {
 			}
 		}
 		file_gripql_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*HistogramAggregation); i {
+			switch v := v.(*AggregationsRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4375,7 +5850,7 @@ func file_gripql_proto_init() {
 			}
 		}
 		file_gripql_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*FieldAggregation); i {
+			switch v := v.(*Aggregations); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4387,7 +5862,7 @@ func file_gripql_proto_init() {
 			}
 		}
 		file_gripql_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TypeAggregation); i {
+			switch v := v.(*Aggregate); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4399,7 +5874,7 @@ func file_gripql_proto_init() {
 			}
 		}
 		file_gripql_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CountAggregation); i {
+			switch v := v.(*TermAggregation); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4411,7 +5886,7 @@ func file_gripql_proto_init() {
 			}
 		}
 		file_gripql_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*NamedAggregationResult); i {
+			switch v := v.(*PercentileAggregation); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4423,7 +5898,7 @@ func file_gripql_proto_init() {
 			}
 		}
 		file_gripql_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*HasExpressionList); i {
+			switch v := v.(*HistogramAggregation); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4435,7 +5910,7 @@ func file_gripql_proto_init() {
 			}
 		}
 		file_gripql_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*HasExpression); i {
+			switch v := v.(*DateHistogramAggregation); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4447,7 +5922,7 @@ func file_gripql_proto_init() {
 			}
 		}
 		file_gripql_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*HasCondition); i {
+			switch v := v.(*GeohashGridAggregation); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4459,7 +5934,7 @@ func file_gripql_proto_init() {
 			}
 		}
 		file_gripql_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SelectStatement); i {
+			switch v := v.(*FieldAggregation); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4471,7 +5946,7 @@ func file_gripql_proto_init() {
 			}
 		}
 		file_gripql_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Selection); i {
+			switch v := v.(*TypeAggregation); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4483,7 +5958,7 @@ func file_gripql_proto_init() {
 			}
 		}
 		file_gripql_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Selections); i {
+			switch v := v.(*CountAggregation); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4495,7 +5970,7 @@ func file_gripql_proto_init() {
 			}
 		}
 		file_gripql_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Jump); i {
+			switch v := v.(*NamedAggregationResult); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4507,7 +5982,7 @@ func file_gripql_proto_init() {
 			}
 		}
 		file_gripql_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Set); i {
+			switch v := v.(*HasExpressionList); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4519,7 +5994,7 @@ func file_gripql_proto_init() {
 			}
 		}
 		file_gripql_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Increment); i {
+			switch v := v.(*HasExpression); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4531,7 +6006,7 @@ func file_gripql_proto_init() {
 			}
 		}
 		file_gripql_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Vertex); i {
+			switch v := v.(*HasCondition); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4543,7 +6018,7 @@ func file_gripql_proto_init() {
 			}
 		}
 		file_gripql_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Edge); i {
+			switch v := v.(*SelectStatement); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4555,7 +6030,7 @@ func file_gripql_proto_init() {
 			}
 		}
 		file_gripql_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*QueryResult); i {
+			switch v := v.(*Selection); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4567,7 +6042,7 @@ func file_gripql_proto_init() {
 			}
 		}
 		file_gripql_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*QueryJob); i {
+			switch v := v.(*Selections); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4579,7 +6054,7 @@ func file_gripql_proto_init() {
 			}
 		}
 		file_gripql_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ExtendQuery); i {
+			switch v := v.(*Jump); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4591,7 +6066,7 @@ func file_gripql_proto_init() {
 			}
 		}
 		file_gripql_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*JobStatus); i {
+			switch v := v.(*Set); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4603,7 +6078,7 @@ func file_gripql_proto_init() {
 			}
 		}
 		file_gripql_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*EditResult); i {
+			switch v := v.(*Increment); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4615,7 +6090,7 @@ func file_gripql_proto_init() {
 			}
 		}
 		file_gripql_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*BulkEditResult); i {
+			switch v := v.(*Vertex); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4627,7 +6102,7 @@ func file_gripql_proto_init() {
 			}
 		}
 		file_gripql_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GraphElement); i {
+			switch v := v.(*Edge); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4639,7 +6114,7 @@ func file_gripql_proto_init() {
 			}
 		}
 		file_gripql_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GraphID); i {
+			switch v := v.(*QueryResult); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4651,7 +6126,7 @@ func file_gripql_proto_init() {
 			}
 		}
 		file_gripql_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ElementID); i {
+			switch v := v.(*QueryJob); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4663,7 +6138,7 @@ func file_gripql_proto_init() {
 			}
 		}
 		file_gripql_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*IndexID); i {
+			switch v := v.(*ExtendQuery); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4675,7 +6150,7 @@ func file_gripql_proto_init() {
 			}
 		}
 		file_gripql_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Timestamp); i {
+			switch v := v.(*JobStatus); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4687,7 +6162,7 @@ func file_gripql_proto_init() {
 			}
 		}
 		file_gripql_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Empty); i {
+			switch v := v.(*EditResult); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4699,7 +6174,7 @@ func file_gripql_proto_init() {
 			}
 		}
 		file_gripql_proto_msgTypes[38].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ListGraphsResponse); i {
+			switch v := v.(*BulkEditResult); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4711,7 +6186,7 @@ func file_gripql_proto_init() {
 			}
 		}
 		file_gripql_proto_msgTypes[39].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ListIndicesResponse); i {
+			switch v := v.(*GraphElement); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4723,7 +6198,7 @@ func file_gripql_proto_init() {
 			}
 		}
 		file_gripql_proto_msgTypes[40].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ListLabelsResponse); i {
+			switch v := v.(*GraphID); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4735,7 +6210,7 @@ func file_gripql_proto_init() {
 			}
 		}
 		file_gripql_proto_msgTypes[41].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TableInfo); i {
+			switch v := v.(*ElementID); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4747,7 +6222,7 @@ func file_gripql_proto_init() {
 			}
 		}
 		file_gripql_proto_msgTypes[42].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PluginConfig); i {
+			switch v := v.(*IndexID); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4759,7 +6234,7 @@ func file_gripql_proto_init() {
 			}
 		}
 		file_gripql_proto_msgTypes[43].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PluginStatus); i {
+			switch v := v.(*Timestamp); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4771,7 +6246,7 @@ func file_gripql_proto_init() {
 			}
 		}
 		file_gripql_proto_msgTypes[44].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ListDriversResponse); i {
+			switch v := v.(*Empty); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4783,6 +6258,162 @@ func file_gripql_proto_init() {
 			}
 		}
 		file_gripql_proto_msgTypes[45].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListGraphsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gripql_proto_msgTypes[46].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListIndicesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gripql_proto_msgTypes[47].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListLabelsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gripql_proto_msgTypes[48].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TableInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gripql_proto_msgTypes[49].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GraphEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gripql_proto_msgTypes[50].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*QueryParameter); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gripql_proto_msgTypes[51].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*QueryDefinition); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gripql_proto_msgTypes[52].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StoredQueryID); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gripql_proto_msgTypes[53].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StoredQueryCall); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gripql_proto_msgTypes[54].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListStoredQueriesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gripql_proto_msgTypes[55].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PluginConfig); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gripql_proto_msgTypes[56].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PluginStatus); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gripql_proto_msgTypes[57].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListDriversResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gripql_proto_msgTypes[58].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ListPluginsResponse); i {
 			case 0:
 				return &v.state
@@ -4795,7 +6426,7 @@ func file_gripql_proto_init() {
 			}
 		}
 	}
-	file_gripql_proto_msgTypes[3].OneofWrappers = []interface{}{
+	file_gripql_proto_msgTypes[4].OneofWrappers = []interface{}{
 		(*GraphStatement_V)(nil),
 		(*GraphStatement_E)(nil),
 		(*GraphStatement_In)(nil),
@@ -4818,36 +6449,47 @@ func file_gripql_proto_init() {
 		(*GraphStatement_HasKey)(nil),
 		(*GraphStatement_HasId)(nil),
 		(*GraphStatement_Distinct)(nil),
+		(*GraphStatement_Sort)(nil),
+		(*GraphStatement_Seek)(nil),
 		(*GraphStatement_Fields)(nil),
 		(*GraphStatement_Unwind)(nil),
 		(*GraphStatement_Count)(nil),
 		(*GraphStatement_Aggregate)(nil),
 		(*GraphStatement_Render)(nil),
 		(*GraphStatement_Path)(nil),
+		(*GraphStatement_Group)(nil),
+		(*GraphStatement_Fold)(nil),
 		(*GraphStatement_Mark)(nil),
 		(*GraphStatement_Jump)(nil),
 		(*GraphStatement_Set)(nil),
 		(*GraphStatement_Increment)(nil),
+		(*GraphStatement_Where)(nil),
+		(*GraphStatement_Not)(nil),
+		(*GraphStatement_Union)(nil),
+		(*GraphStatement_Optional)(nil),
+		(*GraphStatement_Coalesce)(nil),
 	}
-	file_gripql_proto_msgTypes[7].OneofWrappers = []interface{}{
+	file_gripql_proto_msgTypes[12].OneofWrappers = []interface{}{
 		(*Aggregate_Term)(nil),
 		(*Aggregate_Percentile)(nil),
 		(*Aggregate_Histogram)(nil),
 		(*Aggregate_Field)(nil),
 		(*Aggregate_Type)(nil),
 		(*Aggregate_Count)(nil),
+		(*Aggregate_DateHistogram)(nil),
+		(*Aggregate_GeohashGrid)(nil),
 	}
-	file_gripql_proto_msgTypes[16].OneofWrappers = []interface{}{
+	file_gripql_proto_msgTypes[23].OneofWrappers = []interface{}{
 		(*HasExpression_And)(nil),
 		(*HasExpression_Or)(nil),
 		(*HasExpression_Not)(nil),
 		(*HasExpression_Condition)(nil),
 	}
-	file_gripql_proto_msgTypes[19].OneofWrappers = []interface{}{
+	file_gripql_proto_msgTypes[26].OneofWrappers = []interface{}{
 		(*Selection_Vertex)(nil),
 		(*Selection_Edge)(nil),
 	}
-	file_gripql_proto_msgTypes[26].OneofWrappers = []interface{}{
+	file_gripql_proto_msgTypes[33].OneofWrappers = []interface{}{
 		(*QueryResult_Vertex)(nil),
 		(*QueryResult_Edge)(nil),
 		(*QueryResult_Aggregations)(nil),
@@ -4855,16 +6497,17 @@ func file_gripql_proto_init() {
 		(*QueryResult_Render)(nil),
 		(*QueryResult_Count)(nil),
 		(*QueryResult_Path)(nil),
+		(*QueryResult_Arrow)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_gripql_proto_rawDesc,
-			NumEnums:      3,
-			NumMessages:   49,
+			NumEnums:      6,
+			NumMessages:   62,
 			NumExtensions: 0,
-			NumServices:   4,
+			NumServices:   5,
 		},
 		GoTypes:           file_gripql_proto_goTypes,
 		DependencyIndexes: file_gripql_proto_depIdxs,
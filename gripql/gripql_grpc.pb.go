@@ -23,6 +23,7 @@ const _ = grpc.SupportPackageIsVersion7
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type QueryClient interface {
 	Traversal(ctx context.Context, in *GraphQuery, opts ...grpc.CallOption) (Query_TraversalClient, error)
+	TraversalText(ctx context.Context, in *GraphQueryText, opts ...grpc.CallOption) (Query_TraversalTextClient, error)
 	GetVertex(ctx context.Context, in *ElementID, opts ...grpc.CallOption) (*Vertex, error)
 	GetEdge(ctx context.Context, in *ElementID, opts ...grpc.CallOption) (*Edge, error)
 	GetTimestamp(ctx context.Context, in *GraphID, opts ...grpc.CallOption) (*Timestamp, error)
@@ -32,6 +33,7 @@ type QueryClient interface {
 	ListIndices(ctx context.Context, in *GraphID, opts ...grpc.CallOption) (*ListIndicesResponse, error)
 	ListLabels(ctx context.Context, in *GraphID, opts ...grpc.CallOption) (*ListLabelsResponse, error)
 	ListTables(ctx context.Context, in *Empty, opts ...grpc.CallOption) (Query_ListTablesClient, error)
+	Watch(ctx context.Context, in *GraphID, opts ...grpc.CallOption) (Query_WatchClient, error)
 }
 
 type queryClient struct {
@@ -74,6 +76,38 @@ func (x *queryTraversalClient) Recv() (*QueryResult, error) {
 	return m, nil
 }
 
+func (c *queryClient) TraversalText(ctx context.Context, in *GraphQueryText, opts ...grpc.CallOption) (Query_TraversalTextClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Query_ServiceDesc.Streams[1], "/gripql.Query/TraversalText", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &queryTraversalTextClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Query_TraversalTextClient interface {
+	Recv() (*QueryResult, error)
+	grpc.ClientStream
+}
+
+type queryTraversalTextClient struct {
+	grpc.ClientStream
+}
+
+func (x *queryTraversalTextClient) Recv() (*QueryResult, error) {
+	m := new(QueryResult)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *queryClient) GetVertex(ctx context.Context, in *ElementID, opts ...grpc.CallOption) (*Vertex, error) {
 	out := new(Vertex)
 	err := c.cc.Invoke(ctx, "/gripql.Query/GetVertex", in, out, opts...)
@@ -147,7 +181,7 @@ func (c *queryClient) ListLabels(ctx context.Context, in *GraphID, opts ...grpc.
 }
 
 func (c *queryClient) ListTables(ctx context.Context, in *Empty, opts ...grpc.CallOption) (Query_ListTablesClient, error) {
-	stream, err := c.cc.NewStream(ctx, &Query_ServiceDesc.Streams[1], "/gripql.Query/ListTables", opts...)
+	stream, err := c.cc.NewStream(ctx, &Query_ServiceDesc.Streams[2], "/gripql.Query/ListTables", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -178,11 +212,44 @@ func (x *queryListTablesClient) Recv() (*TableInfo, error) {
 	return m, nil
 }
 
+func (c *queryClient) Watch(ctx context.Context, in *GraphID, opts ...grpc.CallOption) (Query_WatchClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Query_ServiceDesc.Streams[3], "/gripql.Query/Watch", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &queryWatchClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Query_WatchClient interface {
+	Recv() (*GraphEvent, error)
+	grpc.ClientStream
+}
+
+type queryWatchClient struct {
+	grpc.ClientStream
+}
+
+func (x *queryWatchClient) Recv() (*GraphEvent, error) {
+	m := new(GraphEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // QueryServer is the server API for Query service.
 // All implementations must embed UnimplementedQueryServer
 // for forward compatibility
 type QueryServer interface {
 	Traversal(*GraphQuery, Query_TraversalServer) error
+	TraversalText(*GraphQueryText, Query_TraversalTextServer) error
 	GetVertex(context.Context, *ElementID) (*Vertex, error)
 	GetEdge(context.Context, *ElementID) (*Edge, error)
 	GetTimestamp(context.Context, *GraphID) (*Timestamp, error)
@@ -192,6 +259,7 @@ type QueryServer interface {
 	ListIndices(context.Context, *GraphID) (*ListIndicesResponse, error)
 	ListLabels(context.Context, *GraphID) (*ListLabelsResponse, error)
 	ListTables(*Empty, Query_ListTablesServer) error
+	Watch(*GraphID, Query_WatchServer) error
 	mustEmbedUnimplementedQueryServer()
 }
 
@@ -202,6 +270,9 @@ type UnimplementedQueryServer struct {
 func (UnimplementedQueryServer) Traversal(*GraphQuery, Query_TraversalServer) error {
 	return status.Errorf(codes.Unimplemented, "method Traversal not implemented")
 }
+func (UnimplementedQueryServer) TraversalText(*GraphQueryText, Query_TraversalTextServer) error {
+	return status.Errorf(codes.Unimplemented, "method TraversalText not implemented")
+}
 func (UnimplementedQueryServer) GetVertex(context.Context, *ElementID) (*Vertex, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetVertex not implemented")
 }
@@ -229,6 +300,9 @@ func (UnimplementedQueryServer) ListLabels(context.Context, *GraphID) (*ListLabe
 func (UnimplementedQueryServer) ListTables(*Empty, Query_ListTablesServer) error {
 	return status.Errorf(codes.Unimplemented, "method ListTables not implemented")
 }
+func (UnimplementedQueryServer) Watch(*GraphID, Query_WatchServer) error {
+	return status.Errorf(codes.Unimplemented, "method Watch not implemented")
+}
 func (UnimplementedQueryServer) mustEmbedUnimplementedQueryServer() {}
 
 // UnsafeQueryServer may be embedded to opt out of forward compatibility for this service.
@@ -263,6 +337,27 @@ func (x *queryTraversalServer) Send(m *QueryResult) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _Query_TraversalText_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GraphQueryText)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(QueryServer).TraversalText(m, &queryTraversalTextServer{stream})
+}
+
+type Query_TraversalTextServer interface {
+	Send(*QueryResult) error
+	grpc.ServerStream
+}
+
+type queryTraversalTextServer struct {
+	grpc.ServerStream
+}
+
+func (x *queryTraversalTextServer) Send(m *QueryResult) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 func _Query_GetVertex_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ElementID)
 	if err := dec(in); err != nil {
@@ -428,6 +523,27 @@ func (x *queryListTablesServer) Send(m *TableInfo) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _Query_Watch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GraphID)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(QueryServer).Watch(m, &queryWatchServer{stream})
+}
+
+type Query_WatchServer interface {
+	Send(*GraphEvent) error
+	grpc.ServerStream
+}
+
+type queryWatchServer struct {
+	grpc.ServerStream
+}
+
+func (x *queryWatchServer) Send(m *GraphEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 // Query_ServiceDesc is the grpc.ServiceDesc for Query service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -474,11 +590,21 @@ var Query_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _Query_Traversal_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "TraversalText",
+			Handler:       _Query_TraversalText_Handler,
+			ServerStreams: true,
+		},
 		{
 			StreamName:    "ListTables",
 			Handler:       _Query_ListTables_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "Watch",
+			Handler:       _Query_Watch_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "gripql.proto",
 }
@@ -494,6 +620,7 @@ type JobClient interface {
 	GetJob(ctx context.Context, in *QueryJob, opts ...grpc.CallOption) (*JobStatus, error)
 	ViewJob(ctx context.Context, in *QueryJob, opts ...grpc.CallOption) (Job_ViewJobClient, error)
 	ResumeJob(ctx context.Context, in *ExtendQuery, opts ...grpc.CallOption) (Job_ResumeJobClient, error)
+	CollectJobs(ctx context.Context, in *GraphID, opts ...grpc.CallOption) (Job_CollectJobsClient, error)
 }
 
 type jobClient struct {
@@ -659,6 +786,38 @@ func (x *jobResumeJobClient) Recv() (*QueryResult, error) {
 	return m, nil
 }
 
+func (c *jobClient) CollectJobs(ctx context.Context, in *GraphID, opts ...grpc.CallOption) (Job_CollectJobsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Job_ServiceDesc.Streams[4], "/gripql.Job/CollectJobs", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &jobCollectJobsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Job_CollectJobsClient interface {
+	Recv() (*JobStatus, error)
+	grpc.ClientStream
+}
+
+type jobCollectJobsClient struct {
+	grpc.ClientStream
+}
+
+func (x *jobCollectJobsClient) Recv() (*JobStatus, error) {
+	m := new(JobStatus)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // JobServer is the server API for Job service.
 // All implementations must embed UnimplementedJobServer
 // for forward compatibility
@@ -670,6 +829,7 @@ type JobServer interface {
 	GetJob(context.Context, *QueryJob) (*JobStatus, error)
 	ViewJob(*QueryJob, Job_ViewJobServer) error
 	ResumeJob(*ExtendQuery, Job_ResumeJobServer) error
+	CollectJobs(*GraphID, Job_CollectJobsServer) error
 	mustEmbedUnimplementedJobServer()
 }
 
@@ -698,6 +858,9 @@ func (UnimplementedJobServer) ViewJob(*QueryJob, Job_ViewJobServer) error {
 func (UnimplementedJobServer) ResumeJob(*ExtendQuery, Job_ResumeJobServer) error {
 	return status.Errorf(codes.Unimplemented, "method ResumeJob not implemented")
 }
+func (UnimplementedJobServer) CollectJobs(*GraphID, Job_CollectJobsServer) error {
+	return status.Errorf(codes.Unimplemented, "method CollectJobs not implemented")
+}
 func (UnimplementedJobServer) mustEmbedUnimplementedJobServer() {}
 
 // UnsafeJobServer may be embedded to opt out of forward compatibility for this service.
@@ -849,6 +1012,27 @@ func (x *jobResumeJobServer) Send(m *QueryResult) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _Job_CollectJobs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GraphID)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(JobServer).CollectJobs(m, &jobCollectJobsServer{stream})
+}
+
+type Job_CollectJobsServer interface {
+	Send(*JobStatus) error
+	grpc.ServerStream
+}
+
+type jobCollectJobsServer struct {
+	grpc.ServerStream
+}
+
+func (x *jobCollectJobsServer) Send(m *JobStatus) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 // Job_ServiceDesc is the grpc.ServiceDesc for Job service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -890,6 +1074,11 @@ var Job_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _Job_ResumeJob_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "CollectJobs",
+			Handler:       _Job_CollectJobs_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "gripql.proto",
 }
@@ -1411,6 +1600,264 @@ var Edit_ServiceDesc = grpc.ServiceDesc{
 	Metadata: "gripql.proto",
 }
 
+// StoredQueryClient is the client API for StoredQuery service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type StoredQueryClient interface {
+	AddStoredQuery(ctx context.Context, in *QueryDefinition, opts ...grpc.CallOption) (*EditResult, error)
+	GetStoredQuery(ctx context.Context, in *StoredQueryID, opts ...grpc.CallOption) (*QueryDefinition, error)
+	ListStoredQueries(ctx context.Context, in *GraphID, opts ...grpc.CallOption) (*ListStoredQueriesResponse, error)
+	DeleteStoredQuery(ctx context.Context, in *StoredQueryID, opts ...grpc.CallOption) (*EditResult, error)
+	RunStoredQuery(ctx context.Context, in *StoredQueryCall, opts ...grpc.CallOption) (StoredQuery_RunStoredQueryClient, error)
+}
+
+type storedQueryClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewStoredQueryClient(cc grpc.ClientConnInterface) StoredQueryClient {
+	return &storedQueryClient{cc}
+}
+
+func (c *storedQueryClient) AddStoredQuery(ctx context.Context, in *QueryDefinition, opts ...grpc.CallOption) (*EditResult, error) {
+	out := new(EditResult)
+	err := c.cc.Invoke(ctx, "/gripql.StoredQuery/AddStoredQuery", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storedQueryClient) GetStoredQuery(ctx context.Context, in *StoredQueryID, opts ...grpc.CallOption) (*QueryDefinition, error) {
+	out := new(QueryDefinition)
+	err := c.cc.Invoke(ctx, "/gripql.StoredQuery/GetStoredQuery", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storedQueryClient) ListStoredQueries(ctx context.Context, in *GraphID, opts ...grpc.CallOption) (*ListStoredQueriesResponse, error) {
+	out := new(ListStoredQueriesResponse)
+	err := c.cc.Invoke(ctx, "/gripql.StoredQuery/ListStoredQueries", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storedQueryClient) DeleteStoredQuery(ctx context.Context, in *StoredQueryID, opts ...grpc.CallOption) (*EditResult, error) {
+	out := new(EditResult)
+	err := c.cc.Invoke(ctx, "/gripql.StoredQuery/DeleteStoredQuery", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storedQueryClient) RunStoredQuery(ctx context.Context, in *StoredQueryCall, opts ...grpc.CallOption) (StoredQuery_RunStoredQueryClient, error) {
+	stream, err := c.cc.NewStream(ctx, &StoredQuery_ServiceDesc.Streams[0], "/gripql.StoredQuery/RunStoredQuery", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &storedQueryRunStoredQueryClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type StoredQuery_RunStoredQueryClient interface {
+	Recv() (*QueryResult, error)
+	grpc.ClientStream
+}
+
+type storedQueryRunStoredQueryClient struct {
+	grpc.ClientStream
+}
+
+func (x *storedQueryRunStoredQueryClient) Recv() (*QueryResult, error) {
+	m := new(QueryResult)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// StoredQueryServer is the server API for StoredQuery service.
+// All implementations must embed UnimplementedStoredQueryServer
+// for forward compatibility
+type StoredQueryServer interface {
+	AddStoredQuery(context.Context, *QueryDefinition) (*EditResult, error)
+	GetStoredQuery(context.Context, *StoredQueryID) (*QueryDefinition, error)
+	ListStoredQueries(context.Context, *GraphID) (*ListStoredQueriesResponse, error)
+	DeleteStoredQuery(context.Context, *StoredQueryID) (*EditResult, error)
+	RunStoredQuery(*StoredQueryCall, StoredQuery_RunStoredQueryServer) error
+	mustEmbedUnimplementedStoredQueryServer()
+}
+
+// UnimplementedStoredQueryServer must be embedded to have forward compatible implementations.
+type UnimplementedStoredQueryServer struct {
+}
+
+func (UnimplementedStoredQueryServer) AddStoredQuery(context.Context, *QueryDefinition) (*EditResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddStoredQuery not implemented")
+}
+func (UnimplementedStoredQueryServer) GetStoredQuery(context.Context, *StoredQueryID) (*QueryDefinition, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStoredQuery not implemented")
+}
+func (UnimplementedStoredQueryServer) ListStoredQueries(context.Context, *GraphID) (*ListStoredQueriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListStoredQueries not implemented")
+}
+func (UnimplementedStoredQueryServer) DeleteStoredQuery(context.Context, *StoredQueryID) (*EditResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteStoredQuery not implemented")
+}
+func (UnimplementedStoredQueryServer) RunStoredQuery(*StoredQueryCall, StoredQuery_RunStoredQueryServer) error {
+	return status.Errorf(codes.Unimplemented, "method RunStoredQuery not implemented")
+}
+func (UnimplementedStoredQueryServer) mustEmbedUnimplementedStoredQueryServer() {}
+
+// UnsafeStoredQueryServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to StoredQueryServer will
+// result in compilation errors.
+type UnsafeStoredQueryServer interface {
+	mustEmbedUnimplementedStoredQueryServer()
+}
+
+func RegisterStoredQueryServer(s grpc.ServiceRegistrar, srv StoredQueryServer) {
+	s.RegisterService(&StoredQuery_ServiceDesc, srv)
+}
+
+func _StoredQuery_AddStoredQuery_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryDefinition)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StoredQueryServer).AddStoredQuery(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gripql.StoredQuery/AddStoredQuery",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StoredQueryServer).AddStoredQuery(ctx, req.(*QueryDefinition))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StoredQuery_GetStoredQuery_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StoredQueryID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StoredQueryServer).GetStoredQuery(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gripql.StoredQuery/GetStoredQuery",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StoredQueryServer).GetStoredQuery(ctx, req.(*StoredQueryID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StoredQuery_ListStoredQueries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GraphID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StoredQueryServer).ListStoredQueries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gripql.StoredQuery/ListStoredQueries",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StoredQueryServer).ListStoredQueries(ctx, req.(*GraphID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StoredQuery_DeleteStoredQuery_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StoredQueryID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StoredQueryServer).DeleteStoredQuery(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gripql.StoredQuery/DeleteStoredQuery",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StoredQueryServer).DeleteStoredQuery(ctx, req.(*StoredQueryID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StoredQuery_RunStoredQuery_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StoredQueryCall)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(StoredQueryServer).RunStoredQuery(m, &storedQueryRunStoredQueryServer{stream})
+}
+
+type StoredQuery_RunStoredQueryServer interface {
+	Send(*QueryResult) error
+	grpc.ServerStream
+}
+
+type storedQueryRunStoredQueryServer struct {
+	grpc.ServerStream
+}
+
+func (x *storedQueryRunStoredQueryServer) Send(m *QueryResult) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// StoredQuery_ServiceDesc is the grpc.ServiceDesc for StoredQuery service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var StoredQuery_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "gripql.StoredQuery",
+	HandlerType: (*StoredQueryServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "AddStoredQuery",
+			Handler:    _StoredQuery_AddStoredQuery_Handler,
+		},
+		{
+			MethodName: "GetStoredQuery",
+			Handler:    _StoredQuery_GetStoredQuery_Handler,
+		},
+		{
+			MethodName: "ListStoredQueries",
+			Handler:    _StoredQuery_ListStoredQueries_Handler,
+		},
+		{
+			MethodName: "DeleteStoredQuery",
+			Handler:    _StoredQuery_DeleteStoredQuery_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "RunStoredQuery",
+			Handler:       _StoredQuery_RunStoredQuery_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "gripql.proto",
+}
+
 // ConfigureClient is the client API for Configure service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
@@ -2,14 +2,39 @@ package gripql
 
 import "google.golang.org/protobuf/types/known/structpb"
 
+// Ref is the path of a field of the traveler, such as "$a.age". Passed as the
+// value of a condition, the key is compared to the value of that field.
+type Ref string
+
+func conditionValue(value interface{}) (*structpb.Value, string) {
+	if r, ok := value.(Ref); ok {
+		return nil, string(r)
+	}
+	sValue, _ := structpb.NewValue(value)
+	return sValue, ""
+}
+
+// conditionValues reads the values of Within and Without, where a single Ref
+// refers to a list
+func conditionValues(values []interface{}) (*structpb.Value, string) {
+	if len(values) == 1 {
+		if r, ok := values[0].(Ref); ok {
+			return nil, string(r)
+		}
+	}
+	sValue, _ := structpb.NewValue(values)
+	return sValue, ""
+}
+
 // Eq asserts that the value the provided key resolves to is equal to the provided value.
 func Eq(key string, value interface{}) *HasExpression {
-	sValue, _ := structpb.NewValue(value)
+	sValue, ref := conditionValue(value)
 	return &HasExpression{
 		Expression: &HasExpression_Condition{
 			Condition: &HasCondition{
 				Key:       key,
 				Value:     sValue,
+				ValueRef:  ref,
 				Condition: Condition_EQ,
 			},
 		},
@@ -18,12 +43,13 @@ func Eq(key string, value interface{}) *HasExpression {
 
 // Neq asserts that the value the provided key resolves to is not equal to the provided value.
 func Neq(key string, value interface{}) *HasExpression {
-	sValue, _ := structpb.NewValue(value)
+	sValue, ref := conditionValue(value)
 	return &HasExpression{
 		Expression: &HasExpression_Condition{
 			Condition: &HasCondition{
 				Key:       key,
 				Value:     sValue,
+				ValueRef:  ref,
 				Condition: Condition_NEQ,
 			},
 		},
@@ -32,12 +58,13 @@ func Neq(key string, value interface{}) *HasExpression {
 
 // Gt asserts that the value the provided key resolves to is greater than the provided value.
 func Gt(key string, value interface{}) *HasExpression {
-	sValue, _ := structpb.NewValue(value)
+	sValue, ref := conditionValue(value)
 	return &HasExpression{
 		Expression: &HasExpression_Condition{
 			Condition: &HasCondition{
 				Key:       key,
 				Value:     sValue,
+				ValueRef:  ref,
 				Condition: Condition_GT,
 			},
 		},
@@ -46,12 +73,13 @@ func Gt(key string, value interface{}) *HasExpression {
 
 // Gte asserts that the value the provided key resolves to is greater than or equal to the provided value.
 func Gte(key string, value interface{}) *HasExpression {
-	sValue, _ := structpb.NewValue(value)
+	sValue, ref := conditionValue(value)
 	return &HasExpression{
 		Expression: &HasExpression_Condition{
 			Condition: &HasCondition{
 				Key:       key,
 				Value:     sValue,
+				ValueRef:  ref,
 				Condition: Condition_GTE,
 			},
 		},
@@ -60,12 +88,13 @@ func Gte(key string, value interface{}) *HasExpression {
 
 // Lt asserts that the value the provided key resolves to is less than the provided value.
 func Lt(key string, value interface{}) *HasExpression {
-	sValue, _ := structpb.NewValue(value)
+	sValue, ref := conditionValue(value)
 	return &HasExpression{
 		Expression: &HasExpression_Condition{
 			Condition: &HasCondition{
 				Key:       key,
 				Value:     sValue,
+				ValueRef:  ref,
 				Condition: Condition_LT,
 			},
 		},
@@ -74,12 +103,13 @@ func Lt(key string, value interface{}) *HasExpression {
 
 // Lte asserts that the value the provided key resolves to is less than or equal to the provided value.
 func Lte(key string, value interface{}) *HasExpression {
-	sValue, _ := structpb.NewValue(value)
+	sValue, ref := conditionValue(value)
 	return &HasExpression{
 		Expression: &HasExpression_Condition{
 			Condition: &HasCondition{
 				Key:       key,
 				Value:     sValue,
+				ValueRef:  ref,
 				Condition: Condition_LTE,
 			},
 		},
@@ -89,12 +119,13 @@ func Lte(key string, value interface{}) *HasExpression {
 // Inside asserts that the number the provided key resolves to is greater than
 // the first provided number and less than the second.
 func Inside(key string, value interface{}) *HasExpression {
-	sValue, _ := structpb.NewValue(value)
+	sValue, ref := conditionValue(value)
 	return &HasExpression{
 		Expression: &HasExpression_Condition{
 			Condition: &HasCondition{
 				Key:       key,
 				Value:     sValue,
+				ValueRef:  ref,
 				Condition: Condition_INSIDE,
 			},
 		},
@@ -104,12 +135,13 @@ func Inside(key string, value interface{}) *HasExpression {
 // Outside asserts that the number the provided key resolves to is less than
 // the first provided number and greater than the second.
 func Outside(key string, value interface{}) *HasExpression {
-	sValue, _ := structpb.NewValue(value)
+	sValue, ref := conditionValue(value)
 	return &HasExpression{
 		Expression: &HasExpression_Condition{
 			Condition: &HasCondition{
 				Key:       key,
 				Value:     sValue,
+				ValueRef:  ref,
 				Condition: Condition_OUTSIDE,
 			},
 		},
@@ -119,12 +151,13 @@ func Outside(key string, value interface{}) *HasExpression {
 // Between asserts that the number the provided key resolves to is greater than
 // or equal to the first provided number and less than the second.
 func Between(key string, value interface{}) *HasExpression {
-	sValue, _ := structpb.NewValue(value)
+	sValue, ref := conditionValue(value)
 	return &HasExpression{
 		Expression: &HasExpression_Condition{
 			Condition: &HasCondition{
 				Key:       key,
 				Value:     sValue,
+				ValueRef:  ref,
 				Condition: Condition_BETWEEN,
 			},
 		},
@@ -133,12 +166,13 @@ func Between(key string, value interface{}) *HasExpression {
 
 // Within asserts that the value the provided key resolves to is in the provided list of values.
 func Within(key string, values ...interface{}) *HasExpression {
-	sValue, _ := structpb.NewValue(values)
+	sValue, ref := conditionValues(values)
 	return &HasExpression{
 		Expression: &HasExpression_Condition{
 			Condition: &HasCondition{
 				Key:       key,
 				Value:     sValue,
+				ValueRef:  ref,
 				Condition: Condition_WITHIN,
 			},
 		},
@@ -147,12 +181,13 @@ func Within(key string, values ...interface{}) *HasExpression {
 
 // Without asserts that the value the provided key resolves to is not in the provided list of values.
 func Without(key string, values ...interface{}) *HasExpression {
-	sValue, _ := structpb.NewValue(values)
+	sValue, ref := conditionValues(values)
 	return &HasExpression{
 		Expression: &HasExpression_Condition{
 			Condition: &HasCondition{
 				Key:       key,
 				Value:     sValue,
+				ValueRef:  ref,
 				Condition: Condition_WITHOUT,
 			},
 		},
@@ -161,18 +196,59 @@ func Without(key string, values ...interface{}) *HasExpression {
 
 // Contains asserts that the array the provided key resolves to contains the provided value.
 func Contains(key string, value interface{}) *HasExpression {
-	sValue, _ := structpb.NewValue(value)
+	sValue, ref := conditionValue(value)
 	return &HasExpression{
 		Expression: &HasExpression_Condition{
 			Condition: &HasCondition{
 				Key:       key,
 				Value:     sValue,
+				ValueRef:  ref,
 				Condition: Condition_CONTAINS,
 			},
 		},
 	}
 }
 
+// geoPoint is the condition value form of a point
+func geoPoint(lat, lon float64) map[string]interface{} {
+	return map[string]interface{}{"lat": lat, "lon": lon}
+}
+
+func geoCondition(key string, value []interface{}, cond Condition) *HasExpression {
+	sValue, _ := structpb.NewValue(value)
+	return &HasExpression{
+		Expression: &HasExpression_Condition{
+			Condition: &HasCondition{
+				Key:       key,
+				Value:     sValue,
+				Condition: cond,
+			},
+		},
+	}
+}
+
+// GeoRadius asserts that the point the provided key resolves to is within the
+// provided distance, in meters, of a center point.
+func GeoRadius(key string, lat, lon, meters float64) *HasExpression {
+	return geoCondition(key, []interface{}{geoPoint(lat, lon), meters}, Condition_GEO_RADIUS)
+}
+
+// GeoBox asserts that the point the provided key resolves to is inside the box
+// between two latitudes and two longitudes.
+func GeoBox(key string, south, west, north, east float64) *HasExpression {
+	return geoCondition(key, []interface{}{geoPoint(south, west), geoPoint(north, east)}, Condition_GEO_BOX)
+}
+
+// GeoPolygon asserts that the point the provided key resolves to is inside the
+// polygon with the provided [lat, lon] corners.
+func GeoPolygon(key string, corners ...[2]float64) *HasExpression {
+	value := make([]interface{}, len(corners))
+	for i, c := range corners {
+		value[i] = geoPoint(c[0], c[1])
+	}
+	return geoCondition(key, value, Condition_GEO_POLYGON)
+}
+
 // And repreesents a logical "and" of two or more HasExpressions
 func And(expressions ...*HasExpression) *HasExpression {
 	return &HasExpression{
@@ -9,6 +9,14 @@ function process(val) {
 	return val
 }
 
+function subqueries(args) {
+	var list = Array.prototype.slice.call(args)
+	if (list.length == 1 && Array.isArray(list[0])) {
+		list = list[0]
+	}
+	return list.map(function(q) { return {'query': q.query} })
+}
+
 function query() {
 	return {
 		query: [],
@@ -108,6 +116,14 @@ function query() {
 			this.query.push({'render': r})
 			return this
 		},
+		group: function(key, value) {
+			this.query.push({'group': {'key': key, 'value': value}})
+			return this
+		},
+		fold: function(r) {
+			this.query.push({'fold': r})
+			return this
+		},
 		has: function(expression) {
 			this.query.push({'has': expression})
 			return this
@@ -124,6 +140,29 @@ function query() {
 			this.query.push({'hasKey': process(key)})
 			return this
 		},
+		where: function(subquery) {
+			this.query.push({'where': {'query': subquery.query}})
+			return this
+		},
+		exists: function(subquery) {
+			return this.where(subquery)
+		},
+		not_: function(subquery) {
+			this.query.push({'not': {'query': subquery.query}})
+			return this
+		},
+		union: function() {
+			this.query.push({'union': {'queries': subqueries(arguments)}})
+			return this
+		},
+		optional: function(subquery) {
+			this.query.push({'optional': {'query': subquery.query}})
+			return this
+		},
+		coalesce: function() {
+			this.query.push({'coalesce': {'queries': subqueries(arguments)}})
+			return this
+		},
 		set: function(key, value) {
 			this.query.push({'set':{'key':key, 'value':value}})
 			return this
@@ -160,52 +199,86 @@ function not_(expression) {
 	return {'not': expression}
 }
 
+// Ref is the path of a field of the traveler, such as "$a.age", that a
+// condition compares its key to
+function Ref(path) {
+	this.path = path
+}
+
+function ref(path) {
+	return new Ref(path)
+}
+
+function condition(key, value, cond) {
+	if (value instanceof Ref) {
+		return {'condition': {'key': key, 'valueRef': value.path, 'condition': cond}}
+	}
+	return {'condition': {'key': key, 'value': value, 'condition': cond}}
+}
+
 function eq(key, value) {
-	return {'condition': {'key': key, 'value': value, 'condition': 'EQ'}}
+	return condition(key, value, 'EQ')
 }
 
 function neq(key, value) {
-	return {'condition': {'key': key, 'value': value, 'condition': 'NEQ'}}
+	return condition(key, value, 'NEQ')
 }
 
 function gt(key, value) {
-	return {'condition': {'key': key, 'value': value, 'condition': 'GT'}}
+	return condition(key, value, 'GT')
 }
 
 function gte(key, value) {
-	return {'condition': {'key': key, 'value': value, 'condition': 'GTE'}}
+	return condition(key, value, 'GTE')
 }
 
 function lt(key, value) {
-	return {'condition': {'key': key, 'value': value, 'condition': 'LT'}}
+	return condition(key, value, 'LT')
 }
 
 function lte(key, value) {
-	return {'condition': {'key': key, 'value': value, 'condition': 'LTE'}}
+	return condition(key, value, 'LTE')
 }
 
 function inside(key, values) {
-	return {'condition': {'key': key, 'value': process(values), 'condition': 'INSIDE'}}
+	return condition(key, values instanceof Ref ? values : process(values), 'INSIDE')
 }
 
 function outside(key, values) {
-	return {'condition': {'key': key, 'value': process(values), 'condition': 'OUTSIDE'}}
+	return condition(key, values instanceof Ref ? values : process(values), 'OUTSIDE')
 }
 
 function between(key, values) {
-	return {'condition': {'key': key, 'value': process(values), 'condition': 'BETWEEN'}}
+	return condition(key, values instanceof Ref ? values : process(values), 'BETWEEN')
 }
 
 function within(key, values) {
-	return {'condition': {'key': key, 'value': process(values), 'condition': 'WITHIN'}}
+	return condition(key, values instanceof Ref ? values : process(values), 'WITHIN')
 }
 
 function without(key, values) {
-	return {'condition': {'key': key, 'value': process(values), 'condition': 'WITHOUT'}}
+	return condition(key, values instanceof Ref ? values : process(values), 'WITHOUT')
 }
 
 function contains(key, value) {
-	return {'condition': {'key': key, 'value': value, 'condition': 'CONTAINS'}}
+	return condition(key, value, 'CONTAINS')
+}
+
+function point(lat, lon) {
+	return {'lat': lat, 'lon': lon}
+}
+
+function geoRadius(key, lat, lon, meters) {
+	return condition(key, [point(lat, lon), meters], 'GEO_RADIUS')
+}
+
+function geoBox(key, south, west, north, east) {
+	return condition(key, [point(south, west), point(north, east)], 'GEO_BOX')
+}
+
+// corners is a list of [lat, lon] pairs
+function geoPolygon(key, corners) {
+	return condition(key, process(corners).map(function(c) { return point(c[0], c[1]) }), 'GEO_POLYGON')
 }
 
 // Aggregation builders
@@ -256,6 +329,32 @@ function histogram(name, field, interval) {
 	}
 }
 
+function dateHistogram(name, field, interval) {
+	if (["day", "month", "year"].indexOf(interval) < 0) {
+		throw "expected interval to be day, month or year"
+	}
+	return {
+		"name": name,
+		"dateHistogram": {
+			"field": field, "interval": interval
+		}
+	}
+}
+
+function geohashGrid(name, field, precision) {
+	if (precision) {
+		if (typeof precision != "number") {
+			throw "expected precision to be a number"
+		}
+	}
+	return {
+		"name": name,
+		"geohashGrid": {
+			"field": field, "precision": precision
+		}
+	}
+}
+
 function V(id) {
   return query().V(id)
 }
@@ -0,0 +1,1174 @@
+package gripql
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// ParseError is an error in the text of a query
+type ParseError struct {
+	Line   int
+	Column int
+	Msg    string
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("%d:%d: %s", e.Line, e.Column, e.Msg)
+}
+
+// ParseQuery parses the text of a query written in the dotted syntax of the
+// javascript and python clients, such as
+//
+//	V().hasLabel("Person").as_("a").out("knows").has(gt("age", 30)).select("a")
+//
+// Method names are matched ignoring case and a trailing underscore, so both
+// hasLabel and HasLabel, or as_ and as, are accepted. The condition and
+// aggregation functions may be written with a gripql. prefix, and
+// subqueries can start with either query() or __
+func ParseQuery(text string) (*Query, error) {
+	p := &parser{text: text}
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+	n, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+	if p.tok.kind != tokEOF {
+		return nil, p.errorf(p.tok.pos, "unexpected %s after the end of the query", p.tok)
+	}
+	v, err := p.eval(n)
+	if err != nil {
+		return nil, err
+	}
+	q, ok := v.(*Query)
+	if !ok {
+		return nil, p.errorf(n.pos, "expected a query, found %s", describe(v))
+	}
+	return q, nil
+}
+
+type tokKind int
+
+const (
+	tokEOF tokKind = iota
+	tokIdent
+	tokString
+	tokNumber
+	tokPunct
+)
+
+type token struct {
+	kind tokKind
+	pos  int
+	text string
+	val  interface{}
+}
+
+func (t token) String() string {
+	switch t.kind {
+	case tokEOF:
+		return "end of query"
+	case tokString:
+		return strconv.Quote(t.val.(string))
+	}
+	return fmt.Sprintf("'%s'", t.text)
+}
+
+type nodeKind int
+
+const (
+	nodeValue nodeKind = iota
+	nodeList
+	nodeObject
+	nodeCall
+	nodeEmptyQuery
+)
+
+type node struct {
+	kind  nodeKind
+	pos   int
+	value interface{}
+	name  string
+	recv  *node
+	args  []*node
+	keys  []string
+}
+
+type parser struct {
+	text string
+	off  int
+	tok  token
+}
+
+func (p *parser) errorf(pos int, format string, args ...interface{}) error {
+	line, col := 1, 1
+	for _, r := range p.text[:pos] {
+		if r == '\n' {
+			line++
+			col = 1
+		} else {
+			col++
+		}
+	}
+	return &ParseError{Line: line, Column: col, Msg: fmt.Sprintf(format, args...)}
+}
+
+// next reads the next token of the text
+func (p *parser) next() error {
+	for p.off < len(p.text) {
+		r, size := utf8.DecodeRuneInString(p.text[p.off:])
+		if unicode.IsSpace(r) {
+			p.off += size
+		} else if strings.HasPrefix(p.text[p.off:], "//") {
+			for p.off < len(p.text) && p.text[p.off] != '\n' {
+				p.off++
+			}
+		} else {
+			break
+		}
+	}
+	start := p.off
+	if start >= len(p.text) {
+		p.tok = token{kind: tokEOF, pos: start}
+		return nil
+	}
+	r, size := utf8.DecodeRuneInString(p.text[start:])
+	switch {
+	case r == '_' || r == '$' || unicode.IsLetter(r):
+		p.off += size
+		for p.off < len(p.text) {
+			r, size := utf8.DecodeRuneInString(p.text[p.off:])
+			if r != '_' && r != '$' && !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+				break
+			}
+			p.off += size
+		}
+		p.tok = token{kind: tokIdent, pos: start, text: p.text[start:p.off]}
+	case r == '"' || r == '\'':
+		s, err := p.readString(r)
+		if err != nil {
+			return err
+		}
+		p.tok = token{kind: tokString, pos: start, text: p.text[start:p.off], val: s}
+	case r == '-' || r == '.' && start+1 < len(p.text) && isDigit(p.text[start+1]) || r >= '0' && r <= '9':
+		p.off++
+		for p.off < len(p.text) {
+			c := p.text[p.off]
+			if isDigit(c) || c == '.' || c == 'e' || c == 'E' ||
+				(c == '-' || c == '+') && (p.text[p.off-1] == 'e' || p.text[p.off-1] == 'E') {
+				p.off++
+			} else {
+				break
+			}
+		}
+		text := p.text[start:p.off]
+		f, err := strconv.ParseFloat(text, 64)
+		if err != nil {
+			return p.errorf(start, "invalid number '%s'", text)
+		}
+		p.tok = token{kind: tokNumber, pos: start, text: text, val: f}
+	case strings.ContainsRune(".()[]{},:", r):
+		p.off++
+		p.tok = token{kind: tokPunct, pos: start, text: string(r)}
+	default:
+		return p.errorf(start, "unexpected character %q", r)
+	}
+	return nil
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// readString reads a string quoted with q, using the escapes of javascript
+func (p *parser) readString(q rune) (string, error) {
+	start := p.off
+	p.off++
+	var sb strings.Builder
+	for p.off < len(p.text) {
+		r, size := utf8.DecodeRuneInString(p.text[p.off:])
+		switch {
+		case r == q:
+			p.off += size
+			return sb.String(), nil
+		case r == '\n':
+			return "", p.errorf(p.off, "newline in string")
+		case r == '\\':
+			if p.off+1 >= len(p.text) {
+				return "", p.errorf(p.off, "unterminated string")
+			}
+			c := p.text[p.off+1]
+			switch c {
+			case 'n':
+				sb.WriteByte('\n')
+			case 't':
+				sb.WriteByte('\t')
+			case 'r':
+				sb.WriteByte('\r')
+			case 'b':
+				sb.WriteByte('\b')
+			case 'f':
+				sb.WriteByte('\f')
+			case '\\', '/', '"', '\'':
+				sb.WriteByte(c)
+			case 'u':
+				if p.off+6 > len(p.text) {
+					return "", p.errorf(p.off, "invalid escape in string")
+				}
+				v, err := strconv.ParseUint(p.text[p.off+2:p.off+6], 16, 32)
+				if err != nil {
+					return "", p.errorf(p.off, "invalid escape in string")
+				}
+				sb.WriteRune(rune(v))
+				p.off += 4
+			default:
+				return "", p.errorf(p.off, "invalid escape in string")
+			}
+			p.off += 2
+		default:
+			sb.WriteRune(r)
+			p.off += size
+		}
+	}
+	return "", p.errorf(start, "unterminated string")
+}
+
+func (p *parser) isPunct(s string) bool {
+	return p.tok.kind == tokPunct && p.tok.text == s
+}
+
+func (p *parser) expect(s string) error {
+	if !p.isPunct(s) {
+		return p.errorf(p.tok.pos, "expected '%s', found %s", s, p.tok)
+	}
+	return p.next()
+}
+
+// parseExpr parses a value, or a call followed by a chain of method calls
+func (p *parser) parseExpr() (*node, error) {
+	n, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	for p.isPunct(".") {
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		if p.tok.kind != tokIdent {
+			return nil, p.errorf(p.tok.pos, "expected a method name, found %s", p.tok)
+		}
+		m := &node{kind: nodeCall, pos: p.tok.pos, name: p.tok.text, recv: n}
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		if m.args, err = p.parseArgs(m.name); err != nil {
+			return nil, err
+		}
+		n = m
+	}
+	return n, nil
+}
+
+func (p *parser) parsePrimary() (*node, error) {
+	t := p.tok
+	switch t.kind {
+	case tokString, tokNumber:
+		return &node{kind: nodeValue, pos: t.pos, value: t.val}, p.next()
+	case tokIdent:
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		switch t.text {
+		case "true", "false":
+			return &node{kind: nodeValue, pos: t.pos, value: t.text == "true"}, nil
+		case "null", "None", "undefined":
+			return &node{kind: nodeValue, pos: t.pos}, nil
+		case "True", "False":
+			return &node{kind: nodeValue, pos: t.pos, value: t.text == "True"}, nil
+		case "__":
+			return &node{kind: nodeEmptyQuery, pos: t.pos}, nil
+		case "gripql":
+			if err := p.expect("."); err != nil {
+				return nil, err
+			}
+			if p.tok.kind != tokIdent {
+				return nil, p.errorf(p.tok.pos, "expected a function name, found %s", p.tok)
+			}
+			t = p.tok
+			if err := p.next(); err != nil {
+				return nil, err
+			}
+		}
+		args, err := p.parseArgs(t.text)
+		if err != nil {
+			return nil, err
+		}
+		return &node{kind: nodeCall, pos: t.pos, name: t.text, args: args}, nil
+	case tokPunct:
+		switch t.text {
+		case "[":
+			if err := p.next(); err != nil {
+				return nil, err
+			}
+			items, err := p.parseList("]")
+			if err != nil {
+				return nil, err
+			}
+			return &node{kind: nodeList, pos: t.pos, args: items}, nil
+		case "{":
+			return p.parseObject()
+		case "(":
+			if err := p.next(); err != nil {
+				return nil, err
+			}
+			n, err := p.parseExpr()
+			if err != nil {
+				return nil, err
+			}
+			return n, p.expect(")")
+		}
+	}
+	return nil, p.errorf(t.pos, "unexpected %s", t)
+}
+
+func (p *parser) parseArgs(name string) ([]*node, error) {
+	if !p.isPunct("(") {
+		return nil, p.errorf(p.tok.pos, "expected '(' after %s, found %s", name, p.tok)
+	}
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+	return p.parseList(")")
+}
+
+// parseList parses comma separated values up to the closing punctuation
+func (p *parser) parseList(end string) ([]*node, error) {
+	items := []*node{}
+	for !p.isPunct(end) {
+		n, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, n)
+		if p.isPunct(",") {
+			if err := p.next(); err != nil {
+				return nil, err
+			}
+		} else if !p.isPunct(end) {
+			return nil, p.errorf(p.tok.pos, "expected ',' or '%s', found %s", end, p.tok)
+		}
+	}
+	return items, p.next()
+}
+
+func (p *parser) parseObject() (*node, error) {
+	n := &node{kind: nodeObject, pos: p.tok.pos}
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+	for !p.isPunct("}") {
+		if p.tok.kind != tokString && p.tok.kind != tokIdent {
+			return nil, p.errorf(p.tok.pos, "expected an object key, found %s", p.tok)
+		}
+		key := p.tok.text
+		if p.tok.kind == tokString {
+			key = p.tok.val.(string)
+		}
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		if err := p.expect(":"); err != nil {
+			return nil, err
+		}
+		v, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		n.keys = append(n.keys, key)
+		n.args = append(n.args, v)
+		if p.isPunct(",") {
+			if err := p.next(); err != nil {
+				return nil, err
+			}
+		} else if !p.isPunct("}") {
+			return nil, p.errorf(p.tok.pos, "expected ',' or '}', found %s", p.tok)
+		}
+	}
+	return n, p.next()
+}
+
+// arg is an evaluated argument, with its position for error messages
+type arg struct {
+	pos int
+	val interface{}
+}
+
+// eval evaluates a node to a *Query, *HasExpression, Ref, *Aggregate or a
+// JSON value
+func (p *parser) eval(n *node) (interface{}, error) {
+	switch n.kind {
+	case nodeValue:
+		return n.value, nil
+	case nodeEmptyQuery:
+		return NewQuery(), nil
+	case nodeList:
+		out := make([]interface{}, len(n.args))
+		for i, a := range n.args {
+			v, err := p.eval(a)
+			if err != nil {
+				return nil, err
+			}
+			out[i] = v
+		}
+		return out, nil
+	case nodeObject:
+		out := map[string]interface{}{}
+		for i, a := range n.args {
+			v, err := p.eval(a)
+			if err != nil {
+				return nil, err
+			}
+			out[n.keys[i]] = v
+		}
+		return out, nil
+	}
+	args := make([]arg, len(n.args))
+	for i, a := range n.args {
+		v, err := p.eval(a)
+		if err != nil {
+			return nil, err
+		}
+		args[i] = arg{a.pos, v}
+	}
+	name := strings.TrimSuffix(strings.ToLower(n.name), "_")
+	call := &callContext{p: p, pos: n.pos, name: n.name, args: args}
+	if n.recv == nil {
+		f, ok := parseFunctions[name]
+		if !ok {
+			return nil, p.errorf(n.pos, "unknown function %s", n.name)
+		}
+		return call.run(f)
+	}
+	recv, err := p.eval(n.recv)
+	if err != nil {
+		return nil, err
+	}
+	q, ok := recv.(*Query)
+	if !ok {
+		return nil, p.errorf(n.pos, "method %s called on %s", n.name, describe(recv))
+	}
+	m, ok := parseMethods[name]
+	if !ok {
+		return nil, p.errorf(n.pos, "unknown method %s", n.name)
+	}
+	call.q = q
+	return call.run(m)
+}
+
+func describe(v interface{}) string {
+	switch v.(type) {
+	case *Query:
+		return "a query"
+	case *HasExpression:
+		return "a condition"
+	case Ref:
+		return "a ref"
+	case *Aggregate:
+		return "an aggregation"
+	case string:
+		return "a string"
+	case float64:
+		return "a number"
+	case bool:
+		return "a boolean"
+	case nil:
+		return "null"
+	case []interface{}:
+		return "a list"
+	case map[string]interface{}:
+		return "an object"
+	}
+	return fmt.Sprintf("%T", v)
+}
+
+// callContext holds the arguments of a function or method call
+type callContext struct {
+	p    *parser
+	pos  int
+	name string
+	q    *Query
+	args []arg
+	// the least number of arguments of the function
+	min int
+}
+
+func (c *callContext) errorf(pos int, format string, args ...interface{}) error {
+	return c.p.errorf(pos, "%s: %s", c.name, fmt.Sprintf(format, args...))
+}
+
+// run checks the number of arguments, and calls the function
+func (c *callContext) run(b builtin) (interface{}, error) {
+	if err := c.nargs(b.min, b.max); err != nil {
+		return nil, err
+	}
+	c.min = b.min
+	return b.f(c)
+}
+
+func (c *callContext) nargs(min, max int) error {
+	n := len(c.args)
+	if n >= min && (max < 0 || n <= max) {
+		return nil
+	}
+	switch {
+	case min == max:
+		return c.errorf(c.pos, "expected %d arguments, found %d", min, n)
+	case n < min:
+		return c.errorf(c.pos, "expected at least %d arguments, found %d", min, n)
+	}
+	return c.errorf(c.pos, "expected at most %d arguments, found %d", max, n)
+}
+
+func (c *callContext) str(i int) (string, error) {
+	s, ok := c.args[i].val.(string)
+	if !ok {
+		return "", c.errorf(c.args[i].pos, "expected a string, found %s", describe(c.args[i].val))
+	}
+	return s, nil
+}
+
+func (c *callContext) number(i int) (float64, error) {
+	f, ok := c.args[i].val.(float64)
+	if !ok {
+		return 0, c.errorf(c.args[i].pos, "expected a number, found %s", describe(c.args[i].val))
+	}
+	return f, nil
+}
+
+func (c *callContext) uint(i int) (uint32, error) {
+	f, err := c.number(i)
+	if err != nil {
+		return 0, err
+	}
+	if f < 0 || f != float64(uint32(f)) {
+		return 0, c.errorf(c.args[i].pos, "expected a positive integer, found %v", f)
+	}
+	return uint32(f), nil
+}
+
+func (c *callContext) int(i int) (int32, error) {
+	f, err := c.number(i)
+	if err != nil {
+		return 0, err
+	}
+	if f != float64(int32(f)) {
+		return 0, c.errorf(c.args[i].pos, "expected an integer, found %v", f)
+	}
+	return int32(f), nil
+}
+
+// strs reads the arguments from i on as strings, where each argument can
+// also be a list of strings
+func (c *callContext) strs(i int) ([]string, error) {
+	out := []string{}
+	for _, a := range c.args[i:] {
+		vals := []interface{}{a.val}
+		if l, ok := a.val.([]interface{}); ok {
+			vals = l
+		}
+		for _, v := range vals {
+			s, ok := v.(string)
+			if !ok {
+				return nil, c.errorf(a.pos, "expected a string, found %s", describe(v))
+			}
+			out = append(out, s)
+		}
+	}
+	if i < len(c.args) {
+		if err := c.enough(i, len(out)); err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+// list reads the arguments from i on, where a single list argument holds the
+// values
+func (c *callContext) list(i int) ([]arg, error) {
+	if len(c.args) == i+1 {
+		if l, ok := c.args[i].val.([]interface{}); ok {
+			if err := c.enough(i, len(l)); err != nil {
+				return nil, err
+			}
+			out := make([]arg, len(l))
+			for j, v := range l {
+				out[j] = arg{c.args[i].pos, v}
+			}
+			return out, nil
+		}
+	}
+	return c.args[i:], nil
+}
+
+// enough checks that the n values read from argument i on make up the
+// minimum number of arguments
+func (c *callContext) enough(i int, n int) error {
+	if i+n < c.min {
+		return c.errorf(c.args[i].pos, "expected at least %d values, found %d", c.min-i, n)
+	}
+	return nil
+}
+
+// value checks that the argument is a JSON value, and returns it
+func (c *callContext) value(i int) (interface{}, error) {
+	if err := checkValue(c.args[i].val); err != nil {
+		return nil, c.errorf(c.args[i].pos, "%s", err)
+	}
+	return c.args[i].val, nil
+}
+
+func checkValue(v interface{}) error {
+	switch x := v.(type) {
+	case string, float64, bool, nil:
+		return nil
+	case []interface{}:
+		for _, i := range x {
+			if err := checkValue(i); err != nil {
+				return err
+			}
+		}
+		return nil
+	case map[string]interface{}:
+		for _, i := range x {
+			if err := checkValue(i); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return fmt.Errorf("expected a value, found %s", describe(v))
+}
+
+func (c *callContext) template(i int) (*structpb.Value, error) {
+	v, err := c.value(i)
+	if err != nil {
+		return nil, err
+	}
+	return structpb.NewValue(v)
+}
+
+func (c *callContext) condition(i int) (interface{}, error) {
+	if r, ok := c.args[i].val.(Ref); ok {
+		return r, nil
+	}
+	return c.value(i)
+}
+
+func (c *callContext) expression(a arg) (*HasExpression, error) {
+	e, ok := a.val.(*HasExpression)
+	if !ok {
+		return nil, c.errorf(a.pos, "expected a condition, found %s", describe(a.val))
+	}
+	return e, nil
+}
+
+func (c *callContext) query(a arg) (*Query, error) {
+	q, ok := a.val.(*Query)
+	if !ok {
+		return nil, c.errorf(a.pos, "expected a subquery, found %s", describe(a.val))
+	}
+	return q, nil
+}
+
+func (c *callContext) queries() ([]*Query, error) {
+	items, err := c.list(0)
+	if err != nil {
+		return nil, err
+	}
+	out := []*Query{}
+	for _, a := range items {
+		q, err := c.query(a)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, q)
+	}
+	return out, nil
+}
+
+type parseFunc func(c *callContext) (interface{}, error)
+
+// builtin is a function or method of the query language, with the number of
+// arguments it takes, or -1 for max if there is no limit. When the arguments
+// from some position on can also be given as one list, the list must hold
+// enough values to make up min
+type builtin struct {
+	min, max int
+	f        parseFunc
+}
+
+var parseFunctions map[string]builtin
+var parseMethods map[string]builtin
+
+func init() {
+	parseFunctions = map[string]builtin{
+		"v": {0, -1, func(c *callContext) (interface{}, error) {
+			ids, err := c.strs(0)
+			return NewQuery().V(ids...), err
+		}},
+		"e": {0, -1, func(c *callContext) (interface{}, error) {
+			ids, err := c.strs(0)
+			return NewQuery().E(ids...), err
+		}},
+		"query": {0, 0, func(c *callContext) (interface{}, error) {
+			return NewQuery(), nil
+		}},
+		"eq":       {2, 2, compareFunc(Eq)},
+		"neq":      {2, 2, compareFunc(Neq)},
+		"gt":       {2, 2, compareFunc(Gt)},
+		"gte":      {2, 2, compareFunc(Gte)},
+		"lt":       {2, 2, compareFunc(Lt)},
+		"lte":      {2, 2, compareFunc(Lte)},
+		"contains": {2, 2, compareFunc(Contains)},
+		"inside":   {2, 3, rangeFunc(Inside)},
+		"outside":  {2, 3, rangeFunc(Outside)},
+		"between":  {2, 3, rangeFunc(Between)},
+		"within":   {2, -1, listFunc(Within)},
+		"without":  {2, -1, listFunc(Without)},
+		"and":      {1, -1, logicFunc(And)},
+		"or":       {1, -1, logicFunc(Or)},
+		"not": {1, 1, func(c *callContext) (interface{}, error) {
+			e, err := c.expression(c.args[0])
+			return Not(e), err
+		}},
+		"georadius": {4, 4, func(c *callContext) (interface{}, error) {
+			key, nums, err := c.geoArgs()
+			if err != nil {
+				return nil, err
+			}
+			return GeoRadius(key, nums[0], nums[1], nums[2]), nil
+		}},
+		"geobox": {5, 5, func(c *callContext) (interface{}, error) {
+			key, nums, err := c.geoArgs()
+			if err != nil {
+				return nil, err
+			}
+			return GeoBox(key, nums[0], nums[1], nums[2], nums[3]), nil
+		}},
+		"geopolygon": {2, -1, func(c *callContext) (interface{}, error) {
+			key, err := c.str(0)
+			if err != nil {
+				return nil, err
+			}
+			points, err := c.list(1)
+			if err != nil {
+				return nil, err
+			}
+			corners := [][2]float64{}
+			for _, a := range points {
+				l, ok := a.val.([]interface{})
+				if !ok || len(l) != 2 {
+					return nil, c.errorf(a.pos, "expected a [lat, lon] pair, found %s", describe(a.val))
+				}
+				lat, ok1 := l[0].(float64)
+				lon, ok2 := l[1].(float64)
+				if !ok1 || !ok2 {
+					return nil, c.errorf(a.pos, "expected a [lat, lon] pair, found %s", describe(a.val))
+				}
+				corners = append(corners, [2]float64{lat, lon})
+			}
+			if len(corners) < 3 {
+				return nil, c.errorf(c.args[1].pos, "expected at least 3 corners, found %d", len(corners))
+			}
+			return GeoPolygon(key, corners...), nil
+		}},
+		"ref": {1, 1, func(c *callContext) (interface{}, error) {
+			s, err := c.str(0)
+			return Ref(s), err
+		}},
+		"term": {2, 3, func(c *callContext) (interface{}, error) {
+			name, field, err := c.aggregation()
+			if err != nil {
+				return nil, err
+			}
+			t := &TermAggregation{Field: field}
+			if len(c.args) == 3 && c.args[2].val != nil {
+				if t.Size, err = c.uint(2); err != nil {
+					return nil, err
+				}
+			}
+			return &Aggregate{Name: name, Aggregation: &Aggregate_Term{t}}, nil
+		}},
+		"histogram": {3, 3, func(c *callContext) (interface{}, error) {
+			name, field, err := c.aggregation()
+			if err != nil {
+				return nil, err
+			}
+			interval, err := c.uint(2)
+			return &Aggregate{Name: name, Aggregation: &Aggregate_Histogram{&HistogramAggregation{Field: field, Interval: interval}}}, err
+		}},
+		"datehistogram": {3, 3, func(c *callContext) (interface{}, error) {
+			name, field, err := c.aggregation()
+			if err != nil {
+				return nil, err
+			}
+			interval, err := c.str(2)
+			if err != nil {
+				return nil, err
+			}
+			if err := CheckDateInterval(interval); err != nil {
+				return nil, c.errorf(c.args[2].pos, "%v", err)
+			}
+			return &Aggregate{Name: name, Aggregation: &Aggregate_DateHistogram{&DateHistogramAggregation{Field: field, Interval: interval}}}, nil
+		}},
+		"geohashgrid": {2, 3, func(c *callContext) (interface{}, error) {
+			name, field, err := c.aggregation()
+			if err != nil {
+				return nil, err
+			}
+			g := &GeohashGridAggregation{Field: field}
+			if len(c.args) == 3 && c.args[2].val != nil {
+				if g.Precision, err = c.uint(2); err != nil {
+					return nil, err
+				}
+			}
+			return &Aggregate{Name: name, Aggregation: &Aggregate_GeohashGrid{g}}, nil
+		}},
+		"percentile": {2, -1, func(c *callContext) (interface{}, error) {
+			name, field, err := c.aggregation()
+			if err != nil {
+				return nil, err
+			}
+			percents := []float64{1, 5, 25, 50, 75, 95, 99}
+			if len(c.args) > 2 {
+				vals, err := c.list(2)
+				if err != nil {
+					return nil, err
+				}
+				percents = []float64{}
+				for _, a := range vals {
+					f, ok := a.val.(float64)
+					if !ok {
+						return nil, c.errorf(a.pos, "expected a number, found %s", describe(a.val))
+					}
+					percents = append(percents, f)
+				}
+			}
+			return &Aggregate{Name: name, Aggregation: &Aggregate_Percentile{&PercentileAggregation{Field: field, Percents: percents}}}, nil
+		}},
+		"field": {2, 2, func(c *callContext) (interface{}, error) {
+			name, field, err := c.aggregation()
+			return &Aggregate{Name: name, Aggregation: &Aggregate_Field{&FieldAggregation{Field: field}}}, err
+		}},
+		"type": {1, 2, func(c *callContext) (interface{}, error) {
+			name, field, err := c.aggregation()
+			return &Aggregate{Name: name, Aggregation: &Aggregate_Type{&TypeAggregation{Field: field}}}, err
+		}},
+		"count": {1, 1, func(c *callContext) (interface{}, error) {
+			name, _, err := c.aggregation()
+			return &Aggregate{Name: name, Aggregation: &Aggregate_Count{&CountAggregation{}}}, err
+		}},
+	}
+	// the python spelling
+	parseFunctions["date_histogram"] = parseFunctions["datehistogram"]
+	parseFunctions["geohash_grid"] = parseFunctions["geohashgrid"]
+	parseFunctions["geo_radius"] = parseFunctions["georadius"]
+	parseFunctions["geo_box"] = parseFunctions["geobox"]
+	parseFunctions["geo_polygon"] = parseFunctions["geopolygon"]
+
+	parseMethods = map[string]builtin{
+		"v":        {0, -1, labelMethod((*Query).V)},
+		"e":        {0, -1, labelMethod((*Query).E)},
+		"in":       {0, -1, labelMethod((*Query).In)},
+		"inv":      {0, -1, labelMethod((*Query).In)},
+		"out":      {0, -1, labelMethod((*Query).Out)},
+		"outv":     {0, -1, labelMethod((*Query).Out)},
+		"both":     {0, -1, labelMethod((*Query).Both)},
+		"bothv":    {0, -1, labelMethod((*Query).Both)},
+		"ine":      {0, -1, labelMethod((*Query).InE)},
+		"oute":     {0, -1, labelMethod((*Query).OutE)},
+		"bothe":    {0, -1, labelMethod((*Query).BothE)},
+		"innull":   {0, -1, labelMethod((*Query).InNull)},
+		"outnull":  {0, -1, labelMethod((*Query).OutNull)},
+		"inenull":  {0, -1, labelMethod(statementMethod(func(l *structpb.ListValue) isGraphStatement_Statement { return &GraphStatement_InENull{l} }))},
+		"outenull": {0, -1, labelMethod(statementMethod(func(l *structpb.ListValue) isGraphStatement_Statement { return &GraphStatement_OutENull{l} }))},
+		"haslabel": {1, -1, labelMethod((*Query).HasLabel)},
+		"hasid":    {1, -1, labelMethod((*Query).HasID)},
+		"haskey":   {1, -1, labelMethod((*Query).HasKey)},
+		"select":   {1, -1, labelMethod((*Query).Select)},
+		"fields":   {0, -1, labelMethod((*Query).Fields)},
+		"distinct": {0, -1, labelMethod((*Query).Distinct)},
+		"sort":     {1, -1, labelMethod((*Query).Sort)},
+		"path":     {0, -1, labelMethod(statementMethod(func(l *structpb.ListValue) isGraphStatement_Statement { return &GraphStatement_Path{l} }))},
+		"as":       {1, 1, stringMethod((*Query).As)},
+		"unwind":   {1, 1, stringMethod(func(q *Query, s string) *Query { return q.with(&GraphStatement{Statement: &GraphStatement_Unwind{s}}) })},
+		"mark":     {1, 1, stringMethod(func(q *Query, s string) *Query { return q.with(&GraphStatement{Statement: &GraphStatement_Mark{s}}) })},
+		"limit":    {1, 1, uintMethod((*Query).Limit)},
+		"skip":     {1, 1, uintMethod((*Query).Skip)},
+		"where":    {1, 1, subQueryMethod((*Query).Where)},
+		"exists":   {1, 1, subQueryMethod((*Query).Where)},
+		"optional": {1, 1, subQueryMethod((*Query).Optional)},
+		"union":    {1, -1, subQueriesMethod((*Query).Union)},
+		"coalesce": {1, -1, subQueriesMethod((*Query).Coalesce)},
+		"render":   {1, 1, templateMethod(func(v *structpb.Value) isGraphStatement_Statement { return &GraphStatement_Render{v} })},
+		"fold":     {1, 1, templateMethod(func(v *structpb.Value) isGraphStatement_Statement { return &GraphStatement_Fold{v} })},
+		"range": {2, 2, func(c *callContext) (interface{}, error) {
+			start, err := c.int(0)
+			if err != nil {
+				return nil, err
+			}
+			stop, err := c.int(1)
+			return c.q.with(&GraphStatement{Statement: &GraphStatement_Range{&Range{Start: start, Stop: stop}}}), err
+		}},
+		"count": {0, 0, func(c *callContext) (interface{}, error) {
+			return c.q.Count(), nil
+		}},
+		"has": {1, 1, func(c *callContext) (interface{}, error) {
+			e, err := c.expression(c.args[0])
+			return c.q.Has(e), err
+		}},
+		// not takes a subquery as a method, and a condition as a function
+		"not": {1, 1, subQueryMethod((*Query).Not)},
+		"group": {2, 2, func(c *callContext) (interface{}, error) {
+			key, err := c.str(0)
+			if err != nil {
+				return nil, err
+			}
+			v, err := c.template(1)
+			return c.q.with(&GraphStatement{Statement: &GraphStatement_Group{&Group{Key: key, Value: v}}}), err
+		}},
+		"set": {2, 2, func(c *callContext) (interface{}, error) {
+			key, err := c.str(0)
+			if err != nil {
+				return nil, err
+			}
+			v, err := c.template(1)
+			return c.q.with(&GraphStatement{Statement: &GraphStatement_Set{&Set{Key: key, Value: v}}}), err
+		}},
+		"increment": {1, 2, func(c *callContext) (interface{}, error) {
+			key, err := c.str(0)
+			if err != nil {
+				return nil, err
+			}
+			var v int32 = 1
+			if len(c.args) == 2 {
+				if v, err = c.int(1); err != nil {
+					return nil, err
+				}
+			}
+			return c.q.with(&GraphStatement{Statement: &GraphStatement_Increment{&Increment{Key: key, Value: v}}}), nil
+		}},
+		"jump": {2, 3, func(c *callContext) (interface{}, error) {
+			mark, err := c.str(0)
+			if err != nil {
+				return nil, err
+			}
+			j := &Jump{Mark: mark}
+			if c.args[1].val != nil {
+				if j.Expression, err = c.expression(c.args[1]); err != nil {
+					return nil, err
+				}
+			}
+			if len(c.args) == 3 {
+				emit, ok := c.args[2].val.(bool)
+				if !ok {
+					return nil, c.errorf(c.args[2].pos, "expected a boolean, found %s", describe(c.args[2].val))
+				}
+				j.Emit = emit
+			}
+			return c.q.with(&GraphStatement{Statement: &GraphStatement_Jump{j}}), nil
+		}},
+		"aggregate": {1, -1, func(c *callContext) (interface{}, error) {
+			vals, err := c.list(0)
+			if err != nil {
+				return nil, err
+			}
+			aggs := []*Aggregate{}
+			for _, a := range vals {
+				agg, ok := a.val.(*Aggregate)
+				if !ok {
+					return nil, c.errorf(a.pos, "expected an aggregation, found %s", describe(a.val))
+				}
+				aggs = append(aggs, agg)
+			}
+			return c.q.Aggregate(aggs), nil
+		}},
+	}
+}
+
+// aggregation reads the name and, if given, the field of an aggregation
+func (c *callContext) aggregation() (string, string, error) {
+	name, err := c.str(0)
+	if err != nil || len(c.args) < 2 {
+		return name, "", err
+	}
+	field, err := c.str(1)
+	return name, field, err
+}
+
+// geoArgs reads a key followed by numbers
+func (c *callContext) geoArgs() (string, []float64, error) {
+	key, err := c.str(0)
+	if err != nil {
+		return "", nil, err
+	}
+	nums := make([]float64, len(c.args)-1)
+	for i := range nums {
+		if nums[i], err = c.number(i + 1); err != nil {
+			return "", nil, err
+		}
+	}
+	return key, nums, nil
+}
+
+func compareFunc(f func(string, interface{}) *HasExpression) parseFunc {
+	return func(c *callContext) (interface{}, error) {
+		key, err := c.str(0)
+		if err != nil {
+			return nil, err
+		}
+		v, err := c.condition(1)
+		return f(key, v), err
+	}
+}
+
+// rangeFunc reads a key followed by the lower and upper bound, given as two
+// arguments, as a [lower, upper] list, or as a ref to such a list
+func rangeFunc(f func(string, interface{}) *HasExpression) parseFunc {
+	return func(c *callContext) (interface{}, error) {
+		key, err := c.str(0)
+		if err != nil {
+			return nil, err
+		}
+		if len(c.args) == 3 {
+			lower, err := c.value(1)
+			if err != nil {
+				return nil, err
+			}
+			upper, err := c.value(2)
+			return f(key, []interface{}{lower, upper}), err
+		}
+		if r, ok := c.args[1].val.(Ref); ok {
+			return f(key, r), nil
+		}
+		if l, ok := c.args[1].val.([]interface{}); !ok || len(l) != 2 {
+			return nil, c.errorf(c.args[1].pos, "expected a [lower, upper] pair, found %s", describe(c.args[1].val))
+		}
+		v, err := c.value(1)
+		return f(key, v), err
+	}
+}
+
+func listFunc(f func(string, ...interface{}) *HasExpression) parseFunc {
+	return func(c *callContext) (interface{}, error) {
+		key, err := c.str(0)
+		if err != nil {
+			return nil, err
+		}
+		if r, ok := c.args[1].val.(Ref); ok && len(c.args) == 2 {
+			return f(key, r), nil
+		}
+		items, err := c.list(1)
+		if err != nil {
+			return nil, err
+		}
+		vals := []interface{}{}
+		for _, a := range items {
+			if err := checkValue(a.val); err != nil {
+				return nil, c.errorf(a.pos, "%s", err)
+			}
+			vals = append(vals, a.val)
+		}
+		return f(key, vals...), nil
+	}
+}
+
+func logicFunc(f func(...*HasExpression) *HasExpression) parseFunc {
+	return func(c *callContext) (interface{}, error) {
+		items, err := c.list(0)
+		if err != nil {
+			return nil, err
+		}
+		exprs := []*HasExpression{}
+		for _, a := range items {
+			e, err := c.expression(a)
+			if err != nil {
+				return nil, err
+			}
+			exprs = append(exprs, e)
+		}
+		return f(exprs...), nil
+	}
+}
+
+func statementMethod(f func(*structpb.ListValue) isGraphStatement_Statement) func(*Query, ...string) *Query {
+	return func(q *Query, s ...string) *Query {
+		l, _ := structpb.NewList(stringsToInterfaces(s))
+		return q.with(&GraphStatement{Statement: f(l)})
+	}
+}
+
+func stringsToInterfaces(s []string) []interface{} {
+	out := make([]interface{}, len(s))
+	for i := range s {
+		out[i] = s[i]
+	}
+	return out
+}
+
+func labelMethod(f func(*Query, ...string) *Query) parseFunc {
+	return func(c *callContext) (interface{}, error) {
+		s, err := c.strs(0)
+		return f(c.q, s...), err
+	}
+}
+
+func stringMethod(f func(*Query, string) *Query) parseFunc {
+	return func(c *callContext) (interface{}, error) {
+		s, err := c.str(0)
+		return f(c.q, s), err
+	}
+}
+
+func uintMethod(f func(*Query, uint32) *Query) parseFunc {
+	return func(c *callContext) (interface{}, error) {
+		n, err := c.uint(0)
+		return f(c.q, n), err
+	}
+}
+
+func subQueryMethod(f func(*Query, *Query) *Query) parseFunc {
+	return func(c *callContext) (interface{}, error) {
+		sub, err := c.query(c.args[0])
+		if err != nil {
+			return nil, err
+		}
+		return f(c.q, sub), nil
+	}
+}
+
+func subQueriesMethod(f func(*Query, ...*Query) *Query) parseFunc {
+	return func(c *callContext) (interface{}, error) {
+		subs, err := c.queries()
+		if err != nil {
+			return nil, err
+		}
+		return f(c.q, subs...), nil
+	}
+}
+
+func templateMethod(f func(*structpb.Value) isGraphStatement_Statement) parseFunc {
+	return func(c *callContext) (interface{}, error) {
+		v, err := c.template(0)
+		if err != nil {
+			return nil, err
+		}
+		return c.q.with(&GraphStatement{Statement: f(v)}), nil
+	}
+}
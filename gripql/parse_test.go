@@ -0,0 +1,131 @@
+package gripql
+
+import (
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+)
+
+func TestParseQuery(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected *Query
+	}{
+		{
+			`V().hasLabel("Person").out().limit(5)`,
+			V().HasLabel("Person").Out().Limit(5),
+		},
+		{
+			`V(["1", "2"]).In_('knows').As("a").outE().hasKey("since", "weight").select("a")`,
+			V("1", "2").In("knows").As("a").OutE().HasKey("since", "weight").Select("a"),
+		},
+		{
+			`V().has(and_(gt("age", 30), gripql.within("name", ["bob", "alice"]), not_(eq("$a.x", ref("$b.x")))))`,
+			V().Has(And(Gt("age", 30.0), Within("name", "bob", "alice"), Not(Eq("$a.x", Ref("$b.x"))))),
+		},
+		{
+			`V().has(between("age", 20, 30)).has(or_([inside("x", [1, 2]), contains("tags", "ab\n")]))`,
+			V().Has(Between("age", []interface{}{20.0, 30.0})).Has(Or(Inside("x", []interface{}{1.0, 2.0}), Contains("tags", "ab\n"))),
+		},
+		{
+			`V().where(__.out("knows")).not_(query().in_()).union(__.out(), __).optional(__.outE()).coalesce([__.in_(), __])`,
+			V().Where(NewQuery().Out("knows")).Not(NewQuery().In()).Union(NewQuery().Out(), NewQuery()).
+				Optional(NewQuery().OutE()).Coalesce(NewQuery().In(), NewQuery()),
+		},
+		{
+			`V().render({"name": "$.name", 'ids': ["$._gid", "$a._gid"], n: null, b: true}).count()`,
+			V().Render(map[string]interface{}{"name": "$.name", "ids": []interface{}{"$._gid", "$a._gid"}, "n": nil, "b": true}).Count(),
+		},
+		{
+			`V().group("$.gene", "$._gid").fold(["$._gid"]).sort("-age").distinct()`,
+			V().Group("$.gene", "$._gid").Fold([]interface{}{"$._gid"}).Sort("-age").Distinct(),
+		},
+		{
+			`V().aggregate(term("t", "name", 10), gripql.histogram("h", "age", 5), percentile("p", "age", [50]), count("c"))`,
+			V().Aggregate([]*Aggregate{
+				{Name: "t", Aggregation: &Aggregate_Term{&TermAggregation{Field: "name", Size: 10}}},
+				{Name: "h", Aggregation: &Aggregate_Histogram{&HistogramAggregation{Field: "age", Interval: 5}}},
+				{Name: "p", Aggregation: &Aggregate_Percentile{&PercentileAggregation{Field: "age", Percents: []float64{50}}}},
+				{Name: "c", Aggregation: &Aggregate_Count{&CountAggregation{}}},
+			}),
+		},
+		{
+			`V().has(between("created", ["2014-12-10", "2015-01-01T00:00:00Z"])).aggregate(date_histogram("d", "created", "month"))`,
+			V().Has(Between("created", []interface{}{"2014-12-10", "2015-01-01T00:00:00Z"})).Aggregate([]*Aggregate{
+				{Name: "d", Aggregation: &Aggregate_DateHistogram{&DateHistogramAggregation{Field: "created", Interval: "month"}}},
+			}),
+		},
+		{
+			`V().has(or(geo_radius("loc", 37.77, -122.42, 5000), geobox("loc", 37, -123, 38, -122))).aggregate(geohash_grid("cells", "loc", 4))`,
+			V().Has(Or(GeoRadius("loc", 37.77, -122.42, 5000), GeoBox("loc", 37, -123, 38, -122))).Aggregate([]*Aggregate{
+				{Name: "cells", Aggregation: &Aggregate_GeohashGrid{&GeohashGridAggregation{Field: "loc", Precision: 4}}},
+			}),
+		},
+		{
+			`V().has(geo_polygon("loc", [[0, 0], [0, 1], [1, 1]]))`,
+			V().Has(GeoPolygon("loc", [2]float64{0, 0}, [2]float64{0, 1}, [2]float64{1, 1})),
+		},
+		{
+			"V() // all vertices\n  .skip(2)\n  .range(0, -1)",
+			V().Skip(2).Range(0, -1),
+		},
+	}
+	for _, tt := range tests {
+		q, err := ParseQuery(tt.text)
+		if err != nil {
+			t.Errorf("%s: %v", tt.text, err)
+			continue
+		}
+		if !proto.Equal(&GraphQuery{Query: q.Statements}, &GraphQuery{Query: tt.expected.Statements}) {
+			t.Errorf("%s: parsed to %s", tt.text, q.String())
+		}
+	}
+}
+
+func TestQueryString(t *testing.T) {
+	q := V().HasLabel("Person").Sort("-age", "_gid").Limit(5)
+	if s := q.String(); s != "V().HasLabel(Person).Sort(-age, _gid).Limit(5)" {
+		t.Errorf("unexpected query string: %s", s)
+	}
+}
+
+func TestParseQueryErrors(t *testing.T) {
+	tests := []struct {
+		text string
+		err  string
+	}{
+		{`V().outt()`, `1:5: unknown method outt`},
+		{`V().out(`, `1:9: unexpected end of query`},
+		{`V().out("a" "b")`, `1:13: expected ',' or ')', found "b"`},
+		{"V()\n  .limit(-1)", `2:10: limit: expected a positive integer, found -1`},
+		{`V().has(eq("a", __))`, `1:17: eq: expected a value, found a query`},
+		{`V().has("a")`, `1:9: has: expected a condition, found a string`},
+		{`eq("a", 1)`, `1:1: expected a query, found a condition`},
+		{`V().count().limit(1) x`, `1:22: unexpected 'x' after the end of the query`},
+		{`V("a).out()`, `1:3: unterminated string`},
+		{`V().range(1)`, `1:5: range: expected 2 arguments, found 1`},
+		{`V().has(geo_polygon("loc", [0, 1], [1]))`, `1:36: geo_polygon: expected a [lat, lon] pair, found a list`},
+		{`V().has(geoRadius("loc", 1, 2))`, `1:9: geoRadius: expected 4 arguments, found 3`},
+		{`V().aggregate(dateHistogram("d", "created", "week"))`, `1:45: dateHistogram: unknown date interval 'week': expected day, month or year`},
+		{`V().has(inside("x", 1))`, `1:21: inside: expected a [lower, upper] pair, found a number`},
+		{`V().has(between("x", [1]))`, `1:22: between: expected a [lower, upper] pair, found a list`},
+		{`V().has(and_())`, `1:9: and_: expected at least 1 arguments, found 0`},
+		{`V().has(or_([]))`, `1:13: or_: expected at least 1 values, found 0`},
+		{`V().has(within("x"))`, `1:9: within: expected at least 2 arguments, found 1`},
+		{`V().union()`, `1:5: union: expected at least 1 arguments, found 0`},
+		{`V().coalesce([])`, `1:14: coalesce: expected at least 1 values, found 0`},
+		{`V().sort()`, `1:5: sort: expected at least 1 arguments, found 0`},
+		{`V().hasLabel([])`, `1:14: hasLabel: expected at least 1 values, found 0`},
+		{`V().aggregate()`, `1:5: aggregate: expected at least 1 arguments, found 0`},
+		{`V().where(__, __)`, `1:5: where: expected 1 arguments, found 2`},
+		{`V().has(geo_polygon("loc", [[0, 0], [0, 1]]))`, `1:28: geo_polygon: expected at least 3 corners, found 2`},
+	}
+	for _, tt := range tests {
+		_, err := ParseQuery(tt.text)
+		if err == nil {
+			t.Errorf("%s: expected an error", tt.text)
+		} else if err.Error() != tt.err {
+			t.Errorf("%s: expected error %q, found %q", tt.text, tt.err, err.Error())
+		}
+	}
+}
@@ -1,11 +1,13 @@
 from __future__ import absolute_import, print_function, unicode_literals
 
-from gripql.aggregations import term, histogram, percentile, field, type, count
+from gripql.aggregations import (term, histogram, date_histogram, geohash_grid,
+                                  percentile, field, type, count)
 from gripql.connection import Connection
 from gripql.graph import Graph, BulkAdd
 from gripql.operators import (and_, or_, not_, eq, neq, gt, gte, lt, lte,
                               inside, outside, between, within, without,
-                              contains)
+                              contains, geo_radius, geo_box, geo_polygon,
+                              ref)
 from gripql.query import Query, __
 
 
@@ -30,8 +32,14 @@ __all__ = [
     within,
     without,
     contains,
+    geo_radius,
+    geo_box,
+    geo_polygon,
+    ref,
     term,
     histogram,
+    date_histogram,
+    geohash_grid,
     percentile,
     count
 ]
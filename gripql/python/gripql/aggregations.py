@@ -28,6 +28,30 @@ def histogram(name, field, interval):
         }
     }
 
+def date_histogram(name, field, interval):
+    """
+    Count RFC3339 timestamps in calendar buckets.
+
+    "interval" is one of "day", "month" or "year".
+    """
+    return {
+        "name": name,
+        "dateHistogram": {
+            "field": field, "interval": interval
+        }
+    }
+
+def geohash_grid(name, field, precision=5):
+    """
+    Count points in the geohash cells of length "precision" (1 to 12).
+    """
+    return {
+        "name": name,
+        "geohashGrid": {
+            "field": field, "precision": precision
+        }
+    }
+
 def field(name, field):
     return {
         "name": name,
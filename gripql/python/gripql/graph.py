@@ -1,8 +1,9 @@
 from __future__ import absolute_import, print_function, unicode_literals
 
 import json
+import requests
 
-from gripql.util import BaseConnection, raise_for_status
+from gripql.util import BaseConnection, arrow_table, raise_for_status
 from gripql.query import Query
 
 
@@ -130,11 +131,11 @@ class Graph(BaseConnection):
     def bulkAdd(self):
         return BulkAdd(self.base_url, self.graph, self.user, self.password, self.token)
 
-    def addIndex(self, label, field):
+    def addIndex(self, label, field, index_type="FIELD_INDEX"):
         url = self.url + "/index/" + label
         response = self.session.post(
             url,
-            json={"field": field}
+            json={"field": field, "type": index_type}
         )
         raise_for_status(response)
         return response.json()
@@ -156,6 +157,102 @@ class Graph(BaseConnection):
         raise_for_status(response)
         return response.json()
 
+    def watch(self):
+        """
+        Stream the vertices and edges added, updated or removed from the graph
+        """
+        response = self.session.get(
+            self.url + "/watch",
+            headers=self._request_header(),
+            stream=True
+        )
+        raise_for_status(response)
+        for result in response.iter_lines(chunk_size=None):
+            result_dict = json.loads(result.decode())
+            if "error" in result_dict:
+                raise requests.HTTPError(result_dict['error']['message'])
+            yield result_dict.get("result", result_dict)
+
+    def addStoredQuery(self, name, query, parameters=[], description=""):
+        """
+        Store a named query for the graph. Parameters are dicts with a name,
+        a type (STRING, NUMERIC, BOOL, MAP or ARRAY), and an optional
+        default_value. Strings in the query of the form ${name} are replaced
+        by the value of the parameter when the query is run.
+        """
+        if isinstance(query, Query):
+            query = query.to_dict()["query"]
+        payload = {
+            "graph": self.graph,
+            "name": name,
+            "description": description,
+            "parameters": parameters,
+            "query": query
+        }
+        response = self.session.post(
+            self.url + "/stored-query",
+            json=payload
+        )
+        raise_for_status(response)
+        return response.json()
+
+    def getStoredQuery(self, name):
+        """
+        Get the definition of a stored query.
+        """
+        response = self.session.get(
+            self.url + "/stored-query/" + name
+        )
+        raise_for_status(response)
+        return response.json()
+
+    def listStoredQueries(self):
+        """
+        List the stored queries of the graph.
+        """
+        response = self.session.get(
+            self.url + "/stored-query"
+        )
+        raise_for_status(response)
+        return response.json().get("queries", [])
+
+    def deleteStoredQuery(self, name):
+        """
+        Delete a stored query.
+        """
+        response = self.session.delete(
+            self.url + "/stored-query/" + name
+        )
+        raise_for_status(response)
+        return response.json()
+
+    def runStoredQuery(self, name, **params):
+        """
+        Run a stored query, with the parameters given as keyword arguments.
+        """
+        response = self.session.post(
+            self.url + "/stored-query/" + name + "/run",
+            json=params,
+            headers=self._request_header(),
+            stream=True
+        )
+        raise_for_status(response)
+        for result in response.iter_lines(chunk_size=None):
+            result_dict = json.loads(result.decode())
+            if "error" in result_dict:
+                raise requests.HTTPError(result_dict['error']['message'])
+            result_dict = result_dict.get("result", result_dict)
+            if "vertex" in result_dict:
+                yield result_dict["vertex"]
+            elif "edge" in result_dict:
+                yield result_dict["edge"]
+            elif "aggregations" in result_dict:
+                yield result_dict["aggregations"]
+            elif "render" in result_dict:
+                yield result_dict["render"]
+            else:
+                yield result_dict
+
     def query(self):
         """
         Create a query handle.
@@ -199,13 +296,31 @@ class Graph(BaseConnection):
         raise_for_status(response)
         return response.json()
 
-    def readJob(self, id, raw=False):
+    def collectJobs(self):
+        """
+        Remove jobs that violate the server's job retention policy
+        """
+        url = self.url + "/job-gc"
+        response = self.session.post(
+            url,
+            headers=self._request_header()
+        )
+        raise_for_status(response)
+        for result in response.iter_lines(chunk_size=None):
+            yield json.loads(result)
+
+    def readJob(self, id, raw=False, arrow=False):
         """
         read job
+
+        "arrow" returns the results as pyarrow Tables, as Query.arrow does.
         """
+        body = {}
+        if arrow:
+            body["format"] = "ARROW"
         response = self.session.post(
             self.url + "/job/" + id,
-            json={},
+            json=body,
             headers=self._request_header()
         )
         # Duplicate code from Query, need to get helper function
@@ -216,8 +331,12 @@ class Graph(BaseConnection):
                 #logger.error("Failed to decode: %s", result)
                 raise e
 
+            if "error" in result_dict:
+                raise requests.HTTPError(result_dict['error']['message'])
             if raw:
                 extracted = result_dict
+            elif "arrow" in result_dict:
+                extracted = arrow_table(result_dict["arrow"])
             elif "vertex" in result_dict:
                 extracted = result_dict["vertex"]
             elif "edge" in result_dict:
@@ -235,8 +354,6 @@ class Graph(BaseConnection):
                 extracted = result_dict["render"]
             elif "count" in result_dict:
                 extracted = result_dict
-            elif "error" in result_dict:
-                raise requests.HTTPError(result_dict['error']['message'])
             else:
                 extracted = result_dict
 
@@ -13,55 +13,102 @@ def not_(expression):
     return {"not": expression}
 
 
+class ref(str):
+    """
+    Path of a field of the traveler, such as "$a.age". Given as the value of
+    a condition, the key is compared to the value of that field.
+    """
+
+
+def _condition(key, value, condition):
+    if isinstance(value, ref):
+        return {"condition": {"key": key, "valueRef": str(value), "condition": condition}}
+    return {"condition": {"key": key, "value": value, "condition": condition}}
+
+
 def eq(key, value):
-    return {"condition": {"key": key, "value": value, "condition": "EQ"}}
+    return _condition(key, value, "EQ")
 
 
 def neq(key, value):
-    return {"condition": {"key": key, "value": value, "condition": "NEQ"}}
+    return _condition(key, value, "NEQ")
 
 
 def gt(key, value):
-    return {"condition": {"key": key, "value": value, "condition": "GT"}}
+    return _condition(key, value, "GT")
 
 
 def gte(key, value):
-    return {"condition": {"key": key, "value": value, "condition": "GTE"}}
+    return _condition(key, value, "GTE")
 
 
 def lt(key, value):
-    return {"condition": {"key": key, "value": value, "condition": "LT"}}
+    return _condition(key, value, "LT")
 
 
 def lte(key, value):
-    return {"condition": {"key": key, "value": value, "condition": "LTE"}}
+    return _condition(key, value, "LTE")
 
 
-def inside(key, lower, upper):
-    return {"condition": {"key": key, "value": [lower, upper], "condition": "INSIDE"}}
+def inside(key, lower, upper=None):
+    if isinstance(lower, ref):
+        return _condition(key, lower, "INSIDE")
+    return _condition(key, [lower, upper], "INSIDE")
 
 
-def outside(key, lower, upper):
-    return {"condition": {"key": key, "value": [lower, upper], "condition": "OUTSIDE"}}
+def outside(key, lower, upper=None):
+    if isinstance(lower, ref):
+        return _condition(key, lower, "OUTSIDE")
+    return _condition(key, [lower, upper], "OUTSIDE")
 
 
-def between(key, lower, upper):
-    return {"condition": {"key": key, "value": [lower, upper], "condition": "BETWEEN"}}
+def between(key, lower, upper=None):
+    if isinstance(lower, ref):
+        return _condition(key, lower, "BETWEEN")
+    return _condition(key, [lower, upper], "BETWEEN")
 
 
 def within(key, values):
-    if not isinstance(values, list):
+    if not isinstance(values, (list, ref)):
         if not isinstance(values, dict):
             values = [values]
-    return {"condition": {"key": key, "value": values, "condition": "WITHIN"}}
+    return _condition(key, values, "WITHIN")
 
 
 def without(key, values):
-    if not isinstance(values, list):
+    if not isinstance(values, (list, ref)):
         if not isinstance(values, dict):
             values = [values]
-    return {"condition": {"key": key, "value": values, "condition": "WITHOUT"}}
+    return _condition(key, values, "WITHOUT")
 
 
 def contains(key, value):
-    return {"condition": {"key": key, "value": value, "condition": "CONTAINS"}}
+    return _condition(key, value, "CONTAINS")
+
+
+def _point(lat, lon):
+    return {"lat": lat, "lon": lon}
+
+
+def geo_radius(key, lat, lon, meters):
+    """
+    Point within "meters" of the center.
+    """
+    return _condition(key, [_point(lat, lon), meters], "GEO_RADIUS")
+
+
+def geo_box(key, south, west, north, east):
+    """
+    Point between two latitudes and two longitudes. A box whose west edge is
+    east of its east edge crosses the antimeridian.
+    """
+    corners = [_point(south, west), _point(north, east)]
+    return _condition(key, corners, "GEO_BOX")
+
+
+def geo_polygon(key, corners):
+    """
+    Point inside the polygon with the (lat, lon) corners.
+    """
+    points = [_point(lat, lon) for lat, lon in corners]
+    return _condition(key, points, "GEO_POLYGON")
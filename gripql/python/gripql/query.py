@@ -10,7 +10,7 @@ except ImportError:
 import logging
 import requests
 
-from gripql.util import BaseConnection, Rate, raise_for_status
+from gripql.util import BaseConnection, Rate, arrow_table, raise_for_status
 
 
 def _wrap_value(value, typ):
@@ -36,6 +36,12 @@ def _wrap_dict_value(value):
     return _wrap_value(value, dict)
 
 
+def _subquery_list(subqueries):
+    if len(subqueries) == 1 and isinstance(subqueries[0], (list, tuple)):
+        subqueries = subqueries[0]
+    return [{"query": q.query} for q in subqueries]
+
+
 class Query(BaseConnection):
     def __init__(self, url, graph, user=None, password=None, token=None, credential_file=None, resume=None):
         super(Query, self).__init__(url, user, password, token, credential_file)
@@ -43,13 +49,44 @@ class Query(BaseConnection):
         self.graph = graph
         self.query = []
         self.resume = resume
+        self.options = {}
 
-    def __append(self, part):
+    def __copy(self):
         q = self.__class__(self.base_url, self.graph, self.user, self.password, self.token, self.credential_file, self.resume)
         q.query = self.query[:]
+        q.options = dict(self.options)
+        return q
+
+    def __append(self, part):
+        q = self.__copy()
         q.query.append(part)
         return q
 
+    def parallel(self, workers, preserve_order=False):
+        """
+        Run the stateless steps of the query, such as has and render, on
+        several workers.
+
+        "preserve_order" keeps the order the results would have on a single
+        worker.
+        """
+        q = self.__copy()
+        q.options.update({"parallelism": workers, "preserveOrder": preserve_order})
+        return q
+
+    def arrow(self):
+        """
+        Return the results as pyarrow Tables of up to 1000 rows, instead of
+        one result at a time.
+
+        Vertices and edges have _gid and _label columns, and edges _from and
+        _to columns, followed by their data fields. Nested fields are
+        flattened into dotted names, and lists are JSON strings.
+        """
+        q = self.__copy()
+        q.options["format"] = "ARROW"
+        return q
+
     def V(self, id=[]):
         """
         Start the query at a vertex.
@@ -224,6 +261,51 @@ class Query(BaseConnection):
         field = _wrap_str_value(field)
         return self.__append({"fields": field})
 
+    def where(self, subquery):
+        """
+        Keep the vertices/edges for which the subquery has at least one result.
+
+        The subquery, built with `gripql.__`, starts from the current
+        vertex/edge, e.g. `.where(__.outE("knows").has(gripql.gt("weight", 0.5)))`
+        """
+        return self.__append({"where": {"query": subquery.query}})
+
+    def exists(self, subquery):
+        """
+        Same as `where`.
+        """
+        return self.where(subquery)
+
+    def not_(self, subquery):
+        """
+        Keep the vertices/edges for which the subquery has no results.
+        """
+        return self.__append({"not": {"query": subquery.query}})
+
+    def union(self, *subqueries):
+        """
+        Emit the results of each subquery, in order, for every vertex/edge.
+
+        The subqueries, built with `gripql.__`, start from the current
+        vertex/edge and may also be passed as a list. An empty subquery emits
+        the vertex/edge itself, e.g. `.union(__.out("knows"), __)`
+        """
+        return self.__append({"union": {"queries": _subquery_list(subqueries)}})
+
+    def optional(self, subquery):
+        """
+        Emit the results of the subquery, or the current vertex/edge if it has
+        none.
+        """
+        return self.__append({"optional": {"query": subquery.query}})
+
+    def coalesce(self, *subqueries):
+        """
+        Emit the results of the first subquery that has any for the current
+        vertex/edge.
+        """
+        return self.__append({"coalesce": {"queries": _subquery_list(subqueries)}})
+
     def as_(self, name):
         """
         Mark the current vertex/edge with the given name.
@@ -282,6 +364,14 @@ class Query(BaseConnection):
         props = _wrap_str_value(props)
         return self.__append({"distinct": props})
 
+    def sort(self, props):
+        """
+        Order elements by the values of the provided property list.
+        Properties starting with "-" are sorted in descending order.
+        """
+        props = _wrap_str_value(props)
+        return self.__append({"sort": props})
+
     def set(self, key, value):
         """
         Set field to constant value
@@ -313,6 +403,23 @@ class Query(BaseConnection):
         """
         return self.__append({"render": template})
 
+    def group(self, key, value):
+        """
+        Render `value` for each element and collect the results into lists
+        keyed by the value found at `key`.
+
+        Returns one result per key, e.g. `{"key": "TP53", "values": [...]}`.
+        Elements without a value at `key` are dropped.
+        """
+        return self.__append({"group": {"key": key, "value": value}})
+
+    def fold(self, template):
+        """
+        Render `template` for each element and return a single list of the
+        results.
+        """
+        return self.__append({"fold": template})
+
     def path(self):
         """
         Display path of query
@@ -336,14 +443,15 @@ class Query(BaseConnection):
         """
         Return the query as a JSON string.
         """
-        output = {"query": self.query}
-        return jdumps(output)
+        return jdumps(self.to_dict())
 
     def to_dict(self):
         """
         Return the query as a dictionary.
         """
-        return {"query": self.query}
+        output = {"query": self.query}
+        output.update(self.options)
+        return output
 
     def __iter__(self):
         return self.__stream()
@@ -376,8 +484,8 @@ class Query(BaseConnection):
             logger.debug('POST %s', self.url)
         else:
             url = self.base_url + "/v1/graph/" + self.graph + "/job-resume"
-            data = self.to_dict()
-            data['srcId'] = self.resume
+            # resumed jobs use the workers set by the server
+            data = {"query": self.query, "srcId": self.resume}
             response = self.session.post(
                 url,
                 json=data,
@@ -394,8 +502,12 @@ class Query(BaseConnection):
                 logger.error("Failed to decode: %s", result)
                 raise e
 
+            if "error" in result_dict:
+                raise requests.HTTPError(result_dict['error']['message'])
             if raw:
                 extracted = result_dict
+            elif "arrow" in result_dict:
+                extracted = arrow_table(result_dict["arrow"])
             elif "vertex" in result_dict:
                 extracted = result_dict["vertex"]
             elif "edge" in result_dict:
@@ -415,8 +527,6 @@ class Query(BaseConnection):
                 extracted = result_dict["path"]
             elif "count" in result_dict:
                 extracted = result_dict
-            elif "error" in result_dict:
-                raise requests.HTTPError(result_dict['error']['message'])
             else:
                 extracted = result_dict
 
@@ -498,6 +608,7 @@ class Query(BaseConnection):
 class __Query(Query):
     def __init__(self):
         self.query = []
+        self.options = {}
 
     def __append(self, part):
         q = self.__class__()
@@ -1,5 +1,6 @@
 from __future__ import absolute_import, print_function, unicode_literals
 
+import base64
 import os
 import json
 import requests
@@ -123,6 +124,19 @@ def process_url(url):
     return urlunparse((scheme, netloc, path, params, query, frag))
 
 
+def arrow_table(data):
+    """
+    Decode an Arrow result, a base64 encoded Arrow IPC stream, into a
+    pyarrow Table.
+    """
+    try:
+        import pyarrow
+    except ImportError:
+        raise ImportError("pyarrow is required to read Arrow results")
+    reader = pyarrow.ipc.open_stream(base64.b64decode(data))
+    return reader.read_all()
+
+
 def raise_for_status(response):
     http_error_msg = ''
     if isinstance(response.reason, bytes):
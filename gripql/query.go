@@ -180,8 +180,23 @@ func (q *Query) Distinct(args ...string) *Query {
 	return q.with(&GraphStatement{Statement: &GraphStatement_Distinct{protoutil.NewListFromStrings(args)}})
 }
 
-// Render adds a render step to the query
-func (q *Query) Render(template interface{}) *Query {
+// Sort orders records by the values of fields. Fields starting with "-" are
+// sorted in descending order
+func (q *Query) Sort(fields ...string) *Query {
+	return q.with(&GraphStatement{Statement: &GraphStatement_Sort{protoutil.NewListFromStrings(fields)}})
+}
+
+// Seek keeps the records that come after the position in the order of a
+// sort on the same fields. The position holds a value for each field
+func (q *Query) Seek(fields []string, position []interface{}) *Query {
+	values, err := structpb.NewList(position)
+	if err != nil {
+		log.Errorf("seek error: %s", err)
+	}
+	return q.with(&GraphStatement{Statement: &GraphStatement_Seek{&Seek{Fields: fields, Values: values}}})
+}
+
+func templateValue(template interface{}) (*structpb.Value, error) {
 	if sList, ok := template.([]string); ok {
 		t := []any{}
 		for _, j := range sList {
@@ -189,13 +204,75 @@ func (q *Query) Render(template interface{}) *Query {
 		}
 		template = t
 	}
-	value, err := structpb.NewValue(template)
+	return structpb.NewValue(template)
+}
+
+// Render adds a render step to the query
+func (q *Query) Render(template interface{}) *Query {
+	value, err := templateValue(template)
 	if err != nil {
 		log.Errorf("render error: %s", err)
 	}
 	return q.with(&GraphStatement{Statement: &GraphStatement_Render{value}})
 }
 
+// Group renders the template for each traveler and collects the values into
+// lists keyed by the value found at key. One render result is emitted for
+// each key
+func (q *Query) Group(key string, template interface{}) *Query {
+	value, err := templateValue(template)
+	if err != nil {
+		log.Errorf("group error: %s", err)
+	}
+	return q.with(&GraphStatement{Statement: &GraphStatement_Group{&Group{Key: key, Value: value}}})
+}
+
+// Fold renders the template for each traveler and emits a single render
+// result holding the list of values
+func (q *Query) Fold(template interface{}) *Query {
+	value, err := templateValue(template)
+	if err != nil {
+		log.Errorf("fold error: %s", err)
+	}
+	return q.with(&GraphStatement{Statement: &GraphStatement_Fold{value}})
+}
+
+// Where keeps the travelers for which the subquery has at least one result.
+// The subquery starts from the current element of the traveler
+func (q *Query) Where(sub *Query) *Query {
+	return q.with(&GraphStatement{Statement: &GraphStatement_Where{&SubQuery{Query: sub.Statements}}})
+}
+
+// Not keeps the travelers for which the subquery has no results
+func (q *Query) Not(sub *Query) *Query {
+	return q.with(&GraphStatement{Statement: &GraphStatement_Not{&SubQuery{Query: sub.Statements}}})
+}
+
+func subQueryList(subs []*Query) *SubQueryList {
+	out := &SubQueryList{}
+	for _, sub := range subs {
+		out.Queries = append(out.Queries, &SubQuery{Query: sub.Statements})
+	}
+	return out
+}
+
+// Union emits the results of each of the subqueries. An empty subquery emits
+// the traveler unchanged
+func (q *Query) Union(subs ...*Query) *Query {
+	return q.with(&GraphStatement{Statement: &GraphStatement_Union{subQueryList(subs)}})
+}
+
+// Optional emits the results of the subquery, or the traveler unchanged if
+// it has none
+func (q *Query) Optional(sub *Query) *Query {
+	return q.with(&GraphStatement{Statement: &GraphStatement_Optional{&SubQuery{Query: sub.Statements}}})
+}
+
+// Coalesce emits the results of the first subquery that has results
+func (q *Query) Coalesce(subs ...*Query) *Query {
+	return q.with(&GraphStatement{Statement: &GraphStatement_Coalesce{subQueryList(subs)}})
+}
+
 func (q *Query) Aggregate(agg []*Aggregate) *Query {
 	return q.with(&GraphStatement{Statement: &GraphStatement_Aggregate{Aggregate: &Aggregations{Aggregations: agg}}})
 }
@@ -273,6 +350,12 @@ func (q *Query) String() string {
 		case *GraphStatement_Range:
 			add("Range", fmt.Sprintf("%d", stmt.Range.Start), fmt.Sprintf("%d", stmt.Range.Stop))
 
+		case *GraphStatement_Sort:
+			add("Sort", protoutil.AsStringList(stmt.Sort)...)
+
+		case *GraphStatement_Seek:
+			add("Seek", stmt.Seek.Fields...)
+
 		case *GraphStatement_Count:
 			add("Count")
 
@@ -289,21 +372,61 @@ func (q *Query) String() string {
 		case *GraphStatement_Aggregate:
 			add("Aggregate")
 
+		case *GraphStatement_Where:
+			add("Where", (&Query{Statements: stmt.Where.Query}).String())
+
+		case *GraphStatement_Not:
+			add("Not", (&Query{Statements: stmt.Not.Query}).String())
+
+		case *GraphStatement_Union:
+			add("Union", subQueryStrings(stmt.Union)...)
+
+		case *GraphStatement_Optional:
+			add("Optional", (&Query{Statements: stmt.Optional.Query}).String())
+
+		case *GraphStatement_Coalesce:
+			add("Coalesce", subQueryStrings(stmt.Coalesce)...)
+
 		case *GraphStatement_Render:
 			jtxt, err := protojson.Marshal(stmt.Render)
 			if err != nil {
 				log.Errorf("serialization error: %s", err)
 			}
 			add("Render", string(jtxt))
+
+		case *GraphStatement_Group:
+			jtxt, err := protojson.Marshal(stmt.Group.Value)
+			if err != nil {
+				log.Errorf("serialization error: %s", err)
+			}
+			add("Group", stmt.Group.Key, string(jtxt))
+
+		case *GraphStatement_Fold:
+			jtxt, err := protojson.Marshal(stmt.Fold)
+			if err != nil {
+				log.Errorf("serialization error: %s", err)
+			}
+			add("Fold", string(jtxt))
 		}
 	}
 
 	return strings.Join(parts, ".")
 }
 
+func subQueryStrings(subs *SubQueryList) []string {
+	out := []string{}
+	for _, sub := range subs.Queries {
+		out = append(out, (&Query{Statements: sub.Query}).String())
+	}
+	return out
+}
+
 func HasExpressionString(stmt *HasExpression) string {
 	if exp := stmt.GetCondition(); exp != nil {
 		//exp.Condition
+		if exp.ValueRef != "" {
+			return fmt.Sprintf("%s = %s", exp.Key, exp.ValueRef)
+		}
 		return fmt.Sprintf("%s = %s", exp.Key, exp.Value)
 	}
 	return ""
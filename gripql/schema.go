@@ -25,6 +25,9 @@ func GetDataFieldTypes(data map[string]interface{}) map[string]interface{} {
 func GetFieldType(field interface{}) string {
 	switch field.(type) {
 	case string:
+		if _, ok := ParseDateTime(field); ok {
+			return FieldType_DATETIME.String()
+		}
 		return FieldType_STRING.String()
 	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
 		return FieldType_NUMERIC.String()
@@ -0,0 +1,294 @@
+package schema
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/bmeg/grip/gripql"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// JSONSchemaDraft is the JSON Schema version of exported documents
+const JSONSchemaDraft = "https://json-schema.org/draft/2020-12/schema"
+
+// GraphToJSONSchema converts a graph schema into one JSON Schema document per
+// vertex label. The edges from each label are described as JSON Hyper-Schema
+// links, with the edge properties as the link submissionSchema.
+func GraphToJSONSchema(graph *gripql.Graph) ([]map[string]interface{}, error) {
+	links := map[string][]interface{}{}
+	edges := append([]*gripql.Edge{}, graph.GetEdges()...)
+	sort.SliceStable(edges, func(i, j int) bool {
+		if edges[i].Label != edges[j].Label {
+			return edges[i].Label < edges[j].Label
+		}
+		return edges[i].To < edges[j].To
+	})
+	for _, e := range edges {
+		link := map[string]interface{}{
+			"rel":          e.Label,
+			"href":         e.To + "/{id}",
+			"targetSchema": map[string]interface{}{"$ref": e.To},
+			"targetHints":  map[string]interface{}{"directionality": []interface{}{"outbound"}},
+		}
+		if data := e.GetDataMap(); len(data) > 0 {
+			link["submissionSchema"] = fieldsToJSONSchema(data)
+		}
+		links[e.From] = append(links[e.From], link)
+	}
+
+	out := []map[string]interface{}{}
+	for _, v := range graph.GetVertices() {
+		doc := fieldsToJSONSchema(v.GetDataMap())
+		doc["$schema"] = JSONSchemaDraft
+		doc["$id"] = v.Gid
+		doc["title"] = v.Gid
+		if l, ok := links[v.Gid]; ok {
+			doc["links"] = l
+		}
+		out = append(out, doc)
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		return out[i]["$id"].(string) < out[j]["$id"].(string)
+	})
+	return out, nil
+}
+
+func fieldsToJSONSchema(fields map[string]interface{}) map[string]interface{} {
+	props := map[string]interface{}{}
+	for k, v := range fields {
+		props[k] = fieldToJSONSchema(v)
+	}
+	return map[string]interface{}{"type": "object", "properties": props}
+}
+
+func fieldToJSONSchema(field interface{}) map[string]interface{} {
+	switch f := field.(type) {
+	case map[string]interface{}:
+		return fieldsToJSONSchema(f)
+	case []interface{}:
+		out := map[string]interface{}{"type": "array"}
+		if len(f) > 0 {
+			out["items"] = fieldToJSONSchema(f[0])
+		}
+		return out
+	case string:
+		switch f {
+		case gripql.FieldType_STRING.String():
+			return map[string]interface{}{"type": "string"}
+		case gripql.FieldType_DATETIME.String():
+			return map[string]interface{}{"type": "string", "format": "date-time"}
+		case gripql.FieldType_NUMERIC.String():
+			return map[string]interface{}{"type": "number"}
+		case gripql.FieldType_BOOL.String():
+			return map[string]interface{}{"type": "boolean"}
+		case gripql.FieldType_MAP.String():
+			return map[string]interface{}{"type": "object"}
+		case gripql.FieldType_ARRAY.String():
+			return map[string]interface{}{"type": "array"}
+		}
+	}
+	return map[string]interface{}{}
+}
+
+// JSONSchemaToGraph converts JSON Schema documents, one per vertex label, into
+// a graph schema. The label of each document is its title, or its $id if
+// there is no title. Edges are read from JSON Hyper-Schema links; the rel is the
+// edge label and the targetSchema $ref is the label of the destination vertex.
+func JSONSchemaToGraph(graphName string, docs []map[string]interface{}) (*gripql.Graph, error) {
+	vertices := []*gripql.Vertex{}
+	edges := []*gripql.Edge{}
+	for i, doc := range docs {
+		label := schemaLabel(doc)
+		if label == "" {
+			return nil, fmt.Errorf("document %d: no title or $id", i)
+		}
+		rels := map[string]bool{}
+		links, _ := doc["links"].([]interface{})
+		for _, l := range links {
+			link, ok := l.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			rel, _ := link["rel"].(string)
+			target, _ := link["targetSchema"].(map[string]interface{})
+			to := refLabel(target)
+			if rel == "" || to == "" {
+				return nil, fmt.Errorf("%s: links must have a rel and a targetSchema $ref", label)
+			}
+			rels[rel] = true
+			data := map[string]interface{}{}
+			if sub, ok := link["submissionSchema"].(map[string]interface{}); ok {
+				data = jsonSchemaToFields(doc, sub)
+			}
+			sData, err := structpb.NewStruct(data)
+			if err != nil {
+				return nil, fmt.Errorf("%s: link %s: %v", label, rel, err)
+			}
+			edges = append(edges, &gripql.Edge{
+				Gid:   fmt.Sprintf("(%s)--%s->(%s)", label, rel, to),
+				Label: rel,
+				From:  label,
+				To:    to,
+				Data:  sData,
+			})
+		}
+
+		fields := jsonSchemaToFields(doc, doc)
+		// link properties describe edges, not vertex data
+		for rel := range rels {
+			delete(fields, rel)
+		}
+		sData, err := structpb.NewStruct(fields)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", label, err)
+		}
+		vertices = append(vertices, &gripql.Vertex{Gid: label, Label: "Vertex", Data: sData})
+	}
+	return &gripql.Graph{Graph: graphName, Vertices: vertices, Edges: edges}, nil
+}
+
+func schemaLabel(doc map[string]interface{}) string {
+	if title, ok := doc["title"].(string); ok && title != "" {
+		return title
+	}
+	if id, ok := doc["$id"].(string); ok {
+		return refName(id)
+	}
+	return ""
+}
+
+// refLabel returns the vertex label referenced by a schema, such as
+// {"$ref": "Person"} or {"$ref": "person.yaml#/"}
+func refLabel(s map[string]interface{}) string {
+	ref, _ := s["$ref"].(string)
+	return refName(ref)
+}
+
+func refName(ref string) string {
+	ref = strings.SplitN(ref, "#", 2)[0]
+	ref = path.Base(ref)
+	if ref == "." || ref == "/" {
+		return ""
+	}
+	return strings.TrimSuffix(ref, path.Ext(ref))
+}
+
+// jsonSchemaToFields converts the properties of an object schema into GRIP
+// field types. Local references are resolved against root.
+func jsonSchemaToFields(root, s map[string]interface{}) map[string]interface{} {
+	out := map[string]interface{}{}
+	props, _ := resolveRef(root, s, 0)["properties"].(map[string]interface{})
+	for k, v := range props {
+		if p, ok := v.(map[string]interface{}); ok {
+			out[k] = jsonSchemaToField(root, p, 0)
+		}
+	}
+	return out
+}
+
+// maximum depth of nested references that will be resolved
+const maxRefDepth = 16
+
+func jsonSchemaToField(root, s map[string]interface{}, depth int) interface{} {
+	if depth > maxRefDepth {
+		return gripql.FieldType_UNKNOWN.String()
+	}
+	s = resolveRef(root, s, depth)
+	for _, key := range []string{"anyOf", "oneOf", "allOf"} {
+		if alts, ok := s[key].([]interface{}); ok {
+			for _, a := range alts {
+				if am, ok := a.(map[string]interface{}); ok {
+					if f := jsonSchemaToField(root, am, depth+1); f != gripql.FieldType_UNKNOWN.String() {
+						return f
+					}
+				}
+			}
+		}
+	}
+	switch schemaType(s) {
+	case "string":
+		if format, _ := s["format"].(string); format == "date-time" || format == "date" {
+			return gripql.FieldType_DATETIME.String()
+		}
+		return gripql.FieldType_STRING.String()
+	case "number", "integer":
+		return gripql.FieldType_NUMERIC.String()
+	case "boolean":
+		return gripql.FieldType_BOOL.String()
+	case "object":
+		props, ok := s["properties"].(map[string]interface{})
+		if !ok || len(props) == 0 {
+			return gripql.FieldType_MAP.String()
+		}
+		out := map[string]interface{}{}
+		for k, v := range props {
+			if p, ok := v.(map[string]interface{}); ok {
+				out[k] = jsonSchemaToField(root, p, depth+1)
+			}
+		}
+		return out
+	case "array":
+		if items, ok := s["items"].(map[string]interface{}); ok {
+			return []interface{}{jsonSchemaToField(root, items, depth+1)}
+		}
+		return gripql.FieldType_ARRAY.String()
+	}
+	return gripql.FieldType_UNKNOWN.String()
+}
+
+// schemaType returns the type of a schema, ignoring "null" in type lists and
+// inferring the type of enums without one
+func schemaType(s map[string]interface{}) string {
+	switch t := s["type"].(type) {
+	case string:
+		return t
+	case []interface{}:
+		for _, i := range t {
+			if ts, ok := i.(string); ok && ts != "null" {
+				return ts
+			}
+		}
+	}
+	if enum, ok := s["enum"].([]interface{}); ok && len(enum) > 0 {
+		switch gripql.GetFieldType(enum[0]) {
+		case gripql.FieldType_STRING.String(), gripql.FieldType_DATETIME.String():
+			return "string"
+		case gripql.FieldType_NUMERIC.String():
+			return "number"
+		case gripql.FieldType_BOOL.String():
+			return "boolean"
+		}
+	}
+	if _, ok := s["properties"]; ok {
+		return "object"
+	}
+	return ""
+}
+
+// resolveRef follows local references, such as "#/definitions/name", within
+// the root document. Other references are left unresolved.
+func resolveRef(root, s map[string]interface{}, depth int) map[string]interface{} {
+	for ; depth <= maxRefDepth; depth++ {
+		ref, ok := s["$ref"].(string)
+		if !ok || !strings.HasPrefix(ref, "#/") {
+			return s
+		}
+		var cur interface{} = root
+		for _, p := range strings.Split(strings.TrimPrefix(ref, "#/"), "/") {
+			p = strings.ReplaceAll(strings.ReplaceAll(p, "~1", "/"), "~0", "~")
+			m, ok := cur.(map[string]interface{})
+			if !ok {
+				return s
+			}
+			cur = m[p]
+		}
+		next, ok := cur.(map[string]interface{})
+		if !ok {
+			return s
+		}
+		s = next
+	}
+	return s
+}
@@ -0,0 +1,138 @@
+package schema
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/bmeg/grip/gripql"
+	"sigs.k8s.io/yaml"
+)
+
+var testSchema = `
+graph: test
+vertices:
+- gid: Person
+  label: Vertex
+  data:
+    name: STRING
+    age: NUMERIC
+    alive: BOOL
+    born: DATETIME
+    tags: [STRING]
+    address:
+      city: STRING
+- gid: Dog
+  label: Vertex
+  data:
+    name: STRING
+edges:
+- gid: (Person)--owns->(Dog)
+  label: owns
+  from: Person
+  to: Dog
+  data:
+    since: NUMERIC
+- gid: (Person)--friend->(Person)
+  label: friend
+  from: Person
+  to: Person
+  data: {}
+`
+
+func TestJSONSchemaRoundTrip(t *testing.T) {
+	graph, err := gripql.ParseYAMLGraph([]byte(testSchema))
+	if err != nil {
+		t.Fatal(err)
+	}
+	docs, err := GraphToJSONSchema(graph)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(docs) != 2 || docs[0]["$id"] != "Dog" || docs[1]["$id"] != "Person" {
+		t.Fatalf("unexpected documents: %v", docs)
+	}
+	if links, ok := docs[1]["links"].([]interface{}); !ok || len(links) != 2 {
+		t.Errorf("unexpected links: %v", docs[1]["links"])
+	}
+
+	out, err := JSONSchemaToGraph("test", docs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	vertices := map[string]map[string]interface{}{}
+	for _, v := range out.Vertices {
+		vertices[v.Gid] = v.GetDataMap()
+	}
+	for _, v := range graph.Vertices {
+		if !reflect.DeepEqual(vertices[v.Gid], v.GetDataMap()) {
+			t.Errorf("vertex %s: %v != %v", v.Gid, vertices[v.Gid], v.GetDataMap())
+		}
+	}
+	edges := map[string]map[string]interface{}{}
+	for _, e := range out.Edges {
+		edges[e.Gid] = e.GetDataMap()
+	}
+	for _, e := range graph.Edges {
+		if d, ok := edges[e.Gid]; !ok || !reflect.DeepEqual(d, e.GetDataMap()) {
+			t.Errorf("edge %s: %v != %v", e.Gid, d, e.GetDataMap())
+		}
+	}
+}
+
+var testJSONSchema = `
+$id: sample.yaml
+type: object
+definitions:
+  id:
+    type: string
+properties:
+  id:
+    $ref: "#/definitions/id"
+  count:
+    type: [integer, "null"]
+  state:
+    enum: [open, closed]
+  score:
+    anyOf:
+    - type: "null"
+    - type: number
+  projects:
+    type: array
+    items:
+      $ref: "#/definitions/id"
+links:
+- rel: projects
+  href: "project/{id}"
+  targetSchema:
+    $ref: project.yaml
+`
+
+func TestJSONSchemaImport(t *testing.T) {
+	doc := map[string]interface{}{}
+	if err := yaml.Unmarshal([]byte(testJSONSchema), &doc); err != nil {
+		t.Fatal(err)
+	}
+	graph, err := JSONSchemaToGraph("test", []map[string]interface{}{doc})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(graph.Vertices) != 1 || graph.Vertices[0].Gid != "sample" {
+		t.Fatalf("unexpected vertices: %v", graph.Vertices)
+	}
+	expected := map[string]interface{}{
+		"id":    "STRING",
+		"count": "NUMERIC",
+		"state": "STRING",
+		"score": "NUMERIC",
+	}
+	if d := graph.Vertices[0].GetDataMap(); !reflect.DeepEqual(d, expected) {
+		t.Errorf("unexpected fields: %v", d)
+	}
+	if len(graph.Edges) != 1 {
+		t.Fatalf("unexpected edges: %v", graph.Edges)
+	}
+	e := graph.Edges[0]
+	if e.From != "sample" || e.To != "project" || e.Label != "projects" {
+		t.Errorf("unexpected edge: %v", e)
+	}
+}
@@ -0,0 +1,163 @@
+package gripql
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+var placeholderRE = regexp.MustCompile(`\$\{([_A-Za-z][_0-9A-Za-z]*)\}`)
+var storedQueryNameRE = regexp.MustCompile(`^[_A-Za-z][_0-9A-Za-z]*$`)
+
+// Validate returns an error if the stored query is invalid: it must have a
+// name that can be used as a GraphQL field, a query, and only use parameters
+// that are declared
+func (def *QueryDefinition) Validate() error {
+	if !storedQueryNameRE.MatchString(def.Name) {
+		return fmt.Errorf("invalid stored query name '%s'; must match %s", def.Name, storedQueryNameRE)
+	}
+	if len(def.Query) == 0 {
+		return fmt.Errorf("stored query '%s' has no statements", def.Name)
+	}
+	declared := map[string]bool{}
+	for _, p := range def.Parameters {
+		if !storedQueryNameRE.MatchString(p.Name) {
+			return fmt.Errorf("invalid parameter name '%s'; must match %s", p.Name, storedQueryNameRE)
+		}
+		if declared[p.Name] {
+			return fmt.Errorf("parameter '%s' is declared more than once", p.Name)
+		}
+		declared[p.Name] = true
+		if p.DefaultValue != nil {
+			if err := checkParameterType(p, p.DefaultValue.AsInterface()); err != nil {
+				return fmt.Errorf("default value: %v", err)
+			}
+		}
+	}
+	for _, st := range def.Query {
+		data, err := protojson.Marshal(st)
+		if err != nil {
+			return err
+		}
+		for _, m := range placeholderRE.FindAllStringSubmatch(string(data), -1) {
+			if !declared[m[1]] {
+				return fmt.Errorf("stored query '%s' uses undeclared parameter '%s'", def.Name, m[1])
+			}
+		}
+	}
+	return nil
+}
+
+// Bind returns the statements of the stored query, with the parameters
+// replaced by the values given. A string that is only a placeholder is
+// replaced by the value, keeping its type, and an ARRAY value in a list is
+// spliced into the list. Placeholders within a longer string are replaced by
+// the text of the value.
+func (def *QueryDefinition) Bind(values map[string]interface{}) ([]*GraphStatement, error) {
+	params := map[string]interface{}{}
+	for _, p := range def.Parameters {
+		val, ok := values[p.Name]
+		if !ok || val == nil {
+			if p.DefaultValue == nil {
+				return nil, fmt.Errorf("missing required parameter '%s'", p.Name)
+			}
+			val = p.DefaultValue.AsInterface()
+		}
+		if err := checkParameterType(p, val); err != nil {
+			return nil, err
+		}
+		params[p.Name] = val
+	}
+	for k := range values {
+		if _, ok := params[k]; !ok {
+			return nil, fmt.Errorf("unknown parameter '%s'", k)
+		}
+	}
+
+	out := make([]*GraphStatement, len(def.Query))
+	for i, st := range def.Query {
+		data, err := protojson.Marshal(st)
+		if err != nil {
+			return nil, err
+		}
+		var doc interface{}
+		if err := json.Unmarshal(data, &doc); err != nil {
+			return nil, err
+		}
+		data, err = json.Marshal(substitute(doc, params))
+		if err != nil {
+			return nil, err
+		}
+		out[i] = &GraphStatement{}
+		if err := protojson.Unmarshal(data, out[i]); err != nil {
+			return nil, fmt.Errorf("statement %d: parameter value does not fit the statement: %v", i, err)
+		}
+	}
+	return out, nil
+}
+
+func checkParameterType(p *QueryParameter, val interface{}) error {
+	ok := true
+	switch p.Type {
+	case FieldType_STRING:
+		_, ok = val.(string)
+	case FieldType_DATETIME:
+		_, ok = ParseDateTime(val)
+	case FieldType_NUMERIC:
+		switch val.(type) {
+		case float64, float32, int, int32, int64, uint32, uint64:
+		default:
+			ok = false
+		}
+	case FieldType_BOOL:
+		_, ok = val.(bool)
+	case FieldType_ARRAY:
+		_, ok = val.([]interface{})
+	case FieldType_MAP:
+		_, ok = val.(map[string]interface{})
+	}
+	if !ok {
+		return fmt.Errorf("parameter '%s' must be of type %s, got %T", p.Name, p.Type, val)
+	}
+	return nil
+}
+
+func substitute(doc interface{}, params map[string]interface{}) interface{} {
+	switch x := doc.(type) {
+	case string:
+		if m := placeholderRE.FindStringSubmatch(x); m != nil && m[0] == x {
+			return params[m[1]]
+		}
+		return placeholderRE.ReplaceAllStringFunc(x, func(s string) string {
+			val := params[placeholderRE.FindStringSubmatch(s)[1]]
+			if str, ok := val.(string); ok {
+				return str
+			}
+			data, _ := json.Marshal(val)
+			return string(data)
+		})
+	case []interface{}:
+		out := []interface{}{}
+		for _, v := range x {
+			if s, ok := v.(string); ok {
+				if m := placeholderRE.FindStringSubmatch(s); m != nil && m[0] == s {
+					if list, ok := params[m[1]].([]interface{}); ok {
+						out = append(out, list...)
+						continue
+					}
+				}
+			}
+			out = append(out, substitute(v, params))
+		}
+		return out
+	case map[string]interface{}:
+		out := map[string]interface{}{}
+		for k, v := range x {
+			out[k] = substitute(v, params)
+		}
+		return out
+	}
+	return doc
+}
@@ -0,0 +1,78 @@
+package gripql
+
+import (
+	"testing"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestStoredQueryBind(t *testing.T) {
+	def := &QueryDefinition{
+		Graph: "test",
+		Name:  "friendsOf",
+		Parameters: []*QueryParameter{
+			{Name: "ids", Type: FieldType_ARRAY},
+			{Name: "age", Type: FieldType_NUMERIC, DefaultValue: structpb.NewNumberValue(21)},
+			{Name: "label", Type: FieldType_STRING, DefaultValue: structpb.NewStringValue("knows")},
+		},
+		Query: V("${ids}").Out("${label}").Has(Gt("age", "${age}")).As("${label}_friend").Statements,
+	}
+	if err := def.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := V("1", "2").Out("likes").Has(Gt("age", 30.0)).As("likes_friend").Statements
+	query, err := def.Bind(map[string]interface{}{"ids": []interface{}{"1", "2"}, "age": 30.0, "label": "likes"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(query) != len(expected) {
+		t.Fatalf("expected %d statements, got %d", len(expected), len(query))
+	}
+	for i := range expected {
+		a, _ := protojson.Marshal(expected[i])
+		b, _ := protojson.Marshal(query[i])
+		if string(a) != string(b) {
+			t.Errorf("statement %d: expected %s, got %s", i, a, b)
+		}
+	}
+
+	query, err = def.Bind(map[string]interface{}{"ids": []interface{}{"1"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if query[1].GetOut().Values[0].GetStringValue() != "knows" {
+		t.Errorf("default value was not used: %s", query[1])
+	}
+
+	if _, err := def.Bind(map[string]interface{}{}); err == nil {
+		t.Error("expected error for missing parameter")
+	}
+	if _, err := def.Bind(map[string]interface{}{"ids": []interface{}{"1"}, "age": "old"}); err == nil {
+		t.Error("expected error for parameter of the wrong type")
+	}
+	if _, err := def.Bind(map[string]interface{}{"ids": []interface{}{"1"}, "other": 1.0}); err == nil {
+		t.Error("expected error for unknown parameter")
+	}
+}
+
+func TestStoredQueryValidate(t *testing.T) {
+	invalid := []*QueryDefinition{
+		{Name: "bad name", Query: V().Statements},
+		{Name: "empty"},
+		{Name: "undeclared", Query: V("${id}").Statements},
+		{Name: "duplicate", Query: V().Statements, Parameters: []*QueryParameter{{Name: "a"}, {Name: "a"}}},
+		{Name: "badDefault", Query: V().Statements, Parameters: []*QueryParameter{
+			{Name: "a", Type: FieldType_BOOL, DefaultValue: structpb.NewStringValue("yes")},
+		}},
+		{Name: "badDate", Query: V().Statements, Parameters: []*QueryParameter{
+			{Name: "a", Type: FieldType_DATETIME, DefaultValue: structpb.NewStringValue("yesterday")},
+		}},
+	}
+	for _, def := range invalid {
+		if err := def.Validate(); err == nil {
+			t.Errorf("expected error for stored query %s", def.Name)
+		}
+	}
+}
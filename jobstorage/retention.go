@@ -0,0 +1,152 @@
+package jobstorage
+
+import (
+	"sort"
+	"time"
+
+	"github.com/bmeg/grip/gripql"
+	"github.com/bmeg/grip/util/duration"
+)
+
+// RetentionPolicy describes when spooled job results should be removed
+type RetentionPolicy struct {
+	// Remove jobs that were submitted longer ago than this. Set to 0 to turn off
+	MaxAge duration.Duration
+	// Maximum number of bytes of results kept across all graphs. Least recently
+	// used jobs are removed first. Set to 0 to turn off
+	MaxBytes int64
+	// Maximum number of bytes of results kept for each graph. Set to 0 to turn off
+	MaxGraphBytes int64
+	// Remove jobs once the graph they were run against has been modified
+	InvalidateOnChange bool
+	// How often the server should apply the policy. Set to 0 to turn off
+	Interval duration.Duration
+}
+
+// TimestampFunc returns the current timestamp of a graph
+type TimestampFunc func(graph string) string
+
+// IsSet returns true if the policy will remove any jobs
+func (p RetentionPolicy) IsSet() bool {
+	return p.MaxAge > 0 || p.MaxBytes > 0 || p.MaxGraphBytes > 0 || p.InvalidateOnChange
+}
+
+// expired returns true if the job should be removed, regardless of quotas
+func (p RetentionPolicy) expired(job *Job, now time.Time, ts TimestampFunc) bool {
+	if p.MaxAge > 0 {
+		if created, err := time.Parse(time.RFC3339, job.Status.Timestamp); err == nil {
+			if now.Sub(created) > time.Duration(p.MaxAge) {
+				return true
+			}
+		}
+	}
+	if p.InvalidateOnChange && ts != nil {
+		if job.Status.GraphTimestamp != ts(job.Status.Graph) {
+			return true
+		}
+	}
+	return false
+}
+
+// selectEvictions returns the jobs that should be removed under the policy.
+// Only jobs of graph (or all jobs if graph is empty) are removed, but quotas
+// are computed across every job in the list.
+func (p RetentionPolicy) selectEvictions(graph string, jobs []*Job, now time.Time, ts TimestampFunc) []*Job {
+	out := []*Job{}
+	kept := []*Job{}
+	for _, j := range jobs {
+		if (graph == "" || j.Status.Graph == graph) && p.expired(j, now, ts) {
+			out = append(out, j)
+		} else {
+			kept = append(kept, j)
+		}
+	}
+
+	// least recently used first
+	size := map[*Job]int64{}
+	access := map[*Job]time.Time{}
+	for _, j := range kept {
+		size[j], access[j] = j.usage()
+	}
+	sort.SliceStable(kept, func(i, j int) bool {
+		return access[kept[i]].Before(access[kept[j]])
+	})
+
+	evicted := map[*Job]bool{}
+	if p.MaxGraphBytes > 0 {
+		graphBytes := map[string]int64{}
+		for _, j := range kept {
+			graphBytes[j.Status.Graph] += size[j]
+		}
+		for _, j := range kept {
+			if graph != "" && j.Status.Graph != graph {
+				continue
+			}
+			if graphBytes[j.Status.Graph] > p.MaxGraphBytes {
+				graphBytes[j.Status.Graph] -= size[j]
+				evicted[j] = true
+			}
+		}
+	}
+	if p.MaxBytes > 0 {
+		var total int64
+		for _, j := range kept {
+			if !evicted[j] {
+				total += size[j]
+			}
+		}
+		for _, j := range kept {
+			if total <= p.MaxBytes {
+				break
+			}
+			if evicted[j] || (graph != "" && j.Status.Graph != graph) {
+				continue
+			}
+			total -= size[j]
+			evicted[j] = true
+		}
+	}
+	for _, j := range kept {
+		if evicted[j] {
+			out = append(out, j)
+		}
+	}
+	return out
+}
+
+// Collect removes the finished jobs of graph that violate the retention policy,
+// and returns the status of each removed job. If graph is empty, all graphs are
+// collected.
+func (fs *FSResults) Collect(graph string, policy RetentionPolicy, ts TimestampFunc) (chan *gripql.JobStatus, error) {
+	jobs := []*Job{}
+	fs.jobs.Range(func(key, value interface{}) bool {
+		vJob := value.(*Job)
+		if vJob.Status.State == gripql.JobState_COMPLETE || vJob.Status.State == gripql.JobState_ERROR {
+			jobs = append(jobs, vJob)
+		}
+		return true
+	})
+	evictions := policy.selectEvictions(graph, jobs, time.Now(), ts)
+	return deleteJobs(evictions, fs.Delete), nil
+}
+
+// deleteJobs removes each of the jobs, and returns the status of the ones
+// that were removed
+func deleteJobs(jobs []*Job, del func(graph, id string) error) chan *gripql.JobStatus {
+	out := make(chan *gripql.JobStatus, len(jobs))
+	go func() {
+		defer close(out)
+		for _, j := range jobs {
+			if err := del(j.Status.Graph, j.Status.Id); err == nil {
+				out <- &gripql.JobStatus{
+					Id:        j.Status.Id,
+					Graph:     j.Status.Graph,
+					State:     gripql.JobState_DELETED,
+					Count:     j.Status.Count,
+					Timestamp: j.Status.Timestamp,
+				}
+			}
+		}
+	}()
+	return out
+}
@@ -0,0 +1,120 @@
+package jobstorage
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/bmeg/grip/gdbi"
+	"github.com/bmeg/grip/gripql"
+	"github.com/bmeg/grip/util/duration"
+)
+
+func testJob(graph, id string, created, access time.Time, size int64) *Job {
+	return &Job{
+		Status: gripql.JobStatus{
+			Graph:          graph,
+			Id:             id,
+			State:          gripql.JobState_COMPLETE,
+			Timestamp:      created.Format(time.RFC3339),
+			GraphTimestamp: "1",
+		},
+		Size:       size,
+		LastAccess: access,
+	}
+}
+
+func evictedIDs(jobs []*Job) map[string]bool {
+	out := map[string]bool{}
+	for _, j := range jobs {
+		out[j.Status.Id] = true
+	}
+	return out
+}
+
+func TestRetentionSelect(t *testing.T) {
+	now := time.Now()
+	jobs := []*Job{
+		testJob("a", "old", now.Add(-48*time.Hour), now, 10),
+		testJob("a", "lru", now.Add(-time.Hour), now.Add(-3*time.Hour), 10),
+		testJob("a", "recent", now.Add(-time.Hour), now.Add(-time.Minute), 10),
+		testJob("b", "other", now.Add(-time.Hour), now.Add(-4*time.Hour), 10),
+	}
+
+	p := RetentionPolicy{MaxAge: duration.Duration(24 * time.Hour)}
+	e := evictedIDs(p.selectEvictions("", jobs, now, nil))
+	if len(e) != 1 || !e["old"] {
+		t.Errorf("unexpected age evictions: %v", e)
+	}
+
+	p = RetentionPolicy{MaxGraphBytes: 15}
+	e = evictedIDs(p.selectEvictions("", jobs, now, nil))
+	if len(e) != 2 || !e["lru"] || !e["recent"] {
+		t.Errorf("unexpected graph quota evictions: %v", e)
+	}
+
+	p = RetentionPolicy{MaxBytes: 25}
+	e = evictedIDs(p.selectEvictions("", jobs, now, nil))
+	if len(e) != 2 || !e["other"] || !e["lru"] {
+		t.Errorf("unexpected total quota evictions: %v", e)
+	}
+
+	// only jobs of the requested graph are removed
+	e = evictedIDs(p.selectEvictions("a", jobs, now, nil))
+	if len(e) != 2 || !e["lru"] || !e["recent"] {
+		t.Errorf("unexpected graph scoped evictions: %v", e)
+	}
+
+	p = RetentionPolicy{InvalidateOnChange: true}
+	ts := func(graph string) string {
+		if graph == "b" {
+			return "2"
+		}
+		return "1"
+	}
+	e = evictedIDs(p.selectEvictions("", jobs, now, ts))
+	if len(e) != 1 || !e["other"] {
+		t.Errorf("unexpected timestamp evictions: %v", e)
+	}
+}
+
+func TestRetentionCollect(t *testing.T) {
+	fs := NewFSJobStorage(t.TempDir())
+	ts := func(graph string) string { return "1" }
+	ids := []string{}
+	for i := 0; i < 3; i++ {
+		in := make(chan gdbi.Traveler)
+		close(in)
+		id, err := fs.Spool("graph", &Stream{Pipe: in, GraphTimestamp: fmt.Sprintf("%d", i)})
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, id)
+	}
+	for _, id := range ids {
+		for {
+			s, _ := fs.Status("graph", id)
+			if s.State == gripql.JobState_COMPLETE {
+				break
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+	removed, err := fs.Collect("graph", RetentionPolicy{InvalidateOnChange: true}, ts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	count := 0
+	for r := range removed {
+		if r.State != gripql.JobState_DELETED {
+			t.Errorf("unexpected state: %s", r.State)
+		}
+		count++
+	}
+	if count != 2 {
+		t.Errorf("wrong number of removed jobs: %d != 2", count)
+	}
+	if _, err := fs.Status("graph", ids[1]); err != nil {
+		t.Errorf("job with matching timestamp was removed")
+	}
+}
@@ -0,0 +1,405 @@
+package jobstorage
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/bmeg/grip/gripql"
+	"github.com/bmeg/grip/util"
+	"github.com/bmeg/grip/util/duration"
+	"github.com/kennygrant/sanitize"
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+// S3Config describes an S3 compatible object store used to store jobs
+type S3Config struct {
+	// Host and port of the object store
+	Endpoint string
+	Bucket   string
+	// Key prefix that all jobs are stored under
+	Prefix string
+	// Credentials. If not set AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY are used
+	AccessKeyID     string
+	SecretAccessKey string
+	Region          string
+	Secure          bool
+	// How often running jobs update their status. A running job that hasn't
+	// been updated for four intervals is treated as failed, as the server
+	// running it has stopped. Defaults to 30s
+	Heartbeat duration.Duration
+}
+
+// upload part size used when spooling results of unknown length
+const s3PartSize = 16 * 1024 * 1024
+
+// s3Heartbeat is the default interval at which running jobs update their status
+const s3Heartbeat = 30 * time.Second
+
+// s3MissedHeartbeats is the number of heartbeats a running job can miss
+// before it is treated as failed
+const s3MissedHeartbeats = 4
+
+// S3Results stores job status and results in an S3 compatible object store,
+// using the same layout as FSResults, so jobs can be shared between servers
+type S3Results struct {
+	client    *minio.Client
+	bucket    string
+	prefix    string
+	heartbeat time.Duration
+}
+
+func NewS3JobStorage(conf S3Config) (*S3Results, error) {
+	accessKeyID := conf.AccessKeyID
+	if accessKeyID == "" {
+		accessKeyID = os.Getenv("AWS_ACCESS_KEY_ID")
+	}
+	secretAccessKey := conf.SecretAccessKey
+	if secretAccessKey == "" {
+		secretAccessKey = os.Getenv("AWS_SECRET_ACCESS_KEY")
+	}
+	region := conf.Region
+	if region == "" {
+		region = "us-east-1"
+	}
+	client, err := minio.New(conf.Endpoint, &minio.Options{
+		Creds:  credentials.NewStaticV4(accessKeyID, secretAccessKey, ""),
+		Secure: conf.Secure,
+		Region: region,
+	})
+	if err != nil {
+		return nil, err
+	}
+	exists, err := client.BucketExists(context.Background(), conf.Bucket)
+	if err != nil {
+		return nil, fmt.Errorf("checking job bucket %s: %v", conf.Bucket, err)
+	}
+	if !exists {
+		err = client.MakeBucket(context.Background(), conf.Bucket, minio.MakeBucketOptions{Region: region})
+		if err != nil {
+			return nil, fmt.Errorf("creating job bucket %s: %v", conf.Bucket, err)
+		}
+	}
+	heartbeat := time.Duration(conf.Heartbeat)
+	if heartbeat <= 0 {
+		heartbeat = s3Heartbeat
+	}
+	return &S3Results{client: client, bucket: conf.Bucket, prefix: strings.Trim(conf.Prefix, "/"), heartbeat: heartbeat}, nil
+}
+
+func (s3 *S3Results) graphKey(graph string) string {
+	return path.Join(s3.prefix, sanitize.Name(graph)) + "/"
+}
+
+func (s3 *S3Results) objectKey(graph, id, name string) string {
+	return path.Join(s3.prefix, jobKey(graph, id), name)
+}
+
+func (s3 *S3Results) readJob(ctx context.Context, graph, id string) (*Job, error) {
+	obj, err := s3.client.GetObject(ctx, s3.bucket, s3.objectKey(graph, id, "status"), minio.GetObjectOptions{})
+	if err != nil {
+		return nil, err
+	}
+	defer obj.Close()
+	sData, err := ioutil.ReadAll(obj)
+	if err != nil {
+		if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+			return nil, fmt.Errorf("Job Not Found")
+		}
+		return nil, err
+	}
+	job := Job{}
+	if err := json.Unmarshal(sData, &job); err != nil {
+		return nil, err
+	}
+	if s3.stale(&job, time.Now()) {
+		job.Status.State = gripql.JobState_ERROR
+		job.Status.Error = fmt.Sprintf("job stopped updating its status at %s", job.Heartbeat.Format(time.RFC3339))
+	}
+	return &job, nil
+}
+
+// stale returns true if the job is running, but hasn't updated its status
+// for too long, because the server running it has stopped
+func (s3 *S3Results) stale(job *Job, now time.Time) bool {
+	if job.Status.State != gripql.JobState_RUNNING && job.Status.State != gripql.JobState_QUEUED {
+		return false
+	}
+	if job.Heartbeat.IsZero() {
+		created, err := time.Parse(time.RFC3339, job.Status.Timestamp)
+		if err != nil {
+			return false
+		}
+		job.Heartbeat = created
+	}
+	return now.Sub(job.Heartbeat) > s3MissedHeartbeats*s3.heartbeat
+}
+
+// readAccess sets the last access time of the job, which is stored apart
+// from the status so reading results doesn't rewrite it
+func (s3 *S3Results) readAccess(ctx context.Context, job *Job) {
+	obj, err := s3.client.GetObject(ctx, s3.bucket, s3.objectKey(job.Status.Graph, job.Status.Id, "access"), minio.GetObjectOptions{})
+	if err != nil {
+		return
+	}
+	defer obj.Close()
+	data, err := ioutil.ReadAll(obj)
+	if err != nil {
+		return
+	}
+	if t, err := time.Parse(time.RFC3339Nano, strings.TrimSpace(string(data))); err == nil {
+		job.mu.Lock()
+		if t.After(job.LastAccess) {
+			job.LastAccess = t
+		}
+		job.mu.Unlock()
+	}
+}
+
+// writeAccess records that the results of the job were read
+func (s3 *S3Results) writeAccess(ctx context.Context, graph, id string) error {
+	data := []byte(time.Now().UTC().Format(time.RFC3339Nano) + "\n")
+	_, err := s3.client.PutObject(ctx, s3.bucket, s3.objectKey(graph, id, "access"),
+		bytes.NewReader(data), int64(len(data)), minio.PutObjectOptions{ContentType: "text/plain"})
+	return err
+}
+
+// beat updates the status of a running job every heartbeat interval, until
+// stop is closed
+func (s3 *S3Results) beat(ctx context.Context, job *Job, stop chan struct{}, done chan struct{}) {
+	defer close(done)
+	ticker := time.NewTicker(s3.heartbeat)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			job.mu.Lock()
+			job.Heartbeat = time.Now()
+			job.mu.Unlock()
+			if err := s3.writeJob(ctx, job); err != nil {
+				log.Printf("Error updating job status: %s %s", job.Status.Id, err)
+			}
+		}
+	}
+}
+
+func (s3 *S3Results) writeJob(ctx context.Context, job *Job) error {
+	sData, err := job.marshal()
+	if err != nil {
+		return err
+	}
+	sData = append(sData, '\n')
+	_, err = s3.client.PutObject(ctx, s3.bucket, s3.objectKey(job.Status.Graph, job.Status.Id, "status"),
+		bytes.NewReader(sData), int64(len(sData)), minio.PutObjectOptions{ContentType: "application/json"})
+	return err
+}
+
+// listJobs reads the status of every job of graph, or all jobs if graph is empty
+func (s3 *S3Results) listJobs(ctx context.Context, graph string) chan *Job {
+	prefix := s3.graphKey(graph)
+	if graph == "" {
+		prefix = s3.prefix
+		if prefix != "" {
+			prefix = prefix + "/"
+		}
+	}
+	out := make(chan *Job, 10)
+	go func() {
+		defer close(out)
+		for obj := range s3.client.ListObjects(ctx, s3.bucket, minio.ListObjectsOptions{Prefix: prefix, Recursive: true}) {
+			if obj.Err != nil {
+				log.Printf("Error listing jobs: %s", obj.Err)
+				return
+			}
+			if path.Base(obj.Key) != "status" {
+				continue
+			}
+			jobDir := path.Dir(obj.Key)
+			graphName := path.Base(path.Dir(jobDir))
+			job, err := s3.readJob(ctx, graphName, path.Base(jobDir))
+			if err != nil {
+				log.Printf("Error reading job data: %s", err)
+				continue
+			}
+			if graph == "" || job.Status.Graph == graph {
+				out <- job
+			}
+		}
+	}()
+	return out
+}
+
+func (s3 *S3Results) List(graph string) (chan string, error) {
+	out := make(chan string)
+	go func() {
+		defer close(out)
+		for job := range s3.listJobs(context.Background(), graph) {
+			out <- job.Status.Id
+		}
+	}()
+	return out, nil
+}
+
+func (s3 *S3Results) Search(graph string, Query []*gripql.GraphStatement) (chan *gripql.JobStatus, error) {
+	out := make(chan *gripql.JobStatus)
+	qcs, _ := TraversalChecksum(Query)
+	go func() {
+		defer close(out)
+		for job := range s3.listJobs(context.Background(), graph) {
+			if JobMatch(qcs, job.StepChecksums) {
+				out <- &job.Status
+			}
+		}
+	}()
+	return out, nil
+}
+
+func (s3 *S3Results) Spool(graph string, stream *Stream) (string, error) {
+	ctx := context.Background()
+	// servers sharing the bucket name jobs independently, so the id must be
+	// unique across them
+	jobName := "job-" + util.UUID()
+	cs, _ := TraversalChecksum(stream.Query)
+	job := &Job{
+		Status: gripql.JobStatus{Query: stream.Query, Id: jobName, Graph: graph,
+			Timestamp: time.Now().Format(time.RFC3339), GraphTimestamp: stream.GraphTimestamp},
+		DataType:      stream.DataType,
+		MarkTypes:     stream.MarkTypes,
+		StepChecksums: cs,
+		Parallelism:   stream.Parallelism,
+		PreserveOrder: stream.PreserveOrder,
+		LastAccess:    time.Now(),
+		Heartbeat:     time.Now(),
+	}
+	job.Status.State = gripql.JobState_RUNNING
+	if err := s3.writeJob(ctx, job); err != nil {
+		return "", err
+	}
+
+	reader, writer := io.Pipe()
+	tbStream := MarshalStream(stream.Pipe, 4) //TODO: make worker count configurable
+	// the results are counted apart from the job, which the heartbeat
+	// serializes while they are written
+	counted := make(chan uint64, 1)
+	go func() {
+		defer writer.Close()
+		var count uint64
+		for i := range tbStream {
+			writer.Write(i)
+			writer.Write([]byte("\n"))
+			count++
+		}
+		counted <- count
+	}()
+	log.Printf("Starting Job: %#v", job)
+	stop := make(chan struct{})
+	stopped := make(chan struct{})
+	go s3.beat(ctx, job, stop, stopped)
+	go func() {
+		info, err := s3.client.PutObject(ctx, s3.bucket, s3.objectKey(graph, jobName, "results"),
+			reader, -1, minio.PutObjectOptions{PartSize: s3PartSize})
+		if err != nil {
+			// unblock the writer, which still drains the results
+			reader.CloseWithError(err)
+		}
+		count := <-counted
+		close(stop)
+		<-stopped
+		job.Status.Count = count
+		if err != nil {
+			job.Status.State = gripql.JobState_ERROR
+			log.Printf("Job Error: %s %s", jobName, err)
+		} else if stream.Err != nil && stream.Err() != nil {
+			job.Size = info.Size
+			job.Status.State = gripql.JobState_ERROR
+			job.Status.Error = stream.Err().Error()
+			log.Printf("Job Error: %s %s", jobName, job.Status.Error)
+		} else {
+			job.Size = info.Size
+			job.Status.State = gripql.JobState_COMPLETE
+			log.Printf("Job Done: %s (%d results)", jobName, job.Status.Count)
+		}
+		if err := s3.writeJob(ctx, job); err != nil {
+			log.Printf("Job Error: %s %s", jobName, err)
+		}
+	}()
+	return jobName, nil
+}
+
+func (s3 *S3Results) Stream(ctx context.Context, graph, id string) (*Stream, error) {
+	job, err := s3.readJob(ctx, graph, id)
+	if err != nil {
+		return nil, err
+	}
+	if job.Status.State == gripql.JobState_ERROR && job.Status.Error != "" {
+		return nil, fmt.Errorf("Job %s failed: %s", id, job.Status.Error)
+	}
+	if job.Status.State != gripql.JobState_COMPLETE {
+		return nil, fmt.Errorf("Job %s not complete", id)
+	}
+	if err := s3.writeAccess(ctx, graph, id); err != nil {
+		log.Printf("Error updating job access time: %s", err)
+	}
+	results, err := s3.client.GetObject(ctx, s3.bucket, s3.objectKey(graph, id, "results"), minio.GetObjectOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return &Stream{
+		Pipe:           streamResults(ctx, results),
+		DataType:       job.DataType,
+		MarkTypes:      job.MarkTypes,
+		GraphTimestamp: job.Status.GraphTimestamp,
+		Parallelism:    job.Parallelism,
+		PreserveOrder:  job.PreserveOrder,
+	}, nil
+}
+
+func (s3 *S3Results) Delete(graph, id string) error {
+	ctx := context.Background()
+	job, err := s3.readJob(ctx, graph, id)
+	if err != nil {
+		return err
+	}
+	if job.Status.State == gripql.JobState_RUNNING || job.Status.State == gripql.JobState_QUEUED {
+		return fmt.Errorf("Job cancel not yet implemented")
+	}
+	for _, name := range []string{"results", "access", "status"} {
+		err := s3.client.RemoveObject(ctx, s3.bucket, s3.objectKey(graph, id, name), minio.RemoveObjectOptions{})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s3 *S3Results) Status(graph, id string) (*gripql.JobStatus, error) {
+	job, err := s3.readJob(context.Background(), graph, id)
+	if err != nil {
+		return nil, err
+	}
+	return &job.Status, nil
+}
+
+func (s3 *S3Results) Collect(graph string, policy RetentionPolicy, ts TimestampFunc) (chan *gripql.JobStatus, error) {
+	jobs := []*Job{}
+	ctx := context.Background()
+	for job := range s3.listJobs(ctx, "") {
+		if job.Status.State == gripql.JobState_COMPLETE || job.Status.State == gripql.JobState_ERROR {
+			s3.readAccess(ctx, job)
+			jobs = append(jobs, job)
+		}
+	}
+	evictions := policy.selectEvictions(graph, jobs, time.Now(), ts)
+	return deleteJobs(evictions, s3.Delete), nil
+}
@@ -0,0 +1,384 @@
+package jobstorage
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bmeg/grip/gdbi"
+	"github.com/bmeg/grip/gripql"
+	"github.com/bmeg/grip/util/duration"
+)
+
+// fakeS3 is a minimal in memory stand-in for an S3 compatible object store,
+// implementing only the calls used by S3Results
+type fakeS3 struct {
+	mu      sync.Mutex
+	buckets map[string]map[string][]byte
+	uploads map[string]map[int][]byte
+}
+
+func newFakeS3() *fakeS3 {
+	return &fakeS3{buckets: map[string]map[string][]byte{}, uploads: map[string]map[int][]byte{}}
+}
+
+type listBucketResult struct {
+	XMLName     xml.Name `xml:"ListBucketResult"`
+	Name        string
+	Prefix      string
+	KeyCount    int
+	MaxKeys     int
+	IsTruncated bool
+	Contents    []listObject
+}
+
+type listObject struct {
+	Key          string
+	Size         int
+	ETag         string
+	LastModified string
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	parts := strings.SplitN(strings.TrimPrefix(r.URL.Path, "/"), "/", 2)
+	bucket := parts[0]
+	key := ""
+	if len(parts) > 1 {
+		key = parts[1]
+	}
+	query := r.URL.Query()
+	objects, bucketExists := f.buckets[bucket]
+
+	notFound := func(code string) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprintf(w, "<Error><Code>%s</Code><Message>not found</Message></Error>", code)
+	}
+
+	if key == "" {
+		switch r.Method {
+		case http.MethodHead:
+			if !bucketExists {
+				w.WriteHeader(http.StatusNotFound)
+			}
+		case http.MethodPut:
+			f.buckets[bucket] = map[string][]byte{}
+		case http.MethodGet:
+			if !bucketExists {
+				notFound("NoSuchBucket")
+				return
+			}
+			prefix := query.Get("prefix")
+			res := listBucketResult{Name: bucket, Prefix: prefix, MaxKeys: 1000}
+			keys := []string{}
+			for k := range objects {
+				if strings.HasPrefix(k, prefix) {
+					keys = append(keys, k)
+				}
+			}
+			sort.Strings(keys)
+			for _, k := range keys {
+				res.Contents = append(res.Contents, listObject{
+					Key: k, Size: len(objects[k]), ETag: `"etag"`,
+					LastModified: time.Now().UTC().Format(time.RFC3339),
+				})
+			}
+			res.KeyCount = len(res.Contents)
+			xml.NewEncoder(w).Encode(res)
+		}
+		return
+	}
+
+	if !bucketExists {
+		notFound("NoSuchBucket")
+		return
+	}
+	body, _ := ioutil.ReadAll(r.Body)
+	if strings.HasPrefix(r.Header.Get("X-Amz-Content-Sha256"), "STREAMING-") {
+		body = decodeChunked(body)
+	}
+	switch r.Method {
+	case http.MethodPost:
+		if _, ok := query["uploads"]; ok {
+			id := fmt.Sprintf("upload-%d", len(f.uploads))
+			f.uploads[id] = map[int][]byte{}
+			fmt.Fprintf(w, "<InitiateMultipartUploadResult><Bucket>%s</Bucket><Key>%s</Key><UploadId>%s</UploadId></InitiateMultipartUploadResult>", bucket, key, id)
+			return
+		}
+		id := query.Get("uploadId")
+		nums := []int{}
+		for n := range f.uploads[id] {
+			nums = append(nums, n)
+		}
+		sort.Ints(nums)
+		data := []byte{}
+		for _, n := range nums {
+			data = append(data, f.uploads[id][n]...)
+		}
+		objects[key] = data
+		delete(f.uploads, id)
+		fmt.Fprintf(w, "<CompleteMultipartUploadResult><Bucket>%s</Bucket><Key>%s</Key><ETag>\"etag\"</ETag></CompleteMultipartUploadResult>", bucket, key)
+	case http.MethodPut:
+		if id := query.Get("uploadId"); id != "" {
+			var n int
+			fmt.Sscanf(query.Get("partNumber"), "%d", &n)
+			f.uploads[id][n] = body
+		} else {
+			objects[key] = body
+		}
+		w.Header().Set("ETag", `"etag"`)
+	case http.MethodGet, http.MethodHead:
+		data, ok := objects[key]
+		if !ok {
+			notFound("NoSuchKey")
+			return
+		}
+		w.Header().Set("Content-Length", fmt.Sprintf("%d", len(data)))
+		w.Header().Set("Last-Modified", time.Now().UTC().Format(http.TimeFormat))
+		w.Header().Set("ETag", `"etag"`)
+		if r.Method == http.MethodGet {
+			w.Write(data)
+		}
+	case http.MethodDelete:
+		delete(objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// decodeChunked strips the signatures from an aws-chunked request body
+func decodeChunked(body []byte) []byte {
+	out := []byte{}
+	for len(body) > 0 {
+		header := body
+		if i := bytes.Index(body, []byte("\r\n")); i >= 0 {
+			header = body[:i]
+			body = body[i+2:]
+		}
+		var size int
+		fmt.Sscanf(strings.SplitN(string(header), ";", 2)[0], "%x", &size)
+		if size == 0 || size > len(body) {
+			break
+		}
+		out = append(out, body[:size]...)
+		body = bytes.TrimPrefix(body[size:], []byte("\r\n"))
+	}
+	return out
+}
+
+func waitForJob(t *testing.T, js JobStorage, graph, id string) *gripql.JobStatus {
+	for i := 0; i < 500; i++ {
+		s, err := js.Status(graph, id)
+		if err == nil && s.State != gripql.JobState_RUNNING && s.State != gripql.JobState_QUEUED {
+			return s
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("job %s did not finish", id)
+	return nil
+}
+
+func TestS3JobStorage(t *testing.T) {
+	fake := newFakeS3()
+	srv := httptest.NewServer(fake)
+	defer srv.Close()
+	u, _ := url.Parse(srv.URL)
+	conf := S3Config{Endpoint: u.Host, Bucket: "jobs", Prefix: "grip", AccessKeyID: "test", SecretAccessKey: "test"}
+
+	// two storage instances sharing the same bucket, like two servers behind
+	// a load balancer
+	js1, err := NewS3JobStorage(conf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	js2, err := NewS3JobStorage(conf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	totalCount := 100
+	in := make(chan gdbi.Traveler, 10)
+	go func() {
+		defer close(in)
+		for i := 0; i < totalCount; i++ {
+			in <- &gdbi.BaseTraveler{Current: &gdbi.Vertex{ID: fmt.Sprintf("%d", i)}}
+		}
+	}()
+	query := gripql.NewQuery().V().HasLabel("Person").Out().Statements
+	id, err := js1.Spool("graph", &Stream{Pipe: in, DataType: gdbi.VertexData, Query: query, GraphTimestamp: "1", Parallelism: 8, PreserveOrder: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	status := waitForJob(t, js2, "graph", id)
+	if status.State != gripql.JobState_COMPLETE {
+		t.Fatalf("job not complete: %s", status.State)
+	}
+	if status.Count != uint64(totalCount) {
+		t.Errorf("wrong job count: %d != %d", status.Count, totalCount)
+	}
+
+	ids, _ := js2.List("graph")
+	found := 0
+	for i := range ids {
+		if i == id {
+			found++
+		}
+	}
+	if found != 1 {
+		t.Errorf("job not listed")
+	}
+
+	search, _ := js2.Search("graph", gripql.NewQuery().V().HasLabel("Person").Out().Count().Statements)
+	found = 0
+	for s := range search {
+		if s.Id == id {
+			found++
+		}
+	}
+	if found != 1 {
+		t.Errorf("job not found in search")
+	}
+
+	stream, err := js2.Stream(context.Background(), "graph", id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stream.DataType != gdbi.VertexData {
+		t.Errorf("wrong data type: %d", stream.DataType)
+	}
+	if stream.Parallelism != 8 || !stream.PreserveOrder {
+		t.Errorf("wrong parallelism: %d %v", stream.Parallelism, stream.PreserveOrder)
+	}
+	// reading results records the access time without rewriting the status
+	// of the job, which other servers may be reading
+	fake.mu.Lock()
+	statusKey := js2.objectKey("graph", id, "status")
+	statusData := string(fake.buckets["jobs"][statusKey])
+	_, accessed := fake.buckets["jobs"][js2.objectKey("graph", id, "access")]
+	fake.mu.Unlock()
+	if !accessed {
+		t.Errorf("job access time not stored")
+	}
+	count := 0
+	for o := range stream.Pipe {
+		if o.GetCurrent() == nil || o.GetCurrent().ID != fmt.Sprintf("%d", count) {
+			t.Errorf("incorrect output: %#v", o)
+		}
+		count++
+	}
+	if count != totalCount {
+		t.Errorf("wrong output count: %d != %d", count, totalCount)
+	}
+
+	fake.mu.Lock()
+	if string(fake.buckets["jobs"][statusKey]) != statusData {
+		t.Errorf("job status rewritten by stream")
+	}
+	fake.mu.Unlock()
+
+	removed, err := js1.Collect("", RetentionPolicy{InvalidateOnChange: true}, func(string) string { return "2" })
+	if err != nil {
+		t.Fatal(err)
+	}
+	found = 0
+	for r := range removed {
+		if r.Id == id {
+			found++
+		}
+	}
+	if found != 1 {
+		t.Errorf("stale job not collected")
+	}
+	if _, err := js2.Status("graph", id); err == nil {
+		t.Errorf("collected job still found")
+	}
+	if err := js2.Delete("graph", id); err == nil {
+		t.Errorf("deleting a missing job did not fail")
+	}
+}
+
+func TestS3JobHeartbeat(t *testing.T) {
+	srv := httptest.NewServer(newFakeS3())
+	defer srv.Close()
+	u, _ := url.Parse(srv.URL)
+	conf := S3Config{Endpoint: u.Host, Bucket: "jobs", AccessKeyID: "test", SecretAccessKey: "test",
+		Heartbeat: duration.Duration(20 * time.Millisecond)}
+	js1, err := NewS3JobStorage(conf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	js2, err := NewS3JobStorage(conf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// a running job keeps its status up to date, so other servers don't
+	// treat it as failed
+	in := make(chan gdbi.Traveler)
+	id, err := js1.Spool("graph", &Stream{Pipe: in, DataType: gdbi.VertexData})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 10; i++ {
+		in <- &gdbi.BaseTraveler{Current: &gdbi.Vertex{ID: fmt.Sprintf("%d", i)}}
+		time.Sleep(20 * time.Millisecond)
+		s, err := js2.Status("graph", id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if s.State != gripql.JobState_RUNNING {
+			t.Fatalf("running job reported as %s: %s", s.State, s.Error)
+		}
+	}
+	if err := js2.Delete("graph", id); err == nil {
+		t.Error("deleted running job")
+	}
+	close(in)
+	status := waitForJob(t, js2, "graph", id)
+	if status.State != gripql.JobState_COMPLETE || status.Count != 10 {
+		t.Errorf("unexpected job status: %s %d", status.State, status.Count)
+	}
+
+	// a job whose server stopped is failed once it misses its heartbeats,
+	// so it can be removed
+	ctx := context.Background()
+	stale := &Job{
+		Status: gripql.JobStatus{Id: "job-stale", Graph: "graph", State: gripql.JobState_RUNNING,
+			Timestamp: time.Now().Add(-time.Hour).Format(time.RFC3339)},
+		Heartbeat: time.Now().Add(-time.Second),
+	}
+	if err := js1.writeJob(ctx, stale); err != nil {
+		t.Fatal(err)
+	}
+	status, err = js2.Status("graph", "job-stale")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status.State != gripql.JobState_ERROR || status.Error == "" {
+		t.Errorf("stale job reported as %s", status.State)
+	}
+	removed, err := js2.Collect("graph", RetentionPolicy{MaxAge: duration.Duration(time.Minute)}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	found := 0
+	for r := range removed {
+		if r.Id == "job-stale" {
+			found++
+		}
+	}
+	if found != 1 {
+		t.Errorf("stale job not collected")
+	}
+}
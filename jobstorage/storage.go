@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"log"
 	"os"
@@ -19,10 +20,17 @@ import (
 )
 
 type Stream struct {
-	Pipe      gdbi.InPipe
-	DataType  gdbi.DataType
-	MarkTypes map[string]gdbi.DataType
-	Query     []*gripql.GraphStatement
+	Pipe           gdbi.InPipe
+	DataType       gdbi.DataType
+	MarkTypes      map[string]gdbi.DataType
+	Query          []*gripql.GraphStatement
+	GraphTimestamp string
+	// Parallelism and PreserveOrder are the parallel workers of the query,
+	// which resumed queries run with
+	Parallelism   uint32
+	PreserveOrder bool
+	// Err returns the error that stopped the pipe, once it is closed
+	Err func() error
 }
 
 type JobStorage interface {
@@ -32,6 +40,7 @@ type JobStorage interface {
 	Stream(ctx context.Context, graph, id string) (*Stream, error)
 	Delete(graph, id string) error
 	Status(graph, id string) (*gripql.JobStatus, error)
+	Collect(graph string, policy RetentionPolicy, ts TimestampFunc) (chan *gripql.JobStatus, error)
 }
 
 type Job struct {
@@ -39,6 +48,36 @@ type Job struct {
 	DataType      gdbi.DataType
 	MarkTypes     map[string]gdbi.DataType
 	StepChecksums []string
+	Parallelism   uint32
+	PreserveOrder bool
+	Size          int64
+	LastAccess    time.Time
+	// When a running job last updated its status, for storage shared between
+	// servers
+	Heartbeat time.Time
+	// guards Size, LastAccess and Heartbeat, which change while the job is shared
+	mu sync.Mutex
+}
+
+// touch records that the results of the job were read
+func (job *Job) touch() {
+	job.mu.Lock()
+	job.LastAccess = time.Now()
+	job.mu.Unlock()
+}
+
+// usage returns the size of the results of the job and when they were last read
+func (job *Job) usage() (int64, time.Time) {
+	job.mu.Lock()
+	defer job.mu.Unlock()
+	return job.Size, job.LastAccess
+}
+
+// marshal serializes the job status
+func (job *Job) marshal() ([]byte, error) {
+	job.mu.Lock()
+	defer job.mu.Unlock()
+	return json.Marshal(job)
 }
 
 func jobKey(graph, job string) string {
@@ -65,6 +104,16 @@ func NewFSJobStorage(path string) *FSResults {
 				err := json.Unmarshal(sData, &job)
 				if err == nil {
 					log.Printf("Found job %s %s", graphName, jobName)
+					if job.Size == 0 {
+						if info, err := os.Stat(filepath.Join(jobDir, "results")); err == nil {
+							job.Size = info.Size()
+						}
+					}
+					if job.LastAccess.IsZero() {
+						if info, err := os.Stat(j); err == nil {
+							job.LastAccess = info.ModTime()
+						}
+					}
 					out.jobs.Store(jobKey(graphName, jobName), &job)
 				} else {
 					log.Printf("Error Unmarshaling job data: %s", err)
@@ -138,10 +187,14 @@ func (fs *FSResults) Spool(graph string, stream *Stream) (string, error) {
 
 	cs, _ := TraversalChecksum(stream.Query)
 	job := &Job{
-		Status:        gripql.JobStatus{Query: stream.Query, Id: jobName, Graph: graph, Timestamp: time.Now().Format(time.RFC3339)},
+		Status: gripql.JobStatus{Query: stream.Query, Id: jobName, Graph: graph,
+			Timestamp: time.Now().Format(time.RFC3339), GraphTimestamp: stream.GraphTimestamp},
 		DataType:      stream.DataType,
 		MarkTypes:     stream.MarkTypes,
 		StepChecksums: cs,
+		Parallelism:   stream.Parallelism,
+		PreserveOrder: stream.PreserveOrder,
+		LastAccess:    time.Now(),
 	}
 	fs.jobs.Store(jobKey(graph, jobName), job)
 	tbStream := MarshalStream(stream.Pipe, 4) //TODO: make worker count configurable
@@ -154,16 +207,31 @@ func (fs *FSResults) Spool(graph string, stream *Stream) (string, error) {
 			resultFile.Write([]byte("\n"))
 			job.Status.Count += 1
 		}
+		if info, err := resultFile.Stat(); err == nil {
+			job.mu.Lock()
+			job.Size = info.Size()
+			job.mu.Unlock()
+		}
 		statusPath := filepath.Join(spoolDir, "status")
 		statusFile, err := os.Create(statusPath)
 		if err == nil {
 			defer statusFile.Close()
 			job.Status.State = gripql.JobState_COMPLETE
-			out, err := json.Marshal(job)
+			if stream.Err != nil {
+				if jerr := stream.Err(); jerr != nil {
+					job.Status.State = gripql.JobState_ERROR
+					job.Status.Error = jerr.Error()
+				}
+			}
+			out, err := job.marshal()
 			if err == nil {
 				statusFile.Write([]byte(fmt.Sprintf("%s\n", out)))
 			}
-			log.Printf("Job Done: %s (%d results)", jobName, job.Status.Count)
+			if job.Status.State == gripql.JobState_ERROR {
+				log.Printf("Job Error: %s %s", jobName, job.Status.Error)
+			} else {
+				log.Printf("Job Done: %s (%d results)", jobName, job.Status.Count)
+			}
 		} else {
 			job.Status.State = gripql.JobState_ERROR
 			log.Printf("Job Error: %s %s", jobName, err)
@@ -176,40 +244,54 @@ func (fs *FSResults) Stream(ctx context.Context, graph, id string) (*Stream, err
 	if v, ok := fs.jobs.Load(jobKey(graph, id)); ok {
 		vJob := v.(*Job)
 		if vJob.Status.State == gripql.JobState_COMPLETE {
+			vJob.touch()
 			resultFile := filepath.Join(fs.BaseDir, sanitize.Name(graph), sanitize.Name(id), "results")
 			results, err := os.Open(resultFile)
 			if err != nil {
 				return nil, err
 			}
-			os := make(chan []byte, 40)
-			out := UnmarshalStream(os, 4) //TODO: make worker count configurable
-			go func() {
-				defer close(os)
-				defer results.Close()
-				scan := bufio.NewScanner(results)
-				bufSize := 1024 * 1024 * 32
-				buf := make([]byte, bufSize)
-				scan.Buffer(buf, bufSize)
-				for scan.Scan() {
-					if ctx.Err() == context.Canceled {
-						return
-					}
-					c := make([]byte, len(scan.Bytes()))
-					copy(c, scan.Bytes())
-					os <- c
-				}
-			}()
+			out := streamResults(ctx, results)
 			return &Stream{
-				Pipe:      out,
-				DataType:  vJob.DataType,
-				MarkTypes: vJob.MarkTypes,
+				Pipe:           out,
+				DataType:       vJob.DataType,
+				MarkTypes:      vJob.MarkTypes,
+				GraphTimestamp: vJob.Status.GraphTimestamp,
+				Parallelism:    vJob.Parallelism,
+				PreserveOrder:  vJob.PreserveOrder,
 			}, nil
 		}
+		if vJob.Status.State == gripql.JobState_ERROR && vJob.Status.Error != "" {
+			return nil, fmt.Errorf("Job %s failed: %s", id, vJob.Status.Error)
+		}
 		return nil, fmt.Errorf("Job %s not complete", id)
 	}
 	return nil, fmt.Errorf("Job Not Found")
 }
 
+// streamResults reads serialized travelers, one per line, and closes
+// the reader once done
+func streamResults(ctx context.Context, results io.ReadCloser) chan gdbi.Traveler {
+	lines := make(chan []byte, 40)
+	out := UnmarshalStream(lines, 4) //TODO: make worker count configurable
+	go func() {
+		defer close(lines)
+		defer results.Close()
+		scan := bufio.NewScanner(results)
+		bufSize := 1024 * 1024 * 32
+		buf := make([]byte, bufSize)
+		scan.Buffer(buf, bufSize)
+		for scan.Scan() {
+			if ctx.Err() == context.Canceled {
+				return
+			}
+			c := make([]byte, len(scan.Bytes()))
+			copy(c, scan.Bytes())
+			lines <- c
+		}
+	}()
+	return out
+}
+
 func (fs *FSResults) Delete(graph, id string) error {
 	if v, ok := fs.jobs.Load(jobKey(graph, id)); ok {
 		vJob := v.(*Job)
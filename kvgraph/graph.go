@@ -10,7 +10,6 @@ import (
 	"github.com/bmeg/grip/gripql"
 	"github.com/bmeg/grip/kvi"
 	"github.com/bmeg/grip/kvindex"
-	"github.com/bmeg/grip/log"
 	"google.golang.org/protobuf/proto"
 
 	multierror "github.com/hashicorp/go-multierror"
@@ -150,6 +149,7 @@ func (kgdb *KVInterfaceGDB) BulkAdd(stream <-chan *gdbi.GraphElement) error {
 				continue
 			}
 		}
+		kgdb.kvg.ts.Touch(kgdb.graph)
 		return bulkErr.ErrorOrNil()
 	})
 	return err
@@ -170,10 +170,10 @@ func (kgdb *KVInterfaceGDB) DelEdge(eid string) error {
 		return fmt.Errorf("Edge Not Found")
 	}
 
-	_, _, sid, did, _, _ := EdgeKeyParse(ekey)
+	_, _, sid, did, label, etype := EdgeKeyParse(ekey)
 
-	skey := SrcEdgeKeyPrefix(kgdb.graph, sid, did, eid)
-	dkey := DstEdgeKeyPrefix(kgdb.graph, sid, did, eid)
+	skey := SrcEdgeKey(kgdb.graph, sid, did, eid, label, etype)
+	dkey := DstEdgeKey(kgdb.graph, sid, did, eid, label, etype)
 
 	if err := kgdb.kvg.kv.Delete(ekey); err != nil {
 		return err
@@ -235,7 +235,7 @@ func (kgdb *KVInterfaceGDB) GetEdgeList(ctx context.Context, loadProp bool) <-ch
 	o := make(chan *gdbi.Edge, 100)
 	go func() {
 		defer close(o)
-		kgdb.kvg.kv.View(func(it kvi.KVIterator) error {
+		err := kgdb.kvg.kv.View(func(it kvi.KVIterator) error {
 			ePrefix := EdgeListPrefix(kgdb.graph)
 			for it.Seek(ePrefix); it.Valid() && bytes.HasPrefix(it.Key(), ePrefix); it.Next() {
 				select {
@@ -249,7 +249,9 @@ func (kgdb *KVInterfaceGDB) GetEdgeList(ctx context.Context, loadProp bool) <-ch
 					if loadProp {
 						edgeData, _ := it.Value()
 						ge := &gripql.Edge{}
-						proto.Unmarshal(edgeData, ge)
+						if err := proto.Unmarshal(edgeData, ge); err != nil {
+							return fmt.Errorf("unmarshal error: %v", err)
+						}
 						e := &gdbi.Edge{ID: ge.Gid, Label: ge.Label, From: sid, To: did, Data: ge.Data.AsMap(), Loaded: true}
 						o <- e
 					} else {
@@ -260,6 +262,7 @@ func (kgdb *KVInterfaceGDB) GetEdgeList(ctx context.Context, loadProp bool) <-ch
 			}
 			return nil
 		})
+		gdbi.ReportError(ctx, "GetEdgeList", err)
 	}()
 	return o
 }
@@ -306,7 +309,7 @@ func (kgdb *KVInterfaceGDB) GetVertexChannel(ctx context.Context, ids chan gdbi.
 	data := make(chan elementData, 100)
 	go func() {
 		defer close(data)
-		kgdb.kvg.kv.View(func(it kvi.KVIterator) error {
+		err := kgdb.kvg.kv.View(func(it kvi.KVIterator) error {
 			for id := range ids {
 				if id.IsSignal() {
 					data <- elementData{req: id}
@@ -323,6 +326,7 @@ func (kgdb *KVInterfaceGDB) GetVertexChannel(ctx context.Context, ids chan gdbi.
 			}
 			return nil
 		})
+		gdbi.ReportError(ctx, "GetVertexChannel", err)
 	}()
 
 	out := make(chan gdbi.ElementLookup, 100)
@@ -333,7 +337,10 @@ func (kgdb *KVInterfaceGDB) GetVertexChannel(ctx context.Context, ids chan gdbi.
 				out <- d.req
 			} else {
 				v := gripql.Vertex{}
-				proto.Unmarshal(d.data, &v)
+				if err := proto.Unmarshal(d.data, &v); err != nil {
+					gdbi.ReportError(ctx, "GetVertexChannel", fmt.Errorf("unmarshal error: %v", err))
+					continue
+				}
 				d.req.Vertex = &gdbi.Vertex{
 					ID:     d.req.ID,
 					Label:  v.Label,
@@ -353,7 +360,7 @@ func (kgdb *KVInterfaceGDB) GetOutChannel(ctx context.Context, reqChan chan gdbi
 	vertexChan := make(chan elementData, 100)
 	go func() {
 		defer close(vertexChan)
-		kgdb.kvg.kv.View(func(it kvi.KVIterator) error {
+		err := kgdb.kvg.kv.View(func(it kvi.KVIterator) error {
 			for req := range reqChan {
 				if req.IsSignal() {
 					vertexChan <- elementData{req: req}
@@ -384,12 +391,13 @@ func (kgdb *KVInterfaceGDB) GetOutChannel(ctx context.Context, reqChan chan gdbi
 			}
 			return nil
 		})
+		gdbi.ReportError(ctx, "GetOutChannel", err)
 	}()
 
 	o := make(chan gdbi.ElementLookup, 100)
 	go func() {
 		defer close(o)
-		kgdb.kvg.kv.View(func(it kvi.KVIterator) error {
+		err := kgdb.kvg.kv.View(func(it kvi.KVIterator) error {
 			for req := range vertexChan {
 				if req.req.IsSignal() {
 					o <- req.req
@@ -402,7 +410,7 @@ func (kgdb *KVInterfaceGDB) GetOutChannel(ctx context.Context, reqChan chan gdbi
 							//if load { //TODO: can't skip loading data, because the label in the data
 							err = proto.Unmarshal(dataValue, v)
 							if err != nil {
-								log.Errorf("GetOutChannel: unmarshal error: %v", err)
+								gdbi.ReportError(ctx, "GetOutChannel", fmt.Errorf("unmarshal error: %v", err))
 								continue
 								//}
 							}
@@ -422,6 +430,7 @@ func (kgdb *KVInterfaceGDB) GetOutChannel(ctx context.Context, reqChan chan gdbi
 			}
 			return nil
 		})
+		gdbi.ReportError(ctx, "GetOutChannel", err)
 	}()
 	return o
 }
@@ -431,7 +440,7 @@ func (kgdb *KVInterfaceGDB) GetInChannel(ctx context.Context, reqChan chan gdbi.
 	o := make(chan gdbi.ElementLookup, 100)
 	go func() {
 		defer close(o)
-		kgdb.kvg.kv.View(func(it kvi.KVIterator) error {
+		err := kgdb.kvg.kv.View(func(it kvi.KVIterator) error {
 			for req := range reqChan {
 				if req.IsSignal() {
 					o <- req
@@ -449,7 +458,7 @@ func (kgdb *KVInterfaceGDB) GetInChannel(ctx context.Context, reqChan chan gdbi.
 								//if load { //TODO: Can't skip data load because vertex label is in data
 								err = proto.Unmarshal(dataValue, v)
 								if err != nil {
-									log.Errorf("GetInChannel: unmarshal error: %v", err)
+									gdbi.ReportError(ctx, "GetInChannel", fmt.Errorf("unmarshal error: %v", err))
 									continue
 								}
 								//}
@@ -472,6 +481,7 @@ func (kgdb *KVInterfaceGDB) GetInChannel(ctx context.Context, reqChan chan gdbi.
 			}
 			return nil
 		})
+		gdbi.ReportError(ctx, "GetInChannel", err)
 	}()
 	return o
 }
@@ -481,7 +491,7 @@ func (kgdb *KVInterfaceGDB) GetOutEdgeChannel(ctx context.Context, reqChan chan
 	o := make(chan gdbi.ElementLookup, 100)
 	go func() {
 		defer close(o)
-		kgdb.kvg.kv.View(func(it kvi.KVIterator) error {
+		err := kgdb.kvg.kv.View(func(it kvi.KVIterator) error {
 			for req := range reqChan {
 				if req.IsSignal() {
 					o <- req
@@ -528,6 +538,7 @@ func (kgdb *KVInterfaceGDB) GetOutEdgeChannel(ctx context.Context, reqChan chan
 			}
 			return nil
 		})
+		gdbi.ReportError(ctx, "GetOutEdgeChannel", err)
 
 	}()
 	return o
@@ -538,7 +549,7 @@ func (kgdb *KVInterfaceGDB) GetInEdgeChannel(ctx context.Context, reqChan chan g
 	o := make(chan gdbi.ElementLookup, 100)
 	go func() {
 		defer close(o)
-		kgdb.kvg.kv.View(func(it kvi.KVIterator) error {
+		err := kgdb.kvg.kv.View(func(it kvi.KVIterator) error {
 			for req := range reqChan {
 				if req.IsSignal() {
 					o <- req
@@ -585,6 +596,7 @@ func (kgdb *KVInterfaceGDB) GetInEdgeChannel(ctx context.Context, reqChan chan g
 			}
 			return nil
 		})
+		gdbi.ReportError(ctx, "GetInEdgeChannel", err)
 
 	}()
 	return o
@@ -636,7 +648,7 @@ func (kgdb *KVInterfaceGDB) GetVertexList(ctx context.Context, loadProp bool) <-
 	o := make(chan *gdbi.Vertex, 100)
 	go func() {
 		defer close(o)
-		kgdb.kvg.kv.View(func(it kvi.KVIterator) error {
+		err := kgdb.kvg.kv.View(func(it kvi.KVIterator) error {
 			vPrefix := VertexListPrefix(kgdb.graph)
 
 			for it.Seek(vPrefix); it.Valid() && bytes.HasPrefix(it.Key(), vPrefix); it.Next() {
@@ -647,7 +659,9 @@ func (kgdb *KVInterfaceGDB) GetVertexList(ctx context.Context, loadProp bool) <-
 				}
 				gv := &gripql.Vertex{}
 				dataValue, _ := it.Value()
-				proto.Unmarshal(dataValue, gv)
+				if err := proto.Unmarshal(dataValue, gv); err != nil {
+					return fmt.Errorf("unmarshal error: %v", err)
+				}
 				keyValue := it.Key()
 				_, vid := VertexKeyParse(keyValue)
 				o <- &gdbi.Vertex{
@@ -659,6 +673,7 @@ func (kgdb *KVInterfaceGDB) GetVertexList(ctx context.Context, loadProp bool) <-
 			}
 			return nil
 		})
+		gdbi.ReportError(ctx, "GetVertexList", err)
 	}()
 	return o
 }
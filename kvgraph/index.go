@@ -1,15 +1,40 @@
 package kvgraph
 
 import (
+	"bytes"
 	"context"
+	"encoding/binary"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/bmeg/grip/gripql"
 	"github.com/bmeg/grip/jsonpath"
+	"github.com/bmeg/grip/kvi"
+	"github.com/bmeg/grip/kvindex"
 	"github.com/bmeg/grip/log"
+	"github.com/bmeg/grip/util/geo"
+	"google.golang.org/protobuf/proto"
 )
 
+// indexVersion is the layout of the index terms. Version 2 stores timestamp
+// strings as time terms, so that they can be found by time range
+const indexVersion = 2
+
+// indexVersionKey holds the layout version in the key value store. It doesn't
+// collide with the single letter prefixes of the graph and index keys
+var indexVersionKey = []byte("kvgraph.index.version")
+
+// reindexBatchSize is the number of elements read from the graph before they
+// are written to the index
+const reindexBatchSize = 1000
+
+// indexDoc is an element document to be written to the index
+type indexDoc struct {
+	id  string
+	doc map[string]interface{}
+}
+
 func (kgraph *KVGraph) setupGraphIndex(graph string) error {
 	err := kgraph.idx.AddField(fmt.Sprintf("%s.v.label", graph))
 	if err != nil {
@@ -22,6 +47,122 @@ func (kgraph *KVGraph) setupGraphIndex(graph string) error {
 	return nil
 }
 
+// migrateIndex rebuilds the index when it was written with an older term
+// layout. The entries of all fields are dropped, and the vertices and edges
+// of every graph are streamed back into the index.
+func (kgraph *KVGraph) migrateIndex() error {
+	if v, err := kgraph.kv.Get(indexVersionKey); err == nil {
+		if n, _ := binary.Uvarint(v); n >= indexVersion {
+			return nil
+		}
+	}
+	if graphs := kgraph.ListGraphs(); len(graphs) > 0 {
+		log.Infof("Reindexing key value graphs")
+		for _, f := range kgraph.idx.ListFields() {
+			isGeo := kgraph.idx.GeoFields[f]
+			if err := kgraph.idx.RemoveField(f); err != nil {
+				return err
+			}
+			add := kgraph.idx.AddField
+			if isGeo {
+				add = kgraph.idx.AddGeoField
+			}
+			if err := add(f); err != nil {
+				return err
+			}
+		}
+		for _, graph := range graphs {
+			if err := kgraph.reindex(VertexListPrefix(graph), vertexDoc(graph, "")); err != nil {
+				return fmt.Errorf("reindexing graph %s: %v", graph, err)
+			}
+			if err := kgraph.reindex(EdgeListPrefix(graph), edgeDoc(graph)); err != nil {
+				return fmt.Errorf("reindexing graph %s: %v", graph, err)
+			}
+		}
+	}
+	b := make([]byte, binary.MaxVarintLen64)
+	n := binary.PutUvarint(b, indexVersion)
+	return kgraph.kv.Set(indexVersionKey, b[:n])
+}
+
+// reindex streams the elements stored under prefix into the index. The graph
+// and the index share a store, so each batch is read before it is written.
+// toDoc returns a nil document for elements that are skipped
+func (kgraph *KVGraph) reindex(prefix []byte, toDoc func(key, value []byte) (indexDoc, error)) error {
+	start := prefix
+	for start != nil {
+		batch := []indexDoc{}
+		var next []byte
+		err := kgraph.kv.View(func(it kvi.KVIterator) error {
+			for it.Seek(start); it.Valid() && bytes.HasPrefix(it.Key(), prefix); it.Next() {
+				if len(batch) >= reindexBatchSize {
+					next = append([]byte{}, it.Key()...)
+					return nil
+				}
+				value, err := it.Value()
+				if err != nil {
+					return err
+				}
+				d, err := toDoc(it.Key(), value)
+				if err != nil {
+					return err
+				}
+				if d.doc != nil {
+					batch = append(batch, d)
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		err = kgraph.kv.BulkWrite(func(tx kvi.KVBulkWrite) error {
+			for _, d := range batch {
+				if err := kgraph.idx.AddDocTx(tx, d.id, d.doc); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		start = next
+	}
+	return nil
+}
+
+// vertexDoc builds the index documents of stored vertices. When label isn't
+// empty, vertices with other labels are skipped
+func vertexDoc(graph string, label string) func(key, value []byte) (indexDoc, error) {
+	return func(key, value []byte) (indexDoc, error) {
+		v := &gripql.Vertex{}
+		if err := proto.Unmarshal(value, v); err != nil {
+			return indexDoc{}, fmt.Errorf("unmarshal error: %v", err)
+		}
+		if label != "" && v.Label != label {
+			return indexDoc{}, nil
+		}
+		_, id := VertexKeyParse(key)
+		return indexDoc{id: id, doc: map[string]interface{}{graph: vertexIdxStruct(v)}}, nil
+	}
+}
+
+// edgeDoc builds the index documents of stored edges
+func edgeDoc(graph string) func(key, value []byte) (indexDoc, error) {
+	return func(key, value []byte) (indexDoc, error) {
+		_, eid, _, _, _, etype := EdgeKeyParse(key)
+		if etype != edgeSingle {
+			return indexDoc{}, nil
+		}
+		e := &gripql.Edge{}
+		if err := proto.Unmarshal(value, e); err != nil {
+			return indexDoc{}, fmt.Errorf("unmarshal error: %v", err)
+		}
+		return indexDoc{id: eid, doc: map[string]interface{}{graph: edgeIdxStruct(e)}}, nil
+	}
+}
+
 func (kgraph *KVGraph) deleteGraphIndex(graph string) {
 	fields := kgraph.idx.ListFields()
 	for _, f := range fields {
@@ -59,12 +200,59 @@ func edgeIdxStruct(e *gripql.Edge) map[string]interface{} {
 	return k
 }
 
-//AddVertexIndex add index to vertices
-func (kgdb *KVInterfaceGDB) AddVertexIndex(label string, field string) error {
-	log.WithFields(log.Fields{"label": label, "field": field}).Info("Adding vertex index")
+//AddVertexIndex add index to vertices. Points are stored by geohash only
+//for GEO_INDEX fields
+func (kgdb *KVInterfaceGDB) AddVertexIndex(label string, field string, itype gripql.IndexType) error {
+	log.WithFields(log.Fields{"label": label, "field": field, "type": itype}).Info("Adding vertex index")
 	field = normalizePath(field)
-	//TODO kick off background process to reindex existing data
-	return kgdb.kvg.idx.AddField(fmt.Sprintf("%s.v.%s.%s", kgdb.graph, label, field))
+	path := fmt.Sprintf("%s.v.%s.%s", kgdb.graph, label, field)
+	add := kgdb.kvg.idx.AddField
+	if itype == gripql.IndexType_GEO_INDEX {
+		add = kgdb.kvg.idx.AddGeoField
+	}
+	if err := add(path); err != nil {
+		return err
+	}
+	return kgdb.indexVertices(label)
+}
+
+// indexVertices adds the vertices with the label that were loaded before
+// their fields were indexed. Like migrateIndex, the vertices are streamed from
+// the graph into the index in batches
+func (kgdb *KVInterfaceGDB) indexVertices(label string) error {
+	return kgdb.kvg.reindex(VertexListPrefix(kgdb.graph), vertexDoc(kgdb.graph, label))
+}
+
+// VertexGeoScan produces the ids of vertices with the label whose indexed
+// field holds a point near the box
+func (kgdb *KVInterfaceGDB) VertexGeoScan(ctx context.Context, label string, field string, box geo.Box) (chan string, bool) {
+	path := fmt.Sprintf("%s.v.%s.%s", kgdb.graph, label, normalizePath(field))
+	if !kgdb.kvg.idx.GeoFields[path] {
+		return nil, false
+	}
+	log.WithFields(log.Fields{"label": label, "field": field}).Debug("Running VertexGeoScan")
+	return kgdb.kvg.idx.GetGeoBox(ctx, path, box, 0), true
+}
+
+// VertexTimeScan produces the ids of vertices with the label whose indexed
+// field holds a timestamp in the range, or a string that isn't a timestamp
+func (kgdb *KVInterfaceGDB) VertexTimeScan(ctx context.Context, label string, field string, min, max time.Time) (chan string, bool) {
+	path := fmt.Sprintf("%s.v.%s.%s", kgdb.graph, label, normalizePath(field))
+	if _, ok := kgdb.kvg.idx.Fields[path]; !ok {
+		return nil, false
+	}
+	log.WithFields(log.Fields{"label": label, "field": field}).Debug("Running VertexTimeScan")
+	out := make(chan string, 100)
+	go func() {
+		defer close(out)
+		for id := range kgdb.kvg.idx.GetTimeRange(ctx, path, min, max, 0) {
+			out <- id
+		}
+		for id := range kgdb.kvg.idx.GetTypeMatch(ctx, path, kvindex.TermString, 0) {
+			out <- id
+		}
+	}()
+	return out, true
 }
 
 //DeleteVertexIndex delete index from vertices
@@ -84,7 +272,11 @@ func (kgdb *KVInterfaceGDB) GetVertexIndexList() <-chan *gripql.IndexID {
 		for _, f := range fields {
 			t := strings.Split(f, ".")
 			if len(t) > 3 {
-				out <- &gripql.IndexID{Graph: kgdb.graph, Label: t[2], Field: t[3]}
+				itype := gripql.IndexType_FIELD_INDEX
+				if kgdb.kvg.idx.GeoFields[f] {
+					itype = gripql.IndexType_GEO_INDEX
+				}
+				out <- &gripql.IndexID{Graph: kgdb.graph, Label: t[2], Field: t[3], Type: itype}
 			}
 		}
 	}()
@@ -1,6 +1,8 @@
 package kvgraph
 
 import (
+	"fmt"
+
 	"github.com/bmeg/grip/gdbi"
 	"github.com/bmeg/grip/kvi"
 	"github.com/bmeg/grip/kvindex"
@@ -27,15 +29,28 @@ func NewKVGraphDB(name string, dbPath string) (gdbi.GraphDB, error) {
 	if err != nil {
 		return nil, err
 	}
-	return NewKVGraph(kv), nil
+	db, err := NewKVGraph(kv)
+	if err != nil {
+		kv.Close()
+		return nil, err
+	}
+	return db, nil
 }
 
-// NewKVGraph creats a new instance of KVGraph given a KVInterface
-func NewKVGraph(kv kvi.KVInterface) gdbi.GraphDB {
+// NewKVGraph creats a new instance of KVGraph given a KVInterface. An index
+// written with an older layout is rebuilt first; if that fails, the error is
+// returned and the rebuild starts over the next time the graph is opened
+func NewKVGraph(kv kvi.KVInterface) (gdbi.GraphDB, error) {
 	ts := timestamp.NewTimestamp()
 	o := &KVGraph{kv: kv, ts: &ts, idx: kvindex.NewIndex(kv)}
+	if err := o.idx.LoadFields(); err != nil {
+		return nil, fmt.Errorf("loading index fields: %v", err)
+	}
+	if err := o.migrateIndex(); err != nil {
+		return nil, fmt.Errorf("migrating index: %v", err)
+	}
 	for _, i := range o.ListGraphs() {
 		o.ts.Touch(i)
 	}
-	return o
+	return o, nil
 }
@@ -1,11 +1,19 @@
 package test
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"reflect"
+	"sort"
 	"testing"
+	"time"
 
+	"github.com/bmeg/grip/gdbi"
+	"github.com/bmeg/grip/gripql"
+	"github.com/bmeg/grip/kvgraph"
 	"github.com/bmeg/grip/kvindex"
+	"github.com/bmeg/grip/util/geo"
 )
 
 var numDocs = `[
@@ -102,3 +110,237 @@ func TestFloatRange(t *testing.T) {
 	}
 
 }
+
+var timeDocs = `[
+{"value" : "2014-12-09T13:50:51.644000Z"},
+{"value" : "2014-12-10T15:10:51Z"},
+{"value" : "2014-12-10T16:10:51+01:00"},
+{"value" : "2014-12-20"},
+{"value" : "1969-07-20T20:17:00Z"},
+{"value" : "not a date"}
+]`
+
+func TestTimeRange(t *testing.T) {
+	resetKVInterface()
+	idx := kvindex.NewIndex(kvdriver)
+	idx.AddField("value")
+
+	data := []map[string]interface{}{}
+	json.Unmarshal([]byte(timeDocs), &data)
+	for i, d := range data {
+		if err := idx.AddDoc(fmt.Sprintf("%d", i), d); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ranges := []struct {
+		min, max string
+		docs     []string
+	}{
+		{"2014-12-10T00:00:00Z", "2014-12-11T00:00:00Z", []string{"1", "2"}},
+		{"1900-01-01T00:00:00Z", "2014-12-10T00:00:00Z", []string{"4", "0"}},
+		{"2014-12-10T15:10:51Z", "2014-12-20T00:00:00Z", []string{"1", "2"}},
+		{"2014-12-20T00:00:00Z", "2014-12-20T00:00:00Z", []string{}},
+	}
+	for _, r := range ranges {
+		min, _ := time.Parse(time.RFC3339, r.min)
+		max, _ := time.Parse(time.RFC3339, r.max)
+		docs := []string{}
+		for d := range idx.GetTimeRange(context.Background(), "value", min, max, 0) {
+			docs = append(docs, d)
+		}
+		if !reflect.DeepEqual(docs, r.docs) {
+			t.Errorf("range %s - %s: %v != %v", r.min, r.max, docs, r.docs)
+		}
+	}
+
+	// timestamps keep their text, and match exactly
+	for d := range idx.GetTermMatch(context.Background(), "value", "2014-12-10T16:10:51+01:00", 0) {
+		if d != "2" {
+			t.Errorf("unexpected term match %s", d)
+		}
+	}
+	count := 0
+	for tcount := range idx.FieldTermCounts("value") {
+		if !contains([]string{"2014-12-09T13:50:51.644000Z", "2014-12-10T15:10:51Z", "2014-12-10T16:10:51+01:00", "2014-12-20", "1969-07-20T20:17:00Z", "not a date"}, tcount.String) {
+			t.Errorf("unexpected term %v", tcount)
+		}
+		count++
+	}
+	if count != len(data) {
+		t.Errorf("wrong term count %d != %d", count, len(data))
+	}
+}
+
+func TestTimeIndexMigration(t *testing.T) {
+	resetKVInterface()
+	gdb, err := kvgraph.NewKVGraph(kvdriver)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := gdb.AddGraph("visits"); err != nil {
+		t.Fatal(err)
+	}
+	graph, err := gdb.Graph("visits")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := graph.AddVertexIndex("Visit", "date", gripql.IndexType_FIELD_INDEX); err != nil {
+		t.Fatal(err)
+	}
+	dates := []string{"2014-12-09T13:50:51Z", "2014-12-10", "2015-01-01", "not a date"}
+	vertices := []*gdbi.Vertex{}
+	for i, d := range dates {
+		vertices = append(vertices, &gdbi.Vertex{ID: fmt.Sprintf("%d", i), Label: "Visit", Data: map[string]interface{}{"date": d}})
+	}
+	if err := graph.AddVertex(vertices); err != nil {
+		t.Fatal(err)
+	}
+
+	// turn the index into one written before timestamps were time terms
+	path := "visits.v.Visit.date"
+	idx := kvindex.NewIndex(kvdriver)
+	if err := idx.RemoveField(path); err != nil {
+		t.Fatal(err)
+	}
+	if err := idx.AddField(path); err != nil {
+		t.Fatal(err)
+	}
+	for i, d := range dates {
+		if err := kvdriver.Set(kvindex.EntryKey(path, kvindex.TermString, []byte(d), fmt.Sprintf("%d", i)), []byte{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := kvdriver.Delete([]byte("kvgraph.index.version")); err != nil {
+		t.Fatal(err)
+	}
+
+	gdb, err = kvgraph.NewKVGraph(kvdriver)
+	if err != nil {
+		t.Fatal(err)
+	}
+	graph, err = gdb.Graph("visits")
+	if err != nil {
+		t.Fatal(err)
+	}
+	strs := []string{}
+	for id := range idx.GetTypeMatch(context.Background(), path, kvindex.TermString, 0) {
+		strs = append(strs, id)
+	}
+	if !reflect.DeepEqual(strs, []string{"3"}) {
+		t.Errorf("graph was not reindexed, string entries: %v", strs)
+	}
+
+	min, _ := time.Parse(time.RFC3339, "2014-12-10T00:00:00Z")
+	max, _ := time.Parse(time.RFC3339, "2015-01-01T00:00:00Z")
+	ids, ok := graph.(gdbi.TimeIndexer).VertexTimeScan(context.Background(), "Visit", "$.date", min, max)
+	if !ok {
+		t.Fatal("indexed field wasn't scanned")
+	}
+	found := []string{}
+	for id := range ids {
+		found = append(found, id)
+	}
+	if !reflect.DeepEqual(found, []string{"1", "3"}) {
+		t.Errorf("unexpected time scan: %v", found)
+	}
+	if _, ok := graph.(gdbi.TimeIndexer).VertexTimeScan(context.Background(), "Visit", "$.other", min, max); ok {
+		t.Error("field without an index was scanned")
+	}
+}
+
+var geoDocs = `[
+{"loc" : {"lat": 37.8044, "lon": -122.2712}},
+{"loc" : {"type": "Point", "coordinates": [-122.4194, 37.7749]}},
+{"loc" : [-73.9857, 40.7484]},
+{"loc" : {"lat": -0.5, "lng": 179.9}},
+{"loc" : {"lat": 0.5, "lon": -179.9}}
+]`
+
+func TestGeoBox(t *testing.T) {
+	resetKVInterface()
+	idx := kvindex.NewIndex(kvdriver)
+	idx.AddGeoField("loc")
+	idx.AddField("plain")
+
+	data := []map[string]interface{}{}
+	json.Unmarshal([]byte(geoDocs), &data)
+	for i, d := range data {
+		d["plain"] = d["loc"]
+		if err := idx.AddDoc(fmt.Sprintf("%d", i), d); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	boxes := []struct {
+		box  geo.Box
+		docs []string
+	}{
+		{geo.Box{South: 37, West: -123, North: 38.5, East: -122}, []string{"0", "1"}},
+		{geo.Box{South: 40, West: -75, North: 41, East: -73}, []string{"2"}},
+		{geo.Box{South: -1, West: 179, North: 1, East: -179}, []string{"3", "4"}},
+		{geo.Box{South: 10, West: 10, North: 11, East: 11}, []string{}},
+	}
+	for _, b := range boxes {
+		docs := []string{}
+		for d := range idx.GetGeoBox(context.Background(), "loc", b.box, 0) {
+			docs = append(docs, d)
+		}
+		sort.Strings(docs)
+		if !reflect.DeepEqual(docs, b.docs) {
+			t.Errorf("box %v: %v != %v", b.box, docs, b.docs)
+		}
+		for d := range idx.GetGeoBox(context.Background(), "plain", b.box, 0) {
+			t.Errorf("point of a field without a geo index was found: %s", d)
+		}
+	}
+
+	loaded := kvindex.NewIndex(kvdriver)
+	if err := loaded.LoadFields(); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(loaded.GeoFields, map[string]bool{"loc": true}) {
+		t.Errorf("unexpected geo fields: %v", loaded.GeoFields)
+	}
+
+	// an unparseable point can't be indexed
+	if err := idx.AddDoc("5", map[string]interface{}{"loc": map[string]interface{}{"lat": "north"}}); err == nil {
+		t.Error("expected error indexing a map that isn't a point")
+	}
+}
+
+func TestGeoIndexType(t *testing.T) {
+	resetKVInterface()
+	gdb, err := kvgraph.NewKVGraph(kvdriver)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := gdb.AddGraph("sites"); err != nil {
+		t.Fatal(err)
+	}
+	graph, err := gdb.Graph("sites")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := graph.AddVertexIndex("Site", "loc", gripql.IndexType_GEO_INDEX); err != nil {
+		t.Fatal(err)
+	}
+	if err := graph.AddVertexIndex("Site", "name", gripql.IndexType_FIELD_INDEX); err != nil {
+		t.Fatal(err)
+	}
+	types := map[string]gripql.IndexType{}
+	for i := range graph.GetVertexIndexList() {
+		types[i.Field] = i.Type
+	}
+	expected := map[string]gripql.IndexType{"loc": gripql.IndexType_GEO_INDEX, "name": gripql.IndexType_FIELD_INDEX}
+	if !reflect.DeepEqual(types, expected) {
+		t.Errorf("unexpected index types: %v", types)
+	}
+	box := geo.Box{South: 37, West: -123, North: 38.5, East: -122}
+	if _, ok := graph.(gdbi.GeoIndexer).VertexGeoScan(context.Background(), "Site", "$.loc", box); !ok {
+		t.Error("geo index wasn't scanned")
+	}
+	if _, ok := graph.(gdbi.GeoIndexer).VertexGeoScan(context.Background(), "Site", "$.name", box); ok {
+		t.Error("field index was scanned for points")
+	}
+}
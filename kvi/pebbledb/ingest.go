@@ -0,0 +1,100 @@
+package pebbledb
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/bmeg/grip/log"
+	"github.com/cockroachdb/pebble/objstorage/objstorageprovider"
+	"github.com/cockroachdb/pebble/sstable"
+	"github.com/cockroachdb/pebble/vfs"
+)
+
+// DefaultIngestSize is the number of bytes of keys and values buffered
+// before an SST file is written
+const DefaultIngestSize = 256 << 20
+
+type ingestEntry struct {
+	key   []byte
+	value []byte
+}
+
+// Ingester buffers keys and values in memory, and adds them to the database
+// as sorted SST files. This skips the write ahead log and memtables used by
+// regular writes. Keys set in later batches replace earlier ones.
+type Ingester struct {
+	pdb     *PebbleKV
+	dir     string
+	maxSize int
+	entries []ingestEntry
+	size    int
+	files   int
+}
+
+// NewIngester creates an ingester that writes its SST files into dir, which
+// must be on the same file system as the database
+func (pdb *PebbleKV) NewIngester(dir string, maxSize int) (*Ingester, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+	if maxSize <= 0 {
+		maxSize = DefaultIngestSize
+	}
+	return &Ingester{pdb: pdb, dir: dir, maxSize: maxSize}, nil
+}
+
+// Set adds a key/value to the current batch
+func (in *Ingester) Set(key, value []byte) error {
+	in.entries = append(in.entries, ingestEntry{copyBytes(key), copyBytes(value)})
+	in.size += len(key) + len(value)
+	if in.size >= in.maxSize {
+		return in.Flush()
+	}
+	return nil
+}
+
+// Flush sorts the current batch, writes it to an SST file and ingests it
+func (in *Ingester) Flush() error {
+	if len(in.entries) == 0 {
+		return nil
+	}
+	sort.SliceStable(in.entries, func(i, j int) bool {
+		return bytes.Compare(in.entries[i].key, in.entries[j].key) < 0
+	})
+	path := filepath.Join(in.dir, fmt.Sprintf("%06d.sst", in.files))
+	in.files++
+	f, err := vfs.Default.Create(path)
+	if err != nil {
+		return err
+	}
+	w := sstable.NewWriter(objstorageprovider.NewFileWritable(f), sstable.WriterOptions{
+		TableFormat: in.pdb.db.FormatMajorVersion().MaxTableFormat(),
+	})
+	for i, e := range in.entries {
+		// when a key was set more than once, keep the last value
+		if i+1 < len(in.entries) && bytes.Equal(e.key, in.entries[i+1].key) {
+			continue
+		}
+		if err := w.Set(e.key, e.value); err != nil {
+			w.Close()
+			return err
+		}
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	log.WithFields(log.Fields{"file": path, "entries": len(in.entries)}).Debug("Ingesting SST file")
+	in.entries = in.entries[:0]
+	in.size = 0
+	return in.pdb.db.Ingest([]string{path})
+}
+
+// Close ingests the remaining batch, and removes the SST directory
+func (in *Ingester) Close() error {
+	err := in.Flush()
+	os.RemoveAll(in.dir)
+	return err
+}
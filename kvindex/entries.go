@@ -4,6 +4,10 @@ import (
 	"encoding/binary"
 	"fmt"
 	"math"
+	"time"
+
+	"github.com/bmeg/grip/gripql"
+	"github.com/bmeg/grip/util/geo"
 )
 
 // TermType defines in a term is a Number or a String
@@ -16,8 +20,23 @@ const (
 	TermString TermType = 0x01
 	//TermNumber means the term is a number
 	TermNumber TermType = 0x02
+	//TermTime means the term is an RFC3339 timestamp
+	TermTime TermType = 0x03
+	//TermGeo means the term is the geohash of a point
+	TermGeo TermType = 0x04
 )
 
+// timeBytesLen is the length of the sortable time that starts a TermTime term
+const timeBytesLen = 12
+
+// timeBytes encodes a time so that byte order is time order
+func timeBytes(t time.Time) []byte {
+	out := make([]byte, timeBytesLen)
+	binary.BigEndian.PutUint64(out, uint64(t.Unix())^(1<<63))
+	binary.BigEndian.PutUint32(out[8:], uint32(t.Nanosecond()))
+	return out
+}
+
 type entryValue struct {
 	term     []byte
 	termKey  []byte
@@ -61,6 +80,11 @@ func mapDig(i map[string]interface{}, path []string) interface{} {
 func GetTermBytes(term interface{}) ([]byte, TermType) {
 	switch val := term.(type) {
 	case string:
+		// timestamps are ordered by time, and keep their original text so
+		// that they match exactly
+		if t, ok := gripql.ParseDateTime(val); ok {
+			return append(timeBytes(t), val...), TermTime
+		}
 		return []byte(val), TermString
 
 	case float64:
@@ -68,6 +92,13 @@ func GetTermBytes(term interface{}) ([]byte, TermType) {
 		binary.BigEndian.PutUint64(out, math.Float64bits(val))
 		return out, TermNumber
 
+	case map[string]interface{}, []interface{}:
+		// points are stored by geohash, so that nearby points share a prefix
+		if p, ok := geo.ParsePoint(val); ok {
+			return []byte(geo.Geohash(p, geo.MaxPrecision)), TermGeo
+		}
+		return nil, TermUnknown
+
 	default:
 		return nil, TermUnknown
 	}
@@ -83,6 +114,12 @@ func GetBytesTerm(val []byte, ttype TermType) interface{} {
 		u := binary.BigEndian.Uint64(val)
 		return math.Float64frombits(u)
 
+	case TermTime:
+		return string(val[timeBytesLen:])
+
+	case TermGeo:
+		return string(val)
+
 	default:
 		return nil
 	}
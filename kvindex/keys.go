@@ -94,6 +94,10 @@ func EntryKeyParse(key []byte) (string, TermType, []byte, string) {
 	if ttype == TermNumber {
 		return field, ttype, suffix[0:8], string(suffix[8:])
 	}
+	if ttype == TermTime {
+		stmp := bytes.SplitN(suffix[timeBytesLen:], []byte{0}, 2)
+		return field, ttype, suffix[0 : timeBytesLen+len(stmp[0])], string(stmp[1])
+	}
 	stmp := bytes.Split(suffix, []byte{0})
 	return field, ttype, stmp[0], string(stmp[1])
 }
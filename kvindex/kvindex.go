@@ -7,15 +7,20 @@ import (
 	"fmt"
 	"math"
 	"strings"
+	"time"
 
 	"github.com/bmeg/grip/kvi"
 	"github.com/bmeg/grip/log"
+	"github.com/bmeg/grip/util/geo"
 
 	"google.golang.org/protobuf/proto"
 )
 
 const bufferSize = 1000
 
+// geoCoverCells is the most geohash cells scanned for a box
+const geoCoverCells = 32
+
 func contains(c string, s []string) bool {
 	for _, i := range s {
 		if c == i {
@@ -38,8 +43,13 @@ func containsPrefix(c string, s []string) bool {
 type KVIndex struct {
 	KV     kvi.KVInterface
 	Fields map[string][]string
+	// GeoFields are the fields whose points are indexed by geohash
+	GeoFields map[string]bool
 }
 
+// geoFieldValue marks the field keys of geo fields
+var geoFieldValue = []byte{1}
+
 // KVTermCount Get all terms and their counts
 type KVTermCount struct {
 	String string
@@ -49,14 +59,51 @@ type KVTermCount struct {
 
 // NewIndex create new key value index
 func NewIndex(kv kvi.KVInterface) *KVIndex {
-	return &KVIndex{KV: kv, Fields: make(map[string][]string)}
+	return &KVIndex{KV: kv, Fields: make(map[string][]string), GeoFields: make(map[string]bool)}
 }
 
 // AddField add new field to be indexed
 func (idx *KVIndex) AddField(path string) error {
+	return idx.addField(path, false)
+}
+
+// AddGeoField adds a field to be indexed, whose points are also indexed by
+// geohash
+func (idx *KVIndex) AddGeoField(path string) error {
+	return idx.addField(path, true)
+}
+
+func (idx *KVIndex) addField(path string, geo bool) error {
 	fk := FieldKey(path)
 	idx.Fields[path] = strings.Split(path, ".")
-	return idx.KV.Set(fk, []byte{})
+	val := []byte{}
+	if geo {
+		idx.GeoFields[path] = true
+		val = geoFieldValue
+	} else {
+		delete(idx.GeoFields, path)
+	}
+	return idx.KV.Set(fk, val)
+}
+
+// LoadFields reads the indexed fields, and whether they are geo fields, from
+// the store
+func (idx *KVIndex) LoadFields() error {
+	fPrefix := FieldPrefix()
+	return idx.KV.View(func(it kvi.KVIterator) error {
+		for it.Seek(fPrefix); it.Valid() && bytes.HasPrefix(it.Key(), fPrefix); it.Next() {
+			field := FieldKeyParse(it.Key())
+			idx.Fields[field] = strings.Split(field, ".")
+			v, err := it.Value()
+			if err != nil {
+				return err
+			}
+			if bytes.Equal(v, geoFieldValue) {
+				idx.GeoFields[field] = true
+			}
+		}
+		return nil
+	})
 }
 
 // RemoveField removes an indexed field
@@ -67,6 +114,7 @@ func (idx *KVIndex) RemoveField(path string) error {
 	idx.KV.DeletePrefix(fkt)
 	idx.KV.DeletePrefix(ed)
 	delete(idx.Fields, path)
+	delete(idx.GeoFields, path)
 	return idx.KV.Delete(fk)
 }
 
@@ -104,8 +152,11 @@ func (idx *KVIndex) AddDocTx(tx kvi.KVBulkWrite, docID string, doc map[string]in
 		x := mapDig(doc, p)
 		if x != nil {
 			term, t := GetTermBytes(x)
+			if t == TermGeo && !idx.GeoFields[field] {
+				continue
+			}
 			switch t {
-			case TermString, TermNumber:
+			case TermString, TermNumber, TermTime, TermGeo:
 				entryKey := EntryKey(field, t, term, docID)
 				err := tx.Set(entryKey, []byte{})
 				if err != nil {
@@ -189,33 +240,34 @@ func (idx *KVIndex) RemoveDoc(docID string) error {
 			return fmt.Errorf("failed to unmarshal document: %v", err)
 		}
 		for _, entryKey := range doc.Entries {
+			// count the term before deleting the entry, since a count that
+			// was invalidated is recounted from the entries
+			field, ttype, term, _ := EntryKeyParse(entryKey)
+			termKey := TermKey(field, ttype, term)
+			count, err := idx.termGetCount(tx, field, ttype, term)
+			if err != nil {
+				return fmt.Errorf("Termcount Error: %s", err)
+			}
 			err = tx.Delete(entryKey)
 			if err != nil {
 				return fmt.Errorf("failed to delete entry %s: %v", entryKey, err)
 			}
-
-			field, ttype, term, _ := EntryKeyParse(entryKey)
-			termKey := TermKey(field, ttype, term)
-			if count, err := idx.termGetCount(tx, field, ttype, term); err == nil {
-				if count > 0 {
-					count = count - 1
-				}
-				//if count == 0, then the term should be removed from the index
-				if count == 0 {
-					err = tx.Delete(termKey)
-					if err != nil {
-						return fmt.Errorf("failed to delete term key %s: %v", termKey, err)
-					}
-				} else {
-					buf := make([]byte, binary.MaxVarintLen64)
-					binary.PutUvarint(buf, count)
-					err = tx.Set(termKey, buf)
-					if err != nil {
-						return fmt.Errorf("failed to set term key %s: %v", termKey, err)
-					}
+			if count > 0 {
+				count = count - 1
+			}
+			//if count == 0, then the term should be removed from the index
+			if count == 0 {
+				err = tx.Delete(termKey)
+				if err != nil {
+					return fmt.Errorf("failed to delete term key %s: %v", termKey, err)
 				}
 			} else {
-				return fmt.Errorf("Termcount Error: %s", err)
+				buf := make([]byte, binary.MaxVarintLen64)
+				binary.PutUvarint(buf, count)
+				err = tx.Set(termKey, buf)
+				if err != nil {
+					return fmt.Errorf("failed to set term key %s: %v", termKey, err)
+				}
 			}
 		}
 
@@ -259,6 +311,102 @@ func (idx *KVIndex) GetTermMatch(ctx context.Context, field string, value interf
 	return out
 }
 
+// GetTimeRange finds all documents where field holds a timestamp at or after
+// min and before max
+func (idx *KVIndex) GetTimeRange(ctx context.Context, field string, min, max time.Time, maxCount int) chan string {
+	out := make(chan string, bufferSize)
+	go func() {
+		defer close(out)
+		if !min.Before(max) {
+			return
+		}
+		prefix := EntryTypePrefix(field, TermTime)
+		start := append(append([]byte{}, prefix...), timeBytes(min)...)
+		end := append(append([]byte{}, prefix...), timeBytes(max)...)
+		count := 0
+		idx.KV.View(func(it kvi.KVIterator) error {
+			for it.Seek(start); it.Valid() && bytes.HasPrefix(it.Key(), prefix) && bytes.Compare(it.Key(), end) < 0; it.Next() {
+				select {
+				case <-ctx.Done():
+					return nil
+				default:
+				}
+				_, _, _, doc := EntryKeyParse(it.Key())
+				out <- doc
+				count++
+				if maxCount > 0 && count >= maxCount {
+					return nil
+				}
+			}
+			return nil
+		})
+	}()
+	return out
+}
+
+// GetTypeMatch finds all documents where field holds a term of the type
+func (idx *KVIndex) GetTypeMatch(ctx context.Context, field string, ttype TermType, maxCount int) chan string {
+	out := make(chan string, bufferSize)
+	go func() {
+		defer close(out)
+		prefix := EntryTypePrefix(field, ttype)
+		count := 0
+		idx.KV.View(func(it kvi.KVIterator) error {
+			for it.Seek(prefix); it.Valid() && bytes.HasPrefix(it.Key(), prefix); it.Next() {
+				select {
+				case <-ctx.Done():
+					return nil
+				default:
+				}
+				_, _, _, doc := EntryKeyParse(it.Key())
+				out <- doc
+				count++
+				if maxCount > 0 && count >= maxCount {
+					return nil
+				}
+			}
+			return nil
+		})
+	}()
+	return out
+}
+
+// GetGeoBox finds documents where field holds a point in the geohash cells
+// covering box. Points near the box but outside it may also be found
+func (idx *KVIndex) GetGeoBox(ctx context.Context, field string, box geo.Box, maxCount int) chan string {
+	out := make(chan string, bufferSize)
+	go func() {
+		defer close(out)
+		count := 0
+		for _, cell := range geo.Cover(box, geoCoverCells) {
+			prefix := append(EntryTypePrefix(field, TermGeo), cell...)
+			done := false
+			idx.KV.View(func(it kvi.KVIterator) error {
+				for it.Seek(prefix); it.Valid() && bytes.HasPrefix(it.Key(), prefix); it.Next() {
+					select {
+					case <-ctx.Done():
+						done = true
+						return nil
+					default:
+					}
+					_, _, _, doc := EntryKeyParse(it.Key())
+					out <- doc
+					count++
+					if maxCount > 0 && count >= maxCount {
+						done = true
+						return nil
+					}
+				}
+				return nil
+			})
+			if done {
+				return
+			}
+		}
+	}()
+	return out
+}
+
 // FieldTerms list all unique terms held by a field
 func (idx *KVIndex) FieldTerms(field string) chan interface{} {
 	out := make(chan interface{}, bufferSize)
@@ -333,7 +481,7 @@ func (idx *KVIndex) fieldTermCounts(field string, ftype TermType) chan KVTermCou
 					switch ttype {
 					case TermNumber:
 						out <- KVTermCount{Number: GetBytesTerm(term, ttype).(float64), Count: count}
-					case TermString:
+					case TermString, TermTime, TermGeo:
 						out <- KVTermCount{String: GetBytesTerm(term, ttype).(string), Count: count}
 					default:
 						continue
@@ -60,13 +60,29 @@ func (comp *Compiler) Compile(stmts []*gripql.GraphStatement, opts *gdbi.Compile
 	// - Jump
 	// - Set
 	// - Increment
+	// - Where
+	// - Not
+	// - Union
+	// - Optional
+	// - Coalesce
+	// - Seek
+	// - Geohash grid aggregations
 	//If they are present, the system will default to using the core driver
 	unsupportedOps := false
 	for _, gs := range stmts {
 		switch gs.GetStatement().(type) {
 		case *gripql.GraphStatement_Jump, *gripql.GraphStatement_Set,
-			*gripql.GraphStatement_Increment:
+			*gripql.GraphStatement_Increment, *gripql.GraphStatement_Where,
+			*gripql.GraphStatement_Not, *gripql.GraphStatement_Union,
+			*gripql.GraphStatement_Optional, *gripql.GraphStatement_Coalesce,
+			*gripql.GraphStatement_Seek:
 			unsupportedOps = true
+		case *gripql.GraphStatement_Aggregate:
+			for _, a := range gs.GetAggregate().Aggregations {
+				if a.GetGeohashGrid() != nil {
+					unsupportedOps = true
+				}
+			}
 		}
 	}
 	if unsupportedOps {
@@ -87,6 +103,8 @@ func (comp *Compiler) Compile(stmts []*gripql.GraphStatement, opts *gdbi.Compile
 	lastType := gdbi.NoData
 	markTypes := map[string]gdbi.DataType{}
 	aggTypes := map[string]*gripql.Aggregate{}
+	// steps run by GRIP after a sort keep the order of the mongo results
+	sorted := false
 	vertCol := fmt.Sprintf("%s_vertices", comp.db.graph)
 	edgeCol := fmt.Sprintf("%s_edges", comp.db.graph)
 
@@ -525,7 +543,10 @@ func (comp *Compiler) Compile(stmts []*gripql.GraphStatement, opts *gdbi.Compile
 			if lastType != gdbi.VertexData && lastType != gdbi.EdgeData {
 				return &Pipeline{}, fmt.Errorf(`"has" statement is only valid for edge or vertex types not: %s`, lastType.String())
 			}
-			whereExpr := convertHasExpression(stmt.Has, false)
+			whereExpr, err := convertHasExpression(stmt.Has, false)
+			if err != nil {
+				return &Pipeline{}, err
+			}
 			matchStmt := bson.D{primitive.E{Key: "$match", Value: whereExpr}}
 			query = append(query, matchStmt)
 
@@ -539,7 +560,7 @@ func (comp *Compiler) Compile(stmts []*gripql.GraphStatement, opts *gdbi.Compile
 				ilabels[i] = v
 			}
 			has := gripql.Within("_label", ilabels...)
-			whereExpr := convertHasExpression(has, false)
+			whereExpr, _ := convertHasExpression(has, false)
 			matchStmt := bson.D{primitive.E{Key: "$match", Value: whereExpr}}
 			query = append(query, matchStmt)
 
@@ -553,7 +574,7 @@ func (comp *Compiler) Compile(stmts []*gripql.GraphStatement, opts *gdbi.Compile
 				iids[i] = v
 			}
 			has := gripql.Within("_gid", iids...)
-			whereExpr := convertHasExpression(has, false)
+			whereExpr, _ := convertHasExpression(has, false)
 			matchStmt := bson.D{primitive.E{Key: "$match", Value: whereExpr}}
 			query = append(query, matchStmt)
 
@@ -646,6 +667,35 @@ func (comp *Compiler) Compile(stmts []*gripql.GraphStatement, opts *gdbi.Compile
 				}}})
 			}
 
+		case *gripql.GraphStatement_Sort:
+			if lastType != gdbi.VertexData && lastType != gdbi.EdgeData {
+				return &Pipeline{}, fmt.Errorf(`"sort" statement is only valid for edge or vertex types not: %s`, lastType.String())
+			}
+			sorted = true
+			fields := protoutil.AsStringList(stmt.Sort)
+			if len(fields) == 0 {
+				return &Pipeline{}, fmt.Errorf(`no fields provided to "sort" statement`)
+			}
+			keys := bson.D{}
+			for _, f := range fields {
+				order := 1
+				if strings.HasPrefix(f, "-") {
+					order = -1
+					f = strings.TrimPrefix(f, "-")
+				}
+				namespace := jsonpath.GetNamespace(f)
+				f = jsonpath.GetJSONPath(f)
+				f = strings.TrimPrefix(f, "$.")
+				if f == "gid" {
+					f = "_id"
+				}
+				if namespace != jsonpath.Current {
+					f = fmt.Sprintf("marks.%s.%s", namespace, f)
+				}
+				keys = append(keys, primitive.E{Key: f, Value: order})
+			}
+			query = append(query, bson.D{primitive.E{Key: "$sort", Value: keys}})
+
 		case *gripql.GraphStatement_As:
 			if lastType == gdbi.NoData {
 				return &Pipeline{}, fmt.Errorf(`"as" statement is not valid at the beginning of a traversal`)
@@ -708,7 +758,26 @@ func (comp *Compiler) Compile(stmts []*gripql.GraphStatement, opts *gdbi.Compile
 			if lastType != gdbi.VertexData && lastType != gdbi.EdgeData {
 				return &Pipeline{}, fmt.Errorf(`"render" statement is only valid for edge or vertex types not: %s`, lastType.String())
 			}
-			procs = append(procs, &core.Render{Template: stmt.Render.AsInterface()})
+			// the other steps run in the mongo pipeline, but render runs in
+			// GRIP and may use the parallel workers of the query
+			procs = append(procs, &gdbi.Parallel{Processor: &core.Render{Template: stmt.Render.AsInterface()}, Ordered: sorted})
+			lastType = gdbi.RenderData
+
+		case *gripql.GraphStatement_Group:
+			if lastType != gdbi.VertexData && lastType != gdbi.EdgeData {
+				return &Pipeline{}, fmt.Errorf(`"group" statement is only valid for edge or vertex types not: %s`, lastType.String())
+			}
+			if stmt.Group.Key == "" {
+				return &Pipeline{}, fmt.Errorf(`"group" statement has an empty key`)
+			}
+			procs = append(procs, core.NewGroup(stmt.Group.Key, stmt.Group.Value.AsInterface()))
+			lastType = gdbi.RenderData
+
+		case *gripql.GraphStatement_Fold:
+			if lastType != gdbi.VertexData && lastType != gdbi.EdgeData {
+				return &Pipeline{}, fmt.Errorf(`"fold" statement is only valid for edge or vertex types not: %s`, lastType.String())
+			}
+			procs = append(procs, core.NewFold(stmt.Fold.AsInterface()))
 			lastType = gdbi.RenderData
 
 		case *gripql.GraphStatement_Path:
@@ -837,6 +906,44 @@ func (comp *Compiler) Compile(stmts []*gripql.GraphStatement, opts *gdbi.Compile
 					aggTypes[a.Name] = a
 					aggs[a.Name] = stmt
 
+				case *gripql.Aggregate_DateHistogram:
+					agg := a.GetDateHistogram()
+					field := jsonpath.GetJSONPath(agg.Field)
+					field = strings.TrimPrefix(field, "$.")
+					// buckets are keyed by the RFC3339 timestamp of their start
+					formats := map[string]string{
+						gripql.DateIntervalDay:   "%Y-%m-%dT00:00:00Z",
+						gripql.DateIntervalMonth: "%Y-%m-01T00:00:00Z",
+						gripql.DateIntervalYear:  "%Y-01-01T00:00:00Z",
+					}
+					format, ok := formats[agg.Interval]
+					if !ok {
+						return &Pipeline{}, fmt.Errorf("date histogram aggregation '%s': %v", a.Name, gripql.CheckDateInterval(agg.Interval))
+					}
+					stmt := []bson.M{
+						{
+							"$project": bson.M{
+								"date": bson.M{"$convert": bson.M{"input": "$" + field, "to": "date", "onError": nil, "onNull": nil}},
+							},
+						},
+						{
+							"$match": bson.M{
+								"date": bson.M{"$ne": nil},
+							},
+						},
+						{
+							"$group": bson.M{
+								"_id":   bson.M{"$dateToString": bson.M{"format": format, "date": "$date"}},
+								"count": bson.M{"$sum": 1},
+							},
+						},
+						{
+							"$sort": bson.M{"_id": 1},
+						},
+					}
+					aggTypes[a.Name] = a
+					aggs[a.Name] = stmt
+
 				case *gripql.Aggregate_Percentile:
 					agg := a.GetPercentile()
 					field := jsonpath.GetJSONPath(agg.Field)
@@ -2,9 +2,12 @@ package mongo
 
 import (
 	"fmt"
+	"reflect"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/bmeg/grip/gdbi"
 	"github.com/bmeg/grip/gripql"
 	"github.com/bmeg/grip/jsonpath"
 	"github.com/bmeg/grip/util"
@@ -68,3 +71,127 @@ func TestDistinctPathing(t *testing.T) {
 		t.Errorf("mark key not found")
 	}
 }
+
+func TestRefCondition(t *testing.T) {
+	expr, err := convertHasExpression(gripql.Gt("$b.age", gripql.Ref("$a._gid")), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	e, ok := expr["$expr"].(bson.M)
+	if !ok {
+		t.Fatalf("expected $expr match, got %v", expr)
+	}
+	and := e["$and"].(bson.A)
+	gt := and[len(and)-1].(bson.M)["$gt"].(bson.A)
+	if gt[0] != "$marks.b.data.age" || gt[1] != "$marks.a._id" {
+		t.Errorf("unexpected comparison %v", gt)
+	}
+
+	expr, _ = convertHasExpression(gripql.Eq("name", gripql.Ref("$a.name")), true)
+	not := expr["$expr"].(bson.M)["$not"].(bson.A)
+	eq := not[0].(bson.M)["$eq"].(bson.A)
+	if eq[0] != "$data.name" || eq[1] != "$marks.a.data.name" {
+		t.Errorf("unexpected comparison %v", eq)
+	}
+}
+
+func TestDateCondition(t *testing.T) {
+	expr, err := convertHasExpression(gripql.Between("created", []interface{}{"2014-12-10", "2014-12-11T00:00:00+01:00"}), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	and := expr["$and"].([]bson.M)
+	if len(and) != 2 {
+		t.Fatalf("expected two bounds, got %v", expr)
+	}
+	gte := and[0]["$expr"].(bson.M)["$and"].(bson.A)[1].(bson.M)["$gte"].(bson.A)
+	if ts, ok := gte[1].(time.Time); !ok || !ts.Equal(time.Date(2014, 12, 10, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected lower bound %v", gte[1])
+	}
+	lt := and[1]["$expr"].(bson.M)["$and"].(bson.A)[1].(bson.M)["$lt"].(bson.A)
+	if ts, ok := lt[1].(time.Time); !ok || !ts.Equal(time.Date(2014, 12, 10, 23, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected upper bound %v", lt[1])
+	}
+	conv := lt[0].(bson.M)["$convert"].(bson.M)
+	if conv["input"] != "$data.created" || conv["to"] != "date" {
+		t.Errorf("unexpected field conversion %v", conv)
+	}
+
+	// equality and non-timestamp values keep the plain match
+	expr, _ = convertHasExpression(gripql.Eq("created", "2014-12-10"), false)
+	if _, ok := expr["data.created"]; !ok {
+		t.Errorf("unexpected equality match %v", expr)
+	}
+}
+
+func TestGeoCondition(t *testing.T) {
+	expr, err := convertHasExpression(gripql.GeoRadius("loc", 37.77, -122.42, 5000), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// $geoWithin only reads GeoJSON points and [lon, lat] pairs stored in the
+	// field itself, so points stored as {lat, lon} maps are not matched
+	if len(expr) != 1 {
+		t.Errorf("expected a single $geoWithin match on the field, got %v", expr)
+	}
+	within := expr["data.loc"].(bson.M)["$geoWithin"].(bson.M)
+	sphere := within["$centerSphere"].(bson.A)
+	if center := sphere[0].(bson.A); center[0] != -122.42 || center[1] != 37.77 {
+		t.Errorf("expected [lon, lat] center, got %v", center)
+	}
+
+	// a box crossing the antimeridian
+	expr, _ = convertHasExpression(gripql.Not(gripql.GeoBox("loc", -10, 170, 10, -170)), false)
+	nor := expr["$nor"].(bson.A)
+	geom := nor[0].(bson.M)["data.loc"].(bson.M)["$geoWithin"].(bson.M)["$geometry"].(bson.M)
+	ring := geom["coordinates"].(bson.A)[0].(bson.A)
+	expected := bson.A{
+		bson.A{170.0, -10.0}, bson.A{-170.0, -10.0}, bson.A{-170.0, 10.0},
+		bson.A{170.0, 10.0}, bson.A{170.0, -10.0},
+	}
+	if !reflect.DeepEqual(ring, expected) {
+		t.Errorf("unexpected box ring %v", ring)
+	}
+
+	expr, _ = convertHasExpression(gripql.GeoPolygon("loc", [2]float64{0, 0}, [2]float64{0, 1}, [2]float64{1, 1}), false)
+	geom = expr["data.loc"].(bson.M)["$geoWithin"].(bson.M)["$geometry"].(bson.M)
+	ring = geom["coordinates"].(bson.A)[0].(bson.A)
+	if len(ring) != 4 || !reflect.DeepEqual(ring[0], ring[3]) {
+		t.Errorf("expected closed polygon ring, got %v", ring)
+	}
+
+	// invalid shapes fail the query, also when negated
+	c := NewCompiler(&Graph{})
+	for _, has := range []*gripql.HasExpression{
+		gripql.GeoRadius("loc", 37.77, -122.42, -1),
+		gripql.Not(gripql.GeoBox("loc", 10, 0, -10, 1)),
+	} {
+		q := gripql.NewQuery().V().HasLabel("Site").Has(has)
+		if _, err := c.Compile(q.Statements, nil); err == nil {
+			t.Errorf("expected error compiling %v", has)
+		}
+	}
+}
+
+func TestParallelRender(t *testing.T) {
+	c := NewCompiler(&Graph{})
+	for _, sorted := range []bool{false, true} {
+		q := gripql.NewQuery().V().HasLabel("Person")
+		if sorted {
+			q = q.Sort("name")
+		}
+		q = q.Render("$.name")
+		pipe, err := c.Compile(q.Statements, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		procs := pipe.Processors()
+		p, ok := procs[len(procs)-1].(*gdbi.Parallel)
+		if !ok {
+			t.Fatalf("render isn't parallel: %T", procs[len(procs)-1])
+		}
+		if p.Ordered != sorted {
+			t.Errorf("sorted %v: unexpected ordered %v", sorted, p.Ordered)
+		}
+	}
+}
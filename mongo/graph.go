@@ -170,6 +170,7 @@ func (mg *Graph) GetVertexList(ctx context.Context, load bool) <-chan *gdbi.Vert
 		}
 		query, err := vCol.Find(ctx, bson.M{}, opts)
 		if err != nil {
+			gdbi.ReportError(ctx, "GetVertexList", err)
 			return
 		}
 		defer query.Close(ctx)
@@ -184,7 +185,7 @@ func (mg *Graph) GetVertexList(ctx context.Context, load bool) <-chan *gdbi.Vert
 				v := UnpackVertex(result)
 				o <- v
 			} else {
-				log.Errorf("Error decoding edge %#v", result)
+				gdbi.ReportError(ctx, "GetVertexList", fmt.Errorf("decode error: %v", err))
 			}
 		}
 	}()
@@ -205,6 +206,7 @@ func (mg *Graph) GetEdgeList(ctx context.Context, loadProp bool) <-chan *gdbi.Ed
 		}
 		query, err := eCol.Find(ctx, bson.M{}, opts)
 		if err != nil {
+			gdbi.ReportError(ctx, "GetEdgeList", err)
 			return
 		}
 		defer query.Close(ctx)
@@ -221,7 +223,7 @@ func (mg *Graph) GetEdgeList(ctx context.Context, loadProp bool) <-chan *gdbi.Ed
 					o <- e
 				}
 			} else {
-				log.Errorf("Error decoding edge %#v", result)
+				gdbi.ReportError(ctx, "GetEdgeList", fmt.Errorf("decode error: %v", err))
 			}
 		}
 	}()
@@ -255,6 +257,7 @@ func (mg *Graph) GetVertexChannel(ctx context.Context, ids chan gdbi.ElementLook
 			}
 			cursor, err := vCol.Find(context.TODO(), query, opts)
 			if err != nil {
+				gdbi.ReportError(ctx, "GetVertexChannel", err)
 				return
 			}
 			chunk := map[string]*gdbi.Vertex{}
@@ -264,11 +267,11 @@ func (mg *Graph) GetVertexChannel(ctx context.Context, ids chan gdbi.ElementLook
 					v := UnpackVertex(result)
 					chunk[v.ID] = v
 				} else {
-					log.WithFields(log.Fields{"error": err}).Error("Decode error")
+					gdbi.ReportError(ctx, "GetVertexChannel", fmt.Errorf("decode error: %v", err))
 				}
 			}
 			if err := cursor.Close(context.TODO()); err != nil {
-				log.WithFields(log.Fields{"error": err}).Error("GetVertexChannel")
+				gdbi.ReportError(ctx, "GetVertexChannel", err)
 			}
 			for _, id := range batch {
 				if x, ok := chunk[id.ID]; ok {
@@ -320,6 +323,7 @@ func (mg *Graph) GetOutChannel(ctx context.Context, reqChan chan gdbi.ElementLoo
 
 			eCol := mg.ar.EdgeCollection(mg.graph)
 			cursor, err := eCol.Aggregate(context.TODO(), query)
+			gdbi.ReportError(ctx, "GetOutChannel", err)
 			if err == nil {
 				result := map[string]interface{}{}
 				for cursor.Next(context.TODO()) {
@@ -337,11 +341,11 @@ func (mg *Graph) GetOutChannel(ctx context.Context, reqChan chan gdbi.ElementLoo
 							log.WithFields(log.Fields{"result": result["dst"]}).Error("GetOutChannel: unable to cast result to map[string]interface{}")
 						}
 					} else {
-						log.WithFields(log.Fields{"result": result, "error": err}).Error("GetOutChannel: decode error")
+						gdbi.ReportError(ctx, "GetOutChannel", fmt.Errorf("decode error: %v", err))
 					}
 				}
 				if err := cursor.Close(context.TODO()); err != nil {
-					log.WithFields(log.Fields{"error": err}).Error("GetOutChannel: iter error")
+					gdbi.ReportError(ctx, "GetOutChannel", err)
 				}
 				if emitNull {
 					for id, count := range batchMapReturnCount {
@@ -399,6 +403,7 @@ func (mg *Graph) GetInChannel(ctx context.Context, reqChan chan gdbi.ElementLook
 
 			eCol := mg.ar.EdgeCollection(mg.graph)
 			cursor, err := eCol.Aggregate(context.TODO(), query)
+			gdbi.ReportError(ctx, "GetInChannel", err)
 			if err == nil {
 				result := map[string]interface{}{}
 				for cursor.Next(context.TODO()) {
@@ -416,11 +421,11 @@ func (mg *Graph) GetInChannel(ctx context.Context, reqChan chan gdbi.ElementLook
 							log.WithFields(log.Fields{"result": result["src"]}).Error("GetInChannel: unable to cast result to map[string]interface{}")
 						}
 					} else {
-						log.WithFields(log.Fields{"error": err}).Error("Decode")
+						gdbi.ReportError(ctx, "GetInChannel", fmt.Errorf("decode error: %v", err))
 					}
 				}
 				if err := cursor.Close(context.TODO()); err != nil {
-					log.WithFields(log.Fields{"error": err}).Error("GetInChannel: iter error")
+					gdbi.ReportError(ctx, "GetInChannel", err)
 				}
 				if emitNull {
 					for id, count := range batchMapReturnCount {
@@ -469,6 +474,7 @@ func (mg *Graph) GetOutEdgeChannel(ctx context.Context, reqChan chan gdbi.Elemen
 			}
 			eCol := mg.ar.EdgeCollection(mg.graph)
 			cursor, err := eCol.Aggregate(context.TODO(), query)
+			gdbi.ReportError(ctx, "GetOutEdgeChannel", err)
 			if err == nil {
 				result := map[string]interface{}{}
 				for cursor.Next(context.TODO()) {
@@ -482,11 +488,11 @@ func (mg *Graph) GetOutEdgeChannel(ctx context.Context, reqChan chan gdbi.Elemen
 							o <- ri
 						}
 					} else {
-						log.WithFields(log.Fields{"error": err}).Error("Decode")
+						gdbi.ReportError(ctx, "GetOutEdgeChannel", fmt.Errorf("decode error: %v", err))
 					}
 				}
 				if err := cursor.Close(context.TODO()); err != nil {
-					log.WithFields(log.Fields{"error": err}).Error("GetOutEdgeChannel: iter error")
+					gdbi.ReportError(ctx, "GetOutEdgeChannel", err)
 				}
 				if emitNull {
 					for id, count := range batchMapReturnCount {
@@ -536,6 +542,7 @@ func (mg *Graph) GetInEdgeChannel(ctx context.Context, reqChan chan gdbi.Element
 			}
 			eCol := mg.ar.EdgeCollection(mg.graph)
 			cursor, err := eCol.Aggregate(context.TODO(), query)
+			gdbi.ReportError(ctx, "GetInEdgeChannel", err)
 			if err == nil {
 				result := map[string]interface{}{}
 				for cursor.Next(context.TODO()) {
@@ -549,11 +556,11 @@ func (mg *Graph) GetInEdgeChannel(ctx context.Context, reqChan chan gdbi.Element
 							o <- ri
 						}
 					} else {
-						log.WithFields(log.Fields{"error": err}).Error("Decode")
+						gdbi.ReportError(ctx, "GetInEdgeChannel", fmt.Errorf("decode error: %v", err))
 					}
 				}
 				if err := cursor.Close(context.TODO()); err != nil {
-					log.WithFields(log.Fields{"error": err}).Error("GetInEdgeChannel: iter error")
+					gdbi.ReportError(ctx, "GetInEdgeChannel", err)
 				}
 				if emitNull {
 					for id, count := range batchMapReturnCount {
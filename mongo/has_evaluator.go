@@ -1,19 +1,28 @@
 package mongo
 
 import (
+	"fmt"
 	"strings"
+	"time"
 
 	"github.com/bmeg/grip/gripql"
 	"github.com/bmeg/grip/jsonpath"
 	"github.com/bmeg/grip/log"
+	"github.com/bmeg/grip/util/geo"
 	"go.mongodb.org/mongo-driver/bson"
 )
 
-func convertHasExpression(stmt *gripql.HasExpression, not bool) bson.M {
+// convertHasExpression converts a has expression to a $match filter. It
+// fails on geo conditions with invalid shapes
+func convertHasExpression(stmt *gripql.HasExpression, not bool) (bson.M, error) {
 	output := bson.M{}
+	var err error
 	switch stmt.Expression.(type) {
 	case *gripql.HasExpression_Condition:
 		cond := stmt.GetCondition()
+		if cond.ValueRef != "" {
+			return convertRefCondition(cond, not), nil
+		}
 		switch cond.Condition {
 		case gripql.Condition_INSIDE:
 			val := cond.Value.AsInterface()
@@ -21,7 +30,7 @@ func convertHasExpression(stmt *gripql.HasExpression, not bool) bson.M {
 			if !ok {
 				log.Error("unable to cast values from INSIDE statement")
 			} else {
-				output = convertHasExpression(gripql.And(gripql.Gt(cond.Key, lims[0]), gripql.Lt(cond.Key, lims[1])), not)
+				output, err = convertHasExpression(gripql.And(gripql.Gt(cond.Key, lims[0]), gripql.Lt(cond.Key, lims[1])), not)
 			}
 
 		case gripql.Condition_OUTSIDE:
@@ -30,7 +39,7 @@ func convertHasExpression(stmt *gripql.HasExpression, not bool) bson.M {
 			if !ok {
 				log.Error("unable to cast values from OUTSIDE statement")
 			} else {
-				output = convertHasExpression(gripql.Or(gripql.Lt(cond.Key, lims[0]), gripql.Gt(cond.Key, lims[1])), not)
+				output, err = convertHasExpression(gripql.Or(gripql.Lt(cond.Key, lims[0]), gripql.Gt(cond.Key, lims[1])), not)
 			}
 
 		case gripql.Condition_BETWEEN:
@@ -39,8 +48,15 @@ func convertHasExpression(stmt *gripql.HasExpression, not bool) bson.M {
 			if !ok {
 				log.Error("unable to cast values from BETWEEN statement")
 			} else {
-				output = convertHasExpression(gripql.And(gripql.Gte(cond.Key, lims[0]), gripql.Lt(cond.Key, lims[1])), not)
+				output, err = convertHasExpression(gripql.And(gripql.Gte(cond.Key, lims[0]), gripql.Lt(cond.Key, lims[1])), not)
+			}
+
+		case gripql.Condition_GEO_RADIUS, gripql.Condition_GEO_BOX, gripql.Condition_GEO_POLYGON:
+			shape, err := geo.ParseShape(cond.Condition, cond.Value.AsInterface())
+			if err != nil {
+				return nil, fmt.Errorf("%s: %v", cond.Key, err)
 			}
+			output = convertGeoCondition(convertPath(cond.Key), shape, not)
 
 		default:
 			output = convertCondition(cond, not)
@@ -50,7 +66,11 @@ func convertHasExpression(stmt *gripql.HasExpression, not bool) bson.M {
 		and := stmt.GetAnd()
 		andRes := []bson.M{}
 		for _, e := range and.Expressions {
-			andRes = append(andRes, convertHasExpression(e, not))
+			res, err := convertHasExpression(e, not)
+			if err != nil {
+				return nil, err
+			}
+			andRes = append(andRes, res)
 		}
 		output = bson.M{"$and": andRes}
 		if not {
@@ -61,7 +81,11 @@ func convertHasExpression(stmt *gripql.HasExpression, not bool) bson.M {
 		or := stmt.GetOr()
 		orRes := []bson.M{}
 		for _, e := range or.Expressions {
-			orRes = append(orRes, convertHasExpression(e, not))
+			res, err := convertHasExpression(e, not)
+			if err != nil {
+				return nil, err
+			}
+			orRes = append(orRes, res)
 		}
 		output = bson.M{"$or": orRes}
 		if not {
@@ -69,14 +93,13 @@ func convertHasExpression(stmt *gripql.HasExpression, not bool) bson.M {
 		}
 
 	case *gripql.HasExpression_Not:
-		notRes := convertHasExpression(stmt.GetNot(), true)
-		output = notRes
+		output, err = convertHasExpression(stmt.GetNot(), true)
 
 	default:
 		log.Error("unknown where expression type")
 	}
 
-	return output
+	return output, err
 }
 
 func convertPath(key string) string {
@@ -93,6 +116,12 @@ func convertCondition(cond *gripql.HasCondition, not bool) bson.M {
 	var val interface{}
 	key = convertPath(cond.Key)
 	val = cond.Value.AsInterface()
+	if ts, ok := gripql.ParseDateTime(val); ok {
+		switch cond.Condition {
+		case gripql.Condition_GT, gripql.Condition_GTE, gripql.Condition_LT, gripql.Condition_LTE:
+			return convertDateCondition(key, ts, cond.Condition, not)
+		}
+	}
 	expr := bson.M{}
 	switch cond.Condition {
 	case gripql.Condition_EQ:
@@ -121,3 +150,133 @@ func convertCondition(cond *gripql.HasCondition, not bool) bson.M {
 	}
 	return bson.M{key: expr}
 }
+
+// convertDateCondition converts an ordering condition on a timestamp to an
+// $expr match that reads the field as a date, so timestamps with different
+// offsets or precision are compared by time rather than as strings
+func convertDateCondition(key string, ts time.Time, cond gripql.Condition, not bool) bson.M {
+	field := bson.M{"$convert": bson.M{"input": "$" + key, "to": "date", "onError": nil, "onNull": nil}}
+	ops := map[gripql.Condition]string{
+		gripql.Condition_GT:  "$gt",
+		gripql.Condition_GTE: "$gte",
+		gripql.Condition_LT:  "$lt",
+		gripql.Condition_LTE: "$lte",
+	}
+	// null sorts before every date, but never matches an ordering in the core engine
+	expr := bson.M{"$and": bson.A{
+		bson.M{"$ne": bson.A{field, nil}},
+		bson.M{ops[cond]: bson.A{field, ts}},
+	}}
+	if not {
+		expr = bson.M{"$not": bson.A{expr}}
+	}
+	return bson.M{"$expr": expr}
+}
+
+// convertGeoCondition converts a geo condition to a $geoWithin match. Mongo
+// reads GeoJSON points and [lon, lat] pairs, and follows great circles along
+// the edges of boxes and polygons
+func convertGeoCondition(key string, shape geo.Shape, not bool) bson.M {
+	var within bson.M
+	switch s := shape.(type) {
+	case geo.Circle:
+		center := bson.A{s.Center.Lon, s.Center.Lat}
+		within = bson.M{"$centerSphere": bson.A{center, s.Radius / geo.EarthRadius}}
+	case geo.Box:
+		east := s.East
+		if s.West > s.East {
+			east += 360
+		}
+		// corners along the edges keep each edge shorter than half the earth
+		ring := bson.A{}
+		for lon := s.West; lon < east; lon += 90 {
+			ring = append(ring, bson.A{normalizeLon(lon), s.South})
+		}
+		ring = append(ring, bson.A{normalizeLon(east), s.South})
+		for lon := east; lon > s.West; lon -= 90 {
+			ring = append(ring, bson.A{normalizeLon(lon), s.North})
+		}
+		ring = append(ring, bson.A{s.West, s.North}, bson.A{s.West, s.South})
+		within = bson.M{"$geometry": bson.M{"type": "Polygon", "coordinates": bson.A{ring}}}
+	case geo.Polygon:
+		ring := bson.A{}
+		for _, p := range s {
+			ring = append(ring, bson.A{p.Lon, p.Lat})
+		}
+		ring = append(ring, bson.A{s[0].Lon, s[0].Lat})
+		within = bson.M{"$geometry": bson.M{"type": "Polygon", "coordinates": bson.A{ring}}}
+	}
+	expr := bson.M{key: bson.M{"$geoWithin": within}}
+	if not {
+		return bson.M{"$nor": bson.A{expr}}
+	}
+	return expr
+}
+
+func normalizeLon(lon float64) float64 {
+	if lon > 180 {
+		return lon - 360
+	}
+	return lon
+}
+
+// exprPath returns the aggregation expression reading a traveler path, where
+// marks are read from the marks of the document
+func exprPath(path string) string {
+	namespace := jsonpath.GetNamespace(path)
+	if namespace != jsonpath.Current {
+		return fmt.Sprintf("$marks.%s.%s", namespace, convertPath(path))
+	}
+	return "$" + convertPath(path)
+}
+
+// convertRefCondition converts a condition comparing two fields of the
+// traveler to an $expr match
+func convertRefCondition(cond *gripql.HasCondition, not bool) bson.M {
+	key := exprPath(cond.Key)
+	ref := exprPath(cond.ValueRef)
+	// missing fields sort before every value in mongo, but never match an
+	// ordering in the core engine
+	exists := func(f interface{}) bson.M {
+		return bson.M{"$ne": bson.A{bson.M{"$type": f}, "missing"}}
+	}
+	lower := bson.M{"$arrayElemAt": bson.A{ref, 0}}
+	upper := bson.M{"$arrayElemAt": bson.A{ref, 1}}
+	var expr bson.M
+	switch cond.Condition {
+	case gripql.Condition_EQ:
+		expr = bson.M{"$eq": bson.A{key, ref}}
+	case gripql.Condition_NEQ:
+		expr = bson.M{"$ne": bson.A{key, ref}}
+	case gripql.Condition_GT:
+		expr = bson.M{"$and": bson.A{exists(key), exists(ref), bson.M{"$gt": bson.A{key, ref}}}}
+	case gripql.Condition_GTE:
+		expr = bson.M{"$and": bson.A{exists(key), exists(ref), bson.M{"$gte": bson.A{key, ref}}}}
+	case gripql.Condition_LT:
+		expr = bson.M{"$and": bson.A{exists(key), exists(ref), bson.M{"$lt": bson.A{key, ref}}}}
+	case gripql.Condition_LTE:
+		expr = bson.M{"$and": bson.A{exists(key), exists(ref), bson.M{"$lte": bson.A{key, ref}}}}
+	case gripql.Condition_INSIDE:
+		expr = bson.M{"$and": bson.A{exists(key), bson.M{"$isArray": ref},
+			bson.M{"$gt": bson.A{key, lower}}, bson.M{"$lt": bson.A{key, upper}}}}
+	case gripql.Condition_OUTSIDE:
+		expr = bson.M{"$and": bson.A{exists(key), bson.M{"$isArray": ref},
+			bson.M{"$or": bson.A{bson.M{"$lt": bson.A{key, lower}}, bson.M{"$gt": bson.A{key, upper}}}}}}
+	case gripql.Condition_BETWEEN:
+		expr = bson.M{"$and": bson.A{exists(key), bson.M{"$isArray": ref},
+			bson.M{"$gte": bson.A{key, lower}}, bson.M{"$lt": bson.A{key, upper}}}}
+	case gripql.Condition_WITHIN:
+		expr = bson.M{"$and": bson.A{bson.M{"$isArray": ref}, bson.M{"$in": bson.A{key, ref}}}}
+	case gripql.Condition_WITHOUT:
+		expr = bson.M{"$not": bson.A{bson.M{"$and": bson.A{bson.M{"$isArray": ref}, bson.M{"$in": bson.A{key, ref}}}}}}
+	case gripql.Condition_CONTAINS:
+		expr = bson.M{"$and": bson.A{bson.M{"$isArray": key}, bson.M{"$in": bson.A{ref, key}}}}
+	default:
+		log.Error("unknown where condition type")
+		return bson.M{}
+	}
+	if not {
+		expr = bson.M{"$not": bson.A{expr}}
+	}
+	return bson.M{"$expr": expr}
+}
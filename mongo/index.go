@@ -14,19 +14,28 @@ import (
 )
 
 // AddVertexIndex add index to vertices
-func (mg *Graph) AddVertexIndex(label string, field string) error {
-	log.WithFields(log.Fields{"label": label, "field": field}).Info("Adding vertex index")
+func (mg *Graph) AddVertexIndex(label string, field string, itype gripql.IndexType) error {
+	log.WithFields(log.Fields{"label": label, "field": field, "type": itype}).Info("Adding vertex index")
 	field = jsonpath.GetJSONPath(field)
 	field = strings.TrimPrefix(field, "$.")
 
-	idx := mg.ar.VertexCollection(mg.graph).Indexes()
+	c := mg.ar.VertexCollection(mg.graph)
+	idx := c.Indexes()
+
+	model := mongo.IndexModel{
+		Keys:    bson.D{{Key: "label", Value: 1}, {Key: field, Value: 1}},
+		Options: options.Index().SetUnique(false).SetSparse(true).SetBackground(true),
+	}
+	// geo indexes are 2dsphere indexes, limited to the label as vertices of
+	// other labels may hold values in the field that aren't points
+	if itype == gripql.IndexType_GEO_INDEX {
+		model = mongo.IndexModel{
+			Keys:    bson.D{{Key: "label", Value: 1}, {Key: field, Value: "2dsphere"}},
+			Options: options.Index().SetBackground(true).SetPartialFilterExpression(bson.M{"label": label}),
+		}
+	}
 
-	_, err := idx.CreateOne(
-		context.Background(),
-		mongo.IndexModel{
-			Keys:    bson.D{{"label", 1}, {field, 1}},
-			Options: options.Index().SetUnique(false).SetSparse(true).SetBackground(true),
-		})
+	_, err := idx.CreateOne(context.Background(), model)
 	if err != nil {
 		return fmt.Errorf("failed create index %s %s %s", label, field, err)
 	}
@@ -91,8 +100,12 @@ func (mg *Graph) GetVertexIndexList() <-chan *gripql.IndexID {
 					}
 					if len(key) > 0 {
 						f := strings.TrimPrefix(key, "data.")
+						itype := gripql.IndexType_FIELD_INDEX
+						if recKeys[key] == "2dsphere" {
+							itype = gripql.IndexType_GEO_INDEX
+						}
 						for _, l := range labels {
-							out <- &gripql.IndexID{Graph: mg.graph, Label: l, Field: f}
+							out <- &gripql.IndexID{Graph: mg.graph, Label: l, Field: f, Type: itype}
 						}
 					}
 				}
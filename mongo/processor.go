@@ -5,6 +5,7 @@ import (
 	"context"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/bmeg/grip/gdbi"
 	"github.com/bmeg/grip/gripql"
@@ -111,6 +112,16 @@ func (proc *Processor) Process(ctx context.Context, man gdbi.Manager, in gdbi.In
 						}
 
 						var lastBucket float64
+						var lastDate time.Time
+						// empty date buckets are filled in, unless the span is too wide
+						fillDates := true
+						if dh := proc.aggTypes[k].GetDateHistogram(); dh != nil && len(buckets) > 0 {
+							first, _ := buckets[0].(map[string]interface{})
+							last, _ := buckets[len(buckets)-1].(map[string]interface{})
+							start, _ := gripql.ParseDateTime(first["_id"])
+							end, _ := gripql.ParseDateTime(last["_id"])
+							fillDates = gripql.DateBucketCount(start, end, dh.Interval) <= gripql.MaxDateBuckets
+						}
 						for i, bucket := range buckets {
 							bucket, ok := bucket.(map[string]interface{})
 							if !ok {
@@ -133,6 +144,22 @@ func (proc *Processor) Process(ctx context.Context, man gdbi.Manager, in gdbi.In
 								}
 								lastBucket = curPos
 
+							case *gripql.Aggregate_DateHistogram:
+								term = bucket["_id"]
+								interval := proc.aggTypes[k].GetDateHistogram().Interval
+								curDate, err := time.Parse(time.RFC3339, bucket["_id"].(string))
+								if err != nil {
+									plog.Errorf("failed to parse date histogram aggregation result key: %v", err)
+									continue
+								}
+								if fillDates && !lastDate.IsZero() {
+									_, nv, _ := gripql.DateBucket(lastDate, interval)
+									for ; nv.Before(curDate); _, nv, _ = gripql.DateBucket(nv, interval) {
+										out <- &gdbi.BaseTraveler{Aggregation: &gdbi.Aggregate{Name: k, Key: nv.Format(time.RFC3339), Value: float64(0.0)}}
+									}
+								}
+								lastDate = curDate
+
 							case *gripql.Aggregate_Percentile:
 								bid := strings.Replace(bucket["_id"].(string), "_", ".", -1)
 								f, err := strconv.ParseFloat(bid, 64)
@@ -1,17 +1,21 @@
 package server
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"sync"
 
 	"github.com/bmeg/grip/engine/pipeline"
 	"github.com/bmeg/grip/gdbi"
+	"github.com/bmeg/grip/gdbi/schema"
 	"github.com/bmeg/grip/gripper"
 	"github.com/bmeg/grip/gripql"
 	"github.com/bmeg/grip/log"
 	"github.com/bmeg/grip/util"
+	"github.com/bmeg/grip/util/arrow"
 	"golang.org/x/net/context"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -26,24 +30,96 @@ func (server *GripServer) Traversal(query *gripql.GraphQuery, queryServer gripql
 	if err != nil {
 		return err
 	}
-	compiler := graph.Compiler()
-	compiledPipeline, err := compiler.Compile(query.Query, nil)
-	if err != nil {
-		return err
+	ctx, cancel := gdbi.WithQueryErrors(queryServer.Context())
+	defer cancel()
+	ctx = server.withParallelism(ctx, query)
+	var res <-chan *gripql.QueryResult
+	cached := false
+	if server.conf.Server.QueryCache.Enable && server.jStorage != nil && cacheableQuery(query.Query) {
+		res, cached = server.resumeCached(ctx, graph, query)
+		if !cached {
+			go server.trackPrefixes(graph, query)
+		}
+	}
+	if !cached {
+		compiler := graph.Compiler()
+		compiledPipeline, err := compiler.Compile(query.Query, nil)
+		if err != nil {
+			return err
+		}
+		res = pipeline.Run(ctx, compiledPipeline, server.conf.Server.WorkDir)
 	}
-	res := pipeline.Run(queryServer.Context(), compiledPipeline, server.conf.Server.WorkDir)
 	err = nil
-	for row := range res {
-		if err == nil {
-			err = queryServer.Send(row)
+	if query.Format == gripql.ResultFormat_ARROW {
+		err = arrow.Send(res, queryServer.Send)
+	} else {
+		for row := range res {
+			if err == nil {
+				err = queryServer.Send(row)
+			}
 		}
 	}
+	if qerr := gdbi.QueryError(ctx); qerr != nil {
+		return queryStatus(qerr)
+	}
 	if err != nil {
 		return fmt.Errorf("error sending Traversal result: %v", err)
 	}
 	return nil
 }
 
+// TraversalText parses a query written in the text syntax of the clients and
+// streams the results back
+func (server *GripServer) TraversalText(query *gripql.GraphQueryText, queryServer gripql.Query_TraversalTextServer) error {
+	q, err := gripql.ParseQuery(query.Query)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "parsing query: %v", err)
+	}
+	return server.Traversal(&gripql.GraphQuery{
+		Graph:         query.Graph,
+		Query:         q.Statements,
+		Parallelism:   query.Parallelism,
+		PreserveOrder: query.PreserveOrder,
+		Format:        query.Format,
+	}, queryServer)
+}
+
+// withParallelism sets the workers for the stateless steps of a query, from
+// the query or else the server config
+func (server *GripServer) withParallelism(ctx context.Context, query *gripql.GraphQuery) context.Context {
+	conf := server.conf.Server.Parallelism
+	workers := conf.Workers
+	if query.Parallelism > 0 {
+		workers = int(query.Parallelism)
+		if conf.MaxWorkers > 0 && workers > conf.MaxWorkers {
+			workers = conf.MaxWorkers
+		}
+	}
+	return gdbi.WithParallelism(ctx, workers, query.PreserveOrder || conf.PreserveOrder)
+}
+
+// queryStatus converts the error of a query step to a gRPC status, naming the
+// step that failed in the details of the status
+func queryStatus(err error) error {
+	code := codes.Internal
+	if errors.Is(err, gdbi.ErrLimit) {
+		code = codes.ResourceExhausted
+	}
+	st := status.New(code, err.Error())
+	var se *gdbi.StepError
+	if errors.As(err, &se) {
+		info := &errdetails.ErrorInfo{
+			Reason:   "STEP_FAILED",
+			Domain:   "grip",
+			Metadata: map[string]string{"step": se.Step},
+		}
+		if dst, derr := st.WithDetails(info); derr == nil {
+			st = dst
+		}
+	}
+	return st.Err()
+}
+
 // ListGraphs returns a list of graphs managed by the driver
 func (server *GripServer) ListGraphs(ctx context.Context, empty *gripql.Empty) (*gripql.ListGraphsResponse, error) {
 	//server.updateGraphMap()
@@ -131,6 +207,13 @@ func (server *GripServer) DeleteGraph(ctx context.Context, elem *gripql.GraphID)
 			return nil, fmt.Errorf("DeleteGraph: deleting schema for graph %s: %v", elem.Graph, err)
 		}
 	}
+	queriesName := fmt.Sprintf("%s%s", elem.Graph, storedQuerySuffix)
+	if server.graphExists(queriesName) {
+		err := gdb.DeleteGraph(queriesName)
+		if err != nil {
+			return nil, fmt.Errorf("DeleteGraph: deleting stored queries for graph %s: %v", elem.Graph, err)
+		}
+	}
 	server.updateGraphMap()
 	return &gripql.EditResult{Id: elem.Graph}, nil
 }
@@ -158,6 +241,9 @@ func (server *GripServer) AddVertex(ctx context.Context, elem *gripql.GraphEleme
 	if isSchema(elem.Graph) {
 		return nil, fmt.Errorf("unable to add vertex to graph schema; use AddSchema")
 	}
+	if isStoredQueries(elem.Graph) {
+		return nil, fmt.Errorf("unable to add vertex to stored queries; use AddStoredQuery")
+	}
 	return server.addVertex(ctx, elem)
 }
 
@@ -176,11 +262,34 @@ func (server *GripServer) addVertex(ctx context.Context, elem *gripql.GraphEleme
 	if err != nil {
 		return nil, fmt.Errorf("vertex validation failed: %v", err)
 	}
+	validator, err := server.schemaValidator(elem.Graph)
+	if err != nil {
+		return nil, err
+	}
+	if validator != nil {
+		if err := validator.ValidateVertex(vertex); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "schema validation failed: %v", err)
+		}
+	}
+	if elem.Create {
+		server.createMu.Lock()
+		defer server.createMu.Unlock()
+		if graph.GetVertex(vertex.Gid, false) != nil {
+			return nil, status.Errorf(codes.AlreadyExists, "vertex %s already exists", vertex.Gid)
+		}
+	}
 
+	var event *gripql.GraphEvent
+	if server.changes.watching(elem.Graph) {
+		event = vertexEvent(elem.Graph, graph, vertex)
+	}
 	err = graph.AddVertex([]*gdbi.Vertex{gdbi.NewElementFromVertex(vertex)})
 	if err != nil {
 		return nil, err
 	}
+	if event != nil {
+		server.changes.publish(event)
+	}
 	return &gripql.EditResult{Id: elem.Vertex.Gid}, nil
 }
 
@@ -189,6 +298,9 @@ func (server *GripServer) AddEdge(ctx context.Context, elem *gripql.GraphElement
 	if isSchema(elem.Graph) {
 		return nil, fmt.Errorf("unable to add edge to graph schema; use AddSchema")
 	}
+	if isStoredQueries(elem.Graph) {
+		return nil, fmt.Errorf("unable to add edge to stored queries; use AddStoredQuery")
+	}
 	return server.addEdge(ctx, elem)
 }
 
@@ -210,22 +322,143 @@ func (server *GripServer) addEdge(ctx context.Context, elem *gripql.GraphElement
 	if err != nil {
 		return nil, fmt.Errorf("edge validation failed: %v", err)
 	}
+	validator, err := server.schemaValidator(elem.Graph)
+	if err != nil {
+		return nil, err
+	}
+	if validator != nil {
+		if err := validator.ValidateEdge(edge, vertexLabels(graph, nil)); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "schema validation failed: %v", err)
+		}
+	}
+	if elem.Create {
+		server.createMu.Lock()
+		defer server.createMu.Unlock()
+		if graph.GetEdge(edge.Gid, false) != nil {
+			return nil, status.Errorf(codes.AlreadyExists, "edge %s already exists", edge.Gid)
+		}
+	}
 
+	var event *gripql.GraphEvent
+	if server.changes.watching(elem.Graph) {
+		event = edgeEvent(elem.Graph, graph, edge)
+	}
 	err = graph.AddEdge([]*gdbi.Edge{gdbi.NewElementFromEdge(edge)})
 	if err != nil {
 		return nil, err
 	}
+	if event != nil {
+		server.changes.publish(event)
+	}
 	return &gripql.EditResult{Id: edge.Gid}, nil
 }
 
+// maximum number of element errors returned from BulkAdd
+const maxBulkErrors = 1000
+
+// maximum number of vertex labels BulkAdd keeps to validate edges against
+// vertices that may not have been written yet. Once reached, the elements
+// sent so far are written, so the labels can be read from the graph
+const maxPendingLabels = 100000
+
 // BulkAdd a stream of inputs and loads them into the graph
 func (server *GripServer) BulkAdd(stream gripql.Edit_BulkAddServer) error {
 	var graphName string
 	var insertCount int32
 	var errorCount int32
+	elementErrors := []string{}
+
+	// record a rejected element, reporting the first few errors to the client
+	reject := func(err error) {
+		errorCount++
+		if len(elementErrors) < maxBulkErrors {
+			elementErrors = append(elementErrors, err.Error())
+		}
+	}
 
-	elementStream := make(chan *gdbi.GraphElement, 100)
+	var validator *schema.Validator
+	var validatorErr error
+	// labels of vertices in the stream, so edges can be validated before
+	// the vertices are written
+	pending := map[string]string{}
+	var current gdbi.GraphInterface
+	// elements of a watched graph are written in batches, so their events
+	// can be sent once they are written
+	batch := []*gripql.GraphElement{}
+	watched := false
+
+	var elementStream chan *gdbi.GraphElement
 	wg := &sync.WaitGroup{}
+	var validatorReported bool
+
+	// write the batch of a watched graph and send its events
+	flushBatch := func() {
+		if len(batch) == 0 {
+			return
+		}
+		events := elementEvents(graphName, current, batch)
+		vertices := []*gdbi.Vertex{}
+		edges := []*gdbi.Edge{}
+		for _, element := range batch {
+			if element.Vertex != nil {
+				vertices = append(vertices, gdbi.NewElementFromVertex(element.Vertex))
+			} else {
+				edges = append(edges, gdbi.NewElementFromEdge(element.Edge))
+			}
+		}
+		batch = []*gripql.GraphElement{}
+		err := current.AddVertex(vertices)
+		if err == nil {
+			err = current.AddEdge(edges)
+		}
+		if err != nil {
+			log.WithFields(log.Fields{"graph": graphName, "error": err}).Error("BulkAdd: error")
+			errorCount++
+			return
+		}
+		server.changes.publish(events...)
+	}
+
+	// send a valid element to the current graph
+	write := func(element *gripql.GraphElement) {
+		if !watched {
+			elementStream <- gdbi.NewGraphElement(element)
+			return
+		}
+		batch = append(batch, element)
+		if len(batch) >= watchBatchSize {
+			flushBatch()
+		}
+	}
+
+	// stop writing to the current graph
+	closeStream := func() {
+		flushBatch()
+		if elementStream != nil {
+			close(elementStream)
+			elementStream = nil
+		}
+	}
+
+	// start writing elements to the current graph
+	startStream := func() {
+		if watched {
+			return
+		}
+		graph, name, stream := current, graphName, make(chan *gdbi.GraphElement, 100)
+		elementStream = stream
+		wg.Add(1)
+		go func() {
+			log.WithFields(log.Fields{"graph": name}).Info("BulkAdd: streaming elements to graph")
+			err := graph.BulkAdd(stream)
+			if err != nil {
+				log.WithFields(log.Fields{"graph": name, "error": err}).Error("BulkAdd: error")
+				// not a good representation of the true number of errors
+				errorCount++
+			}
+			wg.Done()
+		}()
+	}
 
 	for {
 		element, err := stream.Recv()
@@ -244,11 +477,17 @@ func (server *GripServer) BulkAdd(stream gripql.Edit_BulkAddServer) error {
 			errorCount++
 			continue
 		}
+		if isStoredQueries(element.Graph) {
+			reject(fmt.Errorf("cannot add element to stored queries graph %s", element.Graph))
+			continue
+		}
 
 		// create a BulkAdd stream per graph
 		// close and switch when a new graph is encountered
 		if element.Graph != graphName {
-			close(elementStream)
+			closeStream()
+			// look the graph up again for the next element
+			graphName = ""
 			gdb, err := server.getGraphDB(element.Graph)
 			if err != nil {
 				errorCount++
@@ -263,29 +502,46 @@ func (server *GripServer) BulkAdd(stream gripql.Edit_BulkAddServer) error {
 			}
 
 			graphName = element.Graph
-			elementStream = make(chan *gdbi.GraphElement, 100)
-
-			wg.Add(1)
-			go func() {
-				log.WithFields(log.Fields{"graph": element.Graph}).Info("BulkAdd: streaming elements to graph")
-				err := graph.BulkAdd(elementStream)
-				if err != nil {
-					log.WithFields(log.Fields{"graph": element.Graph, "error": err}).Error("BulkAdd: error")
-					// not a good representation of the true number of errors
-					errorCount++
-				}
-				wg.Done()
-			}()
+			current = graph
+			validator, validatorErr = server.schemaValidator(element.Graph)
+			validatorReported = false
+			pending = map[string]string{}
+			watched = server.changes.watching(element.Graph)
+			startStream()
+		}
+
+		if validatorErr != nil {
+			// the same error applies to every element of the graph
+			if validatorReported {
+				errorCount++
+			} else {
+				reject(validatorErr)
+				validatorReported = true
+			}
+			continue
+		}
+
+		if validator != nil && len(pending) >= maxPendingLabels {
+			closeStream()
+			wg.Wait()
+			pending = map[string]string{}
+			startStream()
 		}
 
 		if element.Vertex != nil {
 			err := element.Vertex.Validate()
+			if err == nil && validator != nil {
+				err = validator.ValidateVertex(element.Vertex)
+			}
 			if err != nil {
-				errorCount++
+				reject(err)
 				log.WithFields(log.Fields{"graph": element.Graph, "error": err}).Errorf("BulkAdd: vertex validation failed")
 			} else {
 				insertCount++
-				elementStream <- gdbi.NewGraphElement(element)
+				if validator != nil {
+					pending[element.Vertex.Gid] = element.Vertex.Label
+				}
+				write(&gripql.GraphElement{Graph: element.Graph, Vertex: element.Vertex})
 			}
 		}
 
@@ -294,20 +550,23 @@ func (server *GripServer) BulkAdd(stream gripql.Edit_BulkAddServer) error {
 				element.Edge.Gid = util.UUID()
 			}
 			err := element.Edge.Validate()
+			if err == nil && validator != nil {
+				err = validator.ValidateEdge(element.Edge, vertexLabels(current, pending))
+			}
 			if err != nil {
-				errorCount++
+				reject(err)
 				log.WithFields(log.Fields{"graph": element.Graph, "error": err}).Errorf("BulkAdd: edge validation failed")
 			} else {
 				insertCount++
-				elementStream <- gdbi.NewGraphElement(element)
+				write(&gripql.GraphElement{Graph: element.Graph, Edge: element.Edge})
 			}
 		}
 	}
 
-	close(elementStream)
+	closeStream()
 	wg.Wait()
 
-	return stream.SendAndClose(&gripql.BulkEditResult{InsertCount: insertCount, ErrorCount: errorCount})
+	return stream.SendAndClose(&gripql.BulkEditResult{InsertCount: insertCount, ErrorCount: errorCount, Errors: elementErrors})
 }
 
 // DeleteVertex deletes a vertex from the server
@@ -315,6 +574,9 @@ func (server *GripServer) DeleteVertex(ctx context.Context, elem *gripql.Element
 	if isSchema(elem.Graph) {
 		return nil, fmt.Errorf("unable to delete vertex from graph schema; use AddSchema")
 	}
+	if isStoredQueries(elem.Graph) {
+		return nil, fmt.Errorf("unable to delete vertex from stored queries; use DeleteStoredQuery")
+	}
 	gdb, err := server.getGraphDB(elem.Graph)
 	if err != nil {
 		return nil, err
@@ -323,10 +585,16 @@ func (server *GripServer) DeleteVertex(ctx context.Context, elem *gripql.Element
 	if err != nil {
 		return nil, err
 	}
+	// the edges of the vertex are deleted with it
+	var events []*gripql.GraphEvent
+	if server.changes.watching(elem.Graph) {
+		events = removeEvents(ctx, elem.Graph, graph, elem.Id)
+	}
 	err = graph.DelVertex(elem.Id)
 	if err != nil {
 		return nil, err
 	}
+	server.changes.publish(events...)
 	return &gripql.EditResult{Id: elem.Id}, nil
 }
 
@@ -335,6 +603,9 @@ func (server *GripServer) DeleteEdge(ctx context.Context, elem *gripql.ElementID
 	if isSchema(elem.Graph) {
 		return nil, fmt.Errorf("unable to delete edge from graph schema; use AddSchema")
 	}
+	if isStoredQueries(elem.Graph) {
+		return nil, fmt.Errorf("unable to delete edge from stored queries; use DeleteStoredQuery")
+	}
 	gdb, err := server.getGraphDB(elem.Graph)
 	if err != nil {
 		return nil, err
@@ -343,10 +614,19 @@ func (server *GripServer) DeleteEdge(ctx context.Context, elem *gripql.ElementID
 	if err != nil {
 		return nil, err
 	}
+	var event *gripql.GraphEvent
+	if server.changes.watching(elem.Graph) {
+		if e := graph.GetEdge(elem.Id, true); e != nil {
+			event = &gripql.GraphEvent{Graph: elem.Graph, Type: gripql.EventType_REMOVED, Edge: e.ToEdge()}
+		}
+	}
 	err = graph.DelEdge(elem.Id)
 	if err != nil {
 		return nil, err
 	}
+	if event != nil {
+		server.changes.publish(event)
+	}
 	return &gripql.EditResult{Id: elem.Id}, nil
 }
 
@@ -363,7 +643,7 @@ func (server *GripServer) AddIndex(ctx context.Context, idx *gripql.IndexID) (*g
 	if err != nil {
 		return nil, err
 	}
-	err = graph.AddVertexIndex(idx.Label, idx.Field)
+	err = graph.AddVertexIndex(idx.Label, idx.Field, idx.Type)
 	if err != nil {
 		return nil, err
 	}
@@ -433,7 +713,7 @@ func (server *GripServer) GetSchema(ctx context.Context, elem *gripql.GraphID) (
 	if !server.graphExists(elem.Graph) {
 		return nil, status.Errorf(codes.NotFound, fmt.Sprintf("graph %s: not found", elem.Graph))
 	}
-	schema, ok := server.schemas[elem.Graph]
+	schema, ok := server.getSchema(elem.Graph)
 	if !ok {
 		if server.conf.Server.AutoBuildSchemas {
 			return nil, status.Errorf(codes.Unavailable, fmt.Sprintf("graph %s: schema not available; try again later", elem.Graph))
@@ -471,7 +751,7 @@ func (server *GripServer) AddSchema(ctx context.Context, req *gripql.Graph) (*gr
 	if err != nil {
 		return nil, fmt.Errorf("failed to store new schema: %v", err)
 	}
-	server.schemas[req.Graph] = req
+	server.setSchema(req.Graph, req)
 	return &gripql.EditResult{Id: req.Graph}, nil
 }
 
@@ -0,0 +1,204 @@
+package server
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/bmeg/grip/gdbi"
+	"github.com/bmeg/grip/gripql"
+	"github.com/bmeg/grip/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// number of events buffered for each watcher
+const watchBufferSize = 1000
+
+// how long publishing waits for a watcher with a full buffer. Writes to a
+// watched graph are slowed down by a slow watcher for up to this long, after
+// which the watcher is disconnected
+const watchSendTimeout = 5 * time.Second
+
+// number of elements BulkAdd writes to a watched graph before publishing
+// their events
+const watchBatchSize = 100
+
+// number of concurrent lookups used to tell added elements from updated ones
+const watchLookupWorkers = 8
+
+// watcher is a client watching a graph. done is closed when the watcher is
+// removed, so publishers stop sending to it
+type watcher struct {
+	events chan *gripql.GraphEvent
+	done   chan struct{}
+}
+
+// changeFeed sends the edits made to graphs to the clients watching them
+type changeFeed struct {
+	mu       sync.Mutex
+	watchers map[string]map[*watcher]bool
+}
+
+func newChangeFeed() *changeFeed {
+	return &changeFeed{watchers: map[string]map[*watcher]bool{}}
+}
+
+func (f *changeFeed) watch(graph string) *watcher {
+	w := &watcher{events: make(chan *gripql.GraphEvent, watchBufferSize), done: make(chan struct{})}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, ok := f.watchers[graph]; !ok {
+		f.watchers[graph] = map[*watcher]bool{}
+	}
+	f.watchers[graph][w] = true
+	return w
+}
+
+func (f *changeFeed) unwatch(graph string, w *watcher) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if ws, ok := f.watchers[graph]; ok && ws[w] {
+		delete(ws, w)
+		close(w.done)
+		if len(ws) == 0 {
+			delete(f.watchers, graph)
+		}
+	}
+}
+
+// watching returns true if a client is watching the graph, so edits only
+// look up the previous state of elements when an event will be sent
+func (f *changeFeed) watching(graph string) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.watchers[graph]) > 0
+}
+
+// publish sends events to the watchers of their graphs, in order. A watcher
+// whose buffer is full holds up the publisher for up to watchSendTimeout,
+// and is then disconnected
+func (f *changeFeed) publish(events ...*gripql.GraphEvent) {
+	for _, ev := range events {
+		f.mu.Lock()
+		ws := make([]*watcher, 0, len(f.watchers[ev.Graph]))
+		for w := range f.watchers[ev.Graph] {
+			ws = append(ws, w)
+		}
+		f.mu.Unlock()
+		for _, w := range ws {
+			select {
+			case w.events <- ev:
+				continue
+			case <-w.done:
+				continue
+			default:
+			}
+			timer := time.NewTimer(watchSendTimeout)
+			select {
+			case w.events <- ev:
+			case <-w.done:
+			case <-timer.C:
+				log.WithFields(log.Fields{"graph": ev.Graph}).Error("Watch: client fell behind, disconnecting")
+				f.unwatch(ev.Graph, w)
+			}
+			timer.Stop()
+		}
+	}
+}
+
+// elementEvent describes writing an element, which is an update if the
+// element exists
+func elementEvent(graph string, gdb gdbi.GraphInterface, elem *gripql.GraphElement) *gripql.GraphEvent {
+	if elem.Vertex != nil {
+		return vertexEvent(graph, gdb, elem.Vertex)
+	}
+	return edgeEvent(graph, gdb, elem.Edge)
+}
+
+// elementEvents describes writing a batch of elements, looking them up
+// concurrently
+func elementEvents(graph string, gdb gdbi.GraphInterface, elems []*gripql.GraphElement) []*gripql.GraphEvent {
+	events := make([]*gripql.GraphEvent, len(elems))
+	next := make(chan int, len(elems))
+	for i := range elems {
+		next <- i
+	}
+	close(next)
+	wg := &sync.WaitGroup{}
+	for w := 0; w < watchLookupWorkers && w < len(elems); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				events[i] = elementEvent(graph, gdb, elems[i])
+			}
+		}()
+	}
+	wg.Wait()
+	return events
+}
+
+// vertexEvent describes adding v, which is an update if the vertex exists
+func vertexEvent(graph string, gdb gdbi.GraphInterface, v *gripql.Vertex) *gripql.GraphEvent {
+	t := gripql.EventType_ADDED
+	if gdb.GetVertex(v.Gid, false) != nil {
+		t = gripql.EventType_UPDATED
+	}
+	return &gripql.GraphEvent{Graph: graph, Type: t, Vertex: v}
+}
+
+// edgeEvent describes adding e, which is an update if the edge exists
+func edgeEvent(graph string, gdb gdbi.GraphInterface, e *gripql.Edge) *gripql.GraphEvent {
+	t := gripql.EventType_ADDED
+	if gdb.GetEdge(e.Gid, false) != nil {
+		t = gripql.EventType_UPDATED
+	}
+	return &gripql.GraphEvent{Graph: graph, Type: t, Edge: e}
+}
+
+// removeEvents describes deleting a vertex and the edges that are deleted
+// with it
+func removeEvents(ctx context.Context, graph string, gdb gdbi.GraphInterface, id string) []*gripql.GraphEvent {
+	v := gdb.GetVertex(id, true)
+	if v == nil {
+		return nil
+	}
+	events := []*gripql.GraphEvent{}
+	seen := map[string]bool{}
+	for _, get := range []func(context.Context, chan gdbi.ElementLookup, bool, bool, []string) chan gdbi.ElementLookup{gdb.GetOutEdgeChannel, gdb.GetInEdgeChannel} {
+		req := make(chan gdbi.ElementLookup, 1)
+		req <- gdbi.ElementLookup{ID: id}
+		close(req)
+		for r := range get(ctx, req, true, false, nil) {
+			if r.Edge == nil || seen[r.Edge.ID] {
+				continue
+			}
+			seen[r.Edge.ID] = true
+			events = append(events, &gripql.GraphEvent{Graph: graph, Type: gripql.EventType_REMOVED, Edge: r.Edge.ToEdge()})
+		}
+	}
+	return append(events, &gripql.GraphEvent{Graph: graph, Type: gripql.EventType_REMOVED, Vertex: v.ToVertex()})
+}
+
+// Watch streams the vertices and edges that are added, updated or removed
+// from a graph, until the client disconnects
+func (server *GripServer) Watch(elem *gripql.GraphID, srv gripql.Query_WatchServer) error {
+	if !server.graphExists(elem.Graph) {
+		return status.Errorf(codes.NotFound, "graph %s not found", elem.Graph)
+	}
+	w := server.changes.watch(elem.Graph)
+	defer server.changes.unwatch(elem.Graph, w)
+	for {
+		select {
+		case <-srv.Context().Done():
+			return nil
+		case <-w.done:
+			return status.Errorf(codes.ResourceExhausted, "too many events were not received")
+		case ev := <-w.events:
+			if err := srv.Send(ev); err != nil {
+				return err
+			}
+		}
+	}
+}
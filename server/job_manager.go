@@ -2,6 +2,7 @@ package server
 
 import (
 	"context"
+	"time"
 
 	"github.com/bmeg/grip/engine"
 	"github.com/bmeg/grip/engine/pipeline"
@@ -9,6 +10,9 @@ import (
 	"github.com/bmeg/grip/gripql"
 	"github.com/bmeg/grip/jobstorage"
 	"github.com/bmeg/grip/log"
+	"github.com/bmeg/grip/util/arrow"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func (server *GripServer) Submit(ctx context.Context, query *gripql.GraphQuery) (*gripql.QueryJob, error) {
@@ -32,13 +36,22 @@ func (server *GripServer) Submit(ctx context.Context, query *gripql.GraphQuery)
 	man := engine.NewManager(server.conf.Server.WorkDir)
 	bufsize := 5000 //make this configurable?
 
-	res := pipeline.Start(context.Background(), pipe, man, bufsize, nil, nil)
+	jctx, cancel := gdbi.WithQueryErrors(context.Background())
+	jctx = server.withParallelism(jctx, query)
+	res := pipeline.Start(jctx, pipe, man, bufsize, nil, nil)
 	jobID, err := server.jStorage.Spool(query.Graph,
 		&jobstorage.Stream{
-			DataType:  dataType,
-			MarkTypes: markTypes,
-			Pipe:      res,
-			Query:     query.Query,
+			DataType:       dataType,
+			MarkTypes:      markTypes,
+			Pipe:           res,
+			Query:          query.Query,
+			GraphTimestamp: graph.GetTimestamp(),
+			Parallelism:    query.Parallelism,
+			PreserveOrder:  query.PreserveOrder,
+			Err: func() error {
+				defer cancel()
+				return gdbi.QueryError(jctx)
+			},
 		})
 	return &gripql.QueryJob{
 		Id:    jobID,
@@ -77,6 +90,18 @@ func (server *GripServer) ListJobs(graph *gripql.GraphID, srv gripql.Job_ListJob
 }
 
 func (server *GripServer) SearchJobs(query *gripql.GraphQuery, srv gripql.Job_SearchJobsServer) error {
+	if server.conf.Server.JobRetention.InvalidateOnChange {
+		// drop results computed against an older version of the graph
+		// before offering them as a match
+		policy := jobstorage.RetentionPolicy{InvalidateOnChange: true}
+		removed, err := server.jStorage.Collect(query.Graph, policy, server.graphTimestamp)
+		if err != nil {
+			return err
+		}
+		for i := range removed {
+			log.WithFields(log.Fields{"graph": i.Graph, "job": i.Id}).Info("Removed job")
+		}
+	}
 	stream, err := server.jStorage.Search(query.Graph, query.Query)
 	if err != nil {
 		return err
@@ -90,7 +115,7 @@ func (server *GripServer) SearchJobs(query *gripql.GraphQuery, srv gripql.Job_Se
 func (server *GripServer) ViewJob(job *gripql.QueryJob, srv gripql.Job_ViewJobServer) error {
 	stream, err := server.jStorage.Stream(context.Background(), job.Graph, job.Id)
 	if err != nil {
-		return nil
+		return status.Error(codes.FailedPrecondition, err.Error())
 	}
 	gdb, err := server.getGraphDB(job.Graph)
 	if err != nil {
@@ -100,6 +125,16 @@ func (server *GripServer) ViewJob(job *gripql.QueryJob, srv gripql.Job_ViewJobSe
 	if err != nil {
 		return err
 	}
+	if job.Format == gripql.ResultFormat_ARROW {
+		res := make(chan *gripql.QueryResult, 100)
+		go func() {
+			defer close(res)
+			for o := range stream.Pipe {
+				res <- pipeline.Convert(graph, stream.DataType, stream.MarkTypes, o)
+			}
+		}()
+		return arrow.Send(res, srv.Send)
+	}
 	for o := range stream.Pipe {
 		res := pipeline.Convert(graph, stream.DataType, stream.MarkTypes, o)
 		srv.Send(res)
@@ -117,13 +152,15 @@ func (server *GripServer) ResumeJob(query *gripql.ExtendQuery, srv gripql.Job_Re
 	if err != nil {
 		return err
 	}
-	ctx, cancel := context.WithCancel(context.Background())
+	ctx, cancel := gdbi.WithQueryErrors(context.Background())
 	defer cancel()
 
 	stream, err := server.jStorage.Stream(ctx, query.Graph, query.SrcId)
 	if err != nil {
-		return err
+		return status.Error(codes.FailedPrecondition, err.Error())
 	}
+	// the resumed steps run with the workers of the job's query
+	ctx = server.withParallelism(ctx, &gripql.GraphQuery{Parallelism: stream.Parallelism, PreserveOrder: stream.PreserveOrder})
 	compiler := graph.Compiler()
 	log.Infof("Compiling resume pipeline: %s", stream.DataType)
 	pipe, err := compiler.Compile(query.Query, &gdbi.CompileOptions{PipelineExtension: stream.DataType, ExtensionMarkTypes: stream.MarkTypes})
@@ -135,9 +172,63 @@ func (server *GripServer) ResumeJob(query *gripql.ExtendQuery, srv gripql.Job_Re
 		}()
 		return err
 	}
-	res := pipeline.Resume(context.Background(), pipe, server.conf.Server.WorkDir, stream.Pipe, cancel)
+	res := pipeline.Resume(ctx, pipe, server.conf.Server.WorkDir, stream.Pipe, cancel)
 	for o := range res {
 		srv.Send(o)
 	}
+	if err := gdbi.QueryError(ctx); err != nil {
+		return queryStatus(err)
+	}
 	return nil
 }
+
+func (server *GripServer) CollectJobs(graph *gripql.GraphID, srv gripql.Job_CollectJobsServer) error {
+	stream, err := server.jStorage.Collect(graph.Graph, server.conf.Server.JobRetention, server.graphTimestamp)
+	if err != nil {
+		return err
+	}
+	// the jobs are removed even if the client goes away, so keep reading
+	// the stream after a failed send
+	var sendErr error
+	for i := range stream {
+		if sendErr == nil {
+			sendErr = srv.Send(i)
+		}
+	}
+	return sendErr
+}
+
+// collectJobs periodically applies the job retention policy to all graphs
+func (server *GripServer) collectJobs(ctx context.Context) {
+	ticker := time.NewTicker(time.Duration(server.conf.Server.JobRetention.Interval))
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			stream, err := server.jStorage.Collect("", server.conf.Server.JobRetention, server.graphTimestamp)
+			if err != nil {
+				log.Errorf("Collecting jobs: %s", err)
+				continue
+			}
+			for i := range stream {
+				log.WithFields(log.Fields{"graph": i.Graph, "job": i.Id}).Info("Removed job")
+			}
+		}
+	}
+}
+
+// graphTimestamp returns the current timestamp of a graph, or an empty string
+// if the graph no longer exists
+func (server *GripServer) graphTimestamp(graph string) string {
+	gdb, err := server.getGraphDB(graph)
+	if err != nil {
+		return ""
+	}
+	g, err := gdb.Graph(graph)
+	if err != nil {
+		return ""
+	}
+	return g.GetTimestamp()
+}
@@ -7,14 +7,19 @@ import (
 	"time"
 
 	"github.com/bmeg/grip/config"
+	"github.com/bmeg/grip/gdbi"
+	"github.com/bmeg/grip/gdbi/schema"
 	"github.com/bmeg/grip/gripper"
 	"github.com/bmeg/grip/gripql"
 	"github.com/bmeg/grip/log"
 	"github.com/bmeg/grip/util/rpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 var schemaSuffix = "__schema__"
 var mappingSuffix = "__mapping__"
+var storedQuerySuffix = "__queries__"
 
 func isSchema(graphName string) bool {
 	return strings.HasSuffix(graphName, schemaSuffix)
@@ -24,6 +29,70 @@ func isMapping(graphName string) bool {
 	return strings.HasSuffix(graphName, mappingSuffix)
 }
 
+func isStoredQueries(graphName string) bool {
+	return strings.HasSuffix(graphName, storedQuerySuffix)
+}
+
+// getSchema returns the cached schema of a graph
+func (server *GripServer) getSchema(graph string) (*gripql.Graph, bool) {
+	server.schemaMu.RLock()
+	defer server.schemaMu.RUnlock()
+	s, ok := server.schemas[graph]
+	return s, ok
+}
+
+// setSchema caches the schema of a graph, replacing the validator built
+// from the previous schema
+func (server *GripServer) setSchema(graph string, s *gripql.Graph) {
+	server.schemaMu.Lock()
+	defer server.schemaMu.Unlock()
+	server.schemas[graph] = s
+	delete(server.validators, graph)
+}
+
+// schemaValidator returns the validator for writes to a graph, or nil if the
+// graph schema is not enforced. The validator is built once for each version
+// of the schema
+func (server *GripServer) schemaValidator(graph string) (*schema.Validator, error) {
+	conf, ok := server.conf.Server.SchemaEnforcement[graph]
+	if !ok {
+		return nil, nil
+	}
+	server.schemaMu.RLock()
+	v, ok := server.validators[graph]
+	server.schemaMu.RUnlock()
+	if ok {
+		return v, nil
+	}
+	server.schemaMu.Lock()
+	defer server.schemaMu.Unlock()
+	if v, ok := server.validators[graph]; ok {
+		return v, nil
+	}
+	graphSchema, ok := server.schemas[graph]
+	if !ok {
+		return nil, status.Errorf(codes.FailedPrecondition, "graph %s: schema enforcement enabled but schema not found; use AddSchema", graph)
+	}
+	v = schema.NewValidator(graphSchema, conf)
+	server.validators[graph] = v
+	return v, nil
+}
+
+// vertexLabels looks up vertex labels in the graph, after checking the labels
+// of vertices that may not have been written yet
+func vertexLabels(graph gdbi.GraphInterface, pending map[string]string) schema.VertexLabelFunc {
+	return func(gid string) (string, bool) {
+		if label, ok := pending[gid]; ok {
+			return label, true
+		}
+		v := graph.GetVertex(gid, false)
+		if v == nil {
+			return "", false
+		}
+		return v.Label, true
+	}
+}
+
 func (server *GripServer) getGraph(graph string) (*gripql.Graph, error) {
 
 	conn, err := gripql.Connect(rpc.ConfigWithDefaults(server.conf.Server.RPCAddress()), true)
@@ -57,10 +126,10 @@ func (server *GripServer) buildSchemas(ctx context.Context) {
 				return
 
 			default:
-				if isSchema(name) {
+				if isSchema(name) || isStoredQueries(name) {
 					continue
 				}
-				if _, ok := server.schemas[name]; ok {
+				if _, ok := server.getSchema(name); ok {
 					log.WithFields(log.Fields{"graph": name}).Debug("skipping build; cached schema found")
 					continue
 				}
@@ -72,7 +141,7 @@ func (server *GripServer) buildSchemas(ctx context.Context) {
 					if err != nil {
 						log.WithFields(log.Fields{"graph": name, "error": err}).Error("failed to store graph schema")
 					}
-					server.schemas[name] = schema
+					server.setSchema(name, schema)
 				} else {
 					log.WithFields(log.Fields{"graph": name, "error": err}).Error("failed to build graph schema")
 				}
@@ -0,0 +1,211 @@
+package server
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/bmeg/grip/engine/pipeline"
+	"github.com/bmeg/grip/gdbi"
+	"github.com/bmeg/grip/gripql"
+	"github.com/bmeg/grip/jobstorage"
+	"github.com/bmeg/grip/log"
+)
+
+// maximum number of statement prefixes tracked for automatic caching
+const maxTrackedPrefixes = 10000
+
+// prefixTracker counts how often statement prefixes are seen, so popular
+// prefixes can be submitted as jobs
+type prefixTracker struct {
+	mu     sync.Mutex
+	counts map[string]int
+	// ids of the jobs running for submitted prefixes
+	pending map[string]string
+}
+
+func newPrefixTracker() *prefixTracker {
+	return &prefixTracker{counts: map[string]int{}, pending: map[string]string{}}
+}
+
+// cacheableQuery returns true if any prefix of the query can be evaluated
+// separately from the rest of the query
+func cacheableQuery(query []*gripql.GraphStatement) bool {
+	if len(query) < 2 {
+		return false
+	}
+	for _, s := range query {
+		switch s.GetStatement().(type) {
+		case *gripql.GraphStatement_Mark, *gripql.GraphStatement_Jump:
+			return false
+		}
+	}
+	return true
+}
+
+// cachedPrefix finds the completed job with the longest statement prefix
+// matching query that was run against the current version of the graph
+func (server *GripServer) cachedPrefix(graph string, timestamp string, query []*gripql.GraphStatement) *gripql.JobStatus {
+	jobs, err := server.jStorage.Search(graph, query)
+	if err != nil {
+		return nil
+	}
+	var out *gripql.JobStatus
+	for j := range jobs {
+		if j.State != gripql.JobState_COMPLETE || j.GraphTimestamp != timestamp {
+			continue
+		}
+		if len(j.Query) > len(query) {
+			continue
+		}
+		if out == nil || len(j.Query) > len(out.Query) {
+			out = j
+		}
+	}
+	return out
+}
+
+// resumeCached evaluates a traversal starting from the results of a cached job.
+// It returns false if there is no usable job, in which case nothing has been
+// sent.
+func (server *GripServer) resumeCached(ctx context.Context, graph gdbi.GraphInterface, query *gripql.GraphQuery) (<-chan *gripql.QueryResult, bool) {
+	job := server.cachedPrefix(query.Graph, graph.GetTimestamp(), query.Query)
+	if job == nil {
+		return nil, false
+	}
+	sctx, cancel := context.WithCancel(ctx)
+	stream, err := server.jStorage.Stream(sctx, query.Graph, job.Id)
+	if err != nil {
+		cancel()
+		return nil, false
+	}
+	drain := func() {
+		cancel()
+		go func() {
+			for range stream.Pipe {
+			}
+		}()
+	}
+	if stream.GraphTimestamp != graph.GetTimestamp() {
+		drain()
+		return nil, false
+	}
+
+	suffix := query.Query[len(job.Query):]
+	if len(suffix) == 0 {
+		out := make(chan *gripql.QueryResult, 100)
+		go func() {
+			defer close(out)
+			defer cancel()
+			for t := range stream.Pipe {
+				if !t.IsSignal() {
+					out <- pipeline.Convert(graph, stream.DataType, stream.MarkTypes, t)
+				}
+			}
+		}()
+		log.WithFields(log.Fields{"graph": query.Graph, "job": job.Id}).Debug("Traversal using cached job")
+		return out, true
+	}
+
+	compiler := graph.Compiler()
+	pipe, err := compiler.Compile(suffix, &gdbi.CompileOptions{PipelineExtension: stream.DataType, ExtensionMarkTypes: stream.MarkTypes})
+	if err != nil {
+		drain()
+		return nil, false
+	}
+	log.WithFields(log.Fields{"graph": query.Graph, "job": job.Id, "prefix": len(job.Query)}).Debug("Traversal resuming cached job")
+	return pipeline.Resume(sctx, pipe, server.conf.Server.WorkDir, stream.Pipe, cancel), true
+}
+
+// trackPrefixes counts the statement prefixes of a traversal, and submits the
+// longest prefix that has been seen often enough as a job. It looks up jobs,
+// so it is run in the background of the traversal
+func (server *GripServer) trackPrefixes(graph gdbi.GraphInterface, query *gripql.GraphQuery) {
+	threshold := server.conf.Server.QueryCache.AutoCacheThreshold
+	if threshold <= 0 {
+		return
+	}
+	minPrefix := server.conf.Server.QueryCache.MinPrefix
+	if minPrefix < 2 {
+		minPrefix = 2
+	}
+	checksums, err := jobstorage.TraversalChecksum(query.Query)
+	if err != nil {
+		return
+	}
+	timestamp := graph.GetTimestamp()
+
+	keys := make([]string, len(query.Query))
+	for i := minPrefix; i < len(query.Query); i++ {
+		keys[i] = query.Graph + ":" + timestamp + ":" + strings.Join(checksums[:i], ",")
+	}
+
+	// forget prefixes whose jobs are done, so they are counted again. Once
+	// the job completes the prefix is served from the cache, and once it is
+	// removed by the retention policy it can be submitted again
+	t := server.prefixes
+	t.mu.Lock()
+	jobs := map[string]string{}
+	for _, key := range keys[minPrefix:] {
+		if id, ok := t.pending[key]; ok {
+			jobs[key] = id
+		}
+	}
+	t.mu.Unlock()
+	done := []string{}
+	for key, id := range jobs {
+		st, err := server.jStorage.Status(query.Graph, id)
+		if err != nil || (st.State != gripql.JobState_RUNNING && st.State != gripql.JobState_QUEUED) {
+			done = append(done, key)
+		}
+	}
+
+	t.mu.Lock()
+	for _, key := range done {
+		delete(t.pending, key)
+	}
+	if len(t.counts) > maxTrackedPrefixes {
+		t.counts = map[string]int{}
+	}
+	if len(t.pending) > maxTrackedPrefixes {
+		t.pending = map[string]string{}
+	}
+	submit := 0
+	for i := len(query.Query) - 1; i >= minPrefix; i-- {
+		key := keys[i]
+		if _, ok := t.pending[key]; ok {
+			break
+		}
+		t.counts[key]++
+		if t.counts[key] >= threshold && submit == 0 {
+			submit = i
+			// reserve the prefix until the job is submitted
+			t.pending[key] = ""
+			delete(t.counts, key)
+		}
+	}
+	t.mu.Unlock()
+
+	if submit == 0 {
+		return
+	}
+	if job := server.cachedPrefix(query.Graph, timestamp, query.Query[:submit]); job != nil && len(job.Query) == submit {
+		t.mu.Lock()
+		delete(t.pending, keys[submit])
+		t.mu.Unlock()
+		return
+	}
+	res, err := server.Submit(context.Background(), &gripql.GraphQuery{Graph: query.Graph, Query: query.Query[:submit]})
+	t.mu.Lock()
+	if err != nil {
+		delete(t.pending, keys[submit])
+	} else {
+		t.pending[keys[submit]] = res.Id
+	}
+	t.mu.Unlock()
+	if err != nil {
+		log.WithFields(log.Fields{"graph": query.Graph, "error": err}).Error("Caching query prefix")
+		return
+	}
+	log.WithFields(log.Fields{"graph": query.Graph, "job": res.Id, "prefix": submit}).Info("Caching query prefix")
+}
@@ -10,10 +10,12 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/bmeg/grip/config"
 	"github.com/bmeg/grip/gdbi"
+	"github.com/bmeg/grip/gdbi/schema"
 	"github.com/bmeg/grip/gripql"
 	"github.com/bmeg/grip/jobstorage"
 	"github.com/bmeg/grip/log"
@@ -34,6 +36,7 @@ import (
 	_ "github.com/bmeg/grip/kvi/pebbledb" // import so level will register itself
 	"github.com/bmeg/grip/mongo"
 	"github.com/bmeg/grip/psql"
+	"github.com/bmeg/grip/sharded"
 )
 
 // GripServer is a GRPC based grip server
@@ -42,15 +45,24 @@ type GripServer struct {
 	gripql.UnimplementedEditServer
 	gripql.UnimplementedJobServer
 	gripql.UnimplementedConfigureServer
+	gripql.UnimplementedStoredQueryServer
 	dbs      map[string]gdbi.GraphDB  //graph database drivers
 	graphMap map[string]string        //mapping from graph name to graph database driver
 	conf     *config.Config           //global configuration
 	schemas  map[string]*gripql.Graph //cached schemas
+	// validators built from the cached schemas, for enforced graphs
+	validators map[string]*schema.Validator
+	schemaMu   sync.RWMutex
+	// serializes writes that create elements, so checking that an id is free
+	// and writing the element happen together
+	createMu sync.Mutex
 	mappings map[string]*gripql.Graph //cached gripper graph mappings
 	plugins  map[string]*Plugin
 	sources  map[string]gripper.GRIPSourceClient
 	baseDir  string
 	jStorage jobstorage.JobStorage
+	prefixes *prefixTracker
+	changes  *changeFeed
 }
 
 // NewGripServer initializes a GRPC server to connect to the graph store
@@ -91,12 +103,15 @@ func NewGripServer(conf *config.Config, baseDir string, drivers map[string]gdbi.
 	}
 
 	server := &GripServer{
-		dbs:      gdbs,
-		conf:     conf,
-		schemas:  schemas,
-		mappings: map[string]*gripql.Graph{},
-		plugins:  map[string]*Plugin{},
-		sources:  sources,
+		dbs:        gdbs,
+		conf:       conf,
+		schemas:    schemas,
+		validators: map[string]*schema.Validator{},
+		mappings:   map[string]*gripql.Graph{},
+		plugins:    map[string]*Plugin{},
+		sources:    sources,
+		prefixes:   newPrefixTracker(),
+		changes:    newChangeFeed(),
 	}
 
 	if conf.Default == "" {
@@ -136,6 +151,8 @@ func StartDriver(d config.DriverConfig, sources map[string]gripper.GRIPSourceCli
 		return esql.NewGraphDB(*d.ExistingSQL)
 	} else if d.Gripper != nil {
 		return gripper.NewGDBFromConfig(d.Gripper.Graph, d.Gripper.Mapping, sources)
+	} else if d.Sharded != nil {
+		return sharded.NewGraphDB(*d.Sharded)
 	}
 	return nil, fmt.Errorf("unknown driver: %#v", d)
 }
@@ -229,7 +246,13 @@ func (server *GripServer) Serve(pctx context.Context) error {
 			gripql.DirectUnaryInterceptor(unaryAuthInt),
 			gripql.DirectStreamInterceptor(streamAuthInt),
 		)
-		handler, err := setup(gripql.WrapClient(queryClient, writeClient, nil, nil))
+		client := gripql.WrapClient(queryClient, writeClient, nil, nil)
+		client.StoredQueryC = gripql.NewStoredQueryDirectClient(
+			server,
+			gripql.DirectUnaryInterceptor(unaryAuthInt),
+			gripql.DirectStreamInterceptor(streamAuthInt),
+		)
+		handler, err := setup(client)
 		if err == nil {
 			log.Infof("Plugin added to /%s/", name)
 			prefix := fmt.Sprintf("/%s/", name)
@@ -347,6 +370,18 @@ func (server *GripServer) Serve(pctx context.Context) error {
 		}
 	}
 
+	// Register StoredQuery Service
+	gripql.RegisterStoredQueryServer(grpcServer, server)
+	err = gripql.RegisterStoredQueryHandlerClient(ctx, grpcMux,
+		gripql.NewStoredQueryDirectClient(
+			server,
+			gripql.DirectUnaryInterceptor(unaryAuthInt),
+			gripql.DirectStreamInterceptor(streamAuthInt),
+		))
+	if err != nil {
+		return fmt.Errorf("registering stored query endpoint: %v", err)
+	}
+
 	if !server.conf.Server.NoJobs {
 		gripql.RegisterJobServer(grpcServer, server)
 		err = gripql.RegisterJobHandlerClient(ctx, grpcMux,
@@ -358,8 +393,18 @@ func (server *GripServer) Serve(pctx context.Context) error {
 		if err != nil {
 			return fmt.Errorf("registering job endpoint: %v", err)
 		}
-		jobDir := filepath.Join(server.conf.Server.WorkDir, "jobs")
-		server.jStorage = jobstorage.NewFSJobStorage(jobDir)
+		if server.conf.Server.JobStorage.S3 != nil {
+			server.jStorage, err = jobstorage.NewS3JobStorage(*server.conf.Server.JobStorage.S3)
+			if err != nil {
+				return fmt.Errorf("starting job storage: %v", err)
+			}
+		} else {
+			jobDir := filepath.Join(server.conf.Server.WorkDir, "jobs")
+			server.jStorage = jobstorage.NewFSJobStorage(jobDir)
+		}
+		if server.conf.Server.JobRetention.IsSet() && server.conf.Server.JobRetention.Interval > 0 {
+			go server.collectJobs(ctx)
+		}
 	}
 
 	if server.conf.Server.EnablePlugins {
@@ -408,7 +453,7 @@ func (server *GripServer) Serve(pctx context.Context) error {
 				log.WithFields(log.Fields{"graph": graph}).Debug("Loading existing schema into cache")
 				schema, err := server.getGraph(graph)
 				if err == nil {
-					server.schemas[strings.TrimSuffix(graph, schemaSuffix)] = schema
+					server.setSchema(strings.TrimSuffix(graph, schemaSuffix), schema)
 				}
 			} else if isMapping(graph) {
 				log.WithFields(log.Fields{"graph": graph}).Debug("Loading existing mapping into cache")
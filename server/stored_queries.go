@@ -0,0 +1,206 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/bmeg/grip/gdbi"
+	"github.com/bmeg/grip/gripql"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// label of the vertices that hold stored queries
+const storedQueryLabel = "StoredQuery"
+
+// storedQueryGraph returns the graph holding the stored queries of a graph,
+// creating it if create is true
+func (server *GripServer) storedQueryGraph(graph string, create bool) (gdbi.GraphInterface, error) {
+	if !server.graphExists(graph) {
+		return nil, status.Errorf(codes.NotFound, "graph %s not found", graph)
+	}
+	name := graph + storedQuerySuffix
+	if !server.graphExists(name) {
+		if !create {
+			return nil, nil
+		}
+		// keep the stored queries with the graph they query
+		gdb, err := server.getGraphDB(graph)
+		if err != nil {
+			return nil, err
+		}
+		if err := gdb.AddGraph(name); err != nil {
+			return nil, fmt.Errorf("creating stored query graph for %s: %v", graph, err)
+		}
+		server.updateGraphMap()
+	}
+	gdb, err := server.getGraphDB(name)
+	if err != nil {
+		return nil, err
+	}
+	return gdb.Graph(name)
+}
+
+func storedQueryVertex(def *gripql.QueryDefinition) (*gripql.Vertex, error) {
+	data, err := protojson.Marshal(def)
+	if err != nil {
+		return nil, err
+	}
+	m := map[string]interface{}{}
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+	v := &gripql.Vertex{Gid: def.Name, Label: storedQueryLabel}
+	v.SetDataMap(m)
+	return v, nil
+}
+
+func vertexStoredQuery(v *gdbi.Vertex) (*gripql.QueryDefinition, error) {
+	data, err := json.Marshal(v.Data)
+	if err != nil {
+		return nil, err
+	}
+	def := &gripql.QueryDefinition{}
+	if err := protojson.Unmarshal(data, def); err != nil {
+		return nil, fmt.Errorf("reading stored query %s: %v", v.ID, err)
+	}
+	return def, nil
+}
+
+// zeroValues returns a value for each parameter, so a stored query can be
+// compiled before it is called
+func zeroValues(def *gripql.QueryDefinition) map[string]interface{} {
+	out := map[string]interface{}{}
+	for _, p := range def.Parameters {
+		if p.DefaultValue != nil {
+			continue
+		}
+		switch p.Type {
+		case gripql.FieldType_STRING:
+			out[p.Name] = ""
+		case gripql.FieldType_DATETIME:
+			out[p.Name] = "1970-01-01T00:00:00Z"
+		case gripql.FieldType_NUMERIC:
+			out[p.Name] = 0.0
+		case gripql.FieldType_BOOL:
+			out[p.Name] = false
+		case gripql.FieldType_ARRAY:
+			out[p.Name] = []interface{}{}
+		case gripql.FieldType_MAP:
+			out[p.Name] = map[string]interface{}{}
+		default:
+			out[p.Name] = ""
+		}
+	}
+	return out
+}
+
+// AddStoredQuery stores a named query for a graph, replacing any query with
+// the same name
+func (server *GripServer) AddStoredQuery(ctx context.Context, def *gripql.QueryDefinition) (*gripql.EditResult, error) {
+	if err := def.Validate(); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "AddStoredQuery: %v", err)
+	}
+	// check that the query compiles before storing it
+	gdb, err := server.getGraphDB(def.Graph)
+	if err != nil {
+		return nil, err
+	}
+	graph, err := gdb.Graph(def.Graph)
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "graph %s not found", def.Graph)
+	}
+	query, err := def.Bind(zeroValues(def))
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "AddStoredQuery: %v", err)
+	}
+	if _, err := graph.Compiler().Compile(query, nil); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "AddStoredQuery: %v", err)
+	}
+
+	queries, err := server.storedQueryGraph(def.Graph, true)
+	if err != nil {
+		return nil, err
+	}
+	v, err := storedQueryVertex(def)
+	if err != nil {
+		return nil, err
+	}
+	if err := queries.AddVertex([]*gdbi.Vertex{gdbi.NewElementFromVertex(v)}); err != nil {
+		return nil, fmt.Errorf("AddStoredQuery: %v", err)
+	}
+	return &gripql.EditResult{Id: def.Name}, nil
+}
+
+func (server *GripServer) getStoredQuery(graph, name string) (*gripql.QueryDefinition, error) {
+	queries, err := server.storedQueryGraph(graph, false)
+	if err != nil {
+		return nil, err
+	}
+	if queries != nil {
+		if v := queries.GetVertex(name, true); v != nil {
+			return vertexStoredQuery(v)
+		}
+	}
+	return nil, status.Errorf(codes.NotFound, "stored query %s not found in graph %s", name, graph)
+}
+
+// GetStoredQuery returns the definition of a stored query
+func (server *GripServer) GetStoredQuery(ctx context.Context, id *gripql.StoredQueryID) (*gripql.QueryDefinition, error) {
+	return server.getStoredQuery(id.Graph, id.Name)
+}
+
+// ListStoredQueries returns the stored queries of a graph
+func (server *GripServer) ListStoredQueries(ctx context.Context, id *gripql.GraphID) (*gripql.ListStoredQueriesResponse, error) {
+	queries, err := server.storedQueryGraph(id.Graph, false)
+	if err != nil {
+		return nil, err
+	}
+	out := &gripql.ListStoredQueriesResponse{Queries: []*gripql.QueryDefinition{}}
+	if queries == nil {
+		return out, nil
+	}
+	for v := range queries.GetVertexList(ctx, true) {
+		def, err := vertexStoredQuery(v)
+		if err != nil {
+			return nil, err
+		}
+		out.Queries = append(out.Queries, def)
+	}
+	sort.Slice(out.Queries, func(i, j int) bool {
+		return out.Queries[i].Name < out.Queries[j].Name
+	})
+	return out, nil
+}
+
+// DeleteStoredQuery removes a stored query
+func (server *GripServer) DeleteStoredQuery(ctx context.Context, id *gripql.StoredQueryID) (*gripql.EditResult, error) {
+	if _, err := server.getStoredQuery(id.Graph, id.Name); err != nil {
+		return nil, err
+	}
+	queries, err := server.storedQueryGraph(id.Graph, false)
+	if err != nil {
+		return nil, err
+	}
+	if err := queries.DelVertex(id.Name); err != nil {
+		return nil, fmt.Errorf("DeleteStoredQuery: %v", err)
+	}
+	return &gripql.EditResult{Id: id.Name}, nil
+}
+
+// RunStoredQuery runs a stored query with the parameters given, streaming
+// back the results
+func (server *GripServer) RunStoredQuery(call *gripql.StoredQueryCall, srv gripql.StoredQuery_RunStoredQueryServer) error {
+	def, err := server.getStoredQuery(call.Graph, call.Name)
+	if err != nil {
+		return err
+	}
+	query, err := def.Bind(call.Parameters.AsMap())
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "stored query %s: %v", call.Name, err)
+	}
+	return server.Traversal(&gripql.GraphQuery{Graph: call.Graph, Query: query}, srv)
+}
@@ -0,0 +1,611 @@
+package sharded
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bmeg/grip/engine/core"
+	"github.com/bmeg/grip/gdbi"
+	"github.com/bmeg/grip/gripql"
+	"github.com/bmeg/grip/log"
+	"github.com/bmeg/grip/util"
+	multierror "github.com/hashicorp/go-multierror"
+)
+
+const batchSize int = 100
+
+// Graph is the interface to a single graph spread across the shards
+type Graph struct {
+	shards []gripql.Client
+	addrs  []string
+	graph  string
+}
+
+// Compiler returns a query compiler that uses the graph
+func (g *Graph) Compiler() gdbi.Compiler {
+	return core.NewCompiler(g, core.IndexStartOptimize)
+}
+
+// GetTimestamp joins the timestamps of the shards, so it changes when any of
+// them changes
+func (g *Graph) GetTimestamp() string {
+	out := make([]string, len(g.shards))
+	for i, cli := range g.shards {
+		ts, err := cli.GetTimestamp(g.graph)
+		if err != nil {
+			log.WithFields(log.Fields{"error": err, "shard": g.addrs[i]}).Error("GetTimestamp")
+			continue
+		}
+		out[i] = ts.Timestamp
+	}
+	return strings.Join(out, ",")
+}
+
+func (g *Graph) shardOf(gid string) int {
+	return shardOf(gid, len(g.shards))
+}
+
+// edgeShards returns the shards holding an edge, which are the shards of its
+// vertices
+func (g *Graph) edgeShards(from, to string) []int {
+	a, b := g.shardOf(from), g.shardOf(to)
+	if a == b {
+		return []int{a}
+	}
+	return []int{a, b}
+}
+
+// query runs a traversal on a shard, passing each result to f
+func (g *Graph) query(ctx context.Context, shard int, q *gripql.Query, f func(*gripql.QueryResult)) error {
+	cl, err := g.shards[shard].QueryC.Traversal(ctx, &gripql.GraphQuery{Graph: g.graph, Query: q.Statements})
+	if err != nil {
+		return fmt.Errorf("shard %s: %v", g.addrs[shard], err)
+	}
+	for {
+		res, err := cl.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("shard %s: %v", g.addrs[shard], err)
+		}
+		f(res)
+	}
+}
+
+// queryShards runs traversals on several shards at once. f is called from
+// the goroutine of each shard
+func (g *Graph) queryShards(ctx context.Context, queries map[int]*gripql.Query, f func(shard int, res *gripql.QueryResult)) error {
+	var wg sync.WaitGroup
+	errs := make([]error, len(g.shards))
+	for shard, q := range queries {
+		wg.Add(1)
+		go func(shard int, q *gripql.Query) {
+			defer wg.Done()
+			errs[shard] = g.query(ctx, shard, q, func(res *gripql.QueryResult) { f(shard, res) })
+		}(shard, q)
+	}
+	wg.Wait()
+	var err *multierror.Error
+	for _, e := range errs {
+		if e != nil {
+			err = multierror.Append(err, e)
+		}
+	}
+	return err.ErrorOrNil()
+}
+
+// allShards returns the same query for every shard
+func (g *Graph) allShards(q *gripql.Query) map[int]*gripql.Query {
+	out := make(map[int]*gripql.Query, len(g.shards))
+	for i := range g.shards {
+		out[i] = q
+	}
+	return out
+}
+
+// byShard groups vertex ids by the shard holding them, dropping repeats
+func (g *Graph) byShard(ids []string) map[int][]string {
+	out := map[int][]string{}
+	seen := map[string]bool{}
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			s := g.shardOf(id)
+			out[s] = append(out[s], id)
+		}
+	}
+	return out
+}
+
+// getVertices fetches vertices from the shards holding them
+func (g *Graph) getVertices(ctx context.Context, ids []string) (map[string]*gdbi.Vertex, error) {
+	queries := map[int]*gripql.Query{}
+	for s, sIDs := range g.byShard(ids) {
+		queries[s] = gripql.V(sIDs...)
+	}
+	var mu sync.Mutex
+	out := make(map[string]*gdbi.Vertex, len(ids))
+	err := g.queryShards(ctx, queries, func(shard int, res *gripql.QueryResult) {
+		if v := res.GetVertex(); v != nil {
+			mu.Lock()
+			out[v.Gid] = gdbi.NewElementFromVertex(v)
+			mu.Unlock()
+		}
+	})
+	return out, err
+}
+
+// getEdges fetches the outgoing, or incoming, edges of vertices from the
+// shards holding the vertices
+func (g *Graph) getEdges(ctx context.Context, ids []string, out bool, edgeLabels []string) ([]*gdbi.Edge, error) {
+	queries := map[int]*gripql.Query{}
+	for s, sIDs := range g.byShard(ids) {
+		if out {
+			queries[s] = gripql.V(sIDs...).OutE(edgeLabels...)
+		} else {
+			queries[s] = gripql.V(sIDs...).InE(edgeLabels...)
+		}
+	}
+	var mu sync.Mutex
+	edges := []*gdbi.Edge{}
+	err := g.queryShards(ctx, queries, func(shard int, res *gripql.QueryResult) {
+		if e := res.GetEdge(); e != nil {
+			mu.Lock()
+			edges = append(edges, gdbi.NewElementFromEdge(e))
+			mu.Unlock()
+		}
+	})
+	return edges, err
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// Write methods
+////////////////////////////////////////////////////////////////////////////////
+
+// AddVertex adds vertices to the shards holding them
+func (g *Graph) AddVertex(vertices []*gdbi.Vertex) error {
+	stream := make(chan *gdbi.GraphElement, len(vertices))
+	for _, v := range vertices {
+		stream <- &gdbi.GraphElement{Vertex: v}
+	}
+	close(stream)
+	return g.BulkAdd(stream)
+}
+
+// AddEdge adds edges to the shards of their vertices
+func (g *Graph) AddEdge(edges []*gdbi.Edge) error {
+	stream := make(chan *gdbi.GraphElement, len(edges))
+	for _, e := range edges {
+		stream <- &gdbi.GraphElement{Edge: e}
+	}
+	close(stream)
+	return g.BulkAdd(stream)
+}
+
+// BulkAdd streams vertices and edges to the shards holding them
+func (g *Graph) BulkAdd(stream <-chan *gdbi.GraphElement) error {
+	var wg sync.WaitGroup
+	chans := make([]chan *gripql.GraphElement, len(g.shards))
+	errs := make([]error, len(g.shards))
+	for i := range g.shards {
+		chans[i] = make(chan *gripql.GraphElement, 100)
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = g.shards[i].BulkAdd(chans[i])
+			// keep reading after a failed load, so the other shards finish
+			for range chans[i] {
+			}
+		}(i)
+	}
+	for elem := range stream {
+		if elem.Vertex != nil {
+			chans[g.shardOf(elem.Vertex.ID)] <- &gripql.GraphElement{Graph: g.graph, Vertex: elem.Vertex.ToVertex()}
+		}
+		if elem.Edge != nil {
+			// both copies of the edge need the same id
+			if elem.Edge.ID == "" {
+				elem.Edge.ID = util.UUID()
+			}
+			e := elem.Edge.ToEdge()
+			for _, s := range g.edgeShards(e.From, e.To) {
+				chans[s] <- &gripql.GraphElement{Graph: g.graph, Edge: e}
+			}
+		}
+	}
+	for i := range chans {
+		close(chans[i])
+	}
+	wg.Wait()
+	var bulkErr *multierror.Error
+	for i, err := range errs {
+		if err != nil {
+			bulkErr = multierror.Append(bulkErr, fmt.Errorf("shard %s: %v", g.addrs[i], err))
+		}
+	}
+	return bulkErr.ErrorOrNil()
+}
+
+// DelVertex deletes a vertex, and the copies of its edges kept on the shards
+// of the other vertices of the edges. The copies are deleted before the
+// vertex, and are added back when deleting a copy or the vertex fails
+func (g *Graph) DelVertex(key string) error {
+	ctx := context.Background()
+	shard := g.shardOf(key)
+	outE, err := g.getEdges(ctx, []string{key}, true, nil)
+	if err != nil {
+		return fmt.Errorf("DelVertex: %v", err)
+	}
+	inE, err := g.getEdges(ctx, []string{key}, false, nil)
+	if err != nil {
+		return fmt.Errorf("DelVertex: %v", err)
+	}
+	type edgeCopy struct {
+		shard int
+		edge  *gdbi.Edge
+	}
+	deleted := []edgeCopy{}
+	rollback := func(err error) error {
+		var delErr *multierror.Error
+		delErr = multierror.Append(delErr, err)
+		for _, c := range deleted {
+			if err := g.shards[c.shard].AddEdge(g.graph, c.edge.ToEdge()); err != nil {
+				delErr = multierror.Append(delErr, fmt.Errorf("restoring edge %s: shard %s: %v", c.edge.ID, g.addrs[c.shard], err))
+			}
+		}
+		return fmt.Errorf("DelVertex: %v", delErr.ErrorOrNil())
+	}
+	for _, e := range append(outE, inE...) {
+		for _, s := range g.edgeShards(e.From, e.To) {
+			if s != shard {
+				if err := g.shards[s].DeleteEdge(g.graph, e.ID); err != nil {
+					return rollback(fmt.Errorf("shard %s: %v", g.addrs[s], err))
+				}
+				deleted = append(deleted, edgeCopy{s, e})
+			}
+		}
+	}
+	if err := g.shards[shard].DeleteVertex(g.graph, key); err != nil {
+		return rollback(fmt.Errorf("shard %s: %v", g.addrs[shard], err))
+	}
+	return nil
+}
+
+// DelEdge deletes an edge from the shards of its vertices
+func (g *Graph) DelEdge(key string) error {
+	e := g.GetEdge(key, false)
+	if e == nil {
+		return fmt.Errorf("edge %s not found", key)
+	}
+	for _, s := range g.edgeShards(e.From, e.To) {
+		if err := g.shards[s].DeleteEdge(g.graph, key); err != nil {
+			return fmt.Errorf("DelEdge: shard %s: %v", g.addrs[s], err)
+		}
+	}
+	return nil
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// Index methods
+////////////////////////////////////////////////////////////////////////////////
+
+// AddVertexIndex adds a vertex index on every shard
+func (g *Graph) AddVertexIndex(label string, field string, itype gripql.IndexType) error {
+	for i, cli := range g.shards {
+		_, err := cli.EditC.AddIndex(context.Background(), &gripql.IndexID{Graph: g.graph, Label: label, Field: field, Type: itype})
+		if err != nil {
+			return fmt.Errorf("AddVertexIndex: shard %s: %v", g.addrs[i], err)
+		}
+	}
+	return nil
+}
+
+// DeleteVertexIndex deletes a vertex index from every shard
+func (g *Graph) DeleteVertexIndex(label string, field string) error {
+	for i, cli := range g.shards {
+		_, err := cli.EditC.DeleteIndex(context.Background(), &gripql.IndexID{Graph: g.graph, Label: label, Field: field})
+		if err != nil {
+			return fmt.Errorf("DeleteVertexIndex: shard %s: %v", g.addrs[i], err)
+		}
+	}
+	return nil
+}
+
+// GetVertexIndexList lists the indices held by every shard. Nothing is
+// listed when a shard can't be read
+func (g *Graph) GetVertexIndexList() <-chan *gripql.IndexID {
+	o := make(chan *gripql.IndexID, 100)
+	go func() {
+		defer close(o)
+		type indexKey struct {
+			label, field string
+		}
+		counts := map[indexKey]int{}
+		indices := []*gripql.IndexID{}
+		failed := false
+		for i, cli := range g.shards {
+			res, err := cli.ListIndices(g.graph)
+			if err != nil {
+				log.WithFields(log.Fields{"error": err, "shard": g.addrs[i]}).Error("GetVertexIndexList")
+				failed = true
+				continue
+			}
+			for _, idx := range res.Indices {
+				k := indexKey{idx.Label, idx.Field}
+				if counts[k] == 0 {
+					indices = append(indices, idx)
+				}
+				counts[k]++
+			}
+		}
+		if failed {
+			return
+		}
+		for _, idx := range indices {
+			if counts[indexKey{idx.Label, idx.Field}] == len(g.shards) {
+				o <- idx
+			} else {
+				log.WithFields(log.Fields{"label": idx.Label, "field": idx.Field}).Warning("GetVertexIndexList: index is missing on some shards")
+			}
+		}
+	}()
+	return o
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// Read methods
+////////////////////////////////////////////////////////////////////////////////
+
+// GetVertex loads a vertex given an id. It returns nil if not found
+func (g *Graph) GetVertex(gid string, load bool) *gdbi.Vertex {
+	v, err := g.shards[g.shardOf(gid)].GetVertex(g.graph, gid)
+	if err != nil {
+		return nil
+	}
+	return gdbi.NewElementFromVertex(v)
+}
+
+// GetEdge loads an edge given an id. As edges are placed by their vertices,
+// every shard is asked for it. It returns nil if not found
+func (g *Graph) GetEdge(gid string, load bool) *gdbi.Edge {
+	for _, cli := range g.shards {
+		if e, err := cli.GetEdge(g.graph, gid); err == nil {
+			return gdbi.NewElementFromEdge(e)
+		}
+	}
+	return nil
+}
+
+// GetVertexList produces a channel of all vertices in the graph
+func (g *Graph) GetVertexList(ctx context.Context, load bool) <-chan *gdbi.Vertex {
+	o := make(chan *gdbi.Vertex, 100)
+	go func() {
+		defer close(o)
+		err := g.queryShards(ctx, g.allShards(gripql.V()), func(shard int, res *gripql.QueryResult) {
+			select {
+			case o <- gdbi.NewElementFromVertex(res.GetVertex()):
+			case <-ctx.Done():
+			}
+		})
+		if err != nil {
+			gdbi.ReportError(ctx, "GetVertexList", err)
+		}
+	}()
+	return o
+}
+
+// GetEdgeList produces a channel of all edges in the graph
+func (g *Graph) GetEdgeList(ctx context.Context, load bool) <-chan *gdbi.Edge {
+	o := make(chan *gdbi.Edge, 100)
+	go func() {
+		defer close(o)
+		err := g.queryShards(ctx, g.allShards(gripql.E()), func(shard int, res *gripql.QueryResult) {
+			e := res.GetEdge()
+			// the copy kept on the shard of the source vertex is listed
+			if g.shardOf(e.From) != shard {
+				return
+			}
+			select {
+			case o <- gdbi.NewElementFromEdge(e):
+			case <-ctx.Done():
+			}
+		})
+		if err != nil {
+			gdbi.ReportError(ctx, "GetEdgeList", err)
+		}
+	}()
+	return o
+}
+
+// VertexLabelScan produces a channel of all vertex ids where the vertex label matches `label`
+func (g *Graph) VertexLabelScan(ctx context.Context, label string) chan string {
+	o := make(chan string, 100)
+	go func() {
+		defer close(o)
+		q := gripql.V().HasLabel(label).Render("_gid")
+		err := g.queryShards(ctx, g.allShards(q), func(shard int, res *gripql.QueryResult) {
+			select {
+			case o <- res.GetRender().GetStringValue():
+			case <-ctx.Done():
+			}
+		})
+		if err != nil {
+			gdbi.ReportError(ctx, "VertexLabelScan", err)
+		}
+	}()
+	return o
+}
+
+// ListVertexLabels returns the vertex labels found on any shard
+func (g *Graph) ListVertexLabels() ([]string, error) {
+	return g.listLabels(func(res *gripql.ListLabelsResponse) []string { return res.VertexLabels })
+}
+
+// ListEdgeLabels returns the edge labels found on any shard
+func (g *Graph) ListEdgeLabels() ([]string, error) {
+	return g.listLabels(func(res *gripql.ListLabelsResponse) []string { return res.EdgeLabels })
+}
+
+func (g *Graph) listLabels(labels func(*gripql.ListLabelsResponse) []string) ([]string, error) {
+	seen := map[string]bool{}
+	out := []string{}
+	for i, cli := range g.shards {
+		res, err := cli.ListLabels(g.graph)
+		if err != nil {
+			return nil, fmt.Errorf("shard %s: %v", g.addrs[i], err)
+		}
+		for _, l := range labels(res) {
+			if !seen[l] {
+				seen[l] = true
+				out = append(out, l)
+			}
+		}
+	}
+	sort.Strings(out)
+	return out, nil
+}
+
+// GetVertexChannel is passed a channel of vertex ids and it produces a channel of vertices
+func (g *Graph) GetVertexChannel(ctx context.Context, reqChan chan gdbi.ElementLookup, load bool) chan gdbi.ElementLookup {
+	batches := gdbi.LookupBatcher(reqChan, batchSize, time.Microsecond)
+
+	o := make(chan gdbi.ElementLookup, 100)
+	go func() {
+		defer close(o)
+		for batch := range batches {
+			vertices, err := g.getVertices(ctx, lookupIDs(batch))
+			if err != nil {
+				gdbi.ReportError(ctx, "GetVertexChannel", err)
+				forwardSignals(batch, o)
+				continue
+			}
+			for _, req := range batch {
+				if req.IsSignal() {
+					o <- req
+				} else if v, ok := vertices[req.ID]; ok {
+					req.Vertex = v
+					o <- req
+				}
+			}
+		}
+	}()
+	return o
+}
+
+// adjacent looks up the edges of a batch of vertices, and the vertices at
+// their other end when vertices is set
+func (g *Graph) adjacent(ctx context.Context, step string, reqChan chan gdbi.ElementLookup, out bool, vertices bool, emitNull bool, edgeLabels []string) chan gdbi.ElementLookup {
+	batches := gdbi.LookupBatcher(reqChan, batchSize, time.Microsecond)
+
+	o := make(chan gdbi.ElementLookup, 100)
+	go func() {
+		defer close(o)
+		for batch := range batches {
+			edges, err := g.getEdges(ctx, lookupIDs(batch), out, edgeLabels)
+			if err != nil {
+				gdbi.ReportError(ctx, step, err)
+				forwardSignals(batch, o)
+				continue
+			}
+			// edges by the vertex they were found from, and the ids at
+			// their other end
+			found := map[string][]*gdbi.Edge{}
+			other := make([]string, 0, len(edges))
+			for _, e := range edges {
+				if out {
+					found[e.From] = append(found[e.From], e)
+					other = append(other, e.To)
+				} else {
+					found[e.To] = append(found[e.To], e)
+					other = append(other, e.From)
+				}
+			}
+			var ends map[string]*gdbi.Vertex
+			if vertices {
+				ends, err = g.getVertices(ctx, other)
+				if err != nil {
+					gdbi.ReportError(ctx, step, err)
+					forwardSignals(batch, o)
+					continue
+				}
+			}
+			for _, req := range batch {
+				if req.IsSignal() {
+					o <- req
+					continue
+				}
+				count := 0
+				for _, e := range found[req.ID] {
+					if !vertices {
+						req.Edge = e
+						o <- req
+						count++
+						continue
+					}
+					end := e.To
+					if !out {
+						end = e.From
+					}
+					if v, ok := ends[end]; ok {
+						req.Vertex = v
+						o <- req
+						count++
+					}
+				}
+				if count == 0 && emitNull {
+					req.Vertex = nil
+					req.Edge = nil
+					o <- req
+				}
+			}
+		}
+	}()
+	return o
+}
+
+// lookupIDs returns the ids of the lookups of a batch that aren't signals
+func lookupIDs(batch []gdbi.ElementLookup) []string {
+	ids := make([]string, 0, len(batch))
+	for i := range batch {
+		if !batch[i].IsSignal() {
+			ids = append(ids, batch[i].ID)
+		}
+	}
+	return ids
+}
+
+// forwardSignals passes on the signals of a batch whose lookups failed, so
+// that the steps after a failed lookup still see them
+func forwardSignals(batch []gdbi.ElementLookup, o chan gdbi.ElementLookup) {
+	for i := range batch {
+		if batch[i].IsSignal() {
+			o <- batch[i]
+		}
+	}
+}
+
+// GetOutChannel is passed a channel of vertex ids and finds the connected vertices via outgoing edges
+func (g *Graph) GetOutChannel(ctx context.Context, reqChan chan gdbi.ElementLookup, load bool, emitNull bool, edgeLabels []string) chan gdbi.ElementLookup {
+	return g.adjacent(ctx, "GetOutChannel", reqChan, true, true, emitNull, edgeLabels)
+}
+
+// GetInChannel is passed a channel of vertex ids and finds the connected vertices via incoming edges
+func (g *Graph) GetInChannel(ctx context.Context, reqChan chan gdbi.ElementLookup, load bool, emitNull bool, edgeLabels []string) chan gdbi.ElementLookup {
+	return g.adjacent(ctx, "GetInChannel", reqChan, false, true, emitNull, edgeLabels)
+}
+
+// GetOutEdgeChannel is passed a channel of vertex ids and finds the outgoing edges
+func (g *Graph) GetOutEdgeChannel(ctx context.Context, reqChan chan gdbi.ElementLookup, load bool, emitNull bool, edgeLabels []string) chan gdbi.ElementLookup {
+	return g.adjacent(ctx, "GetOutEdgeChannel", reqChan, true, false, emitNull, edgeLabels)
+}
+
+// GetInEdgeChannel is passed a channel of vertex ids and finds the incoming edges
+func (g *Graph) GetInEdgeChannel(ctx context.Context, reqChan chan gdbi.ElementLookup, load bool, emitNull bool, edgeLabels []string) chan gdbi.ElementLookup {
+	return g.adjacent(ctx, "GetInEdgeChannel", reqChan, false, false, emitNull, edgeLabels)
+}
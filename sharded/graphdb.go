@@ -0,0 +1,163 @@
+/*
+Package sharded spreads a graph across several GRIP servers. Vertices are
+placed on a shard by the hash of their gid, and each edge is kept on the
+shards of both of its vertices, so that the edges of a vertex can be found
+on its own shard. Queries are compiled and run by the server using the
+driver, which looks up elements on the shards that hold them.
+*/
+package sharded
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+
+	"github.com/bmeg/grip/gdbi"
+	"github.com/bmeg/grip/gdbi/schema"
+	"github.com/bmeg/grip/gripql"
+	"github.com/bmeg/grip/log"
+	"github.com/bmeg/grip/util/rpc"
+	multierror "github.com/hashicorp/go-multierror"
+)
+
+// Config describes the configuration for the sharded driver
+type Config struct {
+	// RPC addresses of the servers holding the shards. Vertices are placed
+	// by their position in the list, so the list can't change once graphs
+	// hold data
+	Shards   []string
+	User     string
+	Password string
+}
+
+// GraphDB manages graphs spread across the shard servers
+type GraphDB struct {
+	shards []gripql.Client
+	addrs  []string
+}
+
+// NewGraphDB connects to the shard servers
+func NewGraphDB(conf Config) (gdbi.GraphDB, error) {
+	log.Infof("Starting Sharded Driver with %d shards", len(conf.Shards))
+	if len(conf.Shards) == 0 {
+		return nil, fmt.Errorf("no shards configured")
+	}
+	db := &GraphDB{addrs: conf.Shards}
+	for _, addr := range conf.Shards {
+		rconf := rpc.ConfigWithDefaults(addr)
+		rconf.User = conf.User
+		rconf.Password = conf.Password
+		cli, err := gripql.Connect(rconf, true)
+		if err != nil {
+			db.Close()
+			return nil, fmt.Errorf("connecting to shard %s: %v", addr, err)
+		}
+		db.shards = append(db.shards, cli)
+	}
+	return db, nil
+}
+
+// shardOf returns the position of the shard holding a vertex
+func shardOf(gid string, n int) int {
+	h := fnv.New32a()
+	h.Write([]byte(gid))
+	return int(h.Sum32() % uint32(n))
+}
+
+// Close the connections to the shards
+func (db *GraphDB) Close() error {
+	for _, cli := range db.shards {
+		cli.Close()
+	}
+	return nil
+}
+
+// AddGraph creates a new graph named `graph` on every shard
+func (db *GraphDB) AddGraph(graph string) error {
+	if err := gripql.ValidateGraphName(graph); err != nil {
+		return err
+	}
+	for i, cli := range db.shards {
+		if err := cli.AddGraph(graph); err != nil {
+			return fmt.Errorf("AddGraph: shard %s: %v", db.addrs[i], err)
+		}
+	}
+	return nil
+}
+
+// DeleteGraph deletes the graph named `graph` from every shard
+func (db *GraphDB) DeleteGraph(graph string) error {
+	for i, cli := range db.shards {
+		if err := cli.DeleteGraph(graph); err != nil {
+			return fmt.Errorf("DeleteGraph: shard %s: %v", db.addrs[i], err)
+		}
+	}
+	return nil
+}
+
+// listGraphs lists the graphs held by every shard. A graph missing on some
+// shards, such as one whose AddGraph failed part way, isn't listed
+func (db *GraphDB) listGraphs() ([]string, error) {
+	counts := map[string]int{}
+	graphs := []string{}
+	var listErr *multierror.Error
+	for i, cli := range db.shards {
+		res, err := cli.ListGraphs()
+		if err != nil {
+			listErr = multierror.Append(listErr, fmt.Errorf("shard %s: %v", db.addrs[i], err))
+			continue
+		}
+		for _, g := range res.Graphs {
+			if counts[g] == 0 {
+				graphs = append(graphs, g)
+			}
+			counts[g]++
+		}
+	}
+	if err := listErr.ErrorOrNil(); err != nil {
+		return nil, err
+	}
+	out := []string{}
+	for _, g := range graphs {
+		if counts[g] == len(db.shards) {
+			out = append(out, g)
+		} else {
+			log.WithFields(log.Fields{"graph": g}).Warning("ListGraphs: graph is missing on some shards")
+		}
+	}
+	return out, nil
+}
+
+// ListGraphs lists the graphs held by every shard. Nothing is listed when a
+// shard can't be read
+func (db *GraphDB) ListGraphs() []string {
+	graphs, err := db.listGraphs()
+	if err != nil {
+		log.WithFields(log.Fields{"error": err}).Error("ListGraphs")
+		return []string{}
+	}
+	return graphs
+}
+
+// Graph obtains the gdbi.DBI for a particular graph
+func (db *GraphDB) Graph(graph string) (gdbi.GraphInterface, error) {
+	graphs, err := db.listGraphs()
+	if err != nil {
+		return nil, err
+	}
+	for _, g := range graphs {
+		if g == graph {
+			return &Graph{shards: db.shards, addrs: db.addrs, graph: graph}, nil
+		}
+	}
+	return nil, fmt.Errorf("graph '%s' was not found", graph)
+}
+
+// BuildSchema returns the schema of a specific graph in the database
+func (db *GraphDB) BuildSchema(ctx context.Context, graph string, sampleN uint32, random bool) (*gripql.Graph, error) {
+	gr, err := db.Graph(graph)
+	if err != nil {
+		return nil, err
+	}
+	return schema.SchemaScan(ctx, graph, gr, sampleN, random)
+}
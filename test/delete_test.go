@@ -0,0 +1,73 @@
+package test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/bmeg/grip/engine/pipeline"
+	"github.com/bmeg/grip/gdbi"
+	"github.com/bmeg/grip/gripql"
+)
+
+func TestDeleteEdge(t *testing.T) {
+	if dbname == "existing-sql" {
+		t.Skip("existing-sql graphs are read only")
+	}
+	graph := "delete-edge"
+	if err := gdb.AddGraph(graph); err != nil {
+		t.Fatal(err)
+	}
+	defer gdb.DeleteGraph(graph)
+	g, err := gdb.Graph(graph)
+	if err != nil {
+		t.Fatal(err)
+	}
+	vs := []*gdbi.Vertex{
+		{ID: "a", Label: "Node", Data: map[string]interface{}{}},
+		{ID: "b", Label: "Node", Data: map[string]interface{}{}},
+		{ID: "c", Label: "Node", Data: map[string]interface{}{}},
+	}
+	es := []*gdbi.Edge{
+		{ID: "ab", From: "a", To: "b", Label: "link", Data: map[string]interface{}{}},
+		{ID: "ac", From: "a", To: "c", Label: "link", Data: map[string]interface{}{}},
+	}
+	if err := g.AddVertex(vs); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.AddEdge(es); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.DelEdge("ab"); err != nil {
+		t.Fatal(err)
+	}
+
+	// the edge must be gone from the adjacency of both of its vertices
+	tests := []struct {
+		query    *gripql.Query
+		expected []string
+	}{
+		{gripql.V("a").OutE(), []string{"ac"}},
+		{gripql.V("a").Out(), []string{"c"}},
+		{gripql.V("b").InE(), []string{}},
+		{gripql.V("b").In(), []string{}},
+	}
+	for _, test := range tests {
+		pipe, err := g.Compiler().Compile(test.query.Statements, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids := []string{}
+		for r := range pipeline.Run(context.Background(), pipe, "./") {
+			if e := r.GetEdge(); e != nil {
+				ids = append(ids, e.Gid)
+			}
+			if v := r.GetVertex(); v != nil {
+				ids = append(ids, v.Gid)
+			}
+		}
+		if !reflect.DeepEqual(ids, test.expected) {
+			t.Errorf("%s: expected %v, found %v", test.query.String(), test.expected, ids)
+		}
+	}
+}
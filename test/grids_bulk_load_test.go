@@ -0,0 +1,101 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"testing"
+
+	"github.com/bmeg/grip/gdbi"
+	"github.com/bmeg/grip/grids"
+)
+
+func TestGridsBulkLoad(t *testing.T) {
+	dir := t.TempDir()
+	loader, err := grids.NewBulkLoader(dir, "bulk")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 9; i >= 0; i-- {
+		label := "even"
+		if i%2 == 1 {
+			label = "odd"
+		}
+		v := &gdbi.Vertex{ID: fmt.Sprintf("v%d", i), Label: label, Data: map[string]interface{}{"n": float64(i)}}
+		if err := loader.AddVertex(v); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// later copies replace earlier ones
+	if err := loader.AddVertex(&gdbi.Vertex{ID: "v0", Label: "even", Data: map[string]interface{}{"n": 100.0}}); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 9; i++ {
+		e := &gdbi.Edge{ID: fmt.Sprintf("e%d", i), Label: "next", From: fmt.Sprintf("v%d", i), To: fmt.Sprintf("v%d", i+1)}
+		if err := loader.AddEdge(e); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := loader.AddEdge(&gdbi.Edge{ID: "missing", Label: "next", From: "v0", To: "nowhere"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := loader.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if loader.Skipped() != 1 {
+		t.Errorf("expected 1 skipped edge, got %d", loader.Skipped())
+	}
+
+	gdb, err := grids.NewGraphDB(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer gdb.Close()
+	graph, err := gdb.Graph("bulk")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	v := graph.GetVertex("v0", true)
+	if v == nil || v.Label != "even" || v.Data["n"] != 100.0 {
+		t.Errorf("unexpected vertex: %v", v)
+	}
+	odd := []string{}
+	for id := range graph.VertexLabelScan(context.Background(), "odd") {
+		odd = append(odd, id)
+	}
+	sort.Strings(odd)
+	if fmt.Sprint(odd) != "[v1 v3 v5 v7 v9]" {
+		t.Errorf("unexpected label scan: %v", odd)
+	}
+	labels, _ := graph.ListEdgeLabels()
+	if fmt.Sprint(labels) != "[next]" {
+		t.Errorf("unexpected edge labels: %v", labels)
+	}
+
+	reqs := make(chan gdbi.ElementLookup, 1)
+	reqs <- gdbi.ElementLookup{ID: "v3"}
+	close(reqs)
+	out := []string{}
+	for r := range graph.GetOutChannel(context.Background(), reqs, false, false, nil) {
+		out = append(out, r.Vertex.ID)
+	}
+	if fmt.Sprint(out) != "[v4]" {
+		t.Errorf("unexpected out vertices: %v", out)
+	}
+
+	// keys assigned after the load must not collide with loaded ones
+	if err := graph.AddVertex([]*gdbi.Vertex{{ID: "new", Label: "odd"}}); err != nil {
+		t.Fatal(err)
+	}
+	count := 0
+	for range graph.GetVertexList(context.Background(), false) {
+		count++
+	}
+	if count != 11 {
+		t.Errorf("expected 11 vertices, got %d", count)
+	}
+	if v := graph.GetVertex("v9", true); v == nil || v.Data["n"] != 9.0 {
+		t.Errorf("vertex changed after insert: %v", v)
+	}
+}
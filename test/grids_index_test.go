@@ -0,0 +1,166 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/bmeg/grip/gdbi"
+	"github.com/bmeg/grip/grids"
+	"github.com/bmeg/grip/kvi"
+	"github.com/bmeg/grip/kvi/pebbledb"
+	"github.com/bmeg/grip/kvindex"
+)
+
+func gridsLabelScan(graph gdbi.GraphInterface, label string) string {
+	ids := []string{}
+	for id := range graph.VertexLabelScan(context.Background(), label) {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return fmt.Sprint(ids)
+}
+
+func gridsNeighbors(graph gdbi.GraphInterface, id string, out bool) string {
+	reqs := make(chan gdbi.ElementLookup, 1)
+	reqs <- gdbi.ElementLookup{ID: id}
+	close(reqs)
+	var res chan gdbi.ElementLookup
+	if out {
+		res = graph.GetOutChannel(context.Background(), reqs, false, false, nil)
+	} else {
+		res = graph.GetInChannel(context.Background(), reqs, false, false, nil)
+	}
+	ids := []string{}
+	for r := range res {
+		ids = append(ids, r.Vertex.ID)
+	}
+	sort.Strings(ids)
+	return fmt.Sprint(ids)
+}
+
+func TestGridsReplaceElement(t *testing.T) {
+	gdb, err := grids.NewGraphDB(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer gdb.Close()
+	if err := gdb.AddGraph("replace"); err != nil {
+		t.Fatal(err)
+	}
+	graph, err := gdb.Graph("replace")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = graph.AddVertex([]*gdbi.Vertex{
+		{ID: "a", Label: "Old"},
+		{ID: "b", Label: "Node"},
+		{ID: "c", Label: "Node"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := graph.AddEdge([]*gdbi.Edge{{ID: "e", Label: "link", From: "a", To: "b"}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := graph.AddVertex([]*gdbi.Vertex{{ID: "a", Label: "New"}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := graph.AddEdge([]*gdbi.Edge{{ID: "e", Label: "link", From: "a", To: "c"}}); err != nil {
+		t.Fatal(err)
+	}
+	if s := gridsLabelScan(graph, "Old"); s != "[]" {
+		t.Errorf("replaced label still indexed: %s", s)
+	}
+	if s := gridsLabelScan(graph, "New"); s != "[a]" {
+		t.Errorf("unexpected label scan: %s", s)
+	}
+	if s := gridsNeighbors(graph, "a", true); s != "[c]" {
+		t.Errorf("unexpected out vertices: %s", s)
+	}
+	if s := gridsNeighbors(graph, "b", false); s != "[]" {
+		t.Errorf("replaced edge still linked: %s", s)
+	}
+
+	// BulkAdd replaces elements the same way
+	stream := make(chan *gdbi.GraphElement, 2)
+	stream <- &gdbi.GraphElement{Vertex: &gdbi.Vertex{ID: "b", Label: "Other"}}
+	stream <- &gdbi.GraphElement{Edge: &gdbi.Edge{ID: "e", Label: "link", From: "b", To: "c"}}
+	close(stream)
+	if err := graph.BulkAdd(stream); err != nil {
+		t.Fatal(err)
+	}
+	if s := gridsLabelScan(graph, "Node"); s != "[c]" {
+		t.Errorf("unexpected label scan after BulkAdd: %s", s)
+	}
+	if s := gridsNeighbors(graph, "a", true); s != "[]" {
+		t.Errorf("unexpected out vertices after BulkAdd: %s", s)
+	}
+	if s := gridsNeighbors(graph, "c", false); s != "[b]" {
+		t.Errorf("unexpected in vertices after BulkAdd: %s", s)
+	}
+
+	if err := graph.DelVertex("c"); err != nil {
+		t.Fatal(err)
+	}
+	if s := gridsLabelScan(graph, "Node"); s != "[]" {
+		t.Errorf("deleted vertex still indexed: %s", s)
+	}
+}
+
+func TestGridsIndexMigration(t *testing.T) {
+	dir := t.TempDir()
+	gdb, err := grids.NewGraphDB(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := gdb.AddGraph("migrate"); err != nil {
+		t.Fatal(err)
+	}
+	graph, err := gdb.Graph("migrate")
+	if err != nil {
+		t.Fatal(err)
+	}
+	vertices := []*gdbi.Vertex{}
+	for i := 0; i < 10; i++ {
+		vertices = append(vertices, &gdbi.Vertex{ID: fmt.Sprintf("v%d", i), Label: "Node"})
+	}
+	if err := graph.AddVertex(vertices); err != nil {
+		t.Fatal(err)
+	}
+	gdb.Close()
+
+	// turn the index into one written before the layout was versioned, whose
+	// documents never matched the label fields
+	kv, err := pebbledb.NewKVInterface(filepath.Join(dir, "migrate", "index"), kvi.Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	idx := kvindex.NewIndex(kv)
+	if err := idx.RemoveField("migrate.v.label"); err != nil {
+		t.Fatal(err)
+	}
+	if err := idx.AddField("migrate.v.label"); err != nil {
+		t.Fatal(err)
+	}
+	if err := kv.Delete([]byte("grids.index.version")); err != nil {
+		t.Fatal(err)
+	}
+	kv.Close()
+
+	gdb, err = grids.NewGraphDB(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer gdb.Close()
+	graph, err = gdb.Graph("migrate")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := gridsLabelScan(graph, "Node"); s != "[v0 v1 v2 v3 v4 v5 v6 v7 v8 v9]" {
+		t.Errorf("graph was not reindexed: %s", s)
+	}
+}
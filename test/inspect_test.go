@@ -117,6 +117,55 @@ func TestOutputMasking(t *testing.T) {
 	}
 }
 
+func TestSubQueryOutputs(t *testing.T) {
+	q := gripql.NewQuery()
+	q = q.V().Out().Where(gripql.NewQuery().HasLabel("Person").Out("knows")).Out()
+	steps := inspect.PipelineSteps(q.Statements)
+	if !setcmp.ArrayEq(steps, []string{"1", "2", "2", "3"}) {
+		t.Errorf("Step mapping error: %s", steps)
+	}
+	out := inspect.PipelineStepOutputs(q.Statements)
+	if !setcmp.ArrayEq(out["2"], []string{"_label"}) {
+		t.Errorf("Incorrect output: %s", out)
+	}
+
+	q = gripql.NewQuery()
+	q = q.V().Out().Not(gripql.NewQuery().Has(gripql.Eq("name", "bob"))).Out()
+	out = inspect.PipelineStepOutputs(q.Statements)
+	if !setcmp.ArrayEq(out["2"], []string{"*"}) {
+		t.Errorf("Incorrect output: %s", out)
+	}
+}
+
+func TestBranchOutputs(t *testing.T) {
+	q := gripql.NewQuery()
+	q = q.V().Out().Union(gripql.NewQuery().HasLabel("Person").Out(), gripql.NewQuery()).Has(gripql.Eq("name", "bob"))
+	steps := inspect.PipelineSteps(q.Statements)
+	if !setcmp.ArrayEq(steps, []string{"1", "2", "3", "3"}) {
+		t.Errorf("Step mapping error: %s", steps)
+	}
+	out := inspect.PipelineStepOutputs(q.Statements)
+	if !setcmp.ArrayEq(out["2"], []string{"*"}) {
+		t.Errorf("Incorrect output: %s", out)
+	}
+
+	q = gripql.NewQuery()
+	q = q.V().Out().Coalesce(gripql.NewQuery().HasLabel("Person").Out()).Count()
+	out = inspect.PipelineStepOutputs(q.Statements)
+	if !setcmp.ArrayEq(out["2"], []string{"_label"}) {
+		t.Errorf("Incorrect output: %s", out)
+	}
+}
+
+func TestHasMarkOutputs(t *testing.T) {
+	q := gripql.NewQuery()
+	q = q.V().HasLabel("users").As("a").Out().Has(gripql.Eq("user_id", gripql.Ref("$a.id"))).Count()
+	out := inspect.PipelineStepOutputs(q.Statements)
+	if !setcmp.ContainsString(out["1"], "*") {
+		t.Errorf("Incorrect output: %s", out)
+	}
+}
+
 func TestOutputIndexMasking(t *testing.T) {
 	q := gripql.NewQuery()
 	q = gripql.NewQuery()
@@ -20,6 +20,7 @@ import (
 	_ "github.com/bmeg/grip/kvi/leveldb"  // import so level will register itself
 	"github.com/bmeg/grip/mongo"
 	"github.com/bmeg/grip/psql"
+	"github.com/bmeg/grip/sharded"
 	"github.com/bmeg/grip/util"
 	_ "github.com/lib/pq" // import so postgres will register as a sql driver
 )
@@ -138,6 +139,13 @@ func TestMain(m *testing.M) {
 		gdb, err = mongo.NewGraphDB(*dbconfig.MongoDB)
 	} else if dbconfig.PSQL != nil {
 		gdb, err = psql.NewGraphDB(*dbconfig.PSQL)
+	} else if dbconfig.Sharded != nil {
+		var stop func()
+		stop, err = startShards(dbconfig.Sharded)
+		defer stop()
+		if err == nil {
+			gdb, err = sharded.NewGraphDB(*dbconfig.Sharded)
+		}
 	} else {
 		err = fmt.Errorf("unknown database")
 	}
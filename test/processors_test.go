@@ -11,6 +11,7 @@ import (
 	"testing"
 
 	"github.com/bmeg/grip/engine/pipeline"
+	"github.com/bmeg/grip/gdbi"
 	"github.com/bmeg/grip/gripql"
 	"github.com/bmeg/grip/util"
 	"google.golang.org/protobuf/encoding/protojson"
@@ -297,6 +298,22 @@ func TestEngine(t *testing.T) {
 			Q.V().Range(10, 50).Count(),
 			count(40),
 		},
+		{
+			Q.V().HasLabel("products").Sort("-price", "_gid").Limit(3),
+			ordered("products:7", "products:10", "products:6"),
+		},
+		{
+			Q.V().HasLabel("products").Sort("title").Limit(3),
+			ordered("products:9", "products:10", "products:20"),
+		},
+		{
+			Q.V().HasLabel("products").Sort("-title").Skip(18),
+			ordered("products:10", "products:9"),
+		},
+		{
+			Q.V().HasLabel("products").Seek([]string{"title"}, []interface{}{"P"}).Count(),
+			count(4),
+		},
 		{
 			Q.V("users:1").Fields(),
 			pickRes(vertex("users:1", "users", data{})),
@@ -354,6 +371,58 @@ func TestEngine(t *testing.T) {
 			Q.V("users:11").As("a").OutE().As("b").Out().Has(gripql.Neq("_gid", "purchases:4")).Select("b").Out(),
 			pick("purchases:26"),
 		},
+		{
+			Q.V().HasLabel("users").As("a").Out().Has(gripql.Eq("user_id", gripql.Ref("$a.id"))).Count(),
+			count(100),
+		},
+		{
+			Q.V("users:11").As("a").Out().As("b").Has(gripql.Gt("$b.id", gripql.Ref("$a.id"))),
+			pick("purchases:26"),
+		},
+		{
+			Q.V("users:11").As("a").Out().Has(gripql.Not(gripql.Lte("id", gripql.Ref("$a.id")))),
+			pick("purchases:26"),
+		},
+		{
+			Q.V().HasLabel("users").Where(gripql.NewQuery().Out("userPurchases")).Count(),
+			count(46),
+		},
+		{
+			Q.V().HasLabel("users").Not(gripql.NewQuery().Out("userPurchases")).Count(),
+			count(4),
+		},
+		{
+			Q.V().HasLabel("users").Where(gripql.NewQuery().Out().Out().Has(gripql.Gt("price", 100))).Count(),
+			count(21),
+		},
+		{
+			Q.V().HasLabel("users").Where(gripql.NewQuery().Out().Where(gripql.NewQuery().Out().Has(gripql.Gt("price", 100)))).Count(),
+			count(21),
+		},
+		{
+			Q.V("users:11").As("a").Out().Where(gripql.NewQuery().Select("a").Has(gripql.Eq("_gid", "users:11"))).Not(gripql.NewQuery().Out().Has(gripql.Gt("price", 100))),
+			pick("purchases:4"),
+		},
+		{
+			Q.V("users:11").Union(gripql.NewQuery().Out(), gripql.NewQuery()),
+			pick("purchases:4", "purchases:26", "users:11"),
+		},
+		{
+			Q.V("users:11").Union(gripql.NewQuery().Out().Out().Has(gripql.Gt("price", 100)), gripql.NewQuery().Out().HasID("purchases:4")),
+			pick("products:6", "purchases:4"),
+		},
+		{
+			Q.V().HasLabel("users").Optional(gripql.NewQuery().Out("userPurchases")).Count(),
+			count(104),
+		},
+		{
+			Q.V().HasLabel("users").Coalesce(gripql.NewQuery().Out("userPurchases"), gripql.NewQuery()).HasLabel("users").Count(),
+			count(4),
+		},
+		{
+			Q.V("users:11").As("a").Coalesce(gripql.NewQuery().Out().Out().Has(gripql.Gt("price", 1000)), gripql.NewQuery().Out().Has(gripql.Eq("_gid", "purchases:26"))).Select("a"),
+			pick("users:11"),
+		},
 		{
 			Q.V("users:1").As("a").Out().As("b").Select("a", "b"),
 			pickSelection(map[string]interface{}{
@@ -394,6 +463,28 @@ func TestEngine(t *testing.T) {
 				Render(map[string]interface{}{"user_id": "$a._gid", "purchase_id": "$b._gid", "purchaser": "$b.name"}),
 			render(map[string]interface{}{"user_id": "users:1", "purchase_id": "purchases:57", "purchaser": "Letitia Sprau"}),
 		},
+		{
+			Q.V("users:11").Out().Sort("_gid").Fold("_gid"),
+			render([]interface{}{"purchases:26", "purchases:4"}),
+		},
+		{
+			Q.V("users:11").Out().Sort("_gid").Group("user_id", map[string]interface{}{"id": "_gid", "state": "state"}),
+			render(map[string]interface{}{"key": 11, "values": []interface{}{
+				map[string]interface{}{"id": "purchases:26", "state": "SC"},
+				map[string]interface{}{"id": "purchases:4", "state": "NY"},
+			}}),
+		},
+		{
+			Q.V("users:1", "users:11").As("u").Out().Sort("_gid").Group("$u._gid", "state"),
+			render(
+				map[string]interface{}{"key": "users:1", "values": []interface{}{"IL"}},
+				map[string]interface{}{"key": "users:11", "values": []interface{}{"SC", "NY"}},
+			),
+		},
+		{
+			Q.V("users:11").Out().Has(gripql.Eq("state", "XX")).Fold("_gid"),
+			render([]interface{}{}),
+		},
 	}
 
 	for _, desc := range tests {
@@ -410,6 +501,19 @@ func TestEngine(t *testing.T) {
 			res := pipeline.Run(context.Background(), compiledPipeline, workdir)
 			desc.expected(t, res)
 		})
+
+		// stateless steps on several workers, keeping the order of results
+		t.Run(name+"_parallel", func(t *testing.T) {
+			compiledPipeline, err := db.Compiler().Compile(desc.query.Statements, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			workdir := "./test.workdir." + util.RandomString(6)
+			defer os.RemoveAll(workdir)
+			ctx := gdbi.WithParallelism(context.Background(), 4, true)
+			res := pipeline.Run(ctx, compiledPipeline, workdir)
+			desc.expected(t, res)
+		})
 	}
 }
 
@@ -495,6 +599,19 @@ func getEdge(gid string) *gripql.Edge {
 	return nil
 }
 
+// ordered checks that the results are the vertices with gids, in order
+func ordered(gids ...string) checker {
+	return func(t *testing.T, actual <-chan *gripql.QueryResult) {
+		actualS := []string{}
+		for r := range actual {
+			actualS = append(actualS, r.GetVertex().GetGid())
+		}
+		if !reflect.DeepEqual(actualS, gids) {
+			t.Errorf("expected %v, got %v", gids, actualS)
+		}
+	}
+}
+
 func pickgid(gid string) *gripql.QueryResult {
 	v := getVertex(gid)
 	if v != nil {
@@ -595,14 +712,15 @@ func count(i int) checker {
 	return compare(expect)
 }
 
-func render(v interface{}) checker {
-	vs, _ := structpb.NewValue(v)
-	expect := []*gripql.QueryResult{
-		{
+func render(vals ...interface{}) checker {
+	expect := []*gripql.QueryResult{}
+	for _, v := range vals {
+		vs, _ := structpb.NewValue(v)
+		expect = append(expect, &gripql.QueryResult{
 			Result: &gripql.QueryResult_Render{
 				Render: vs,
 			},
-		},
+		})
 	}
 	return compare(expect)
 }
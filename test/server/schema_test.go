@@ -0,0 +1,88 @@
+package server
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/bmeg/grip/config"
+	"github.com/bmeg/grip/gdbi"
+	"github.com/bmeg/grip/gdbi/schema"
+	"github.com/bmeg/grip/gripql"
+	"github.com/bmeg/grip/kvgraph"
+	"github.com/bmeg/grip/server"
+	"github.com/bmeg/grip/util"
+	"github.com/bmeg/grip/util/rpc"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func mustStruct(t *testing.T, m map[string]interface{}) *structpb.Struct {
+	s, err := structpb.NewStruct(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func personSchema(t *testing.T, nameType string) *gripql.Graph {
+	data := mustStruct(t, map[string]interface{}{"name": nameType})
+	return &gripql.Graph{Graph: "test", Vertices: []*gripql.Vertex{{Gid: "Person", Label: "Vertex", Data: data}}}
+}
+
+func TestSchemaEnforcementRebuild(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	conf := config.DefaultConfig()
+	config.TestifyConfig(conf)
+	conf.Server.SchemaEnforcement = map[string]schema.Enforcement{"test": {}}
+	defer os.RemoveAll(conf.Server.WorkDir)
+
+	tmpDB := "grip.db." + util.RandomString(6)
+	gdb, err := kvgraph.NewKVGraphDB("badger", tmpDB)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer os.RemoveAll(tmpDB)
+
+	srv, err := server.NewGripServer(conf, "./", map[string]gdbi.GraphDB{"badger": gdb})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	go srv.Serve(ctx)
+
+	cli, err := gripql.Connect(rpc.ConfigWithDefaults(conf.Server.RPCAddress()), true)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := cli.AddGraph("test"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	vertex := func(gid string, name interface{}) *gripql.Vertex {
+		return &gripql.Vertex{Gid: gid, Label: "Person", Data: mustStruct(t, map[string]interface{}{"name": name})}
+	}
+
+	if err := cli.AddVertex("test", vertex("1", "bob")); err == nil {
+		t.Error("expected a write without a schema to fail")
+	}
+	if err := cli.AddSchema(personSchema(t, "STRING")); err != nil {
+		t.Fatal(err)
+	}
+	if err := cli.AddVertex("test", vertex("1", "bob")); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := cli.AddVertex("test", vertex("2", 2.0)); err == nil {
+		t.Error("expected a numeric name to be rejected")
+	}
+
+	// replacing the schema replaces the validator built from the old one
+	if err := cli.AddSchema(personSchema(t, "NUMERIC")); err != nil {
+		t.Fatal(err)
+	}
+	if err := cli.AddVertex("test", vertex("2", 2.0)); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := cli.AddVertex("test", vertex("3", "carol")); err == nil {
+		t.Error("expected a string name to be rejected by the new schema")
+	}
+}
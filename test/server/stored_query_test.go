@@ -0,0 +1,86 @@
+package server
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/bmeg/grip/config"
+	"github.com/bmeg/grip/gdbi"
+	"github.com/bmeg/grip/gripql"
+	"github.com/bmeg/grip/kvgraph"
+	"github.com/bmeg/grip/server"
+	"github.com/bmeg/grip/util"
+	"github.com/bmeg/grip/util/rpc"
+)
+
+func TestStoredQueryGraphEdits(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	conf := config.DefaultConfig()
+	config.TestifyConfig(conf)
+	defer os.RemoveAll(conf.Server.WorkDir)
+
+	tmpDB := "grip.db." + util.RandomString(6)
+	gdb, err := kvgraph.NewKVGraphDB("badger", tmpDB)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer os.RemoveAll(tmpDB)
+
+	srv, err := server.NewGripServer(conf, "./", map[string]gdbi.GraphDB{"badger": gdb})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	go srv.Serve(ctx)
+
+	cli, err := gripql.Connect(rpc.ConfigWithDefaults(conf.Server.RPCAddress()), true)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := cli.AddGraph("test"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	err = cli.AddStoredQuery(&gripql.QueryDefinition{Graph: "test", Name: "people", Query: gripql.V().HasLabel("Person").Statements})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	queries := "test__queries__"
+	if err := cli.AddVertex(queries, &gripql.Vertex{Gid: "people", Label: "Query"}); err == nil {
+		t.Error("expected AddVertex to fail")
+	}
+	if err := cli.AddEdge(queries, &gripql.Edge{Gid: "e", From: "people", To: "people", Label: "x"}); err == nil {
+		t.Error("expected AddEdge to fail")
+	}
+	if err := cli.DeleteVertex(queries, "people"); err == nil {
+		t.Error("expected DeleteVertex to fail")
+	}
+	if err := cli.DeleteEdge(queries, "e"); err == nil {
+		t.Error("expected DeleteEdge to fail")
+	}
+	bulk, err := cli.EditC.BulkAdd(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := bulk.Send(&gripql.GraphElement{Graph: queries, Vertex: &gripql.Vertex{Gid: "people", Label: "Query"}}); err != nil {
+		t.Fatal(err)
+	}
+	res, err := bulk.CloseAndRecv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the access filter drops the element before the server sees it
+	if res.InsertCount != 0 {
+		t.Errorf("unexpected BulkAdd result: %s", res)
+	}
+
+	def, err := cli.GetStoredQuery("test", "people")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if def.Name != "people" {
+		t.Errorf("unexpected stored query: %s", def)
+	}
+}
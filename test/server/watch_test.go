@@ -0,0 +1,126 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/bmeg/grip/config"
+	"github.com/bmeg/grip/gdbi"
+	"github.com/bmeg/grip/gripql"
+	"github.com/bmeg/grip/kvgraph"
+	"github.com/bmeg/grip/server"
+	"github.com/bmeg/grip/util"
+	"github.com/bmeg/grip/util/rpc"
+)
+
+func TestWatch(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	conf := config.DefaultConfig()
+	config.TestifyConfig(conf)
+	defer os.RemoveAll(conf.Server.WorkDir)
+
+	tmpDB := "grip.db." + util.RandomString(6)
+	gdb, err := kvgraph.NewKVGraphDB("badger", tmpDB)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer os.RemoveAll(tmpDB)
+
+	srv, err := server.NewGripServer(conf, "./", map[string]gdbi.GraphDB{"badger": gdb})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	go srv.Serve(ctx)
+
+	cli, err := gripql.Connect(rpc.ConfigWithDefaults(conf.Server.RPCAddress()), true)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := cli.AddGraph("test"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	events, err := cli.Watch(ctx, "test")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// wait for the watch to be registered
+	time.Sleep(100 * time.Millisecond)
+
+	v := &gripql.Vertex{Gid: "1", Label: "Person"}
+	v.SetDataMap(map[string]interface{}{"name": "alice"})
+	if err := cli.AddVertex("test", v); err != nil {
+		t.Fatal(err)
+	}
+	v.SetDataMap(map[string]interface{}{"name": "bob"})
+	if err := cli.AddVertex("test", v); err != nil {
+		t.Fatal(err)
+	}
+	if err := cli.AddVertex("test", &gripql.Vertex{Gid: "2", Label: "Person"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := cli.AddEdge("test", &gripql.Edge{Gid: "e1", From: "1", To: "2", Label: "knows"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := cli.DeleteVertex("test", "1"); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []struct {
+		t    gripql.EventType
+		gid  string
+		edge bool
+	}{
+		{gripql.EventType_ADDED, "1", false},
+		{gripql.EventType_UPDATED, "1", false},
+		{gripql.EventType_ADDED, "2", false},
+		{gripql.EventType_ADDED, "e1", true},
+		{gripql.EventType_REMOVED, "e1", true},
+		{gripql.EventType_REMOVED, "1", false},
+	}
+	for _, e := range expected {
+		select {
+		case ev := <-events:
+			gid := ev.GetVertex().GetGid()
+			if e.edge {
+				gid = ev.GetEdge().GetGid()
+			}
+			if ev.Type != e.t || gid != e.gid {
+				t.Errorf("expected %s %s, got %s", e.t, e.gid, ev)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for %s %s", e.t, e.gid)
+		}
+	}
+
+	// BulkAdd sends the events of each batch once it is written
+	elems := make(chan *gripql.GraphElement, 250)
+	elems <- &gripql.GraphElement{Graph: "test", Vertex: &gripql.Vertex{Gid: "2", Label: "Person"}}
+	for i := 0; i < 249; i++ {
+		elems <- &gripql.GraphElement{Graph: "test", Vertex: &gripql.Vertex{Gid: fmt.Sprintf("b%d", i), Label: "Person"}}
+	}
+	close(elems)
+	if err := cli.BulkAdd(elems); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 250; i++ {
+		select {
+		case ev := <-events:
+			gid := ev.GetVertex().GetGid()
+			expected := gripql.EventType_ADDED
+			if i == 0 {
+				expected = gripql.EventType_UPDATED
+			}
+			if ev.Type != expected || (i > 0 && gid != fmt.Sprintf("b%d", i-1)) {
+				t.Errorf("unexpected bulk event %d: %s", i, ev)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for bulk event %d", i)
+		}
+	}
+}
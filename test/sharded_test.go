@@ -0,0 +1,314 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/bmeg/grip/config"
+	"github.com/bmeg/grip/engine/pipeline"
+	"github.com/bmeg/grip/gdbi"
+	"github.com/bmeg/grip/gripql"
+	"github.com/bmeg/grip/kvgraph"
+	"github.com/bmeg/grip/server"
+	"github.com/bmeg/grip/sharded"
+	"github.com/bmeg/grip/util"
+	"github.com/bmeg/grip/util/rpc"
+)
+
+// shardAddrs are the addresses of the shard servers started for the tests
+var shardAddrs []string
+
+// startShards starts a badger backed server for each shard of the config,
+// setting the shard addresses to those of the servers
+func startShards(conf *sharded.Config) (func(), error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	dirs := []string{}
+	stop := func() {
+		cancel()
+		for _, d := range dirs {
+			os.RemoveAll(d)
+		}
+	}
+	for i := range conf.Shards {
+		sconf := config.DefaultConfig()
+		config.TestifyConfig(sconf)
+		tmpDB := "grip.db." + util.RandomString(6)
+		dirs = append(dirs, sconf.Server.WorkDir, tmpDB)
+		gdb, err := kvgraph.NewKVGraphDB("badger", tmpDB)
+		if err != nil {
+			return stop, err
+		}
+		srv, err := server.NewGripServer(sconf, "./", map[string]gdbi.GraphDB{"badger": gdb})
+		if err != nil {
+			return stop, err
+		}
+		go srv.Serve(ctx)
+		conf.Shards[i] = sconf.Server.RPCAddress()
+	}
+	shardAddrs = conf.Shards
+	return stop, nil
+}
+
+// shardCount counts the results of a query run on a single shard
+func shardCount(t *testing.T, addr string, graph string, q *gripql.Query) int {
+	cli, err := gripql.Connect(rpc.ConfigWithDefaults(addr), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cli.Close()
+	res, err := cli.Traversal(&gripql.GraphQuery{Graph: graph, Query: q.Count().Statements})
+	if err != nil {
+		t.Fatal(err)
+	}
+	count := 0
+	for r := range res {
+		count = int(r.GetCount())
+	}
+	return count
+}
+
+func TestShardPlacement(t *testing.T) {
+	if shardAddrs == nil {
+		t.Skip("only for the sharded driver")
+	}
+	total := 0
+	for _, addr := range shardAddrs {
+		n := shardCount(t, addr, "test-graph", gripql.V())
+		if n == 0 {
+			t.Errorf("shard %s holds no vertices", addr)
+		}
+		total += n
+	}
+	if total != len(vertices) {
+		t.Errorf("expected %d vertices across the shards, found %d", len(vertices), total)
+	}
+}
+
+func TestShardDelete(t *testing.T) {
+	if shardAddrs == nil {
+		t.Skip("only for the sharded driver")
+	}
+	graph := "sharded-delete"
+	if err := gdb.AddGraph(graph); err != nil {
+		t.Fatal(err)
+	}
+	defer gdb.DeleteGraph(graph)
+	g, err := gdb.Graph(graph)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// enough vertices for every shard to hold some
+	vs := []*gdbi.Vertex{}
+	es := []*gdbi.Edge{}
+	for i := 0; i < 20; i++ {
+		vs = append(vs, &gdbi.Vertex{ID: fmt.Sprintf("v%d", i), Label: "Node", Data: map[string]interface{}{}})
+		if i > 0 {
+			es = append(es, &gdbi.Edge{ID: fmt.Sprintf("e%d", i), From: "v0", To: fmt.Sprintf("v%d", i), Label: "link", Data: map[string]interface{}{}})
+		}
+	}
+	if err := g.AddVertex(vs); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.AddEdge(es); err != nil {
+		t.Fatal(err)
+	}
+	count := func(ch <-chan *gdbi.Edge) int {
+		n := 0
+		for range ch {
+			n++
+		}
+		return n
+	}
+	if n := count(g.GetEdgeList(context.Background(), true)); n != len(es) {
+		t.Errorf("expected %d edges, found %d", len(es), n)
+	}
+	traverse := func(q *gripql.Query) int {
+		pipe, err := g.Compiler().Compile(q.Count().Statements, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		n := 0
+		for r := range pipeline.Run(context.Background(), pipe, "./") {
+			n = int(r.GetCount())
+		}
+		return n
+	}
+
+	if err := g.DelEdge("e1"); err != nil {
+		t.Fatal(err)
+	}
+	if n := traverse(gripql.V().OutE()); n != len(es)-1 {
+		t.Errorf("expected %d edges after deleting one, found %d", len(es)-1, n)
+	}
+	if err := g.DelVertex("v0"); err != nil {
+		t.Fatal(err)
+	}
+	for _, addr := range shardAddrs {
+		if n := shardCount(t, addr, graph, gripql.E()); n != 0 {
+			t.Errorf("shard %s still holds %d edges", addr, n)
+		}
+	}
+	if n := traverse(gripql.V().InE()); n != 0 {
+		t.Errorf("expected no edges after deleting v0, found %d", n)
+	}
+}
+
+func TestShardSignals(t *testing.T) {
+	if shardAddrs == nil {
+		t.Skip("only for the sharded driver")
+	}
+	graph := "sharded-signals"
+	if err := gdb.AddGraph(graph); err != nil {
+		t.Fatal(err)
+	}
+	defer gdb.DeleteGraph(graph)
+	g, err := gdb.Graph(graph)
+	if err != nil {
+		t.Fatal(err)
+	}
+	vs := []*gdbi.Vertex{}
+	for i := 0; i < 20; i++ {
+		vs = append(vs, &gdbi.Vertex{ID: fmt.Sprintf("v%d", i), Label: "Node", Data: map[string]interface{}{}})
+	}
+	if err := g.AddVertex(vs); err != nil {
+		t.Fatal(err)
+	}
+	// lookups on the shard without the graph fail
+	cli, err := gripql.Connect(rpc.ConfigWithDefaults(shardAddrs[1]), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cli.Close()
+	if err := cli.DeleteGraph(graph); err != nil {
+		t.Fatal(err)
+	}
+
+	lookups := map[string]func(context.Context, chan gdbi.ElementLookup) chan gdbi.ElementLookup{
+		"GetVertexChannel": func(ctx context.Context, req chan gdbi.ElementLookup) chan gdbi.ElementLookup {
+			return g.GetVertexChannel(ctx, req, true)
+		},
+		"GetOutChannel": func(ctx context.Context, req chan gdbi.ElementLookup) chan gdbi.ElementLookup {
+			return g.GetOutChannel(ctx, req, true, false, nil)
+		},
+	}
+	for name, lookup := range lookups {
+		ctx, cancel := gdbi.WithQueryErrors(context.Background())
+		req := make(chan gdbi.ElementLookup, 100)
+		req <- gdbi.ElementLookup{Ref: &gdbi.BaseTraveler{Signal: &gdbi.Signal{ID: 1}}}
+		for _, v := range vs {
+			req <- gdbi.ElementLookup{ID: v.ID, Ref: &gdbi.BaseTraveler{}}
+		}
+		req <- gdbi.ElementLookup{Ref: &gdbi.BaseTraveler{Signal: &gdbi.Signal{ID: 2}}}
+		close(req)
+		signals := []int{}
+		for res := range lookup(ctx, req) {
+			if res.IsSignal() {
+				signals = append(signals, res.Ref.GetSignal().ID)
+			}
+		}
+		if len(signals) != 2 || signals[0] != 1 || signals[1] != 2 {
+			t.Errorf("%s: unexpected signals %v", name, signals)
+		}
+		if gdbi.QueryError(ctx) == nil {
+			t.Errorf("%s: the failed lookup wasn't reported", name)
+		}
+		cancel()
+	}
+}
+
+func TestShardFailures(t *testing.T) {
+	if shardAddrs == nil {
+		t.Skip("only for the sharded driver")
+	}
+	graph := "sharded-failures"
+	if err := gdb.AddGraph(graph); err != nil {
+		t.Fatal(err)
+	}
+	clients := []gripql.Client{}
+	for _, addr := range shardAddrs {
+		cli, err := gripql.Connect(rpc.ConfigWithDefaults(addr), true)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer cli.Close()
+		clients = append(clients, cli)
+	}
+	defer func() {
+		for _, cli := range clients {
+			cli.DeleteGraph(graph)
+		}
+	}()
+	g, err := gdb.Graph(graph)
+	if err != nil {
+		t.Fatal(err)
+	}
+	vs := []*gdbi.Vertex{}
+	es := []*gdbi.Edge{}
+	for i := 0; i < 20; i++ {
+		vs = append(vs, &gdbi.Vertex{ID: fmt.Sprintf("v%d", i), Label: "Node", Data: map[string]interface{}{}})
+		if i > 0 {
+			es = append(es, &gdbi.Edge{ID: fmt.Sprintf("e%d", i), From: "v0", To: fmt.Sprintf("v%d", i), Label: "link", Data: map[string]interface{}{}})
+		}
+	}
+	if err := g.AddVertex(vs); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.AddEdge(es); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.AddVertexIndex("Node", "name", gripql.IndexType_FIELD_INDEX); err != nil {
+		t.Fatal(err)
+	}
+	indices := func() int {
+		n := 0
+		for range g.GetVertexIndexList() {
+			n++
+		}
+		return n
+	}
+	if n := indices(); n != 1 {
+		t.Errorf("expected 1 index, found %d", n)
+	}
+
+	// remove the graph from a shard holding copies of the edges of v0, but
+	// not v0 itself
+	failed := -1
+	edges := make([]int, len(shardAddrs))
+	for i, addr := range shardAddrs {
+		edges[i] = shardCount(t, addr, graph, gripql.E())
+		if failed == -1 && edges[i] > 0 && shardCount(t, addr, graph, gripql.V("v0")) == 0 {
+			failed = i
+		}
+	}
+	if failed == -1 {
+		t.Fatal("no shard holds only copies of the edges of v0")
+	}
+	if err := clients[failed].DeleteGraph(graph); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := g.DelVertex("v0"); err == nil {
+		t.Error("expected DelVertex to fail")
+	}
+	for i, addr := range shardAddrs {
+		if i == failed {
+			continue
+		}
+		if n := shardCount(t, addr, graph, gripql.E()); n != edges[i] {
+			t.Errorf("shard %s holds %d edges after a failed DelVertex, expected %d", addr, n, edges[i])
+		}
+	}
+	if n := indices(); n != 0 {
+		t.Errorf("expected no indices with a missing shard, found %d", n)
+	}
+	for _, name := range gdb.ListGraphs() {
+		if name == graph {
+			t.Error("graph missing on a shard was listed")
+		}
+	}
+	if _, err := gdb.Graph(graph); err == nil {
+		t.Error("expected error opening a graph missing on a shard")
+	}
+}
@@ -0,0 +1,258 @@
+// Package arrow encodes query results as Arrow IPC streams, so that clients
+// can load them into data frames without decoding each result
+package arrow
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	arrowlib "github.com/apache/arrow/go/v12/arrow"
+	"github.com/apache/arrow/go/v12/arrow/array"
+	"github.com/apache/arrow/go/v12/arrow/ipc"
+	"github.com/apache/arrow/go/v12/arrow/memory"
+	"github.com/bmeg/grip/gripql"
+)
+
+// BatchSize is the number of results in each record batch sent by Send
+const BatchSize = 1000
+
+// Reserved columns of vertex and edge rows
+const (
+	GidColumn   = "_gid"
+	LabelColumn = "_label"
+	FromColumn  = "_from"
+	ToColumn    = "_to"
+)
+
+// Row flattens a query result into a row of named values. Vertices and edges
+// have the reserved columns followed by their data fields, with nested maps
+// flattened into dotted names. The columns of each selection are prefixed
+// with the name of its mark. Counts, aggregations and paths have count,
+// name/key/value and path columns, and renders that are not maps a render
+// column.
+func Row(res *gripql.QueryResult) map[string]interface{} {
+	row := map[string]interface{}{}
+	switch r := res.GetResult().(type) {
+	case *gripql.QueryResult_Vertex:
+		vertexRow("", r.Vertex, row)
+	case *gripql.QueryResult_Edge:
+		edgeRow("", r.Edge, row)
+	case *gripql.QueryResult_Selections:
+		for mark, s := range r.Selections.GetSelections() {
+			if v := s.GetVertex(); v != nil {
+				vertexRow(mark+".", v, row)
+			} else if e := s.GetEdge(); e != nil {
+				edgeRow(mark+".", e, row)
+			}
+		}
+	case *gripql.QueryResult_Render:
+		v := r.Render.AsInterface()
+		if m, ok := v.(map[string]interface{}); ok {
+			flatten("", m, row)
+		} else {
+			row["render"] = v
+		}
+	case *gripql.QueryResult_Count:
+		row["count"] = float64(r.Count)
+	case *gripql.QueryResult_Aggregations:
+		row["name"] = r.Aggregations.Name
+		row["key"] = r.Aggregations.Key.AsInterface()
+		row["value"] = r.Aggregations.Value
+	case *gripql.QueryResult_Path:
+		row["path"] = r.Path.AsSlice()
+	}
+	return row
+}
+
+func vertexRow(prefix string, v *gripql.Vertex, row map[string]interface{}) {
+	row[prefix+GidColumn] = v.Gid
+	row[prefix+LabelColumn] = v.Label
+	flatten(prefix, v.GetDataMap(), row)
+}
+
+func edgeRow(prefix string, e *gripql.Edge, row map[string]interface{}) {
+	row[prefix+GidColumn] = e.Gid
+	row[prefix+LabelColumn] = e.Label
+	row[prefix+FromColumn] = e.From
+	row[prefix+ToColumn] = e.To
+	flatten(prefix, e.GetDataMap(), row)
+}
+
+func flatten(prefix string, data map[string]interface{}, row map[string]interface{}) {
+	for k, v := range data {
+		if m, ok := v.(map[string]interface{}); ok {
+			flatten(prefix+k+".", m, row)
+			continue
+		}
+		row[prefix+k] = v
+	}
+}
+
+// Encode writes rows as an Arrow IPC stream holding the schema and one record
+// batch. Columns are sorted by name. A column is a double or a boolean column
+// if all its values are numbers or booleans, and otherwise a string column,
+// with values other than strings stored as JSON. Missing values are null.
+func Encode(rows []map[string]interface{}) ([]byte, error) {
+	names := []string{}
+	types := map[string]arrowlib.DataType{}
+	for _, row := range rows {
+		for k, v := range row {
+			if v == nil {
+				if _, ok := types[k]; !ok {
+					types[k] = nil
+					names = append(names, k)
+				}
+				continue
+			}
+			t := valueType(v)
+			prev, ok := types[k]
+			switch {
+			case !ok:
+				names = append(names, k)
+				types[k] = t
+			case prev == nil:
+				types[k] = t
+			case prev != t:
+				types[k] = arrowlib.BinaryTypes.String
+			}
+		}
+	}
+	sort.Strings(names)
+	fields := make([]arrowlib.Field, len(names))
+	for i, n := range names {
+		t := types[n]
+		if t == nil {
+			t = arrowlib.BinaryTypes.String
+		}
+		fields[i] = arrowlib.Field{Name: n, Type: t, Nullable: true}
+	}
+	schema := arrowlib.NewSchema(fields, nil)
+
+	mem := memory.NewGoAllocator()
+	builder := array.NewRecordBuilder(mem, schema)
+	defer builder.Release()
+	for i, n := range names {
+		col := builder.Field(i)
+		for _, row := range rows {
+			v, ok := row[n]
+			if !ok || v == nil {
+				col.AppendNull()
+				continue
+			}
+			switch b := col.(type) {
+			case *array.Float64Builder:
+				b.Append(v.(float64))
+			case *array.BooleanBuilder:
+				b.Append(v.(bool))
+			case *array.StringBuilder:
+				s, err := formatString(v)
+				if err != nil {
+					return nil, fmt.Errorf("column %s: %v", n, err)
+				}
+				b.Append(s)
+			}
+		}
+	}
+	rec := builder.NewRecord()
+	defer rec.Release()
+
+	buf := &bytes.Buffer{}
+	w := ipc.NewWriter(buf, ipc.WithSchema(schema), ipc.WithAllocator(mem))
+	if err := w.Write(rec); err != nil {
+		w.Close()
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func valueType(v interface{}) arrowlib.DataType {
+	switch v.(type) {
+	case float64:
+		return arrowlib.PrimitiveTypes.Float64
+	case bool:
+		return arrowlib.FixedWidthTypes.Boolean
+	}
+	return arrowlib.BinaryTypes.String
+}
+
+func formatString(v interface{}) (string, error) {
+	if s, ok := v.(string); ok {
+		return s, nil
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// Decode reads the rows of an Arrow IPC stream written by Encode. Null values
+// are left out of the rows.
+func Decode(data []byte) ([]map[string]interface{}, error) {
+	r, err := ipc.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Release()
+	out := []map[string]interface{}{}
+	for r.Next() {
+		rec := r.Record()
+		rows := make([]map[string]interface{}, rec.NumRows())
+		for i := range rows {
+			rows[i] = map[string]interface{}{}
+		}
+		for c, col := range rec.Columns() {
+			name := rec.ColumnName(c)
+			for i := range rows {
+				if col.IsNull(i) {
+					continue
+				}
+				switch a := col.(type) {
+				case *array.Float64:
+					rows[i][name] = a.Value(i)
+				case *array.Boolean:
+					rows[i][name] = a.Value(i)
+				case *array.String:
+					rows[i][name] = a.Value(i)
+				default:
+					return nil, fmt.Errorf("column %s: unsupported type %s", name, col.DataType())
+				}
+			}
+		}
+		out = append(out, rows...)
+	}
+	return out, r.Err()
+}
+
+// Send reads the results of a query and sends them as Arrow results of up to
+// BatchSize rows. The results are drained if sending fails, and the first
+// error is returned.
+func Send(in <-chan *gripql.QueryResult, send func(*gripql.QueryResult) error) error {
+	var err error
+	rows := make([]map[string]interface{}, 0, BatchSize)
+	flush := func() {
+		if err == nil && len(rows) > 0 {
+			var b []byte
+			if b, err = Encode(rows); err == nil {
+				err = send(&gripql.QueryResult{Result: &gripql.QueryResult_Arrow{Arrow: b}})
+			}
+		}
+		rows = rows[:0]
+	}
+	for res := range in {
+		if err != nil {
+			continue
+		}
+		rows = append(rows, Row(res))
+		if len(rows) >= BatchSize {
+			flush()
+		}
+	}
+	flush()
+	return err
+}
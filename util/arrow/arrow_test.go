@@ -0,0 +1,99 @@
+package arrow
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/bmeg/grip/gripql"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestRoundTrip(t *testing.T) {
+	results := []*gripql.QueryResult{
+		{Result: &gripql.QueryResult_Vertex{Vertex: &gripql.Vertex{
+			Gid: "a", Label: "Person",
+			Data: mustStruct(map[string]interface{}{"age": 30.0, "address": map[string]interface{}{"city": "Portland"}}),
+		}}},
+		{Result: &gripql.QueryResult_Vertex{Vertex: &gripql.Vertex{
+			Gid: "b", Label: "Person",
+			Data: mustStruct(map[string]interface{}{"age": "unknown", "ok": true, "tags": []interface{}{"x", 1.0}}),
+		}}},
+		{Result: &gripql.QueryResult_Edge{Edge: &gripql.Edge{Gid: "ab", Label: "knows", From: "a", To: "b"}}},
+	}
+	in := make(chan *gripql.QueryResult, len(results))
+	for _, r := range results {
+		in <- r
+	}
+	close(in)
+
+	sent := []*gripql.QueryResult{}
+	err := Send(in, func(r *gripql.QueryResult) error {
+		sent = append(sent, r)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sent) != 1 {
+		t.Fatalf("expected one batch, got %d", len(sent))
+	}
+	rows, err := Decode(sent[0].GetArrow())
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []map[string]interface{}{
+		{"_gid": "a", "_label": "Person", "age": "30", "address.city": "Portland"},
+		{"_gid": "b", "_label": "Person", "age": "unknown", "ok": true, "tags": `["x",1]`},
+		{"_gid": "ab", "_label": "knows", "_from": "a", "_to": "b"},
+	}
+	if !reflect.DeepEqual(rows, expected) {
+		t.Errorf("unexpected rows:\n%v\n%v", rows, expected)
+	}
+}
+
+func TestSendBatches(t *testing.T) {
+	in := make(chan *gripql.QueryResult, BatchSize+10)
+	for i := 0; i < BatchSize+10; i++ {
+		in <- &gripql.QueryResult{Result: &gripql.QueryResult_Count{Count: uint32(i)}}
+	}
+	close(in)
+	counts := []int{}
+	err := Send(in, func(r *gripql.QueryResult) error {
+		rows, err := Decode(r.GetArrow())
+		if err != nil {
+			return err
+		}
+		if len(rows) > 0 && rows[0]["count"] != float64(len(counts)*BatchSize) {
+			return fmt.Errorf("unexpected first row: %v", rows[0])
+		}
+		counts = append(counts, len(rows))
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(counts, []int{BatchSize, 10}) {
+		t.Errorf("unexpected batches: %v", counts)
+	}
+
+	in = make(chan *gripql.QueryResult, 3)
+	for i := 0; i < 3; i++ {
+		in <- &gripql.QueryResult{Result: &gripql.QueryResult_Count{Count: uint32(i)}}
+	}
+	close(in)
+	if err := Send(in, func(*gripql.QueryResult) error { return fmt.Errorf("closed") }); err == nil {
+		t.Error("expected send error")
+	}
+	if len(in) != 0 {
+		t.Error("results were not drained")
+	}
+}
+
+func mustStruct(m map[string]interface{}) *structpb.Struct {
+	s, err := structpb.NewStruct(m)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
@@ -0,0 +1,241 @@
+// Package checkpoint records the progress of a graph load, so a load that was
+// interrupted can be resumed without sending its input again.
+package checkpoint
+
+import (
+	"crypto/sha1"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/bmeg/grip/gripql"
+	"github.com/bmeg/grip/log"
+)
+
+// DefaultBatchSize is the number of elements committed together
+const DefaultBatchSize = 100000
+
+// Checkpoint is stored as a JSON sidecar file, and lists how many elements of
+// each input have been committed to the graph
+type Checkpoint struct {
+	Graph  string            `json:"graph"`
+	Inputs map[string]*Input `json:"inputs"`
+	// Number of elements sent in each commit
+	BatchSize int `json:"-"`
+	path      string
+}
+
+// Input is the progress of a single file or other source of elements.
+// Elements counts the elements of the input in the order they were read,
+// including those skipped by the graph. Offset is the Offset of the last
+// committed element, for inputs that can seek.
+type Input struct {
+	Elements int64     `json:"elements"`
+	Vertices int64     `json:"vertices"`
+	Edges    int64     `json:"edges"`
+	Offset   int64     `json:"offset,omitempty"`
+	Size     int64     `json:"size,omitempty"`
+	Done     bool      `json:"done"`
+	Updated  time.Time `json:"updated"`
+}
+
+// Element is a graph element read from an input. Offset is where the element
+// ends in the input, such as the byte offset of the end of its line. It is 0
+// for inputs that can't seek.
+type Element struct {
+	*gripql.GraphElement
+	Offset int64
+}
+
+// OpenFunc opens the element stream of an input, starting after the element
+// that ended at offset. Inputs are opened at offset 0 to read them from the
+// start, which is always the case for inputs that can't seek.
+type OpenFunc func(offset int64) (chan Element, error)
+
+// Elements wraps the elements of an input that can't seek
+func Elements(in chan *gripql.GraphElement) chan Element {
+	out := make(chan Element, cap(in))
+	go func() {
+		for e := range in {
+			out <- Element{GraphElement: e}
+		}
+		close(out)
+	}()
+	return out
+}
+
+// CommitFunc writes a batch of elements to the graph, returning once they
+// are stored
+type CommitFunc func([]*gripql.GraphElement) error
+
+// Open reads the checkpoint at path. A new checkpoint is returned if the
+// file doesn't exist
+func Open(path string, graph string) (*Checkpoint, error) {
+	c := &Checkpoint{Graph: graph, Inputs: map[string]*Input{}, BatchSize: DefaultBatchSize, path: path}
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return c, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, c); err != nil {
+		return nil, fmt.Errorf("reading checkpoint %s: %v", path, err)
+	}
+	if c.Graph != graph {
+		return nil, fmt.Errorf("checkpoint %s is for graph '%s', not '%s'", path, c.Graph, graph)
+	}
+	if c.Inputs == nil {
+		c.Inputs = map[string]*Input{}
+	}
+	return c, nil
+}
+
+// Path returns the location of the checkpoint file
+func (c *Checkpoint) Path() string {
+	return c.path
+}
+
+// Input returns the recorded progress of an input
+func (c *Checkpoint) Input(name string) Input {
+	if in, ok := c.Inputs[name]; ok {
+		return *in
+	}
+	return Input{}
+}
+
+// Save writes the checkpoint file. The file is replaced atomically, so an
+// interrupted save leaves the previous checkpoint in place
+func (c *Checkpoint) Save() error {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp, err := ioutil.TempFile(filepath.Dir(c.path), filepath.Base(c.path)+".tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), c.path)
+}
+
+// Load streams an input to commit in batches, recording the progress after
+// each commit. Inputs that can seek are opened after the last committed
+// element. The elements of other inputs that were committed by an earlier run
+// are read and dropped. An input that was finished is not opened. Local files
+// that changed size since the checkpoint was recorded are an error.
+//
+// Edges with a blank id are given one derived from the input name and the
+// position of the edge, so edges sent again after a resume replace the
+// copies that were already stored.
+func (c *Checkpoint) Load(name string, open OpenFunc, commit CommitFunc) (Input, error) {
+	in := c.Input(name)
+	if info, err := os.Stat(name); err == nil && !info.IsDir() {
+		if in.Size != 0 && in.Size != info.Size() {
+			return in, fmt.Errorf("%s has changed since the checkpoint was recorded", name)
+		}
+		in.Size = info.Size()
+	}
+	if in.Done {
+		log.WithFields(log.Fields{"input": name, "elements": in.Elements}).Info("Skipping loaded input")
+		return in, nil
+	}
+	elems, err := open(in.Offset)
+	if err != nil {
+		return in, err
+	}
+	// pos counts the elements from the start of the input
+	var pos int64
+	if in.Offset > 0 {
+		pos = in.Elements
+		log.WithFields(log.Fields{"input": name, "elements": in.Elements, "offset": in.Offset}).Info("Resuming input")
+	} else if in.Elements > 0 {
+		log.WithFields(log.Fields{"input": name, "elements": in.Elements}).Info("Resuming input")
+	}
+
+	batchSize := c.BatchSize
+	if batchSize <= 0 {
+		batchSize = DefaultBatchSize
+	}
+	batch := make([]*gripql.GraphElement, 0, batchSize)
+	pending := Input{}
+	flush := func(done bool) error {
+		if len(batch) > 0 {
+			if err := commit(batch); err != nil {
+				return err
+			}
+		}
+		in.Elements += pending.Elements
+		in.Vertices += pending.Vertices
+		in.Edges += pending.Edges
+		if pending.Offset > 0 {
+			in.Offset = pending.Offset
+		}
+		in.Done = done
+		in.Updated = time.Now()
+		saved := in
+		c.Inputs[name] = &saved
+		batch = batch[:0]
+		pending = Input{}
+		return c.Save()
+	}
+
+	for e := range elems {
+		pos++
+		if pos <= in.Elements {
+			continue
+		}
+		pending.Elements++
+		pending.Offset = e.Offset
+		if e.Vertex != nil {
+			pending.Vertices++
+		} else if e.Edge != nil {
+			pending.Edges++
+			if e.Edge.Gid == "" {
+				e.Edge.Gid = edgeID(name, e.Offset, pos)
+			}
+		}
+		batch = append(batch, e.GraphElement)
+		if len(batch) >= batchSize {
+			if err := flush(false); err != nil {
+				go drain(elems)
+				return in, err
+			}
+		}
+	}
+	if err := flush(true); err != nil {
+		return in, err
+	}
+	return in, nil
+}
+
+// edgeID derives the id of an edge from the offset of the edge in its input,
+// or its position for inputs that can't seek
+func edgeID(name string, offset int64, pos int64) string {
+	key := fmt.Sprintf("%s:%d", name, pos)
+	if offset > 0 {
+		key = fmt.Sprintf("%s@%d", name, offset)
+	}
+	return fmt.Sprintf("%x", sha1.Sum([]byte(key)))
+}
+
+func drain(elems chan Element) {
+	for range elems {
+	}
+}
@@ -0,0 +1,171 @@
+package checkpoint
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/bmeg/grip/gripql"
+)
+
+func openElements(n int, opened *int) OpenFunc {
+	return func(int64) (chan Element, error) {
+		*opened++
+		out := make(chan *gripql.GraphElement)
+		go func() {
+			for i := 0; i < n; i++ {
+				if i%5 == 4 {
+					out <- &gripql.GraphElement{Edge: &gripql.Edge{Gid: fmt.Sprintf("e%d", i)}}
+				} else {
+					out <- &gripql.GraphElement{Vertex: &gripql.Vertex{Gid: fmt.Sprintf("v%d", i)}}
+				}
+			}
+			close(out)
+		}()
+		return Elements(out), nil
+	}
+}
+
+// seekElements is an input of n edges with blank ids, where element i ends
+// at offset 10*(i+1). read counts the elements that were read.
+func seekElements(n int, read *int) OpenFunc {
+	return func(offset int64) (chan Element, error) {
+		out := make(chan Element)
+		go func() {
+			for i := int(offset / 10); i < n; i++ {
+				*read++
+				out <- Element{&gripql.GraphElement{Edge: &gripql.Edge{From: fmt.Sprint(i)}}, int64(10 * (i + 1))}
+			}
+			close(out)
+		}()
+		return out, nil
+	}
+}
+
+func TestResume(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "load.checkpoint.json")
+	cp, err := Open(path, "graph")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cp.BatchSize = 10
+
+	stored := []string{}
+	commits := 0
+	failAt := 2
+	commit := func(batch []*gripql.GraphElement) error {
+		commits++
+		if commits == failAt {
+			return fmt.Errorf("connection lost")
+		}
+		for _, e := range batch {
+			if e.Vertex != nil {
+				stored = append(stored, e.Vertex.Gid)
+			} else {
+				stored = append(stored, e.Edge.Gid)
+			}
+		}
+		return nil
+	}
+
+	opened := 0
+	if _, err := cp.Load("input", openElements(25, &opened), commit); err == nil {
+		t.Fatal("expected commit error")
+	}
+	if len(stored) != 10 {
+		t.Fatalf("expected 10 stored elements, got %d", len(stored))
+	}
+
+	cp, err = Open(path, "graph")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cp.BatchSize = 10
+	in := cp.Input("input")
+	if in.Elements != 10 || in.Vertices != 8 || in.Edges != 2 || in.Done {
+		t.Errorf("unexpected checkpoint: %+v", in)
+	}
+
+	failAt = -1
+	in, err = cp.Load("input", openElements(25, &opened), commit)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !in.Done || in.Elements != 25 || in.Vertices != 20 || in.Edges != 5 {
+		t.Errorf("unexpected checkpoint: %+v", in)
+	}
+	if len(stored) != 25 {
+		t.Fatalf("expected 25 stored elements, got %d", len(stored))
+	}
+	for i, id := range stored {
+		if id[1:] != fmt.Sprint(i) {
+			t.Errorf("element %d stored out of order: %s", i, id)
+		}
+	}
+
+	// a finished input isn't opened again
+	if _, err := cp.Load("input", openElements(25, &opened), commit); err != nil {
+		t.Fatal(err)
+	}
+	if opened != 2 || len(stored) != 25 {
+		t.Errorf("finished input was loaded again")
+	}
+
+	if _, err := Open(path, "other"); err == nil {
+		t.Error("expected error opening checkpoint of another graph")
+	}
+}
+
+func TestResumeSeek(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "load.checkpoint.json")
+	cp, err := Open(path, "graph")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cp.BatchSize = 10
+
+	ids := map[string]string{}
+	commits := 0
+	commit := func(batch []*gripql.GraphElement) error {
+		commits++
+		if commits == 2 {
+			return fmt.Errorf("connection lost")
+		}
+		for _, e := range batch {
+			ids[e.Edge.From] = e.Edge.Gid
+		}
+		return nil
+	}
+	failed := 0
+	if _, err := cp.Load("input", seekElements(25, &failed), commit); err == nil {
+		t.Fatal("expected commit error")
+	}
+	if in := cp.Input("input"); in.Elements != 10 || in.Offset != 100 {
+		t.Errorf("unexpected checkpoint: %+v", in)
+	}
+	first := ids["3"]
+
+	read := 0
+	in, err := cp.Load("input", seekElements(25, &read), commit)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if read != 15 {
+		t.Errorf("expected the resumed load to read 15 elements, read %d", read)
+	}
+	if !in.Done || in.Elements != 25 || in.Edges != 25 || in.Offset != 250 {
+		t.Errorf("unexpected checkpoint: %+v", in)
+	}
+	// blank ids are derived from the offset of the edge, so they don't change
+	// when the edge is sent again
+	if ids["3"] != first || first != edgeID("input", 40, 4) {
+		t.Errorf("unexpected id of edge 3: %s", ids["3"])
+	}
+	unique := map[string]bool{}
+	for _, id := range ids {
+		unique[id] = true
+	}
+	if len(ids) != 25 || len(unique) != 25 {
+		t.Errorf("expected 25 distinct edge ids: %v", ids)
+	}
+}
@@ -0,0 +1,312 @@
+package export
+
+import (
+	"bytes"
+	"encoding/xml"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/bmeg/grip/gripql"
+	"github.com/knakk/rdf"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+const testSchema = `
+graph: test
+vertices:
+- gid: Person
+  label: Vertex
+  data:
+    name: STRING
+    age: NUMERIC
+    tags: [STRING]
+    address:
+      city: STRING
+    visits: [{place: STRING}]
+edges:
+- gid: (Person)--knows->(Person)
+  label: knows
+  from: Person
+  to: Person
+  data:
+    since: NUMERIC
+`
+
+func testGraph(t *testing.T) (Fields, []*gripql.Vertex, []*gripql.Edge) {
+	schema, err := gripql.ParseYAMLGraph([]byte(testSchema))
+	if err != nil {
+		t.Fatal(err)
+	}
+	d1, _ := structpb.NewStruct(map[string]interface{}{
+		"name": "Ann <& \"quoted\">", "age": 30, "tags": []interface{}{"a", "b"},
+		"address": map[string]interface{}{"city": "Portland"},
+		"visits":  []interface{}{map[string]interface{}{"place": "x"}},
+	})
+	d2, _ := structpb.NewStruct(map[string]interface{}{"name": "Bob", "age": "unknown"})
+	e, _ := structpb.NewStruct(map[string]interface{}{"since": 2001})
+	vertices := []*gripql.Vertex{
+		{Gid: "1", Label: "Person", Data: d1},
+		{Gid: "2", Label: "Person", Data: d2},
+	}
+	edges := []*gripql.Edge{{Gid: "e1", Label: "knows", From: "1", To: "2", Data: e}}
+	return SchemaFields(schema), vertices, edges
+}
+
+func TestSchemaFields(t *testing.T) {
+	fields, _, _ := testGraph(t)
+	expected := []Field{
+		{Name: "address.city", Type: "STRING"},
+		{Name: "age", Type: "NUMERIC"},
+		{Name: "name", Type: "STRING"},
+		{Name: "tags", Type: "STRING", List: true},
+		{Name: "visits", Type: "STRING"},
+	}
+	if !reflect.DeepEqual(fields.Vertex, expected) {
+		t.Errorf("unexpected vertex fields: %v", fields.Vertex)
+	}
+	if !reflect.DeepEqual(fields.Edge, []Field{{Name: "since", Type: "NUMERIC"}}) {
+		t.Errorf("unexpected edge fields: %v", fields.Edge)
+	}
+}
+
+func writeAll(t *testing.T, w Writer, vertices []*gripql.Vertex, edges []*gripql.Edge) {
+	for _, v := range vertices {
+		if err := w.WriteVertex(v); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, e := range edges {
+		if err := w.WriteEdge(e); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+type xmlData struct {
+	Key   string `xml:"key,attr"`
+	Value string `xml:",chardata"`
+}
+
+func TestGraphML(t *testing.T) {
+	fields, vertices, edges := testGraph(t)
+	buf := &bytes.Buffer{}
+	w, err := NewGraphMLWriter(buf, fields)
+	if err != nil {
+		t.Fatal(err)
+	}
+	writeAll(t, w, vertices, edges)
+
+	doc := struct {
+		Keys []struct {
+			ID   string `xml:"id,attr"`
+			Name string `xml:"attr.name,attr"`
+			Type string `xml:"attr.type,attr"`
+		} `xml:"key"`
+		Nodes []struct {
+			ID   string    `xml:"id,attr"`
+			Data []xmlData `xml:"data"`
+		} `xml:"graph>node"`
+		Edges []struct {
+			Source string    `xml:"source,attr"`
+			Target string    `xml:"target,attr"`
+			Data   []xmlData `xml:"data"`
+		} `xml:"graph>edge"`
+	}{}
+	if err := xml.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("invalid GraphML: %v\n%s", err, buf.String())
+	}
+	keys := map[string]string{}
+	for _, k := range doc.Keys {
+		keys[k.ID] = k.Name
+	}
+	if len(doc.Nodes) != 2 || len(doc.Edges) != 1 {
+		t.Fatalf("wrong number of elements: %s", buf.String())
+	}
+	values := map[string]string{}
+	for _, d := range doc.Nodes[0].Data {
+		values[keys[d.Key]] = d.Value
+	}
+	expected := map[string]string{
+		"label": "Person", "name": "Ann <& \"quoted\">", "age": "30", "tags": `["a","b"]`,
+		"address.city": "Portland", "visits": `[{"place":"x"}]`,
+	}
+	if !reflect.DeepEqual(values, expected) {
+		t.Errorf("unexpected node data: %v", values)
+	}
+	// values that don't match the schema type are left out
+	for _, d := range doc.Nodes[1].Data {
+		if keys[d.Key] == "age" {
+			t.Errorf("mismatched value exported: %v", d)
+		}
+	}
+	if doc.Edges[0].Source != "1" || doc.Edges[0].Target != "2" {
+		t.Errorf("unexpected edge: %v", doc.Edges[0])
+	}
+}
+
+func TestGEXF(t *testing.T) {
+	fields, vertices, edges := testGraph(t)
+	buf := &bytes.Buffer{}
+	w, err := NewGEXFWriter(buf, fields)
+	if err != nil {
+		t.Fatal(err)
+	}
+	writeAll(t, w, vertices, edges)
+
+	doc := struct {
+		Nodes []struct {
+			ID    string `xml:"id,attr"`
+			Label string `xml:"label,attr"`
+		} `xml:"graph>nodes>node"`
+		Edges []struct {
+			Label string `xml:"label,attr"`
+		} `xml:"graph>edges>edge"`
+	}{}
+	if err := xml.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("invalid GEXF: %v\n%s", err, buf.String())
+	}
+	if len(doc.Nodes) != 2 || doc.Nodes[0].Label != "Person" || len(doc.Edges) != 1 || doc.Edges[0].Label != "knows" {
+		t.Errorf("unexpected document: %s", buf.String())
+	}
+	if err := w.WriteVertex(vertices[0]); err == nil {
+		t.Errorf("expected error writing vertex after edges")
+	}
+}
+
+func TestNeo4j(t *testing.T) {
+	fields, vertices, edges := testGraph(t)
+	nodes := &bytes.Buffer{}
+	rels := &bytes.Buffer{}
+	w, err := NewNeo4jWriter(nodes, rels, fields)
+	if err != nil {
+		t.Fatal(err)
+	}
+	writeAll(t, w, vertices, edges)
+	expectedNodes := strings.Join([]string{
+		"gid:ID,:LABEL,address.city,age:double,name,tags:string[],visits",
+		`1,Person,Portland,30,"Ann <& ""quoted"">",a;b,"[{""place"":""x""}]"`,
+		"2,Person,,,Bob,,",
+		"",
+	}, "\n")
+	if nodes.String() != expectedNodes {
+		t.Errorf("unexpected nodes:\n%s", nodes.String())
+	}
+	expectedRels := "gid,:START_ID,:END_ID,:TYPE,since:double\ne1,1,2,knows,2001\n"
+	if rels.String() != expectedRels {
+		t.Errorf("unexpected relationships:\n%s", rels.String())
+	}
+}
+
+func TestParquet(t *testing.T) {
+	schema, err := gripql.ParseYAMLGraph([]byte(testSchema))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, vertices, edges := testGraph(t)
+	dir := t.TempDir()
+	w, err := NewParquetWriter(dir, schema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	writeAll(t, w, vertices, edges)
+
+	files, err := ParquetFiles(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedFiles := []string{filepath.Join(dir, "Person.vertex.parquet"), filepath.Join(dir, "knows.edge.parquet")}
+	if !reflect.DeepEqual(files, expectedFiles) {
+		t.Fatalf("unexpected files: %v", files)
+	}
+
+	elems := []*gripql.GraphElement{}
+	for _, f := range files {
+		stream, err := StreamParquet(f)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for e := range stream {
+			elems = append(elems, e)
+		}
+	}
+	if len(elems) != 3 {
+		t.Fatalf("expected 3 elements, got %d", len(elems))
+	}
+	expected := map[string]interface{}{
+		"name": "Ann <& \"quoted\">", "age": 30.0, "tags": []interface{}{"a", "b"},
+		"address": map[string]interface{}{"city": "Portland"},
+		"visits":  `[{"place":"x"}]`,
+	}
+	if v := elems[0].Vertex; v.Gid != "1" || v.Label != "Person" || !reflect.DeepEqual(v.GetDataMap(), expected) {
+		t.Errorf("unexpected vertex: %v", v)
+	}
+	if v := elems[1].Vertex; !reflect.DeepEqual(v.GetDataMap(), map[string]interface{}{"name": "Bob"}) {
+		t.Errorf("unexpected vertex: %v", v)
+	}
+	e := elems[2].Edge
+	if e.Gid != "e1" || e.From != "1" || e.To != "2" || e.Label != "knows" || e.GetDataMap()["since"] != 2001.0 {
+		t.Errorf("unexpected edge: %v", e)
+	}
+}
+
+func TestRDF(t *testing.T) {
+	_, vertices, edges := testGraph(t)
+	conf := DefaultRDFConfig()
+	conf.Labels["Person"] = "http://example.org/people/"
+	for _, format := range []rdf.Format{rdf.NTriples, rdf.Turtle} {
+		buf := &bytes.Buffer{}
+		w, err := NewRDFWriter(buf, format == rdf.Turtle, conf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, v := range vertices {
+			if err := w.WriteVertex(v); err != nil {
+				t.Fatal(err)
+			}
+		}
+		for _, e := range edges {
+			if err := w.WriteEdge(e); err != nil {
+				t.Fatal(err)
+			}
+		}
+		w.WriteEdge(&gripql.Edge{Label: "knows", From: "1", To: "missing"})
+		if err := w.Close(); err != nil {
+			t.Fatal(err)
+		}
+
+		triples, err := rdf.NewTripleDecoder(bytes.NewReader(buf.Bytes()), format).DecodeAll()
+		if err != nil {
+			t.Fatalf("%s\n%s", err, buf.String())
+		}
+		found := map[string]bool{}
+		for _, tr := range triples {
+			found[tr.Subj.String()+" "+tr.Pred.String()+" "+tr.Obj.Serialize(rdf.NTriples)] = true
+		}
+		if len(triples) != 11 {
+			t.Errorf("expected 11 triples, got %d\n%s", len(triples), buf.String())
+		}
+		for _, expected := range []string{
+			"http://example.org/people/1 " + RDFType + " <urn:grip:vocab:Person>",
+			`http://example.org/people/1 urn:grip:vocab:name "Ann <& \"quoted\">"`,
+			`http://example.org/people/1 urn:grip:vocab:age "30"^^<http://www.w3.org/2001/XMLSchema#integer>`,
+			`http://example.org/people/1 urn:grip:vocab:tags "b"`,
+			`http://example.org/people/1 urn:grip:vocab:address.city "Portland"`,
+			`http://example.org/people/2 urn:grip:vocab:age "unknown"`,
+			"http://example.org/people/1 urn:grip:vocab:knows <http://example.org/people/2>",
+		} {
+			if !found[expected] {
+				t.Errorf("missing triple %s\n%s", expected, buf.String())
+			}
+		}
+	}
+
+	label, gid, ok := conf.ParseVertexIRI(conf.VertexIRI("City", "a/b c"))
+	if !ok || label != "City" || gid != "a/b c" {
+		t.Errorf("unexpected vertex IRI parse: %s %s", label, gid)
+	}
+}
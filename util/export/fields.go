@@ -0,0 +1,189 @@
+package export
+
+import (
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/bmeg/grip/gripql"
+)
+
+// Field is a flattened data field, such as "address.city"
+type Field struct {
+	Name string
+	// STRING, NUMERIC or BOOL
+	Type string
+	// The field holds a list of Type values
+	List bool
+}
+
+// Fields are the flattened vertex and edge data fields of a graph
+type Fields struct {
+	Vertex []Field
+	Edge   []Field
+}
+
+// SchemaFields flattens the vertex and edge fields of a graph schema. Nested
+// maps become dotted field names. Lists of maps, and fields whose type is
+// unknown, are exported as JSON strings.
+func SchemaFields(schema *gripql.Graph) Fields {
+	vFields := map[string]Field{}
+	for _, v := range schema.GetVertices() {
+		flattenSchema("", v.GetDataMap(), vFields)
+	}
+	eFields := map[string]Field{}
+	for _, e := range schema.GetEdges() {
+		flattenSchema("", e.GetDataMap(), eFields)
+	}
+	return Fields{Vertex: sortFields(vFields), Edge: sortFields(eFields)}
+}
+
+// LabelFields flattens the data fields of each vertex and edge label of a
+// graph schema
+func LabelFields(schema *gripql.Graph) (map[string][]Field, map[string][]Field) {
+	vertex := map[string][]Field{}
+	for _, v := range schema.GetVertices() {
+		fields := map[string]Field{}
+		flattenSchema("", v.GetDataMap(), fields)
+		vertex[v.Gid] = sortFields(fields)
+	}
+	edgeFields := map[string]map[string]Field{}
+	for _, e := range schema.GetEdges() {
+		if edgeFields[e.Label] == nil {
+			edgeFields[e.Label] = map[string]Field{}
+		}
+		flattenSchema("", e.GetDataMap(), edgeFields[e.Label])
+	}
+	edge := map[string][]Field{}
+	for label, fields := range edgeFields {
+		edge[label] = sortFields(fields)
+	}
+	return vertex, edge
+}
+
+func sortFields(fields map[string]Field) []Field {
+	out := make([]Field, 0, len(fields))
+	for _, f := range fields {
+		out = append(out, f)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+func flattenSchema(prefix string, data map[string]interface{}, out map[string]Field) {
+	for k, v := range data {
+		name := prefix + k
+		var f Field
+		switch t := v.(type) {
+		case map[string]interface{}:
+			flattenSchema(name+".", t, out)
+			continue
+		case []interface{}:
+			f = Field{Name: name, Type: gripql.FieldType_STRING.String()}
+			if len(t) > 0 {
+				if s, ok := t[0].(string); ok && scalarType(s) {
+					f = Field{Name: name, Type: s, List: true}
+				}
+			}
+		case string:
+			f = Field{Name: name, Type: t}
+			if !scalarType(t) {
+				f.Type = gripql.FieldType_STRING.String()
+			}
+		default:
+			f = Field{Name: name, Type: gripql.FieldType_STRING.String()}
+		}
+		// labels that disagree on a field's type are exported as strings
+		if prev, ok := out[name]; ok && prev != f {
+			f = Field{Name: name, Type: gripql.FieldType_STRING.String()}
+		}
+		out[name] = f
+	}
+}
+
+func scalarType(t string) bool {
+	return t == gripql.FieldType_STRING.String() || t == gripql.FieldType_NUMERIC.String() || t == gripql.FieldType_BOOL.String()
+}
+
+// flatten returns the values of the fields found in data, formatted as strings.
+// Lists are joined with listDelimiter, or formatted as JSON arrays if it is
+// empty. Values that don't match the field type are left out.
+func flatten(fields []Field, data map[string]interface{}, listDelimiter string) map[string]string {
+	out := map[string]string{}
+	for _, f := range fields {
+		v, ok := lookup(data, f.Name)
+		if !ok || v == nil {
+			continue
+		}
+		if f.List {
+			if s, ok := formatList(f.Type, v, listDelimiter); ok {
+				out[f.Name] = s
+			}
+			continue
+		}
+		if s, ok := formatValue(f.Type, v); ok {
+			out[f.Name] = s
+		}
+	}
+	return out
+}
+
+// formatList formats the items of a list that match the field type
+func formatList(fieldType string, v interface{}, listDelimiter string) (string, bool) {
+	l, ok := v.([]interface{})
+	if !ok {
+		return "", false
+	}
+	items := []string{}
+	values := []interface{}{}
+	for _, i := range l {
+		if s, ok := formatValue(fieldType, i); ok {
+			items = append(items, s)
+			values = append(values, i)
+		}
+	}
+	if listDelimiter == "" {
+		b, _ := json.Marshal(values)
+		return string(b), true
+	}
+	return strings.Join(items, listDelimiter), true
+}
+
+func lookup(data map[string]interface{}, name string) (interface{}, bool) {
+	if v, ok := data[name]; ok {
+		return v, true
+	}
+	parts := strings.SplitN(name, ".", 2)
+	if len(parts) < 2 {
+		return nil, false
+	}
+	m, ok := data[parts[0]].(map[string]interface{})
+	if !ok {
+		return nil, false
+	}
+	return lookup(m, parts[1])
+}
+
+func formatValue(fieldType string, v interface{}) (string, bool) {
+	switch fieldType {
+	case gripql.FieldType_NUMERIC.String():
+		if n, ok := v.(float64); ok {
+			return strconv.FormatFloat(n, 'g', -1, 64), true
+		}
+		return "", false
+	case gripql.FieldType_BOOL.String():
+		if b, ok := v.(bool); ok {
+			return strconv.FormatBool(b), true
+		}
+		return "", false
+	}
+	if s, ok := v.(string); ok {
+		return s, true
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return "", false
+	}
+	return string(b), true
+}
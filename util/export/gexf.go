@@ -0,0 +1,100 @@
+package export
+
+import (
+	"io"
+
+	"github.com/bmeg/grip/gripql"
+)
+
+// GEXFWriter writes a graph as GEXF 1.3
+type GEXFWriter struct {
+	xmlWriter
+	fields Fields
+}
+
+// NewGEXFWriter writes the GEXF header, declaring an attribute for each field
+func NewGEXFWriter(w io.Writer, fields Fields) (*GEXFWriter, error) {
+	g := &GEXFWriter{xmlWriter: xmlWriter{w: w}, fields: fields}
+	g.startEdge = func() error {
+		g.printf("    </nodes>\n    <edges>\n")
+		return g.err
+	}
+	g.printf("<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n")
+	g.printf("<gexf xmlns=\"http://gexf.net/1.3\" version=\"1.3\">\n")
+	g.printf("  <graph defaultedgetype=\"directed\" mode=\"static\">\n")
+	g.writeAttributes("node", fields.Vertex)
+	g.writeAttributes("edge", fields.Edge)
+	g.printf("    <nodes>\n")
+	return g, g.err
+}
+
+func (g *GEXFWriter) writeAttributes(class string, fields []Field) {
+	if len(fields) == 0 {
+		return
+	}
+	g.printf("    <attributes class=\"%s\">\n", class)
+	for i, f := range fields {
+		g.printf("      <attribute id=\"%d\" title=\"%s\" type=\"%s\"/>\n", i, xmlEscape(f.Name), gexfType(f))
+	}
+	g.printf("    </attributes>\n")
+}
+
+func gexfType(f Field) string {
+	if f.List {
+		return "string"
+	}
+	switch f.Type {
+	case gripql.FieldType_NUMERIC.String():
+		return "double"
+	case gripql.FieldType_BOOL.String():
+		return "boolean"
+	}
+	return "string"
+}
+
+// WriteVertex writes a node element. The vertex label is used as the node label
+func (g *GEXFWriter) WriteVertex(v *gripql.Vertex) error {
+	if err := g.checkVertex(); err != nil {
+		return err
+	}
+	g.printf("      <node id=\"%s\" label=\"%s\">\n", xmlEscape(v.Gid), xmlEscape(v.Label))
+	g.writeValues(g.fields.Vertex, flatten(g.fields.Vertex, v.GetDataMap(), ""))
+	g.printf("      </node>\n")
+	return g.err
+}
+
+// WriteEdge writes an edge element
+func (g *GEXFWriter) WriteEdge(e *gripql.Edge) error {
+	if err := g.beginEdges(); err != nil {
+		return err
+	}
+	g.printf("      <edge id=\"%s\" source=\"%s\" target=\"%s\" label=\"%s\">\n",
+		xmlEscape(e.Gid), xmlEscape(e.From), xmlEscape(e.To), xmlEscape(e.Label))
+	g.writeValues(g.fields.Edge, flatten(g.fields.Edge, e.GetDataMap(), ""))
+	g.printf("      </edge>\n")
+	return g.err
+}
+
+func (g *GEXFWriter) writeValues(fields []Field, values map[string]string) {
+	if len(values) == 0 {
+		return
+	}
+	g.printf("        <attvalues>\n")
+	for i, f := range fields {
+		if val, ok := values[f.Name]; ok {
+			g.printf("          <attvalue for=\"%d\" value=\"%s\"/>\n", i, xmlEscape(val))
+		}
+	}
+	g.printf("        </attvalues>\n")
+}
+
+// Close writes the end of the document
+func (g *GEXFWriter) Close() error {
+	if g.inEdges {
+		g.printf("    </edges>\n")
+	} else {
+		g.printf("    </nodes>\n")
+	}
+	g.printf("  </graph>\n</gexf>\n")
+	return g.err
+}
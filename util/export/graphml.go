@@ -0,0 +1,82 @@
+package export
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/bmeg/grip/gripql"
+)
+
+// GraphMLWriter writes a graph as GraphML
+type GraphMLWriter struct {
+	xmlWriter
+	fields Fields
+}
+
+// NewGraphMLWriter writes the GraphML header, declaring a key for each field
+func NewGraphMLWriter(w io.Writer, fields Fields) (*GraphMLWriter, error) {
+	g := &GraphMLWriter{xmlWriter: xmlWriter{w: w}, fields: fields}
+	g.printf("<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n")
+	g.printf("<graphml xmlns=\"http://graphml.graphdrawing.org/xmlns\">\n")
+	g.printf("  <key id=\"label\" for=\"node\" attr.name=\"label\" attr.type=\"string\"/>\n")
+	for i, f := range fields.Vertex {
+		g.printf("  <key id=\"v%d\" for=\"node\" attr.name=\"%s\" attr.type=\"%s\"/>\n", i, xmlEscape(f.Name), graphMLType(f))
+	}
+	g.printf("  <key id=\"edge_label\" for=\"edge\" attr.name=\"label\" attr.type=\"string\"/>\n")
+	for i, f := range fields.Edge {
+		g.printf("  <key id=\"e%d\" for=\"edge\" attr.name=\"%s\" attr.type=\"%s\"/>\n", i, xmlEscape(f.Name), graphMLType(f))
+	}
+	g.printf("  <graph id=\"G\" edgedefault=\"directed\">\n")
+	return g, g.err
+}
+
+func graphMLType(f Field) string {
+	if f.List {
+		return "string"
+	}
+	switch f.Type {
+	case gripql.FieldType_NUMERIC.String():
+		return "double"
+	case gripql.FieldType_BOOL.String():
+		return "boolean"
+	}
+	return "string"
+}
+
+// WriteVertex writes a node element
+func (g *GraphMLWriter) WriteVertex(v *gripql.Vertex) error {
+	if err := g.checkVertex(); err != nil {
+		return err
+	}
+	g.printf("    <node id=\"%s\">\n", xmlEscape(v.Gid))
+	g.printf("      <data key=\"label\">%s</data>\n", xmlEscape(v.Label))
+	g.writeData("v", g.fields.Vertex, flatten(g.fields.Vertex, v.GetDataMap(), ""))
+	g.printf("    </node>\n")
+	return g.err
+}
+
+// WriteEdge writes an edge element
+func (g *GraphMLWriter) WriteEdge(e *gripql.Edge) error {
+	if err := g.beginEdges(); err != nil {
+		return err
+	}
+	g.printf("    <edge id=\"%s\" source=\"%s\" target=\"%s\">\n", xmlEscape(e.Gid), xmlEscape(e.From), xmlEscape(e.To))
+	g.printf("      <data key=\"edge_label\">%s</data>\n", xmlEscape(e.Label))
+	g.writeData("e", g.fields.Edge, flatten(g.fields.Edge, e.GetDataMap(), ""))
+	g.printf("    </edge>\n")
+	return g.err
+}
+
+func (g *GraphMLWriter) writeData(prefix string, fields []Field, values map[string]string) {
+	for i, f := range fields {
+		if val, ok := values[f.Name]; ok {
+			g.printf("      <data key=\"%s\">%s</data>\n", fmt.Sprintf("%s%d", prefix, i), xmlEscape(val))
+		}
+	}
+}
+
+// Close writes the end of the document
+func (g *GraphMLWriter) Close() error {
+	g.printf("  </graph>\n</graphml>\n")
+	return g.err
+}
@@ -0,0 +1,87 @@
+package export
+
+import (
+	"encoding/csv"
+	"io"
+
+	"github.com/bmeg/grip/gripql"
+)
+
+// Neo4jArrayDelimiter separates the values of lists, and is the default
+// array delimiter of neo4j-admin import
+const Neo4jArrayDelimiter = ";"
+
+// Neo4jWriter writes a graph as node and relationship CSV files, in the
+// format used by neo4j-admin import
+type Neo4jWriter struct {
+	nodes  *csv.Writer
+	rels   *csv.Writer
+	fields Fields
+}
+
+// NewNeo4jWriter writes the CSV headers of the node and relationship files
+func NewNeo4jWriter(nodes io.Writer, rels io.Writer, fields Fields) (*Neo4jWriter, error) {
+	n := &Neo4jWriter{nodes: csv.NewWriter(nodes), rels: csv.NewWriter(rels), fields: fields}
+	header := []string{"gid:ID", ":LABEL"}
+	for _, f := range fields.Vertex {
+		header = append(header, f.Name+neo4jType(f))
+	}
+	if err := n.nodes.Write(header); err != nil {
+		return nil, err
+	}
+	header = []string{"gid", ":START_ID", ":END_ID", ":TYPE"}
+	for _, f := range fields.Edge {
+		header = append(header, f.Name+neo4jType(f))
+	}
+	if err := n.rels.Write(header); err != nil {
+		return nil, err
+	}
+	return n, nil
+}
+
+func neo4jType(f Field) string {
+	t := ""
+	switch f.Type {
+	case gripql.FieldType_NUMERIC.String():
+		t = ":double"
+	case gripql.FieldType_BOOL.String():
+		t = ":boolean"
+	}
+	if f.List {
+		if t == "" {
+			t = ":string"
+		}
+		t += "[]"
+	}
+	return t
+}
+
+func row(fields []Field, values map[string]string, prefix ...string) []string {
+	out := append([]string{}, prefix...)
+	for _, f := range fields {
+		out = append(out, values[f.Name])
+	}
+	return out
+}
+
+// WriteVertex writes a node row
+func (n *Neo4jWriter) WriteVertex(v *gripql.Vertex) error {
+	values := flatten(n.fields.Vertex, v.GetDataMap(), Neo4jArrayDelimiter)
+	return n.nodes.Write(row(n.fields.Vertex, values, v.Gid, v.Label))
+}
+
+// WriteEdge writes a relationship row
+func (n *Neo4jWriter) WriteEdge(e *gripql.Edge) error {
+	values := flatten(n.fields.Edge, e.GetDataMap(), Neo4jArrayDelimiter)
+	return n.rels.Write(row(n.fields.Edge, values, e.Gid, e.From, e.To, e.Label))
+}
+
+// Close flushes both files
+func (n *Neo4jWriter) Close() error {
+	n.nodes.Flush()
+	n.rels.Flush()
+	if err := n.nodes.Error(); err != nil {
+		return err
+	}
+	return n.rels.Error()
+}
@@ -0,0 +1,358 @@
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/bmeg/grip/gripql"
+	"github.com/bmeg/grip/log"
+	"github.com/bmeg/grip/util/parquet"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// Metadata keys of the Parquet files written by ParquetWriter
+const (
+	parquetElementKey = "grip.element"
+	parquetLabelKey   = "grip.label"
+	parquetFieldsKey  = "grip.fields"
+)
+
+// Reserved Parquet columns
+const (
+	gidColumn   = "_gid"
+	labelColumn = "_label"
+	fromColumn  = "_from"
+	toColumn    = "_to"
+)
+
+// ParquetWriter writes a Parquet file per vertex and edge label into a
+// directory. Vertex files are named <label>.vertex.parquet, and have _gid and
+// _label columns followed by the data fields of the label. Edge files are
+// named <label>.edge.parquet, and also have _from and _to columns. Lists are
+// stored as JSON strings.
+type ParquetWriter struct {
+	dir          string
+	fields       Fields
+	vertexFields map[string][]Field
+	edgeFields   map[string][]Field
+	files        map[string]*parquetFile
+}
+
+type parquetFile struct {
+	file   *os.File
+	writer *parquet.Writer
+	fields []Field
+}
+
+// NewParquetWriter creates the output directory. Labels that are missing from
+// the schema are written with the fields of all labels
+func NewParquetWriter(dir string, schema *gripql.Graph) (*ParquetWriter, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	vertexFields, edgeFields := LabelFields(schema)
+	return &ParquetWriter{
+		dir:          dir,
+		fields:       SchemaFields(schema),
+		vertexFields: vertexFields,
+		edgeFields:   edgeFields,
+		files:        map[string]*parquetFile{},
+	}, nil
+}
+
+func parquetType(f Field) parquet.Type {
+	if f.List {
+		return parquet.String
+	}
+	switch f.Type {
+	case gripql.FieldType_NUMERIC.String():
+		return parquet.Double
+	case gripql.FieldType_BOOL.String():
+		return parquet.Boolean
+	}
+	return parquet.String
+}
+
+func (p *ParquetWriter) file(element string, label string) (*parquetFile, error) {
+	key := element + ":" + label
+	if f, ok := p.files[key]; ok {
+		return f, nil
+	}
+	columns := []parquet.Column{{Name: gidColumn}, {Name: labelColumn}}
+	fields, ok := p.vertexFields[label]
+	if !ok {
+		fields = p.fields.Vertex
+	}
+	if element == "edge" {
+		columns = append(columns, parquet.Column{Name: fromColumn}, parquet.Column{Name: toColumn})
+		if fields, ok = p.edgeFields[label]; !ok {
+			fields = p.fields.Edge
+		}
+	}
+	for _, f := range fields {
+		columns = append(columns, parquet.Column{Name: f.Name, Type: parquetType(f)})
+	}
+	fieldsJSON, err := json.Marshal(fields)
+	if err != nil {
+		return nil, err
+	}
+	path := filepath.Join(p.dir, url.PathEscape(label)+"."+element+".parquet")
+	out, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+	w, err := parquet.NewWriter(out, columns, map[string]string{
+		parquetElementKey: element,
+		parquetLabelKey:   label,
+		parquetFieldsKey:  string(fieldsJSON),
+	})
+	if err != nil {
+		out.Close()
+		return nil, err
+	}
+	f := &parquetFile{file: out, writer: w, fields: fields}
+	p.files[key] = f
+	return f, nil
+}
+
+func parquetValues(fields []Field, data map[string]interface{}, row []interface{}) []interface{} {
+	for _, f := range fields {
+		var value interface{}
+		if v, ok := lookup(data, f.Name); ok && v != nil {
+			switch {
+			case f.List:
+				if s, ok := formatList(f.Type, v, ""); ok {
+					value = s
+				}
+			case f.Type == gripql.FieldType_NUMERIC.String():
+				if n, ok := v.(float64); ok {
+					value = n
+				}
+			case f.Type == gripql.FieldType_BOOL.String():
+				if b, ok := v.(bool); ok {
+					value = b
+				}
+			default:
+				if s, ok := formatValue(f.Type, v); ok {
+					value = s
+				}
+			}
+		}
+		row = append(row, value)
+	}
+	return row
+}
+
+// WriteVertex writes a row to the file of the vertex label
+func (p *ParquetWriter) WriteVertex(v *gripql.Vertex) error {
+	f, err := p.file("vertex", v.Label)
+	if err != nil {
+		return err
+	}
+	return f.writer.Write(parquetValues(f.fields, v.GetDataMap(), []interface{}{v.Gid, v.Label}))
+}
+
+// WriteEdge writes a row to the file of the edge label
+func (p *ParquetWriter) WriteEdge(e *gripql.Edge) error {
+	f, err := p.file("edge", e.Label)
+	if err != nil {
+		return err
+	}
+	return f.writer.Write(parquetValues(f.fields, e.GetDataMap(), []interface{}{e.Gid, e.Label, e.From, e.To}))
+}
+
+// Close writes the footers of the Parquet files, and closes them
+func (p *ParquetWriter) Close() error {
+	var out error
+	for _, f := range p.files {
+		if err := f.writer.Close(); err != nil && out == nil {
+			out = err
+		}
+		if err := f.file.Close(); err != nil && out == nil {
+			out = err
+		}
+	}
+	p.files = map[string]*parquetFile{}
+	return out
+}
+
+// ParquetFiles lists the Parquet files in a directory, with the vertex files
+// before the edge files
+func ParquetFiles(dir string) ([]string, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.parquet"))
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(files, func(i, j int) bool {
+		return !strings.HasSuffix(files[i], ".edge.parquet") && strings.HasSuffix(files[j], ".edge.parquet")
+	})
+	return files, nil
+}
+
+// StreamParquet reads the vertices or edges of a Parquet file. Files written
+// by ParquetWriter are read back as they were written. In other files, each
+// row is an edge if the file has _from and _to columns, and a vertex
+// otherwise. If there is no _label column, the label is taken from the file
+// name. Columns with dotted names become nested fields. Rows that can't be
+// converted are logged and skipped.
+func StreamParquet(path string) (chan *gripql.GraphElement, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	reader, err := parquet.NewReader(file, info.Size())
+	if err != nil {
+		file.Close()
+		return nil, fmt.Errorf("reading %s: %v", path, err)
+	}
+	if skipped := reader.Unsupported(); len(skipped) > 0 {
+		log.WithFields(log.Fields{"file": path, "columns": skipped}).Warning("Skipping nested and unsupported Parquet columns")
+	}
+
+	meta := reader.Metadata()
+	columns := reader.Columns()
+	index := map[string]int{}
+	for i, c := range columns {
+		index[c.Name] = i
+	}
+	_, hasFrom := index[fromColumn]
+	_, hasTo := index[toColumn]
+	element := meta[parquetElementKey]
+	if element == "" {
+		element = "vertex"
+		if hasFrom && hasTo {
+			element = "edge"
+		}
+	}
+	if element == "edge" && !(hasFrom && hasTo) {
+		file.Close()
+		return nil, fmt.Errorf("%s: edge files need %s and %s columns", path, fromColumn, toColumn)
+	}
+	if _, ok := index[gidColumn]; !ok && element == "vertex" {
+		file.Close()
+		return nil, fmt.Errorf("%s: vertex files need a %s column", path, gidColumn)
+	}
+	label := meta[parquetLabelKey]
+	if label == "" {
+		label = strings.TrimSuffix(filepath.Base(path), ".parquet")
+		label = strings.TrimSuffix(strings.TrimSuffix(label, ".vertex"), ".edge")
+		if l, err := url.PathUnescape(label); err == nil {
+			label = l
+		}
+	}
+	lists := map[string]bool{}
+	if s, ok := meta[parquetFieldsKey]; ok {
+		fields := []Field{}
+		if err := json.Unmarshal([]byte(s), &fields); err != nil {
+			log.WithFields(log.Fields{"file": path, "error": err}).Warning("Invalid field metadata")
+		}
+		for _, f := range fields {
+			lists[f.Name] = f.List
+		}
+	}
+
+	out := make(chan *gripql.GraphElement, 100)
+	go func() {
+		defer file.Close()
+		defer reader.Close()
+		defer close(out)
+		rowNum := 0
+		for {
+			row, err := reader.Read()
+			if err == io.EOF {
+				return
+			}
+			if err != nil {
+				log.WithFields(log.Fields{"file": path, "error": err}).Error("Reading Parquet file")
+				return
+			}
+			rowNum++
+			elem, err := parquetElement(element, label, columns, lists, row)
+			if err != nil {
+				log.WithFields(log.Fields{"file": path, "row": rowNum, "error": err}).Error("Converting Parquet row")
+				continue
+			}
+			out <- elem
+		}
+	}()
+	return out, nil
+}
+
+func parquetElement(element string, label string, columns []parquet.Column, lists map[string]bool, row []interface{}) (*gripql.GraphElement, error) {
+	var gid, from, to string
+	data := map[string]interface{}{}
+	for i, c := range columns {
+		v := row[i]
+		if v == nil {
+			continue
+		}
+		switch c.Name {
+		case gidColumn:
+			gid = fmt.Sprintf("%v", v)
+			continue
+		case labelColumn:
+			label = fmt.Sprintf("%v", v)
+			continue
+		case fromColumn:
+			if element == "edge" {
+				from = fmt.Sprintf("%v", v)
+				continue
+			}
+		case toColumn:
+			if element == "edge" {
+				to = fmt.Sprintf("%v", v)
+				continue
+			}
+		}
+		if n, ok := v.(int64); ok {
+			v = float64(n)
+		}
+		if s, ok := v.(string); ok && lists[c.Name] {
+			l := []interface{}{}
+			if err := json.Unmarshal([]byte(s), &l); err != nil {
+				return nil, fmt.Errorf("column %s: %v", c.Name, err)
+			}
+			v = l
+		}
+		setNested(data, c.Name, v)
+	}
+	d, err := structpb.NewStruct(data)
+	if err != nil {
+		return nil, err
+	}
+	if element == "edge" {
+		if from == "" || to == "" {
+			return nil, fmt.Errorf("missing %s or %s", fromColumn, toColumn)
+		}
+		return &gripql.GraphElement{Edge: &gripql.Edge{Gid: gid, Label: label, From: from, To: to, Data: d}}, nil
+	}
+	if gid == "" {
+		return nil, fmt.Errorf("missing %s", gidColumn)
+	}
+	return &gripql.GraphElement{Vertex: &gripql.Vertex{Gid: gid, Label: label, Data: d}}, nil
+}
+
+// setNested sets a dotted field name, such as "address.city", as nested maps
+func setNested(data map[string]interface{}, name string, v interface{}) {
+	parts := strings.Split(name, ".")
+	for _, p := range parts[:len(parts)-1] {
+		m, ok := data[p].(map[string]interface{})
+		if !ok {
+			m = map[string]interface{}{}
+			data[p] = m
+		}
+		data = m
+	}
+	data[parts[len(parts)-1]] = v
+}
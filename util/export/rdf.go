@@ -0,0 +1,322 @@
+package export
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/bmeg/grip/gripql"
+	"github.com/bmeg/grip/log"
+	"github.com/knakk/rdf"
+	"sigs.k8s.io/yaml"
+)
+
+// RDF namespaces used when mapping graph elements to triples
+const (
+	RDFType   = "http://www.w3.org/1999/02/22-rdf-syntax-ns#type"
+	XSDPrefix = "http://www.w3.org/2001/XMLSchema#"
+)
+
+// RDFConfig maps vertices, labels and data fields to RDF IRIs
+type RDFConfig struct {
+	// Vertex IRIs are Base + label + "/" + gid, unless the label has a prefix
+	// in Labels, or the gid is already an http(s) or urn IRI
+	Base string `json:"base"`
+	// Data fields and edge labels become the predicates Vocab + name, and
+	// vertex labels become the classes Vocab + label
+	Vocab string `json:"vocab"`
+	// IRI prefix of the vertices of a label. Vertex IRIs are prefix + gid
+	Labels map[string]string `json:"labels"`
+	// Prefix names declared in Turtle output, such as foaf: http://xmlns.com/foaf/0.1/
+	Prefixes map[string]string `json:"prefixes"`
+}
+
+// DefaultRDFConfig returns the mapping used when no config file is given
+func DefaultRDFConfig() *RDFConfig {
+	return &RDFConfig{
+		Base:     "urn:grip:vertex:",
+		Vocab:    "urn:grip:vocab:",
+		Labels:   map[string]string{},
+		Prefixes: map[string]string{"grip": "urn:grip:vocab:"},
+	}
+}
+
+// LoadRDFConfig reads a YAML RDF mapping. Base and Vocab default to the
+// values of DefaultRDFConfig
+func LoadRDFConfig(path string) (*RDFConfig, error) {
+	conf := DefaultRDFConfig()
+	if path == "" {
+		return conf, nil
+	}
+	raw, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := yaml.Unmarshal(raw, conf); err != nil {
+		return nil, fmt.Errorf("reading RDF config %s: %v", path, err)
+	}
+	if conf.Labels == nil {
+		conf.Labels = map[string]string{}
+	}
+	if conf.Prefixes == nil {
+		conf.Prefixes = map[string]string{}
+	}
+	return conf, nil
+}
+
+func absoluteIRI(s string) bool {
+	if !(strings.HasPrefix(s, "http://") || strings.HasPrefix(s, "https://") || strings.HasPrefix(s, "urn:")) {
+		return false
+	}
+	_, err := rdf.NewIRI(s)
+	return err == nil
+}
+
+// VertexIRI returns the IRI of a vertex
+func (c *RDFConfig) VertexIRI(label, gid string) string {
+	if prefix, ok := c.Labels[label]; ok {
+		return prefix + url.PathEscape(gid)
+	}
+	if absoluteIRI(gid) {
+		return gid
+	}
+	return c.Base + url.PathEscape(label) + "/" + url.PathEscape(gid)
+}
+
+// ParseVertexIRI returns the label and gid of a vertex IRI. The label is
+// empty when the IRI is used as the gid
+func (c *RDFConfig) ParseVertexIRI(iri string) (string, string, bool) {
+	best, found := "", false
+	for label, prefix := range c.Labels {
+		if strings.HasPrefix(iri, prefix) && (!found || len(prefix) > len(c.Labels[best])) {
+			best, found = label, true
+		}
+	}
+	if found {
+		if gid, err := url.PathUnescape(iri[len(c.Labels[best]):]); err == nil {
+			return best, gid, true
+		}
+	}
+	if strings.HasPrefix(iri, c.Base) {
+		parts := strings.SplitN(iri[len(c.Base):], "/", 2)
+		if len(parts) == 2 {
+			label, err1 := url.PathUnescape(parts[0])
+			gid, err2 := url.PathUnescape(parts[1])
+			if err1 == nil && err2 == nil {
+				return label, gid, true
+			}
+		}
+	}
+	if absoluteIRI(iri) {
+		return "", iri, true
+	}
+	return "", "", false
+}
+
+// TermIRI returns the predicate IRI of a data field or edge label, or the
+// class IRI of a vertex label
+func (c *RDFConfig) TermIRI(name string) string {
+	return c.Vocab + url.PathEscape(name)
+}
+
+// ParseTermIRI returns the field or label name of a predicate or class IRI
+func (c *RDFConfig) ParseTermIRI(iri string) (string, bool) {
+	if !strings.HasPrefix(iri, c.Vocab) {
+		return "", false
+	}
+	name, err := url.PathUnescape(iri[len(c.Vocab):])
+	return name, err == nil
+}
+
+// Literal returns the lexical form and XSD datatype of a data value. Whole
+// numbers are integers, and other numbers are doubles. Values that aren't
+// strings, numbers or booleans are JSON encoded strings
+func Literal(v interface{}) (string, string) {
+	switch t := v.(type) {
+	case string:
+		return t, XSDPrefix + "string"
+	case bool:
+		return strconv.FormatBool(t), XSDPrefix + "boolean"
+	case float64:
+		if t == math.Trunc(t) && math.Abs(t) < 1e15 {
+			return strconv.FormatInt(int64(t), 10), XSDPrefix + "integer"
+		}
+		return strconv.FormatFloat(t, 'g', -1, 64), XSDPrefix + "double"
+	case int64:
+		return strconv.FormatInt(t, 10), XSDPrefix + "integer"
+	case int:
+		return strconv.Itoa(t), XSDPrefix + "integer"
+	}
+	b, _ := json.Marshal(v)
+	return string(b), XSDPrefix + "string"
+}
+
+// Predicate is a data field, or edge label, and one of its values
+type Predicate struct {
+	Name  string
+	Value interface{}
+}
+
+// DataPredicates flattens data into a predicate per value. Nested maps
+// become dotted names, and lists give a predicate per item
+func DataPredicates(data map[string]interface{}) []Predicate {
+	out := []Predicate{}
+	dataPredicates("", data, &out)
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+func dataPredicates(prefix string, data map[string]interface{}, out *[]Predicate) {
+	for k, v := range data {
+		name := prefix + k
+		switch t := v.(type) {
+		case nil:
+		case map[string]interface{}:
+			dataPredicates(name+".", t, out)
+		case []interface{}:
+			for _, i := range t {
+				if i != nil {
+					*out = append(*out, Predicate{name, i})
+				}
+			}
+		default:
+			*out = append(*out, Predicate{name, t})
+		}
+	}
+}
+
+// RDFWriter writes a graph as N-Triples or Turtle. Each vertex gets an
+// rdf:type triple for its label and a triple per data value, and each edge
+// becomes a triple between its vertices. Edge data can't be attached to a
+// triple, and is not written. The label of each vertex is kept in memory,
+// so the IRIs of edges can be created.
+type RDFWriter struct {
+	w        *bufio.Writer
+	turtle   bool
+	conf     *RDFConfig
+	labels   map[string]string
+	prefixes []string
+	inEdges  bool
+	skipped  int
+	err      error
+}
+
+// NewRDFWriter starts an N-Triples, or Turtle, document
+func NewRDFWriter(w io.Writer, turtle bool, conf *RDFConfig) (*RDFWriter, error) {
+	r := &RDFWriter{w: bufio.NewWriter(w), turtle: turtle, conf: conf, labels: map[string]string{}}
+	if turtle {
+		names := []string{}
+		for name := range conf.Prefixes {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			r.printf("@prefix %s: <%s> .\n", name, conf.Prefixes[name])
+			r.prefixes = append(r.prefixes, name)
+		}
+		if _, ok := conf.Prefixes["xsd"]; !ok {
+			r.printf("@prefix xsd: <%s> .\n", XSDPrefix)
+		}
+		r.printf("\n")
+	}
+	return r, r.err
+}
+
+func (r *RDFWriter) printf(format string, args ...interface{}) {
+	if r.err == nil {
+		_, r.err = fmt.Fprintf(r.w, format, args...)
+	}
+}
+
+var literalEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\r", `\r`, "\t", `\t`)
+
+var pnLocal = regexp.MustCompile(`^[A-Za-z0-9_]([A-Za-z0-9_.-]*[A-Za-z0-9_-])?$`)
+
+func (r *RDFWriter) iri(s string) string {
+	if r.turtle {
+		if s == RDFType {
+			return "a"
+		}
+		for _, name := range r.prefixes {
+			ns := r.conf.Prefixes[name]
+			if strings.HasPrefix(s, ns) && pnLocal.MatchString(s[len(ns):]) {
+				return name + ":" + s[len(ns):]
+			}
+		}
+	}
+	return "<" + s + ">"
+}
+
+func (r *RDFWriter) literal(v interface{}) string {
+	lex, dt := Literal(v)
+	quoted := `"` + literalEscaper.Replace(lex) + `"`
+	switch {
+	case dt == XSDPrefix+"string":
+		return quoted
+	case r.turtle && (dt == XSDPrefix+"integer" || dt == XSDPrefix+"boolean"):
+		return lex
+	case r.turtle:
+		return quoted + "^^xsd:" + strings.TrimPrefix(dt, XSDPrefix)
+	}
+	return quoted + "^^<" + dt + ">"
+}
+
+// WriteVertex writes the type and data triples of a vertex
+func (r *RDFWriter) WriteVertex(v *gripql.Vertex) error {
+	if r.inEdges {
+		return fmt.Errorf("vertices must be written before edges")
+	}
+	r.labels[v.Gid] = v.Label
+	subj := r.iri(r.conf.VertexIRI(v.Label, v.Gid))
+	preds := DataPredicates(v.GetDataMap())
+	if r.turtle {
+		r.printf("%s a %s", subj, r.iri(r.conf.TermIRI(v.Label)))
+		for _, p := range preds {
+			r.printf(" ;\n    %s %s", r.iri(r.conf.TermIRI(p.Name)), r.literal(p.Value))
+		}
+		r.printf(" .\n")
+		return r.err
+	}
+	r.printf("%s %s %s .\n", subj, r.iri(RDFType), r.iri(r.conf.TermIRI(v.Label)))
+	for _, p := range preds {
+		r.printf("%s %s %s .\n", subj, r.iri(r.conf.TermIRI(p.Name)), r.literal(p.Value))
+	}
+	return r.err
+}
+
+// WriteEdge writes the triple of an edge. Edges to vertices that were not
+// written are skipped
+func (r *RDFWriter) WriteEdge(e *gripql.Edge) error {
+	r.inEdges = true
+	from, ok := r.labels[e.From]
+	if !ok {
+		r.skipped++
+		return r.err
+	}
+	to, ok := r.labels[e.To]
+	if !ok {
+		r.skipped++
+		return r.err
+	}
+	r.printf("%s %s %s .\n", r.iri(r.conf.VertexIRI(from, e.From)), r.iri(r.conf.TermIRI(e.Label)), r.iri(r.conf.VertexIRI(to, e.To)))
+	return r.err
+}
+
+// Close flushes the document
+func (r *RDFWriter) Close() error {
+	if r.skipped > 0 {
+		log.Warningf("Skipped %d edges with vertices that were not exported", r.skipped)
+	}
+	if r.err != nil {
+		return r.err
+	}
+	return r.w.Flush()
+}
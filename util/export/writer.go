@@ -0,0 +1,67 @@
+package export
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+
+	"github.com/bmeg/grip/gripql"
+)
+
+// Writer writes a graph in an export format. All vertices must be written
+// before the edges.
+type Writer interface {
+	WriteVertex(*gripql.Vertex) error
+	WriteEdge(*gripql.Edge) error
+	// Close finishes the document. It does not close the underlying writers
+	Close() error
+}
+
+// Formats lists the supported export formats
+var Formats = []string{"graphml", "gexf", "neo4j-csv", "parquet", "ntriples", "turtle"}
+
+func xmlEscape(s string) string {
+	var b xmlBuffer
+	xml.EscapeText(&b, []byte(s))
+	return string(b)
+}
+
+type xmlBuffer []byte
+
+func (b *xmlBuffer) Write(p []byte) (int, error) {
+	*b = append(*b, p...)
+	return len(p), nil
+}
+
+// xmlWriter tracks the sections of an XML document that nodes and edges are
+// written into
+type xmlWriter struct {
+	w         io.Writer
+	inEdges   bool
+	err       error
+	startEdge func() error
+}
+
+func (x *xmlWriter) printf(format string, args ...interface{}) {
+	if x.err == nil {
+		_, x.err = fmt.Fprintf(x.w, format, args...)
+	}
+}
+
+func (x *xmlWriter) beginEdges() error {
+	if x.inEdges {
+		return x.err
+	}
+	x.inEdges = true
+	if x.startEdge != nil {
+		x.err = x.startEdge()
+	}
+	return x.err
+}
+
+func (x *xmlWriter) checkVertex() error {
+	if x.inEdges {
+		return fmt.Errorf("vertices must be written before edges")
+	}
+	return x.err
+}
@@ -6,11 +6,11 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"net/url"
 	"os"
 	"path/filepath"
 	"strings"
-	"sync"
 
 	"github.com/bmeg/grip/gripql"
 	"github.com/bmeg/grip/log"
@@ -56,6 +56,31 @@ func getS3URL(path string) *url.URL {
 
 // StreamLines returns a channel of lines from a file.
 func StreamLines(file string, chanSize int) (chan string, error) {
+	lines, err := StreamLinesAt(file, 0, chanSize)
+	if err != nil {
+		return nil, err
+	}
+	lineChan := make(chan string, chanSize)
+	go func() {
+		for l := range lines {
+			lineChan <- l.Text
+		}
+		close(lineChan)
+	}()
+	return lineChan, nil
+}
+
+// Line is a line of a file, with the byte offset of the end of the line
+type Line struct {
+	Text   string
+	Offset int64
+}
+
+// StreamLinesAt returns a channel of the lines of a file, starting at a byte
+// offset. The offsets of gzip files count uncompressed bytes, so the part of
+// a gzip file before the offset is decompressed and dropped, while other files
+// are seeked.
+func StreamLinesAt(file string, offset int64, chanSize int) (chan Line, error) {
 
 	var fh io.ReadCloser
 	var err error
@@ -80,28 +105,49 @@ func StreamLines(file string, chanSize int) (chan string, error) {
 			return nil, err
 		}
 	}
-	var scanner *bufio.Scanner
+	var r io.Reader = fh
 
 	if strings.HasSuffix(file, ".gz") {
 		gz, err := gzip.NewReader(fh)
 		if err != nil {
+			fh.Close()
+			return nil, err
+		}
+		r = gz
+		if offset > 0 {
+			if _, err := io.CopyN(ioutil.Discard, gz, offset); err != nil {
+				fh.Close()
+				return nil, fmt.Errorf("skipping to offset %d of %s: %v", offset, file, err)
+			}
+		}
+	} else if offset > 0 {
+		seeker, ok := fh.(io.Seeker)
+		if !ok {
+			fh.Close()
+			return nil, fmt.Errorf("%s can't seek to offset %d", file, offset)
+		}
+		if _, err := seeker.Seek(offset, io.SeekStart); err != nil {
+			fh.Close()
 			return nil, err
 		}
-		scanner = bufio.NewScanner(gz)
-	} else {
-		scanner = bufio.NewScanner(fh)
 	}
+	scanner := bufio.NewScanner(r)
 
 	const maxCapacity = 16 * 1024 * 1024
 	buf := make([]byte, 0, 64*1024)
 	scanner.Buffer(buf, maxCapacity)
+	pos := offset
+	scanner.Split(func(data []byte, atEOF bool) (int, []byte, error) {
+		advance, token, err := bufio.ScanLines(data, atEOF)
+		pos += int64(advance)
+		return advance, token, err
+	})
 
-	lineChan := make(chan string, chanSize)
+	lineChan := make(chan Line, chanSize)
 
 	go func() {
 		for scanner.Scan() {
-			line := scanner.Text()
-			lineChan <- line
+			lineChan <- Line{Text: scanner.Text(), Offset: pos}
 		}
 		if err := scanner.Err(); err != nil {
 			log.WithFields(log.Fields{"error": err}).Errorf("Reading file: %s", file)
@@ -113,90 +159,169 @@ func StreamLines(file string, chanSize int) (chan string, error) {
 	return lineChan, nil
 }
 
-// StreamVerticesFromFile reads a file containing a vertex per line and
-// streams *gripql.Vertex objects out on a channel
-func StreamVerticesFromFile(file string, workers int) (chan *gripql.Vertex, error) {
-	if workers < 1 {
-		workers = 1
-	}
-	if workers > 99 {
-		workers = 99
-	}
-	lineChan, err := StreamLines(file, workers)
-	if err != nil {
-		return nil, err
-	}
+// parseBatchSize is the number of lines handed to a parsing worker at a time
+const parseBatchSize = 1000
 
-	vertChan := make(chan *gripql.Vertex, workers)
-	var wg sync.WaitGroup
+// parsedLine is a value parsed from a line, with the offset of the end of
+// the line
+type parsedLine struct {
+	value  interface{}
+	offset int64
+}
 
-	jum := protojson.UnmarshalOptions{DiscardUnknown: true}
+// parseLines runs parse over the lines with a pool of workers, and streams
+// the results in the order of the lines. Lines where parse returns nil are
+// dropped.
+func parseLines(lines chan Line, workers int, parse func(string) interface{}) chan parsedLine {
+	type batch struct {
+		lines []Line
+		out   chan []parsedLine
+	}
+	todo := make(chan *batch, workers)
+	order := make(chan *batch, workers*2)
+	go func() {
+		b := &batch{out: make(chan []parsedLine, 1)}
+		for line := range lines {
+			b.lines = append(b.lines, line)
+			if len(b.lines) == parseBatchSize {
+				todo <- b
+				order <- b
+				b = &batch{out: make(chan []parsedLine, 1)}
+			}
+		}
+		if len(b.lines) > 0 {
+			todo <- b
+			order <- b
+		}
+		close(todo)
+		close(order)
+	}()
 
 	for i := 0; i < workers; i++ {
-		wg.Add(1)
 		go func() {
-			for line := range lineChan {
-				v := &gripql.Vertex{}
-				err := jum.Unmarshal([]byte(line), v)
-				if err != nil {
-					log.WithFields(log.Fields{"error": err}).Errorf("Unmarshaling vertex: %s", line)
-				} else {
-					vertChan <- v
+			for b := range todo {
+				res := make([]parsedLine, 0, len(b.lines))
+				for _, line := range b.lines {
+					if v := parse(line.Text); v != nil {
+						res = append(res, parsedLine{v, line.Offset})
+					}
 				}
+				b.out <- res
 			}
-			wg.Done()
 		}()
 	}
 
+	out := make(chan parsedLine, workers)
 	go func() {
-		wg.Wait()
-		close(vertChan)
+		for b := range order {
+			for _, v := range <-b.out {
+				out <- v
+			}
+		}
+		close(out)
 	}()
+	return out
+}
 
+// FileElement is a vertex or edge read from a line of a file, with the byte
+// offset of the end of the line
+type FileElement struct {
+	Vertex *gripql.Vertex
+	Edge   *gripql.Edge
+	Offset int64
+}
+
+// StreamVerticesFromFile reads a file containing a vertex per line and
+// streams *gripql.Vertex objects out on a channel, in the order of the file
+func StreamVerticesFromFile(file string, workers int) (chan *gripql.Vertex, error) {
+	elems, err := StreamVerticesAt(file, 0, workers)
+	if err != nil {
+		return nil, err
+	}
+	vertChan := make(chan *gripql.Vertex, cap(elems))
+	go func() {
+		for e := range elems {
+			vertChan <- e.Vertex
+		}
+		close(vertChan)
+	}()
 	return vertChan, nil
 }
 
 // StreamEdgesFromFile reads a file containing an edge per line and
-// streams gripql.Edge objects on a channel
+// streams gripql.Edge objects on a channel, in the order of the file
 func StreamEdgesFromFile(file string, workers int) (chan *gripql.Edge, error) {
+	elems, err := StreamEdgesAt(file, 0, workers)
+	if err != nil {
+		return nil, err
+	}
+	edgeChan := make(chan *gripql.Edge, cap(elems))
+	go func() {
+		for e := range elems {
+			edgeChan <- e.Edge
+		}
+		close(edgeChan)
+	}()
+	return edgeChan, nil
+}
+
+// StreamVerticesAt reads a file containing a vertex per line, starting at a
+// byte offset given by an earlier FileElement, and streams the vertices in
+// the order of the file
+func StreamVerticesAt(file string, offset int64, workers int) (chan FileElement, error) {
+	jum := protojson.UnmarshalOptions{DiscardUnknown: true}
+	return streamElementsAt(file, offset, workers, func(line string) interface{} {
+		v := &gripql.Vertex{}
+		if err := jum.Unmarshal([]byte(line), v); err != nil {
+			log.WithFields(log.Fields{"error": err}).Errorf("Unmarshaling vertex: %s", line)
+			return nil
+		}
+		return v
+	})
+}
+
+// StreamEdgesAt reads a file containing an edge per line, starting at a byte
+// offset given by an earlier FileElement, and streams the edges in the order
+// of the file
+func StreamEdgesAt(file string, offset int64, workers int) (chan FileElement, error) {
+	jum := protojson.UnmarshalOptions{DiscardUnknown: true}
+	return streamElementsAt(file, offset, workers, func(line string) interface{} {
+		e := &gripql.Edge{}
+		if err := jum.Unmarshal([]byte(line), e); err != nil {
+			log.WithFields(log.Fields{"error": err}).Errorf("Unmarshaling edge: %s", line)
+			return nil
+		}
+		return e
+	})
+}
+
+func streamElementsAt(file string, offset int64, workers int, parse func(string) interface{}) (chan FileElement, error) {
 	if workers < 1 {
 		workers = 1
 	}
 	if workers > 99 {
 		workers = 99
 	}
-	lineChan, err := StreamLines(file, workers)
+	lineChan, err := StreamLinesAt(file, offset, workers)
 	if err != nil {
 		return nil, err
 	}
-
-	edgeChan := make(chan *gripql.Edge, workers)
-	var wg sync.WaitGroup
-
-	jum := protojson.UnmarshalOptions{DiscardUnknown: true}
-
-	for i := 0; i < workers; i++ {
-		wg.Add(1)
-		go func() {
-			for line := range lineChan {
-				e := &gripql.Edge{}
-				err := jum.Unmarshal([]byte(line), e)
-				if err != nil {
-					log.WithFields(log.Fields{"error": err}).Errorf("Unmarshaling edge: %s", line)
-				} else {
-					edgeChan <- e
-				}
-			}
-			wg.Done()
-		}()
-	}
-
+	parsed := parseLines(lineChan, workers, parse)
+	out := make(chan FileElement, workers)
 	go func() {
-		wg.Wait()
-		close(edgeChan)
+		for p := range parsed {
+			e := FileElement{Offset: p.offset}
+			switch v := p.value.(type) {
+			case *gripql.Vertex:
+				e.Vertex = v
+			case *gripql.Edge:
+				e.Edge = v
+			}
+			out <- e
+		}
+		close(out)
 	}()
-
-	return edgeChan, nil
+	return out, nil
 }
 
 func DirScan(baseDir string, fileGlob string) ([]string, error) {
@@ -0,0 +1,48 @@
+package util
+
+import (
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestStreamLinesAt(t *testing.T) {
+	dir := t.TempDir()
+	content := "first\r\nsecond\n\nfourth"
+	plain := filepath.Join(dir, "lines.txt")
+	if err := os.WriteFile(plain, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	compressed := filepath.Join(dir, "lines.txt.gz")
+	f, err := os.Create(compressed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gz := gzip.NewWriter(f)
+	gz.Write([]byte(content))
+	gz.Close()
+	f.Close()
+
+	read := func(file string, offset int64) []Line {
+		lines, err := StreamLinesAt(file, offset, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		out := []Line{}
+		for l := range lines {
+			out = append(out, l)
+		}
+		return out
+	}
+	expected := []Line{{"first", 7}, {"second", 14}, {"", 15}, {"fourth", 21}}
+	for _, file := range []string{plain, compressed} {
+		if lines := read(file, 0); !reflect.DeepEqual(lines, expected) {
+			t.Errorf("%s: unexpected lines %v", file, lines)
+		}
+		if lines := read(file, 14); !reflect.DeepEqual(lines, expected[2:]) {
+			t.Errorf("%s: unexpected lines from offset 14 %v", file, lines)
+		}
+	}
+}
@@ -0,0 +1,227 @@
+// Package geo reads points and shapes from graph data and condition values,
+// and tests whether a point lies inside a shape
+package geo
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/bmeg/grip/gripql"
+	"github.com/spf13/cast"
+)
+
+// EarthRadius is the mean radius of the earth, in meters
+const EarthRadius = 6371008.8
+
+// Point is a location in degrees
+type Point struct {
+	Lat float64
+	Lon float64
+}
+
+// ParsePoint reads a point from a value, which may be a map with lat and lon
+// (or lng) fields, a GeoJSON Point, or a [lon, lat] pair as in GeoJSON
+func ParsePoint(val interface{}) (Point, bool) {
+	var lat, lon interface{}
+	switch v := val.(type) {
+	case map[string]interface{}:
+		if v["type"] == "Point" {
+			return ParsePoint(v["coordinates"])
+		}
+		lat = v["lat"]
+		if lon = v["lon"]; lon == nil {
+			lon = v["lng"]
+		}
+	case []interface{}:
+		if len(v) != 2 {
+			return Point{}, false
+		}
+		lon, lat = v[0], v[1]
+	default:
+		return Point{}, false
+	}
+	latF, err := cast.ToFloat64E(lat)
+	if err != nil || lat == nil {
+		return Point{}, false
+	}
+	lonF, err := cast.ToFloat64E(lon)
+	if err != nil || lon == nil {
+		return Point{}, false
+	}
+	if latF < -90 || latF > 90 || lonF < -180 || lonF > 180 {
+		return Point{}, false
+	}
+	return Point{Lat: latF, Lon: lonF}, true
+}
+
+// Distance returns the great circle distance between two points, in meters
+func Distance(a, b Point) float64 {
+	lat1 := a.Lat * math.Pi / 180
+	lat2 := b.Lat * math.Pi / 180
+	dLat := lat2 - lat1
+	dLon := (b.Lon - a.Lon) * math.Pi / 180
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * EarthRadius * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+// Shape is an area that points are tested against
+type Shape interface {
+	Contains(p Point) bool
+	// Bounds returns a box holding the whole shape
+	Bounds() Box
+}
+
+// Box is the area between two latitudes and two longitudes. A box whose
+// west edge is east of its east edge crosses the antimeridian
+type Box struct {
+	South, West, North, East float64
+}
+
+// Contains tests if a point is inside the box, including its edges
+func (b Box) Contains(p Point) bool {
+	if p.Lat < b.South || p.Lat > b.North {
+		return false
+	}
+	if b.West > b.East {
+		return p.Lon >= b.West || p.Lon <= b.East
+	}
+	return p.Lon >= b.West && p.Lon <= b.East
+}
+
+// Bounds returns the box itself
+func (b Box) Bounds() Box {
+	return b
+}
+
+// Circle is the area within a distance, in meters, of its center
+type Circle struct {
+	Center Point
+	Radius float64
+}
+
+// Contains tests if a point is within the radius of the center
+func (c Circle) Contains(p Point) bool {
+	return Distance(c.Center, p) <= c.Radius
+}
+
+// Bounds returns the box holding the circle
+func (c Circle) Bounds() Box {
+	dLat := c.Radius / EarthRadius * 180 / math.Pi
+	south := c.Center.Lat - dLat
+	north := c.Center.Lat + dLat
+	// circles reaching a pole hold every longitude
+	if south <= -90 || north >= 90 {
+		return Box{South: math.Max(south, -90), West: -180, North: math.Min(north, 90), East: 180}
+	}
+	dLon := math.Asin(math.Min(1, math.Sin(c.Radius/EarthRadius)/math.Cos(c.Center.Lat*math.Pi/180))) * 180 / math.Pi
+	west := c.Center.Lon - dLon
+	if west < -180 {
+		west += 360
+	}
+	east := c.Center.Lon + dLon
+	if east > 180 {
+		east -= 360
+	}
+	return Box{South: south, West: west, North: north, East: east}
+}
+
+// Polygon is the area inside a ring of points. Edges are straight lines
+// between the coordinates of the points
+type Polygon []Point
+
+// Contains tests if a point is inside the polygon
+func (poly Polygon) Contains(p Point) bool {
+	inside := false
+	for i, j := 0, len(poly)-1; i < len(poly); j, i = i, i+1 {
+		a, b := poly[i], poly[j]
+		if (a.Lat > p.Lat) != (b.Lat > p.Lat) &&
+			p.Lon < (b.Lon-a.Lon)*(p.Lat-a.Lat)/(b.Lat-a.Lat)+a.Lon {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
+// Bounds returns the smallest box holding the polygon
+func (poly Polygon) Bounds() Box {
+	b := Box{South: 90, West: 180, North: -90, East: -180}
+	for _, p := range poly {
+		b.South = math.Min(b.South, p.Lat)
+		b.North = math.Max(b.North, p.Lat)
+		b.West = math.Min(b.West, p.Lon)
+		b.East = math.Max(b.East, p.Lon)
+	}
+	return b
+}
+
+// IsCondition tests if a condition is one of the geo conditions
+func IsCondition(cond gripql.Condition) bool {
+	switch cond {
+	case gripql.Condition_GEO_RADIUS, gripql.Condition_GEO_BOX, gripql.Condition_GEO_POLYGON:
+		return true
+	}
+	return false
+}
+
+// ParseShape reads the value of a geo condition. A GEO_RADIUS value is a
+// center point and a radius in meters, a GEO_BOX value is its southwest and
+// northeast corners, and a GEO_POLYGON value is a list of at least three points
+func ParseShape(cond gripql.Condition, val interface{}) (Shape, error) {
+	vals, ok := val.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("expected a list not %T for %s condition value", val, cond)
+	}
+	points := func(vals []interface{}) ([]Point, error) {
+		out := make([]Point, len(vals))
+		for i, v := range vals {
+			p, ok := ParsePoint(v)
+			if !ok {
+				return nil, fmt.Errorf("%s condition value %v is not a point", cond, v)
+			}
+			out[i] = p
+		}
+		return out, nil
+	}
+	switch cond {
+	case gripql.Condition_GEO_RADIUS:
+		if len(vals) != 2 {
+			return nil, fmt.Errorf("expected a center and a radius for %s condition value", cond)
+		}
+		center, err := points(vals[:1])
+		if err != nil {
+			return nil, err
+		}
+		radius, err := cast.ToFloat64E(vals[1])
+		if err != nil || radius < 0 {
+			return nil, fmt.Errorf("%s radius %v is not a positive number", cond, vals[1])
+		}
+		return Circle{Center: center[0], Radius: radius}, nil
+
+	case gripql.Condition_GEO_BOX:
+		if len(vals) != 2 {
+			return nil, fmt.Errorf("expected southwest and northeast corners for %s condition value", cond)
+		}
+		corners, err := points(vals)
+		if err != nil {
+			return nil, err
+		}
+		if corners[0].Lat > corners[1].Lat {
+			return nil, fmt.Errorf("%s southwest corner is north of the northeast corner", cond)
+		}
+		return Box{South: corners[0].Lat, West: corners[0].Lon, North: corners[1].Lat, East: corners[1].Lon}, nil
+
+	case gripql.Condition_GEO_POLYGON:
+		ring, err := points(vals)
+		if err != nil {
+			return nil, err
+		}
+		if len(ring) > 1 && ring[0] == ring[len(ring)-1] {
+			ring = ring[:len(ring)-1]
+		}
+		if len(ring) < 3 {
+			return nil, fmt.Errorf("expected at least three points for %s condition value", cond)
+		}
+		return Polygon(ring), nil
+	}
+	return nil, fmt.Errorf("%s is not a geo condition", cond)
+}
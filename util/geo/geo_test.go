@@ -0,0 +1,144 @@
+package geo
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/bmeg/grip/gripql"
+)
+
+func TestParsePoint(t *testing.T) {
+	tests := []struct {
+		val interface{}
+		ok  bool
+	}{
+		{map[string]interface{}{"lat": 37.8, "lon": -122.3}, true},
+		{map[string]interface{}{"lat": "37.8", "lng": -122.3}, true},
+		{map[string]interface{}{"type": "Point", "coordinates": []interface{}{-122.3, 37.8}}, true},
+		{[]interface{}{-122.3, 37.8}, true},
+		{[]interface{}{37.8, -122.3, 0.0}, false},
+		{map[string]interface{}{"lat": 37.8}, false},
+		{map[string]interface{}{"lat": 95.0, "lon": 0.0}, false},
+		{"37.8,-122.3", false},
+	}
+	for _, tt := range tests {
+		p, ok := ParsePoint(tt.val)
+		if ok != tt.ok {
+			t.Errorf("ParsePoint(%v): expected ok %v", tt.val, tt.ok)
+		}
+		if ok && (p.Lat != 37.8 || p.Lon != -122.3) {
+			t.Errorf("ParsePoint(%v) = %v", tt.val, p)
+		}
+	}
+}
+
+func TestDistance(t *testing.T) {
+	london := Point{Lat: 51.5074, Lon: -0.1278}
+	paris := Point{Lat: 48.8566, Lon: 2.3522}
+	if d := Distance(london, paris); math.Abs(d-343500) > 1000 {
+		t.Errorf("unexpected distance %f", d)
+	}
+	// the shorter way around crosses the antimeridian
+	if d := Distance(Point{Lon: 179.5}, Point{Lon: -179.5}); math.Abs(d-111195) > 10 {
+		t.Errorf("unexpected distance %f", d)
+	}
+}
+
+func TestShapes(t *testing.T) {
+	box := Box{South: -10, West: 170, North: 10, East: -170}
+	if !box.Contains(Point{Lon: 180}) || !box.Contains(Point{Lon: -175}) || box.Contains(Point{Lon: 0}) {
+		t.Error("box crossing the antimeridian")
+	}
+	circle := Circle{Center: Point{Lat: 0, Lon: 179.9}, Radius: 50000}
+	b := circle.Bounds()
+	if b.West < b.East || !b.Contains(Point{Lon: -179.8}) {
+		t.Errorf("unexpected circle bounds %v", b)
+	}
+	if !circle.Contains(Point{Lon: -179.8}) {
+		t.Error("expected point across the antimeridian inside circle")
+	}
+	polar := Circle{Center: Point{Lat: 89.9}, Radius: 50000}
+	if b := polar.Bounds(); b.North != 90 || b.West != -180 || b.East != 180 {
+		t.Errorf("unexpected polar circle bounds %v", b)
+	}
+	// a concave polygon
+	poly := Polygon{{0, 0}, {0, 10}, {10, 10}, {5, 5}, {10, 0}}
+	if !poly.Contains(Point{2, 5}) || poly.Contains(Point{8, 5}) || !poly.Contains(Point{8, 8}) {
+		t.Error("unexpected polygon containment")
+	}
+}
+
+func TestParseShape(t *testing.T) {
+	pt := func(lat, lon float64) interface{} {
+		return map[string]interface{}{"lat": lat, "lon": lon}
+	}
+	tests := []struct {
+		cond gripql.Condition
+		val  interface{}
+		err  string
+	}{
+		{gripql.Condition_GEO_RADIUS, []interface{}{pt(1, 2), 100.0}, ""},
+		{gripql.Condition_GEO_RADIUS, []interface{}{pt(1, 2), -1.0}, "not a positive number"},
+		{gripql.Condition_GEO_RADIUS, []interface{}{pt(1, 2)}, "expected a center"},
+		{gripql.Condition_GEO_BOX, []interface{}{pt(1, 2), pt(3, 4)}, ""},
+		{gripql.Condition_GEO_BOX, []interface{}{pt(3, 2), pt(1, 4)}, "north of"},
+		{gripql.Condition_GEO_POLYGON, []interface{}{pt(0, 0), pt(0, 1), pt(1, 1), pt(0, 0)}, ""},
+		{gripql.Condition_GEO_POLYGON, []interface{}{pt(0, 0), pt(0, 1), pt(0, 0)}, "at least three"},
+		{gripql.Condition_GEO_POLYGON, []interface{}{pt(0, 0), "a", pt(1, 1)}, "not a point"},
+		{gripql.Condition_GEO_BOX, "box", "expected a list"},
+	}
+	for _, tt := range tests {
+		_, err := ParseShape(tt.cond, tt.val)
+		if tt.err == "" && err != nil {
+			t.Errorf("%s %v: unexpected error %s", tt.cond, tt.val, err)
+		} else if tt.err != "" && (err == nil || !strings.Contains(err.Error(), tt.err)) {
+			t.Errorf("%s %v: expected error %q, got %v", tt.cond, tt.val, tt.err, err)
+		}
+	}
+}
+
+func TestGeohash(t *testing.T) {
+	// reference values from the geohash.org encoder
+	if h := Geohash(Point{Lat: 57.64911, Lon: 10.40744}, 11); h != "u4pruydqqvj" {
+		t.Errorf("unexpected geohash %s", h)
+	}
+	if h := Geohash(Point{Lat: 42.6, Lon: -5.6}, 5); h != "ezs42" {
+		t.Errorf("unexpected geohash %s", h)
+	}
+}
+
+func TestCover(t *testing.T) {
+	points := []Point{{37.80, -122.27}, {37.77, -122.42}, {37.70, -122.50}}
+	box := Box{South: 37.6, West: -122.6, North: 37.9, East: -122.2}
+	cells := Cover(box, 32)
+	if len(cells) == 0 || len(cells) > 32 {
+		t.Fatalf("unexpected cover %v", cells)
+	}
+	for _, p := range points {
+		h := Geohash(p, MaxPrecision)
+		found := false
+		for _, c := range cells {
+			if strings.HasPrefix(h, c) {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("point %v (%s) not covered by %v", p, h, cells)
+		}
+	}
+
+	cells = Cover(Box{South: -1, West: 179, North: 1, East: -179}, 32)
+	for _, p := range []Point{{0, 179.5}, {0, -179.5}} {
+		h := Geohash(p, MaxPrecision)
+		found := false
+		for _, c := range cells {
+			if strings.HasPrefix(h, c) {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("point %v (%s) not covered by %v", p, h, cells)
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package geo
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// DefaultPrecision is the geohash length used when none is given, for cells
+// about 5km across
+const DefaultPrecision = 5
+
+// MaxPrecision is the length of the longest geohash, about 4cm across
+const MaxPrecision = 12
+
+const geohashAlphabet = "0123456789bcdefghjkmnpqrstuvwxyz"
+
+// Geohash encodes a point as a geohash of the given length. Points in the
+// same cell share a geohash, and the geohashes of cells inside a larger cell
+// start with its geohash
+func Geohash(p Point, precision int) string {
+	if precision > MaxPrecision {
+		precision = MaxPrecision
+	}
+	latMin, latMax := -90.0, 90.0
+	lonMin, lonMax := -180.0, 180.0
+	out := make([]byte, precision)
+	even := true
+	for i := range out {
+		var c byte
+		for bit := 4; bit >= 0; bit-- {
+			if even {
+				mid := (lonMin + lonMax) / 2
+				if p.Lon >= mid {
+					c |= 1 << uint(bit)
+					lonMin = mid
+				} else {
+					lonMax = mid
+				}
+			} else {
+				mid := (latMin + latMax) / 2
+				if p.Lat >= mid {
+					c |= 1 << uint(bit)
+					latMin = mid
+				} else {
+					latMax = mid
+				}
+			}
+			even = !even
+		}
+		out[i] = geohashAlphabet[c]
+	}
+	return string(out)
+}
+
+// CheckPrecision returns an error for a geohash length out of range
+func CheckPrecision(precision uint32) error {
+	if precision < 1 || precision > MaxPrecision {
+		return fmt.Errorf("geohash precision %d is not between 1 and %d", precision, MaxPrecision)
+	}
+	return nil
+}
+
+// cellSize returns the width and height, in degrees, of geohash cells
+func cellSize(precision int) (float64, float64) {
+	lonBits := (5*precision + 1) / 2
+	latBits := 5 * precision / 2
+	return 360 / math.Exp2(float64(lonBits)), 180 / math.Exp2(float64(latBits))
+}
+
+// cellRange returns the first and last cell index holding a span of degrees
+func cellRange(min, max, origin, size float64) (int, int) {
+	last := int(math.Round(-2*origin/size)) - 1
+	lo := int(math.Floor((min - origin) / size))
+	hi := int(math.Floor((max - origin) / size))
+	if lo < 0 {
+		lo = 0
+	}
+	if hi > last {
+		hi = last
+	}
+	return lo, hi
+}
+
+// Cover returns the geohashes of cells that together hold the box, using the
+// longest geohashes that need no more than maxCells cells
+func Cover(b Box, maxCells int) []string {
+	if b.West > b.East {
+		east := Cover(Box{South: b.South, West: b.West, North: b.North, East: 180}, maxCells/2)
+		west := Cover(Box{South: b.South, West: -180, North: b.North, East: b.East}, maxCells/2)
+		return append(east, west...)
+	}
+	precision := 1
+	for p := 2; p <= MaxPrecision; p++ {
+		w, h := cellSize(p)
+		x0, x1 := cellRange(b.West, b.East, -180, w)
+		y0, y1 := cellRange(b.South, b.North, -90, h)
+		if (x1-x0+1)*(y1-y0+1) > maxCells {
+			break
+		}
+		precision = p
+	}
+	w, h := cellSize(precision)
+	x0, x1 := cellRange(b.West, b.East, -180, w)
+	y0, y1 := cellRange(b.South, b.North, -90, h)
+	out := []string{}
+	for x := x0; x <= x1; x++ {
+		for y := y0; y <= y1; y++ {
+			center := Point{Lat: -90 + (float64(y)+0.5)*h, Lon: -180 + (float64(x)+0.5)*w}
+			out = append(out, Geohash(center, precision))
+		}
+	}
+	sort.Strings(out)
+	return out
+}
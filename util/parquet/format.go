@@ -0,0 +1,71 @@
+// Package parquet reads and writes flat Parquet files, where every column is
+// a single optional value. It uses the Parquet implementation of the Apache
+// Arrow Go module. Nested and repeated columns are not supported.
+package parquet
+
+import (
+	"fmt"
+
+	"github.com/apache/arrow/go/v12/arrow"
+)
+
+// Type is the type of the values in a column
+type Type int
+
+// Column types
+const (
+	String Type = iota
+	Double
+	Int64
+	Boolean
+)
+
+func (t Type) String() string {
+	switch t {
+	case String:
+		return "string"
+	case Double:
+		return "double"
+	case Int64:
+		return "int64"
+	case Boolean:
+		return "boolean"
+	}
+	return fmt.Sprintf("Type(%d)", int(t))
+}
+
+// Column is a named column of a file
+type Column struct {
+	Name string
+	Type Type
+}
+
+func arrowType(t Type) arrow.DataType {
+	switch t {
+	case Double:
+		return arrow.PrimitiveTypes.Float64
+	case Int64:
+		return arrow.PrimitiveTypes.Int64
+	case Boolean:
+		return arrow.FixedWidthTypes.Boolean
+	}
+	return arrow.BinaryTypes.String
+}
+
+// columnType returns the column type that values of an Arrow type are read
+// as. Timestamps and dates are read as RFC3339 strings.
+func columnType(t arrow.DataType) (Type, bool) {
+	switch t.ID() {
+	case arrow.STRING, arrow.LARGE_STRING, arrow.BINARY, arrow.LARGE_BINARY, arrow.FIXED_SIZE_BINARY,
+		arrow.TIMESTAMP, arrow.DATE32, arrow.DATE64:
+		return String, true
+	case arrow.FLOAT32, arrow.FLOAT64:
+		return Double, true
+	case arrow.INT8, arrow.INT16, arrow.INT32, arrow.INT64,
+		arrow.UINT8, arrow.UINT16, arrow.UINT32, arrow.UINT64:
+		return Int64, true
+	case arrow.BOOL:
+		return Boolean, true
+	}
+	return 0, false
+}
@@ -0,0 +1,174 @@
+package parquet
+
+import (
+	"bytes"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/apache/arrow/go/v12/arrow"
+	"github.com/apache/arrow/go/v12/arrow/array"
+	"github.com/apache/arrow/go/v12/arrow/memory"
+	pq "github.com/apache/arrow/go/v12/parquet"
+	"github.com/apache/arrow/go/v12/parquet/compress"
+	"github.com/apache/arrow/go/v12/parquet/pqarrow"
+)
+
+func TestRoundTrip(t *testing.T) {
+	columns := []Column{
+		{Name: "name", Type: String},
+		{Name: "score", Type: Double},
+		{Name: "count", Type: Int64},
+		{Name: "ok", Type: Boolean},
+	}
+	rows := [][]interface{}{}
+	for i := 0; i < 25; i++ {
+		row := []interface{}{"", float64(i) / 2, int64(-i), i%3 == 0}
+		row[0] = string(rune('a' + i))
+		if i%4 == 1 {
+			row[i%len(row)] = nil
+		}
+		rows = append(rows, row)
+	}
+
+	buf := &bytes.Buffer{}
+	w, err := NewWriter(buf, columns, map[string]string{"key": "value"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	w.RowGroupSize = 10
+	for _, row := range rows {
+		if err := w.Write(row); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Write([]interface{}{1.0, nil, nil, nil}); err == nil {
+		t.Error("expected type error")
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	if !reflect.DeepEqual(r.Columns(), columns) {
+		t.Errorf("unexpected columns: %v", r.Columns())
+	}
+	if r.NumRows() != 25 || r.NumRowGroups() != 3 {
+		t.Errorf("unexpected row groups: %d rows in %d groups", r.NumRows(), r.NumRowGroups())
+	}
+	if r.Metadata()["key"] != "value" {
+		t.Errorf("unexpected metadata: %v", r.Metadata())
+	}
+	for i, expected := range rows {
+		row, err := r.Read()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(row, expected) {
+			t.Errorf("row %d: expected %v, got %v", i, expected, row)
+		}
+	}
+	if _, err := r.Read(); err != io.EOF {
+		t.Errorf("expected EOF, got %v", err)
+	}
+}
+
+func TestEmptyFile(t *testing.T) {
+	buf := &bytes.Buffer{}
+	w, err := NewWriter(buf, []Column{{Name: "a", Type: String}}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	r, err := NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	if _, err := r.Read(); err != io.EOF {
+		t.Errorf("expected EOF, got %v", err)
+	}
+	if _, err := NewReader(bytes.NewReader([]byte("not a parquet file")), 18); err == nil {
+		t.Error("expected error reading invalid file")
+	}
+}
+
+// TestReadExternalFile reads a file written directly by the Arrow Parquet
+// writer, with column types, encodings and compression that Writer doesn't
+// produce.
+func TestReadExternalFile(t *testing.T) {
+	mem := memory.NewGoAllocator()
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "id", Type: arrow.BinaryTypes.String},
+		{Name: "n", Type: arrow.PrimitiveTypes.Int32, Nullable: true},
+		{Name: "f", Type: arrow.PrimitiveTypes.Float32},
+		{Name: "when", Type: &arrow.TimestampType{Unit: arrow.Millisecond, TimeZone: "UTC"}},
+		{Name: "tags", Type: arrow.ListOf(arrow.BinaryTypes.String)},
+		{Name: "address", Type: arrow.StructOf(arrow.Field{Name: "city", Type: arrow.BinaryTypes.String})},
+	}, nil)
+	b := array.NewRecordBuilder(mem, schema)
+	defer b.Release()
+	b.Field(0).(*array.StringBuilder).AppendValues([]string{"a", "b", "a"}, nil)
+	b.Field(1).(*array.Int32Builder).AppendValues([]int32{1, 0, 3}, []bool{true, false, true})
+	b.Field(2).(*array.Float32Builder).AppendValues([]float32{0.5, 1.5, 2}, nil)
+	b.Field(3).(*array.TimestampBuilder).AppendValues([]arrow.Timestamp{0, 1000, 1500}, nil)
+	tags := b.Field(4).(*array.ListBuilder)
+	address := b.Field(5).(*array.StructBuilder)
+	for i := 0; i < 3; i++ {
+		tags.Append(true)
+		tags.ValueBuilder().(*array.StringBuilder).Append("x")
+		address.Append(true)
+		address.FieldBuilder(0).(*array.StringBuilder).Append("Portland")
+	}
+	rec := b.NewRecord()
+	defer rec.Release()
+
+	buf := &bytes.Buffer{}
+	props := pq.NewWriterProperties(pq.WithCompression(compress.Codecs.Zstd), pq.WithDictionaryDefault(true))
+	fw, err := pqarrow.NewFileWriter(schema, buf, props, pqarrow.DefaultWriterProps())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := fw.Write(rec); err != nil {
+		t.Fatal(err)
+	}
+	if err := fw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	expectedColumns := []Column{{Name: "id", Type: String}, {Name: "n", Type: Int64}, {Name: "f", Type: Double}, {Name: "when", Type: String}}
+	if !reflect.DeepEqual(r.Columns(), expectedColumns) {
+		t.Errorf("unexpected columns: %v", r.Columns())
+	}
+	if !reflect.DeepEqual(r.Unsupported(), []string{"tags", "address"}) {
+		t.Errorf("unexpected unsupported columns: %v", r.Unsupported())
+	}
+	expected := [][]interface{}{
+		{"a", int64(1), 0.5, "1970-01-01T00:00:00Z"},
+		{"b", nil, 1.5, "1970-01-01T00:00:01Z"},
+		{"a", int64(3), 2.0, "1970-01-01T00:00:01.5Z"},
+	}
+	for i, e := range expected {
+		row, err := r.Read()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(row, e) {
+			t.Errorf("row %d: expected %v, got %v", i, e, row)
+		}
+	}
+	if _, err := r.Read(); err != io.EOF {
+		t.Errorf("expected EOF, got %v", err)
+	}
+}
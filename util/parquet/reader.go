@@ -0,0 +1,179 @@
+package parquet
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/apache/arrow/go/v12/arrow"
+	"github.com/apache/arrow/go/v12/arrow/array"
+	"github.com/apache/arrow/go/v12/arrow/memory"
+	"github.com/apache/arrow/go/v12/parquet/file"
+	"github.com/apache/arrow/go/v12/parquet/pqarrow"
+)
+
+// readBatchSize is the number of rows decoded at a time
+const readBatchSize = 1024
+
+// Reader reads the rows of a Parquet file
+type Reader struct {
+	file        *file.Reader
+	columns     []Column
+	unsupported []string
+	records     pqarrow.RecordReader
+	record      arrow.Record
+	pos         int
+	remaining   int64
+}
+
+// NewReader reads the file footer and schema
+func NewReader(r io.ReaderAt, size int64) (*Reader, error) {
+	pf, err := file.NewParquetReader(io.NewSectionReader(r, 0, size))
+	if err != nil {
+		return nil, err
+	}
+	fr, err := pqarrow.NewFileReader(pf, pqarrow.ArrowReadProperties{BatchSize: readBatchSize}, memory.DefaultAllocator)
+	if err != nil {
+		return nil, err
+	}
+	out := &Reader{file: pf}
+	leaves := []int{}
+	for _, f := range fr.Manifest.Fields {
+		if t, ok := columnType(f.Field.Type); ok && f.IsLeaf() {
+			out.columns = append(out.columns, Column{Name: f.Field.Name, Type: t})
+			leaves = append(leaves, f.ColIndex)
+			continue
+		}
+		out.unsupported = append(out.unsupported, f.Field.Name)
+	}
+	if len(leaves) == 0 {
+		// no readable columns; return empty rows
+		out.remaining = pf.NumRows()
+		return out, nil
+	}
+	out.records, err = fr.GetRecordReader(context.Background(), leaves, nil)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Columns returns the columns that are read
+func (r *Reader) Columns() []Column {
+	return r.columns
+}
+
+// Unsupported returns the names of the columns that are skipped, because
+// they are nested, repeated, or of an unsupported type
+func (r *Reader) Unsupported() []string {
+	return r.unsupported
+}
+
+// Metadata returns the key/value metadata of the file
+func (r *Reader) Metadata() map[string]string {
+	out := map[string]string{}
+	kv := r.file.MetaData().KeyValueMetadata()
+	for i, k := range kv.Keys() {
+		out[k] = kv.Values()[i]
+	}
+	return out
+}
+
+// NumRows returns the number of rows in the file
+func (r *Reader) NumRows() int64 {
+	return r.file.NumRows()
+}
+
+// NumRowGroups returns the number of row groups in the file
+func (r *Reader) NumRowGroups() int {
+	return r.file.NumRowGroups()
+}
+
+// Read returns the next row, with a value for each of Columns. Values are
+// nil, string, float64, int64 or bool. It returns io.EOF after the last row
+func (r *Reader) Read() ([]interface{}, error) {
+	if r.records == nil {
+		if r.remaining == 0 {
+			return nil, io.EOF
+		}
+		r.remaining--
+		return []interface{}{}, nil
+	}
+	for r.record == nil || r.pos >= int(r.record.NumRows()) {
+		rec, err := r.records.Read()
+		if err != nil {
+			return nil, err
+		}
+		r.record = rec
+		r.pos = 0
+	}
+	row := make([]interface{}, len(r.columns))
+	for i, col := range r.record.Columns() {
+		v, err := value(col, r.pos)
+		if err != nil {
+			return nil, fmt.Errorf("column %s: %v", r.columns[i].Name, err)
+		}
+		row[i] = v
+	}
+	r.pos++
+	return row, nil
+}
+
+func value(col arrow.Array, i int) (interface{}, error) {
+	if col.IsNull(i) {
+		return nil, nil
+	}
+	switch a := col.(type) {
+	case *array.String:
+		return a.Value(i), nil
+	case *array.LargeString:
+		return a.Value(i), nil
+	case *array.Binary:
+		return string(a.Value(i)), nil
+	case *array.LargeBinary:
+		return string(a.Value(i)), nil
+	case *array.FixedSizeBinary:
+		return string(a.Value(i)), nil
+	case *array.Timestamp:
+		unit := a.DataType().(*arrow.TimestampType).Unit
+		return a.Value(i).ToTime(unit).Format(time.RFC3339Nano), nil
+	case *array.Date32:
+		return a.Value(i).ToTime().Format("2006-01-02"), nil
+	case *array.Date64:
+		return a.Value(i).ToTime().Format("2006-01-02"), nil
+	case *array.Float32:
+		return float64(a.Value(i)), nil
+	case *array.Float64:
+		return a.Value(i), nil
+	case *array.Int8:
+		return int64(a.Value(i)), nil
+	case *array.Int16:
+		return int64(a.Value(i)), nil
+	case *array.Int32:
+		return int64(a.Value(i)), nil
+	case *array.Int64:
+		return a.Value(i), nil
+	case *array.Uint8:
+		return int64(a.Value(i)), nil
+	case *array.Uint16:
+		return int64(a.Value(i)), nil
+	case *array.Uint32:
+		return int64(a.Value(i)), nil
+	case *array.Uint64:
+		return int64(a.Value(i)), nil
+	case *array.Boolean:
+		return a.Value(i), nil
+	}
+	return nil, fmt.Errorf("unsupported type %s", col.DataType())
+}
+
+// Close releases the decoded record batches. It does not close the
+// underlying reader
+func (r *Reader) Close() {
+	if r.records != nil {
+		r.records.Release()
+		r.records = nil
+	}
+	r.remaining = 0
+}
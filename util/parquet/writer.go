@@ -0,0 +1,156 @@
+package parquet
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/apache/arrow/go/v12/arrow"
+	"github.com/apache/arrow/go/v12/arrow/array"
+	"github.com/apache/arrow/go/v12/arrow/memory"
+	pq "github.com/apache/arrow/go/v12/parquet"
+	"github.com/apache/arrow/go/v12/parquet/compress"
+	"github.com/apache/arrow/go/v12/parquet/pqarrow"
+)
+
+// DefaultRowGroupSize is the number of rows buffered before a row group is
+// written
+const DefaultRowGroupSize = 50000
+
+// Writer writes rows to a snappy compressed Parquet file
+type Writer struct {
+	// RowGroupSize is the number of rows in each row group
+	RowGroupSize int
+	columns      []Column
+	builder      *array.RecordBuilder
+	writer       *pqarrow.FileWriter
+	rows         int
+	closed       bool
+}
+
+// writeOnly hides the Close method of the output, which the Parquet writer
+// would otherwise call
+type writeOnly struct {
+	io.Writer
+}
+
+// NewWriter starts a file with the given columns. The metadata is stored as
+// key/value pairs in the file footer
+func NewWriter(w io.Writer, columns []Column, metadata map[string]string) (*Writer, error) {
+	names := map[string]bool{}
+	fields := make([]arrow.Field, len(columns))
+	for i, c := range columns {
+		if names[c.Name] {
+			return nil, fmt.Errorf("duplicate column: %s", c.Name)
+		}
+		names[c.Name] = true
+		fields[i] = arrow.Field{Name: c.Name, Type: arrowType(c.Type), Nullable: true}
+	}
+	keys := []string{}
+	values := []string{}
+	for k, v := range metadata {
+		keys = append(keys, k)
+		values = append(values, v)
+	}
+	meta := arrow.NewMetadata(keys, values)
+	schema := arrow.NewSchema(fields, &meta)
+	props := pq.NewWriterProperties(pq.WithCompression(compress.Codecs.Snappy), pq.WithCreatedBy("grip"))
+	fw, err := pqarrow.NewFileWriter(schema, writeOnly{w}, props, pqarrow.DefaultWriterProps())
+	if err != nil {
+		return nil, err
+	}
+	return &Writer{
+		RowGroupSize: DefaultRowGroupSize,
+		columns:      columns,
+		builder:      array.NewRecordBuilder(memory.DefaultAllocator, schema),
+		writer:       fw,
+	}, nil
+}
+
+// Write adds a row, with a value for each column. Values may be nil, or
+// must match the column type: string, float64, int64 or bool
+func (w *Writer) Write(row []interface{}) error {
+	if w.closed {
+		return fmt.Errorf("writer is closed")
+	}
+	if len(row) != len(w.columns) {
+		return fmt.Errorf("row has %d values, expected %d", len(row), len(w.columns))
+	}
+	for i, v := range row {
+		if v == nil {
+			continue
+		}
+		ok := false
+		switch w.columns[i].Type {
+		case String:
+			_, ok = v.(string)
+		case Double:
+			_, ok = v.(float64)
+		case Int64:
+			_, ok = v.(int64)
+		case Boolean:
+			_, ok = v.(bool)
+		}
+		if !ok {
+			return fmt.Errorf("column %s: expected %s value, got %T", w.columns[i].Name, w.columns[i].Type, v)
+		}
+	}
+	for i, v := range row {
+		switch b := w.builder.Field(i).(type) {
+		case *array.StringBuilder:
+			if v == nil {
+				b.AppendNull()
+			} else {
+				b.Append(v.(string))
+			}
+		case *array.Float64Builder:
+			if v == nil {
+				b.AppendNull()
+			} else {
+				b.Append(v.(float64))
+			}
+		case *array.Int64Builder:
+			if v == nil {
+				b.AppendNull()
+			} else {
+				b.Append(v.(int64))
+			}
+		case *array.BooleanBuilder:
+			if v == nil {
+				b.AppendNull()
+			} else {
+				b.Append(v.(bool))
+			}
+		}
+	}
+	w.rows++
+	if w.rows >= w.RowGroupSize {
+		return w.Flush()
+	}
+	return nil
+}
+
+// Flush writes the buffered rows as a row group
+func (w *Writer) Flush() error {
+	if w.rows == 0 {
+		return nil
+	}
+	rec := w.builder.NewRecord()
+	defer rec.Release()
+	w.rows = 0
+	return w.writer.Write(rec)
+}
+
+// Close writes the remaining rows and the file footer. It does not close the
+// underlying writer
+func (w *Writer) Close() error {
+	if w.closed {
+		return nil
+	}
+	err := w.Flush()
+	w.closed = true
+	w.builder.Release()
+	if cerr := w.writer.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
@@ -0,0 +1,151 @@
+package table
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+// Mapping describes how the rows of tables are converted into graph elements
+type Mapping struct {
+	Tables []*Table `json:"tables"`
+}
+
+// Table maps each row of a CSV or TSV file to a vertex and its edges
+type Table struct {
+	// Local path or s3+http(s) url of the table. Files ending in .gz are decompressed
+	Path string `json:"path"`
+	// csv or tsv. Defaults to the file extension
+	Format string `json:"format"`
+	// Column names, for tables without a header row
+	Columns []string `json:"columns"`
+	// How columns are converted to data fields. Columns that are not listed
+	// become string fields
+	Fields map[string]Field `json:"fields"`
+	Vertex *VertexMapping   `json:"vertex"`
+	Edges  []*EdgeMapping   `json:"edges"`
+	format rune
+	fields map[string]*Field
+}
+
+// Field describes how the values of a column are converted
+type Field struct {
+	// STRING, NUMERIC or BOOL
+	Type string `json:"type"`
+	// Split values on this separator into an array
+	Split string `json:"split"`
+	// Don't include the column in the vertex data
+	Skip bool `json:"skip"`
+	// Name of the data field, if different from the column
+	Name string `json:"name"`
+}
+
+// VertexMapping creates a vertex from each row. Gid and Label are templates
+// where {column} is replaced by the value of the column
+type VertexMapping struct {
+	Gid   string `json:"gid"`
+	Label string `json:"label"`
+}
+
+// EdgeMapping creates edges from each row. Label, From, To and Gid are
+// templates where {column} is replaced by the value of the column
+type EdgeMapping struct {
+	Label string `json:"label"`
+	// Defaults to the gid of the row vertex
+	From string `json:"from"`
+	To   string `json:"to"`
+	// Defaults to a random id
+	Gid string `json:"gid"`
+	// Split the values of the columns in To on this separator, and create an
+	// edge to each of the values
+	Split string `json:"split"`
+	// Columns included in the edge data
+	Data []string `json:"data"`
+}
+
+var templateField = regexp.MustCompile(`{([^{}]+)}`)
+
+// LoadMapping reads a table mapping from a YAML or JSON file. Relative table
+// paths are resolved relative to the mapping file
+func LoadMapping(path string) (*Mapping, error) {
+	raw, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading table mapping %s: %v", path, err)
+	}
+	m, err := ParseMapping(raw)
+	if err != nil {
+		return nil, fmt.Errorf("parsing table mapping %s: %v", path, err)
+	}
+	for _, t := range m.Tables {
+		if !filepath.IsAbs(t.Path) && !strings.Contains(t.Path, "://") {
+			t.Path = filepath.Join(filepath.Dir(path), t.Path)
+		}
+	}
+	return m, nil
+}
+
+// ParseMapping parses a table mapping and checks that it is valid
+func ParseMapping(raw []byte) (*Mapping, error) {
+	m := &Mapping{}
+	if err := yaml.UnmarshalStrict(raw, m); err != nil {
+		return nil, err
+	}
+	for i, t := range m.Tables {
+		if err := t.init(); err != nil {
+			return nil, fmt.Errorf("table %d (%s): %v", i, t.Path, err)
+		}
+	}
+	return m, nil
+}
+
+func (t *Table) init() error {
+	if t.Path == "" {
+		return fmt.Errorf("path not set")
+	}
+	format := strings.ToLower(t.Format)
+	if format == "" {
+		ext := strings.TrimSuffix(strings.ToLower(t.Path), ".gz")
+		format = strings.TrimPrefix(filepath.Ext(ext), ".")
+	}
+	switch format {
+	case "csv":
+		t.format = ','
+	case "tsv", "txt":
+		t.format = '\t'
+	default:
+		return fmt.Errorf("unknown table format '%s'; set format to csv or tsv", format)
+	}
+	if t.Vertex == nil && len(t.Edges) == 0 {
+		return fmt.Errorf("no vertex or edges mapped")
+	}
+	if t.Vertex != nil && (t.Vertex.Gid == "" || t.Vertex.Label == "") {
+		return fmt.Errorf("vertex gid and label must be set")
+	}
+	for _, e := range t.Edges {
+		if e.Label == "" || e.To == "" {
+			return fmt.Errorf("edge label and to must be set")
+		}
+		if e.From == "" && t.Vertex == nil {
+			return fmt.Errorf("edge from must be set for tables without a vertex")
+		}
+		if e.Split != "" && len(templateField.FindAllString(e.To, -1)) != 1 {
+			return fmt.Errorf("edge to must use exactly one column when split is set")
+		}
+	}
+	t.fields = map[string]*Field{}
+	for name, f := range t.Fields {
+		f := f
+		f.Type = strings.ToUpper(f.Type)
+		switch f.Type {
+		case "", "STRING", "NUMERIC", "BOOL", "DATETIME":
+		default:
+			return fmt.Errorf("column %s: unknown type '%s'", name, f.Type)
+		}
+		t.fields[name] = &f
+	}
+	return nil
+}
@@ -0,0 +1,269 @@
+package table
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/bmeg/grip/gripql"
+	"github.com/bmeg/grip/log"
+	"github.com/bmeg/grip/util"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// lineReader joins a channel of lines back into a stream, so quoted values
+// containing newlines can be parsed
+type lineReader struct {
+	lines chan string
+	buf   []byte
+}
+
+func (r *lineReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		line, ok := <-r.lines
+		if !ok {
+			return 0, io.EOF
+		}
+		r.buf = append([]byte(line), '\n')
+	}
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+// Stream reads the rows of the table, and returns the vertices and edges they
+// map to. Rows that can't be converted are logged and skipped.
+func (t *Table) Stream() (chan *gripql.GraphElement, error) {
+	if t.fields == nil {
+		if err := t.init(); err != nil {
+			return nil, err
+		}
+	}
+	lines, err := util.StreamLines(t.Path, 100)
+	if err != nil {
+		return nil, err
+	}
+	reader := csv.NewReader(&lineReader{lines: lines})
+	reader.Comma = t.format
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	header := t.Columns
+	if len(header) == 0 {
+		header, err = reader.Read()
+		if err != nil {
+			go func() {
+				for range lines {
+				}
+			}()
+			return nil, fmt.Errorf("reading header of %s: %v", t.Path, err)
+		}
+	}
+
+	out := make(chan *gripql.GraphElement, 100)
+	go func() {
+		defer close(out)
+		rowNum := 0
+		for {
+			record, err := reader.Read()
+			if err == io.EOF {
+				return
+			}
+			rowNum++
+			if err != nil {
+				log.WithFields(log.Fields{"table": t.Path, "row": rowNum, "error": err}).Error("Reading table row")
+				continue
+			}
+			if len(record) != len(header) {
+				log.WithFields(log.Fields{"table": t.Path, "row": rowNum}).Errorf("Row has %d columns, expected %d", len(record), len(header))
+				continue
+			}
+			row := make(map[string]string, len(header))
+			for i, h := range header {
+				row[h] = record[i]
+			}
+			elems, err := t.Convert(row)
+			if err != nil {
+				log.WithFields(log.Fields{"table": t.Path, "row": rowNum, "error": err}).Error("Converting table row")
+				continue
+			}
+			for _, e := range elems {
+				out <- e
+			}
+		}
+	}()
+	return out, nil
+}
+
+// Convert maps a row, keyed by column name, to graph elements
+func (t *Table) Convert(row map[string]string) ([]*gripql.GraphElement, error) {
+	out := []*gripql.GraphElement{}
+	vertexGid := ""
+	if t.Vertex != nil {
+		gid, err := render(t.Vertex.Gid, row)
+		if err != nil {
+			return nil, fmt.Errorf("vertex gid: %v", err)
+		}
+		label, err := render(t.Vertex.Label, row)
+		if err != nil {
+			return nil, fmt.Errorf("vertex label: %v", err)
+		}
+		data := map[string]interface{}{}
+		for col, val := range row {
+			if f, ok := t.fields[col]; ok && f.Skip {
+				continue
+			}
+			if err := t.addField(data, col, val); err != nil {
+				return nil, err
+			}
+		}
+		sData, err := structpb.NewStruct(data)
+		if err != nil {
+			return nil, err
+		}
+		vertexGid = gid
+		out = append(out, &gripql.GraphElement{Vertex: &gripql.Vertex{Gid: gid, Label: label, Data: sData}})
+	}
+
+	for _, e := range t.Edges {
+		from := vertexGid
+		if e.From != "" {
+			var err error
+			if from, err = render(e.From, row); err != nil {
+				// edges to missing values are optional
+				continue
+			}
+		}
+		label, err := render(e.Label, row)
+		if err != nil {
+			return nil, fmt.Errorf("edge label: %v", err)
+		}
+		data := map[string]interface{}{}
+		for _, col := range e.Data {
+			val, ok := row[col]
+			if !ok {
+				return nil, fmt.Errorf("edge data: column '%s' not found", col)
+			}
+			if err := t.addField(data, col, val); err != nil {
+				return nil, err
+			}
+		}
+		sData, err := structpb.NewStruct(data)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range splitRow(e, row) {
+			to, err := render(e.To, r)
+			if err != nil {
+				continue
+			}
+			gid := ""
+			if e.Gid != "" {
+				if gid, err = render(e.Gid, r); err != nil {
+					return nil, fmt.Errorf("edge gid: %v", err)
+				}
+			}
+			out = append(out, &gripql.GraphElement{Edge: &gripql.Edge{Gid: gid, Label: label, From: from, To: to, Data: sData}})
+		}
+	}
+	return out, nil
+}
+
+// splitRow returns a copy of the row for each of the values of the column
+// in the edge To template
+func splitRow(e *EdgeMapping, row map[string]string) []map[string]string {
+	if e.Split == "" {
+		return []map[string]string{row}
+	}
+	col := templateField.FindStringSubmatch(e.To)[1]
+	out := []map[string]string{}
+	for _, v := range strings.Split(row[col], e.Split) {
+		v = strings.TrimSpace(v)
+		if v == "" {
+			continue
+		}
+		r := make(map[string]string, len(row))
+		for k, rv := range row {
+			r[k] = rv
+		}
+		r[col] = v
+		out = append(out, r)
+	}
+	return out
+}
+
+// render replaces each {column} in the template with the value of the column.
+// Missing or empty values are an error
+func render(template string, row map[string]string) (string, error) {
+	var err error
+	out := templateField.ReplaceAllStringFunc(template, func(m string) string {
+		col := m[1 : len(m)-1]
+		val, ok := row[col]
+		if !ok {
+			err = fmt.Errorf("column '%s' not found", col)
+		} else if val == "" && err == nil {
+			err = fmt.Errorf("column '%s' is empty", col)
+		}
+		return val
+	})
+	if err != nil {
+		return "", err
+	}
+	return out, nil
+}
+
+// addField converts the value of a column and adds it to data. Empty values
+// are left out
+func (t *Table) addField(data map[string]interface{}, col, val string) error {
+	if val == "" {
+		return nil
+	}
+	name := col
+	f, ok := t.fields[col]
+	if !ok {
+		data[name] = val
+		return nil
+	}
+	if f.Name != "" {
+		name = f.Name
+	}
+	if f.Split == "" {
+		v, err := coerce(f.Type, val)
+		if err != nil {
+			return fmt.Errorf("column '%s': %v", col, err)
+		}
+		data[name] = v
+		return nil
+	}
+	list := []interface{}{}
+	for _, s := range strings.Split(val, f.Split) {
+		s = strings.TrimSpace(s)
+		if s == "" {
+			continue
+		}
+		v, err := coerce(f.Type, s)
+		if err != nil {
+			return fmt.Errorf("column '%s': %v", col, err)
+		}
+		list = append(list, v)
+	}
+	data[name] = list
+	return nil
+}
+
+func coerce(fieldType string, val string) (interface{}, error) {
+	switch fieldType {
+	case "NUMERIC":
+		return strconv.ParseFloat(strings.TrimSpace(val), 64)
+	case "BOOL":
+		return strconv.ParseBool(strings.TrimSpace(val))
+	case "DATETIME":
+		val = strings.TrimSpace(val)
+		if _, ok := gripql.ParseDateTime(val); !ok {
+			return nil, fmt.Errorf("'%s' is not an RFC3339 timestamp", val)
+		}
+	}
+	return val, nil
+}
@@ -0,0 +1,135 @@
+package table
+
+import (
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+const testMapping = `
+tables:
+- path: samples.tsv.gz
+  fields:
+    age:
+      type: numeric
+    tags:
+      split: ";"
+    alive:
+      type: bool
+    born:
+      type: datetime
+    project_id:
+      skip: true
+  vertex:
+    gid: "Sample:{id}"
+    label: Sample
+  edges:
+  - label: in_project
+    to: "Project:{project_id}"
+    split: ","
+- path: links.csv
+  columns: [src, dst, weight]
+  fields:
+    weight:
+      type: numeric
+  edges:
+  - label: related
+    from: "Sample:{src}"
+    to: "Sample:{dst}"
+    gid: "{src}-{dst}"
+    data: [weight]
+`
+
+func TestTableStream(t *testing.T) {
+	dir := t.TempDir()
+	fh, err := os.Create(filepath.Join(dir, "samples.tsv.gz"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	gz := gzip.NewWriter(fh)
+	gz.Write([]byte("id\tage\ttags\talive\tborn\tproject_id\tnote\n" +
+		"1\t30\ta; b\ttrue\t1984-02-01\tp1,p2\t\"multi\nline\"\n" +
+		"2\tx\t\tfalse\t\tp1\t\n" +
+		"\t40\t\ttrue\t\t\t\n" +
+		"3\t50\t\tfalse\t1974-05-03T10:00:00Z\t\t\n" +
+		"4\t20\t\tfalse\tMay 3rd\t\t\n"))
+	gz.Close()
+	fh.Close()
+	os.WriteFile(filepath.Join(dir, "links.csv"), []byte("1,3,0.5\n3,1,\n"), 0644)
+	os.WriteFile(filepath.Join(dir, "mapping.yaml"), []byte(testMapping), 0644)
+
+	m, err := LoadMapping(filepath.Join(dir, "mapping.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stream, err := m.Tables[0].Stream()
+	if err != nil {
+		t.Fatal(err)
+	}
+	vertices := map[string]map[string]interface{}{}
+	edges := []string{}
+	for e := range stream {
+		if e.Vertex != nil {
+			if e.Vertex.Label != "Sample" {
+				t.Errorf("wrong label: %s", e.Vertex.Label)
+			}
+			vertices[e.Vertex.Gid] = e.Vertex.GetDataMap()
+		}
+		if e.Edge != nil {
+			edges = append(edges, e.Edge.From+"->"+e.Edge.To)
+		}
+	}
+	// row 2 has a bad number, row 3 has no id, and row 5 has a bad timestamp
+	expected := map[string]map[string]interface{}{
+		"Sample:1": {"id": "1", "age": 30.0, "tags": []interface{}{"a", "b"}, "alive": true, "born": "1984-02-01", "note": "multi\nline"},
+		"Sample:3": {"id": "3", "age": 50.0, "alive": false, "born": "1974-05-03T10:00:00Z"},
+	}
+	if !reflect.DeepEqual(vertices, expected) {
+		t.Errorf("unexpected vertices: %v", vertices)
+	}
+	if !reflect.DeepEqual(edges, []string{"Sample:1->Project:p1", "Sample:1->Project:p2"}) {
+		t.Errorf("unexpected edges: %v", edges)
+	}
+
+	stream, err = m.Tables[1].Stream()
+	if err != nil {
+		t.Fatal(err)
+	}
+	count := 0
+	for e := range stream {
+		count++
+		if e.Edge == nil || e.Edge.Label != "related" {
+			t.Fatalf("unexpected element: %v", e)
+		}
+		if e.Edge.Gid == "1-3" {
+			if e.Edge.From != "Sample:1" || e.Edge.To != "Sample:3" || e.Edge.GetDataMap()["weight"] != 0.5 {
+				t.Errorf("unexpected edge: %v", e.Edge)
+			}
+		} else if e.Edge.Gid != "3-1" || len(e.Edge.GetDataMap()) != 0 {
+			t.Errorf("unexpected edge: %v", e.Edge)
+		}
+	}
+	if count != 2 {
+		t.Errorf("wrong number of edges: %d", count)
+	}
+}
+
+func TestParseMappingErrors(t *testing.T) {
+	bad := []string{
+		`tables: [{path: a.xls, vertex: {gid: "{id}", label: A}}]`,
+		`tables: [{path: a.csv}]`,
+		`tables: [{path: a.csv, vertex: {gid: "{id}"}}]`,
+		`tables: [{path: a.csv, vertex: {gid: "{id}", label: A}, fields: {x: {type: date}}}]`,
+		`tables: [{path: a.csv, edges: [{label: e, to: "{id}"}]}]`,
+		`tables: [{path: a.csv, vertex: {gid: "{id}", label: A}, edges: [{label: e, to: "{a}:{b}", split: ","}]}]`,
+		`tables: [{path: a.csv, vertex: {gid: "{id}", label: A, extra: 1}}]`,
+	}
+	for _, b := range bad {
+		if _, err := ParseMapping([]byte(b)); err == nil {
+			t.Errorf("expected error for mapping: %s", b)
+		}
+	}
+}